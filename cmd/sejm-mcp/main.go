@@ -2,9 +2,11 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/janisz/sejm-mcp/internal/server"
 )
@@ -40,13 +42,34 @@ func validateAndSetMode(sseMode, httpMode, stdioMode *bool) {
 
 func main() {
 	var (
-		showHelp    = flag.Bool("help", false, "Show help message")
-		showVersion = flag.Bool("version", false, "Show version information")
-		sseMode     = flag.Bool("sse", false, "Start SSE stream server mode (real-time with heartbeat)")
-		httpMode    = flag.Bool("http", false, "Start HTTP server mode (stateless, easier for hosting/caching)")
-		serverAddr  = flag.String("addr", ":8080", "Server address (used with -sse or -http)")
-		stdioMode   = flag.Bool("stdio", false, "Use stdio mode (default)")
-		debugMode   = flag.Bool("debug", false, "Enable debug logging")
+		showHelp            = flag.Bool("help", false, "Show help message")
+		showVersion         = flag.Bool("version", false, "Show version information")
+		sseMode             = flag.Bool("sse", false, "Start SSE stream server mode (real-time with heartbeat)")
+		httpMode            = flag.Bool("http", false, "Start HTTP server mode (stateless, easier for hosting/caching)")
+		serverAddr          = flag.String("addr", ":8080", "Server address (used with -sse or -http)")
+		stdioMode           = flag.Bool("stdio", false, "Use stdio mode (default)")
+		debugMode           = flag.Bool("debug", false, "Enable debug logging and per-call traces with debug='true' on tools")
+		language            = flag.String("lang", server.LanguageEnglish, "Default output language for tool responses: 'en' (English) or 'pl' (Polish)")
+		jobsDir             = flag.String("jobs-dir", "", "Directory for persisting background job results (async='true' tool calls); empty keeps them in memory only")
+		watchDir            = flag.String("watch-dir", "", "Directory for persisting acts watched with eli_watch_act and their detected changes; empty keeps them in memory only")
+		watchInterval       = flag.Duration("watch-interval", 6*time.Hour, "How often watched acts are checked for changes in SSE and HTTP mode")
+		outputDir           = flag.String("output-dir", "", "Directory where tools with large outputs (act texts, transcripts, exports) write their output when called with save_to_file='true'; empty disables saving")
+		votingIndexDir      = flag.String("voting-index-dir", "", "Directory for a persistent index of voting titles; title searches then cover whole terms instead of recent sittings")
+		sejmURL             = flag.String("sejm-url", os.Getenv("SEJM_API_URL"), "Base URL of the Sejm API, e.g. a mirror or proxy (env SEJM_API_URL; default https://api.sejm.gov.pl)")
+		eliURL              = flag.String("eli-url", os.Getenv("ELI_API_URL"), "Base URL of the ELI API (env ELI_API_URL; default https://api.sejm.gov.pl/eli)")
+		mockDir             = flag.String("mock", "", "Serve API responses from recorded fixtures in this directory instead of the network")
+		recordDir           = flag.String("record", "", "Save API responses as fixtures in this directory for later use with -mock")
+		maxOutput           = flag.Int("max-output-chars", 0, "Default maximum length of tool responses in characters (minimum 500); tool calls can override it with max_output_chars. 0 means unlimited")
+		upstreamTimeout     = flag.Duration("upstream-timeout", 45*time.Second, "Timeout of a single upstream API request, including downloading the response body")
+		maxIdleConns        = flag.Int("max-idle-conns", 100, "Maximum number of idle keep-alive connections to the upstream APIs")
+		maxIdleConnsPerHost = flag.Int("max-idle-conns-per-host", 20, "Maximum number of idle keep-alive connections per upstream host")
+		otlpEndpoint        = flag.String("otlp-endpoint", os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"), "OTLP/HTTP collector URL for exporting traces of tool calls, upstream requests and PDF extraction (env OTEL_EXPORTER_OTLP_ENDPOINT); empty disables tracing")
+		httpCacheTTL        = flag.String("http-cache-ttl", "", "Cache-Control lifetimes of tool responses in HTTP mode by tool class, e.g. 'reference=24h,default=5m,live=30s'; 0 disables caching for a class")
+		toolTimeout         = flag.String("tool-timeout", "", "Execution timeouts of tool calls, e.g. 'default=2m,sejm_find_defections=10m'; a timed out call returns partial results. Background jobs are not limited; unset means unlimited")
+		maxConnections      = flag.Int("max-connections", server.DefaultMaxConnections, "Maximum number of open SSE streams (-sse) or MCP requests in flight (-http); further clients are rejected with 503. 0 means unlimited")
+		maxMessages         = flag.Int("max-messages-per-minute", server.DefaultMaxMessagesPerMinute, "Maximum number of MCP messages per minute of each SSE session (-sse) or client address (-http); excess messages are rejected with 429. 0 means unlimited")
+		idleTimeout         = flag.Duration("idle-timeout", server.DefaultIdleTimeout, "Close SSE streams whose client sent no message for this long, and idle keep-alive connections; 0 keeps them open")
+		profilesFile        = flag.String("profiles", "", "JSON file of named configuration profiles served in HTTP mode under /profiles/<name>/mcp or selected with the X-Sejm-Profile header")
 	)
 
 	flag.Usage = func() {
@@ -67,6 +90,20 @@ func main() {
 		fmt.Fprintf(os.Stderr, "  %s -http              # Start HTTP server on :8080\n", appName)
 		fmt.Fprintf(os.Stderr, "  %s -sse -addr :9000   # Start SSE server on :9000\n", appName)
 		fmt.Fprintf(os.Stderr, "  %s -debug             # Enable debug logging\n", appName)
+		fmt.Fprintf(os.Stderr, "  %s -lang pl           # Polish labels and statuses in tool output\n", appName)
+		fmt.Fprintf(os.Stderr, "  %s -jobs-dir ./jobs   # Keep background job results across restarts\n", appName)
+		fmt.Fprintf(os.Stderr, "  %s -http -watch-dir ./watches # Check watched acts and notify clients of changes\n", appName)
+		fmt.Fprintf(os.Stderr, "  %s -http -otlp-endpoint http://localhost:4318 # Export traces to an OpenTelemetry collector\n", appName)
+		fmt.Fprintf(os.Stderr, "  %s -http -http-cache-ttl default=10m,live=0 # Tune response caching for proxies\n", appName)
+		fmt.Fprintf(os.Stderr, "  %s -tool-timeout default=2m # Stop long scans and return partial results\n", appName)
+		fmt.Fprintf(os.Stderr, "  %s -sse -max-connections 20 -idle-timeout 10m # Bound open streams on a small host\n", appName)
+		fmt.Fprintf(os.Stderr, "  %s -http -profiles profiles.json # Serve several teams with their own settings\n", appName)
+		fmt.Fprintf(os.Stderr, "  %s -output-dir ./corpus # Let tools save act texts and transcripts to files\n", appName)
+		fmt.Fprintf(os.Stderr, "  %s -voting-index-dir ./index # Search voting titles across whole terms\n", appName)
+		fmt.Fprintf(os.Stderr, "  %s -max-output-chars 20000 # Cap response size to save tokens\n", appName)
+		fmt.Fprintf(os.Stderr, "  %s -record ./fixtures # Record API responses while using the server\n", appName)
+		fmt.Fprintf(os.Stderr, "  %s -mock ./fixtures   # Replay recorded responses without network access\n", appName)
+		fmt.Fprintf(os.Stderr, "  %s -sejm-url https://mirror.example/sejm-api -eli-url https://mirror.example/sejm-api/eli\n", appName)
 		fmt.Fprintf(os.Stderr, "\nLOGGING:\n")
 		fmt.Fprintf(os.Stderr, "  Logs are written to stderr in stdio, SSE, and HTTP modes\n")
 		fmt.Fprintf(os.Stderr, "  Use -debug for detailed request/response logging\n\n")
@@ -87,14 +124,102 @@ func main() {
 	// Validate and set mode
 	validateAndSetMode(sseMode, httpMode, stdioMode)
 
+	// Validate default output language
+	outputLanguage, err := server.NormalizeLanguage(*language)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Validate upstream API configuration
+	if *mockDir != "" && *recordDir != "" {
+		fmt.Fprintf(os.Stderr, "Error: Cannot specify both -mock and -record\n")
+		os.Exit(1)
+	}
+	if *maxOutput < 0 {
+		fmt.Fprintf(os.Stderr, "Error: -max-output-chars must not be negative\n")
+		os.Exit(1)
+	}
+	if *upstreamTimeout <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: -upstream-timeout must be positive\n")
+		os.Exit(1)
+	}
+	if *maxConnections < 0 || *maxMessages < 0 || *idleTimeout < 0 {
+		fmt.Fprintf(os.Stderr, "Error: -max-connections, -max-messages-per-minute and -idle-timeout must not be negative\n")
+		os.Exit(1)
+	}
+	if *watchInterval < time.Minute {
+		fmt.Fprintf(os.Stderr, "Error: -watch-interval must be at least 1m\n")
+		os.Exit(1)
+	}
+	if *maxIdleConns < 1 || *maxIdleConnsPerHost < 1 {
+		fmt.Fprintf(os.Stderr, "Error: -max-idle-conns and -max-idle-conns-per-host must be at least 1\n")
+		os.Exit(1)
+	}
+	httpCacheTTLs, err := server.ParseHTTPCacheTTLs(*httpCacheTTL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: -http-cache-ttl: %v\n", err)
+		os.Exit(1)
+	}
+	toolTimeouts, err := server.ParseToolTimeouts(*toolTimeout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: -tool-timeout: %v\n", err)
+		os.Exit(1)
+	}
+	sejmBaseURL, err := server.NormalizeBaseURL(*sejmURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: -sejm-url: %v\n", err)
+		os.Exit(1)
+	}
+	eliBaseURL, err := server.NormalizeBaseURL(*eliURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: -eli-url: %v\n", err)
+		os.Exit(1)
+	}
+	if *profilesFile != "" && !*httpMode {
+		fmt.Fprintf(os.Stderr, "Error: -profiles requires -http\n")
+		os.Exit(1)
+	}
+
 	// Create server with configuration
 	config := server.Config{
-		DebugMode: *debugMode,
+		DebugMode:            *debugMode,
+		Language:             outputLanguage,
+		JobsDir:              *jobsDir,
+		WatchDir:             *watchDir,
+		WatchInterval:        *watchInterval,
+		VotingIndexDir:       *votingIndexDir,
+		OutputDir:            *outputDir,
+		SejmBaseURL:          sejmBaseURL,
+		ELIBaseURL:           eliBaseURL,
+		MockDir:              *mockDir,
+		RecordDir:            *recordDir,
+		MaxOutputChars:       *maxOutput,
+		UpstreamTimeout:      *upstreamTimeout,
+		MaxIdleConns:         *maxIdleConns,
+		MaxIdleConnsPerHost:  *maxIdleConnsPerHost,
+		HTTPCacheTTLs:        httpCacheTTLs,
+		ToolTimeouts:         toolTimeouts,
+		OTLPEndpoint:         *otlpEndpoint,
+		MaxConnections:       *maxConnections,
+		MaxMessagesPerMinute: *maxMessages,
+		IdleTimeout:          *idleTimeout,
 	}
 
 	sejmServer := server.NewSejmServerWithConfig(config)
 
-	var err error
+	// Profiles inherit the flags above and override some of them
+	if *profilesFile != "" {
+		profiles, err := server.LoadProfiles(*profilesFile, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: -profiles: %v\n", err)
+			os.Exit(1)
+		}
+		for name, profileConfig := range profiles {
+			sejmServer.AddProfile(name, server.NewSejmServerWithConfig(profileConfig))
+		}
+	}
+
 	if *sseMode {
 		fmt.Fprintf(os.Stderr, "Starting %s SSE server on %s (debug=%v)\n", appName, *serverAddr, *debugMode)
 		fmt.Fprintf(os.Stderr, "SSE mode provides real-time connection with heartbeat. Logs will be visible in this terminal. Use Ctrl+C to stop.\n")
@@ -108,6 +233,13 @@ func main() {
 		err = sejmServer.RunStdio()
 	}
 
+	// Export spans that are still buffered before exiting
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if shutdownErr := sejmServer.Shutdown(shutdownCtx); shutdownErr != nil {
+		fmt.Fprintf(os.Stderr, "Failed to flush traces: %v\n", shutdownErr)
+	}
+
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Server error: %v\n", err)
 		os.Exit(1)
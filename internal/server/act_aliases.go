@@ -0,0 +1,217 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// actAlias is a well-known act with the names and abbreviations it is asked for by. The address is the act
+// as originally published, which carries the references to its amendments and consolidated texts.
+type actAlias struct {
+	Address     string   `json:"address"`
+	Name        string   `json:"name"`
+	Aliases     []string `json:"aliases"`
+	Description string   `json:"description"`
+}
+
+// actAliases are the acts users most often ask for by name. Tools returning act texts resolve them to the
+// newest consolidated text (tekst jednolity) themselves.
+var actAliases = []actAlias{
+	{"DU/1997/483", "Konstytucja Rzeczypospolitej Polskiej", []string{"konstytucja", "konstytucja rp"}, "Constitution of the Republic of Poland"},
+	{"DU/1964/93", "Kodeks cywilny", []string{"kc", "k.c."}, "Civil Code"},
+	{"DU/1964/296", "Kodeks postępowania cywilnego", []string{"kpc", "k.p.c."}, "Code of Civil Procedure"},
+	{"DU/1964/59", "Kodeks rodzinny i opiekuńczy", []string{"kro", "k.r.o."}, "Family and Guardianship Code"},
+	{"DU/1997/553", "Kodeks karny", []string{"kk", "k.k."}, "Criminal Code"},
+	{"DU/1997/555", "Kodeks postępowania karnego", []string{"kpk", "k.p.k."}, "Code of Criminal Procedure"},
+	{"DU/1997/557", "Kodeks karny wykonawczy", []string{"kkw", "k.k.w."}, "Executive Penal Code"},
+	{"DU/1999/930", "Kodeks karny skarbowy", []string{"kks", "k.k.s."}, "Fiscal Penal Code"},
+	{"DU/1971/114", "Kodeks wykroczeń", []string{"kw", "k.w."}, "Code of Petty Offences"},
+	{"DU/1974/141", "Kodeks pracy", []string{"kp", "k.p."}, "Labour Code"},
+	{"DU/1960/168", "Kodeks postępowania administracyjnego", []string{"kpa", "k.p.a."}, "Code of Administrative Procedure"},
+	{"DU/2000/1037", "Kodeks spółek handlowych", []string{"ksh", "k.s.h."}, "Commercial Companies Code"},
+	{"DU/2011/112", "Kodeks wyborczy", []string{"kodeks wyborczy"}, "Electoral Code"},
+	{"DU/1997/926", "Ordynacja podatkowa", []string{"op", "o.p."}, "Tax Ordinance"},
+	{"DU/2002/1270", "Prawo o postępowaniu przed sądami administracyjnymi", []string{"ppsa", "p.p.s.a."}, "Law on Proceedings before Administrative Courts"},
+	{"DU/1991/350", "Ustawa o podatku dochodowym od osób fizycznych", []string{"updof", "ustawa o pit", "pit"}, "Personal Income Tax Act"},
+	{"DU/1992/86", "Ustawa o podatku dochodowym od osób prawnych", []string{"updop", "ustawa o cit", "cit"}, "Corporate Income Tax Act"},
+	{"DU/2004/535", "Ustawa o podatku od towarów i usług", []string{"ustawa o vat", "vat", "uptu"}, "Value Added Tax Act"},
+	{"DU/2018/1000", "Ustawa o ochronie danych osobowych", []string{"uodo", "ustawa o ochronie danych"}, "Personal Data Protection Act implementing the GDPR"},
+	{"DU/2019/2019", "Prawo zamówień publicznych", []string{"pzp", "p.z.p."}, "Public Procurement Law"},
+	{"DU/1994/414", "Prawo budowlane", []string{"pb", "p.b."}, "Construction Law"},
+	{"DU/1997/602", "Prawo o ruchu drogowym", []string{"prd", "p.r.d."}, "Road Traffic Law"},
+	{"DU/1990/95", "Ustawa o samorządzie gminnym", []string{"usg", "u.s.g."}, "Municipal Self-Government Act"},
+	{"DU/1997/939", "Prawo bankowe", []string{"prawo bankowe"}, "Banking Law"},
+	{"DU/1984/24", "Prawo prasowe", []string{"prawo prasowe"}, "Press Law"},
+	{"DU/1997/348", "Prawo energetyczne", []string{"prawo energetyczne"}, "Energy Law"},
+	{"DU/2017/59", "Prawo oświatowe", []string{"prawo oświatowe"}, "Education Law"},
+	{"DU/1994/83", "Ustawa o prawie autorskim i prawach pokrewnych", []string{"prawo autorskie", "pr. aut."}, "Copyright Act"},
+	{"MP/1992/185", "Regulamin Sejmu Rzeczypospolitej Polskiej", []string{"regulamin sejmu"}, "Rules of Procedure of the Sejm"},
+}
+
+// actAliasParamDescription documents the act parameter added to tools that take an act address
+const actAliasParamDescription = "Instead of publisher, year and position: a well-known act by name or abbreviation, e.g. 'Konstytucja', 'kodeks cywilny', 'KPA', 'KSH'. See eli_get_act_aliases for the list."
+
+// actAliasKey normalizes a name for lookups: lower case, no diacritics, no dots, single spaces
+func actAliasKey(name string) string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(normalizePolish(name), ".", " ")), " ")
+}
+
+// actAliasCandidates returns the aliased acts as completion candidates: the address and the names
+func actAliasCandidates() []completionCandidate {
+	candidates := make([]completionCandidate, len(actAliases))
+	for i, alias := range actAliases {
+		candidates[i] = completionCandidate{value: alias.Address, label: alias.Name + " " + strings.ToUpper(strings.Join(alias.Aliases, " "))}
+	}
+	return candidates
+}
+
+// actAliasCompletions completes the act parameter with the names of the aliased acts
+func actAliasCompletions(ctx context.Context, arguments map[string]string) ([]completionCandidate, error) {
+	candidates := make([]completionCandidate, len(actAliases))
+	for i, alias := range actAliases {
+		candidates[i] = completionCandidate{value: alias.Name, label: strings.ToUpper(alias.Aliases[0])}
+	}
+	return candidates, nil
+}
+
+// findActAlias looks up an act by its name, an abbreviation or its address. A name matching no alias exactly
+// is matched word by word, so 'kodeks postępowania' returns every procedure code.
+func findActAlias(name string) []actAlias {
+	key := actAliasKey(name)
+	for _, alias := range actAliases {
+		if key == actAliasKey(alias.Name) || strings.EqualFold(strings.TrimSpace(name), alias.Address) {
+			return []actAlias{alias}
+		}
+		for _, other := range alias.Aliases {
+			if key == actAliasKey(other) {
+				return []actAlias{alias}
+			}
+		}
+	}
+	var found []actAlias
+	for _, match := range matchEntities(actAliasCandidates(), name) {
+		for _, alias := range actAliases {
+			if alias.Address == match.value {
+				found = append(found, alias)
+			}
+		}
+	}
+	return found
+}
+
+// hasActAddress tells whether a tool identifies an act by publisher, year and position
+func hasActAddress(properties map[string]interface{}) bool {
+	for _, name := range []string{"publisher", "year", "position"} {
+		if _, ok := properties[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// applyActAliasSchema adds the act parameter to tools that take an act address. The address parameters stop
+// being required in the schema, since an alias can replace them; handlers still report them missing.
+func applyActAliasSchema(tool *mcp.Tool) {
+	if !hasActAddress(tool.InputSchema.Properties) {
+		return
+	}
+	tool.InputSchema.Properties["act"] = map[string]interface{}{
+		"type":        "string",
+		"description": actAliasParamDescription,
+	}
+	var required []string
+	for _, name := range tool.InputSchema.Required {
+		if name != "publisher" && name != "year" && name != "position" {
+			required = append(required, name)
+		}
+	}
+	tool.InputSchema.Required = required
+}
+
+// resolveActAlias replaces the act parameter with the publisher, year and position of the named act. It
+// returns the resolution for a note in the result, and an error listing the candidates when the name is
+// ambiguous or unknown. The original arguments map is left untouched.
+func resolveActAlias(request mcp.CallToolRequest) (mcp.CallToolRequest, []string, error) {
+	args := request.GetArguments()
+	name, _ := args["act"].(string)
+	if strings.TrimSpace(name) == "" {
+		return request, nil, nil
+	}
+	found := findActAlias(name)
+	switch {
+	case len(found) == 0:
+		return request, nil, fmt.Errorf("no well-known act is named '%s'. See eli_get_act_aliases for the names, or find the act with eli_search_acts and pass publisher, year and position", name)
+	case len(found) > 1:
+		listed := make([]string, len(found))
+		for i, alias := range found {
+			listed[i] = fmt.Sprintf("'%s' (%s)", alias.Name, alias.Address)
+		}
+		return request, nil, fmt.Errorf("'%s' matches %d acts: %s. Pass a more specific name", name, len(found), strings.Join(listed, "; "))
+	}
+
+	alias := found[0]
+	address, ok := parseReferenceAddress(&alias.Address)
+	if !ok {
+		return request, nil, fmt.Errorf("invalid address '%s' of '%s'", alias.Address, alias.Name)
+	}
+	resolved := make(map[string]any, len(args))
+	for key, value := range args {
+		if key != "act" {
+			resolved[key] = value
+		}
+	}
+	request.Params.Arguments = withActAddress(resolved, address)
+	return request, []string{fmt.Sprintf("act '%s' → %s (%s)", name, alias.Address, alias.Name)}, nil
+}
+
+func (s *SejmServer) handleGetActAliases(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("eli_get_act_aliases called", slog.Any("arguments", request.Params.Arguments))
+
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+	aliases := actAliases
+	filter := strings.TrimSpace(request.GetString("filter", ""))
+	if filter != "" {
+		aliases = findActAlias(filter)
+	}
+
+	if format == "json" {
+		out, _ := json.MarshalIndent(map[string]interface{}{
+			"total":   len(actAliases),
+			"matched": len(aliases),
+			"acts":    aliases,
+		}, "", "  ")
+		return mcp.NewToolResultText(string(out)), nil
+	}
+
+	var data []string
+	for _, alias := range aliases {
+		data = append(data, fmt.Sprintf("• %s – %s (%s); also: %s", alias.Address, alias.Name, alias.Description, strings.Join(alias.Aliases, ", ")))
+	}
+	summary := []string{fmt.Sprintf("%d well-known acts accepted by name in the act parameter", len(actAliases))}
+	if filter != "" {
+		summary = append(summary, fmt.Sprintf("Matching '%s': %d", filter, len(aliases)))
+	}
+	nextActions := []string{"Text of an act: eli_get_act_text with act='kodeks cywilny'", "Amendments of an act: eli_get_act_references with act='KPA'"}
+	note := "Every tool with publisher, year and position also accepts act with one of these names or abbreviations, ignoring case, dots and diacritics. The addresses are the acts as originally published; eli_get_act_text returns their newest consolidated text (tekst jednolity)."
+	if len(aliases) == 0 {
+		note = fmt.Sprintf("No well-known act matches '%s'; find it with eli_search_acts instead. ", filter) + note
+	}
+
+	response := StandardResponse{
+		Operation:   "Well-Known Act Names",
+		Status:      "Retrieved Successfully",
+		Summary:     summary,
+		Data:        data,
+		NextActions: nextActions,
+		Note:        note,
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestFindActAlias(t *testing.T) {
+	for name, expected := range map[string]string{
+		"K.P.A.":              "DU/1960/168",
+		"kodeks cywilny":      "DU/1964/93",
+		"Kodeks Karny":        "DU/1997/553",
+		"kodeks wykroczen":    "DU/1971/114",
+		"konstytucja":         "DU/1997/483",
+		"du/2000/1037":        "DU/2000/1037",
+		"spółek handlowych":   "DU/2000/1037",
+		"Ordynacja Podatkowa": "DU/1997/926",
+	} {
+		found := findActAlias(name)
+		if len(found) != 1 || found[0].Address != expected {
+			t.Errorf("Expected '%s' to resolve to %s, got %v", name, expected, found)
+		}
+	}
+	if found := findActAlias("kodeks postępowania"); len(found) < 3 {
+		t.Errorf("Expected every procedure code for an ambiguous name, got %v", found)
+	}
+	if found := findActAlias("ustawa o ochronie przyrody"); len(found) != 0 {
+		t.Errorf("Expected no act for an unknown name, got %v", found)
+	}
+}
+
+func TestToolCallResolvesActAliases(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/DU/1960/168": `{"ELI": "DU/1960/168", "publisher": "DU", "year": 1960, "pos": 168, "title": "Kodeks postępowania administracyjnego", "status": "obowiązujący"}`,
+	})
+	call := func(tool string, arguments map[string]interface{}) string {
+		encodedArguments, _ := json.Marshal(arguments)
+		message := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"` + tool + `","arguments":` + string(encodedArguments) + `}}`
+		encoded, err := json.Marshal(server.server.HandleMessage(context.Background(), json.RawMessage(message)))
+		if err != nil {
+			t.Fatalf("Failed to encode response: %v", err)
+		}
+		return string(encoded)
+	}
+
+	output := call("eli_get_act_details", map[string]interface{}{"act": "KPA"})
+	if !strings.Contains(output, "Kodeks postępowania administracyjnego") || !strings.Contains(output, "act 'KPA' → DU/1960/168") {
+		t.Errorf("Expected KPA to be resolved to DU/1960/168, got: %s", output)
+	}
+	if output := call("eli_get_act_details", map[string]interface{}{"act": "kodeks postępowania"}); !strings.Contains(output, "Pass a more specific name") {
+		t.Errorf("Expected an ambiguous name to list the candidates, got: %s", output)
+	}
+	if output := call("eli_get_act_details", map[string]interface{}{"act": "ustawa o ochronie przyrody"}); !strings.Contains(output, "eli_get_act_aliases") {
+		t.Errorf("Expected an unknown name to be rejected, got: %s", output)
+	}
+
+	encoded, _ := json.Marshal(server.server.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)))
+	var listed struct {
+		Result struct {
+			Tools []struct {
+				Name        string `json:"name"`
+				InputSchema struct {
+					Properties map[string]interface{} `json:"properties"`
+					Required   []string               `json:"required"`
+				} `json:"inputSchema"`
+			} `json:"tools"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(encoded, &listed); err != nil {
+		t.Fatalf("Failed to decode tools: %v", err)
+	}
+	for _, tool := range listed.Result.Tools {
+		if tool.Name != "eli_get_act_text" {
+			continue
+		}
+		if _, ok := tool.InputSchema.Properties["act"]; !ok || len(tool.InputSchema.Required) != 0 {
+			t.Errorf("Expected eli_get_act_text to accept act instead of a required address, got %+v", tool.InputSchema)
+		}
+	}
+}
+
+func TestHandleGetActAliases(t *testing.T) {
+	server := newServerWithFixtures(t, nil)
+	result, err := server.handleGetActAliases(context.Background(), createMockRequest(map[string]interface{}{"filter": "kpc"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	if !strings.Contains(text, "Matching 'kpc': 1") || !strings.Contains(text, "DU/1964/296 – Kodeks postępowania cywilnego") {
+		t.Errorf("Expected the code of civil procedure, got:\n%s", text)
+	}
+}
@@ -0,0 +1,516 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// firstNumberedJournalYear is the first year in which Dziennik Ustaw and Monitor Polski positions are cited
+// without the issue number (Nr)
+const firstNumberedJournalYear = 2012
+
+// citationStyles are the styles of eli_format_citation in output order
+var citationStyles = []string{"official", "academic", "short"}
+
+var (
+	// citationDatePattern captures the day, month and year of the date clause of an act title
+	citationDatePattern = regexp.MustCompile(`(\d{1,2}) (\p{L}+) (\d{4})`)
+	// provisionUnitPattern matches an article, paragraph or point number such as '5', '5a' or '148'
+	provisionUnitPattern = regexp.MustCompile(`^[0-9]+[a-z]{0,3}$`)
+	// provisionLetterPattern matches a letter (litera) of a point
+	provisionLetterPattern = regexp.MustCompile(`^[a-z]{1,2}$`)
+)
+
+// polishMonths maps the genitive month names used in act titles to month numbers
+var polishMonths = map[string]time.Month{
+	"stycznia": time.January, "lutego": time.February, "marca": time.March, "kwietnia": time.April,
+	"maja": time.May, "czerwca": time.June, "lipca": time.July, "sierpnia": time.August,
+	"września": time.September, "października": time.October, "listopada": time.November, "grudnia": time.December,
+}
+
+// actKindGenitives gives the genitive of the kind that opens an act title, used after 'art. 5'
+var actKindGenitives = map[string]string{
+	"ustawa":         "ustawy",
+	"rozporządzenie": "rozporządzenia",
+	"obwieszczenie":  "obwieszczenia",
+	"uchwała":        "uchwały",
+	"zarządzenie":    "zarządzenia",
+	"postanowienie":  "postanowienia",
+	"konstytucja":    "Konstytucji",
+	"umowa":          "umowy",
+	"dekret":         "dekretu",
+	"decyzja":        "decyzji",
+	"komunikat":      "komunikatu",
+	"wyrok":          "wyroku",
+	"oświadczenie":   "oświadczenia",
+	"ogłoszenie":     "ogłoszenia",
+}
+
+// actNameAbbreviations are the customary abbreviations of acts cited by name, used by the short style
+var actNameAbbreviations = map[string]string{
+	"kodeks cywilny":                                      "k.c.",
+	"kodeks postępowania cywilnego":                       "k.p.c.",
+	"kodeks karny":                                        "k.k.",
+	"kodeks postępowania karnego":                         "k.p.k.",
+	"kodeks karny wykonawczy":                             "k.k.w.",
+	"kodeks karny skarbowy":                               "k.k.s.",
+	"kodeks wykroczeń":                                    "k.w.",
+	"kodeks postępowania w sprawach o wykroczenia":        "k.p.w.",
+	"kodeks pracy":                                        "k.p.",
+	"kodeks rodzinny i opiekuńczy":                        "k.r.o.",
+	"kodeks spółek handlowych":                            "k.s.h.",
+	"kodeks postępowania administracyjnego":               "k.p.a.",
+	"kodeks wyborczy":                                     "k.wyb.",
+	"ordynacja podatkowa":                                 "o.p.",
+	"prawo budowlane":                                     "p.b.",
+	"prawo o ruchu drogowym":                              "p.r.d.",
+	"prawo zamówień publicznych":                          "p.z.p.",
+	"prawo o postępowaniu przed sądami administracyjnymi": "p.p.s.a.",
+}
+
+// citationTitle is an act title split into the parts that change between citation styles, e.g.
+// 'Rozporządzenie' + 'Ministra Zdrowia' + 'z dnia 5 stycznia 2024 r.' + 'w sprawie ...'
+type citationTitle struct {
+	Kind    string
+	Issuer  string
+	Date    time.Time
+	Clause  string
+	Subject string
+	Name    string
+}
+
+// parseCitationTitle splits an act title around its date clause. Acts named after a dash, such as
+// 'Ustawa z dnia 23 kwietnia 1964 r. - Kodeks cywilny', also get their name.
+func parseCitationTitle(title string) citationTitle {
+	title = strings.Join(strings.Fields(title), " ")
+	loc := actDateClausePattern.FindStringIndex(title)
+	if loc == nil {
+		kind, rest, _ := strings.Cut(title, " ")
+		return citationTitle{Kind: kind, Subject: strings.ReplaceAll(rest, " - ", " – ")}
+	}
+
+	t := citationTitle{Clause: title[loc[0]:loc[1]]}
+	t.Kind, t.Issuer, _ = strings.Cut(strings.TrimSpace(title[:loc[0]]), " ")
+	if !strings.HasSuffix(t.Clause, ".") {
+		t.Clause = strings.TrimSuffix(strings.TrimSuffix(t.Clause, "r"), " ") + " r."
+	}
+	if match := citationDatePattern.FindStringSubmatch(t.Clause); match != nil {
+		day, _ := strconv.Atoi(match[1])
+		year, _ := strconv.Atoi(match[3])
+		if month, ok := polishMonths[strings.ToLower(match[2])]; ok {
+			t.Date = time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		}
+	}
+
+	// Subjects start in lower case ('o drogach publicznych', 'w sprawie ...'), names in upper case, and some
+	// titles in the database omit the dash before the name
+	subject := strings.TrimSpace(title[loc[1]:])
+	if trimmed := strings.TrimLeft(subject, "-–— "); trimmed != subject || startsUpper(trimmed) {
+		t.Name = strings.TrimRight(trimmed, ". ")
+		subject = "– " + t.Name
+	}
+	t.Subject = strings.ReplaceAll(subject, " - ", " – ")
+	return t
+}
+
+// startsUpper reports whether a string starts with an upper-case letter
+func startsUpper(s string) bool {
+	for _, r := range s {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+// format renders the title with the kind in the nominative or, after a provision, in the genitive, and the
+// date in full ('z dnia 23 kwietnia 1964 r.') or in figures ('z 23.04.1964 r.')
+func (t citationTitle) format(genitive, fullDate bool) string {
+	kind := t.Kind
+	if genitive {
+		kind = valueOrDefault(actKindGenitives[strings.ToLower(kind)], kind)
+	}
+	date := t.Clause
+	if !fullDate && !t.Date.IsZero() {
+		date = fmt.Sprintf("z %s r.", t.Date.Format("02.01.2006"))
+	}
+	var parts []string
+	for _, part := range []string{kind, t.Issuer, date, t.Subject} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// abbreviation returns the customary short name of the act, e.g. 'k.c.', or "" when it has none
+func (t citationTitle) abbreviation(genitive bool) string {
+	if strings.EqualFold(t.Kind, "konstytucja") {
+		if genitive {
+			return "Konstytucji RP"
+		}
+		return "Konstytucja RP"
+	}
+	return actNameAbbreviations[strings.ToLower(t.Name)]
+}
+
+// citationProvision is the unit of an act being cited, e.g. art. 148 § 1 or art. 5 ust. 2 pkt 3 lit. a
+type citationProvision struct {
+	Article   string
+	Paragraph string
+	Point     string
+	Letter    string
+}
+
+// parseCitationProvision validates the provision parameters of eli_format_citation
+func parseCitationProvision(request mcp.CallToolRequest) (citationProvision, error) {
+	p := citationProvision{
+		Article:   strings.TrimSpace(request.GetString("article", "")),
+		Paragraph: strings.TrimSpace(request.GetString("paragraph", "")),
+		Point:     strings.TrimSpace(request.GetString("point", "")),
+		Letter:    strings.ToLower(strings.TrimSpace(request.GetString("letter", ""))),
+	}
+	// Accept the unit markers users tend to type along with the numbers
+	p.Article = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(p.Article), "art."), "art"))
+	p.Paragraph = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(p.Paragraph), "ust."), "§"))
+	p.Point = strings.TrimSpace(strings.TrimPrefix(strings.ToLower(p.Point), "pkt"))
+	p.Letter = strings.TrimSpace(strings.TrimPrefix(p.Letter, "lit."))
+
+	units := []struct{ name, value string }{{"article", p.Article}, {"paragraph", p.Paragraph}, {"point", p.Point}}
+	for i, unit := range units {
+		if unit.value == "" {
+			continue
+		}
+		if !provisionUnitPattern.MatchString(strings.TrimSpace(strings.TrimPrefix(unit.value, "§"))) {
+			return p, fmt.Errorf("invalid %s '%s': use a number with an optional letter, e.g. '5' or '5a'", unit.name, unit.value)
+		}
+		if i > 0 && p.Article == "" {
+			return p, fmt.Errorf("'%s' requires 'article'", unit.name)
+		}
+	}
+	if p.Letter != "" && (!provisionLetterPattern.MatchString(p.Letter) || p.Point == "") {
+		return p, fmt.Errorf("invalid letter '%s': use a letter such as 'a' together with 'point'", p.Letter)
+	}
+	return p, nil
+}
+
+// format renders the provision. Regulations are divided into paragraphs (§), statutes into articles; the
+// articles of codes are divided into paragraphs (§) and those of other statutes into ustępy (ust.).
+func (p citationProvision) format(title citationTitle) string {
+	if p.Article == "" {
+		return ""
+	}
+	var parts []string
+	regulation := strings.EqualFold(title.Kind, "rozporządzenie") || strings.HasPrefix(p.Article, "§")
+	if regulation {
+		parts = append(parts, "§ "+strings.TrimSpace(strings.TrimPrefix(p.Article, "§")))
+	} else {
+		parts = append(parts, "art. "+p.Article)
+	}
+	if p.Paragraph != "" {
+		if !regulation && strings.HasPrefix(strings.ToLower(title.Name), "kodeks") {
+			parts = append(parts, "§ "+p.Paragraph)
+		} else {
+			parts = append(parts, "ust. "+p.Paragraph)
+		}
+	}
+	if p.Point != "" {
+		parts = append(parts, "pkt "+p.Point)
+	}
+	if p.Letter != "" {
+		parts = append(parts, "lit. "+p.Letter)
+	}
+	return strings.Join(parts, " ")
+}
+
+// citedText is the publication an act is cited by: the act as published or its newest consolidated text,
+// with the amendments published after it
+type citedText struct {
+	Address      actWatch
+	Volume       int
+	Consolidated bool
+	Amendments   []actWatch
+}
+
+// journalName returns the abbreviation of a journal; the official style separates 'Dz. U.'
+func journalName(publisher string, official bool) string {
+	switch publisher {
+	case "DU":
+		if official {
+			return "Dz. U."
+		}
+		return "Dz.U."
+	case "MP":
+		return "M.P."
+	}
+	return publisher
+}
+
+// joinPolish lists items as 'a, b i c', or with another conjunction before the last item
+func joinPolish(items []string, conjunction string) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " " + conjunction + " " + items[len(items)-1]
+}
+
+// position returns the issue number and position of the cited text, e.g. 'Nr 16, poz. 93' or 'poz. 1061'
+func (c citedText) position(separator string) string {
+	if c.Volume > 0 {
+		return fmt.Sprintf("Nr %d%s poz. %d", c.Volume, separator, c.Address.Position)
+	}
+	return fmt.Sprintf("poz. %d", c.Address.Position)
+}
+
+// official renders the journal reference the way legislation cites it, listing the amending acts published
+// since, e.g. 'Dz. U. z 2024 r. poz. 1061 i 1237 oraz z 2025 r. poz. 12'. The year is omitted when the act
+// was published in the year it was passed. Amendments from before 2012 cannot be listed without their issue
+// numbers, so they are summarized as 'z późn. zm.'.
+func (c citedText) official(omitYear bool) string {
+	listable := true
+	for _, amendment := range c.Amendments {
+		listable = listable && amendment.Year >= firstNumberedJournalYear
+	}
+
+	var years []int
+	positions := make(map[int][]string)
+	years = append(years, c.Address.Year)
+	positions[c.Address.Year] = []string{strconv.Itoa(c.Address.Position)}
+	if listable {
+		for _, amendment := range c.Amendments {
+			if _, ok := positions[amendment.Year]; !ok {
+				years = append(years, amendment.Year)
+			}
+			positions[amendment.Year] = append(positions[amendment.Year], strconv.Itoa(amendment.Position))
+		}
+	}
+
+	var groups []string
+	for i, year := range years {
+		group := "poz. " + joinPolish(positions[year], "i")
+		if i == 0 && c.Volume > 0 {
+			group = c.position(",")
+		}
+		if i > 0 || !omitYear {
+			group = fmt.Sprintf("z %d r. %s", year, group)
+		}
+		groups = append(groups, group)
+	}
+	reference := journalName(c.Address.Publisher, true) + " " + joinPolish(groups, "oraz")
+	if !listable {
+		reference += ", z późn. zm."
+	}
+	return reference
+}
+
+// academic renders the journal reference of legal writing, e.g. 't.j. Dz.U. z 2024 r. poz. 1061 ze zm.'
+func (c citedText) academic() string {
+	reference := fmt.Sprintf("%s z %d r. %s", journalName(c.Address.Publisher, false), c.Address.Year, c.position(","))
+	if c.Consolidated {
+		reference = "t.j. " + reference
+	}
+	if len(c.Amendments) > 0 {
+		reference += " ze zm."
+	}
+	return reference
+}
+
+// short renders the compact journal reference, e.g. 'Dz.U. 2024 poz. 1061'
+func (c citedText) short() string {
+	return fmt.Sprintf("%s %d %s", journalName(c.Address.Publisher, false), c.Address.Year, c.position(""))
+}
+
+// formatCitations renders an act citation in every style
+func formatCitations(title citationTitle, provision citationProvision, text citedText) map[string]string {
+	unit := provision.format(title)
+	prefix := ""
+	if unit != "" {
+		prefix = unit + " "
+	}
+	genitive := unit != ""
+	omitYear := !text.Consolidated && !title.Date.IsZero() && title.Date.Year() == text.Address.Year
+
+	citations := map[string]string{
+		"official": fmt.Sprintf("%s%s (%s)", prefix, title.format(genitive, true), text.official(omitYear)),
+		"academic": fmt.Sprintf("%s%s (%s)", prefix, title.format(genitive, false), text.academic()),
+	}
+	switch abbreviation := title.abbreviation(genitive); {
+	case abbreviation != "" && unit != "":
+		citations["short"] = prefix + abbreviation
+	case abbreviation != "":
+		citations["short"] = fmt.Sprintf("%s (%s)", abbreviation, text.short())
+	default:
+		// The subject identifies the act without its date, except for titles that have none
+		short := title
+		if short.Subject != "" {
+			short.Clause, short.Date = "", time.Time{}
+		}
+		citations["short"] = fmt.Sprintf("%s%s (%s)", prefix, short.format(genitive, false), text.short())
+	}
+	return citations
+}
+
+// parseCitationStyles validates a comma-separated list of styles; empty or 'all' selects all of them
+func parseCitationStyles(raw string) ([]string, error) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	if raw == "" || raw == "all" {
+		return citationStyles, nil
+	}
+	wanted := make(map[string]bool)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		known := false
+		for _, style := range citationStyles {
+			known = known || style == name
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown style '%s'. Use %s or 'all'", name, strings.Join(citationStyles, ", "))
+		}
+		wanted[name] = true
+	}
+	var styles []string
+	for _, style := range citationStyles {
+		if wanted[style] {
+			styles = append(styles, style)
+		}
+	}
+	return styles, nil
+}
+
+// resolveCitedText chooses the publication to cite: the newest consolidated text unless disabled, and
+// otherwise the act as published. The issue number of texts from before 2012 is read from their details.
+func (s *SejmServer) resolveCitedText(ctx context.Context, act eli.Act, address actWatch, useConsolidated bool) (citedText, []string) {
+	var notes []string
+	text := citedText{Address: address}
+	if act.Volume != nil {
+		text.Volume = int(*act.Volume)
+	}
+	if texts := consolidatedTexts(act); useConsolidated && len(texts) > 0 {
+		text = citedText{Address: texts[len(texts)-1], Consolidated: true}
+		if text.Address.Year < firstNumberedJournalYear {
+			consolidated, _, err := s.fetchActTextFiles(ctx, text.Address)
+			if err != nil {
+				notes = append(notes, fmt.Sprintf("The issue number (Nr) of the consolidated text %s could not be retrieved (%v), so it is cited by position only.", text.Address.Address, err))
+			} else if consolidated.Volume != nil {
+				text.Volume = int(*consolidated.Volume)
+			}
+		}
+	}
+	if text.Address.Year >= firstNumberedJournalYear {
+		text.Volume = 0
+	}
+	text.Amendments = amendingActsAfter(act, text.Address)
+	return text, notes
+}
+
+func (s *SejmServer) handleFormatCitation(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("eli_format_citation called", slog.Any("arguments", request.Params.Arguments))
+
+	address, err := parseActAddress(request.GetString("publisher", "DU"), request.GetString("year", ""), request.GetString("position", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid act address: %v.", err)), nil
+	}
+	provision, err := parseCitationProvision(request)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid provision: %v.", err)), nil
+	}
+	styles, err := parseCitationStyles(request.GetString("style", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid style: %v.", err)), nil
+	}
+	useConsolidated := request.GetString("consolidated", "true") != "false"
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	act, _, err := s.fetchActTextFiles(ctx, address)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%v. Please verify the coordinates using eli_search_acts.", err)), nil
+	}
+	if act.Title == nil || *act.Title == "" {
+		return mcp.NewToolResultError(fmt.Sprintf("Act %s has no title in the ELI database, so it cannot be cited.", address.Address)), nil
+	}
+	title := parseCitationTitle(*act.Title)
+	text, notes := s.resolveCitedText(ctx, act, address, useConsolidated)
+	citations := formatCitations(title, provision, text)
+
+	if role, _ := classifyAct(*act.Title); role == actRoleConsolidated {
+		notes = append(notes, "This act is the announcement of a consolidated text; to cite the law itself, use the coordinates of the original act, found with eli_search_acts.")
+	}
+	if act.InForce != nil && *act.InForce == eli.NOTINFORCE {
+		notes = append(notes, fmt.Sprintf("The act is not in force (status: %s); add the version date when citing it.", valueOrDefault(stringValue(act.Status), "not in force")))
+	}
+	if _, ok := actKindGenitives[strings.ToLower(title.Kind)]; !ok && provision.Article != "" {
+		notes = append(notes, fmt.Sprintf("The form of '%s' after the provision could not be declined automatically; check the grammar.", title.Kind))
+	}
+
+	if format == "json" {
+		selected := make(map[string]string, len(styles))
+		for _, style := range styles {
+			selected[style] = citations[style]
+		}
+		result := map[string]interface{}{
+			"act":          address.Address,
+			"title":        *act.Title,
+			"citedText":    text.Address.Address,
+			"consolidated": text.Consolidated,
+			"amendments":   len(text.Amendments),
+			"citations":    selected,
+		}
+		if unit := provision.format(title); unit != "" {
+			result["provision"] = unit
+		}
+		if len(notes) > 0 {
+			result["notes"] = notes
+		}
+		data, _ := json.MarshalIndent(result, "", "  ")
+		return mcp.NewToolResultText(string(data)), nil
+	}
+
+	summary := []string{
+		fmt.Sprintf("Act: %s", address.Address),
+		fmt.Sprintf("Title: %s", *act.Title),
+	}
+	if text.Consolidated {
+		summary = append(summary, fmt.Sprintf("Cited text: %s, the newest consolidated text (tekst jednolity)", text.Address.Address))
+	} else {
+		summary = append(summary, fmt.Sprintf("Cited text: %s, as published", text.Address.Address))
+	}
+	summary = append(summary, fmt.Sprintf("Amendments published after it: %d", len(text.Amendments)))
+	if unit := provision.format(title); unit != "" {
+		summary = append(summary, fmt.Sprintf("Provision: %s", unit))
+	}
+
+	labels := map[string]string{
+		"official": "Official (as in legislation)",
+		"academic": "Academic (legal writing)",
+		"short":    "Short",
+	}
+	var data []string
+	for _, style := range styles {
+		data = append(data, fmt.Sprintf("%s:", labels[style]), "  "+citations[style])
+	}
+
+	nextActions := []string{"Check the amendments: eli_get_act_references with category='Akty zmieniające'"}
+	if text.Consolidated {
+		nextActions = append(nextActions, "Cite the act as originally published: add consolidated='false'")
+	}
+	response := StandardResponse{
+		Operation:   "Legal Citation",
+		Status:      "Retrieved Successfully",
+		Summary:     summary,
+		Data:        data,
+		NextActions: nextActions,
+		Note:        strings.Join(append(notes, "Amendments are taken from the references of the act in the ELI database; check the list against the journal before publishing."), " "),
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const civilCodeDetails = `{
+	"ELI": "DU/1964/93", "publisher": "DU", "year": 1964, "volume": 16, "pos": 93, "type": "Ustawa",
+	"title": "Ustawa z dnia 23 kwietnia 1964 r. - Kodeks cywilny", "inForce": "IN_FORCE",
+	"references": {
+		"Inf. o tekście jednolitym": [{"id": "DU/2024/1061"}, {"id": "DU/2023/1610"}],
+		"Akty zmieniające": [{"id": "DU/2025/12"}, {"id": "DU/2020/5"}, {"id": "DU/2024/1237"}]
+	}
+}`
+
+func TestHandleFormatCitation(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/DU/1964/93": civilCodeDetails,
+		"/eli/acts/DU/2024/30": `{"publisher": "DU", "year": 2024, "pos": 30, "title": "Rozporządzenie Ministra Zdrowia z dnia 5 stycznia 2024 r. w sprawie recept"}`,
+	})
+
+	result, err := server.handleFormatCitation(context.Background(), createMockRequest(map[string]interface{}{
+		"year": "1964", "position": "93", "article": "art. 415",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{
+		"Cited text: DU/2024/1061, the newest consolidated text (tekst jednolity)",
+		"Amendments published after it: 2",
+		"art. 415 ustawy z dnia 23 kwietnia 1964 r. – Kodeks cywilny (Dz. U. z 2024 r. poz. 1061 i 1237 oraz z 2025 r. poz. 12)",
+		"art. 415 ustawy z 23.04.1964 r. – Kodeks cywilny (t.j. Dz.U. z 2024 r. poz. 1061 ze zm.)",
+		"  art. 415 k.c.",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+
+	result, _ = server.handleFormatCitation(context.Background(), createMockRequest(map[string]interface{}{
+		"year": "1964", "position": "93", "consolidated": "false", "format": "json",
+	}))
+	var response struct {
+		CitedText string            `json:"citedText"`
+		Citations map[string]string `json:"citations"`
+	}
+	if err := json.Unmarshal([]byte(extractTextContent(result)), &response); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v\n%s", err, extractTextContent(result))
+	}
+	expected := map[string]string{
+		"official": "Ustawa z dnia 23 kwietnia 1964 r. – Kodeks cywilny (Dz. U. Nr 16, poz. 93, z 2020 r. poz. 5, z 2024 r. poz. 1237 oraz z 2025 r. poz. 12)",
+		"academic": "Ustawa z 23.04.1964 r. – Kodeks cywilny (Dz.U. z 1964 r. Nr 16, poz. 93 ze zm.)",
+		"short":    "k.c. (Dz.U. 1964 Nr 16 poz. 93)",
+	}
+	if response.CitedText != "DU/1964/93" {
+		t.Errorf("Expected the act as published to be cited, got %s", response.CitedText)
+	}
+	for style, citation := range expected {
+		if response.Citations[style] != citation {
+			t.Errorf("Expected %s citation %q, got %q", style, citation, response.Citations[style])
+		}
+	}
+
+	result, _ = server.handleFormatCitation(context.Background(), createMockRequest(map[string]interface{}{
+		"year": "2024", "position": "30", "article": "3", "paragraph": "2", "style": "official,short",
+	}))
+	text = extractTextContent(result)
+	for _, expected := range []string{
+		"§ 3 ust. 2 rozporządzenia Ministra Zdrowia z dnia 5 stycznia 2024 r. w sprawie recept (Dz. U. poz. 30)",
+		"§ 3 ust. 2 rozporządzenia Ministra Zdrowia w sprawie recept (Dz.U. 2024 poz. 30)",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+	if strings.Contains(text, "Academic") {
+		t.Errorf("Expected only the requested styles, got:\n%s", text)
+	}
+
+	for _, arguments := range []map[string]interface{}{
+		{"year": "1964", "position": "93", "paragraph": "1"},
+		{"year": "1964", "position": "93", "article": "1", "letter": "a"},
+		{"year": "1964", "position": "93", "style": "harvard"},
+	} {
+		if result, _ := server.handleFormatCitation(context.Background(), createMockRequest(arguments)); !result.IsError {
+			t.Errorf("Expected %v to be rejected", arguments)
+		}
+	}
+}
+
+func TestCitationProvisionInCodes(t *testing.T) {
+	title := parseCitationTitle("Ustawa z dnia 6 czerwca 1997 r. Kodeks karny")
+	provision := citationProvision{Article: "148", Paragraph: "1"}
+	citations := formatCitations(title, provision, citedText{Address: actWatch{Publisher: "DU", Year: 2024, Position: 17}, Consolidated: true})
+	if citations["short"] != "art. 148 § 1 k.k." {
+		t.Errorf("Expected paragraphs of codes to be cited with §, got %q", citations["short"])
+	}
+	if want := "art. 148 § 1 ustawy z dnia 6 czerwca 1997 r. – Kodeks karny (Dz. U. z 2024 r. poz. 17)"; citations["official"] != want {
+		t.Errorf("Expected %q, got %q", want, citations["official"])
+	}
+}
@@ -0,0 +1,362 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Limits of eli_fulltext_search
+const (
+	defaultFulltextActs     = 50
+	maxFulltextActs         = 300
+	defaultFulltextMatches  = 3
+	maxFulltextMatches      = 20
+	defaultFulltextContext  = 80
+	maxListedFulltextActs   = 25
+	actTextIndexTTL         = 24 * time.Hour
+	maxIndexedActTexts      = 2000
+	fulltextProgressPerStep = 10
+)
+
+// indexedActText is the extracted page text of an act, kept in the act text index
+type indexedActText struct {
+	Address string
+	Title   string
+	Type    string
+	Pages   []string
+}
+
+// fulltextHit is an act whose text matches the query, with the matches of every term
+type fulltextHit struct {
+	Address      string                       `json:"address"`
+	Title        string                       `json:"title"`
+	Type         string                       `json:"type,omitempty"`
+	Pages        int                          `json:"pages"`
+	MatchedTerms int                          `json:"matchedTerms"`
+	TotalMatches int                          `json:"totalMatches"`
+	Matches      map[string][]textSearchMatch `json:"matches"`
+	position     int
+}
+
+// fulltextActAddress returns the publisher/year/position address of an act listed by ELI
+func fulltextActAddress(act eli.ActInfo) (string, bool) {
+	if act.Publisher == nil || act.Year == nil || act.Pos == nil {
+		return "", false
+	}
+	return fmt.Sprintf("%s/%d/%d", *act.Publisher, *act.Year, *act.Pos), true
+}
+
+// indexedActTextFor returns the page texts of an act from the act text index, or downloads and extracts its
+// PDF and adds it to the index; cached tells whether the text came from the index. The index keeps at most
+// maxIndexedActTexts acts for a day each; when it is full, the act indexed first is dropped.
+func (s *SejmServer) indexedActTextFor(ctx context.Context, act eli.ActInfo, address string) (text indexedActText, cached bool, err error) {
+	s.cache.mu.RLock()
+	entry := s.cache.ActTexts[address]
+	s.cache.mu.RUnlock()
+	if entry != nil && time.Now().Before(entry.ExpiresAt) {
+		return entry.Data.(indexedActText), true, nil
+	}
+
+	pdfData, err := s.makeTextRequest(ctx, fmt.Sprintf("%s/acts/%s/text.pdf", s.eliBaseURL, address), "pdf")
+	if err != nil {
+		return text, false, err
+	}
+	pages, err := s.extractPDFPageTexts(ctx, pdfData)
+	if err != nil {
+		return text, false, fmt.Errorf("failed to extract text: %w", err)
+	}
+	text = indexedActText{Address: address, Title: stringValue(act.Title), Type: stringValue(act.Type), Pages: pages}
+
+	s.cache.mu.Lock()
+	defer s.cache.mu.Unlock()
+	if s.cache.ActTexts == nil {
+		s.cache.ActTexts = make(map[string]*CacheEntry)
+	}
+	if len(s.cache.ActTexts) >= maxIndexedActTexts {
+		oldest := ""
+		for key, candidate := range s.cache.ActTexts {
+			if oldest == "" || candidate.ExpiresAt.Before(s.cache.ActTexts[oldest].ExpiresAt) {
+				oldest = key
+			}
+		}
+		delete(s.cache.ActTexts, oldest)
+	}
+	s.cache.ActTexts[address] = &CacheEntry{Data: text, ExpiresAt: time.Now().Add(actTextIndexTTL)}
+	return text, false, nil
+}
+
+// rankFulltextHits orders acts by the number of query terms they contain, then by their matches, then by position
+func rankFulltextHits(hits []fulltextHit) {
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].MatchedTerms != hits[j].MatchedTerms {
+			return hits[i].MatchedTerms > hits[j].MatchedTerms
+		}
+		if hits[i].TotalMatches != hits[j].TotalMatches {
+			return hits[i].TotalMatches > hits[j].TotalMatches
+		}
+		return hits[i].position < hits[j].position
+	})
+}
+
+// matchedPages lists the distinct pages of matches, e.g. "3, 7, 12"
+func matchedPages(matches []textSearchMatch) string {
+	var pages []string
+	seen := make(map[int]bool)
+	for _, match := range matches {
+		if !seen[match.Page] {
+			seen[match.Page] = true
+			pages = append(pages, strconv.Itoa(match.Page))
+		}
+	}
+	return strings.Join(pages, ", ")
+}
+
+// parseBoundedInt reads an optional numeric parameter between min and max
+func parseBoundedInt(request mcp.CallToolRequest, name string, defaultValue, minValue, maxValue int) (int, error) {
+	value := request.GetString(name, "")
+	if value == "" {
+		return defaultValue, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < minValue || n > maxValue {
+		return 0, fmt.Errorf("parameter '%s' must be a number between %d and %d", name, minValue, maxValue)
+	}
+	return n, nil
+}
+
+func (s *SejmServer) handleFulltextSearch(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("eli_fulltext_search called", slog.Any("arguments", request.Params.Arguments))
+
+	publisher := strings.ToUpper(strings.TrimSpace(request.GetString("publisher", "")))
+	year := strings.TrimSpace(request.GetString("year", ""))
+	if publisher == "" || year == "" {
+		return mcp.NewToolResultError("Both 'publisher' and 'year' are required: they bound the corpus searched, e.g. publisher='DU', year='2024'. Get publisher codes from eli_get_publishers."), nil
+	}
+	if n, err := strconv.Atoi(year); err != nil || n < 1918 || n > time.Now().Year() {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid year '%s': use a 4-digit year between 1918 and %d.", year, time.Now().Year())), nil
+	}
+	options, err := parseTextSearchOptions(request)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid search options: %v.", err)), nil
+	}
+	terms := splitSearchTerms(request.GetString("search_terms", ""), options)
+	if len(terms) == 0 {
+		return mcp.NewToolResultError("search_terms is required: comma-separated words or phrases to find in act texts, e.g. 'sztuczna inteligencja,algorytm'."), nil
+	}
+	maxActs, err := parseBoundedInt(request, "max_acts", defaultFulltextActs, 1, maxFulltextActs)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error() + "."), nil
+	}
+	offset, err := parseBoundedInt(request, "offset", 0, 0, 100000)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error() + "."), nil
+	}
+	maxMatches, err := parseBoundedInt(request, "max_matches_per_term", defaultFulltextMatches, 1, maxFulltextMatches)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error() + "."), nil
+	}
+	contextChars, err := parseBoundedInt(request, "context_chars", defaultFulltextContext, 20, 500)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error() + "."), nil
+	}
+	requireAll := request.GetString("require_all", "false") == "true"
+	actType := strings.TrimSpace(request.GetString("type", ""))
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+	// Invalid patterns are reported before any text is downloaded
+	if _, _, err := searchTermsInPages(nil, terms, options, contextChars, maxMatches); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid search terms: %v.", err)), nil
+	}
+
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/acts/%s/%s", s.eliBaseURL, publisher, year), map[string]string{
+		"limit":  strconv.Itoa(maxActs),
+		"offset": strconv.Itoa(offset),
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list the acts of %s/%s: %v", publisher, year, err)), nil
+	}
+	var listing eli.Acts
+	if err := s.decodeAPIResponse(data, &listing); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse the acts of %s/%s: %v", publisher, year, err)), nil
+	}
+	var listed []eli.ActInfo
+	if listing.Items != nil {
+		listed = *listing.Items
+	}
+
+	// Only acts with a PDF text of the requested type are searched
+	var acts []eli.ActInfo
+	var addresses []string
+	withoutText := 0
+	for _, act := range listed {
+		address, ok := fulltextActAddress(act)
+		if !ok {
+			continue
+		}
+		if actType != "" && !strings.EqualFold(stringValue(act.Type), actType) {
+			continue
+		}
+		if act.TextPDF == nil || !*act.TextPDF {
+			withoutText++
+			continue
+		}
+		acts = append(acts, act)
+		addresses = append(addresses, address)
+	}
+
+	progress := s.newProgressReporter(ctx, request)
+	coverage := newSourceCoverage("act texts")
+	texts := make([]indexedActText, len(acts))
+	errs := make([]error, len(acts))
+	fromIndex := make([]bool, len(acts))
+	slots := make(chan struct{}, maxConcurrentBodyFetches)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+	for i := range acts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if errs[i] = acquireSlot(ctx, slots); errs[i] != nil {
+				return
+			}
+			defer func() { <-slots }()
+			texts[i], fromIndex[i], errs[i] = s.indexedActTextFor(ctx, acts[i], addresses[i])
+			mu.Lock()
+			done++
+			if done%fulltextProgressPerStep == 0 || done == len(acts) {
+				progress.report(done, len(acts), fmt.Sprintf("Indexed %d of %d act texts", done, len(acts)))
+			}
+			mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	var hits []fulltextHit
+	reused := 0
+	for i, text := range texts {
+		if errs[i] != nil {
+			coverage.fail(addresses[i], errs[i])
+			continue
+		}
+		coverage.succeeded()
+		if fromIndex[i] {
+			reused++
+		}
+		matches, total, _ := searchTermsInPages(text.Pages, terms, options, contextChars, maxMatches)
+		if total == 0 || (requireAll && len(matches) < len(terms)) {
+			continue
+		}
+		hits = append(hits, fulltextHit{
+			Address:      text.Address,
+			Title:        text.Title,
+			Type:         text.Type,
+			Pages:        len(text.Pages),
+			MatchedTerms: len(matches),
+			TotalMatches: total,
+			Matches:      matches,
+			position:     i,
+		})
+	}
+	rankFulltextHits(hits)
+
+	nextOffset := 0
+	if len(listed) == maxActs {
+		nextOffset = offset + maxActs
+	}
+
+	if format == "json" {
+		result := map[string]interface{}{
+			"publisher":   publisher,
+			"year":        year,
+			"terms":       terms,
+			"offset":      offset,
+			"listed":      len(listed),
+			"searched":    coverage.total - len(coverage.failed),
+			"withoutText": withoutText,
+			"hits":        hits,
+		}
+		if nextOffset > 0 {
+			result["next_offset"] = nextOffset
+		}
+		if coverage.partial() {
+			result["unavailable"] = coverage.failed
+		}
+		out, _ := json.MarshalIndent(result, "", "  ")
+		return coverage.result(string(out)), nil
+	}
+
+	searched := coverage.total - len(coverage.failed)
+	matching := options.describe()
+	if requireAll {
+		matching += ", all terms required"
+	}
+	summary := []string{
+		fmt.Sprintf("Searched the texts of %d acts of %s/%s (positions %d-%d of the listing, %d from the index)", searched, publisher, year, offset+1, offset+len(listed), reused),
+		fmt.Sprintf("Search terms: %s (%s)", strings.Join(terms, ", "), matching),
+		fmt.Sprintf("Acts with matches: %d", len(hits)),
+	}
+	if withoutText > 0 {
+		summary = append(summary, fmt.Sprintf("Skipped %d acts without a PDF text", withoutText))
+	}
+
+	var results []string
+	for i, hit := range hits {
+		if i == maxListedFulltextActs {
+			results = append(results, fmt.Sprintf("... and %d more acts; use format='json' or narrow the terms", len(hits)-maxListedFulltextActs))
+			break
+		}
+		if i > 0 {
+			results = append(results, "")
+		}
+		header := fmt.Sprintf("• %s – %s", hit.Address, hit.Title)
+		if hit.Type != "" {
+			header += " (" + hit.Type + ")"
+		}
+		results = append(results, header+fmt.Sprintf(": %d matches of %d terms", hit.TotalMatches, hit.MatchedTerms))
+		for _, term := range terms {
+			matches := hit.Matches[term]
+			if len(matches) == 0 {
+				continue
+			}
+			results = append(results, fmt.Sprintf("  '%s' on pages %s: %s", term, matchedPages(matches), matches[0].Context))
+		}
+	}
+
+	var nextActions []string
+	if len(hits) > 0 {
+		parts := strings.Split(hits[0].Address, "/")
+		nextActions = append(nextActions, fmt.Sprintf("All matches in the top act: eli_search_act_content with publisher='%s', year='%s', position='%s', search_terms='%s'", parts[0], parts[1], parts[2], strings.Join(terms, ",")))
+	}
+	if nextOffset > 0 {
+		nextActions = append(nextActions, fmt.Sprintf("Next acts of the year: offset='%d', max_acts='%d'", nextOffset, maxActs))
+	}
+	nextActions = append(nextActions, "Acts tagged with a keyword instead: eli_search_acts with keyword")
+
+	note := "The texts are extracted from the acts' PDFs and kept in an in-memory index for 24 hours, so repeated searches of the same acts download nothing. A year holds hundreds of acts; search it in windows with offset and max_acts."
+	if len(hits) == 0 {
+		note = "No act text matches the search terms. Try match_mode='stem' for inflected forms or other acts with offset. " + note
+	}
+	response := StandardResponse{
+		Operation:   "ELI Full-Text Search",
+		Status:      coverage.status("Retrieved Successfully"),
+		Summary:     summary,
+		Data:        results,
+		NextActions: nextActions,
+		Note:        note,
+		Unavailable: coverage.unavailable(),
+	}
+	return coverage.result(response.Format()), nil
+}
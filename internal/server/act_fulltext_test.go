@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestHandleFulltextSearch(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/DU/2024": `{"count": 4, "items": [
+			{"publisher": "DU", "year": 2024, "pos": 1, "title": "Ustawa o pierwszej sprawie", "type": "Ustawa", "textPDF": true},
+			{"publisher": "DU", "year": 2024, "pos": 2, "title": "Rozporządzenie w drugiej sprawie", "type": "Rozporządzenie", "textPDF": true},
+			{"publisher": "DU", "year": 2024, "pos": 3, "title": "Obwieszczenie bez tekstu", "type": "Obwieszczenie", "textPDF": false},
+			{"publisher": "DU", "year": 2024, "pos": 4, "title": "Ustawa niedostępna", "type": "Ustawa", "textPDF": true}
+		]}`,
+		"/eli/acts/DU/2024/1/text.pdf": string(multiPagePDF(3)),
+		"/eli/acts/DU/2024/2/text.pdf": string(multiPagePDF(1)),
+	})
+
+	request := createMockRequest(map[string]interface{}{"publisher": "du", "year": "2024", "search_terms": "Page 1,Page 3"})
+	result, err := server.handleFulltextSearch(context.Background(), request)
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{
+		"Partially Retrieved",
+		"1 of 3 act texts unavailable",
+		"DU/2024/4",
+		"Searched the texts of 2 acts of DU/2024 (positions 1-4 of the listing, 0 from the index)",
+		"Acts with matches: 2",
+		"Skipped 1 acts without a PDF text",
+		"• DU/2024/1 – Ustawa o pierwszej sprawie (Ustawa): 2 matches of 2 terms",
+		"'Page 3' on pages 3: **Page 3**",
+		"• DU/2024/2 – Rozporządzenie w drugiej sprawie (Rozporządzenie): 1 matches of 1 terms",
+		"search_terms='Page 1,Page 3'",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+	if strings.Index(text, "DU/2024/1 –") > strings.Index(text, "DU/2024/2 –") {
+		t.Errorf("Expected the act with both terms first:\n%s", text)
+	}
+
+	// The second search reuses the indexed texts and applies the filters
+	result, _ = server.handleFulltextSearch(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "2024", "search_terms": "Page 1,Page 3", "require_all": "true", "type": "ustawa",
+	}))
+	text = extractTextContent(result)
+	if !strings.Contains(text, "1 from the index") || !strings.Contains(text, "Acts with matches: 1") || strings.Contains(text, "DU/2024/2 –") {
+		t.Errorf("Expected only the indexed act with both terms:\n%s", text)
+	}
+
+	result, _ = server.handleFulltextSearch(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "2024", "search_terms": "(", "match_mode": "regex",
+	}))
+	if !result.IsError {
+		t.Errorf("Expected an invalid pattern to be rejected, got: %s", extractTextContent(result))
+	}
+}
@@ -0,0 +1,296 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxKeywordSiblingExamples caps the sibling acts listed per keyword
+const maxKeywordSiblingExamples = 3
+
+// actKeywordSiblings are the other acts tagged with a keyword, or with all keywords of an act
+type actKeywordSiblings struct {
+	Keyword  string   `json:"keyword"`
+	Count    int      `json:"count"`
+	Examples []string `json:"examples,omitempty"`
+}
+
+// actEULaw is an EU act listed in the directives of an act's metadata
+type actEULaw struct {
+	Kind     string `json:"kind,omitempty"`
+	Citation string `json:"citation,omitempty"`
+	CELEX    string `json:"celex,omitempty"`
+	EURLex   string `json:"eurLex,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Date     string `json:"date,omitempty"`
+}
+
+// actClassification is how ELI classifies an act: its type and status and the bodies involved with it
+type actClassification struct {
+	Type           string   `json:"type,omitempty"`
+	Status         string   `json:"status,omitempty"`
+	InForce        string   `json:"inForce,omitempty"`
+	ReleasedBy     []string `json:"releasedBy,omitempty"`
+	AuthorizedBody []string `json:"authorizedBody,omitempty"`
+	Obligated      []string `json:"obligated,omitempty"`
+}
+
+// actKeywords is the tagging view of an act
+type actKeywords struct {
+	Act            string               `json:"act"`
+	Title          string               `json:"title,omitempty"`
+	Keywords       []string             `json:"keywords"`
+	KeywordNames   []string             `json:"keywordNames,omitempty"`
+	EULaw          []actEULaw           `json:"euLaw,omitempty"`
+	Classification actClassification    `json:"classification"`
+	Siblings       []actKeywordSiblings `json:"siblings,omitempty"`
+}
+
+// derefStrings returns the strings of an optional API list
+func derefStrings(values *[]string) []string {
+	if values == nil {
+		return nil
+	}
+	return *values
+}
+
+// actEULawFromDirectives lists the EU acts of an act's directives, with CELEX numbers where the citation is
+// recognized
+func actEULawFromDirectives(directives *[]eli.Directive) []actEULaw {
+	if directives == nil {
+		return nil
+	}
+	var laws []actEULaw
+	for _, directive := range *directives {
+		date := ""
+		if directive.Date != nil {
+			date = directive.Date.String()
+		}
+		refs := euReferencesFromDirective(directive)
+		if len(refs) == 0 {
+			laws = append(laws, actEULaw{Citation: stringValue(directive.Address), Title: stringValue(directive.Title), Date: date})
+			continue
+		}
+		for _, ref := range refs {
+			laws = append(laws, actEULaw{
+				Kind:     ref.Kind,
+				Citation: ref.Citation,
+				CELEX:    ref.CELEX(),
+				EURLex:   ref.EURLexURL(),
+				Title:    stringValue(directive.Title),
+				Date:     date,
+			})
+		}
+	}
+	return laws
+}
+
+// searchKeywordSiblings counts the acts tagged with a keyword (or comma-separated keywords, all required) and
+// names a few of them other than the act itself
+func (s *SejmServer) searchKeywordSiblings(ctx context.Context, keyword, self string) (actKeywordSiblings, error) {
+	siblings := actKeywordSiblings{Keyword: keyword}
+	params := map[string]string{"keyword": keyword, "limit": fmt.Sprint(maxKeywordSiblingExamples + 1)}
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/acts/search", s.eliBaseURL), params)
+	if err != nil {
+		return siblings, err
+	}
+	var result struct {
+		Items []eli.Act `json:"items"`
+		Count int       `json:"count"`
+	}
+	if err := s.decodeAPIResponse(data, &result); err != nil {
+		return siblings, fmt.Errorf("failed to parse search results: %w", err)
+	}
+	siblings.Count = result.Count
+	for _, act := range result.Items {
+		if address := actAddress(act); address != self && len(siblings.Examples) < maxKeywordSiblingExamples {
+			siblings.Examples = append(siblings.Examples, fmt.Sprintf("%s – %s", address, truncateRunes(stringValue(act.Title), 120)))
+		}
+	}
+	// The act itself carries its keywords, so it is one of the matches
+	if siblings.Count > 0 {
+		siblings.Count--
+	}
+	return siblings, nil
+}
+
+func (s *SejmServer) handleGetActKeywords(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("eli_get_act_keywords called", slog.Any("arguments", request.Params.Arguments))
+
+	publisher := request.GetString("publisher", "")
+	year := request.GetString("year", "")
+	position := request.GetString("position", "")
+	if publisher == "" || year == "" || position == "" {
+		return mcp.NewToolResultError("All three parameters are required: publisher (e.g., 'DU'), year (e.g., '2018'), and position (e.g., '1000'), or act with a well-known name. Get these from eli_search_acts results."), nil
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+	includeSiblings := request.GetString("siblings", "true") != "false"
+
+	endpoint := fmt.Sprintf("%s/acts/%s/%s/%s", s.eliBaseURL, publisher, year, position)
+	body, err := s.makeAPIRequest(ctx, endpoint, nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve legal act details from ELI database: %v. Please verify the legal act coordinates: publisher=%s, year=%s, position=%s.", err, publisher, year, position)), nil
+	}
+	var act eli.Act
+	if err := s.decodeAPIResponse(body, &act); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse legal act data from ELI API response: %v.", err)), nil
+	}
+
+	view := actKeywords{
+		Act:          actAddress(act),
+		Title:        stringValue(act.Title),
+		Keywords:     []string{},
+		KeywordNames: derefStrings(act.KeywordsNames),
+		EULaw:        actEULawFromDirectives(act.Directives),
+		Classification: actClassification{
+			Type:           stringValue(act.Type),
+			Status:         stringValue(act.Status),
+			ReleasedBy:     derefStrings(act.ReleasedBy),
+			AuthorizedBody: derefStrings(act.AuthorizedBody),
+			Obligated:      derefStrings(act.Obligated),
+		},
+	}
+	if act.Keywords != nil {
+		view.Keywords = *act.Keywords
+	}
+	if act.InForce != nil {
+		view.Classification.InForce = string(*act.InForce)
+	}
+
+	// One search per keyword, and one for all of them together: the closest siblings share every keyword
+	coverage := newSourceCoverage("keyword searches")
+	if includeSiblings && len(view.Keywords) > 0 {
+		queries := append([]string{}, view.Keywords...)
+		if len(view.Keywords) > 1 {
+			queries = append(queries, strings.Join(view.Keywords, ","))
+		}
+		results := make([]actKeywordSiblings, len(queries))
+		errs := make([]error, len(queries))
+		slots := make(chan struct{}, maxConcurrentBodyFetches)
+		var wg sync.WaitGroup
+		for i, query := range queries {
+			wg.Add(1)
+			go func(i int, query string) {
+				defer wg.Done()
+				if err := acquireSlot(ctx, slots); err != nil {
+					errs[i] = err
+					return
+				}
+				defer func() { <-slots }()
+				results[i], errs[i] = s.searchKeywordSiblings(ctx, query, view.Act)
+			}(i, query)
+		}
+		wg.Wait()
+		for i, query := range queries {
+			if errs[i] != nil {
+				coverage.fail(fmt.Sprintf("keyword '%s'", query), errs[i])
+				continue
+			}
+			coverage.succeeded()
+			view.Siblings = append(view.Siblings, results[i])
+		}
+	}
+
+	if format == "json" {
+		out, _ := json.MarshalIndent(view, "", "  ")
+		return coverage.result(string(out)), nil
+	}
+
+	summary := []string{fmt.Sprintf("Act: %s", view.Act)}
+	if view.Title != "" {
+		summary = append(summary, fmt.Sprintf("Title: %s", view.Title))
+	}
+	summary = append(summary, fmt.Sprintf("Keywords: %d, EU acts: %d", len(view.Keywords), len(view.EULaw)))
+
+	var data []string
+	data = append(data, "Keywords (hasła):")
+	if len(view.Keywords) == 0 {
+		data = append(data, "• none assigned")
+	}
+	for _, keyword := range view.Keywords {
+		data = append(data, "• "+keyword)
+	}
+	if len(view.KeywordNames) > 0 {
+		data = append(data, "", "Named entities:")
+		for _, name := range view.KeywordNames {
+			data = append(data, "• "+name)
+		}
+	}
+	if len(view.EULaw) > 0 {
+		data = append(data, "", "EU law:")
+		for _, law := range view.EULaw {
+			line := "• " + valueOrDefault(law.Citation, "unrecognized citation")
+			if law.CELEX != "" {
+				line += fmt.Sprintf(" (%s, CELEX %s, %s)", law.Kind, law.CELEX, law.EURLex)
+			}
+			if law.Title != "" {
+				line += ": " + truncateRunes(law.Title, 200)
+			}
+			data = append(data, line)
+		}
+	}
+	classification := view.Classification
+	data = append(data, "", "Classification:")
+	for _, field := range []struct{ label, value string }{
+		{"Type", classification.Type},
+		{"Status", classification.Status},
+		{"In force", classification.InForce},
+		{"Released by", strings.Join(classification.ReleasedBy, "; ")},
+		{"Authorized body", strings.Join(classification.AuthorizedBody, "; ")},
+		{"Obligated", strings.Join(classification.Obligated, "; ")},
+	} {
+		if field.value != "" {
+			data = append(data, fmt.Sprintf("• %s: %s", field.label, field.value))
+		}
+	}
+	if len(view.Siblings) > 0 {
+		data = append(data, "", "Other acts with the same keywords:")
+		for _, siblings := range view.Siblings {
+			label := fmt.Sprintf("'%s'", siblings.Keyword)
+			if strings.Contains(siblings.Keyword, ",") {
+				label = "all keywords"
+			}
+			data = append(data, fmt.Sprintf("• %s: %d acts", label, siblings.Count))
+			for _, example := range siblings.Examples {
+				data = append(data, "    "+example)
+			}
+		}
+	}
+
+	var nextActions []string
+	for _, siblings := range view.Siblings {
+		if strings.Contains(siblings.Keyword, ",") {
+			nextActions = append([]string{fmt.Sprintf("Acts sharing every keyword: eli_search_acts with keyword='%s'", siblings.Keyword)}, nextActions...)
+		} else if len(nextActions) < 4 {
+			nextActions = append(nextActions, fmt.Sprintf("Acts tagged '%s': eli_search_acts with keyword='%s'", siblings.Keyword, siblings.Keyword))
+		}
+	}
+	if len(view.Keywords) > 1 && len(view.Keywords) <= maxKeywordsAny {
+		nextActions = append(nextActions, fmt.Sprintf("Acts with any of the keywords: eli_search_acts with keyword='%s', keyword_mode='any'", strings.Join(view.Keywords, ",")))
+	}
+	if len(view.EULaw) > 0 {
+		nextActions = append(nextActions, fmt.Sprintf("EU acts cited in the text too: eli_get_eu_references with publisher='%s', year='%s', position='%s'", publisher, year, position))
+	}
+	nextActions = append(nextActions, "All keywords in use: eli_get_keywords")
+
+	response := StandardResponse{
+		Operation:   "Act Keywords",
+		Status:      coverage.status("Retrieved Successfully"),
+		Summary:     summary,
+		Data:        data,
+		NextActions: nextActions,
+		Unavailable: coverage.unavailable(),
+		Note:        "Keywords are assigned by the publisher of the Journal of Laws; the sibling counts exclude this act. EU law comes from the directives listed in the act's metadata; acts citing EU law only in their text are covered by eli_get_eu_references.",
+	}
+	return coverage.result(response.Format()), nil
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestHandleGetActKeywords(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/DU/2018/1000": `{
+			"ELI": "DU/2018/1000", "publisher": "DU", "year": 2018, "pos": 1000,
+			"title": "Ustawa o ochronie danych osobowych",
+			"type": "Ustawa", "status": "obowiązujący", "inForce": "IN_FORCE",
+			"keywords": ["ochrona danych osobowych", "informatyzacja"],
+			"keywordsNames": ["Prezes Urzędu Ochrony Danych Osobowych"],
+			"releasedBy": ["SEJM"],
+			"directives": [{"address": "Dz.Urz. UE L 119", "title": "Dyrektywa Parlamentu Europejskiego i Rady (UE) 2016/680"}]
+		}`,
+		"/eli/acts/search": `{"count": 3, "items": [
+			{"ELI": "DU/2018/1000", "publisher": "DU", "year": 2018, "pos": 1000, "title": "Ustawa o ochronie danych osobowych"},
+			{"ELI": "DU/2019/730", "publisher": "DU", "year": 2019, "pos": 730, "title": "Ustawa o zmianie niektórych ustaw w związku z zapewnieniem stosowania rozporządzenia 2016/679"},
+			{"ELI": "DU/2018/1135", "publisher": "DU", "year": 2018, "pos": 1135, "title": "Przepisy wprowadzające ustawę o ochronie danych osobowych"}
+		]}`,
+	})
+
+	result, err := server.handleGetActKeywords(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "2018", "position": "1000",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	output := extractTextContent(result)
+	for _, expected := range []string{
+		"• ochrona danych osobowych",
+		"Prezes Urzędu Ochrony Danych Osobowych",
+		"CELEX 32016L0680",
+		"• Released by: SEJM",
+		"'informatyzacja': 2 acts",
+		"all keywords: 2 acts",
+		"DU/2019/730",
+		"keyword='ochrona danych osobowych,informatyzacja'",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q, got: %s", expected, output)
+		}
+	}
+	if strings.Contains(output, "    DU/2018/1000") {
+		t.Errorf("Expected the act itself to be left out of the siblings, got: %s", output)
+	}
+
+	result, err = server.handleGetActKeywords(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "2018", "position": "1000", "siblings": "false", "format": "json",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	var view actKeywords
+	if err := json.Unmarshal([]byte(extractTextContent(result)), &view); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(view.Keywords) != 2 || len(view.EULaw) != 1 || view.EULaw[0].CELEX != "32016L0680" || view.Classification.InForce != "IN_FORCE" || view.Siblings != nil {
+		t.Errorf("Unexpected act keywords: %+v", view)
+	}
+}
+
+func TestHandleGetActKeywordsReportsFailedSearches(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/DU/2018/1000": `{"ELI": "DU/2018/1000", "publisher": "DU", "year": 2018, "pos": 1000, "keywords": ["informatyzacja"]}`,
+	})
+
+	result, err := server.handleGetActKeywords(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "2018", "position": "1000",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	output := extractTextContent(result)
+	if !strings.Contains(output, "• informatyzacja") || !strings.Contains(output, "keyword 'informatyzacja'") {
+		t.Errorf("Expected the keyword with its failed search, got: %s", output)
+	}
+}
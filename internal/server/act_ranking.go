@@ -0,0 +1,249 @@
+package server
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+)
+
+// Roles of a search hit relative to the underlying act it concerns
+const (
+	actRoleOriginal     = "original act"
+	actRoleConsolidated = "consolidated text"
+	actRoleAmendment    = "amendment"
+)
+
+var (
+	// actDateClausePattern matches the 'z dnia 26 czerwca 1974 r.' part of an act title
+	actDateClausePattern = regexp.MustCompile(`(?i)z dnia \d{1,2} \p{L}+ \d{4} ?r\.?`)
+	// consolidatedTextPattern matches announcements of a consolidated text (tekst jednolity)
+	consolidatedTextPattern = regexp.MustCompile(`(?i)jednolitego tekstu\s+`)
+	// amendmentPattern matches the subject of an amending act
+	amendmentPattern = regexp.MustCompile(`(?i)^o zmianie\s+`)
+	// otherActsSuffixPattern matches the trailing 'oraz niektórych innych ustaw' of amending acts
+	otherActsSuffixPattern = regexp.MustCompile(`(?i)\s+oraz\s+(niektórych\s+)?innych\s+ustaw.*$`)
+	// actKindPrefixPattern matches the act kind in genitive form that precedes the subject in references
+	actKindPrefixPattern = regexp.MustCompile(`(?i)^(ustawy|rozporządzenia|uchwały|zarządzenia|obwieszczenia|dekretu)\s+`)
+	// dashPattern matches the dash variants used in act titles
+	dashPattern = regexp.MustCompile(`\s*[-–—]\s*`)
+)
+
+// actGroup collects search hits that concern the same underlying act
+type actGroup struct {
+	Key          string
+	Canonical    eli.Act
+	Role         string
+	Consolidated []eli.Act
+	Amendments   []eli.Act
+	Score        float64
+}
+
+// normalizeActSubject lowercases a title fragment and unifies dashes and whitespace
+func normalizeActSubject(subject string) string {
+	subject = strings.ToLower(strings.Join(strings.Fields(subject), " "))
+	subject = dashPattern.ReplaceAllString(subject, " - ")
+	subject = strings.TrimPrefix(strings.TrimSpace(subject), "- ")
+	return strings.Trim(subject, " .,;")
+}
+
+// classifyAct returns the role of an act in its group and the subject identifying the underlying act.
+// 'Ustawa z dnia 26 czerwca 1974 r. - Kodeks pracy', the announcement of its consolidated text and
+// 'Ustawa ... o zmianie ustawy - Kodeks pracy' all share the subject 'kodeks pracy'.
+func classifyAct(title string) (string, string) {
+	if loc := consolidatedTextPattern.FindStringIndex(title); loc != nil {
+		subject := actKindPrefixPattern.ReplaceAllString(title[loc[1]:], "")
+		return actRoleConsolidated, normalizeActSubject(subject)
+	}
+
+	subject := title
+	if loc := actDateClausePattern.FindStringIndex(title); loc != nil {
+		subject = title[loc[1]:]
+	}
+	subject = normalizeActSubject(subject)
+
+	if amendmentPattern.MatchString(subject) {
+		subject = amendmentPattern.ReplaceAllString(subject, "")
+		subject = otherActsSuffixPattern.ReplaceAllString(subject, "")
+		subject = actKindPrefixPattern.ReplaceAllString(subject, "")
+		return actRoleAmendment, normalizeActSubject(subject)
+	}
+	return actRoleOriginal, subject
+}
+
+// actRelevance scores how well an act answers a title query. Exact subject matches, acts in force
+// and statutes rank first; amendments and consolidated texts rank below the act they concern.
+func actRelevance(act eli.Act, role, subject, query string) float64 {
+	score := 0.0
+	query = normalizeActSubject(query)
+	if query != "" {
+		queryWords := strings.Fields(query)
+		matched := 0
+		for _, word := range queryWords {
+			if strings.Contains(subject, word) {
+				matched++
+			}
+		}
+		score += 40 * float64(matched) / float64(len(queryWords))
+		switch {
+		case subject == query || subject == "o "+query:
+			score += 40
+		case strings.HasPrefix(subject, query):
+			score += 20
+		}
+	}
+
+	if act.InForce != nil && *act.InForce == eli.INFORCE {
+		score += 15
+	}
+	switch role {
+	case actRoleOriginal:
+		score += 10
+	case actRoleAmendment:
+		score -= 25
+	}
+	if act.Type != nil && strings.EqualFold(*act.Type, "ustawa") {
+		score += 5
+	}
+	// Prefer concise titles: 'Kodeks pracy' over 'Przepisy wprowadzające Kodeks pracy'
+	score -= float64(len(subject)) / 20
+	return score
+}
+
+// rankActsByRelevance orders search hits by actRelevance, keeping the API order for ties
+func rankActsByRelevance(acts []eli.Act, query string) []eli.Act {
+	type scoredAct struct {
+		act   eli.Act
+		score float64
+	}
+	scored := make([]scoredAct, len(acts))
+	for i, act := range acts {
+		title := ""
+		if act.Title != nil {
+			title = *act.Title
+		}
+		role, subject := classifyAct(title)
+		scored[i] = scoredAct{act, actRelevance(act, role, subject, query)}
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].score > scored[j].score
+	})
+	ranked := make([]eli.Act, len(acts))
+	for i, s := range scored {
+		ranked[i] = s.act
+	}
+	return ranked
+}
+
+// groupActsByUnderlyingAct merges original acts, their consolidated texts and amendments into one
+// group per underlying act. The canonical entry is the original act when it is among the results,
+// otherwise the most recent consolidated text, otherwise the first hit. Groups are ranked by relevance.
+func groupActsByUnderlyingAct(acts []eli.Act, query string) []*actGroup {
+	groups := make(map[string]*actGroup)
+	var order []*actGroup
+	for _, act := range acts {
+		title := ""
+		if act.Title != nil {
+			title = *act.Title
+		}
+		role, subject := classifyAct(title)
+		key := subject
+		if key == "" && act.ELI != nil {
+			key = *act.ELI
+		}
+
+		group, ok := groups[key]
+		if !ok {
+			group = &actGroup{Key: key}
+			groups[key] = group
+			order = append(order, group)
+		}
+
+		switch role {
+		case actRoleConsolidated:
+			group.Consolidated = append(group.Consolidated, act)
+		case actRoleAmendment:
+			group.Amendments = append(group.Amendments, act)
+		}
+		if group.Role == "" || (role == actRoleOriginal && group.Role != actRoleOriginal) ||
+			(role == actRoleConsolidated && group.Role == actRoleAmendment) {
+			group.Canonical = act
+			group.Role = role
+		}
+	}
+
+	for _, group := range order {
+		if group.Role == actRoleConsolidated {
+			group.Canonical = latestAct(group.Consolidated)
+		}
+		group.Score = actRelevance(group.Canonical, group.Role, group.Key, query)
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].Score > order[j].Score
+	})
+	return order
+}
+
+// latestAct returns the act with the highest year and position
+func latestAct(acts []eli.Act) eli.Act {
+	latest := acts[0]
+	for _, act := range acts[1:] {
+		if actSortKey(act) > actSortKey(latest) {
+			latest = act
+		}
+	}
+	return latest
+}
+
+func actSortKey(act eli.Act) int64 {
+	var key int64
+	if act.Year != nil {
+		key = int64(*act.Year) * 1_000_000
+	}
+	if act.Pos != nil {
+		key += int64(*act.Pos)
+	}
+	return key
+}
+
+// actAddress formats the publisher/year/position identifier of an act
+func actAddress(act eli.Act) string {
+	publisher := "Unknown"
+	if act.Publisher != nil {
+		publisher = *act.Publisher
+	}
+	year := "Unknown"
+	if act.Year != nil {
+		year = fmt.Sprintf("%d", *act.Year)
+	}
+	pos := "Unknown"
+	if act.Pos != nil {
+		pos = fmt.Sprintf("%d", *act.Pos)
+	}
+	return fmt.Sprintf("%s/%s/%s", publisher, year, pos)
+}
+
+// formatActGroup renders a group of search hits for eli_search_acts output
+func formatActGroup(group *actGroup) []string {
+	lines := []string{formatActSearchLine(group.Canonical)}
+	if group.Role != actRoleOriginal {
+		lines = append(lines, fmt.Sprintf("   Shown entry is a %s; the original act is not among the fetched results", group.Role))
+	}
+	if len(group.Consolidated) > 0 && !(group.Role == actRoleConsolidated && len(group.Consolidated) == 1) {
+		lines = append(lines, fmt.Sprintf("   Consolidated texts: %d (latest: %s)", len(group.Consolidated), actAddress(latestAct(group.Consolidated))))
+	}
+	if len(group.Amendments) > 0 {
+		var addresses []string
+		for i, act := range group.Amendments {
+			if i >= 5 {
+				addresses = append(addresses, "...")
+				break
+			}
+			addresses = append(addresses, actAddress(act))
+		}
+		lines = append(lines, fmt.Sprintf("   Amendments: %d (%s)", len(group.Amendments), strings.Join(addresses, ", ")))
+	}
+	return lines
+}
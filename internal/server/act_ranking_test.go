@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+)
+
+func testAct(publisher string, year, pos int32, title string, inForce eli.StatusInForce, actType string) eli.Act {
+	return eli.Act{Publisher: &publisher, Year: &year, Pos: &pos, Title: &title, InForce: &inForce, Type: &actType}
+}
+
+func TestClassifyAct(t *testing.T) {
+	testCases := []struct {
+		title   string
+		role    string
+		subject string
+	}{
+		{"Ustawa z dnia 26 czerwca 1974 r. - Kodeks pracy", actRoleOriginal, "kodeks pracy"},
+		{"Obwieszczenie Marszałka Sejmu Rzeczypospolitej Polskiej z dnia 8 marca 2023 r. w sprawie ogłoszenia jednolitego tekstu ustawy – Kodeks pracy", actRoleConsolidated, "kodeks pracy"},
+		{"Ustawa z dnia 9 marca 2023 r. o zmianie ustawy – Kodeks pracy oraz niektórych innych ustaw", actRoleAmendment, "kodeks pracy"},
+		{"Ustawa z dnia 10 maja 2018 r. o ochronie danych osobowych", actRoleOriginal, "o ochronie danych osobowych"},
+		{"Obwieszczenie Marszałka Sejmu z dnia 1 lipca 2019 r. w sprawie ogłoszenia jednolitego tekstu ustawy o ochronie danych osobowych", actRoleConsolidated, "o ochronie danych osobowych"},
+	}
+	for _, tc := range testCases {
+		role, subject := classifyAct(tc.title)
+		if role != tc.role || subject != tc.subject {
+			t.Errorf("classifyAct(%q) = %q, %q; expected %q, %q", tc.title, role, subject, tc.role, tc.subject)
+		}
+	}
+}
+
+func TestGroupActsByUnderlyingAct(t *testing.T) {
+	acts := []eli.Act{
+		testAct("DU", 2024, 1222, "Ustawa z dnia 16 sierpnia 2024 r. o zmianie ustawy - Kodeks pracy", eli.INFORCE, "Ustawa"),
+		testAct("DU", 1974, 142, "Ustawa z dnia 26 czerwca 1974 r. - Przepisy wprowadzające Kodeks pracy", eli.INFORCE, "Ustawa"),
+		testAct("DU", 2022, 1510, "Obwieszczenie Marszałka Sejmu z dnia 1 czerwca 2022 r. w sprawie ogłoszenia jednolitego tekstu ustawy - Kodeks pracy", eli.UNKNOWN, "Obwieszczenie"),
+		testAct("DU", 2023, 1465, "Obwieszczenie Marszałka Sejmu z dnia 30 czerwca 2023 r. w sprawie ogłoszenia jednolitego tekstu ustawy - Kodeks pracy", eli.UNKNOWN, "Obwieszczenie"),
+		testAct("DU", 1974, 141, "Ustawa z dnia 26 czerwca 1974 r. - Kodeks pracy", eli.INFORCE, "Ustawa"),
+	}
+
+	groups := groupActsByUnderlyingAct(acts, "kodeks pracy")
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(groups))
+	}
+	first := groups[0]
+	if actAddress(first.Canonical) != "DU/1974/141" || first.Role != actRoleOriginal || len(first.Consolidated) != 2 || len(first.Amendments) != 1 {
+		t.Errorf("Unexpected first group: %+v", first)
+	}
+	if actAddress(groups[1].Canonical) != "DU/1974/142" {
+		t.Errorf("Expected introductory provisions second, got %s", actAddress(groups[1].Canonical))
+	}
+	lines := strings.Join(formatActGroup(first), "\n")
+	if !strings.Contains(lines, "Consolidated texts: 2 (latest: DU/2023/1465)") || !strings.Contains(lines, "Amendments: 1 (DU/2024/1222)") {
+		t.Errorf("Unexpected group output: %s", lines)
+	}
+
+	ranked := rankActsByRelevance(acts, "kodeks pracy")
+	var order []string
+	for _, act := range ranked {
+		order = append(order, actAddress(act))
+	}
+	if got := strings.Join(order[:4], ","); got != "DU/1974/141,DU/2022/1510,DU/2023/1465,DU/2024/1222" {
+		t.Errorf("Expected canonical act, consolidated texts, then amendment; got %s", got)
+	}
+}
+
+func TestHandleSearchActsGroupByAct(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/search": `{"count": 3, "items": [
+			{"publisher": "DU", "year": 2023, "pos": 1465, "title": "Obwieszczenie Marszałka Sejmu z dnia 30 czerwca 2023 r. w sprawie ogłoszenia jednolitego tekstu ustawy - Kodeks pracy", "type": "Obwieszczenie"},
+			{"publisher": "DU", "year": 2024, "pos": 1222, "title": "Ustawa z dnia 16 sierpnia 2024 r. o zmianie ustawy - Kodeks pracy", "inForce": "IN_FORCE", "type": "Ustawa"},
+			{"publisher": "DU", "year": 1974, "pos": 141, "title": "Ustawa z dnia 26 czerwca 1974 r. - Kodeks pracy", "inForce": "IN_FORCE", "type": "Ustawa"}
+		]}`,
+	})
+
+	result, err := server.handleSearchActs(context.Background(), createMockRequest(map[string]interface{}{
+		"title": "kodeks pracy", "group_by_act": "true",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Showing 1 underlying acts grouped from 3 fetched results",
+		"• DU/1974/141: Ustawa z dnia 26 czerwca 1974 r. - Kodeks pracy (In force)",
+		"Consolidated texts: 1 (latest: DU/2023/1465)",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+}
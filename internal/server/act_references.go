@@ -0,0 +1,144 @@
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+)
+
+// Directions of act references, relative to the act whose references are listed
+const (
+	referenceDirectionAll      = "all"
+	referenceDirectionIncoming = "incoming"
+	referenceDirectionOutgoing = "outgoing"
+)
+
+// referenceCategoryDirections tells which reference categories of the ELI API are made by other acts
+// about this act (incoming) and which are made by this act about other acts (outgoing)
+var referenceCategoryDirections = map[string]string{
+	"Akty zmieniające":          referenceDirectionIncoming,
+	"Akty uchylające":           referenceDirectionIncoming,
+	"Akty wykonawcze":           referenceDirectionIncoming,
+	"Orzeczenie TK":             referenceDirectionIncoming,
+	"Inf. o tekście jednolitym": referenceDirectionIncoming,
+	"Akty zmienione":            referenceDirectionOutgoing,
+	"Akty zmieniane":            referenceDirectionOutgoing,
+	"Akty uchylone":             referenceDirectionOutgoing,
+	"Akty uznane za uchylone":   referenceDirectionOutgoing,
+	"Akty podstawowe":           referenceDirectionOutgoing,
+	"Podstawa prawna":           referenceDirectionOutgoing,
+	"Podstawa prawna z art.":    referenceDirectionOutgoing,
+	"Tekst jednolity dla aktu":  referenceDirectionOutgoing,
+	"Uchylenia wynikające z":    referenceDirectionOutgoing,
+	"Dyrektywy europejskie":     referenceDirectionOutgoing,
+}
+
+// referenceCategoryOrder lists the most useful categories first; the others follow alphabetically
+var referenceCategoryOrder = []string{
+	"Akty uchylające",
+	"Akty zmieniające",
+	"Akty uchylone",
+	"Akty zmieniane",
+	"Akty zmienione",
+	"Akty podstawowe",
+	"Akty wykonawcze",
+}
+
+// referenceCategoryDescriptions explains the categories listed first
+var referenceCategoryDescriptions = map[string]string{
+	"Akty uchylające":  "Acts that repeal this law",
+	"Akty zmieniające": "Acts that amend this law",
+	"Akty uchylone":    "Acts repealed by this law",
+	"Akty zmieniane":   "Acts amended by this law",
+	"Akty zmienione":   "Acts amended by this law",
+	"Akty podstawowe":  "Foundational acts this law is based on",
+	"Akty wykonawcze":  "Implementing regulations for this law",
+}
+
+// referenceDirection returns the direction of a reference category. Categories missing from
+// referenceCategoryDirections are classified by their participle: 'zmieniające' (amending) points at this
+// act, 'uchylone' (repealed) points away from it. Other categories have no direction.
+func referenceDirection(category string) string {
+	if direction, ok := referenceCategoryDirections[category]; ok {
+		return direction
+	}
+	words := strings.Fields(strings.ToLower(category))
+	if len(words) < 2 {
+		return ""
+	}
+	switch participle := words[1]; {
+	case strings.HasSuffix(participle, "ące"):
+		return referenceDirectionIncoming
+	case strings.HasSuffix(participle, "one") || strings.HasSuffix(participle, "ane"):
+		return referenceDirectionOutgoing
+	}
+	return ""
+}
+
+// selectReferenceCategories keeps the categories named in filter (comma-separated, ignoring case and Polish
+// diacritics) and going in the given direction. Unknown names are returned so that they can be reported.
+func selectReferenceCategories(references eli.CustomReferencesDetailsInfo, filter, direction string) (eli.CustomReferencesDetailsInfo, []string) {
+	selected := make(eli.CustomReferencesDetailsInfo)
+	var unknown []string
+	if strings.TrimSpace(filter) != "" {
+		byName := make(map[string]string, len(references))
+		for category := range references {
+			byName[normalizePolish(category)] = category
+		}
+		for _, name := range strings.Split(filter, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			category, ok := byName[normalizePolish(name)]
+			if !ok {
+				unknown = append(unknown, name)
+				continue
+			}
+			selected[category] = references[category]
+		}
+	} else {
+		for category, refs := range references {
+			selected[category] = refs
+		}
+	}
+
+	if direction != referenceDirectionAll {
+		for category := range selected {
+			if referenceDirection(category) != direction {
+				delete(selected, category)
+			}
+		}
+	}
+	return selected, unknown
+}
+
+// orderedReferenceCategories returns the categories in display order
+func orderedReferenceCategories(references eli.CustomReferencesDetailsInfo) []string {
+	var ordered, rest []string
+	for _, category := range referenceCategoryOrder {
+		if _, ok := references[category]; ok {
+			ordered = append(ordered, category)
+		}
+	}
+	for category := range references {
+		if !containsString(referenceCategoryOrder, category) {
+			rest = append(rest, category)
+		}
+	}
+	sort.Strings(rest)
+	return append(ordered, rest...)
+}
+
+// describeReferenceDirection explains a direction filter for the summary
+func describeReferenceDirection(direction string) string {
+	switch direction {
+	case referenceDirectionIncoming:
+		return "incoming (other acts referring to this act)"
+	case referenceDirectionOutgoing:
+		return "outgoing (acts this act refers to)"
+	}
+	return fmt.Sprintf("%s (both directions)", direction)
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestReferenceDirection(t *testing.T) {
+	for category, expected := range map[string]string{
+		"Akty wykonawcze":        referenceDirectionIncoming,
+		"Akty uchylone":          referenceDirectionOutgoing,
+		"Akty zmieniające":       referenceDirectionIncoming,
+		"Akty zastępujące":       referenceDirectionIncoming,
+		"Akty sprostowane":       referenceDirectionOutgoing,
+		"Uchylenia wynikające z": referenceDirectionOutgoing,
+		"Sprostowanie":           "",
+	} {
+		if got := referenceDirection(category); got != expected {
+			t.Errorf("referenceDirection(%q) = %q, expected %q", category, got, expected)
+		}
+	}
+}
+
+func TestHandleGetActReferencesFilters(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/DU/1964/93/references": `{
+			"Akty wykonawcze": [
+				{"id": "DU/2020/1", "act": {"publisher": "DU", "year": 2020, "pos": 1, "title": "Rozporządzenie A"}},
+				{"id": "DU/2020/2", "act": {"publisher": "DU", "year": 2020, "pos": 2, "title": "Rozporządzenie B"}},
+				{"id": "DU/2020/3", "act": {"publisher": "DU", "year": 2020, "pos": 3, "title": "Rozporządzenie C"}}
+			],
+			"Akty zmieniające": [
+				{"id": "DU/2021/5", "act": {"publisher": "DU", "year": 2021, "pos": 5, "title": "Ustawa zmieniająca"}}
+			],
+			"Podstawa prawna": [
+				{"id": "DU/1952/1", "act": {"publisher": "DU", "year": 1952, "pos": 1, "title": "Konstytucja PRL"}}
+			]
+		}`,
+	})
+
+	result, err := server.handleGetActReferences(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "1964", "position": "93", "direction": "outgoing",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	if !strings.Contains(content, "1. Konstytucja PRL (DU/1952/1)") || strings.Contains(content, "Rozporządzenie A") {
+		t.Errorf("Expected only outgoing references, got: %s", content)
+	}
+
+	result, _ = server.handleGetActReferences(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "1964", "position": "93", "category": "akty wykonawcze, AKTY ZMIENIAJACE", "limit": "2",
+	}))
+	content = extractTextContent(result)
+	for _, expected := range []string{
+		"Total references found: 4",
+		"• Akty zmieniające: showing 1-1 of 1 total references (Acts that amend this law)",
+		"• Akty wykonawcze: showing 1-2 of 3 total references",
+		"... 1 more references available (use category='Akty wykonawcze' with offset=2 to continue)",
+		"Next page: eli_get_act_references with publisher='DU', year='1964', position='93', category='akty wykonawcze, AKTY ZMIENIAJACE', offset='2', limit='2'",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+	if strings.Contains(content, "Konstytucja PRL") {
+		t.Errorf("Expected unselected categories to be left out, got: %s", content)
+	}
+
+	result, _ = server.handleGetActReferences(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "1964", "position": "93", "direction": "sideways",
+	}))
+	if !result.IsError {
+		t.Error("Expected an error for an invalid direction")
+	}
+}
@@ -0,0 +1,297 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// defaultSampleSize and maxSampleSize bound the acts drawn by eli_sample_acts
+	defaultSampleSize = 10
+	maxSampleSize     = 100
+	// sampleDrawsPerAct bounds the offsets drawn per requested act when the status filter rejects some of them
+	sampleDrawsPerAct = 5
+)
+
+// actSearchPage is a page of /acts/search results. The API reports the number of matches in count and,
+// in newer responses, totalCount.
+type actSearchPage struct {
+	Items      []eli.Act `json:"items"`
+	Count      int       `json:"count"`
+	TotalCount int       `json:"totalCount"`
+}
+
+// matches returns the number of acts matching the search
+func (p actSearchPage) matches() int {
+	if p.TotalCount > p.Count {
+		return p.TotalCount
+	}
+	return p.Count
+}
+
+// sampleOffsets draws n distinct offsets below total with Floyd's algorithm, without building the whole range.
+// The same seed gives the same offsets in the same order.
+func sampleOffsets(rng *rand.Rand, total, n int) []int {
+	if n > total {
+		n = total
+	}
+	chosen := make(map[int]bool, n)
+	offsets := make([]int, 0, n)
+	for j := total - n; j < total; j++ {
+		offset := rng.Intn(j + 1)
+		if chosen[offset] {
+			offset = j
+		}
+		chosen[offset] = true
+		offsets = append(offsets, offset)
+	}
+	// Floyd's algorithm favours high offsets late in the order; shuffling keeps prefixes uniform
+	rng.Shuffle(len(offsets), func(i, j int) { offsets[i], offsets[j] = offsets[j], offsets[i] })
+	return offsets
+}
+
+// searchActAt returns the act at an offset of the search results
+func (s *SejmServer) searchActAt(ctx context.Context, params map[string]string, offset int) (eli.Act, error) {
+	query := withParam(params, "limit", "1")
+	query["offset"] = strconv.Itoa(offset)
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/acts/search", s.eliBaseURL), query)
+	if err != nil {
+		return eli.Act{}, err
+	}
+	var page actSearchPage
+	if err := s.decodeAPIResponse(data, &page); err != nil {
+		return eli.Act{}, fmt.Errorf("failed to parse search results: %w", err)
+	}
+	if len(page.Items) == 0 {
+		return eli.Act{}, fmt.Errorf("no act at offset %d", offset)
+	}
+	return page.Items[0], nil
+}
+
+// sampleActs fetches the acts at the given offsets, a few at a time, and keeps the first n that have the
+// legal status (if given), in the order of offsets. It returns the acts and the number of offsets fetched;
+// failures are recorded in coverage.
+func (s *SejmServer) sampleActs(ctx context.Context, params map[string]string, offsets []int, n int, status string, coverage *sourceCoverage) ([]eli.Act, int) {
+	var sample []eli.Act
+	fetched := 0
+	for start := 0; start < len(offsets) && len(sample) < n && ctx.Err() == nil; start += maxConcurrentBodyFetches {
+		batch := offsets[start:min(start+maxConcurrentBodyFetches, len(offsets))]
+		acts := make([]eli.Act, len(batch))
+		errs := make([]error, len(batch))
+		var wg sync.WaitGroup
+		for i, offset := range batch {
+			wg.Add(1)
+			go func(i, offset int) {
+				defer wg.Done()
+				acts[i], errs[i] = s.searchActAt(ctx, params, offset)
+			}(i, offset)
+		}
+		wg.Wait()
+
+		for i := range batch {
+			fetched++
+			if errs[i] != nil {
+				coverage.fail(fmt.Sprintf("offset %d", batch[i]), errs[i])
+				continue
+			}
+			coverage.succeeded()
+			if len(sample) < n && (status == "" || len(filterActsByStatus(acts[i:i+1], status)) == 1) {
+				sample = append(sample, acts[i])
+			}
+		}
+	}
+	return sample, fetched
+}
+
+// handleRandomAct draws a single act; it is eli_sample_acts with count=1
+func (s *SejmServer) handleRandomAct(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	arguments := make(map[string]any)
+	for name, value := range request.GetArguments() {
+		arguments[name] = value
+	}
+	arguments["count"] = "1"
+	request.Params.Arguments = arguments
+	return s.handleSampleActs(ctx, request)
+}
+
+func (s *SejmServer) handleSampleActs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("eli_sample_acts called", slog.Any("arguments", request.Params.Arguments))
+
+	count := defaultSampleSize
+	var err error
+	if value := request.GetString("count", ""); value != "" {
+		count, err = strconv.Atoi(value)
+		if err != nil || count < 1 || count > maxSampleSize {
+			return mcp.NewToolResultError(fmt.Sprintf("Parameter 'count' must be a number between 1 and %d.", maxSampleSize)), nil
+		}
+	}
+	seed := time.Now().UnixNano()
+	seedGiven := false
+	if value := strings.TrimSpace(request.GetString("seed", "")); value != "" {
+		seed, err = strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid seed '%s'. Use an integer, e.g. '42'.", value)), nil
+		}
+		seedGiven = true
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	params := make(map[string]string)
+	var filters []string
+	if publisher := strings.ToUpper(strings.TrimSpace(request.GetString("publisher", ""))); publisher != "" {
+		params["publisher"] = publisher
+		filters = append(filters, "publisher "+publisher)
+	}
+	if docType := strings.TrimSpace(request.GetString("type", "")); docType != "" {
+		params["type"] = docType
+		filters = append(filters, "type "+docType)
+	}
+	if keyword := strings.Join(splitKeywords(request.GetString("keyword", "")), ","); keyword != "" {
+		params["keyword"] = keyword
+		filters = append(filters, "keywords "+keyword)
+	}
+	if inForce := request.GetString("in_force", ""); inForce != "" {
+		if inForce != "0" && inForce != "1" {
+			return mcp.NewToolResultError("Parameter 'in_force' must be '1' (acts in force) or '0' (acts not in force)."), nil
+		}
+		params["inForce"] = inForce
+		filters = append(filters, "in_force "+inForce)
+	}
+	yearFrom, yearTo := 0, 0
+	for _, bound := range []struct {
+		name   string
+		target *int
+	}{{"year_from", &yearFrom}, {"year_to", &yearTo}} {
+		if value := request.GetString(bound.name, ""); value != "" {
+			year, err := strconv.Atoi(value)
+			if err != nil || year < 1918 || year > time.Now().Year()+1 {
+				return mcp.NewToolResultError(fmt.Sprintf("Parameter '%s' must be a year between 1918 and %d.", bound.name, time.Now().Year()+1)), nil
+			}
+			*bound.target = year
+		}
+	}
+	if yearFrom != 0 && yearTo != 0 && yearFrom > yearTo {
+		return mcp.NewToolResultError(fmt.Sprintf("year_from (%d) must not be after year_to (%d).", yearFrom, yearTo)), nil
+	}
+	if yearFrom != 0 {
+		params["dateFrom"] = fmt.Sprintf("%d-01-01", yearFrom)
+	}
+	if yearTo != 0 {
+		params["dateTo"] = fmt.Sprintf("%d-12-31", yearTo)
+	}
+	if yearFrom != 0 || yearTo != 0 {
+		filters = append(filters, fmt.Sprintf("announced %s to %s", valueOrDefault(params["dateFrom"], "start"), valueOrDefault(params["dateTo"], "now")))
+	}
+	legalStatus := ""
+	if value := request.GetString("status", ""); value != "" {
+		legalStatus, err = normalizeLegalStatus(value)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid status: %v. See eli_get_statuses.", err)), nil
+		}
+		filters = append(filters, "status "+legalStatus)
+	}
+
+	// The number of matches tells the range of offsets to draw from
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/acts/search", s.eliBaseURL), withParam(params, "limit", "1"))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to search Polish legal acts database: %v. Please verify your filters are valid.", err)), nil
+	}
+	var page actSearchPage
+	if err := s.decodeAPIResponse(data, &page); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse legal acts search results: %v.", err)), nil
+	}
+	total := page.matches()
+	if total == 0 {
+		return mcp.NewToolResultError("No acts match the filters. Widen the year range or remove the type or keyword filter."), nil
+	}
+
+	draws := count
+	if legalStatus != "" {
+		// The API cannot filter by legal status, so rejected acts are replaced by further draws
+		draws = count * sampleDrawsPerAct
+	}
+	rng := rand.New(rand.NewSource(seed))
+	offsets := sampleOffsets(rng, total, draws)
+	coverage := newSourceCoverage("acts")
+	sample, fetched := s.sampleActs(ctx, params, offsets, count, legalStatus, coverage)
+	if len(sample) == 0 && len(coverage.failed) > 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve sampled acts: %s", coverage.failed[0])), nil
+	}
+
+	if format == "json" {
+		result, _ := json.MarshalIndent(map[string]interface{}{
+			"seed":        seed,
+			"matches":     total,
+			"requested":   count,
+			"fetched":     fetched,
+			"filters":     filters,
+			"acts":        sample,
+			"unavailable": coverage.failed,
+		}, "", "  ")
+		return coverage.result(string(result)), nil
+	}
+
+	summary := []string{
+		fmt.Sprintf("Matching acts: %d", total),
+		fmt.Sprintf("Sampled: %d of %d requested", len(sample), count),
+		fmt.Sprintf("Seed: %d", seed),
+	}
+	if len(filters) > 0 {
+		summary = append(summary, "Filters: "+strings.Join(filters, ", "))
+	}
+	var results []string
+	for _, act := range sample {
+		results = append(results, formatActSearchLine(act))
+	}
+	var notes []string
+	if len(sample) < count {
+		if legalStatus != "" {
+			notes = append(notes, fmt.Sprintf("Only %d of %d drawn acts had the status '%s'; narrow the filters (e.g. in_force) to sample rarer statuses.", len(sample), fetched, legalStatus))
+		} else if total < count {
+			notes = append(notes, fmt.Sprintf("Only %d acts match the filters, so all of them are listed.", total))
+		}
+	}
+	if !seedGiven {
+		notes = append(notes, fmt.Sprintf("Repeat the call with seed='%d' to get the same sample.", seed))
+	}
+	notes = append(notes, "The same seed and filters give the same sample as long as the database does not change; new acts shift the offsets.")
+
+	response := StandardResponse{
+		Operation:   "Random Act Sample",
+		Status:      coverage.status("Retrieved Successfully"),
+		Summary:     summary,
+		Unavailable: coverage.unavailable(),
+		Data:        results,
+		NextActions: []string{
+			"Act metadata: eli_get_act_details with publisher, year and position",
+			"Text of an act: eli_get_act_text with publisher, year and position",
+			"Another sample: change the seed or omit it",
+			"Structured output for datasets: add format='json'",
+		},
+		Note: strings.Join(notes, " "),
+	}
+	return coverage.result(response.Format()), nil
+}
+
+// withParam returns a copy of params with one more parameter
+func withParam(params map[string]string, name, value string) map[string]string {
+	query := make(map[string]string, len(params)+1)
+	for k, v := range params {
+		query[k] = v
+	}
+	query[name] = value
+	return query
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSampleOffsets(t *testing.T) {
+	offsets := sampleOffsets(rand.New(rand.NewSource(42)), 50000, 20)
+	if len(offsets) != 20 {
+		t.Fatalf("Expected 20 offsets, got %d", len(offsets))
+	}
+	seen := make(map[int]bool)
+	for _, offset := range offsets {
+		if offset < 0 || offset >= 50000 || seen[offset] {
+			t.Fatalf("Offsets must be distinct and in range, got %v", offsets)
+		}
+		seen[offset] = true
+	}
+	if again := sampleOffsets(rand.New(rand.NewSource(42)), 50000, 20); fmt.Sprint(again) != fmt.Sprint(offsets) {
+		t.Errorf("The same seed must give the same offsets: %v vs %v", again, offsets)
+	}
+	if all := sampleOffsets(rand.New(rand.NewSource(1)), 3, 10); len(all) != 3 {
+		t.Errorf("Expected every offset when sampling more than the total, got %v", all)
+	}
+}
+
+func TestHandleSampleActs(t *testing.T) {
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eli/acts/search" || r.URL.Query().Get("publisher") != "DU" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		status := "obowiązujący"
+		if offset%2 == 1 {
+			status = "uchylony"
+		}
+		fmt.Fprintf(w, `{"count": 1, "totalCount": 40, "items": [{"ELI": "DU/2020/%d", "publisher": "DU", "year": 2020, "pos": %d, "title": "Ustawa numer %d", "status": "%s"}]}`,
+			offset+1, offset+1, offset+1, status)
+	}))
+	t.Cleanup(mirror.Close)
+	server := NewSejmServerWithConfig(Config{ELIBaseURL: mirror.URL + "/eli"})
+
+	arguments := map[string]interface{}{"publisher": "DU", "count": "5", "seed": "7"}
+	result, err := server.handleSampleActs(context.Background(), createMockRequest(arguments))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{"Matching acts: 40", "Sampled: 5 of 5 requested", "Seed: 7", "Ustawa numer"} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, content)
+		}
+	}
+	again, _ := server.handleSampleActs(context.Background(), createMockRequest(arguments))
+	if extractTextContent(again) != content {
+		t.Errorf("The same seed must give the same sample:\n%s\n---\n%s", extractTextContent(again), content)
+	}
+
+	// Acts with another status are rejected and replaced by further draws
+	result, _ = server.handleSampleActs(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "count": "4", "seed": "3", "status": "uchylony", "format": "json",
+	}))
+	content = extractTextContent(result)
+	if result.IsError || strings.Count(content, `"status": "uchylony"`) != 4 || strings.Contains(content, `"status": "obowiązujący"`) {
+		t.Errorf("Expected 4 repealed acts, got:\n%s", content)
+	}
+
+	single, _ := server.handleRandomAct(context.Background(), createMockRequest(map[string]interface{}{"publisher": "DU"}))
+	if single.IsError || !strings.Contains(extractTextContent(single), "Sampled: 1 of 1 requested") {
+		t.Errorf("Expected a single act, got:\n%s", extractTextContent(single))
+	}
+
+	for _, invalid := range []map[string]interface{}{
+		{"count": "0"},
+		{"seed": "abc"},
+		{"year_from": "2020", "year_to": "2010"},
+		{"in_force": "yes"},
+	} {
+		if result, _ := server.handleSampleActs(context.Background(), createMockRequest(invalid)); !result.IsError {
+			t.Errorf("Expected an error for %v", invalid)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// actTextTypeLabels describes the types of text files of an act; other types are listed with their code
+var actTextTypeLabels = map[eli.ActTextType]string{
+	eli.O: "text as published (tekst ogłoszony)",
+	eli.U: "unified text (tekst ujednolicony)",
+	eli.H: "HTML text",
+	eli.I: "additional file (type I)",
+	eli.T: "additional file (type T)",
+}
+
+// actTextFile is a text file of an act as listed by eli_list_act_texts
+type actTextFile struct {
+	FileName string `json:"fileName"`
+	Type     string `json:"type"`
+	Label    string `json:"label"`
+}
+
+// actTextFiles lists the text files attached to an act: the text as published, unified texts, annexes and
+// other files, in the order given by the API
+func actTextFiles(act eli.Act) []actTextFile {
+	if act.Texts == nil {
+		return nil
+	}
+	var files []actTextFile
+	for _, text := range *act.Texts {
+		if text.FileName == nil || *text.FileName == "" {
+			continue
+		}
+		file := actTextFile{FileName: *text.FileName}
+		if text.Type != nil {
+			file.Type = string(*text.Type)
+		}
+		file.Label = valueOrDefault(actTextTypeLabels[eli.ActTextType(file.Type)], fmt.Sprintf("file of type %s", valueOrDefault(file.Type, "unknown")))
+		files = append(files, file)
+	}
+	return files
+}
+
+// fetchActTextFiles returns the details of an act and its text files
+func (s *SejmServer) fetchActTextFiles(ctx context.Context, address actWatch) (eli.Act, []actTextFile, error) {
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/acts/%s", s.eliBaseURL, address.Address), nil)
+	if err != nil {
+		return eli.Act{}, nil, fmt.Errorf("failed to retrieve act %s: %w", address.Address, err)
+	}
+	var act eli.Act
+	if err := s.decodeAPIResponse(data, &act); err != nil {
+		return eli.Act{}, nil, fmt.Errorf("failed to parse act %s: %w", address.Address, err)
+	}
+	return act, actTextFiles(act), nil
+}
+
+func (s *SejmServer) handleListActTexts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("eli_list_act_texts called", slog.Any("arguments", request.Params.Arguments))
+
+	address, err := parseActAddress(request.GetString("publisher", ""), request.GetString("year", ""), request.GetString("position", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid act address: %v.", err)), nil
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	act, files, err := s.fetchActTextFiles(ctx, address)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%v. Please verify the coordinates using eli_search_acts.", err)), nil
+	}
+
+	if format == "json" {
+		result, _ := json.MarshalIndent(map[string]interface{}{
+			"act":   address.Address,
+			"title": stringValue(act.Title),
+			"texts": files,
+		}, "", "  ")
+		return mcp.NewToolResultText(string(result)), nil
+	}
+
+	summary := []string{
+		fmt.Sprintf("Act: %s", address.Address),
+		fmt.Sprintf("Title: %s", valueOrDefault(stringValue(act.Title), "No title")),
+		fmt.Sprintf("Text files: %d", len(files)),
+	}
+	var results []string
+	status := "Retrieved Successfully"
+	if len(files) == 0 {
+		status = "No Results Found"
+		results = append(results, "The ELI API lists no text files for this act.")
+	}
+	for _, file := range files {
+		results = append(results, fmt.Sprintf("• %s – %s", file.FileName, file.Label))
+	}
+
+	nextActions := []string{"Main text of the act: eli_get_act_text with publisher, year and position"}
+	if len(files) > 0 {
+		nextActions = append([]string{fmt.Sprintf("Read a file: eli_get_act_file with publisher='%s', year='%d', position='%d' and file_name (e.g. '%s')", address.Publisher, address.Year, address.Position, files[0].FileName)}, nextActions...)
+	}
+	response := StandardResponse{
+		Operation:   "Act Text Files",
+		Status:      status,
+		Summary:     summary,
+		Data:        results,
+		NextActions: nextActions,
+		Note:        "Besides the text as published, acts may have unified texts and separate files such as annexes (załączniki), which eli_get_act_text does not return.",
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
+
+func (s *SejmServer) handleGetActFile(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("eli_get_act_file called", slog.Any("arguments", request.Params.Arguments))
+
+	address, err := parseActAddress(request.GetString("publisher", ""), request.GetString("year", ""), request.GetString("position", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid act address: %v.", err)), nil
+	}
+	fileName := strings.TrimSpace(request.GetString("file_name", ""))
+	if fileName == "" || strings.ContainsAny(fileName, "/\\") {
+		return mcp.NewToolResultError("Parameter 'file_name' is required and must be a file name listed by eli_list_act_texts, e.g. 'D20231465.pdf'."), nil
+	}
+	if _, _, err := parseAttachmentDelivery(request.GetString("return_content", ""), request.GetString("max_size_bytes", "")); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// The file is addressed by its type, which only the listing knows
+	_, files, err := s.fetchActTextFiles(ctx, address)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%v. Please verify the coordinates using eli_search_acts.", err)), nil
+	}
+	var file *actTextFile
+	var names []string
+	for i := range files {
+		names = append(names, files[i].FileName)
+		if strings.EqualFold(files[i].FileName, fileName) {
+			file = &files[i]
+		}
+	}
+	if file == nil {
+		if len(names) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Act %s has no text files in the ELI API.", address.Address)), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Act %s has no file '%s'. Available files: %s.", address.Address, fileName, strings.Join(names, ", "))), nil
+	}
+
+	endpoint := fmt.Sprintf("%s/acts/%s/text/%s/%s", s.eliBaseURL, address.Address, file.Type, file.FileName)
+	data, err := s.makeAPIRequestWithHeaders(ctx, endpoint, nil, map[string]string{"Accept": "*/*"})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to download %s of act %s: %v.", file.FileName, address.Address, err)), nil
+	}
+	uri := fmt.Sprintf("%seli/%s/%s", attachmentResourceScheme, address.Address, file.FileName)
+	return s.documentFileResult(ctx, request, "eli_get_act_file", file.FileName, uri, endpoint, data, "; it may be a scanned image")
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+)
+
+const actWithAnnexDetails = `{
+	"ELI": "DU/2023/1465", "publisher": "DU", "year": 2023, "pos": 1465, "title": "Obwieszczenie w sprawie tekstu jednolitego",
+	"texts": [
+		{"fileName": "D20231465.pdf", "type": "O"},
+		{"fileName": "D20231465Z1.html", "type": "I"},
+		{"fileName": "D20231465X.pdf", "type": "X"}
+	]
+}`
+
+func TestActTextFiles(t *testing.T) {
+	var act eli.Act
+	if err := json.Unmarshal([]byte(actWithAnnexDetails), &act); err != nil {
+		t.Fatalf("Failed to parse act: %v", err)
+	}
+	files := actTextFiles(act)
+	if len(files) != 3 || files[0].Label != actTextTypeLabels[eli.O] || files[2].Label != "file of type X" {
+		t.Errorf("Unexpected text files: %+v", files)
+	}
+	if files := actTextFiles(eli.Act{}); files != nil {
+		t.Errorf("Expected no files for an act without texts, got %+v", files)
+	}
+}
+
+func TestHandleListActTexts(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/DU/2023/1465": actWithAnnexDetails,
+	})
+
+	result, err := server.handleListActTexts(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "du", "year": "2023", "position": "1465",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	if !strings.Contains(text, "Text files: 3") || !strings.Contains(text, "• D20231465Z1.html – additional file (type I)") {
+		t.Errorf("Expected the three files, got: %s", text)
+	}
+
+	result, _ = server.handleListActTexts(context.Background(), createMockRequest(map[string]interface{}{"publisher": "DU"}))
+	if !result.IsError {
+		t.Error("Expected an error without year and position")
+	}
+}
+
+func TestHandleGetActFile(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/DU/2023/1465":                         actWithAnnexDetails,
+		"/eli/acts/DU/2023/1465/text/I/D20231465Z1.html": `<html><body><h1>Załącznik nr 1</h1><p>Wzór wniosku</p></body></html>`,
+	})
+
+	result, err := server.handleGetActFile(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "2023", "position": "1465", "file_name": "d20231465z1.html",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	if text := extractTextContent(result); !strings.Contains(text, "Załącznik nr 1") || !strings.Contains(text, "Wzór wniosku") {
+		t.Errorf("Expected the annex text, got: %s", text)
+	}
+
+	result, _ = server.handleGetActFile(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "2023", "position": "1465", "file_name": "D20231465Z2.pdf",
+	}))
+	if !result.IsError || !strings.Contains(extractTextContent(result), "Available files: D20231465.pdf, D20231465Z1.html, D20231465X.pdf") {
+		t.Errorf("Expected an unknown file to list the available ones, got: %s", extractTextContent(result))
+	}
+
+	result, _ = server.handleGetActFile(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "2023", "position": "1465", "file_name": "../1466/D20231466.pdf",
+	}))
+	if !result.IsError {
+		t.Error("Expected a path in file_name to be rejected")
+	}
+}
@@ -0,0 +1,353 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// agendaHeadingChars limits the heading shown for an agenda item detected in the transcript text
+const agendaHeadingChars = 200
+
+// agendaOrdinalPattern matches Polish ordinal numerals used for agenda items ('punktu drugiego')
+const agendaOrdinalPattern = `(?:pierwsz|drug|trzeci|czwart|piąt|szóst|siódm|ósm|dziewiąt|dziesiąt|jedenast|dwunast)\p{L}*`
+
+var (
+	// agendaTransitionPattern matches the chair moving to an item: 'Przechodzimy do rozpatrzenia punktu 2' or 'punktu drugiego'
+	agendaTransitionPattern = regexp.MustCompile(`(?i)(?:przechodzimy|przechodzę|przystępujemy|przystąpimy|przejdźmy|przejdziemy|powracamy|wracamy)\s+do\s+(?:\p{L}+\s+){0,3}?(?:punktu|pkt\.?)\s+(\d{1,2}|` + agendaOrdinalPattern + `)`)
+	// agendaOrdinalFirstPattern matches the ordinal-first variant: 'Przystępujemy do rozpatrzenia drugiego punktu'
+	agendaOrdinalFirstPattern = regexp.MustCompile(`(?i)(?:przechodzimy|przechodzę|przystępujemy|przystąpimy|przejdźmy|przejdziemy|powracamy|wracamy)\s+do\s+(?:\p{L}+\s+){0,2}?(` + agendaOrdinalPattern + `)\s+punktu`)
+	// agendaLineHeadingPattern matches item headings at the start of a line: 'Ad 2.' or 'Punkt 2. porządku dziennego'
+	agendaLineHeadingPattern = regexp.MustCompile(`(?im)^[ \t]*(?:ad\.?[ \t]*(\d{1,2})\b|(?:punkt|pkt\.?)[ \t]+(\d{1,2})\.?[ \t]+porządku[ \t]+(?:dziennego|obrad))`)
+	// agendaNumberedLinePattern matches a numbered entry of an agenda listing
+	agendaNumberedLinePattern = regexp.MustCompile(`^\s*(\d{1,2})[.)]\s+(.+)$`)
+	// htmlListItemPattern captures list items of an HTML agenda
+	htmlListItemPattern = regexp.MustCompile(`(?is)<li[^>]*>(.*?)</li>`)
+	// htmlBlockBreakPattern matches tags that end a line of text
+	htmlBlockBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</(?:p|div|h[1-6]|li|tr)>`)
+)
+
+// agendaOrdinals maps ordinal stems to agenda item numbers
+var agendaOrdinals = []struct {
+	stem   string
+	number int
+}{
+	{"pierwsz", 1}, {"drug", 2}, {"trzeci", 3}, {"czwart", 4}, {"piąt", 5}, {"szóst", 6},
+	{"siódm", 7}, {"ósm", 8}, {"dziewiąt", 9}, {"dziesiąt", 10}, {"jedenast", 11}, {"dwunast", 12},
+}
+
+// transcriptAgendaItem is the part of a committee transcript devoted to one agenda item.
+// Item 0 is the opening of the sitting, before the first item is taken up.
+type transcriptAgendaItem struct {
+	Number  int
+	Heading string
+	Text    string
+}
+
+// agendaMarker is a place in the transcript where an agenda item appears to start
+type agendaMarker struct {
+	number int
+	offset int
+}
+
+// agendaItemNumber parses a numeric or ordinal item reference
+func agendaItemNumber(value string) int {
+	if number, err := strconv.Atoi(value); err == nil {
+		return number
+	}
+	value = strings.ToLower(value)
+	for _, ordinal := range agendaOrdinals {
+		if strings.HasPrefix(value, ordinal.stem) {
+			return ordinal.number
+		}
+	}
+	return 0
+}
+
+// htmlToTextLines converts HTML to plain text, keeping paragraph and line breaks
+func htmlToTextLines(content string) string {
+	return normalizeExtractedText(htmlToPlainText(htmlBlockBreakPattern.ReplaceAllString(content, "\n")))
+}
+
+// parseCommitteeAgenda extracts the numbered items of a committee sitting agenda (HTML from the API).
+// List items are numbered in order; otherwise lines like '1. Rozpatrzenie ...' are used. An agenda
+// without numbering is treated as a single item.
+func parseCommitteeAgenda(agendaHTML string) map[int]string {
+	items := make(map[int]string)
+	if matches := htmlListItemPattern.FindAllStringSubmatch(agendaHTML, -1); len(matches) > 0 {
+		for i, match := range matches {
+			items[i+1] = strings.Join(strings.Fields(htmlToPlainText(match[1])), " ")
+		}
+		return items
+	}
+
+	text := htmlToTextLines(agendaHTML)
+	for _, line := range strings.Split(text, "\n") {
+		if match := agendaNumberedLinePattern.FindStringSubmatch(line); match != nil {
+			number, _ := strconv.Atoi(match[1])
+			if _, exists := items[number]; !exists {
+				items[number] = strings.TrimSpace(match[2])
+			}
+		}
+	}
+	if len(items) == 0 {
+		if agenda := strings.Join(strings.Fields(text), " "); agenda != "" {
+			items[1] = agenda
+		}
+	}
+	return items
+}
+
+// findAgendaMarkers returns candidate item starts in text order, each moved back to the start of its line
+func findAgendaMarkers(text string) []agendaMarker {
+	var markers []agendaMarker
+	add := func(value string, offset int) {
+		if number := agendaItemNumber(value); number > 0 {
+			lineStart := strings.LastIndex(text[:offset], "\n") + 1
+			markers = append(markers, agendaMarker{number: number, offset: lineStart})
+		}
+	}
+	for _, pattern := range []*regexp.Regexp{agendaTransitionPattern, agendaOrdinalFirstPattern} {
+		for _, match := range pattern.FindAllStringSubmatchIndex(text, -1) {
+			add(text[match[2]:match[3]], match[0])
+		}
+	}
+	for _, match := range agendaLineHeadingPattern.FindAllStringSubmatchIndex(text, -1) {
+		if match[2] >= 0 {
+			add(text[match[2]:match[3]], match[0])
+		} else {
+			add(text[match[4]:match[5]], match[0])
+		}
+	}
+	sort.SliceStable(markers, func(i, j int) bool {
+		return markers[i].offset < markers[j].offset
+	})
+	return markers
+}
+
+// splitTranscriptByAgenda splits a transcript at the places where the chair takes up successive
+// agenda items. Item numbers must increase; a marker that skips ahead is accepted only when no marker
+// for a skipped item follows, so stray references ('wrócimy do punktu 5') do not cut the text early.
+// The opening before the first detected item is returned as item 0 when it is not empty.
+func splitTranscriptByAgenda(text string, agenda map[int]string) []transcriptAgendaItem {
+	markers := findAgendaMarkers(text)
+
+	var accepted []agendaMarker
+	current := 0
+	for i, marker := range markers {
+		if marker.number <= current {
+			continue
+		}
+		if marker.number > current+1 {
+			skipped := false
+			for _, later := range markers[i+1:] {
+				if later.number > current && later.number < marker.number {
+					skipped = true
+					break
+				}
+			}
+			if skipped {
+				continue
+			}
+		}
+		if len(accepted) > 0 && accepted[len(accepted)-1].offset == marker.offset {
+			accepted[len(accepted)-1] = marker
+		} else {
+			accepted = append(accepted, marker)
+		}
+		current = marker.number
+	}
+
+	var items []transcriptAgendaItem
+	opening := text
+	if len(accepted) > 0 {
+		opening = text[:accepted[0].offset]
+	}
+	if strings.TrimSpace(opening) != "" {
+		items = append(items, transcriptAgendaItem{Number: 0, Heading: "Opening of the sitting and adoption of the agenda", Text: strings.TrimSpace(opening)})
+	}
+	for i, marker := range accepted {
+		end := len(text)
+		if i+1 < len(accepted) {
+			end = accepted[i+1].offset
+		}
+		itemText := strings.TrimSpace(text[marker.offset:end])
+		heading := agenda[marker.number]
+		if heading == "" {
+			heading = strings.Join(strings.Fields(strings.SplitN(itemText, "\n", 2)[0]), " ")
+		}
+		if len(heading) > agendaHeadingChars {
+			heading = truncateRunes(heading, agendaHeadingChars) + "…"
+		}
+		items = append(items, transcriptAgendaItem{Number: marker.number, Heading: heading, Text: itemText})
+	}
+	return items
+}
+
+// committeeTranscriptText returns the plain text of a committee sitting transcript, preferring HTML
+func (s *SejmServer) committeeTranscriptText(ctx context.Context, term int, committeeCode, sittingNumber string) (string, error) {
+	htmlEndpoint := fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings/%s/html", s.sejmBaseURL, term, committeeCode, sittingNumber)
+	htmlData, htmlErr := s.makeTextRequest(ctx, htmlEndpoint, "html")
+	if htmlErr == nil {
+		if text := htmlToTextLines(string(htmlData)); strings.TrimSpace(text) != "" {
+			return text, nil
+		}
+	}
+
+	pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings/%s/pdf", s.sejmBaseURL, term, committeeCode, sittingNumber)
+	pdfData, err := s.makeTextRequest(ctx, pdfEndpoint, "pdf")
+	if err != nil {
+		return "", fmt.Errorf("no HTML (%v) or PDF (%v) transcript available", htmlErr, err)
+	}
+	pageTexts, err := s.extractPDFPageTexts(ctx, pdfData)
+	if err != nil {
+		return "", err
+	}
+	return normalizeExtractedText(strings.Join(pageTexts, "\n")), nil
+}
+
+// committeeSittingAgenda fetches the numbered agenda of a committee sitting; it is optional and
+// an empty map is returned when the sitting details are unavailable
+func (s *SejmServer) committeeSittingAgenda(ctx context.Context, term int, committeeCode, sittingNumber string) map[int]string {
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings/%s", s.sejmBaseURL, term, committeeCode, sittingNumber), nil)
+	if err != nil {
+		return map[int]string{}
+	}
+	var sitting sejm.CommitteeSitting
+	if err := s.decodeAPIResponse(data, &sitting); err != nil || sitting.Agenda == nil {
+		return map[int]string{}
+	}
+	return parseCommitteeAgenda(*sitting.Agenda)
+}
+
+// handleCommitteeTranscriptAgendaItem implements agenda_item for sejm_get_committee_transcript:
+// 'list' shows the detected items, a number returns the discussion of that item with chunking
+func (s *SejmServer) handleCommitteeTranscriptAgendaItem(ctx context.Context, term int, committeeCode, sittingNumber, agendaItem, chunkSize, chunkNumber, showChunkInfo string) (*mcp.CallToolResult, error) {
+	text, err := s.committeeTranscriptText(ctx, term, committeeCode, sittingNumber)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve committee transcript: %v. This committee meeting may not have a transcript available.", err)), nil
+	}
+	agenda := s.committeeSittingAgenda(ctx, term, committeeCode, sittingNumber)
+	items := splitTranscriptByAgenda(text, agenda)
+
+	detected, firstItem := 0, 0
+	for _, item := range items {
+		if item.Number > 0 {
+			if detected == 0 {
+				firstItem = item.Number
+			}
+			detected++
+		}
+	}
+
+	if strings.EqualFold(agendaItem, "list") {
+		var data []string
+		for _, item := range items {
+			share := 0
+			if len(text) > 0 {
+				share = len(item.Text) * 100 / len(text)
+			}
+			label := fmt.Sprintf("pkt %d", item.Number)
+			if item.Number == 0 {
+				label = "Opening"
+			}
+			data = append(data, fmt.Sprintf("%s: %s (%d characters, %d%% of transcript)", label, item.Heading, len(item.Text), share))
+		}
+
+		var missing []string
+		for number := range agenda {
+			found := false
+			for _, item := range items {
+				found = found || item.Number == number
+			}
+			if !found {
+				missing = append(missing, strconv.Itoa(number))
+			}
+		}
+		sort.Slice(missing, func(i, j int) bool {
+			a, _ := strconv.Atoi(missing[i])
+			b, _ := strconv.Atoi(missing[j])
+			return a < b
+		})
+
+		summary := []string{
+			fmt.Sprintf("Committee: %s, sitting #%s (term %d)", committeeCode, sittingNumber, term),
+			fmt.Sprintf("Agenda items on the sitting agenda: %d", len(agenda)),
+			fmt.Sprintf("Agenda items detected in the transcript: %d", detected),
+		}
+		if len(missing) > 0 {
+			summary = append(summary, fmt.Sprintf("Not detected (possibly discussed jointly with another item): pkt %s", strings.Join(missing, ", ")))
+		}
+
+		nextActions := []string{"Read the whole transcript without agenda_item to check boundaries"}
+		if detected > 0 {
+			nextActions = append([]string{fmt.Sprintf("Read one item: sejm_get_committee_transcript with committee_code='%s', sitting_number='%s', agenda_item='%d'", committeeCode, sittingNumber, firstItem)}, nextActions...)
+		}
+		response := StandardResponse{
+			Operation:   "Committee Transcript Agenda Items",
+			Status:      "Retrieved Successfully",
+			Summary:     summary,
+			Data:        data,
+			NextActions: nextActions,
+			Note:        "Item boundaries are detected from the chair's announcements ('Przechodzimy do rozpatrzenia punktu 2', 'Ad 2.'). Items taken up jointly or announced unusually may be merged with a neighbouring item.",
+		}
+		return mcp.NewToolResultText(response.Format()), nil
+	}
+
+	number, err := strconv.Atoi(strings.TrimSpace(agendaItem))
+	if err != nil || number < 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid agenda_item '%s'. Use 'list' to see the detected items, or an item number such as '2' (0 for the opening of the sitting).", agendaItem)), nil
+	}
+	for _, item := range items {
+		if item.Number == number {
+			title := fmt.Sprintf("Committee %s Meeting #%s Transcript, agenda item %d: %s", committeeCode, sittingNumber, number, item.Heading)
+			return s.chunkHTMLContent(item.Text, title, chunkSize, chunkNumber, showChunkInfo)
+		}
+	}
+	if detected == 0 {
+		return mcp.NewToolResultError("No agenda item boundaries were detected in this transcript. Read it without agenda_item, page by page."), nil
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("Agenda item %d was not detected in this transcript. Use agenda_item='list' to see the detected items.", number)), nil
+}
+
+// proceedingAgenda renders the numbered agenda of a Sejm proceeding for sejm_get_proceedings. The list
+// usually carries the agenda; otherwise the proceeding details are fetched, and failures are recorded in coverage.
+func (s *SejmServer) proceedingAgenda(ctx context.Context, term int, proceeding sejm.Proceeding, coverage *sourceCoverage) string {
+	agenda := stringValue(proceeding.Agenda)
+	if agenda == "" && proceeding.Number != nil {
+		label := fmt.Sprintf("proceeding %d", *proceeding.Number)
+		data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/proceedings/%d", s.sejmBaseURL, term, *proceeding.Number), nil)
+		if err != nil {
+			coverage.fail(label, err)
+			return "  Agenda: unavailable\n"
+		}
+		var details sejm.Proceeding
+		if err := s.decodeAPIResponse(data, &details); err != nil {
+			coverage.fail(label, err)
+			return "  Agenda: unavailable\n"
+		}
+		coverage.succeeded()
+		agenda = stringValue(details.Agenda)
+	}
+
+	items := parseCommitteeAgenda(agenda)
+	if len(items) == 0 {
+		return "  Agenda: not published\n"
+	}
+	numbers := make([]int, 0, len(items))
+	for number := range items {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	result := "  Agenda:\n"
+	for _, number := range numbers {
+		result += fmt.Sprintf("    %d. %s\n", number, items[number])
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const testCommitteeTranscript = `Komisja Edukacji i Nauki, obradująca pod przewodnictwem posła Jana Kowalskiego, przewodniczącego Komisji, zrealizowała następujący porządek dzienny.
+Przewodniczący poseł Jan Kowalski (KO):
+Otwieram posiedzenie Komisji. Stwierdzam kworum. Punkt 3 rozpatrzymy na końcu, jeśli starczy czasu.
+Przechodzimy do rozpatrzenia punktu pierwszego porządku dziennego, czyli rządowego projektu ustawy o systemie oświaty.
+Poseł Anna Nowak (PiS):
+Mam pytanie do przedstawiciela ministerstwa.
+Przewodniczący poseł Jan Kowalski (KO):
+Przystępujemy do rozpatrzenia drugiego punktu, czyli informacji ministra.
+Sekretarz stanu Piotr Wiśniewski:
+Informacja została przedstawiona na piśmie.
+Ad 3.
+Przewodniczący poseł Jan Kowalski (KO):
+Sprawy bieżące. Zamykam posiedzenie Komisji.`
+
+func TestSplitTranscriptByAgenda(t *testing.T) {
+	agenda := map[int]string{1: "Rozpatrzenie rządowego projektu ustawy o systemie oświaty (druk nr 100)", 2: "Informacja ministra", 3: "Sprawy bieżące"}
+	items := splitTranscriptByAgenda(testCommitteeTranscript, agenda)
+
+	if len(items) != 4 {
+		t.Fatalf("Expected opening and 3 items, got %d: %+v", len(items), items)
+	}
+	for i, expected := range []int{0, 1, 2, 3} {
+		if items[i].Number != expected {
+			t.Errorf("Item %d has number %d, expected %d", i, items[i].Number, expected)
+		}
+	}
+	if !strings.Contains(items[0].Text, "Stwierdzam kworum") || strings.Contains(items[0].Text, "Przechodzimy") {
+		t.Errorf("Unexpected opening text: %q", items[0].Text)
+	}
+	if !strings.HasPrefix(items[1].Text, "Przechodzimy do rozpatrzenia punktu pierwszego") || !strings.Contains(items[1].Text, "Mam pytanie") {
+		t.Errorf("Unexpected item 1 text: %q", items[1].Text)
+	}
+	if items[2].Heading != "Informacja ministra" || !strings.Contains(items[2].Text, "na piśmie") {
+		t.Errorf("Unexpected item 2: %+v", items[2])
+	}
+	if !strings.HasPrefix(items[3].Text, "Ad 3.") || !strings.Contains(items[3].Text, "Zamykam posiedzenie") {
+		t.Errorf("Unexpected item 3 text: %q", items[3].Text)
+	}
+}
+
+func TestParseCommitteeAgenda(t *testing.T) {
+	listed := parseCommitteeAgenda(`<ol><li>Rozpatrzenie projektu ustawy (druk nr 100).</li><li>Sprawy <b>bieżące</b></li></ol>`)
+	if len(listed) != 2 || listed[2] != "Sprawy bieżące" {
+		t.Errorf("Unexpected list agenda: %#v", listed)
+	}
+
+	numbered := parseCommitteeAgenda(`1. Rozpatrzenie projektu ustawy.<br>2. Sprawy bieżące.`)
+	if len(numbered) != 2 || numbered[1] != "Rozpatrzenie projektu ustawy." {
+		t.Errorf("Unexpected numbered agenda: %#v", numbered)
+	}
+
+	single := parseCommitteeAgenda(`<p>Informacja Ministra Zdrowia.</p>`)
+	if len(single) != 1 || single[1] != "Informacja Ministra Zdrowia." {
+		t.Errorf("Unexpected single-item agenda: %#v", single)
+	}
+}
+
+func TestHandleGetCommitteeTranscriptAgendaItem(t *testing.T) {
+	html := "<html><body><p>" + strings.ReplaceAll(testCommitteeTranscript, "\n", "</p><p>") + "</p></body></html>"
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/committees/EKN/sittings/12":      `{"code":"EKN","num":12,"agenda":"<ol><li>Rozpatrzenie projektu ustawy o systemie oświaty</li><li>Informacja ministra</li><li>Sprawy bieżące</li><li>Wybór zastępcy przewodniczącego</li></ol>"}`,
+		"/sejm/term10/committees/EKN/sittings/12/html": html,
+	})
+
+	list, err := server.handleGetCommitteeTranscript(context.Background(), createMockRequest(map[string]interface{}{
+		"committee_code": "EKN", "sitting_number": "12", "agenda_item": "list",
+	}))
+	if err != nil || list.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(list))
+	}
+	listText := extractTextContent(list)
+	for _, expected := range []string{
+		"Committee Transcript Agenda Items - Retrieved Successfully",
+		"Agenda items detected in the transcript: 3",
+		"pkt 2: Informacja ministra",
+		"Not detected (possibly discussed jointly with another item): pkt 4",
+	} {
+		if !strings.Contains(listText, expected) {
+			t.Errorf("Expected %q in list output, got:\n%s", expected, listText)
+		}
+	}
+
+	item, err := server.handleGetCommitteeTranscript(context.Background(), createMockRequest(map[string]interface{}{
+		"committee_code": "EKN", "sitting_number": "12", "agenda_item": "2",
+	}))
+	if err != nil || item.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(item))
+	}
+	itemText := extractTextContent(item)
+	if !strings.Contains(itemText, "agenda item 2: Informacja ministra") || !strings.Contains(itemText, "na piśmie") || strings.Contains(itemText, "Mam pytanie") {
+		t.Errorf("Unexpected agenda item output:\n%s", itemText)
+	}
+
+	missing, _ := server.handleGetCommitteeTranscript(context.Background(), createMockRequest(map[string]interface{}{
+		"committee_code": "EKN", "sitting_number": "12", "agenda_item": "4",
+	}))
+	if !missing.IsError {
+		t.Errorf("Expected an error for an undetected item, got:\n%s", extractTextContent(missing))
+	}
+}
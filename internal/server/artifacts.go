@@ -0,0 +1,186 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// saveToFileParamDescription documents the save_to_file parameter added to tools with large outputs
+const saveToFileParamDescription = "Optional. Set to 'true' to write the output to a file in the server's output directory and return its path and file:// link instead of the content. Saving the same call again overwrites the file. Useful for building local corpora."
+
+// maxArtifactNameLength bounds the file names built from tool arguments
+const maxArtifactNameLength = 150
+
+// artifactTools lists tools with large outputs that accept save_to_file='true' when an output directory is configured
+var artifactTools = map[string]bool{
+	"eli_get_act_text":                 true,
+	"eli_get_act_file":                 true,
+	"sejm_get_print_text":              true,
+	"sejm_get_print_attachment":        true,
+	"sejm_get_transcripts":             true,
+	"sejm_get_statement":               true,
+	"sejm_get_committee_transcript":    true,
+	"sejm_export_mps":                  true,
+	"sejm_get_mp_interpellation_texts": true,
+	"sejm_get_mp_declaration_text":     true,
+	"sejm_get_committee_document_text": true,
+}
+
+// artifactMetaArguments are the arguments that do not change what a tool returns and are left out of file names
+var artifactMetaArguments = map[string]bool{
+	"language":         true,
+	"max_output_chars": true,
+	"async":            true,
+	"save_to_file":     true,
+	"debug":            true,
+	"return_content":   true,
+	"max_size_bytes":   true,
+}
+
+var unsafeArtifactNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// artifactFileName builds a stable file name from the tool name and its arguments, sorted by name, so that the
+// same call is always saved to the same file. The readable part replaces unsafe characters and is cut to
+// maxArtifactNameLength, so a short hash of the full argument list is appended to keep different calls apart.
+func artifactFileName(tool string, arguments map[string]string, ext string) string {
+	names := make([]string, 0, len(arguments))
+	for name := range arguments {
+		if !artifactMetaArguments[name] && arguments[name] != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	parts := []string{tool}
+	hash := sha256.New()
+	hash.Write([]byte(tool))
+	for _, name := range names {
+		parts = append(parts, name+"-"+arguments[name])
+		// Names and values are NUL-separated, so no two argument lists hash the same input
+		fmt.Fprintf(hash, "\x00%s\x00%s", name, arguments[name])
+	}
+	base := strings.Trim(unsafeArtifactNameChars.ReplaceAllString(strings.Join(parts, "_"), "-"), "-.")
+	if len(base) > maxArtifactNameLength {
+		base = base[:maxArtifactNameLength]
+	}
+	return fmt.Sprintf("%s_%x%s", base, hash.Sum(nil)[:4], ext)
+}
+
+// artifactTextExtension picks the file extension of a text output from the requested format
+func artifactTextExtension(arguments map[string]string) (string, string) {
+	switch strings.ToLower(arguments["format"]) {
+	case "json":
+		return ".json", "application/json"
+	case "csv":
+		return ".csv", "text/csv"
+	}
+	return ".txt", "text/plain"
+}
+
+// artifactBlobExtension picks the file extension of an embedded file from its URI or MIME type
+func artifactBlobExtension(uri, mimeType string) string {
+	if ext := path.Ext(strings.SplitN(uri, "?", 2)[0]); ext != "" && len(ext) <= 6 {
+		return strings.ToLower(ext)
+	}
+	if extensions, err := mime.ExtensionsByType(mimeType); err == nil && len(extensions) > 0 {
+		return extensions[0]
+	}
+	return ".bin"
+}
+
+// writeArtifact writes a file to the output directory through a temporary file, so readers never see a partial file.
+// Every write gets its own temporary file, so concurrent saves of the same call do not interfere; the last one wins.
+func (s *SejmServer) writeArtifact(name string, data []byte) (string, error) {
+	if err := os.MkdirAll(s.config.OutputDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	file, err := filepath.Abs(filepath.Join(s.config.OutputDir, name))
+	if err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), file)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return file, nil
+}
+
+// saveArtifact writes the output of a tool call to the output directory and returns a result that points to the
+// saved files instead of carrying the content. The text of the result is saved as one file and every embedded file
+// (e.g. a print attachment returned with return_content='blob') as another. Error results are returned unchanged.
+func (s *SejmServer) saveArtifact(tool string, request mcp.CallToolRequest, result *mcp.CallToolResult) *mcp.CallToolResult {
+	if result == nil || result.IsError {
+		return result
+	}
+	arguments := jobArguments(request)
+
+	var texts []string
+	var lines []string
+	var links []mcp.Content
+	save := func(name, mimeType, description string, data []byte) bool {
+		file, err := s.writeArtifact(name, data)
+		if err != nil {
+			s.logger.Warn("Failed to save tool output", slog.String("tool", tool), slog.Any("error", err))
+			lines = append(lines, fmt.Sprintf("• Not saved (%s): %v", name, err))
+			return false
+		}
+		s.logger.Info("Tool output saved", slog.String("tool", tool), slog.String("file", file), slog.Int("bytes", len(data)))
+		lines = append(lines, fmt.Sprintf("• %s (%d bytes, %s)", file, len(data), mimeType))
+		links = append(links, mcp.NewResourceLink("file://"+filepath.ToSlash(file), filepath.Base(file), description, mimeType))
+		return true
+	}
+
+	for _, content := range result.Content {
+		switch c := content.(type) {
+		case mcp.TextContent:
+			texts = append(texts, c.Text)
+		case mcp.EmbeddedResource:
+			switch resource := c.Resource.(type) {
+			case mcp.BlobResourceContents:
+				data, err := base64.StdEncoding.DecodeString(resource.Blob)
+				if err != nil {
+					lines = append(lines, fmt.Sprintf("• Not saved (%s): invalid embedded file", resource.URI))
+					continue
+				}
+				ext := artifactBlobExtension(resource.URI, resource.MIMEType)
+				save(artifactFileName(tool, arguments, "_file"+ext), resource.MIMEType, "File embedded in the "+tool+" output", data)
+			case mcp.TextResourceContents:
+				texts = append(texts, resource.Text)
+			}
+		}
+	}
+	if len(texts) > 0 {
+		ext, mimeType := artifactTextExtension(arguments)
+		save(artifactFileName(tool, arguments, ext), mimeType, "Output of "+tool, []byte(strings.Join(texts, "\n\n")))
+	}
+	if len(lines) == 0 {
+		return result
+	}
+
+	message := fmt.Sprintf("Output of %s saved to files instead of being returned:\n%s", tool, strings.Join(lines, "\n"))
+	return &mcp.CallToolResult{Content: append([]mcp.Content{mcp.NewTextContent(message)}, links...)}
+}
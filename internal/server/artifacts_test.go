@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestArtifactFileName(t *testing.T) {
+	name := artifactFileName("eli_get_act_text", map[string]string{
+		"publisher": "DU", "year": "2024", "position": "17", "format": "text", "language": "pl", "save_to_file": "true", "debug": "true",
+	}, ".txt")
+	if name != "eli_get_act_text_format-text_position-17_publisher-DU_year-2024_6c8360a3.txt" {
+		t.Errorf("Unexpected file name: %s", name)
+	}
+	if name := artifactFileName("sejm_get_print_attachment", map[string]string{"attach_name": "../../etc/passwd"}, ".pdf"); strings.Contains(name, "/") {
+		t.Errorf("File name must not contain path separators: %s", name)
+	}
+
+	// Arguments that read the same once made safe, or share a prefix longer than the name, get different files
+	if artifactFileName("sejm_search_prints", map[string]string{"query": "a b"}, ".txt") == artifactFileName("sejm_search_prints", map[string]string{"query": "a/b"}, ".txt") {
+		t.Error("Expected arguments differing only in unsafe characters to be saved to different files")
+	}
+	prefix := strings.Repeat("ustawa o zmianie ustawy ", 10)
+	first := artifactFileName("eli_get_act_text", map[string]string{"query": prefix + "o podatku"}, ".txt")
+	second := artifactFileName("eli_get_act_text", map[string]string{"query": prefix + "o drogach"}, ".txt")
+	if first == second || len(first) > maxArtifactNameLength+len("_12345678.txt") {
+		t.Errorf("Expected long arguments sharing a prefix to be saved to different, bounded files, got %s and %s", first, second)
+	}
+}
+
+func TestSaveArtifact(t *testing.T) {
+	server := NewSejmServerWithConfig(Config{OutputDir: t.TempDir()})
+	request := createMockRequest(map[string]interface{}{"term": "10", "num": "123", "attach_name": "druk.pdf"})
+	pdf := []byte("%PDF-1.4 test")
+	result := &mcp.CallToolResult{Content: []mcp.Content{
+		mcp.NewTextContent("Print 123 attachment"),
+		mcp.NewEmbeddedResource(mcp.BlobResourceContents{URI: "sejm://term10/prints/123/druk.pdf", MIMEType: "application/pdf", Blob: base64.StdEncoding.EncodeToString(pdf)}),
+	}}
+
+	saved := server.saveArtifact("sejm_get_print_attachment", request, result)
+	content := extractTextContent(saved)
+	if !strings.Contains(content, "saved to files") || len(saved.Content) != 3 {
+		t.Fatalf("Expected a message and two resource links, got %d items: %s", len(saved.Content), content)
+	}
+	text, err := os.ReadFile(filepath.Join(server.config.OutputDir, "sejm_get_print_attachment_attach_name-druk.pdf_num-123_term-10_a94e09b0.txt"))
+	if err != nil || string(text) != "Print 123 attachment" {
+		t.Errorf("Unexpected saved text %q: %v", text, err)
+	}
+	file, err := os.ReadFile(filepath.Join(server.config.OutputDir, "sejm_get_print_attachment_attach_name-druk.pdf_num-123_term-10_a94e09b0_file.pdf"))
+	if err != nil || string(file) != string(pdf) {
+		t.Errorf("Unexpected saved attachment %q: %v", file, err)
+	}
+	link, ok := saved.Content[1].(mcp.ResourceLink)
+	if !ok || !strings.HasPrefix(link.URI, "file://") || link.MIMEType != "application/pdf" {
+		t.Errorf("Unexpected resource link: %+v", saved.Content[1])
+	}
+
+	failed := mcp.NewToolResultError("Print not found")
+	if server.saveArtifact("sejm_get_print_attachment", request, failed) != failed {
+		t.Error("Error results must not be saved")
+	}
+}
+
+func TestWriteArtifactConcurrently(t *testing.T) {
+	server := NewSejmServerWithConfig(Config{OutputDir: t.TempDir()})
+	var wg sync.WaitGroup
+	errs := make([]error, 16)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = server.writeArtifact("same.txt", []byte(strings.Repeat(string(rune('a'+i)), 1<<20)))
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Save %d failed: %v", i, err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(server.config.OutputDir, "same.txt"))
+	if err != nil || len(data) != 1<<20 || strings.Count(string(data), string(data[:1])) != len(data) {
+		t.Errorf("Expected the complete content of one save, got %d bytes: %v", len(data), err)
+	}
+	entries, _ := os.ReadDir(server.config.OutputDir)
+	if len(entries) != 1 {
+		t.Errorf("Expected no temporary files left, got %d entries", len(entries))
+	}
+}
+
+func TestSaveToFileToolCall(t *testing.T) {
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sejm/term10/MP" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":1,"firstLastName":"Jan Kowalski","club":"KO","active":true}]`))
+	}))
+	t.Cleanup(mirror.Close)
+	dir := t.TempDir()
+	server := NewSejmServerWithConfig(Config{SejmBaseURL: mirror.URL, OutputDir: dir})
+
+	message := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"sejm_export_mps","arguments":{"term":"10","format":"csv","save_to_file":"true"}}}`
+	encoded, err := json.Marshal(server.server.HandleMessage(context.Background(), json.RawMessage(message)))
+	if err != nil {
+		t.Fatalf("Failed to encode response: %v", err)
+	}
+	if strings.Contains(string(encoded), "Jan Kowalski") || !strings.Contains(string(encoded), "sejm_export_mps_format-csv_term-10_104cf419.csv") {
+		t.Fatalf("Expected a link to the saved export instead of its content, got: %s", encoded)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "sejm_export_mps_format-csv_term-10_104cf419.csv"))
+	if err != nil || !strings.Contains(string(data), "Jan Kowalski") {
+		t.Errorf("Unexpected saved export %q: %v", data, err)
+	}
+
+	// Without an output directory the parameter is not offered
+	plain := NewSejmServer()
+	encoded, _ = json.Marshal(plain.server.HandleMessage(context.Background(), json.RawMessage(`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)))
+	if strings.Contains(string(encoded), "save_to_file") {
+		t.Error("save_to_file must not be offered without -output-dir")
+	}
+}
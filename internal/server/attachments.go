@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Attachment delivery modes for the return_content parameter
+const (
+	attachmentDeliveryNone     = "none"
+	attachmentDeliveryBlob     = "blob"
+	attachmentDeliveryResource = "resource"
+)
+
+// defaultAttachmentMaxBytes is the largest file returned as content when max_size_bytes is not given
+const defaultAttachmentMaxBytes = 5 * 1024 * 1024
+
+// maxAttachmentMaxBytes is the upper bound accepted for max_size_bytes
+const maxAttachmentMaxBytes = 20 * 1024 * 1024
+
+// attachmentResourceScheme is the URI scheme of attachments registered as MCP resources
+const attachmentResourceScheme = "sejm://"
+
+// parseAttachmentDelivery validates return_content and max_size_bytes parameters
+func parseAttachmentDelivery(mode, maxSizeStr string) (string, int, error) {
+	mode = strings.ToLower(strings.TrimSpace(mode))
+	switch mode {
+	case "":
+		mode = attachmentDeliveryNone
+	case attachmentDeliveryNone, attachmentDeliveryBlob, attachmentDeliveryResource:
+	default:
+		return "", 0, fmt.Errorf("invalid return_content '%s': use 'none', 'blob' or 'resource'", mode)
+	}
+
+	maxSize := defaultAttachmentMaxBytes
+	if maxSizeStr != "" {
+		parsed, err := strconv.Atoi(maxSizeStr)
+		if err != nil || parsed < 1 {
+			return "", 0, fmt.Errorf("invalid max_size_bytes '%s': must be a positive number of bytes", maxSizeStr)
+		}
+		maxSize = parsed
+		if maxSize > maxAttachmentMaxBytes {
+			maxSize = maxAttachmentMaxBytes
+		}
+	}
+	return mode, maxSize, nil
+}
+
+// attachmentMIMEType determines the MIME type of an attachment from its content and file name
+func attachmentMIMEType(name string, data []byte) string {
+	switch detectDocumentFormat(name, data) {
+	case documentFormatPDF:
+		return "application/pdf"
+	case documentFormatDOCX:
+		return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	case documentFormatODT:
+		return "application/vnd.oasis.opendocument.text"
+	case documentFormatRTF:
+		return "application/rtf"
+	}
+	if mimeType := mime.TypeByExtension(strings.ToLower(path.Ext(name))); mimeType != "" {
+		return mimeType
+	}
+	return http.DetectContentType(data)
+}
+
+// deliverAttachment builds the extra result content for a downloaded attachment according to the delivery mode.
+// It returns the content to append to the tool result and a summary line describing what was returned.
+// Resources are registered with a handler that downloads the file again (served from the HTTP cache)
+// so attachment bytes are not kept in memory.
+func (s *SejmServer) deliverAttachment(mode string, maxSize int, uri, name, endpoint string, data []byte) ([]mcp.Content, string) {
+	if mode == attachmentDeliveryNone {
+		return nil, "File content not included (use return_content='blob' or 'resource' to receive the file)"
+	}
+	if len(data) > maxSize {
+		return nil, fmt.Sprintf("File content not included: %d bytes exceeds max_size_bytes=%d", len(data), maxSize)
+	}
+
+	mimeType := attachmentMIMEType(name, data)
+	if mode == attachmentDeliveryBlob {
+		blob := mcp.BlobResourceContents{
+			URI:      uri,
+			MIMEType: mimeType,
+			Blob:     base64.StdEncoding.EncodeToString(data),
+		}
+		return []mcp.Content{mcp.NewEmbeddedResource(blob)}, fmt.Sprintf("File content embedded as base64 blob (%s)", mimeType)
+	}
+
+	s.server.AddResource(
+		mcp.NewResource(uri, name,
+			mcp.WithResourceDescription(fmt.Sprintf("Attachment %s (%d bytes)", name, len(data))),
+			mcp.WithMIMEType(mimeType)),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			s.logger.Info("Attachment resource read", slog.String("uri", request.Params.URI))
+			content, err := s.makeAPIRequestWithHeaders(ctx, endpoint, nil, map[string]string{"Accept": "*/*"})
+			if err != nil {
+				return nil, fmt.Errorf("failed to download attachment: %w", err)
+			}
+			return []mcp.ResourceContents{mcp.BlobResourceContents{
+				URI:      request.Params.URI,
+				MIMEType: mimeType,
+				Blob:     base64.StdEncoding.EncodeToString(content),
+			}}, nil
+		})
+
+	link := mcp.NewResourceLink(uri, name, fmt.Sprintf("Attachment %s (%d bytes)", name, len(data)), mimeType)
+	return []mcp.Content{link}, fmt.Sprintf("File registered as MCP resource %s (%s)", uri, mimeType)
+}
+
+// documentFileResult returns the text of a downloaded document, paginated like other documents, followed by the
+// file itself as requested with return_content. A document without extractable text (e.g. a scan) is an error
+// ending with noTextHint, unless the file itself is returned.
+func (s *SejmServer) documentFileResult(ctx context.Context, request mcp.CallToolRequest, toolName, name, uri, endpoint string, data []byte, noTextHint string) (*mcp.CallToolResult, error) {
+	deliveryMode, maxSize, err := parseAttachmentDelivery(request.GetString("return_content", ""), request.GetString("max_size_bytes", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var extra []mcp.Content
+	if deliveryMode != attachmentDeliveryNone {
+		extra, _ = s.deliverAttachment(deliveryMode, maxSize, uri, name, endpoint, data)
+	}
+
+	text, _, err := s.extractAttachmentText(ctx, name, data)
+	if err != nil || strings.TrimSpace(text) == "" {
+		message := fmt.Sprintf("The document %s (%d bytes) has no extractable text%s.", name, len(data), noTextHint)
+		if deliveryMode == attachmentDeliveryNone {
+			message += " Use return_content='blob' to receive the file itself."
+		}
+		if len(extra) > 0 {
+			return &mcp.CallToolResult{Content: append([]mcp.Content{mcp.NewTextContent(message)}, extra...)}, nil
+		}
+		return mcp.NewToolResultError(message), nil
+	}
+
+	result, err := s.documentTextWithPagination(ctx, text, name, toolName,
+		request.GetString("page", ""), request.GetString("pages_per_chunk", ""), request.GetString("show_page_info", "false"))
+	if err != nil || result.IsError {
+		return result, err
+	}
+	result.Content = append(result.Content, extra...)
+	return result, nil
+}
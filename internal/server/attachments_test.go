@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestParseAttachmentDelivery(t *testing.T) {
+	mode, maxSize, err := parseAttachmentDelivery("", "")
+	if err != nil || mode != attachmentDeliveryNone || maxSize != defaultAttachmentMaxBytes {
+		t.Errorf("Expected defaults, got mode=%s maxSize=%d err=%v", mode, maxSize, err)
+	}
+
+	mode, maxSize, err = parseAttachmentDelivery("Blob", "999999999")
+	if err != nil || mode != attachmentDeliveryBlob || maxSize != maxAttachmentMaxBytes {
+		t.Errorf("Expected blob mode with clamped size, got mode=%s maxSize=%d err=%v", mode, maxSize, err)
+	}
+
+	if _, _, err := parseAttachmentDelivery("stream", ""); err == nil {
+		t.Error("Expected error for unknown delivery mode")
+	}
+	if _, _, err := parseAttachmentDelivery("blob", "0"); err == nil {
+		t.Error("Expected error for non-positive max size")
+	}
+}
+
+func TestAttachmentMIMEType(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{"druk.pdf", []byte("%PDF-1.4"), "application/pdf"},
+		{"pismo.rtf", []byte(`{\rtf1}`), "application/rtf"},
+		{"wykres.png", []byte{0x89, 'P', 'N', 'G'}, "image/png"},
+		{"plik", []byte{0xFF, 0xD8, 0xFF, 0xE0}, "image/jpeg"},
+	}
+
+	for _, tc := range testCases {
+		if mimeType := attachmentMIMEType(tc.name, tc.data); mimeType != tc.expected {
+			t.Errorf("Expected MIME type '%s' for %s, got '%s'", tc.expected, tc.name, mimeType)
+		}
+	}
+}
+
+func TestHandleGetPrintAttachmentContent(t *testing.T) {
+	fileData := "%PDF-1.4 test content"
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/prints/300/300.pdf": fileData,
+	})
+
+	call := func(extra map[string]interface{}) *mcp.CallToolResult {
+		args := map[string]interface{}{"term": "10", "num": "300", "attach_name": "300.pdf"}
+		for k, v := range extra {
+			args[k] = v
+		}
+		result, err := server.handleGetPrintAttachment(context.Background(), createMockRequest(args))
+		if err != nil || result.IsError {
+			t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+		}
+		return result
+	}
+
+	t.Run("metadata only by default", func(t *testing.T) {
+		if result := call(nil); len(result.Content) != 1 {
+			t.Errorf("Expected only text content, got %d items", len(result.Content))
+		}
+	})
+
+	t.Run("blob", func(t *testing.T) {
+		result := call(map[string]interface{}{"return_content": "blob"})
+		if len(result.Content) != 2 {
+			t.Fatalf("Expected text and blob content, got %d items", len(result.Content))
+		}
+		embedded, ok := mcp.AsEmbeddedResource(result.Content[1])
+		if !ok {
+			t.Fatalf("Expected embedded resource, got %T", result.Content[1])
+		}
+		blob, ok := mcp.AsBlobResourceContents(embedded.Resource)
+		if !ok || blob.MIMEType != "application/pdf" || blob.Blob != base64.StdEncoding.EncodeToString([]byte(fileData)) {
+			t.Errorf("Unexpected blob content: %+v", embedded.Resource)
+		}
+	})
+
+	t.Run("size limit", func(t *testing.T) {
+		result := call(map[string]interface{}{"return_content": "blob", "max_size_bytes": "5"})
+		if len(result.Content) != 1 || !strings.Contains(extractTextContent(result), "exceeds max_size_bytes=5") {
+			t.Errorf("Expected size limit notice without content, got: %s", extractTextContent(result))
+		}
+	})
+
+	t.Run("resource", func(t *testing.T) {
+		result := call(map[string]interface{}{"return_content": "resource"})
+		if len(result.Content) != 2 {
+			t.Fatalf("Expected text and resource link, got %d items", len(result.Content))
+		}
+		link, ok := result.Content[1].(mcp.ResourceLink)
+		if !ok || link.URI != "sejm://term10/prints/300/300.pdf" {
+			t.Fatalf("Unexpected resource link: %+v", result.Content[1])
+		}
+
+		message := `{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":"` + link.URI + `"}}`
+		response := server.server.HandleMessage(context.Background(), json.RawMessage(message))
+		encoded, err := json.Marshal(response)
+		if err != nil {
+			t.Fatalf("Failed to encode response: %v", err)
+		}
+		if !strings.Contains(string(encoded), base64.StdEncoding.EncodeToString([]byte(fileData))) {
+			t.Errorf("Expected resource read to return file content, got: %s", encoded)
+		}
+	})
+}
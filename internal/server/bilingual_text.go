@@ -0,0 +1,222 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Values of the 'columns' parameter of eli_get_act_text
+const (
+	actColumnsAll     = "all"
+	actColumnsPolish  = "polish"
+	actColumnsForeign = "foreign"
+	actColumnsSplit   = "split"
+)
+
+const (
+	// pdfColumnMinLines is the number of lines a page side needs before it is treated as a separate column
+	pdfColumnMinLines = 5
+	// pdfColumnMinShare is the share of page lines that must start in the right half for a two-column layout
+	pdfColumnMinShare = 0.2
+	// pdfSameLineTolerance is the vertical distance, in points, within which fragments belong to one line
+	pdfSameLineTolerance = 2.0
+)
+
+var (
+	// pdfPageWidthPattern captures the page width from the page element of MuPDF's HTML output
+	pdfPageWidthPattern = regexp.MustCompile(`<div[^>]*\bstyle="[^"]*width:\s*([\d.]+)pt`)
+	// pdfLinePattern captures positioned lines of MuPDF's HTML output
+	pdfLinePattern = regexp.MustCompile(`(?s)<p\b[^>]*\bstyle="([^"]*)"[^>]*>(.*?)</p>`)
+	// pdfTopPattern and pdfLeftPattern read a line position from its style attribute
+	pdfTopPattern  = regexp.MustCompile(`top:\s*(-?[\d.]+)pt`)
+	pdfLeftPattern = regexp.MustCompile(`left:\s*(-?[\d.]+)pt`)
+)
+
+// polishStopwords are frequent short Polish words; they are rare as separate words in other languages of
+// international agreements
+var polishStopwords = map[string]bool{
+	"i": true, "w": true, "z": true, "na": true, "się": true, "nie": true, "jest": true, "oraz": true,
+	"lub": true, "przez": true, "dla": true, "od": true, "po": true, "że": true, "ze": true, "do": true,
+	"art": true, "ust": true, "który": true, "która": true, "które": true,
+}
+
+// pdfTextLine is a line of PDF text with its position on the page, in points
+type pdfTextLine struct {
+	top  float64
+	left float64
+	text string
+}
+
+// pdfTextColumn is the text of one column of a page and whether it reads as Polish
+type pdfTextColumn struct {
+	text   string
+	polish bool
+}
+
+// validActColumns checks the 'columns' parameter
+func validActColumns(columns string) error {
+	switch columns {
+	case actColumnsAll, actColumnsPolish, actColumnsForeign, actColumnsSplit:
+		return nil
+	}
+	return fmt.Errorf("invalid columns '%s'. Use 'all', 'polish', 'foreign' or 'split'", columns)
+}
+
+// parsePDFPageLines reads the positioned lines and the page width from MuPDF's HTML rendering of a page
+func parsePDFPageLines(pageHTML string) ([]pdfTextLine, float64) {
+	var width float64
+	if match := pdfPageWidthPattern.FindStringSubmatch(pageHTML); match != nil {
+		width, _ = strconv.ParseFloat(match[1], 64)
+	}
+	var lines []pdfTextLine
+	for _, match := range pdfLinePattern.FindAllStringSubmatch(pageHTML, -1) {
+		top := pdfTopPattern.FindStringSubmatch(match[1])
+		left := pdfLeftPattern.FindStringSubmatch(match[1])
+		text := strings.TrimSpace(htmlToPlainText(match[2]))
+		if top == nil || left == nil || text == "" {
+			continue
+		}
+		line := pdfTextLine{text: strings.Join(strings.Fields(text), " ")}
+		line.top, _ = strconv.ParseFloat(top[1], 64)
+		line.left, _ = strconv.ParseFloat(left[1], 64)
+		lines = append(lines, line)
+	}
+	return lines, width
+}
+
+// splitPDFColumns divides page lines into a left and a right column when enough lines start in the right half of
+// the page; otherwise the page is returned as a single column. The right column starts at the leftmost line
+// beginning past 40% of the page width, so indented lines of the left column stay in it.
+func splitPDFColumns(lines []pdfTextLine, width float64) [][]pdfTextLine {
+	if width <= 0 || len(lines) < 2*pdfColumnMinLines {
+		return [][]pdfTextLine{lines}
+	}
+	rightStart := math.Inf(1)
+	for _, line := range lines {
+		if line.left >= width*0.4 && line.left < rightStart {
+			rightStart = line.left
+		}
+	}
+	var left, right []pdfTextLine
+	for _, line := range lines {
+		if line.left >= rightStart {
+			right = append(right, line)
+		} else {
+			left = append(left, line)
+		}
+	}
+	if len(left) < pdfColumnMinLines || len(right) < pdfColumnMinLines || float64(len(right)) < float64(len(lines))*pdfColumnMinShare {
+		return [][]pdfTextLine{lines}
+	}
+	return [][]pdfTextLine{left, right}
+}
+
+// joinPDFLines orders lines top to bottom and left to right, joining fragments that share a baseline
+func joinPDFLines(lines []pdfTextLine) string {
+	sorted := append([]pdfTextLine(nil), lines...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if math.Abs(sorted[i].top-sorted[j].top) > pdfSameLineTolerance {
+			return sorted[i].top < sorted[j].top
+		}
+		return sorted[i].left < sorted[j].left
+	})
+	var out []string
+	lastTop := math.Inf(-1)
+	for _, line := range sorted {
+		if len(out) > 0 && math.Abs(line.top-lastTop) <= pdfSameLineTolerance {
+			out[len(out)-1] += " " + line.text
+			continue
+		}
+		out = append(out, line.text)
+		lastTop = line.top
+	}
+	return strings.Join(out, "\n")
+}
+
+// polishTextScore rates how Polish a text looks from the share of Polish diacritics among letters and the share
+// of Polish stopwords among words
+func polishTextScore(text string) float64 {
+	letters, diacritics := 0, 0
+	for _, r := range strings.ToLower(text) {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if strings.ContainsRune("ąćęłńóśźż", r) {
+			diacritics++
+		}
+	}
+	words, stopwords := 0, 0
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) }) {
+		words++
+		if polishStopwords[word] {
+			stopwords++
+		}
+	}
+	if letters == 0 || words == 0 {
+		return 0
+	}
+	return float64(diacritics)/float64(letters)*5 + float64(stopwords)/float64(words)
+}
+
+// looksPolish tells whether a single text reads as Polish; Polish legal text scores well above 0.3
+func looksPolish(text string) bool {
+	return polishTextScore(text) >= 0.15
+}
+
+// pageColumns splits the HTML rendering of a PDF page into its columns and marks the Polish one. With two
+// columns the one scoring higher is Polish, provided it looks Polish at all; a single column is classified alone.
+func pageColumns(pageHTML string) []pdfTextColumn {
+	lines, width := parsePDFPageLines(pageHTML)
+	if len(lines) == 0 {
+		return nil
+	}
+	groups := splitPDFColumns(lines, width)
+	columns := make([]pdfTextColumn, len(groups))
+	for i, group := range groups {
+		columns[i].text = joinPDFLines(group)
+	}
+	if len(columns) == 1 {
+		columns[0].polish = looksPolish(columns[0].text)
+		return columns
+	}
+	polishIndex := 0
+	if polishTextScore(columns[1].text) > polishTextScore(columns[0].text) {
+		polishIndex = 1
+	}
+	columns[polishIndex].polish = looksPolish(columns[polishIndex].text)
+	return columns
+}
+
+// selectPageColumns returns the text of a page for the given columns mode; pages without the requested
+// language yield an empty string
+func selectPageColumns(pageHTML, mode string) string {
+	columns := pageColumns(pageHTML)
+	var polish, foreign []string
+	for _, column := range columns {
+		if column.polish {
+			polish = append(polish, column.text)
+		} else {
+			foreign = append(foreign, column.text)
+		}
+	}
+	switch mode {
+	case actColumnsPolish:
+		return strings.Join(polish, "\n\n")
+	case actColumnsForeign:
+		return strings.Join(foreign, "\n\n")
+	}
+	var parts []string
+	if len(polish) > 0 {
+		parts = append(parts, "[Polish text]\n"+strings.Join(polish, "\n\n"))
+	}
+	if len(foreign) > 0 {
+		parts = append(parts, "[Foreign-language text]\n"+strings.Join(foreign, "\n\n"))
+	}
+	return strings.Join(parts, "\n\n")
+}
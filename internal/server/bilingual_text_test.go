@@ -0,0 +1,87 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// mupdfPage renders lines the way MuPDF's HTML output positions them: one <p> per line
+func mupdfPage(lines []pdfTextLine) string {
+	var b strings.Builder
+	b.WriteString(`<div id="page0" style="width:595.3pt;height:841.9pt">` + "\n")
+	for _, line := range lines {
+		fmt.Fprintf(&b, `<p style="top:%.1fpt;left:%.1fpt;line-height:10.0pt"><span style="font-family:Times,serif;font-size:10.0pt">%s</span></p>`+"\n", line.top, line.left, line.text)
+	}
+	b.WriteString("</div>\n")
+	return b.String()
+}
+
+func bilingualFixture() string {
+	polish := []string{
+		"Artykuł 1",
+		"Umawiające się Strony zobowiązują się",
+		"do współpracy w dziedzinie ochrony",
+		"środowiska oraz wymiany informacji",
+		"na zasadach określonych w niniejszej",
+		"Umowie i przepisach prawa krajowego.",
+	}
+	english := []string{
+		"Article 1",
+		"The Contracting Parties undertake",
+		"to cooperate in the field of",
+		"environmental protection and the",
+		"exchange of information on the terms",
+		"laid down in this Agreement and law.",
+	}
+	var lines []pdfTextLine
+	for i := range polish {
+		top := 80 + float64(i)*12
+		// The right column is listed first to check that lines are ordered by position, not by markup
+		lines = append(lines, pdfTextLine{top: top, left: 310, text: english[i]})
+		lines = append(lines, pdfTextLine{top: top, left: 56.7 + float64(i%2)*10, text: polish[i]})
+	}
+	return mupdfPage(lines)
+}
+
+func TestSelectPageColumnsBilingual(t *testing.T) {
+	page := bilingualFixture()
+
+	polish := selectPageColumns(page, actColumnsPolish)
+	if !strings.HasPrefix(polish, "Artykuł 1\nUmawiające się Strony") || strings.Contains(polish, "Contracting") {
+		t.Errorf("Expected only the Polish column, got: %q", polish)
+	}
+	foreign := selectPageColumns(page, actColumnsForeign)
+	if !strings.HasPrefix(foreign, "Article 1\nThe Contracting Parties") || strings.Contains(foreign, "Umawiające") {
+		t.Errorf("Expected only the English column, got: %q", foreign)
+	}
+	split := selectPageColumns(page, actColumnsSplit)
+	if !strings.HasPrefix(split, "[Polish text]\nArtykuł 1") || !strings.Contains(split, "\n\n[Foreign-language text]\nArticle 1") {
+		t.Errorf("Expected both columns labelled, got: %q", split)
+	}
+}
+
+func TestSelectPageColumnsSingleColumn(t *testing.T) {
+	var lines []pdfTextLine
+	for i, text := range []string{"Article 5", "This Agreement shall enter into force", "on the first day of the second month", "following the exchange of notes."} {
+		lines = append(lines, pdfTextLine{top: 80 + float64(i)*12, left: 56.7, text: text})
+	}
+	page := mupdfPage(lines)
+	if text := selectPageColumns(page, actColumnsPolish); text != "" {
+		t.Errorf("Expected no Polish text on an English page, got: %q", text)
+	}
+	if text := selectPageColumns(page, actColumnsForeign); !strings.HasPrefix(text, "Article 5\n") {
+		t.Errorf("Expected the English page as foreign text, got: %q", text)
+	}
+}
+
+func TestValidActColumns(t *testing.T) {
+	for _, columns := range []string{actColumnsAll, actColumnsPolish, actColumnsForeign, actColumnsSplit} {
+		if err := validActColumns(columns); err != nil {
+			t.Errorf("Expected %q to be valid: %v", columns, err)
+		}
+	}
+	if err := validActColumns("left"); err == nil {
+		t.Error("Expected an error for an unknown columns mode")
+	}
+}
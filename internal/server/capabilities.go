@@ -0,0 +1,554 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Response size classes of the tool catalog, estimated from what a tool returns
+const (
+	toolSizeSmall  = "small"
+	toolSizeMedium = "medium"
+	toolSizeLarge  = "large"
+)
+
+// toolSizeDescriptions explains the response size classes
+var toolSizeDescriptions = map[string]string{
+	toolSizeSmall:  "usually under 5,000 characters",
+	toolSizeMedium: "5,000-30,000 characters; a page of a list, narrowed with filters, limit and offset",
+	toolSizeLarge:  "often over 30,000 characters: document texts and analyses fanning out into many API calls; bounded by pagination or max_output_chars",
+}
+
+// commonToolParams are added to tools by addTool and are described once in the catalog instead of per tool
+var commonToolParams = map[string]bool{
+	"language":         true,
+	"max_output_chars": true,
+	"save_to_file":     true,
+	"debug":            true,
+	"async":            true,
+	"materialize":      true,
+	"page_size":        true,
+}
+
+// toolTermRangePattern captures the term range documented by a term parameter, e.g. "(7-10)"
+var toolTermRangePattern = regexp.MustCompile(`\((\d{1,2})-(\d{1,2})\)`)
+
+// toolUsage counts the successful calls of a tool and the size of their text output
+type toolUsage struct {
+	Calls      int `json:"calls"`
+	TotalChars int `json:"-"`
+	AvgChars   int `json:"avgChars"`
+	MaxChars   int `json:"maxChars"`
+}
+
+// toolUsageStats records the output size of tool calls since start, so the catalog can report typical sizes
+type toolUsageStats struct {
+	mu    sync.Mutex
+	tools map[string]*toolUsage
+}
+
+func newToolUsageStats() *toolUsageStats {
+	return &toolUsageStats{tools: make(map[string]*toolUsage)}
+}
+
+// record counts one call of a tool; servers built without usage stats record nothing
+func (u *toolUsageStats) record(name string, chars int) {
+	if u == nil {
+		return
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	usage, ok := u.tools[name]
+	if !ok {
+		usage = &toolUsage{}
+		u.tools[name] = usage
+	}
+	usage.Calls++
+	usage.TotalChars += chars
+	usage.MaxChars = max(usage.MaxChars, chars)
+}
+
+// snapshot returns a copy of the usage of the tools called since start
+func (u *toolUsageStats) snapshot() map[string]toolUsage {
+	usages := make(map[string]toolUsage)
+	if u == nil {
+		return usages
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for name, usage := range u.tools {
+		copied := *usage
+		copied.AvgChars = usage.TotalChars / usage.Calls
+		usages[name] = copied
+	}
+	return usages
+}
+
+// toolParam is a parameter of a cataloged tool
+type toolParam struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Required    bool   `json:"required,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// toolCapability is the catalog entry of a registered tool
+type toolCapability struct {
+	Name        string      `json:"name"`
+	Family      string      `json:"family"`
+	Summary     string      `json:"summary"`
+	Description string      `json:"description,omitempty"`
+	Parameters  []toolParam `json:"parameters"`
+	Size        string      `json:"size"`
+	Terms       string      `json:"terms,omitempty"`
+	Async       bool        `json:"async,omitempty"`
+	SaveToFile  bool        `json:"saveToFile,omitempty"`
+	Snapshot    bool        `json:"snapshot,omitempty"`
+	Observed    *toolUsage  `json:"observed,omitempty"`
+}
+
+// toolFamily tells which upstream API a tool is built on
+func toolFamily(name string) string {
+	if strings.HasPrefix(name, "eli_") {
+		return "eli"
+	}
+	return "sejm"
+}
+
+// toolSizeClass estimates how large the responses of a tool usually are
+func toolSizeClass(name string) string {
+	if artifactTools[name] || asyncTools[name] {
+		return toolSizeLarge
+	}
+	if _, ok := listSnapshotSources[name]; ok {
+		return toolSizeMedium
+	}
+	return toolSizeSmall
+}
+
+// toolSummary returns the first sentence of a tool description
+func toolSummary(description string) string {
+	if end := strings.Index(description, ". "); end >= 0 {
+		return description[:end+1]
+	}
+	return description
+}
+
+// toolParamType renders the JSON schema type of a parameter, which is a string or a list of alternatives
+func toolParamType(schema map[string]interface{}) string {
+	switch value := schema["type"].(type) {
+	case string:
+		return value
+	case []string:
+		return strings.Join(value, "|")
+	case []interface{}:
+		types := make([]string, 0, len(value))
+		for _, alternative := range value {
+			types = append(types, fmt.Sprint(alternative))
+		}
+		return strings.Join(types, "|")
+	}
+	return "string"
+}
+
+// describeTool builds the catalog entry of a tool; detailed entries carry the full description and the
+// description of every parameter
+func describeTool(tool mcp.Tool, detailed bool) toolCapability {
+	capability := toolCapability{
+		Name:       tool.Name,
+		Family:     toolFamily(tool.Name),
+		Summary:    toolSummary(tool.Description),
+		Size:       toolSizeClass(tool.Name),
+		Parameters: []toolParam{},
+	}
+	if detailed {
+		capability.Description = tool.Description
+	}
+	for name, property := range tool.InputSchema.Properties {
+		schema, _ := property.(map[string]interface{})
+		switch {
+		case name == "async":
+			capability.Async = true
+		case name == "save_to_file":
+			capability.SaveToFile = true
+		case name == "materialize":
+			capability.Snapshot = true
+		}
+		if commonToolParams[name] {
+			continue
+		}
+		param := toolParam{Name: name, Type: toolParamType(schema), Required: containsString(tool.InputSchema.Required, name)}
+		description, _ := schema["description"].(string)
+		if detailed {
+			param.Description = description
+		}
+		if name == "term" {
+			if match := toolTermRangePattern.FindStringSubmatch(description); match != nil {
+				capability.Terms = match[1] + "-" + match[2]
+			}
+		}
+		capability.Parameters = append(capability.Parameters, param)
+	}
+	sort.Slice(capability.Parameters, func(i, j int) bool {
+		a, b := capability.Parameters[i], capability.Parameters[j]
+		if a.Required != b.Required {
+			return a.Required
+		}
+		return a.Name < b.Name
+	})
+	return capability
+}
+
+// toolCatalog lists the registered tools, sorted by name, with the usage observed since start
+func (s *SejmServer) toolCatalog() []toolCapability {
+	usages := s.usage.snapshot()
+	tools := s.server.ListTools()
+	catalog := make([]toolCapability, 0, len(tools))
+	for _, tool := range tools {
+		capability := describeTool(tool.Tool, false)
+		if usage, ok := usages[tool.Tool.Name]; ok {
+			capability.Observed = &usage
+		}
+		catalog = append(catalog, capability)
+	}
+	sort.Slice(catalog, func(i, j int) bool { return catalog[i].Name < catalog[j].Name })
+	return catalog
+}
+
+// dataCoverage is the span of the data served by the upstream APIs
+type dataCoverage struct {
+	Terms      []termCoverage `json:"terms,omitempty"`
+	ELIYears   string         `json:"eliYears,omitempty"`
+	Publishers []string       `json:"publishers,omitempty"`
+}
+
+// termCoverage is a parliamentary term and its dates
+type termCoverage struct {
+	Num     int32  `json:"num"`
+	From    string `json:"from,omitempty"`
+	To      string `json:"to,omitempty"`
+	Current bool   `json:"current,omitempty"`
+}
+
+// fetchDataCoverage reads the terms of the Sejm API and the years of the ELI publishers. The directory
+// responses are cached, so the catalog stays cheap to call; sources that fail are listed as unavailable.
+func (s *SejmServer) fetchDataCoverage(ctx context.Context) (dataCoverage, *sourceCoverage) {
+	var coverage dataCoverage
+	sources := newSourceCoverage("coverage sources")
+
+	var terms []sejm.Term
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term", s.sejmBaseURL), nil)
+	if err == nil {
+		err = s.decodeAPIResponse(data, &terms)
+	}
+	if err != nil {
+		sources.fail("Sejm terms", err)
+	} else {
+		sources.succeeded()
+		for _, term := range terms {
+			if term.Num == nil {
+				continue
+			}
+			entry := termCoverage{Num: *term.Num, Current: term.Current != nil && *term.Current}
+			if term.From != nil {
+				entry.From = term.From.String()
+			}
+			if term.To != nil {
+				entry.To = term.To.String()
+			}
+			coverage.Terms = append(coverage.Terms, entry)
+		}
+		sort.Slice(coverage.Terms, func(i, j int) bool { return coverage.Terms[i].Num < coverage.Terms[j].Num })
+	}
+
+	publishers, err := s.getCachedPublishers(ctx)
+	if err != nil {
+		sources.fail("ELI publishers", err)
+		return coverage, sources
+	}
+	sources.succeeded()
+	first, last := 0, 0
+	for _, publisher := range publishers {
+		if publisher.Years == nil || len(*publisher.Years) == 0 {
+			continue
+		}
+		low, high := (*publisher.Years)[0], (*publisher.Years)[0]
+		for _, year := range *publisher.Years {
+			low, high = min(low, year), max(high, year)
+		}
+		if first == 0 || int(low) < first {
+			first = int(low)
+		}
+		last = max(last, int(high))
+		coverage.Publishers = append(coverage.Publishers, fmt.Sprintf("%s %d-%d", stringValue(publisher.Code), low, high))
+	}
+	sort.Strings(coverage.Publishers)
+	if first > 0 {
+		coverage.ELIYears = fmt.Sprintf("%d-%d", first, last)
+	}
+	return coverage, sources
+}
+
+// lines renders the coverage for text output
+func (c dataCoverage) lines() []string {
+	var lines []string
+	for _, term := range c.Terms {
+		line := fmt.Sprintf("Sejm term %d: %s to %s", term.Num, valueOrDefault(term.From, "?"), valueOrDefault(term.To, "now"))
+		if term.Current {
+			line += " (current)"
+		}
+		lines = append(lines, line)
+	}
+	if c.ELIYears != "" {
+		lines = append(lines, fmt.Sprintf("ELI acts: %s (%s)", c.ELIYears, strings.Join(c.Publishers, ", ")))
+	}
+	return lines
+}
+
+func (s *SejmServer) registerCapabilitiesTool() {
+	s.addTool(mcp.Tool{
+		Name:        "sejm_list_capabilities",
+		Description: "List every tool of this server with its parameters, an estimate of its typical response size (with the sizes observed since start), the terms it covers and whether it runs as a background job or saves to files, plus the date ranges of the data (Sejm terms and ELI publication years). Use it to plan multi-step workflows instead of discovering parameters and limits by trial and error. Pass tool for the full description of one tool and its parameters.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"tool": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Name of one tool (e.g., 'sejm_get_voting_details') to describe in full, with the description of every parameter.",
+				},
+				"family": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. 'sejm' for tools of the Sejm API, 'eli' for the legal acts database, or 'all' (default).",
+				},
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only list tools whose name or description contains these words, ignoring case and Polish diacritics (e.g., 'voting', 'transcript').",
+				},
+				"coverage": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'false' to skip the data coverage, which takes two cached directory requests (default: 'true').",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json'.",
+				},
+			},
+		},
+	}, s.handleListCapabilities)
+}
+
+func (s *SejmServer) handleListCapabilities(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_list_capabilities called", slog.Any("arguments", request.Params.Arguments))
+
+	family := strings.ToLower(request.GetString("family", "all"))
+	if family != "all" && family != "sejm" && family != "eli" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid family '%s'. Use 'sejm', 'eli' or 'all'.", family)), nil
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+	words := strings.Fields(normalizePolish(request.GetString("query", "")))
+
+	if name := strings.TrimSpace(request.GetString("tool", "")); name != "" {
+		return s.describeOneTool(name, format)
+	}
+
+	var catalog []toolCapability
+	for _, capability := range s.toolCatalog() {
+		if family != "all" && capability.Family != family {
+			continue
+		}
+		if len(words) > 0 {
+			text := normalizePolish(capability.Name + " " + strings.ReplaceAll(capability.Name, "_", " ") + " " + s.server.GetTool(capability.Name).Tool.Description)
+			matched := true
+			for _, word := range words {
+				matched = matched && strings.Contains(text, word)
+			}
+			if !matched {
+				continue
+			}
+		}
+		catalog = append(catalog, capability)
+	}
+
+	var coverage dataCoverage
+	var sources *sourceCoverage
+	if request.GetString("coverage", "true") != "false" {
+		coverage, sources = s.fetchDataCoverage(ctx)
+	}
+
+	if format == "json" {
+		output := map[string]interface{}{
+			"tools":        catalog,
+			"sizeClasses":  toolSizeDescriptions,
+			"commonParams": sortedKeys(commonToolParams),
+		}
+		if sources != nil {
+			output["coverage"] = coverage
+			if unavailable := sources.unavailable(); len(unavailable) > 0 {
+				output["unavailable"] = unavailable
+			}
+		}
+		result, _ := json.MarshalIndent(output, "", "  ")
+		return mcp.NewToolResultText(string(result)), nil
+	}
+
+	counts := make(map[string]int)
+	for _, capability := range catalog {
+		counts[capability.Size]++
+	}
+	summary := []string{
+		fmt.Sprintf("Tools listed: %d of %d registered", len(catalog), len(s.server.ListTools())),
+		fmt.Sprintf("Estimated response sizes: %d small, %d medium, %d large", counts[toolSizeSmall], counts[toolSizeMedium], counts[toolSizeLarge]),
+	}
+	for _, size := range []string{toolSizeSmall, toolSizeMedium, toolSizeLarge} {
+		summary = append(summary, fmt.Sprintf("%s: %s", strings.ToUpper(size[:1])+size[1:], toolSizeDescriptions[size]))
+	}
+	summary = append(summary, "Every tool also accepts language and max_output_chars; tools marked async accept async='true', and tools marked save accept save_to_file='true'")
+	summary = append(summary, coverage.lines()...)
+
+	var results []string
+	for _, capability := range catalog {
+		traits := []string{capability.Size}
+		if capability.Terms != "" {
+			traits = append(traits, "terms "+capability.Terms)
+		}
+		if capability.Async {
+			traits = append(traits, "async")
+		}
+		if capability.SaveToFile {
+			traits = append(traits, "save")
+		}
+		if capability.Snapshot {
+			traits = append(traits, "snapshot")
+		}
+		if capability.Observed != nil {
+			traits = append(traits, fmt.Sprintf("observed avg %d chars in %d calls", capability.Observed.AvgChars, capability.Observed.Calls))
+		}
+		var params []string
+		for _, param := range capability.Parameters {
+			if param.Required {
+				params = append(params, param.Name+"*")
+			} else {
+				params = append(params, param.Name)
+			}
+		}
+		results = append(results, fmt.Sprintf("• %s [%s]: %s", capability.Name, strings.Join(traits, "; "), truncateRunes(capability.Summary, 200)))
+		if len(params) > 0 {
+			results = append(results, "  Parameters: "+strings.Join(params, ", "))
+		}
+	}
+	status := "Retrieved Successfully"
+	var unavailable []string
+	if sources != nil {
+		status = sources.status(status)
+		unavailable = sources.unavailable()
+	}
+	if len(catalog) == 0 {
+		status = "No Results Found"
+		results = append(results, "No tool matches. Try a single English word such as 'voting', 'committee' or 'act'.")
+	}
+
+	response := StandardResponse{
+		Operation:   "Server Capabilities",
+		Status:      status,
+		Summary:     summary,
+		Data:        results,
+		NextActions: []string{"Full description of a tool and its parameters: sejm_list_capabilities with tool", "Server health and upstream latency: sejm_ping"},
+		Note:        "Parameters marked * are required. Sizes are estimates from what a tool returns; observed sizes cover the calls since this server started.",
+		Unavailable: unavailable,
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
+
+// describeOneTool returns the detailed catalog entry of a tool
+func (s *SejmServer) describeOneTool(name, format string) (*mcp.CallToolResult, error) {
+	tool := s.server.GetTool(name)
+	if tool == nil {
+		var similar []string
+		for _, capability := range s.toolCatalog() {
+			if strings.Contains(capability.Name, strings.TrimPrefix(strings.TrimPrefix(name, "sejm_"), "eli_")) {
+				similar = append(similar, capability.Name)
+			}
+		}
+		message := fmt.Sprintf("Unknown tool '%s'.", name)
+		if len(similar) > 0 {
+			message += " Did you mean: " + strings.Join(similar, ", ") + "?"
+		} else {
+			message += " List the tools with sejm_list_capabilities."
+		}
+		return mcp.NewToolResultError(message), nil
+	}
+	capability := describeTool(tool.Tool, true)
+	if usage, ok := s.usage.snapshot()[name]; ok {
+		capability.Observed = &usage
+	}
+
+	if format == "json" {
+		result, _ := json.MarshalIndent(capability, "", "  ")
+		return mcp.NewToolResultText(string(result)), nil
+	}
+
+	summary := []string{
+		fmt.Sprintf("Tool: %s (%s API)", capability.Name, strings.ToUpper(capability.Family)),
+		fmt.Sprintf("Estimated response size: %s, %s", capability.Size, toolSizeDescriptions[capability.Size]),
+	}
+	if capability.Observed != nil {
+		summary = append(summary, fmt.Sprintf("Observed since start: %d calls, %d characters on average, %d at most", capability.Observed.Calls, capability.Observed.AvgChars, capability.Observed.MaxChars))
+	}
+	if capability.Terms != "" {
+		summary = append(summary, "Terms: "+capability.Terms)
+	}
+	var options []string
+	if capability.Async {
+		options = append(options, "async='true' runs it as a background job")
+	}
+	if capability.SaveToFile {
+		options = append(options, "save_to_file='true' writes the output to a file")
+	}
+	if capability.Snapshot {
+		options = append(options, "materialize='true' pages through a stable snapshot")
+	}
+	if len(options) > 0 {
+		summary = append(summary, "Options: "+strings.Join(options, "; "))
+	}
+
+	results := []string{capability.Description, "", "Parameters:"}
+	for _, param := range capability.Parameters {
+		label := param.Type
+		if param.Required {
+			label += ", required"
+		}
+		results = append(results, fmt.Sprintf("• %s (%s): %s", param.Name, label, param.Description))
+	}
+
+	response := StandardResponse{
+		Operation:   "Server Capabilities",
+		Status:      "Retrieved Successfully",
+		Summary:     summary,
+		Data:        results,
+		NextActions: []string{"All tools: sejm_list_capabilities without tool"},
+		Note:        "Every tool also accepts language (en/pl) and max_output_chars.",
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
+
+// sortedKeys returns the keys of a set in order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDescribeTool(t *testing.T) {
+	server := NewSejmServer()
+	capability := describeTool(server.server.GetTool("sejm_get_mp_declarations").Tool, true)
+	if capability.Family != "sejm" || capability.Terms != "7-10" || capability.Size != toolSizeSmall {
+		t.Errorf("Unexpected catalog entry: %+v", capability)
+	}
+	if len(capability.Parameters) == 0 || capability.Parameters[0].Name != "mp_id" || !capability.Parameters[0].Required {
+		t.Errorf("Expected the required parameter first, got %+v", capability.Parameters)
+	}
+	for _, param := range capability.Parameters {
+		if commonToolParams[param.Name] {
+			t.Errorf("Expected common parameters to be left out, got %s", param.Name)
+		}
+	}
+
+	capability = describeTool(server.server.GetTool("eli_get_publisher_years").Tool, false)
+	if capability.Family != "eli" || !capability.Async || capability.Size != toolSizeLarge || capability.Parameters[0].Description != "" {
+		t.Errorf("Unexpected catalog entry of an async tool: %+v", capability)
+	}
+}
+
+func TestHandleListCapabilities(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term": `[{"num": 9, "from": "2019-11-12", "to": "2023-11-12"}, {"num": 10, "from": "2023-11-13", "current": true}]`,
+		"/eli/acts":  `[{"code": "DU", "name": "Dziennik Ustaw", "years": [1918, 2024]}, {"code": "MP", "name": "Monitor Polski", "years": [1945, 2025]}]`,
+	})
+	call := func(arguments map[string]interface{}) string {
+		result, err := server.handleListCapabilities(context.Background(), createMockRequest(arguments))
+		if err != nil || result.IsError {
+			t.Fatalf("Unexpected error for %v: %v %s", arguments, err, extractTextContent(result))
+		}
+		return extractTextContent(result)
+	}
+
+	// A call through the registered handler is counted in the observed sizes
+	ping := server.server.GetTool("sejm_ping")
+	if _, err := ping.Handler(context.Background(), createMockRequest(map[string]interface{}{"check_upstreams": "false"})); err != nil {
+		t.Fatalf("Unexpected ping error: %v", err)
+	}
+
+	output := call(map[string]interface{}{"query": "sejm_ping"})
+	for _, expected := range []string{
+		"Tools listed: 1 of",
+		"• sejm_ping [small; observed avg ",
+		"Parameters: check_upstreams, format",
+		"Sejm term 9: 2019-11-12 to 2023-11-12",
+		"Sejm term 10: 2023-11-13 to now (current)",
+		"ELI acts: 1918-2025 (DU 1918-2024, MP 1945-2025)",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output, got: %s", expected, output)
+		}
+	}
+
+	output = call(map[string]interface{}{"family": "eli", "coverage": "false", "format": "json"})
+	var listed struct {
+		Tools    []toolCapability `json:"tools"`
+		Coverage *dataCoverage    `json:"coverage"`
+	}
+	if err := json.Unmarshal([]byte(output), &listed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(listed.Tools) == 0 || listed.Coverage != nil {
+		t.Fatalf("Expected ELI tools without coverage, got %+v", listed)
+	}
+	for _, capability := range listed.Tools {
+		if capability.Family != "eli" {
+			t.Errorf("Expected only ELI tools, got %s", capability.Name)
+		}
+	}
+
+	output = call(map[string]interface{}{"tool": "sejm_get_voting_details"})
+	if !strings.Contains(output, "• verify (string): Set to 'true' to cross-check") || !strings.Contains(output, "• sitting (integer|string, required)") {
+		t.Errorf("Expected the parameters of the tool described in full, got: %s", output)
+	}
+
+	for _, arguments := range []map[string]interface{}{
+		{"tool": "sejm_get_voting"},
+		{"family": "senate"},
+	} {
+		result, _ := server.handleListCapabilities(context.Background(), createMockRequest(arguments))
+		if !result.IsError {
+			t.Errorf("Expected %v to be rejected, got: %s", arguments, extractTextContent(result))
+		}
+	}
+	result, _ := server.handleListCapabilities(context.Background(), createMockRequest(map[string]interface{}{"tool": "get_voting"}))
+	if text := extractTextContent(result); !strings.Contains(text, "sejm_get_voting_details") {
+		t.Errorf("Expected similar tool names to be suggested, got: %s", text)
+	}
+}
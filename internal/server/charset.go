@@ -0,0 +1,160 @@
+package server
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// metaCharsetPattern captures the charset declared by <meta charset="..."> or <meta http-equiv ... content="...; charset=...">
+	metaCharsetPattern = regexp.MustCompile(`(?i)<meta[^>]+charset\s*=\s*["']?([\w-]+)`)
+	// doubleEscapedEntityPattern matches entities escaped twice, e.g. '&amp;oacute;' shown literally as '&oacute;'
+	doubleEscapedEntityPattern = regexp.MustCompile(`&amp;(#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[a-zA-Z][a-zA-Z0-9]{1,31});`)
+)
+
+// iso88592 maps the upper half (0xA0-0xFF) of ISO-8859-2 (Latin-2) to Unicode; 0x80-0x9F are C1 controls
+var iso88592 = []rune("\u00a0Ą˘Ł¤ĽŚ§¨ŠŞŤŹ\u00adŽŻ" +
+	"°ą˛ł´ľśˇ¸šşťź˝žż" +
+	"ŔÁÂĂÄĹĆÇČÉĘËĚÍÎĎ" +
+	"ĐŃŇÓÔŐÖ×ŘŮÚŰÜÝŢß" +
+	"ŕáâăäĺćçčéęëěíîď" +
+	"đńňóôőö÷řůúűüýţ˙")
+
+// mojibakeBytes reverses a wrong single-byte decoding: it maps the characters that UTF-8 bytes turn into
+// when read as Windows-1250, Windows-1252 or ISO-8859-1 back to those bytes. Windows-1250 wins conflicts.
+var mojibakeBytes = func() map[rune]byte {
+	reverse := make(map[rune]byte)
+	for c := 0xA0; c <= 0xFF; c++ {
+		reverse[rune(c)] = byte(c)
+	}
+	for c, r := range map[byte]rune{0x83: 'ƒ', 0x88: 'ˆ', 0x8C: 'Œ', 0x98: '˜', 0x9C: 'œ', 0x9F: 'Ÿ'} {
+		reverse[r] = c
+	}
+	for i, r := range windows1250 {
+		if r != utf8.RuneError {
+			reverse[r] = byte(0x80 + i)
+		}
+	}
+	return reverse
+}()
+
+// decodeISO88592 decodes ISO-8859-2 bytes
+func decodeISO88592(data []byte) string {
+	var builder strings.Builder
+	for _, c := range data {
+		if c < 0xA0 {
+			builder.WriteRune(rune(c))
+		} else {
+			builder.WriteRune(iso88592[c-0xA0])
+		}
+	}
+	return builder.String()
+}
+
+// declaredHTMLCharset returns the lowercased charset declared in an HTML document's meta tags
+func declaredHTMLCharset(data []byte) string {
+	head := data
+	if len(head) > 4096 {
+		head = head[:4096]
+	}
+	if match := metaCharsetPattern.FindSubmatch(head); match != nil {
+		return strings.ToLower(string(match[1]))
+	}
+	return ""
+}
+
+// decodeHTMLBody converts an HTML body to UTF-8. The declared charset is tried first; when the body
+// is not valid in it, decoding is retried as UTF-8, then Windows-1250, the usual encoding of Polish
+// legacy documents. ISO-8859-2 is only used when declared, since it differs from Windows-1250 in a
+// few Polish letters (ą, ś, ź and their capitals).
+func decodeHTMLBody(data []byte) string {
+	data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+	switch declaredHTMLCharset(data) {
+	case "iso-8859-2", "iso8859-2", "latin2", "l2":
+		if !utf8.Valid(data) {
+			return decodeISO88592(data)
+		}
+	}
+	return decodeLegacyText(data)
+}
+
+// isPolishTextRune reports whether a repaired character is a letter or punctuation expected in Polish
+// text, so that valid but unrelated byte sequences (e.g. Cyrillic) are not produced by a repair
+func isPolishTextRune(r rune) bool {
+	return (r >= 0xA0 && r <= 0x17F) || (r >= 0x2010 && r <= 0x206F) || r == 0x20AC || r == 0x2122
+}
+
+// repairMojibake fixes UTF-8 text that was decoded with a single-byte code page somewhere upstream
+// ('zaÅ¼Ã³Å‚Ä‡' or 'zaĹĽĂłĹ‚Ä‡' for 'zażółć'). Each run of non-ASCII characters is mapped back to bytes,
+// and every multi-byte UTF-8 sequence found in them that decodes to a Latin letter or punctuation mark
+// replaces the characters it came from. Correct Polish text never forms such sequences.
+func repairMojibake(text string) string {
+	var builder strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		if runes[i] < 0x80 {
+			builder.WriteRune(runes[i])
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && runes[j] >= 0x80 {
+			j++
+		}
+		builder.WriteString(repairMojibakeRun(runes[i:j]))
+		i = j
+	}
+	return builder.String()
+}
+
+func repairMojibakeRun(run []rune) string {
+	encoded := make([]byte, len(run))
+	for i, r := range run {
+		c, ok := mojibakeBytes[r]
+		if !ok {
+			// Characters outside the single-byte code pages cannot be mojibake bytes
+			encoded[i] = 0
+			continue
+		}
+		encoded[i] = c
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(run); {
+		if r, size := utf8.DecodeRune(encoded[i:]); size > 1 && isPolishTextRune(r) {
+			builder.WriteRune(r)
+			i += size
+			continue
+		}
+		builder.WriteRune(run[i])
+		i++
+	}
+	return builder.String()
+}
+
+// repairDoubleEscapedEntities unescapes one level of '&amp;name;' when the inner entity is a real one
+func repairDoubleEscapedEntities(content string) string {
+	return doubleEscapedEntityPattern.ReplaceAllStringFunc(content, func(match string) string {
+		entity := "&" + strings.TrimPrefix(match, "&amp;")
+		if html.UnescapeString(entity) == entity {
+			return match
+		}
+		return entity
+	})
+}
+
+// normalizeHTMLBody returns an HTML body as clean UTF-8: decoded from its legacy charset, with mojibake
+// and double-escaped entities repaired, and any charset declaration updated to match
+func normalizeHTMLBody(data []byte) []byte {
+	content := decodeHTMLBody(data)
+	content = repairMojibake(content)
+	content = repairDoubleEscapedEntities(content)
+	content = metaCharsetPattern.ReplaceAllStringFunc(content, func(meta string) string {
+		match := metaCharsetPattern.FindStringSubmatchIndex(meta)
+		return meta[:match[2]] + "utf-8" + meta[match[3]:]
+	})
+	return []byte(content)
+}
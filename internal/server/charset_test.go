@@ -0,0 +1,54 @@
+package server
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeHTMLBodyWindows1250(t *testing.T) {
+	// 'Zażółć gęślą jaźń' encoded as Windows-1250
+	body := []byte("<html><head><meta charset=\"windows-1250\"></head><body>Za\xbf\xf3\xb3\xe6 g\xea\x9cl\xb9 ja\x9f\xf1</body></html>")
+	got := string(normalizeHTMLBody(body))
+	if !strings.Contains(got, "Zażółć gęślą jaźń") {
+		t.Errorf("Expected decoded Polish text, got %q", got)
+	}
+	if !strings.Contains(got, `<meta charset="utf-8">`) {
+		t.Errorf("Expected charset declaration updated to utf-8, got %q", got)
+	}
+}
+
+func TestNormalizeHTMLBodyISO88592(t *testing.T) {
+	// 'ąśź' differ between ISO-8859-2 (0xB1 0xB6 0xBC) and Windows-1250
+	body := []byte("<meta http-equiv=\"Content-Type\" content=\"text/html; charset=ISO-8859-2\"><p>\xb1\xb6\xbc</p>")
+	got := string(normalizeHTMLBody(body))
+	if !strings.Contains(got, "<p>ąśź</p>") || !strings.Contains(got, "charset=utf-8") {
+		t.Errorf("Expected ISO-8859-2 decoding, got %q", got)
+	}
+}
+
+func TestRepairMojibake(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"zaÅ¼Ã³Å‚Ä‡ gÄ™Å›lÄ…", "zażółć gęślą"},          // UTF-8 read as Windows-1252
+		{"zaĹĽĂłĹ‚Ä‡ gÄ™Ĺ›lÄ…", "zażółć gęślą"},          // UTF-8 read as Windows-1250
+		{"posiedzenie â€“ pkt 1", "posiedzenie – pkt 1"}, // en dash
+		{"Zażółć gęślą jaźń – ÓŚĆ", "Zażółć gęślą jaźń – ÓŚĆ"},
+		{"Óą Ćł Ńż Ęż", "Óą Ćł Ńż Ęż"},
+		{"Łódź, „cytat”", "Łódź, „cytat”"},
+	}
+	for _, tc := range testCases {
+		if got := repairMojibake(tc.input); got != tc.expected {
+			t.Errorf("repairMojibake(%q) = %q, expected %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestRepairDoubleEscapedEntities(t *testing.T) {
+	got := repairDoubleEscapedEntities("Kto&amp;oacute;rzy &amp;#322; &amp;amp; Smith &amp; Sons &amp;bogus;")
+	expected := "Kto&oacute;rzy &#322; &amp; Smith &amp; Sons &amp;bogus;"
+	if got != expected {
+		t.Errorf("repairDoubleEscapedEntities = %q, expected %q", got, expected)
+	}
+}
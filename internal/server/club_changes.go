@@ -0,0 +1,353 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// clubSnapshot is the club of every MP at one point of the term, taken from a voting or the current MP list
+type clubSnapshot struct {
+	date   string
+	source string
+	clubs  map[int]string
+	names  map[int]string
+}
+
+// clubTransition is an MP moving from one club to another between two snapshots
+type clubTransition struct {
+	MP       int    `json:"mp"`
+	Name     string `json:"name"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	LastSeen string `json:"lastSeenInOldClub"`
+	Changed  string `json:"firstSeenInNewClub"`
+	Source   string `json:"source"`
+}
+
+// clubLifespan tells when a club was first and last seen, and its size at both points
+type clubLifespan struct {
+	Club         string `json:"club"`
+	FirstSeen    string `json:"firstSeen"`
+	LastSeen     string `json:"lastSeen"`
+	FirstMembers int    `json:"membersWhenFirstSeen"`
+	LastMembers  int    `json:"membersWhenLastSeen"`
+	Formed       bool   `json:"formedDuringWindow"`
+	Dissolved    bool   `json:"dissolvedDuringWindow"`
+}
+
+// votingClubSnapshot builds a snapshot from the votes of a voting
+func votingClubSnapshot(date, source string, votes []sejm.Vote) clubSnapshot {
+	snapshot := clubSnapshot{date: date, source: source, clubs: make(map[int]string), names: make(map[int]string)}
+	for _, vote := range votes {
+		if vote.MP == nil || vote.Club == nil {
+			continue
+		}
+		snapshot.clubs[int(*vote.MP)] = *vote.Club
+		snapshot.names[int(*vote.MP)] = strings.TrimSpace(stringValue(vote.FirstName) + " " + stringValue(vote.LastName))
+	}
+	return snapshot
+}
+
+// clubTransitions compares consecutive snapshots, ordered by date, and lists each MP's change of club
+func clubTransitions(snapshots []clubSnapshot) []clubTransition {
+	type lastKnown struct {
+		club string
+		date string
+	}
+	known := make(map[int]lastKnown)
+	var transitions []clubTransition
+	for _, snapshot := range snapshots {
+		mps := make([]int, 0, len(snapshot.clubs))
+		for mp := range snapshot.clubs {
+			mps = append(mps, mp)
+		}
+		sort.Ints(mps)
+		for _, mp := range mps {
+			club := snapshot.clubs[mp]
+			previous, seen := known[mp]
+			if seen && previous.club != club {
+				transitions = append(transitions, clubTransition{
+					MP: mp, Name: snapshot.names[mp], From: previous.club, To: club,
+					LastSeen: previous.date, Changed: snapshot.date, Source: snapshot.source,
+				})
+			}
+			known[mp] = lastKnown{club: club, date: snapshot.date}
+		}
+	}
+	return transitions
+}
+
+// clubLifespans tells when each club appears in the snapshots. A club missing from the first snapshot was
+// formed during the window; one missing from the last snapshot was dissolved.
+func clubLifespans(snapshots []clubSnapshot) []clubLifespan {
+	spans := make(map[string]*clubLifespan)
+	for _, snapshot := range snapshots {
+		members := make(map[string]int)
+		for _, club := range snapshot.clubs {
+			members[club]++
+		}
+		for club, count := range members {
+			span := spans[club]
+			if span == nil {
+				span = &clubLifespan{Club: club, FirstSeen: snapshot.date, FirstMembers: count}
+				spans[club] = span
+			}
+			span.LastSeen = snapshot.date
+			span.LastMembers = count
+		}
+	}
+
+	result := make([]clubLifespan, 0, len(spans))
+	for _, span := range spans {
+		if len(snapshots) > 0 {
+			span.Formed = span.FirstSeen != snapshots[0].date
+			span.Dissolved = span.LastSeen != snapshots[len(snapshots)-1].date
+		}
+		result = append(result, *span)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].LastMembers != result[j].LastMembers {
+			return result[i].LastMembers > result[j].LastMembers
+		}
+		return result[i].Club < result[j].Club
+	})
+	return result
+}
+
+// sittingClubSnapshot samples the last voting of a sitting for the clubs of all MPs at that time
+func (s *SejmServer) sittingClubSnapshot(ctx context.Context, term, sitting int) (clubSnapshot, error) {
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/votings/%d", s.sejmBaseURL, term, sitting), nil)
+	if err != nil {
+		return clubSnapshot{}, err
+	}
+	var votings []sejm.Voting
+	if err := s.decodeAPIResponse(data, &votings); err != nil {
+		return clubSnapshot{}, fmt.Errorf("failed to parse votings: %w", err)
+	}
+	var last *sejm.Voting
+	for i := range votings {
+		if votings[i].VotingNumber != nil && (last == nil || *votings[i].VotingNumber > *last.VotingNumber) {
+			last = &votings[i]
+		}
+	}
+	if last == nil {
+		return clubSnapshot{}, fmt.Errorf("no votings")
+	}
+
+	data, err = s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/votings/%d/%d", s.sejmBaseURL, term, sitting, *last.VotingNumber), nil)
+	if err != nil {
+		return clubSnapshot{}, err
+	}
+	var details sejm.VotingDetails
+	if err := s.decodeAPIResponse(data, &details); err != nil {
+		return clubSnapshot{}, fmt.Errorf("failed to parse voting details: %w", err)
+	}
+	if details.Votes == nil {
+		return clubSnapshot{}, fmt.Errorf("voting %d/%d has no individual votes", sitting, *last.VotingNumber)
+	}
+	date := ""
+	if last.Date != nil {
+		date = last.Date.Format("2006-01-02")
+	}
+	return votingClubSnapshot(date, fmt.Sprintf("sitting %d, voting %d", sitting, *last.VotingNumber), *details.Votes), nil
+}
+
+// currentClubSnapshot takes the clubs of active MPs from the current MP list
+func (s *SejmServer) currentClubSnapshot(ctx context.Context, term int) (clubSnapshot, error) {
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/MP", s.sejmBaseURL, term), nil)
+	if err != nil {
+		return clubSnapshot{}, err
+	}
+	var mps []sejm.MP
+	if err := s.decodeAPIResponse(data, &mps); err != nil {
+		return clubSnapshot{}, fmt.Errorf("failed to parse MPs: %w", err)
+	}
+	snapshot := clubSnapshot{date: time.Now().Format("2006-01-02"), source: "current MP list", clubs: make(map[int]string), names: make(map[int]string)}
+	for _, mp := range mps {
+		if mp.Id == nil || mp.Club == nil || (mp.Active != nil && !*mp.Active) {
+			continue
+		}
+		snapshot.clubs[int(*mp.Id)] = *mp.Club
+		snapshot.names[int(*mp.Id)] = stringValue(mp.FirstLastName)
+	}
+	return snapshot, nil
+}
+
+func (s *SejmServer) handleGetClubChanges(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_club_changes called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	from, err := parseDefectionDate("date_from", request.GetString("date_from", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	to, err := parseDefectionDate("date_to", request.GetString("date_to", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	clubFilter := strings.TrimSpace(request.GetString("club", ""))
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	sittings, err := s.defectionSittings(ctx, term, "", from, to)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to determine sittings to sample: %v", err)), nil
+	}
+
+	// One voting per sitting is enough to know every MP's club on that day
+	progress := s.newProgressReporter(ctx, request)
+	snapshots := make([]clubSnapshot, len(sittings))
+	failures := make([]error, len(sittings))
+	slots := make(chan struct{}, maxConcurrentBodyFetches)
+	var wg sync.WaitGroup
+	var progressMu sync.Mutex
+	done := 0
+	for i, sitting := range sittings {
+		wg.Add(1)
+		go func(i, sitting int) {
+			defer wg.Done()
+			if err := acquireSlot(ctx, slots); err != nil {
+				failures[i] = err
+				return
+			}
+			defer func() { <-slots }()
+			defer func() {
+				progressMu.Lock()
+				done++
+				progress.report(done, len(sittings), fmt.Sprintf("Sampled sitting %d (%d of %d)", sitting, done, len(sittings)))
+				progressMu.Unlock()
+			}()
+			snapshots[i], failures[i] = s.sittingClubSnapshot(ctx, term, sitting)
+		}(i, sitting)
+	}
+	wg.Wait()
+
+	coverage := newSourceCoverage("sources")
+	var sampled []clubSnapshot
+	for i, sitting := range sittings {
+		if failures[i] != nil {
+			coverage.fail(fmt.Sprintf("sitting %d", sitting), failures[i])
+			continue
+		}
+		coverage.succeeded()
+		sampled = append(sampled, snapshots[i])
+	}
+	// The MP list shows changes made after the last voting, unless the window ends in the past
+	if to.IsZero() {
+		current, err := s.currentClubSnapshot(ctx, term)
+		if err != nil {
+			coverage.fail("current MP list", err)
+		} else {
+			coverage.succeeded()
+			sampled = append(sampled, current)
+		}
+	}
+	sort.SliceStable(sampled, func(i, j int) bool { return sampled[i].date < sampled[j].date })
+
+	if len(sampled) < 2 {
+		return mcp.NewToolResultError(fmt.Sprintf("Not enough data to compare club membership in term %d (%d snapshots). Widen the date range or try again later.", term, len(sampled))), nil
+	}
+
+	transitions := clubTransitions(sampled)
+	lifespans := clubLifespans(sampled)
+	if clubFilter != "" {
+		var matching []clubTransition
+		for _, t := range transitions {
+			if strings.EqualFold(t.From, clubFilter) || strings.EqualFold(t.To, clubFilter) {
+				matching = append(matching, t)
+			}
+		}
+		transitions = matching
+		var spans []clubLifespan
+		for _, span := range lifespans {
+			if strings.EqualFold(span.Club, clubFilter) {
+				spans = append(spans, span)
+			}
+		}
+		lifespans = spans
+	}
+	window := fmt.Sprintf("%s to %s", sampled[0].date, sampled[len(sampled)-1].date)
+
+	if format == "json" {
+		result, _ := json.MarshalIndent(map[string]interface{}{
+			"term":        term,
+			"from":        sampled[0].date,
+			"to":          sampled[len(sampled)-1].date,
+			"snapshots":   len(sampled),
+			"transitions": transitions,
+			"clubs":       lifespans,
+			"unavailable": coverage.failed,
+		}, "", "  ")
+		return coverage.result(string(result)), nil
+	}
+
+	formed, dissolved := 0, 0
+	for _, span := range lifespans {
+		if span.Formed {
+			formed++
+		}
+		if span.Dissolved {
+			dissolved++
+		}
+	}
+	summary := []string{
+		fmt.Sprintf("Term: %d, %s", term, window),
+		fmt.Sprintf("Membership snapshots compared: %d", len(sampled)),
+		fmt.Sprintf("Club changes: %d", len(transitions)),
+		fmt.Sprintf("Clubs formed: %d, dissolved: %d", formed, dissolved),
+	}
+	if clubFilter != "" {
+		summary = append(summary, fmt.Sprintf("Club filter: %s", clubFilter))
+	}
+
+	var data []string
+	if len(transitions) > 0 {
+		data = append(data, "MPs who changed clubs:")
+		for _, t := range transitions {
+			data = append(data, fmt.Sprintf("• %s (ID: %d): %s → %s, between %s and %s", valueOrDefault(t.Name, "Unknown MP"), t.MP, t.From, t.To, t.LastSeen, t.Changed))
+		}
+	} else {
+		data = append(data, "No MP changed clubs between the compared snapshots.")
+	}
+	data = append(data, "", "Clubs:")
+	for _, span := range lifespans {
+		line := fmt.Sprintf("• %s: %d members on %s, %d on %s", span.Club, span.FirstMembers, span.FirstSeen, span.LastMembers, span.LastSeen)
+		if span.Formed {
+			line += fmt.Sprintf("; formed, first seen %s", span.FirstSeen)
+		}
+		if span.Dissolved {
+			line += fmt.Sprintf("; dissolved, last seen %s", span.LastSeen)
+		}
+		data = append(data, line)
+	}
+
+	response := StandardResponse{
+		Operation:   "Club Changes",
+		Status:      coverage.status("Retrieved Successfully"),
+		Summary:     summary,
+		Unavailable: coverage.unavailable(),
+		Data:        data,
+		NextActions: []string{
+			"MP profile: sejm_get_mp_details with mp_id",
+			"Votes against the club line: sejm_find_defections with a date range",
+			"Current clubs: sejm_get_clubs",
+			"Structured output: add format='json'",
+		},
+		Note: "Membership is sampled from the last voting of each sitting and from the current MP list, so a change is dated between two snapshots. An MP who switched twice between two sittings shows as one change, and MPs absent from a voting are matched at the next one.",
+	}
+	return coverage.result(response.Format()), nil
+}
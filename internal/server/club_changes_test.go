@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestClubTransitionsAndLifespans(t *testing.T) {
+	snapshots := []clubSnapshot{
+		{date: "2024-01-10", clubs: map[int]string{1: "KO", 2: "PiS", 3: "PiS"}, names: map[int]string{1: "Anna Nowak", 2: "Jan Kowalski", 3: "Piotr Zieliński"}},
+		{date: "2024-02-10", clubs: map[int]string{1: "KO", 2: "PiS"}, names: map[int]string{1: "Anna Nowak", 2: "Jan Kowalski"}},
+		{date: "2024-03-10", clubs: map[int]string{1: "KO", 2: "Nowy", 3: "Nowy"}, names: map[int]string{1: "Anna Nowak", 2: "Jan Kowalski", 3: "Piotr Zieliński"}},
+	}
+
+	transitions := clubTransitions(snapshots)
+	if len(transitions) != 2 {
+		t.Fatalf("Expected 2 transitions, got %+v", transitions)
+	}
+	if transitions[0].MP != 2 || transitions[0].From != "PiS" || transitions[0].To != "Nowy" || transitions[0].LastSeen != "2024-02-10" || transitions[0].Changed != "2024-03-10" {
+		t.Errorf("Unexpected transition: %+v", transitions[0])
+	}
+	// An MP absent from a snapshot is compared with the last one they appeared in
+	if transitions[1].MP != 3 || transitions[1].LastSeen != "2024-01-10" {
+		t.Errorf("Unexpected transition of an absent MP: %+v", transitions[1])
+	}
+
+	spans := clubLifespans(snapshots)
+	byClub := make(map[string]clubLifespan)
+	for _, span := range spans {
+		byClub[span.Club] = span
+	}
+	if nowy := byClub["Nowy"]; !nowy.Formed || nowy.Dissolved || nowy.FirstSeen != "2024-03-10" || nowy.LastMembers != 2 {
+		t.Errorf("Unexpected lifespan of a formed club: %+v", nowy)
+	}
+	if pis := byClub["PiS"]; pis.Formed || !pis.Dissolved || pis.LastSeen != "2024-02-10" || pis.FirstMembers != 2 {
+		t.Errorf("Unexpected lifespan of a dissolved club: %+v", pis)
+	}
+	if ko := byClub["KO"]; ko.Formed || ko.Dissolved {
+		t.Errorf("Unexpected lifespan of a stable club: %+v", ko)
+	}
+}
+
+func TestHandleGetClubChanges(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/votings": `[
+			{"date": "2024-03-07", "proceeding": 7, "votingsNum": 2},
+			{"date": "2024-04-11", "proceeding": 9, "votingsNum": 1},
+			{"date": "2024-05-09", "proceeding": 11, "votingsNum": 1}
+		]`,
+		"/sejm/term10/votings/7": `[
+			{"sitting": 7, "votingNumber": 1, "date": "2024-03-07T10:00:00"},
+			{"sitting": 7, "votingNumber": 2, "date": "2024-03-07T12:00:00"}
+		]`,
+		"/sejm/term10/votings/7/2": `{"votes": [
+			{"MP": 1, "club": "KO", "firstName": "Anna", "lastName": "Nowak", "vote": "YES"},
+			{"MP": 2, "club": "PiS", "firstName": "Jan", "lastName": "Kowalski", "vote": "NO"}
+		]}`,
+		"/sejm/term10/votings/9": `[
+			{"sitting": 9, "votingNumber": 1, "date": "2024-04-11T10:00:00"}
+		]`,
+		"/sejm/term10/votings/9/1": `{"votes": [
+			{"MP": 1, "club": "KO", "firstName": "Anna", "lastName": "Nowak", "vote": "YES"},
+			{"MP": 2, "club": "Republikanie", "firstName": "Jan", "lastName": "Kowalski", "vote": "NO"}
+		]}`,
+		"/sejm/term10/MP": `[
+			{"id": 1, "club": "KO", "firstLastName": "Anna Nowak", "active": true},
+			{"id": 2, "club": "Republikanie", "firstLastName": "Jan Kowalski", "active": true}
+		]`,
+	})
+
+	result, err := server.handleGetClubChanges(context.Background(), createMockRequest(map[string]interface{}{"term": "10"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Membership snapshots compared: 3",
+		"Club changes: 1",
+		"Jan Kowalski (ID: 2): PiS → Republikanie, between 2024-03-07 and 2024-04-11",
+		"Republikanie: 1 members on 2024-04-11",
+		"formed, first seen 2024-04-11",
+		"dissolved, last seen 2024-03-07",
+		"Partially Retrieved",
+		"sitting 11",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, content)
+		}
+	}
+
+	result, err = server.handleGetClubChanges(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "date_to": "2024-03-31",
+	}))
+	if err != nil || !result.IsError || !strings.Contains(extractTextContent(result), "Not enough data") {
+		t.Errorf("Expected an error for a single snapshot, got %v %s", err, extractTextContent(result))
+	}
+
+	result, _ = server.handleGetClubChanges(context.Background(), createMockRequest(map[string]interface{}{"format": "xml"}))
+	if !result.IsError {
+		t.Error("Expected an error for an invalid format")
+	}
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Positions of clubs without a club line
+const (
+	clubPositionSplit   = "SPLIT"
+	clubPositionUnclear = "NO LINE"
+)
+
+// clubPosition is the position of one club in a voting with the votes of its members
+type clubPosition struct {
+	Club     string `json:"club"`
+	Position string `json:"position"`
+	Members  int    `json:"members"`
+	Yes      int    `json:"yes"`
+	No       int    `json:"no"`
+	Abstain  int    `json:"abstain"`
+	Absent   int    `json:"absent"`
+	Dissent  int    `json:"dissent"`
+}
+
+// clubPositions infers the position of each club from the majority of its members' votes. Dissent counts the
+// members who voted YES, NO or ABSTAIN differently from that majority. Clubs are ordered by size.
+func clubPositions(votes []sejm.Vote) []clubPosition {
+	majorities := clubMajorities(votes)
+	byClub := make(map[string]*clubPosition)
+	for _, vote := range votes {
+		club := "No club"
+		if vote.Club != nil && *vote.Club != "" {
+			club = *vote.Club
+		}
+		position := byClub[club]
+		if position == nil {
+			position = &clubPosition{Club: club}
+			byClub[club] = position
+		}
+		position.Members++
+		if vote.Vote == nil {
+			position.Absent++
+			continue
+		}
+		switch *vote.Vote {
+		case sejm.VoteValueYES:
+			position.Yes++
+		case sejm.VoteValueNO:
+			position.No++
+		case sejm.VoteValueABSTAIN:
+			position.Abstain++
+		default:
+			position.Absent++
+		}
+	}
+
+	positions := make([]clubPosition, 0, len(byClub))
+	for club, position := range byClub {
+		voters := position.Yes + position.No + position.Abstain
+		if majority, ok := majorities[club]; ok {
+			position.Position = string(majority.Vote)
+			position.Dissent = voters - majority.Count
+		} else if voters >= minClubVotersForMajority {
+			position.Position = clubPositionSplit
+		} else {
+			position.Position = clubPositionUnclear
+		}
+		positions = append(positions, *position)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Members != positions[j].Members {
+			return positions[i].Members > positions[j].Members
+		}
+		return positions[i].Club < positions[j].Club
+	})
+	return positions
+}
+
+func (s *SejmServer) handleGetClubPositions(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_club_positions called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	sitting := request.GetString("sitting", "")
+	votingNumber := request.GetString("voting_number", "")
+	if sitting == "" || votingNumber == "" {
+		return mcp.NewToolResultError("Both 'sitting' and 'voting_number' parameters are required. Get these from sejm_search_votings results."), nil
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	endpoint := fmt.Sprintf("%s/sejm/term%d/votings/%s/%s", s.sejmBaseURL, term, sitting, votingNumber)
+	data, err := s.makeAPIRequest(ctx, endpoint, nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve voting details: %v. Please verify sitting=%s and voting_number=%s exist.", err, sitting, votingNumber)), nil
+	}
+	var voting sejm.VotingDetails
+	if err := s.decodeAPIResponse(data, &voting); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse voting data: %v.", err)), nil
+	}
+	if voting.Kind != nil && *voting.Kind == sejm.VotingKindONLIST {
+		return mcp.NewToolResultError(fmt.Sprintf("Voting %s/%s is a vote on a list of candidates, which has no YES/NO position per club. Use sejm_get_voting_details to see the votes per option.", sitting, votingNumber)), nil
+	}
+
+	// Older terms have no MP-level votes in JSON; read them from the official PDF as sejm_get_voting_details does
+	var notes []string
+	if voting.Votes == nil || len(*voting.Votes) == 0 {
+		records, err := s.fetchVotingPDFRecords(ctx, term, sitting, votingNumber)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("The API has no MP-level votes for voting %s/%s and they could not be read from the PDF: %v.", sitting, votingNumber, err)), nil
+		}
+		votes := pdfRecordsToVotes(records)
+		voting.Votes = &votes
+		notes = append(notes, "The API has no MP-level votes for this voting, so they were parsed from the official PDF.")
+	}
+	positions := clubPositions(*voting.Votes)
+
+	if format == "json" {
+		result := map[string]interface{}{
+			"term":         term,
+			"sitting":      sitting,
+			"votingNumber": votingNumber,
+			"title":        stringValue(voting.Title),
+			"topic":        stringValue(voting.Topic),
+			"clubs":        positions,
+		}
+		if voting.Date != nil {
+			result["date"] = voting.Date.Format("2006-01-02 15:04")
+		}
+		out, _ := json.MarshalIndent(result, "", "  ")
+		return mcp.NewToolResultText(string(out)), nil
+	}
+
+	summary := []string{fmt.Sprintf("Sitting %s, voting %s (Term %d)", sitting, votingNumber, term)}
+	if voting.Date != nil {
+		summary = append(summary, fmt.Sprintf("Date: %s", voting.Date.Format("2006-01-02 15:04")))
+	}
+	if voting.Title != nil {
+		summary = append(summary, fmt.Sprintf("Title: %s", *voting.Title))
+	}
+	if voting.Topic != nil && *voting.Topic != "" {
+		summary = append(summary, fmt.Sprintf("Topic: %s", *voting.Topic))
+	}
+	if voting.Yes != nil && voting.No != nil && voting.Abstain != nil {
+		summary = append(summary, fmt.Sprintf("Result: %d yes, %d no, %d abstain", *voting.Yes, *voting.No, *voting.Abstain))
+	}
+	united := 0
+	for _, position := range positions {
+		if position.Dissent == 0 && position.Position != clubPositionSplit && position.Position != clubPositionUnclear {
+			united++
+		}
+	}
+	summary = append(summary, fmt.Sprintf("Clubs: %d, of which %d voted without dissent", len(positions), united))
+
+	var results []string
+	for _, position := range positions {
+		line := fmt.Sprintf("• %s (%d members): %s", position.Club, position.Members, position.Position)
+		if position.Dissent > 0 {
+			line += fmt.Sprintf(", %d dissenting", position.Dissent)
+		}
+		line += fmt.Sprintf(" (yes %d, no %d, abstain %d, absent %d)", position.Yes, position.No, position.Abstain, position.Absent)
+		results = append(results, line)
+	}
+
+	notes = append(notes, fmt.Sprintf("A club's position is the most common of its members' YES, NO and ABSTAIN votes. %s means a tie between the most common votes; %s means fewer than %d members voted. Absent counts members who did not vote.", clubPositionSplit, clubPositionUnclear, minClubVotersForMajority))
+	response := StandardResponse{
+		Operation: "Club Positions",
+		Status:    "Retrieved Successfully",
+		Summary:   summary,
+		Data:      results,
+		NextActions: []string{
+			fmt.Sprintf("Names of the dissenting MPs: sejm_find_defections with sitting='%s'", sitting),
+			fmt.Sprintf("MP-by-MP votes: sejm_get_voting_details with sitting='%s', voting_number='%s'", sitting, votingNumber),
+		},
+		Note: strings.Join(notes, " "),
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
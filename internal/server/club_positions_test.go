@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestHandleGetClubPositions(t *testing.T) {
+	var votes []string
+	for i, club := range []string{"KO", "KO", "KO", "KO", "KO", "PiS", "PiS", "PiS", "PiS", "Lewica", "Lewica", "Razem"} {
+		vote := "YES"
+		switch {
+		case club == "PiS" && i != 8:
+			vote = "NO"
+		case club == "KO" && i == 4:
+			vote = "ABSENT"
+		case club == "KO" && i == 3:
+			vote = "ABSTAIN"
+		case club == "Lewica" && i == 10:
+			vote = "NO"
+		}
+		votes = append(votes, fmt.Sprintf(`{"MP": %d, "club": "%s", "vote": "%s"}`, i+1, club, vote))
+	}
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/votings/15/7": fmt.Sprintf(`{"sitting": 15, "votingNumber": 7, "kind": "ELECTRONIC", "title": "Głosowanie nad całością projektu", "yes": 6, "no": 4, "abstain": 1, "votes": [%s]}`, strings.Join(votes, ",")),
+		"/sejm/term10/votings/15/8": `{"sitting": 15, "votingNumber": 8, "kind": "ON_LIST", "votes": [{"MP": 1, "club": "KO"}]}`,
+	})
+
+	result, err := server.handleGetClubPositions(context.Background(), createMockRequest(map[string]interface{}{"sitting": "15", "voting_number": "7"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{
+		"Result: 6 yes, 4 no, 1 abstain",
+		"Clubs: 4, of which 0 voted without dissent",
+		"• KO (5 members): YES, 1 dissenting (yes 3, no 0, abstain 1, absent 1)",
+		"• PiS (4 members): NO, 1 dissenting (yes 1, no 3, abstain 0, absent 0)",
+		"• Lewica (2 members): NO LINE (yes 1, no 1, abstain 0, absent 0)",
+		"• Razem (1 members): NO LINE",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+
+	result, _ = server.handleGetClubPositions(context.Background(), createMockRequest(map[string]interface{}{"sitting": "15", "voting_number": "8"}))
+	if !result.IsError || !strings.Contains(extractTextContent(result), "list of candidates") {
+		t.Errorf("Expected a vote on a list to be rejected, got: %s", extractTextContent(result))
+	}
+}
+
+func TestClubPositionsSplit(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/votings/3/1": `{"votes": [
+			{"club": "PSL", "vote": "YES"}, {"club": "PSL", "vote": "YES"},
+			{"club": "PSL", "vote": "NO"}, {"club": "PSL", "vote": "NO"}
+		]}`,
+	})
+	result, _ := server.handleGetClubPositions(context.Background(), createMockRequest(map[string]interface{}{"sitting": "3", "voting_number": "1", "format": "json"}))
+	if text := extractTextContent(result); !strings.Contains(text, `"position": "SPLIT"`) {
+		t.Errorf("Expected a tie to be reported as a split, got:\n%s", text)
+	}
+}
@@ -0,0 +1,273 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Limits of sejm_get_committee_attendance
+const (
+	defaultAttendanceSittings = 20
+	maxAttendanceSittings     = 60
+	maxListedAbsences         = 10
+)
+
+// memberAttendance is the attendance of one committee member over the analyzed sittings
+type memberAttendance struct {
+	Name     string
+	Club     string
+	Role     string
+	Present  int
+	Absences []int32
+}
+
+// Percentage returns the share of analyzed sittings the member attended
+func (a memberAttendance) Percentage(sittings int) float64 {
+	if sittings == 0 {
+		return 0
+	}
+	return float64(a.Present) * 100 / float64(sittings)
+}
+
+// attendanceName turns the API's "Surname Name" into the "Name Surname" form used in transcripts
+func attendanceName(lastFirstName string) string {
+	parts := strings.Fields(lastFirstName)
+	if len(parts) < 2 {
+		return strings.Join(parts, " ")
+	}
+	return strings.Join(append(parts[1:], parts[0]), " ")
+}
+
+// sittingTranscript is the plain text of one committee sitting transcript
+type sittingTranscript struct {
+	sitting sejm.CommitteeSitting
+	text    string
+	err     error
+}
+
+// fetchSittingTranscripts downloads the HTML transcripts of committee sittings with limited concurrency
+func (s *SejmServer) fetchSittingTranscripts(ctx context.Context, term int, committeeCode string, sittings []sejm.CommitteeSitting) []sittingTranscript {
+	transcripts := make([]sittingTranscript, len(sittings))
+	slots := make(chan struct{}, maxConcurrentBodyFetches)
+	var wg sync.WaitGroup
+	for i, sitting := range sittings {
+		transcripts[i].sitting = sitting
+		wg.Add(1)
+		go func(transcript *sittingTranscript) {
+			defer wg.Done()
+			if err := acquireSlot(ctx, slots); err != nil {
+				transcript.err = err
+				return
+			}
+			defer func() { <-slots }()
+
+			endpoint := fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings/%d/html", s.sejmBaseURL, term, committeeCode, *transcript.sitting.Num)
+			data, err := s.makeTextRequest(ctx, endpoint, "html")
+			if err != nil {
+				transcript.err = err
+				return
+			}
+			transcript.text = strings.ToLower(strings.Join(strings.Fields(htmlToPlainText(string(data))), " "))
+		}(&transcripts[i])
+	}
+	wg.Wait()
+	return transcripts
+}
+
+// computeAttendance marks a member present at every transcript that names them in full
+func computeAttendance(members []sejm.Member, transcripts []sittingTranscript) []memberAttendance {
+	attendance := make([]memberAttendance, 0, len(members))
+	for _, member := range members {
+		if member.LastFirstName == nil {
+			continue
+		}
+		record := memberAttendance{Name: *member.LastFirstName}
+		if member.Club != nil {
+			record.Club = *member.Club
+		}
+		if member.Function != nil {
+			record.Role = committeeMemberRole(*member.Function)
+		}
+		name := strings.ToLower(attendanceName(*member.LastFirstName))
+		for _, transcript := range transcripts {
+			if strings.Contains(transcript.text, name) {
+				record.Present++
+			} else {
+				record.Absences = append(record.Absences, *transcript.sitting.Num)
+			}
+		}
+		attendance = append(attendance, record)
+	}
+	sort.SliceStable(attendance, func(i, j int) bool {
+		if attendance[i].Present != attendance[j].Present {
+			return attendance[i].Present > attendance[j].Present
+		}
+		return attendance[i].Name < attendance[j].Name
+	})
+	return attendance
+}
+
+// attendedSittings selects held sittings within the date range, newest first, up to limit
+func attendedSittings(sittings []sejm.CommitteeSitting, from, to time.Time, limit int) []sejm.CommitteeSitting {
+	today := time.Now().Format("2006-01-02")
+	var selected []sejm.CommitteeSitting
+	for _, sitting := range sittings {
+		if sitting.Num == nil || sitting.Date == nil || sitting.Date.Format("2006-01-02") > today {
+			continue
+		}
+		if sitting.Status != nil && *sitting.Status == sejm.SittingStatusCANCELLED {
+			continue
+		}
+		if !from.IsZero() && sitting.Date.Before(from) {
+			continue
+		}
+		if !to.IsZero() && sitting.Date.After(to) {
+			continue
+		}
+		selected = append(selected, sitting)
+	}
+	sort.SliceStable(selected, func(i, j int) bool { return *selected[i].Num > *selected[j].Num })
+	if len(selected) > limit {
+		selected = selected[:limit]
+	}
+	return selected
+}
+
+func (s *SejmServer) handleGetCommitteeAttendance(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_committee_attendance called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	committeeCode := request.GetString("committee_code", "")
+	if committeeCode == "" {
+		return mcp.NewToolResultError("Committee code is required (e.g., 'ENM', 'ASW'). Get committee codes from sejm_get_committees."), nil
+	}
+	from, err := parseDefectionDate("date_from", request.GetString("date_from", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	to, err := parseDefectionDate("date_to", request.GetString("date_to", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	limit := defaultAttendanceSittings
+	if limitStr := request.GetString("max_sittings", ""); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			limit = parsed
+		}
+		if limit > maxAttendanceSittings {
+			limit = maxAttendanceSittings
+		}
+	}
+
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/committees/%s", s.sejmBaseURL, term, committeeCode), nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve committee %s: %v. Please verify the committee code exists.", committeeCode, err)), nil
+	}
+	var committee sejm.Committee
+	if err := s.decodeAPIResponse(data, &committee); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse committee data: %v.", err)), nil
+	}
+	if committee.Members == nil || len(*committee.Members) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Committee %s has no member list in term %d.", committeeCode, term)), nil
+	}
+
+	data, err = s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings", s.sejmBaseURL, term, committeeCode), nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve sittings for committee %s: %v.", committeeCode, err)), nil
+	}
+	var sittings []sejm.CommitteeSitting
+	if err := s.decodeAPIResponse(data, &sittings); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse committee sittings data: %v.", err)), nil
+	}
+
+	selected := attendedSittings(sittings, from, to, limit)
+	if len(selected) == 0 {
+		response := StandardResponse{
+			Operation:   "Committee Attendance",
+			Status:      "No Results Found",
+			Summary:     []string{fmt.Sprintf("Committee %s held no sittings between %s and %s in term %d", committeeCode, formatOptionalDate(from, "the start of the term"), formatOptionalDate(to, "today"), term)},
+			NextActions: []string{"List the committee's sittings: sejm_get_committee_sittings with committee_code"},
+		}
+		return mcp.NewToolResultText(response.Format()), nil
+	}
+
+	var transcripts []sittingTranscript
+	var unavailable []string
+	for _, transcript := range s.fetchSittingTranscripts(ctx, term, committeeCode, selected) {
+		if transcript.err != nil || transcript.text == "" {
+			unavailable = append(unavailable, strconv.Itoa(int(*transcript.sitting.Num)))
+			continue
+		}
+		transcripts = append(transcripts, transcript)
+	}
+	if len(transcripts) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("None of the %d analyzed sittings of committee %s has a transcript yet (sittings %s). Try an earlier date range.", len(selected), committeeCode, strings.Join(unavailable, ", "))), nil
+	}
+
+	attendance := computeAttendance(*committee.Members, transcripts)
+	var results []string
+	totalPresent := 0
+	for _, member := range attendance {
+		totalPresent += member.Present
+		line := fmt.Sprintf("• %s", member.Name)
+		if member.Club != "" {
+			line += fmt.Sprintf(" (%s)", member.Club)
+		}
+		if member.Role != "" && member.Role != "member" {
+			line += fmt.Sprintf(" [%s]", member.Role)
+		}
+		line += fmt.Sprintf(": %d/%d sittings (%.0f%%)", member.Present, len(transcripts), member.Percentage(len(transcripts)))
+		if len(member.Absences) > 0 {
+			absences := make([]string, 0, maxListedAbsences)
+			for i, num := range member.Absences {
+				if i == maxListedAbsences {
+					absences = append(absences, fmt.Sprintf("and %d more", len(member.Absences)-i))
+					break
+				}
+				absences = append(absences, fmt.Sprintf("#%d", num))
+			}
+			line += fmt.Sprintf(" – not recorded at %s", strings.Join(absences, ", "))
+		}
+		results = append(results, line)
+	}
+
+	committeeName := committeeCode
+	if committee.Name != nil {
+		committeeName = fmt.Sprintf("%s (%s)", *committee.Name, committeeCode)
+	}
+	oldest, newest := transcripts[len(transcripts)-1].sitting, transcripts[0].sitting
+	summary := []string{
+		fmt.Sprintf("Committee: %s, term %d", committeeName, term),
+		fmt.Sprintf("Sittings analyzed: %d (#%d on %s to #%d on %s)", len(transcripts), *oldest.Num, oldest.Date.Format("2006-01-02"), *newest.Num, newest.Date.Format("2006-01-02")),
+		fmt.Sprintf("Members: %d, average attendance %.0f%%", len(attendance), float64(totalPresent)*100/float64(len(attendance)*len(transcripts))),
+	}
+	if len(unavailable) > 0 {
+		summary = append(summary, fmt.Sprintf("Sittings without a transcript (excluded): %s", strings.Join(unavailable, ", ")))
+	}
+
+	response := StandardResponse{
+		Operation: "Committee Attendance",
+		Status:    "Retrieved Successfully",
+		Summary:   summary,
+		Data:      results,
+		NextActions: []string{
+			"Read a sitting transcript: sejm_get_committee_transcript with committee_code and sitting_number",
+			"Older sittings: set date_to before the oldest analyzed sitting",
+			"Member details: sejm_get_committee_members with committee_code",
+		},
+		Note: "The API publishes no committee attendance lists, so a member counts as present when the sitting transcript names them in full (as chair, speaker or in the list of participants). Members who attended without speaking may be missed, so the absence counts are an upper bound rather than proof. Only the current membership is analyzed.",
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
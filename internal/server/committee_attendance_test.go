@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestAttendanceName(t *testing.T) {
+	testCases := map[string]string{
+		"Kowalski Jan":         "Jan Kowalski",
+		"Nowak-Zielińska Anna": "Anna Nowak-Zielińska",
+		"Wiśniewski Jan Maria": "Jan Maria Wiśniewski",
+		"Solo":                 "Solo",
+	}
+	for input, expected := range testCases {
+		if got := attendanceName(input); got != expected {
+			t.Errorf("attendanceName(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestHandleGetCommitteeAttendance(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/committees/ENM": `{"code": "ENM", "name": "Komisja do Spraw Energii", "members": [
+			{"lastFirstName": "Kowalski Jan", "club": "KO", "function": "przewodniczący"},
+			{"lastFirstName": "Nowak Anna", "club": "PiS"},
+			{"lastFirstName": "Wiśniewska Ewa", "club": "Lewica"}
+		]}`,
+		"/sejm/term10/committees/ENM/sittings": `[
+			{"num": 1, "date": "2024-01-10", "status": "FINISHED"},
+			{"num": 2, "date": "2024-02-10", "status": "FINISHED"},
+			{"num": 3, "date": "2024-03-10", "status": "CANCELLED"},
+			{"num": 4, "date": "2024-04-10", "status": "FINISHED"},
+			{"num": 5, "date": "2999-01-01", "status": "PLANNED"}
+		]`,
+		"/sejm/term10/committees/ENM/sittings/1/html": `<p>Przewodniczący poseł <b>Jan Kowalski</b>:</p><p>Poseł Anna Nowak:</p>`,
+		"/sejm/term10/committees/ENM/sittings/2/html": `<p>Przewodniczący poseł Jan   Kowalski:</p>`,
+	})
+
+	result, err := server.handleGetCommitteeAttendance(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "committee_code": "ENM",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Sittings analyzed: 2 (#1 on 2024-01-10 to #2 on 2024-02-10)",
+		"Sittings without a transcript (excluded): 4",
+		"• Kowalski Jan (KO) [chairman]: 2/2 sittings (100%)",
+		"• Nowak Anna (PiS): 1/2 sittings (50%) – not recorded at #2",
+		"• Wiśniewska Ewa (Lewica): 0/2 sittings (0%) – not recorded at #2, #1",
+		"Members: 3, average attendance 50%",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+
+	result, _ = server.handleGetCommitteeAttendance(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "committee_code": "ENM", "date_from": "2025-01-01",
+	}))
+	if result.IsError || !strings.Contains(extractTextContent(result), "held no sittings") {
+		t.Errorf("Expected no sittings in range, got: %s", extractTextContent(result))
+	}
+}
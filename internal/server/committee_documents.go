@@ -0,0 +1,261 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Kinds of committee documents published on sejm.gov.pl
+const (
+	committeeDocumentDesiderata = "desiderata"
+	committeeDocumentOpinions   = "opinions"
+)
+
+// maxCommitteeDocumentPages bounds the pages linked from the committee page that are followed to find documents
+const maxCommitteeDocumentPages = 4
+
+var (
+	// committeeDocumentNumberPattern captures the number of a desideratum or opinion, e.g. "Dezyderat nr 12"
+	committeeDocumentNumberPattern = regexp.MustCompile(`(?i)\b(?:nr|numer)\.?\s*(\d+)`)
+	// committeeDocumentDatePattern captures a date written as dd-mm-yyyy or dd.mm.yyyy
+	committeeDocumentDatePattern = regexp.MustCompile(`\b(\d{1,2})[.\-](\d{1,2})[.\-]((?:19|20)\d{2})\b`)
+)
+
+// committeeDocument is a desideratum (dezyderat) or an opinion (opinia) adopted by a committee
+type committeeDocument struct {
+	Kind   string `json:"kind"`
+	Number int    `json:"number,omitempty"`
+	Title  string `json:"title"`
+	Date   string `json:"date,omitempty"`
+	URL    string `json:"url"`
+}
+
+// committeePageURL returns the committee's page on sejm.gov.pl. Like MP profiles, the page address pattern is
+// only stable from term 7 on.
+func committeePageURL(term int, code string) string {
+	if term < 7 {
+		return ""
+	}
+	return fmt.Sprintf("https://www.sejm.gov.pl/Sejm%d.nsf/agent.xsp?symbol=KOMISJAST&NrKadencji=%d&KodKom=%s", term, term, url.QueryEscape(code))
+}
+
+// committeeDocumentKind classifies a link by its text and address; it returns an empty kind for other links
+func committeeDocumentKind(href, text string) string {
+	label := normalizePolish(text + " " + href)
+	switch {
+	case strings.Contains(label, "dezyderat"):
+		return committeeDocumentDesiderata
+	case strings.Contains(label, "opini"):
+		return committeeDocumentOpinions
+	}
+	return ""
+}
+
+// parseCommitteeDocumentLinks finds desiderata and opinions linked from a page of the Sejm website, and the
+// pages (e.g. the 'Dezyderaty' tab) that may list more of them. Documents on a page listing one kind are of
+// that kind even when the link text is only "pdf".
+func parseCommitteeDocumentLinks(page string, base *url.URL, pageKind string) ([]committeeDocument, []string) {
+	var documents []committeeDocument
+	var tabs []string
+	for _, match := range htmlLinkPattern.FindAllStringSubmatch(page, -1) {
+		link, err := base.Parse(strings.TrimSpace(htmlToPlainText(match[1])))
+		if err != nil || !isSejmWebsiteURL(link) {
+			continue
+		}
+		text := strings.Join(strings.Fields(htmlToPlainText(match[2])), " ")
+		kind := committeeDocumentKind(link.String(), text)
+		if !isDocumentLink(link) {
+			if kind != "" {
+				tabs = append(tabs, link.String())
+			}
+			continue
+		}
+		if kind == "" {
+			kind = pageKind
+		}
+		if kind == "" {
+			continue
+		}
+		document := committeeDocument{Kind: kind, Title: valueOrDefault(text, path.Base(link.Path)), URL: link.String()}
+		if number := committeeDocumentNumberPattern.FindStringSubmatch(text); number != nil {
+			document.Number, _ = strconv.Atoi(number[1])
+		}
+		if date := committeeDocumentDatePattern.FindStringSubmatch(text); date != nil {
+			day, _ := strconv.Atoi(date[1])
+			month, _ := strconv.Atoi(date[2])
+			document.Date = fmt.Sprintf("%s-%02d-%02d", date[3], month, day)
+		}
+		documents = append(documents, document)
+	}
+	return documents, tabs
+}
+
+// fetchCommitteeDocuments lists the desiderata and opinions linked from a committee's page on sejm.gov.pl and
+// the pages it links to, desiderata first and newest first. The Sejm API does not publish them.
+func (s *SejmServer) fetchCommitteeDocuments(ctx context.Context, term int, code string) ([]committeeDocument, error) {
+	page := committeePageURL(term, code)
+	if page == "" {
+		return nil, fmt.Errorf("committee pages on sejm.gov.pl are only available from term 7")
+	}
+	base, _ := url.Parse(page)
+	data, err := s.makeTextRequest(ctx, page, "html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve committee page: %w", err)
+	}
+	documents, tabs := parseCommitteeDocumentLinks(string(data), base, "")
+
+	visited := map[string]bool{page: true}
+	for _, tab := range tabs {
+		if visited[tab] || len(visited) > maxCommitteeDocumentPages {
+			continue
+		}
+		visited[tab] = true
+		tabURL, _ := url.Parse(tab)
+		data, err := s.makeTextRequest(ctx, tab, "html")
+		if err != nil {
+			s.logger.Warn("Failed to retrieve committee document page", slog.String("url", tab), slog.Any("error", err))
+			continue
+		}
+		more, _ := parseCommitteeDocumentLinks(string(data), tabURL, committeeDocumentKind(tab, ""))
+		documents = append(documents, more...)
+	}
+
+	seen := make(map[string]bool)
+	var unique []committeeDocument
+	for _, document := range documents {
+		if !seen[document.URL] {
+			seen[document.URL] = true
+			unique = append(unique, document)
+		}
+	}
+	sort.SliceStable(unique, func(i, j int) bool {
+		if unique[i].Kind != unique[j].Kind {
+			return unique[i].Kind == committeeDocumentDesiderata
+		}
+		if unique[i].Number != unique[j].Number {
+			return unique[i].Number > unique[j].Number
+		}
+		return unique[i].Date > unique[j].Date
+	})
+	return unique, nil
+}
+
+func (s *SejmServer) handleGetCommitteeDocuments(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_committee_documents called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	committeeCode := strings.ToUpper(strings.TrimSpace(request.GetString("committee_code", "")))
+	if committeeCode == "" {
+		return mcp.NewToolResultError("Committee code is required (e.g., 'ENM', 'ASW'). Get committee codes from sejm_get_committees."), nil
+	}
+	kind := strings.ToLower(request.GetString("kind", "all"))
+	if kind != "all" && kind != committeeDocumentDesiderata && kind != committeeDocumentOpinions {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid kind '%s'. Use 'desiderata', 'opinions' or 'all'.", kind)), nil
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	documents, err := s.fetchCommitteeDocuments(ctx, term, committeeCode)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list documents of committee %s in term %d: %v. Please verify the committee code exists.", committeeCode, term, err)), nil
+	}
+	if kind != "all" {
+		var filtered []committeeDocument
+		for _, document := range documents {
+			if document.Kind == kind {
+				filtered = append(filtered, document)
+			}
+		}
+		documents = filtered
+	}
+
+	if format == "json" {
+		result, _ := json.MarshalIndent(map[string]interface{}{
+			"term":          term,
+			"committeeCode": committeeCode,
+			"page":          committeePageURL(term, committeeCode),
+			"documents":     documents,
+		}, "", "  ")
+		return mcp.NewToolResultText(string(result)), nil
+	}
+
+	counts := make(map[string]int)
+	for _, document := range documents {
+		counts[document.Kind]++
+	}
+	summary := []string{
+		fmt.Sprintf("Committee %s, term %d", committeeCode, term),
+		fmt.Sprintf("Desiderata (dezyderaty): %d", counts[committeeDocumentDesiderata]),
+		fmt.Sprintf("Opinions (opinie): %d", counts[committeeDocumentOpinions]),
+		fmt.Sprintf("Committee page: %s", committeePageURL(term, committeeCode)),
+	}
+	var results []string
+	status := "Retrieved Successfully"
+	if len(documents) == 0 {
+		status = "No Results Found"
+		results = append(results, "No desiderata or opinions are linked from the committee's page. The committee may not have adopted any in this term yet.")
+	}
+	for _, document := range documents {
+		label := "Desideratum"
+		if document.Kind == committeeDocumentOpinions {
+			label = "Opinion"
+		}
+		if document.Number > 0 {
+			label += fmt.Sprintf(" no. %d", document.Number)
+		}
+		if document.Date != "" {
+			label += fmt.Sprintf(" (%s)", document.Date)
+		}
+		results = append(results, fmt.Sprintf("• %s: %s", label, truncateRunes(document.Title, 250)), "  "+document.URL)
+	}
+
+	response := StandardResponse{
+		Operation: "Committee Documents",
+		Status:    status,
+		Summary:   summary,
+		Data:      results,
+		NextActions: []string{
+			"Text of a document: sejm_get_committee_document_text with url",
+			"The PDF itself: sejm_get_committee_document_text with url and return_content='blob'",
+			fmt.Sprintf("Committee sittings: sejm_get_committee_sittings with committee_code='%s'", committeeCode),
+		},
+		Note: "Desiderata and opinions are read from the committee's page on sejm.gov.pl, because the Sejm API does not publish them. A desideratum is addressed to the government, which has 30 days to reply; opinions are mostly addressed to other committees.",
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
+
+func (s *SejmServer) handleGetCommitteeDocumentText(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_committee_document_text called", slog.Any("arguments", request.Params.Arguments))
+
+	document, err := url.Parse(strings.TrimSpace(request.GetString("url", "")))
+	if err != nil || !isSejmWebsiteURL(document) || !isDocumentLink(document) {
+		return mcp.NewToolResultError("Parameter 'url' must be the address of a desideratum or opinion document on sejm.gov.pl, as listed by sejm_get_committee_documents."), nil
+	}
+	if _, _, err := parseAttachmentDelivery(request.GetString("return_content", ""), request.GetString("max_size_bytes", "")); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	endpoint := document.String()
+	data, err := s.makeAPIRequestWithHeaders(ctx, endpoint, nil, map[string]string{"Accept": "*/*"})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to download the committee document: %v.", err)), nil
+	}
+	uri := fmt.Sprintf("%scommittee-documents/%s", attachmentResourceScheme, strings.TrimPrefix(document.Host+document.Path, "/"))
+	return s.documentFileResult(ctx, request, "sejm_get_committee_document_text", path.Base(document.Path), uri, endpoint, data,
+		"; older documents are sometimes scans")
+}
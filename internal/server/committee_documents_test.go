@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const committeePageFixture = `<ul class="tabs">
+<li><a href="/Sejm10.nsf/dezyderaty.xsp?komisja=ENM">Dezyderaty</a></li>
+<li><a href="/Sejm10.nsf/opinie.xsp?komisja=ENM">Opinie</a></li>
+<li><a href="https://example.com/opinie.pdf">Opinia zewnętrzna</a></li>
+</ul>`
+
+const committeeDesiderataFixture = `<table>
+<tr><td>Dezyderat nr 2 w sprawie cyfryzacji szkół (12.03.2024)</td><td><a href="https://orka.sejm.gov.pl/SQL2.nsf/dezyd/ENM-2.pdf">pdf</a></td></tr>
+<tr><td><a href='https://orka.sejm.gov.pl/SQL2.nsf/dezyd/ENM-5.pdf'>Dezyderat nr 5 w sprawie wsparcia nauczycieli, 5-06-2024</a></td></tr>
+<tr><td><a href="https://orka.sejm.gov.pl/SQL2.nsf/dezyd/ENM-2.pdf">Dezyderat nr 2</a></td></tr>
+</table>`
+
+const committeeOpinionsFixture = `<a href="https://orka.sejm.gov.pl/SQL2.nsf/opinie/ENM-1.pdf">Opinia nr 1 dla Komisji Finansów Publicznych</a>`
+
+func TestParseCommitteeDocumentLinks(t *testing.T) {
+	base, _ := url.Parse("https://www.sejm.gov.pl/Sejm10.nsf/agent.xsp?symbol=KOMISJAST&NrKadencji=10&KodKom=ENM")
+	documents, tabs := parseCommitteeDocumentLinks(committeePageFixture, base, "")
+	if len(documents) != 0 || len(tabs) != 2 || tabs[0] != "https://www.sejm.gov.pl/Sejm10.nsf/dezyderaty.xsp?komisja=ENM" {
+		t.Fatalf("Expected the two tabs on sejm.gov.pl, got %+v %v", documents, tabs)
+	}
+
+	documents, _ = parseCommitteeDocumentLinks(committeeDesiderataFixture, base, committeeDocumentDesiderata)
+	if len(documents) != 3 || documents[0].Kind != committeeDocumentDesiderata || documents[0].Title != "pdf" {
+		t.Fatalf("Expected the desiderata with the kind of the page, got %+v", documents)
+	}
+	if documents[1].Number != 5 || documents[1].Date != "2024-06-05" {
+		t.Errorf("Expected the number and date from the link text, got %+v", documents[1])
+	}
+}
+
+func TestHandleGetCommitteeDocuments(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/Sejm10.nsf/agent.xsp":      committeePageFixture,
+		"/Sejm10.nsf/dezyderaty.xsp": committeeDesiderataFixture,
+		"/Sejm10.nsf/opinie.xsp":     committeeOpinionsFixture,
+		"/SQL2.nsf/dezyd/ENM-5.pdf":  "not a text layer",
+	})
+
+	result, err := server.handleGetCommitteeDocuments(context.Background(), createMockRequest(map[string]interface{}{
+		"committee_code": "enm", "format": "json",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	var response struct {
+		Documents []committeeDocument `json:"documents"`
+	}
+	if err := json.Unmarshal([]byte(extractTextContent(result)), &response); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(response.Documents) != 3 {
+		t.Fatalf("Expected 3 unique documents, got %+v", response.Documents)
+	}
+	if response.Documents[0].Number != 5 || response.Documents[2].Kind != committeeDocumentOpinions {
+		t.Errorf("Expected desiderata newest first, then opinions, got %+v", response.Documents)
+	}
+
+	result, _ = server.handleGetCommitteeDocuments(context.Background(), createMockRequest(map[string]interface{}{
+		"committee_code": "ENM", "kind": "opinions",
+	}))
+	text := extractTextContent(result)
+	if !strings.Contains(text, "Opinions (opinie): 1") || !strings.Contains(text, "• Opinion no. 1: Opinia nr 1 dla Komisji Finansów Publicznych") || strings.Contains(text, "ENM-5") {
+		t.Errorf("Expected only the opinion, got: %s", text)
+	}
+
+	for _, args := range []map[string]interface{}{
+		{},
+		{"committee_code": "ENM", "kind": "letters"},
+		{"committee_code": "ENM", "term": "6"},
+	} {
+		result, _ := server.handleGetCommitteeDocuments(context.Background(), createMockRequest(args))
+		if !result.IsError {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+
+	for _, address := range []string{"", "https://example.com/dezyderat.pdf", "https://www.sejm.gov.pl/Sejm10.nsf/dezyderaty.xsp?komisja=ENM"} {
+		result, _ := server.handleGetCommitteeDocumentText(context.Background(), createMockRequest(map[string]interface{}{"url": address}))
+		if !result.IsError {
+			t.Errorf("Expected an error for url '%s'", address)
+		}
+	}
+	result, _ = server.handleGetCommitteeDocumentText(context.Background(), createMockRequest(map[string]interface{}{
+		"url": "https://orka.sejm.gov.pl/SQL2.nsf/dezyd/ENM-5.pdf",
+	}))
+	if !result.IsError || !strings.Contains(extractTextContent(result), "return_content='blob'") {
+		t.Errorf("Expected a document without text to suggest downloading the file, got: %s", extractTextContent(result))
+	}
+}
@@ -0,0 +1,271 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Limits of sejm_list_committee_transcripts
+const (
+	defaultTranscriptListSittings = 30
+	maxTranscriptListSittings     = 100
+)
+
+// transcriptFile is the availability of one format of a committee sitting transcript
+type transcriptFile struct {
+	Available    bool   `json:"available"`
+	SizeBytes    int64  `json:"sizeBytes,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	err          error
+}
+
+// committeeTranscriptAvailability tells which transcript formats of a committee sitting can be downloaded
+type committeeTranscriptAvailability struct {
+	Sitting int32          `json:"sitting"`
+	Date    string         `json:"date"`
+	Closed  bool           `json:"closed,omitempty"`
+	HTML    transcriptFile `json:"html"`
+	PDF     transcriptFile `json:"pdf"`
+}
+
+// missing tells whether neither format is published
+func (a committeeTranscriptAvailability) missing() bool {
+	return !a.HTML.Available && !a.PDF.Available
+}
+
+// transcriptFileInfo checks whether a transcript can be downloaded without downloading it. A HEAD request
+// gives the size and date; when the API does not answer HEAD requests, the transcript is downloaded instead.
+// A 404 means the transcript is not published; other failures are returned in err.
+func (s *SejmServer) transcriptFileInfo(ctx context.Context, endpoint string) transcriptFile {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint, nil)
+	if err != nil {
+		return transcriptFile{err: err}
+	}
+	// The size of the body as stored, not of its compressed form
+	req.Header.Set("Accept-Encoding", "identity")
+	resp, err := s.client.Do(req)
+	s.health.record(endpoint, statusCodeOf(resp), err)
+	if err != nil {
+		return transcriptFile{err: err}
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		file := transcriptFile{Available: true, LastModified: resp.Header.Get("Last-Modified")}
+		if resp.ContentLength > 0 {
+			file.SizeBytes = resp.ContentLength
+		}
+		return file
+	case resp.StatusCode == http.StatusNotFound:
+		return transcriptFile{}
+	case resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented:
+		data, err := s.makeAPIRequestWithHeaders(ctx, endpoint, nil, map[string]string{"Accept": "*/*"})
+		if err != nil {
+			if strings.Contains(err.Error(), "404") {
+				return transcriptFile{}
+			}
+			return transcriptFile{err: err}
+		}
+		return transcriptFile{Available: len(data) > 0, SizeBytes: int64(len(data))}
+	}
+	return transcriptFile{err: fmt.Errorf("HTTP %d", resp.StatusCode)}
+}
+
+// statusCodeOf returns the status of a response, or 0 when there is none
+func statusCodeOf(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+// formatTranscriptFile describes one transcript format, e.g. 'PDF 1.2 MB'
+func formatTranscriptFile(name string, file transcriptFile) string {
+	switch {
+	case file.err != nil:
+		return name + " unknown"
+	case !file.Available:
+		return name + " missing"
+	case file.SizeBytes > 0:
+		return fmt.Sprintf("%s %s", name, formatByteSize(file.SizeBytes))
+	}
+	return name + " ✓"
+}
+
+// formatByteSize prints a size in bytes, kB or MB
+func formatByteSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%d kB", size>>10)
+	}
+	return fmt.Sprintf("%d B", size)
+}
+
+func (s *SejmServer) handleListCommitteeTranscripts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_list_committee_transcripts called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	committeeCode := strings.ToUpper(strings.TrimSpace(request.GetString("committee_code", "")))
+	if committeeCode == "" {
+		return mcp.NewToolResultError("Committee code is required (e.g., 'ENM', 'ASW'). Get committee codes from sejm_get_committees."), nil
+	}
+	from, err := parseDefectionDate("date_from", request.GetString("date_from", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	to, err := parseDefectionDate("date_to", request.GetString("date_to", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	limit := defaultTranscriptListSittings
+	if limitStr := request.GetString("max_sittings", ""); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid max_sittings '%s'. Use a positive number (max %d).", limitStr, maxTranscriptListSittings)), nil
+		}
+		limit = min(parsed, maxTranscriptListSittings)
+	}
+	missingOnly := request.GetString("missing_only", "false") == "true"
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings", s.sejmBaseURL, term, committeeCode), nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve sittings for committee %s: %v. Please verify the committee code exists.", committeeCode, err)), nil
+	}
+	var sittings []sejm.CommitteeSitting
+	if err := s.decodeAPIResponse(data, &sittings); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse committee sittings data: %v.", err)), nil
+	}
+	selected := attendedSittings(sittings, from, to, limit)
+	if len(selected) == 0 {
+		response := StandardResponse{
+			Operation:   "Committee Transcripts",
+			Status:      "No Results Found",
+			Summary:     []string{fmt.Sprintf("Committee %s held no sittings between %s and %s in term %d", committeeCode, formatOptionalDate(from, "the start of the term"), formatOptionalDate(to, "today"), term)},
+			NextActions: []string{"List the committee's sittings: sejm_get_committee_sittings with committee_code"},
+		}
+		return mcp.NewToolResultText(response.Format()), nil
+	}
+
+	// Both formats of every sitting are checked with limited concurrency
+	progress := s.newProgressReporter(ctx, request)
+	availability := make([]committeeTranscriptAvailability, len(selected))
+	slots := make(chan struct{}, maxConcurrentBodyFetches)
+	var wg sync.WaitGroup
+	var progressMu sync.Mutex
+	done := 0
+	for i, sitting := range selected {
+		availability[i] = committeeTranscriptAvailability{Sitting: *sitting.Num, Date: sitting.Date.String(), Closed: sitting.Closed != nil && *sitting.Closed}
+		wg.Add(1)
+		go func(entry *committeeTranscriptAvailability) {
+			defer wg.Done()
+			if err := acquireSlot(ctx, slots); err != nil {
+				entry.HTML.err, entry.PDF.err = err, err
+				return
+			}
+			defer func() { <-slots }()
+			base := fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings/%d", s.sejmBaseURL, term, committeeCode, entry.Sitting)
+			entry.HTML = s.transcriptFileInfo(ctx, base+"/html")
+			entry.PDF = s.transcriptFileInfo(ctx, base+"/pdf")
+			progressMu.Lock()
+			done++
+			progress.report(done, len(selected), fmt.Sprintf("Checked sitting %d (%d of %d)", entry.Sitting, done, len(selected)))
+			progressMu.Unlock()
+		}(&availability[i])
+	}
+	wg.Wait()
+
+	coverage := newSourceCoverage("transcript checks")
+	var listed []committeeTranscriptAvailability
+	htmlCount, pdfCount, missing := 0, 0, 0
+	for _, entry := range availability {
+		for _, file := range []struct {
+			name string
+			file transcriptFile
+		}{{"html", entry.HTML}, {"pdf", entry.PDF}} {
+			if file.file.err != nil {
+				coverage.fail(fmt.Sprintf("sitting %d %s", entry.Sitting, file.name), file.file.err)
+			} else {
+				coverage.succeeded()
+			}
+		}
+		if entry.HTML.Available {
+			htmlCount++
+		}
+		if entry.PDF.Available {
+			pdfCount++
+		}
+		if entry.missing() && entry.HTML.err == nil && entry.PDF.err == nil {
+			missing++
+		}
+		if !missingOnly || !entry.HTML.Available || !entry.PDF.Available {
+			listed = append(listed, entry)
+		}
+	}
+
+	if format == "json" {
+		result, _ := json.MarshalIndent(map[string]interface{}{
+			"term":        term,
+			"committee":   committeeCode,
+			"checked":     len(availability),
+			"html":        htmlCount,
+			"pdf":         pdfCount,
+			"missing":     missing,
+			"sittings":    listed,
+			"unavailable": coverage.failed,
+		}, "", "  ")
+		return coverage.result(string(result)), nil
+	}
+
+	summary := []string{
+		fmt.Sprintf("Committee: %s, term %d", committeeCode, term),
+		fmt.Sprintf("Sittings checked: %d (newest first, %s to %s)", len(availability), availability[len(availability)-1].Date, availability[0].Date),
+		fmt.Sprintf("HTML transcripts: %d, PDF transcripts: %d, no transcript: %d", htmlCount, pdfCount, missing),
+	}
+	var results []string
+	for _, entry := range listed {
+		line := fmt.Sprintf("• Sitting #%d (%s): %s, %s", entry.Sitting, entry.Date, formatTranscriptFile("HTML", entry.HTML), formatTranscriptFile("PDF", entry.PDF))
+		if entry.Closed {
+			line += " [closed sitting]"
+		}
+		results = append(results, line)
+	}
+	if len(results) == 0 {
+		results = append(results, "Every checked sitting has both an HTML and a PDF transcript.")
+	}
+
+	response := StandardResponse{
+		Operation:   "Committee Transcripts",
+		Status:      coverage.status("Retrieved Successfully"),
+		Summary:     summary,
+		Unavailable: coverage.unavailable(),
+		Data:        results,
+		NextActions: []string{
+			"Read a transcript: sejm_get_committee_transcript with committee_code and sitting_number (format='html' or 'text' for the PDF)",
+			"Sitting agenda: sejm_get_committee_sitting_details with committee_code and sitting_number",
+			"Older sittings: set date_to or raise max_sittings",
+		},
+		Note: "Transcripts are usually published a few weeks after a sitting, and closed sittings often have none. Sizes are given when the API reports them.",
+	}
+	return coverage.result(response.Format()), nil
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestHandleListCommitteeTranscripts(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/committees/ENM/sittings": `[
+			{"num": 1, "date": "2024-01-10"},
+			{"num": 2, "date": "2024-02-10", "closed": true},
+			{"num": 3, "date": "2024-03-10", "status": "CANCELLED"},
+			{"num": 4, "date": "2099-01-01"}
+		]`,
+		"/sejm/term10/committees/ENM/sittings/1/html": "<html><body>Posiedzenie komisji</body></html>",
+		"/sejm/term10/committees/ENM/sittings/1/pdf":  "%PDF-1.4 transcript",
+	})
+
+	result, err := server.handleListCommitteeTranscripts(context.Background(), createMockRequest(map[string]interface{}{
+		"committee_code": "enm", "format": "json",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	var response struct {
+		Checked  int                               `json:"checked"`
+		Missing  int                               `json:"missing"`
+		Sittings []committeeTranscriptAvailability `json:"sittings"`
+	}
+	if err := json.Unmarshal([]byte(extractTextContent(result)), &response); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if response.Checked != 2 || response.Missing != 1 || len(response.Sittings) != 2 {
+		t.Fatalf("Expected the two held sittings, one without transcripts, got %+v", response)
+	}
+	if first := response.Sittings[1]; first.Sitting != 1 || !first.HTML.Available || !first.PDF.Available {
+		t.Errorf("Expected both formats of sitting 1, got %+v", first)
+	}
+	if closed := response.Sittings[0]; closed.Sitting != 2 || !closed.Closed || !closed.missing() {
+		t.Errorf("Expected the closed sitting 2 without transcripts, got %+v", closed)
+	}
+
+	result, _ = server.handleListCommitteeTranscripts(context.Background(), createMockRequest(map[string]interface{}{
+		"committee_code": "ENM", "missing_only": "true",
+	}))
+	text := extractTextContent(result)
+	if !strings.Contains(text, "Sitting #2 (2024-02-10): HTML missing, PDF missing [closed sitting]") || strings.Contains(text, "Sitting #1 ") {
+		t.Errorf("Expected only the sitting without transcripts, got: %s", text)
+	}
+
+	result, _ = server.handleListCommitteeTranscripts(context.Background(), createMockRequest(map[string]interface{}{}))
+	if !result.IsError {
+		t.Error("Expected an error without committee_code")
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	for size, expected := range map[int64]string{512: "512 B", 4096: "4 kB", 3 << 20: "3.0 MB"} {
+		if got := formatByteSize(size); got != expected {
+			t.Errorf("formatByteSize(%d) = %q, want %q", size, got, expected)
+		}
+	}
+}
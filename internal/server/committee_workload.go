@@ -0,0 +1,288 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Limits of sejm_get_committee_workload
+const (
+	defaultWorkloadLimit = 50
+	maxWorkloadLimit     = 500
+)
+
+// workloadItem is a process waiting in a committee
+type workloadItem struct {
+	Process         string
+	Title           string
+	Stage           string
+	ReferredOn      string
+	DaysInCommittee int
+	ProcessStart    string
+	ProcessDays     int
+	LatestStep      string
+	LatestStepDate  string
+	Reported        bool
+	Committees      []string
+}
+
+// latestSubstage returns the name and date of the most recent dated stage in a stage tree
+func latestSubstage(stage processTimelineStageSource) (string, time.Time, bool) {
+	name, latest, found := stage.StageName, time.Time{}, false
+	if date, ok := parseProcessDate(stage.Date); ok {
+		latest, found = date, true
+	}
+	for _, child := range stage.Children {
+		if childName, date, ok := latestSubstage(child); ok && (!found || !date.Before(latest)) {
+			name, latest, found = childName, date, true
+		}
+	}
+	return name, latest, found
+}
+
+// stageReported tells whether a committee stage holds the committee's report (sprawozdanie), which ends its work
+func stageReported(stage processTimelineStageSource) bool {
+	if strings.HasPrefix(strings.ToLower(stage.StageName), "sprawozdanie") {
+		return true
+	}
+	for _, child := range stage.Children {
+		if stageReported(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// committeeWorkloadItem returns the queue entry of a process when its current stage is handled by the committee
+func committeeWorkloadItem(term int, number string, data []byte, committeeCode string, asOf time.Time) (workloadItem, bool, error) {
+	var source struct {
+		Title  string                       `json:"title"`
+		Stages []processTimelineStageSource `json:"stages"`
+	}
+	if err := json.Unmarshal(data, &source); err != nil {
+		return workloadItem{}, false, fmt.Errorf("failed to parse process stages: %w", err)
+	}
+	timeline, err := buildProcessTimeline(term, number, data, asOf)
+	if err != nil || len(timeline.Stages) == 0 {
+		return workloadItem{}, false, err
+	}
+
+	current := timeline.Stages[len(timeline.Stages)-1]
+	matched := false
+	for _, code := range current.Committees {
+		if strings.EqualFold(code, committeeCode) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return workloadItem{}, false, nil
+	}
+
+	stage := source.Stages[len(source.Stages)-1]
+	item := workloadItem{
+		Process:      number,
+		Title:        source.Title,
+		Stage:        current.Stage,
+		ReferredOn:   current.Start,
+		ProcessStart: timeline.Start,
+		Reported:     stageReported(stage),
+		Committees:   current.Committees,
+	}
+	if current.DurationDays != nil {
+		item.DaysInCommittee = *current.DurationDays
+	}
+	if timeline.TotalDays != nil {
+		item.ProcessDays = *timeline.TotalDays
+	}
+	if name, date, ok := latestSubstage(stage); ok {
+		item.LatestStep, item.LatestStepDate = name, date.Format("2006-01-02")
+	}
+	return item, true, nil
+}
+
+// openProcessNumbers lists the processes of a term that are neither passed nor closed
+func (s *SejmServer) openProcessNumbers(ctx context.Context, term int) ([]string, error) {
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/processes", s.sejmBaseURL, term), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve processes: %w", err)
+	}
+	var processes []sejm.ProcessHeader
+	if err := s.decodeAPIResponse(data, &processes); err != nil {
+		return nil, fmt.Errorf("failed to parse processes: %w", err)
+	}
+	var numbers []string
+	for _, process := range processes {
+		if process.Number == nil || (process.Passed != nil && *process.Passed) || process.ClosureDate != nil {
+			continue
+		}
+		numbers = append(numbers, *process.Number)
+	}
+	return numbers, nil
+}
+
+func (s *SejmServer) handleGetCommitteeWorkload(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_committee_workload called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	committeeCode := strings.ToUpper(strings.TrimSpace(request.GetString("committee_code", "")))
+	if committeeCode == "" {
+		return mcp.NewToolResultError("Parameter 'committee_code' is required. Get committee codes from sejm_get_committees."), nil
+	}
+	includeReported := request.GetString("include_reported", "false") == "true"
+	limit := defaultWorkloadLimit
+	if limitStr := request.GetString("limit", ""); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid limit '%s'. Use a positive number (max %d).", limitStr, maxWorkloadLimit)), nil
+		}
+		limit = min(parsed, maxWorkloadLimit)
+	}
+
+	numbers, err := s.openProcessNumbers(ctx, term)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list legislative processes: %v", err)), nil
+	}
+
+	// Process details hold the stages; they are fetched concurrently and failures are reported with the results
+	asOf := time.Now()
+	progress := s.newProgressReporter(ctx, request)
+	details := make([][]byte, len(numbers))
+	failures := make([]error, len(numbers))
+	slots := make(chan struct{}, maxConcurrentBodyFetches)
+	var wg sync.WaitGroup
+	var progressMu sync.Mutex
+	done := 0
+	for i, number := range numbers {
+		wg.Add(1)
+		go func(i int, number string) {
+			defer wg.Done()
+			if err := acquireSlot(ctx, slots); err != nil {
+				failures[i] = err
+				return
+			}
+			defer func() { <-slots }()
+			defer func() {
+				progressMu.Lock()
+				done++
+				progress.report(done, len(numbers), fmt.Sprintf("Checked process %s (%d of %d)", number, done, len(numbers)))
+				progressMu.Unlock()
+			}()
+			details[i], failures[i] = s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/processes/%s", s.sejmBaseURL, term, number), nil)
+		}(i, number)
+	}
+	wg.Wait()
+
+	coverage := newSourceCoverage("processes")
+	var queue []workloadItem
+	reported := 0
+	for i, number := range numbers {
+		if failures[i] != nil {
+			coverage.fail("process "+number, failures[i])
+			continue
+		}
+		item, ok, err := committeeWorkloadItem(term, number, details[i], committeeCode, asOf)
+		if err != nil {
+			coverage.fail("process "+number, err)
+			continue
+		}
+		coverage.succeeded()
+		if !ok {
+			continue
+		}
+		if item.Reported {
+			reported++
+			if !includeReported {
+				continue
+			}
+		}
+		queue = append(queue, item)
+	}
+	sort.SliceStable(queue, func(i, j int) bool {
+		if queue[i].DaysInCommittee != queue[j].DaysInCommittee {
+			return queue[i].DaysInCommittee > queue[j].DaysInCommittee
+		}
+		return queue[i].Process < queue[j].Process
+	})
+
+	summary := []string{
+		fmt.Sprintf("Committee: %s (term %d)", committeeCode, term),
+		fmt.Sprintf("Open processes checked: %d", len(numbers)),
+		fmt.Sprintf("Processes in the committee's queue: %d", len(queue)),
+	}
+	if includeReported {
+		summary = append(summary, fmt.Sprintf("Including %d processes the committee has already reported on", reported))
+	} else if reported > 0 {
+		summary = append(summary, fmt.Sprintf("Already reported, awaiting the next reading (not listed): %d", reported))
+	}
+	if len(queue) > 0 {
+		total := 0
+		for _, item := range queue {
+			total += item.DaysInCommittee
+		}
+		summary = append(summary, fmt.Sprintf("Days in committee: oldest %d, average %d", queue[0].DaysInCommittee, total/len(queue)))
+	}
+
+	var data []string
+	for i, item := range queue {
+		if i == limit {
+			data = append(data, fmt.Sprintf("... and %d more processes (raise limit to see them)", len(queue)-limit))
+			break
+		}
+		line := fmt.Sprintf("• Process %s: %s", item.Process, valueOrDefault(item.Title, "No title"))
+		data = append(data, line)
+		detail := fmt.Sprintf("  Stage: %s", item.Stage)
+		if item.ReferredOn != "" {
+			detail += fmt.Sprintf(" since %s (%d days)", item.ReferredOn, item.DaysInCommittee)
+		}
+		if item.ProcessStart != "" {
+			detail += fmt.Sprintf("; process started %s (%d days ago)", item.ProcessStart, item.ProcessDays)
+		}
+		data = append(data, detail)
+		status := "  Status: " + valueOrDefault(item.LatestStep, "no dated steps")
+		if item.LatestStepDate != "" {
+			status += fmt.Sprintf(" (%s)", item.LatestStepDate)
+		}
+		if item.Reported {
+			status += "; committee report submitted"
+		}
+		if len(item.Committees) > 1 {
+			status += fmt.Sprintf("; shared with %s", strings.Join(item.Committees, ", "))
+		}
+		data = append(data, status)
+	}
+
+	status := "Retrieved Successfully"
+	if len(queue) == 0 {
+		status = "No Results Found"
+		data = append(data, fmt.Sprintf("No open process is currently at a stage handled by committee %s. Check the code with sejm_get_committees.", committeeCode))
+	}
+
+	response := StandardResponse{
+		Operation:   "Committee Workload",
+		Status:      coverage.status(status),
+		Summary:     summary,
+		Unavailable: coverage.unavailable(),
+		Data:        data,
+		NextActions: []string{
+			"Stage timeline of a process: sejm_get_process_details with process_number and format='json'",
+			"Documents of a process: sejm_get_print_graph with num",
+			fmt.Sprintf("Committee sittings: sejm_get_committee_sittings with committee_code='%s'", committeeCode),
+		},
+		Note: "A process is in a committee's queue when it is neither passed nor closed and its current (last) stage names the committee, e.g. a referral to first reading or committee work after first reading. Days in committee count from the start of that stage.",
+	}
+	return coverage.result(response.Format()), nil
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestHandleGetCommitteeWorkload(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/processes": `[
+			{"number": "10", "title": "Projekt o podatkach", "passed": false, "processStartDate": "2024-01-10"},
+			{"number": "11", "title": "Projekt o drogach", "passed": false, "processStartDate": "2024-02-01"},
+			{"number": "12", "title": "Projekt uchwalony", "passed": true, "processStartDate": "2024-01-01", "closureDate": "2024-03-01"},
+			{"number": "13", "title": "Projekt sprawozdany", "passed": false, "processStartDate": "2024-01-05"},
+			{"number": "14", "title": "Projekt niedostępny", "passed": false, "processStartDate": "2024-01-05"}
+		]`,
+		"/sejm/term10/processes/10": `{"title": "Projekt o podatkach", "processStartDate": "2024-01-10", "stages": [
+			{"stageName": "Projekt ustawy", "date": "2024-01-10"},
+			{"stageName": "Skierowano do I czytania w komisjach", "date": "2024-01-20", "committeeCode": "FPB"}
+		]}`,
+		"/sejm/term10/processes/11": `{"title": "Projekt o drogach", "processStartDate": "2024-02-01", "stages": [
+			{"stageName": "Projekt ustawy", "date": "2024-02-01"},
+			{"stageName": "Praca w komisjach po I czytaniu", "children": [
+				{"stageName": "Posiedzenie komisji", "date": "2024-03-01", "committeeCode": "FPB,GOR"}
+			]}
+		]}`,
+		"/sejm/term10/processes/13": `{"title": "Projekt sprawozdany", "processStartDate": "2024-01-05", "stages": [
+			{"stageName": "Praca w komisjach po I czytaniu", "children": [
+				{"stageName": "Posiedzenie komisji", "date": "2024-01-15", "committeeCode": "FPB"},
+				{"stageName": "Sprawozdanie komisji", "date": "2024-02-01"}
+			]}
+		]}`,
+	})
+
+	result, err := server.handleGetCommitteeWorkload(context.Background(), createMockRequest(map[string]interface{}{
+		"term":           "10",
+		"committee_code": "fpb",
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{
+		"Partially Retrieved",
+		"1 of 4 processes unavailable",
+		"process 14",
+		"Processes in the committee's queue: 2",
+		"Already reported, awaiting the next reading (not listed): 1",
+		"Stage: Skierowano do I czytania w komisjach since 2024-01-20",
+		"shared with FPB, GOR",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+	if strings.Contains(text, "Projekt uchwalony") || strings.Contains(text, "Process 13:") {
+		t.Errorf("Expected passed and reported processes to be left out:\n%s", text)
+	}
+	if strings.Index(text, "Process 10:") > strings.Index(text, "Process 11:") {
+		t.Errorf("Expected the oldest referral first:\n%s", text)
+	}
+
+	result, _ = server.handleGetCommitteeWorkload(context.Background(), createMockRequest(map[string]interface{}{
+		"term":             "10",
+		"committee_code":   "FPB",
+		"include_reported": "true",
+	}))
+	if text := extractTextContent(result); !strings.Contains(text, "Process 13:") || !strings.Contains(text, "committee report submitted") {
+		t.Errorf("Expected reported processes with include_reported='true':\n%s", text)
+	}
+}
@@ -0,0 +1,247 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxCompletionValues is the protocol limit of values in one completion/complete response
+const maxCompletionValues = 100
+
+// completionCandidate is a valid argument value with a label it can also be found by,
+// e.g. a committee code and the committee name
+type completionCandidate struct {
+	value string
+	label string
+}
+
+// completionSource lists the valid values of an argument; arguments holds the arguments already resolved
+type completionSource func(ctx context.Context, arguments map[string]string) ([]completionCandidate, error)
+
+// completionSources returns the sources of completions by argument name, backed by the cached directory endpoints
+func (s *SejmServer) completionSources() map[string]completionSource {
+	return map[string]completionSource{
+		"term":           s.termCompletions,
+		"mp_id":          s.mpCompletions,
+		"committee_code": s.committeeCompletions,
+		"club_id":        s.clubCompletions,
+		"publisher":      s.publisherCompletions,
+		"type":           typeCompletions,
+		"keyword":        s.keywordCompletions,
+		"act":            actAliasCompletions,
+	}
+}
+
+func (s *SejmServer) termCompletions(ctx context.Context, arguments map[string]string) ([]completionCandidate, error) {
+	var candidates []completionCandidate
+	for term := 10; term >= 1; term-- {
+		candidates = append(candidates, completionCandidate{value: strconv.Itoa(term)})
+	}
+	return candidates, nil
+}
+
+func (s *SejmServer) committeeCompletions(ctx context.Context, arguments map[string]string) ([]completionCandidate, error) {
+	term, err := s.validateTerm(arguments["term"])
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/committees", s.sejmBaseURL, term), nil)
+	if err != nil {
+		return nil, err
+	}
+	var committees []sejm.Committee
+	if err := s.decodeAPIResponse(data, &committees); err != nil {
+		return nil, fmt.Errorf("failed to parse committees: %w", err)
+	}
+	var candidates []completionCandidate
+	for _, committee := range committees {
+		if committee.Code != nil {
+			candidates = append(candidates, completionCandidate{value: *committee.Code, label: stringValue(committee.Name)})
+		}
+	}
+	return candidates, nil
+}
+
+func (s *SejmServer) clubCompletions(ctx context.Context, arguments map[string]string) ([]completionCandidate, error) {
+	term, err := s.validateTerm(arguments["term"])
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/clubs", s.sejmBaseURL, term), nil)
+	if err != nil {
+		return nil, err
+	}
+	var clubs []sejm.Club
+	if err := s.decodeAPIResponse(data, &clubs); err != nil {
+		return nil, fmt.Errorf("failed to parse clubs: %w", err)
+	}
+	var candidates []completionCandidate
+	for _, club := range clubs {
+		if club.Id != nil {
+			candidates = append(candidates, completionCandidate{value: *club.Id, label: stringValue(club.Name)})
+		}
+	}
+	return candidates, nil
+}
+
+func (s *SejmServer) publisherCompletions(ctx context.Context, arguments map[string]string) ([]completionCandidate, error) {
+	publishers, err := s.getCachedPublishers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []completionCandidate
+	for _, publisher := range publishers {
+		if publisher.Code != nil {
+			candidates = append(candidates, completionCandidate{value: *publisher.Code, label: stringValue(publisher.Name)})
+		}
+	}
+	return candidates, nil
+}
+
+func typeCompletions(ctx context.Context, arguments map[string]string) ([]completionCandidate, error) {
+	candidates := make([]completionCandidate, 0, len(eliDocumentTypes))
+	for _, docType := range eliDocumentTypes {
+		candidates = append(candidates, completionCandidate{value: docType})
+	}
+	return candidates, nil
+}
+
+func (s *SejmServer) keywordCompletions(ctx context.Context, arguments map[string]string) ([]completionCandidate, error) {
+	data, err := s.makeAPIRequest(ctx, s.eliBaseURL+"/keywords", nil)
+	if err != nil {
+		return nil, err
+	}
+	var keywords []string
+	if err := s.decodeAPIResponse(data, &keywords); err != nil {
+		return nil, fmt.Errorf("failed to parse keywords: %w", err)
+	}
+	candidates := make([]completionCandidate, 0, len(keywords))
+	for _, keyword := range keywords {
+		candidates = append(candidates, completionCandidate{value: keyword})
+	}
+	return candidates, nil
+}
+
+// matchCompletions keeps the candidates whose value starts with the typed text, followed by those whose value
+// or label contains it, ignoring case and Polish diacritics
+func matchCompletions(candidates []completionCandidate, typed string) []string {
+	typed = normalizePolish(strings.TrimSpace(typed))
+	var prefixed, contained []string
+	for _, candidate := range candidates {
+		value := normalizePolish(candidate.value)
+		switch {
+		case strings.HasPrefix(value, typed):
+			prefixed = append(prefixed, candidate.value)
+		case strings.Contains(value, typed) || strings.Contains(normalizePolish(candidate.label), typed):
+			contained = append(contained, candidate.value)
+		}
+	}
+	sort.SliceStable(contained, func(i, j int) bool { return contained[i] < contained[j] })
+	return append(prefixed, contained...)
+}
+
+// completeArgument answers completion/complete for an argument by name, for prompts and resource templates alike.
+// Arguments without a directory get no suggestions.
+func (s *SejmServer) completeArgument(ctx context.Context, argument mcp.CompleteArgument, resolved mcp.CompleteContext) (*mcp.Completion, error) {
+	source, ok := s.completionSources()[argument.Name]
+	if !ok {
+		return &mcp.Completion{Values: []string{}}, nil
+	}
+	candidates, err := source(ctx, resolved.Arguments)
+	if err != nil {
+		s.logger.Warn("Completion source unavailable", slog.String("argument", argument.Name), slog.Any("error", err))
+		return nil, fmt.Errorf("completions for %s are unavailable: %w", argument.Name, err)
+	}
+	values := matchCompletions(candidates, argument.Value)
+	completion := &mcp.Completion{Values: values, Total: len(values)}
+	if len(values) > maxCompletionValues {
+		completion.Values = values[:maxCompletionValues]
+		completion.HasMore = true
+	}
+	if completion.Values == nil {
+		completion.Values = []string{}
+	}
+	return completion, nil
+}
+
+// completionProvider serves completions of prompt and resource template arguments
+type completionProvider struct {
+	server *SejmServer
+}
+
+func (p *completionProvider) CompletePromptArgument(ctx context.Context, promptName string, argument mcp.CompleteArgument, resolved mcp.CompleteContext) (*mcp.Completion, error) {
+	return p.server.completeArgument(ctx, argument, resolved)
+}
+
+func (p *completionProvider) CompleteResourceArgument(ctx context.Context, uri string, argument mcp.CompleteArgument, resolved mcp.CompleteContext) (*mcp.Completion, error) {
+	return p.server.completeArgument(ctx, argument, resolved)
+}
+
+// registerResourceTemplates exposes the directory entries whose identifiers clients can complete
+func (s *SejmServer) registerResourceTemplates() {
+	s.server.AddResourceTemplate(
+		mcp.NewResourceTemplate("sejm://term/{term}/committees/{committee_code}", "Sejm committee",
+			mcp.WithTemplateDescription("Details of a Sejm committee: members, scope and contact. The committee code can be completed."),
+			mcp.WithTemplateMIMEType("application/json")),
+		s.directoryResource(func(arguments map[string]string) (string, error) {
+			term, err := s.validateTerm(arguments["term"])
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s/sejm/term%d/committees/%s", s.sejmBaseURL, term, arguments["committee_code"]), nil
+		}))
+	s.server.AddResourceTemplate(
+		mcp.NewResourceTemplate("sejm://term/{term}/clubs/{club_id}", "Sejm club",
+			mcp.WithTemplateDescription("Details of a parliamentary club or circle. The club ID can be completed."),
+			mcp.WithTemplateMIMEType("application/json")),
+		s.directoryResource(func(arguments map[string]string) (string, error) {
+			term, err := s.validateTerm(arguments["term"])
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s/sejm/term%d/clubs/%s", s.sejmBaseURL, term, arguments["club_id"]), nil
+		}))
+	s.server.AddResourceTemplate(
+		mcp.NewResourceTemplate("eli://publishers/{publisher}/years/{year}", "Acts published in a year",
+			mcp.WithTemplateDescription("Acts of an ELI publisher (e.g. DU, MP) from one year. The publisher code can be completed."),
+			mcp.WithTemplateMIMEType("application/json")),
+		s.directoryResource(func(arguments map[string]string) (string, error) {
+			year, err := strconv.Atoi(arguments["year"])
+			if err != nil {
+				return "", fmt.Errorf("invalid year %q", arguments["year"])
+			}
+			return fmt.Sprintf("%s/acts/%s/%d", s.eliBaseURL, strings.ToUpper(arguments["publisher"]), year), nil
+		}))
+}
+
+// directoryResource reads a resource template from the API endpoint built from its URI arguments
+func (s *SejmServer) directoryResource(endpoint func(arguments map[string]string) (string, error)) func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		s.logger.Info("Directory resource read", slog.String("uri", request.Params.URI))
+		arguments := make(map[string]string, len(request.Params.Arguments))
+		for name, value := range request.Params.Arguments {
+			// Matched template variables arrive as lists of strings
+			if values, ok := value.([]string); ok && len(values) > 0 {
+				arguments[name] = values[0]
+			} else {
+				arguments[name] = fmt.Sprint(value)
+			}
+		}
+		url, err := endpoint(arguments)
+		if err != nil {
+			return nil, err
+		}
+		data, err := s.makeAPIRequest(ctx, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "application/json", Text: string(data)}}, nil
+	}
+}
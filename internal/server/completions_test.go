@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatchCompletions(t *testing.T) {
+	candidates := []completionCandidate{
+		{value: "ZDR", label: "Komisja Zdrowia"},
+		{value: "FPB", label: "Komisja Finansów Publicznych"},
+		{value: "ASW", label: "Komisja Administracji i Spraw Wewnętrznych"},
+	}
+	for typed, expected := range map[string][]string{
+		"zd":       {"ZDR"},
+		"finansow": {"FPB"},
+		"":         {"ZDR", "FPB", "ASW"},
+		"komisja":  {"ASW", "FPB", "ZDR"},
+		"xyz":      nil,
+	} {
+		if got := matchCompletions(candidates, typed); !reflect.DeepEqual(got, expected) {
+			t.Errorf("matchCompletions(%q) = %v, expected %v", typed, got, expected)
+		}
+	}
+}
+
+func TestCompletionComplete(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term9/committees": `[
+			{"code": "ZDR", "name": "Komisja Zdrowia"},
+			{"code": "ZSP", "name": "Komisja Zdrowia Psychicznego"},
+			{"code": "FPB", "name": "Komisja Finansów Publicznych"}
+		]`,
+		"/sejm/term9/committees/ZDR": `{"code": "ZDR", "name": "Komisja Zdrowia"}`,
+	})
+
+	message := `{"jsonrpc":"2.0","id":1,"method":"completion/complete","params":{
+		"ref":{"type":"ref/resource","uri":"sejm://term/{term}/committees/{committee_code}"},
+		"argument":{"name":"committee_code","value":"zdrow"},
+		"context":{"arguments":{"term":"9"}}}}`
+	response := server.server.HandleMessage(context.Background(), json.RawMessage(message))
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("Failed to encode response: %v", err)
+	}
+	if !strings.Contains(string(encoded), `"values":["ZDR","ZSP"]`) {
+		t.Errorf("Expected committee codes matching the name, got: %s", encoded)
+	}
+
+	message = `{"jsonrpc":"2.0","id":2,"method":"completion/complete","params":{
+		"ref":{"type":"ref/resource","uri":"sejm://term/{term}/committees/{committee_code}"},
+		"argument":{"name":"unknown","value":"a"}}}`
+	encoded, _ = json.Marshal(server.server.HandleMessage(context.Background(), json.RawMessage(message)))
+	if !strings.Contains(string(encoded), `"values":[]`) {
+		t.Errorf("Expected no completions for an unknown argument, got: %s", encoded)
+	}
+
+	message = `{"jsonrpc":"2.0","id":3,"method":"resources/read","params":{"uri":"sejm://term/9/committees/ZDR"}}`
+	encoded, _ = json.Marshal(server.server.HandleMessage(context.Background(), json.RawMessage(message)))
+	if !strings.Contains(string(encoded), `Komisja Zdrowia`) {
+		t.Errorf("Expected the committee resource, got: %s", encoded)
+	}
+}
@@ -0,0 +1,315 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Defaults of the connection limits in SSE and HTTP mode, used by the command-line flags
+const (
+	DefaultMaxConnections       = 100
+	DefaultMaxMessagesPerMinute = 300
+	DefaultIdleTimeout          = 30 * time.Minute
+)
+
+// connectionRetryAfter is the Retry-After sent with connections rejected because the server is full
+const connectionRetryAfter = 30 * time.Second
+
+// connectionIDKey carries the ID the limiter assigned to an SSE stream to the session ID generator
+type connectionIDKey struct{}
+
+// trackedConnection is an open SSE stream, or a client address in HTTP mode
+type trackedConnection struct {
+	remote       string
+	openedAt     time.Time
+	lastActivity time.Time
+	messages     int64
+	limiter      *rateLimiter
+	cancel       context.CancelFunc
+}
+
+// ConnectionStats are the counters reported under "connections" by /health and sejm_ping
+type ConnectionStats struct {
+	Mode                 string `json:"mode"`
+	Active               int    `json:"active"`
+	Peak                 int    `json:"peak"`
+	Accepted             int64  `json:"accepted"`
+	Rejected             int64  `json:"rejected"`
+	RateLimited          int64  `json:"rateLimited"`
+	Reaped               int64  `json:"reaped"`
+	MaxConnections       int    `json:"maxConnections,omitempty"`
+	MaxMessagesPerMinute int    `json:"maxMessagesPerMinute,omitempty"`
+	IdleTimeout          string `json:"idleTimeout,omitempty"`
+}
+
+// connectionLimiter bounds the clients of the SSE and HTTP servers. In SSE mode it counts open streams,
+// limits the messages each session posts, and closes streams whose client has been silent for the idle
+// timeout; server keep-alive pings do not count as activity. In HTTP mode, which has no sessions, it
+// counts requests in flight and limits messages per client address.
+type connectionLimiter struct {
+	mu                sync.Mutex
+	mode              string
+	maxConnections    int
+	messagesPerMinute int
+	idleTimeout       time.Duration
+	connections       map[string]*trackedConnection
+	inFlight          int
+	stats             ConnectionStats
+	logger            *slog.Logger
+	now               func() time.Time
+}
+
+func newConnectionLimiter(mode string, config Config, logger *slog.Logger) *connectionLimiter {
+	return &connectionLimiter{
+		mode:              mode,
+		maxConnections:    config.MaxConnections,
+		messagesPerMinute: config.MaxMessagesPerMinute,
+		idleTimeout:       config.IdleTimeout,
+		connections:       make(map[string]*trackedConnection),
+		logger:            logger,
+		now:               time.Now,
+	}
+}
+
+func newConnectionID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("conn-%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// clientAddress returns the host of the request's remote address, so all connections of a client share limits
+func clientAddress(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// open registers a new SSE stream; ok is false when the server already holds the maximum number of streams
+func (l *connectionLimiter) open(remote string, cancel context.CancelFunc) (id string, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.maxConnections > 0 && len(l.connections) >= l.maxConnections {
+		l.stats.Rejected++
+		return "", false
+	}
+	now := l.now()
+	id = newConnectionID()
+	connection := &trackedConnection{remote: remote, openedAt: now, lastActivity: now, cancel: cancel}
+	if l.messagesPerMinute > 0 {
+		connection.limiter = &rateLimiter{perMinute: l.messagesPerMinute, now: l.now}
+	}
+	l.connections[id] = connection
+	l.stats.Accepted++
+	l.stats.Peak = max(l.stats.Peak, len(l.connections))
+	return id, true
+}
+
+// close removes an SSE stream when its request ends
+func (l *connectionLimiter) close(id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.connections, id)
+}
+
+// message records a message from the client with the given key (session ID or client address) and tells
+// whether it fits in the rate limit, and otherwise how long to wait. Unknown sessions are let through so
+// that the MCP server answers them with its own error.
+func (l *connectionLimiter) message(key string, create bool) (bool, time.Duration) {
+	l.mu.Lock()
+	connection := l.connections[key]
+	if connection == nil && create {
+		now := l.now()
+		connection = &trackedConnection{remote: key, openedAt: now}
+		if l.messagesPerMinute > 0 {
+			connection.limiter = &rateLimiter{perMinute: l.messagesPerMinute, now: l.now}
+		}
+		l.connections[key] = connection
+	}
+	if connection == nil {
+		l.mu.Unlock()
+		return true, 0
+	}
+	connection.lastActivity = l.now()
+	connection.messages++
+	limiter := connection.limiter
+	l.mu.Unlock()
+
+	if limiter == nil {
+		return true, 0
+	}
+	ok, wait := limiter.allow()
+	if !ok {
+		l.mu.Lock()
+		l.stats.RateLimited++
+		l.mu.Unlock()
+	}
+	return ok, wait
+}
+
+// acquire reserves a slot for an HTTP request in flight; release must be called when it ends
+func (l *connectionLimiter) acquire() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.maxConnections > 0 && l.inFlight >= l.maxConnections {
+		l.stats.Rejected++
+		return false
+	}
+	l.inFlight++
+	l.stats.Accepted++
+	l.stats.Peak = max(l.stats.Peak, l.inFlight)
+	return true
+}
+
+func (l *connectionLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+}
+
+// reap closes SSE streams idle for longer than the idle timeout, and forgets HTTP clients that have been
+// silent as long, so their rate limiters do not accumulate
+func (l *connectionLimiter) reap() int {
+	if l.idleTimeout <= 0 {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	reaped := 0
+	for id, connection := range l.connections {
+		if now.Sub(connection.lastActivity) < l.idleTimeout {
+			continue
+		}
+		if connection.cancel != nil {
+			connection.cancel()
+			reaped++
+			l.logger.Info("Closing idle SSE connection",
+				slog.String("remote", connection.remote),
+				slog.Duration("idle", now.Sub(connection.lastActivity)),
+				slog.Int64("messages", connection.messages))
+		}
+		delete(l.connections, id)
+	}
+	l.stats.Reaped += int64(reaped)
+	return reaped
+}
+
+// runReaper reaps idle connections until ctx is done
+func (l *connectionLimiter) runReaper(ctx context.Context) {
+	if l.idleTimeout <= 0 {
+		return
+	}
+	interval := min(max(l.idleTimeout/4, time.Second), time.Minute)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.reap()
+		}
+	}
+}
+
+// snapshot returns the current counters
+func (l *connectionLimiter) snapshot() ConnectionStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stats := l.stats
+	stats.Mode = l.mode
+	stats.Active = len(l.connections)
+	if l.mode == "http" {
+		stats.Active = l.inFlight
+	}
+	stats.MaxConnections = l.maxConnections
+	stats.MaxMessagesPerMinute = l.messagesPerMinute
+	if l.idleTimeout > 0 {
+		stats.IdleTimeout = l.idleTimeout.String()
+	}
+	return stats
+}
+
+// writeLimitError rejects a request with a status, a Retry-After header and a plain text reason
+func writeLimitError(w http.ResponseWriter, status int, wait time.Duration, reason string) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+	http.Error(w, reason, status)
+}
+
+// withSSEStreamLimit admits SSE streams up to the connection limit. Each stream gets a cancellable context,
+// so the reaper can close it, and an ID that becomes its MCP session ID (see sseSessionID).
+func (l *connectionLimiter) withSSEStreamLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		id, ok := l.open(clientAddress(r), cancel)
+		if !ok {
+			l.logger.Warn("Rejecting SSE connection: server is full", slog.String("remote", r.RemoteAddr), slog.Int("maxConnections", l.maxConnections))
+			writeLimitError(w, http.StatusServiceUnavailable, connectionRetryAfter,
+				fmt.Sprintf("server is at its limit of %d concurrent connections; retry later", l.maxConnections))
+			return
+		}
+		defer l.close(id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, connectionIDKey{}, id)))
+	})
+}
+
+// sseSessionID uses the limiter's connection ID as the MCP session ID, so messages can be matched to their stream
+func sseSessionID(ctx context.Context, _ *http.Request) (string, error) {
+	if id, ok := ctx.Value(connectionIDKey{}).(string); ok {
+		return id, nil
+	}
+	return newConnectionID(), nil
+}
+
+// withSSEMessageLimit limits the messages each SSE session posts
+func (l *connectionLimiter) withSSEMessageLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			if ok, wait := l.message(r.URL.Query().Get("sessionId"), false); !ok {
+				writeLimitError(w, http.StatusTooManyRequests, wait,
+					fmt.Sprintf("session exceeded %d messages per minute; retry in %d s", l.messagesPerMinute, int(wait.Seconds())+1))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withHTTPConnectionLimit bounds the MCP requests in flight and the messages per client address in HTTP mode
+func (l *connectionLimiter) withHTTPConnectionLimit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if ok, wait := l.message(clientAddress(r), true); !ok {
+			writeLimitError(w, http.StatusTooManyRequests, wait,
+				fmt.Sprintf("client exceeded %d messages per minute; retry in %d s", l.messagesPerMinute, int(wait.Seconds())+1))
+			return
+		}
+		if !l.acquire() {
+			l.logger.Warn("Rejecting MCP request: server is full", slog.String("remote", r.RemoteAddr), slog.Int("maxConnections", l.maxConnections))
+			writeLimitError(w, http.StatusServiceUnavailable, connectionRetryAfter,
+				fmt.Sprintf("server is at its limit of %d concurrent requests; retry later", l.maxConnections))
+			return
+		}
+		defer l.release()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSSEConnectionLimits(t *testing.T) {
+	limiter := newConnectionLimiter("sse", Config{MaxConnections: 1, MaxMessagesPerMinute: 2, IdleTimeout: time.Minute}, slog.Default())
+	now := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	messages := limiter.withSSEMessageLimit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	var streams http.Handler
+	var sessionID string
+	var nestedCode int
+	var codes []int
+	streams = limiter.withSSEStreamLimit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID, _ = sseSessionID(r.Context(), r)
+		// A second stream while this one is open exceeds the limit
+		recorder := httptest.NewRecorder()
+		streams.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/mcp", nil))
+		nestedCode = recorder.Code
+		if recorder.Header().Get("Retry-After") == "" {
+			t.Error("Expected a Retry-After header on the rejected stream")
+		}
+		for i := 0; i < 3; i++ {
+			recorder := httptest.NewRecorder()
+			messages.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/mcp/message?sessionId="+sessionID, nil))
+			codes = append(codes, recorder.Code)
+		}
+	}))
+	streams.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/mcp", nil))
+
+	if nestedCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected the second stream to be rejected with 503, got %d", nestedCode)
+	}
+	if len(sessionID) != 32 {
+		t.Errorf("Expected the connection ID as session ID, got %q", sessionID)
+	}
+	if codes[0] != http.StatusOK || codes[1] != http.StatusOK || codes[2] != http.StatusTooManyRequests {
+		t.Errorf("Expected the third message to be rate limited, got %v", codes)
+	}
+	stats := limiter.snapshot()
+	if stats.Active != 0 || stats.Peak != 1 || stats.Accepted != 1 || stats.Rejected != 1 || stats.RateLimited != 1 {
+		t.Errorf("Unexpected stats after the stream closed: %+v", stats)
+	}
+
+	// Streams are closed once their client is silent for the idle timeout
+	ctx, cancel := context.WithCancel(context.Background())
+	id, _ := limiter.open("127.0.0.1", cancel)
+	now = now.Add(50 * time.Second)
+	limiter.message(id, false)
+	now = now.Add(50 * time.Second)
+	if reaped := limiter.reap(); reaped != 0 {
+		t.Errorf("Expected an active stream to stay open, reaped %d", reaped)
+	}
+	now = now.Add(time.Minute)
+	if reaped := limiter.reap(); reaped != 1 || ctx.Err() == nil {
+		t.Errorf("Expected the idle stream to be cancelled, reaped %d", reaped)
+	}
+	if stats := limiter.snapshot(); stats.Reaped != 1 || stats.Active != 0 {
+		t.Errorf("Unexpected stats after reaping: %+v", stats)
+	}
+}
+
+func TestHTTPConnectionLimits(t *testing.T) {
+	limiter := newConnectionLimiter("http", Config{MaxConnections: 1, MaxMessagesPerMinute: 3}, slog.Default())
+	var handler http.Handler
+	var nestedCode int
+	handler = limiter.withHTTPConnectionLimit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if nestedCode != 0 {
+			return
+		}
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		request.RemoteAddr = "10.0.0.2:4000"
+		handler.ServeHTTP(recorder, request)
+		nestedCode = recorder.Code
+	}))
+
+	var codes []int
+	for i := 0; i < 4; i++ {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		request.RemoteAddr = "10.0.0.1:5000"
+		handler.ServeHTTP(recorder, request)
+		codes = append(codes, recorder.Code)
+	}
+	if nestedCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected a request beyond the concurrency limit to be rejected with 503, got %d", nestedCode)
+	}
+	if codes[2] != http.StatusOK || codes[3] != http.StatusTooManyRequests {
+		t.Errorf("Expected the fourth request of a client to be rate limited, got %v", codes)
+	}
+	if stats := limiter.snapshot(); stats.Active != 0 || stats.Peak != 1 || stats.Rejected != 1 || stats.RateLimited != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+}
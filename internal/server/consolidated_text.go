@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// consolidatedTexts lists the announcements of consolidated texts (tekst jednolity) of an act, oldest first.
+// They are the incoming references whose category mentions a consolidated text, e.g. 'Inf. o tekście jednolitym'.
+func consolidatedTexts(act eli.Act) []actWatch {
+	if act.References == nil {
+		return nil
+	}
+	var texts []actWatch
+	for category, refs := range *act.References {
+		if !strings.Contains(normalizePolish(category), "jednolit") || referenceDirection(category) != referenceDirectionIncoming {
+			continue
+		}
+		for _, ref := range refs {
+			if address, ok := parseReferenceAddress(ref.Id); ok {
+				texts = append(texts, address)
+			}
+		}
+	}
+	sort.Slice(texts, func(i, j int) bool { return actAddressBefore(texts[i], texts[j]) })
+	return texts
+}
+
+// parseReferenceAddress parses the ELI address of a reference, e.g. 'DU/2023/1465'
+func parseReferenceAddress(id *string) (actWatch, bool) {
+	if id == nil {
+		return actWatch{}, false
+	}
+	parts := strings.Split(*id, "/")
+	if len(parts) != 3 {
+		return actWatch{}, false
+	}
+	address, err := parseActAddress(parts[0], parts[1], parts[2])
+	return address, err == nil
+}
+
+// actAddressBefore orders addresses by year and position, which follows publication order within a publisher
+func actAddressBefore(a, b actWatch) bool {
+	if a.Year != b.Year {
+		return a.Year < b.Year
+	}
+	return a.Position < b.Position
+}
+
+// amendmentsAfter counts the amending acts of the same publisher published after the given consolidated text;
+// their changes are not part of it
+func amendmentsAfter(act eli.Act, consolidated actWatch) int {
+	return len(amendingActsAfter(act, consolidated))
+}
+
+// amendingActsAfter lists, oldest first, the amending acts of the same publisher published after the given text
+func amendingActsAfter(act eli.Act, text actWatch) []actWatch {
+	if act.References == nil {
+		return nil
+	}
+	var amendments []actWatch
+	for _, ref := range (*act.References)["Akty zmieniające"] {
+		if address, ok := parseReferenceAddress(ref.Id); ok && address.Publisher == text.Publisher && actAddressBefore(text, address) {
+			amendments = append(amendments, address)
+		}
+	}
+	sort.Slice(amendments, func(i, j int) bool { return actAddressBefore(amendments[i], amendments[j]) })
+	return amendments
+}
+
+// actTextFromConsolidated returns the text of the newest consolidated text of an act instead of the act as
+// published, with a note saying which version was returned. If the consolidated text cannot be retrieved,
+// the original text is returned with a note instead.
+func (s *SejmServer) actTextFromConsolidated(ctx context.Context, request mcp.CallToolRequest, act eli.Act, texts []actWatch) (*mcp.CallToolResult, error) {
+	original := actAddress(act)
+	latest := texts[len(texts)-1]
+	s.logger.Info("Resolving act text to its newest consolidated text", slog.String("act", original), slog.String("consolidated", latest.Address))
+
+	arguments := make(map[string]any)
+	for name, value := range request.GetArguments() {
+		arguments[name] = value
+	}
+	arguments["consolidated"] = "false"
+	resolved := request
+	resolved.Params.Arguments = withActAddress(arguments, latest)
+
+	result, err := s.handleGetActText(ctx, resolved)
+	if err != nil {
+		return result, err
+	}
+	if result.IsError {
+		// The original text is better than none
+		s.logger.Warn("Consolidated text unavailable, returning the original text", slog.String("consolidated", latest.Address), slog.String("error", resultText(result)))
+		fallback := request
+		fallback.Params.Arguments = arguments
+		result, err = s.handleGetActText(ctx, fallback)
+		if err == nil && !result.IsError {
+			prependResultNote(result, fmt.Sprintf("Note: the newest consolidated text (tekst jednolity) %s could not be retrieved, so this is the original text of %s as published. Amendments are not included.", latest.Address, original))
+		}
+		return result, err
+	}
+
+	note := fmt.Sprintf("Note: %s has a consolidated text (tekst jednolity), so this is the text of %s, the newest of %d, instead of the act as originally published.", original, latest.Address, len(texts))
+	if after := amendmentsAfter(act, latest); after > 0 {
+		note += fmt.Sprintf(" %d amending acts were published after it; their changes are not included.", after)
+	}
+	note += fmt.Sprintf(" Use consolidated='false' for the original text of %s.", original)
+	prependResultNote(result, note)
+	return result, nil
+}
+
+// withActAddress returns the arguments pointing at another act
+func withActAddress(arguments map[string]any, address actWatch) map[string]any {
+	result := make(map[string]any, len(arguments))
+	for name, value := range arguments {
+		result[name] = value
+	}
+	result["publisher"] = address.Publisher
+	result["year"] = strconv.Itoa(address.Year)
+	result["position"] = strconv.Itoa(address.Position)
+	return result
+}
+
+// prependResultNote puts a note before the first text of a result
+func prependResultNote(result *mcp.CallToolResult, note string) {
+	for i, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			text.Text = note + "\n\n" + text.Text
+			result.Content[i] = text
+			return
+		}
+	}
+	result.Content = append([]mcp.Content{mcp.NewTextContent(note)}, result.Content...)
+}
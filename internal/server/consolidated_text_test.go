@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+)
+
+const labourCodeDetails = `{
+	"ELI": "DU/1974/24", "publisher": "DU", "year": 1974, "pos": 24, "title": "Ustawa z dnia 26 czerwca 1974 r. Kodeks pracy", "textHTML": true,
+	"references": {
+		"Inf. o tekście jednolitym": [{"id": "DU/2023/1465"}, {"id": "DU/2020/1320"}],
+		"Tekst jednolity dla aktu": [{"id": "DU/1999/1"}],
+		"Akty zmieniające": [{"id": "DU/2022/5"}, {"id": "DU/2024/10"}, {"id": "DU/2024/878"}]
+	}
+}`
+
+func TestConsolidatedTexts(t *testing.T) {
+	var act eli.Act
+	if err := json.Unmarshal([]byte(labourCodeDetails), &act); err != nil {
+		t.Fatalf("Failed to parse act: %v", err)
+	}
+	texts := consolidatedTexts(act)
+	if len(texts) != 2 || texts[0].Address != "DU/2020/1320" || texts[1].Address != "DU/2023/1465" {
+		t.Fatalf("Expected the two consolidated texts oldest first, got %+v", texts)
+	}
+	if after := amendmentsAfter(act, texts[1]); after != 2 {
+		t.Errorf("Expected 2 amendments after the newest consolidated text, got %d", after)
+	}
+	if texts := consolidatedTexts(eli.Act{}); texts != nil {
+		t.Errorf("Expected no consolidated texts for an act without references, got %+v", texts)
+	}
+}
+
+func TestHandleGetActTextResolvesConsolidatedText(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/DU/1974/24":             labourCodeDetails,
+		"/eli/acts/DU/1974/24/text.html":   `<html><body><p>Tekst pierwotny kodeksu</p></body></html>`,
+		"/eli/acts/DU/2023/1465":           `{"ELI": "DU/2023/1465", "publisher": "DU", "year": 2023, "pos": 1465, "textHTML": true}`,
+		"/eli/acts/DU/2023/1465/text.html": `<html><body><p>Tekst jednolity kodeksu</p></body></html>`,
+		"/eli/acts/DU/1997/78":             `{"ELI": "DU/1997/78", "publisher": "DU", "year": 1997, "pos": 78, "textHTML": true}`,
+		"/eli/acts/DU/1997/78/text.html":   `<html><body><p>Konstytucja</p></body></html>`,
+	})
+
+	result, err := server.handleGetActText(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "1974", "position": "24", "format": "html",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"this is the text of DU/2023/1465, the newest of 2",
+		"2 amending acts were published after it",
+		"consolidated='false'",
+		"Tekst jednolity kodeksu",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, content)
+		}
+	}
+
+	result, _ = server.handleGetActText(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "1974", "position": "24", "format": "html", "consolidated": "false",
+	}))
+	if content := extractTextContent(result); !strings.Contains(content, "Tekst pierwotny kodeksu") || strings.Contains(content, "tekst jednolity)") {
+		t.Errorf("Expected the original text without a note, got:\n%s", content)
+	}
+
+	result, _ = server.handleGetActText(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "1997", "position": "78", "format": "html",
+	}))
+	if content := extractTextContent(result); !strings.Contains(content, "Konstytucja") || strings.Contains(content, "Note:") {
+		t.Errorf("Expected an act without consolidated texts to be returned as is, got:\n%s", content)
+	}
+}
+
+func TestHandleGetActTextFallsBackToOriginal(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/DU/1974/24":           labourCodeDetails,
+		"/eli/acts/DU/1974/24/text.html": `<html><body><p>Tekst pierwotny kodeksu</p></body></html>`,
+	})
+
+	result, err := server.handleGetActText(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "1974", "position": "24", "format": "html",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	if !strings.Contains(content, "DU/2023/1465 could not be retrieved") || !strings.Contains(content, "Tekst pierwotny kodeksu") {
+		t.Errorf("Expected the original text with a note, got:\n%s", content)
+	}
+}
@@ -0,0 +1,364 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// maxDigestItems limits the entries listed per digest section
+	maxDigestItems = 25
+	// digestPageSize is the page size used when scanning prints and questions for the digest date
+	digestPageSize = 100
+	// maxDigestPages bounds the scan of date-sorted lists
+	maxDigestPages = 5
+)
+
+// digestSection is one part of the daily digest
+type digestSection struct {
+	title   string
+	count   int
+	summary string
+	lines   []string
+	more    string
+	err     error
+}
+
+// votingOutcome decides whether a voting passed, using the required majority when the API reports it
+func votingOutcome(voting sejm.Voting) string {
+	if voting.Yes == nil || voting.No == nil {
+		return "list vote"
+	}
+	if voting.MajorityVotes != nil && *voting.MajorityVotes > 0 {
+		if *voting.Yes >= *voting.MajorityVotes {
+			return "PASSED"
+		}
+		return "FAILED"
+	}
+	if *voting.Yes > *voting.No {
+		return "PASSED"
+	}
+	return "FAILED"
+}
+
+// limitDigestLines caps a section's lines, pointing to the tool that lists the rest
+func limitDigestLines(section *digestSection, hint string) {
+	if len(section.lines) > maxDigestItems {
+		section.more = fmt.Sprintf("... and %d more (%s)", len(section.lines)-maxDigestItems, hint)
+		section.lines = section.lines[:maxDigestItems]
+	}
+}
+
+func (s *SejmServer) digestVotings(ctx context.Context, term int, day time.Time) digestSection {
+	section := digestSection{title: "Plenary votings"}
+	sittings, err := s.defectionSittings(ctx, term, "", day, day)
+	if err != nil {
+		section.err = err
+		return section
+	}
+	passed := 0
+	for _, number := range sittings {
+		data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/votings/%d", s.sejmBaseURL, term, number), nil)
+		if err != nil {
+			section.err = fmt.Errorf("failed to retrieve votings of sitting %d: %w", number, err)
+			return section
+		}
+		var votings []sejm.Voting
+		if err := s.decodeAPIResponse(data, &votings); err != nil {
+			section.err = fmt.Errorf("failed to parse votings of sitting %d: %w", number, err)
+			return section
+		}
+		for _, voting := range votings {
+			if !votingDateInRange(voting, day, day) {
+				continue
+			}
+			section.count++
+			outcome := votingOutcome(voting)
+			if outcome == "PASSED" {
+				passed++
+			}
+			line := "• "
+			if voting.VotingNumber != nil {
+				line += fmt.Sprintf("#%d ", *voting.VotingNumber)
+			}
+			if voting.Date != nil {
+				line += voting.Date.Format("15:04") + " "
+			}
+			line += truncateRunes(valueOrDefault(stringValue(voting.Title), "No title"), 160)
+			if voting.Topic != nil && *voting.Topic != "" {
+				line += " – " + truncateRunes(*voting.Topic, 120)
+			}
+			line += " → " + outcome
+			if voting.Yes != nil && voting.No != nil {
+				line += fmt.Sprintf(" (%d yes, %d no", *voting.Yes, *voting.No)
+				if voting.Abstain != nil {
+					line += fmt.Sprintf(", %d abstain", *voting.Abstain)
+				}
+				line += ")"
+			}
+			if voting.Sitting != nil {
+				line += fmt.Sprintf(" [sitting %d]", *voting.Sitting)
+			}
+			section.lines = append(section.lines, line)
+		}
+	}
+	section.summary = fmt.Sprintf("%d (%d passed)", section.count, passed)
+	limitDigestLines(&section, "sejm_search_votings with the sitting number")
+	return section
+}
+
+func (s *SejmServer) digestCommitteeSittings(ctx context.Context, term int, date string) digestSection {
+	section := digestSection{title: "Committee sittings"}
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/committees/sittings/%s", s.sejmBaseURL, term, date), nil)
+	if err != nil {
+		section.err = err
+		return section
+	}
+	var sittings []sejm.CommitteeSitting
+	if err := s.decodeAPIResponse(data, &sittings); err != nil {
+		section.err = fmt.Errorf("failed to parse committee sittings: %w", err)
+		return section
+	}
+	// Joint sittings are listed under each committee; they count as one meeting
+	meetings := mergeJointSittings(sittings)
+	cancelled := 0
+	for _, meeting := range meetings {
+		if meeting.Cancelled {
+			cancelled++
+		}
+		section.lines = append(section.lines, "• "+meeting.line(200))
+	}
+	section.count = len(meetings) - cancelled
+	section.summary = strconv.Itoa(section.count)
+	if cancelled > 0 {
+		section.summary += fmt.Sprintf(" (%d cancelled)", cancelled)
+	}
+	limitDigestLines(&section, fmt.Sprintf("sejm_get_committee_sittings_by_date with date='%s'", date))
+	return section
+}
+
+func (s *SejmServer) digestPrints(ctx context.Context, term int, date string) digestSection {
+	section := digestSection{title: "Prints delivered"}
+	// Prints are scanned newest first until the delivery dates fall before the digest date
+	for page := 0; page < maxDigestPages; page++ {
+		params := map[string]string{
+			"limit":   strconv.Itoa(digestPageSize),
+			"offset":  strconv.Itoa(page * digestPageSize),
+			"sort_by": "-deliveryDate",
+		}
+		data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/prints", s.sejmBaseURL, term), params)
+		if err != nil {
+			section.err = err
+			return section
+		}
+		var prints []sejm.Print
+		if err := s.decodeAPIResponse(data, &prints); err != nil {
+			section.err = fmt.Errorf("failed to parse prints: %w", err)
+			return section
+		}
+		older := false
+		for _, print := range prints {
+			if print.DeliveryDate == nil {
+				continue
+			}
+			delivered := print.DeliveryDate.Format("2006-01-02")
+			if delivered < date {
+				older = true
+				continue
+			}
+			if delivered == date {
+				section.lines = append(section.lines, fmt.Sprintf("• Print %s: %s", valueOrDefault(stringValue(print.Number), "?"), truncateRunes(valueOrDefault(stringValue(print.Title), "No title"), 200)))
+			}
+		}
+		if older || len(prints) < digestPageSize {
+			break
+		}
+	}
+	section.count = len(section.lines)
+	section.summary = strconv.Itoa(section.count)
+	limitDigestLines(&section, "sejm_get_prints with sort_by='-deliveryDate'")
+	return section
+}
+
+func (s *SejmServer) digestQuestions(ctx context.Context, term int, date, kind string) digestSection {
+	section := digestSection{title: "Interpellations submitted"}
+	endpoint, hint := "interpellations", "sejm_get_interpellation_details for details"
+	if kind == "writtenQuestions" {
+		section.title = "Written questions submitted"
+		endpoint, hint = "writtenQuestions", fmt.Sprintf("sejm_get_written_questions with since='%s' and till='%s'", date, date)
+	}
+	for page := 0; page < maxDigestPages; page++ {
+		params := map[string]string{
+			"limit":   strconv.Itoa(digestPageSize),
+			"offset":  strconv.Itoa(page * digestPageSize),
+			"sort_by": "-receiptDate",
+			"since":   date,
+			"till":    date,
+		}
+		data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/%s", s.sejmBaseURL, term, endpoint), params)
+		if err != nil {
+			section.err = err
+			return section
+		}
+		var questions []sejm.Interpellation
+		if err := s.decodeAPIResponse(data, &questions); err != nil {
+			section.err = fmt.Errorf("failed to parse %s: %w", endpoint, err)
+			return section
+		}
+		for _, question := range questions {
+			if question.ReceiptDate == nil || question.ReceiptDate.Format("2006-01-02") != date {
+				continue
+			}
+			line := "• "
+			if question.Num != nil {
+				line += fmt.Sprintf("#%d ", *question.Num)
+			}
+			line += truncateRunes(valueOrDefault(stringValue(question.Title), "No title"), 200)
+			if question.To != nil && len(*question.To) > 0 {
+				line += " → " + strings.Join(*question.To, "; ")
+			}
+			if question.From != nil && len(*question.From) > 0 {
+				line += fmt.Sprintf(" [MP IDs: %s]", strings.Join(*question.From, ", "))
+			}
+			section.lines = append(section.lines, line)
+		}
+		if len(questions) < digestPageSize {
+			break
+		}
+	}
+	section.count = len(section.lines)
+	section.summary = strconv.Itoa(section.count)
+	limitDigestLines(&section, hint)
+	return section
+}
+
+func (s *SejmServer) digestVideos(ctx context.Context, term int, date string) digestSection {
+	section := digestSection{title: "Video transmissions"}
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/videos/%s", s.sejmBaseURL, term, date), nil)
+	if err != nil {
+		section.err = err
+		return section
+	}
+	var videos []sejm.Video
+	if err := s.decodeAPIResponse(data, &videos); err != nil {
+		section.err = fmt.Errorf("failed to parse videos: %w", err)
+		return section
+	}
+	for _, video := range videos {
+		line := "• "
+		if video.StartDateTime != nil {
+			line += video.StartDateTime.Format("15:04") + " "
+		}
+		line += truncateRunes(valueOrDefault(stringValue(video.Title), "No title"), 160)
+		if video.Type != nil {
+			line += fmt.Sprintf(" (%s)", *video.Type)
+		}
+		if video.Unid != nil {
+			line += fmt.Sprintf(" [ID: %s]", *video.Unid)
+		}
+		section.lines = append(section.lines, line)
+	}
+	section.count = len(videos)
+	section.summary = strconv.Itoa(section.count)
+	limitDigestLines(&section, fmt.Sprintf("sejm_get_videos_by_date with date='%s'", date))
+	return section
+}
+
+func (s *SejmServer) handleGetDailyDigest(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_daily_digest called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	day, err := parseDefectionDate("date", request.GetString("date", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if day.IsZero() {
+		day = warsawToday()
+	}
+	date := day.Format("2006-01-02")
+
+	// The sources are independent, so they are fetched in parallel
+	fetchers := []func() digestSection{
+		func() digestSection { return s.digestVotings(ctx, term, day) },
+		func() digestSection { return s.digestCommitteeSittings(ctx, term, date) },
+		func() digestSection { return s.digestPrints(ctx, term, date) },
+		func() digestSection { return s.digestQuestions(ctx, term, date, "interpellations") },
+		func() digestSection { return s.digestQuestions(ctx, term, date, "writtenQuestions") },
+		func() digestSection { return s.digestVideos(ctx, term, date) },
+	}
+	sections := make([]digestSection, len(fetchers))
+	var wg sync.WaitGroup
+	for i, fetch := range fetchers {
+		wg.Add(1)
+		go func(i int, fetch func() digestSection) {
+			defer wg.Done()
+			sections[i] = fetch()
+		}(i, fetch)
+	}
+	wg.Wait()
+
+	summary := []string{fmt.Sprintf("Sejm on %s (%s), term %d", date, day.Weekday(), term)}
+	var results, failed []string
+	activity := 0
+	for _, section := range sections {
+		if section.err != nil {
+			s.logger.Warn("Daily digest section failed", slog.String("section", section.title), slog.Any("error", section.err))
+			summary = append(summary, section.title+": unavailable")
+			failed = append(failed, fmt.Sprintf("%s (%v)", strings.ToLower(section.title), section.err))
+			continue
+		}
+		activity += section.count
+		summary = append(summary, fmt.Sprintf("%s: %s", section.title, section.summary))
+		if len(section.lines) == 0 {
+			continue
+		}
+		if len(results) > 0 {
+			results = append(results, "")
+		}
+		results = append(results, section.title+":")
+		results = append(results, section.lines...)
+		if section.more != "" {
+			results = append(results, section.more)
+		}
+	}
+
+	status := "Retrieved Successfully"
+	if activity == 0 {
+		status = "No Results Found"
+		results = append(results, "No recorded parliamentary activity on this date. Weekends, holidays and recess days usually have none.")
+	}
+	var note string
+	if len(failed) > 0 {
+		status = "Partially Retrieved"
+		note = "Some sources could not be retrieved: " + strings.Join(failed, "; ")
+	}
+
+	response := StandardResponse{
+		Operation: "Daily Digest",
+		Status:    status,
+		Summary:   summary,
+		Data:      results,
+		NextActions: []string{
+			"Voting details: sejm_get_voting_details with sitting and voting_number",
+			"Committee sitting details: sejm_get_committee_sitting_details with committee_code and sitting_number",
+			"Print details: sejm_get_print_details with num",
+			fmt.Sprintf("Previous day: sejm_get_daily_digest with date='%s'", day.AddDate(0, 0, -1).Format("2006-01-02")),
+		},
+		Note: note,
+	}
+	if len(failed) > 0 {
+		return markPartial(mcp.NewToolResultText(response.Format())), nil
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
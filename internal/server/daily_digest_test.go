@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+func TestVotingOutcome(t *testing.T) {
+	yes, no, majority := int32(200), int32(180), int32(231)
+	if got := votingOutcome(sejm.Voting{Yes: &yes, No: &no}); got != "PASSED" {
+		t.Errorf("Expected a simple majority to pass, got %s", got)
+	}
+	if got := votingOutcome(sejm.Voting{Yes: &yes, No: &no, MajorityVotes: &majority}); got != "FAILED" {
+		t.Errorf("Expected the absolute majority to be missed, got %s", got)
+	}
+	if got := votingOutcome(sejm.Voting{}); got != "list vote" {
+		t.Errorf("Expected a list vote without tallies, got %s", got)
+	}
+}
+
+func TestHandleGetDailyDigest(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/votings": `[{"date": "2024-03-07", "proceeding": 7, "votingsNum": 1}, {"date": "2024-03-08", "proceeding": 7, "votingsNum": 2}]`,
+		"/sejm/term10/votings/7": `[
+			{"sitting": 7, "votingNumber": 1, "date": "2024-03-07T10:00:00", "title": "Wczorajsze głosowanie", "yes": 300, "no": 100},
+			{"sitting": 7, "votingNumber": 2, "date": "2024-03-08T11:15:00", "title": "Głosowanie nad całością projektu", "topic": "Ustawa o kolei", "yes": 250, "no": 190, "abstain": 3},
+			{"sitting": 7, "votingNumber": 3, "date": "2024-03-08T11:20:00", "title": "Wniosek o odrzucenie", "yes": 100, "no": 340}
+		]`,
+		"/sejm/term10/committees/sittings/2024-03-08": `[
+			{"code": "ASW", "num": 12, "startDateTime": "2024-03-08T09:00:00", "agenda": "<p>Rozpatrzenie projektu</p>"},
+			{"code": "ENM", "num": 4, "status": "CANCELLED"}
+		]`,
+		"/sejm/term10/prints": `[
+			{"number": "301", "title": "Projekt ustawy o kolei", "deliveryDate": "2024-03-08"},
+			{"number": "300", "title": "Sprawozdanie komisji", "deliveryDate": "2024-03-06"}
+		]`,
+		"/sejm/term10/interpellations": `[
+			{"num": 900, "title": "Interpelacja w sprawie szpitali", "receiptDate": "2024-03-08", "to": ["minister zdrowia"], "from": ["12"]},
+			{"num": 899, "title": "Starsza interpelacja", "receiptDate": "2024-03-07"}
+		]`,
+		"/sejm/term10/writtenQuestions":  `[]`,
+		"/sejm/term10/videos/2024-03-08": `[{"unid": "V1", "title": "Posiedzenie Sejmu", "type": "posiedzenie", "startDateTime": "2024-03-08T10:00:00"}]`,
+	})
+
+	result, err := server.handleGetDailyDigest(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "date": "2024-03-08",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Sejm on 2024-03-08 (Friday), term 10",
+		"Plenary votings: 2 (1 passed)",
+		"• #2 11:15 Głosowanie nad całością projektu – Ustawa o kolei → PASSED (250 yes, 190 no, 3 abstain) [sitting 7]",
+		"• #3 11:20 Wniosek o odrzucenie → FAILED",
+		"Committee sittings: 1 (1 cancelled)",
+		"• ASW #12 09:00: Rozpatrzenie projektu",
+		"Prints delivered: 1",
+		"• Print 301: Projekt ustawy o kolei",
+		"Interpellations submitted: 1",
+		"• #900 Interpelacja w sprawie szpitali → minister zdrowia [MP IDs: 12]",
+		"Written questions submitted: 0",
+		"Video transmissions: 1",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+	for _, unexpected := range []string{"Wczorajsze głosowanie", "Sprawozdanie komisji", "Starsza interpelacja"} {
+		if strings.Contains(content, unexpected) {
+			t.Errorf("Expected '%s' from another day to be left out, got: %s", unexpected, content)
+		}
+	}
+
+	result, _ = server.handleGetDailyDigest(context.Background(), createMockRequest(map[string]interface{}{"date": "08.03.2024"}))
+	if !result.IsError {
+		t.Error("Expected an error for an invalid date")
+	}
+}
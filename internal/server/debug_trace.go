@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// debugParamDescription documents the debug parameter offered when the server runs with -debug
+const debugParamDescription = "Optional. Set to 'true' to append a debug trace to the result: every upstream URL requested, its HTTP status, response size, cache status and duration. Use it to find out why a call returns nothing or fails."
+
+// requestTraceKey holds the requestTrace of a tool call in its context
+type requestTraceKey struct{}
+
+// upstreamCallKey holds the upstreamCall of a single upstream request in its context
+type upstreamCallKey struct{}
+
+// upstreamCall is one upstream request of a traced tool call
+type upstreamCall struct {
+	URL      string
+	Statuses []int
+	Cache    string
+	Bytes    int
+	Duration time.Duration
+	Error    string
+}
+
+// requestTrace collects the upstream requests of a tool call called with debug='true'. Fan-out tools issue
+// requests concurrently, so calls are recorded under a lock.
+type requestTrace struct {
+	mu    sync.Mutex
+	start time.Time
+	calls []*upstreamCall
+}
+
+// withRequestTrace starts tracing the upstream requests made with the returned context
+func withRequestTrace(ctx context.Context) (context.Context, *requestTrace) {
+	trace := &requestTrace{start: time.Now()}
+	return context.WithValue(ctx, requestTraceKey{}, trace), trace
+}
+
+// traceUpstreamCall records an upstream request on the trace of the tool call, if it is traced. The returned
+// context carries the call, so the attempts and cache status can be added to it; a nil call records nothing.
+func traceUpstreamCall(ctx context.Context, endpoint string, params map[string]string) (context.Context, *upstreamCall) {
+	trace, ok := ctx.Value(requestTraceKey{}).(*requestTrace)
+	if !ok {
+		return ctx, nil
+	}
+	address := endpoint
+	if len(params) > 0 {
+		query := url.Values{}
+		for key, value := range params {
+			query.Set(key, value)
+		}
+		address += "?" + query.Encode()
+	}
+	call := &upstreamCall{URL: address}
+	trace.mu.Lock()
+	trace.calls = append(trace.calls, call)
+	trace.mu.Unlock()
+	return context.WithValue(ctx, upstreamCallKey{}, call), call
+}
+
+// upstreamCallFrom returns the traced upstream request of a context, or nil
+func upstreamCallFrom(ctx context.Context) *upstreamCall {
+	call, _ := ctx.Value(upstreamCallKey{}).(*upstreamCall)
+	return call
+}
+
+// attempt records the HTTP status of an attempt; 0 stands for a connection error
+func (c *upstreamCall) attempt(status int) {
+	if c != nil {
+		c.Statuses = append(c.Statuses, status)
+	}
+}
+
+// cacheStatus records whether the response came from the response cache
+func (c *upstreamCall) cacheStatus(status string) {
+	if c != nil {
+		c.Cache = status
+	}
+}
+
+// finish records the outcome of the request
+func (c *upstreamCall) finish(bytes int, duration time.Duration, err error) {
+	if c == nil {
+		return
+	}
+	c.Bytes = bytes
+	c.Duration = duration
+	if err != nil {
+		c.Error = err.Error()
+	}
+}
+
+// lines renders the trace for the result of the tool call
+func (t *requestTrace) lines() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	hits, failed, bytes := 0, 0, 0
+	for _, call := range t.calls {
+		if call.Cache == "HIT" {
+			hits++
+		}
+		if call.Error != "" {
+			failed++
+		}
+		bytes += call.Bytes
+	}
+	lines := []string{
+		"Debug trace:",
+		fmt.Sprintf("Total time: %s; upstream requests: %d (%d from cache, %d failed); %d bytes received",
+			time.Since(t.start).Round(time.Millisecond), len(t.calls), hits, failed, bytes),
+	}
+	for i, call := range t.calls {
+		statuses := make([]string, len(call.Statuses))
+		for j, status := range call.Statuses {
+			statuses[j] = fmt.Sprint(status)
+			if status == 0 {
+				statuses[j] = "connection error"
+			}
+		}
+		line := fmt.Sprintf("%d. GET %s → %s, %d bytes, %s",
+			i+1, call.URL, valueOrDefault(strings.Join(statuses, ", "), "not sent"), call.Bytes, call.Duration.Round(time.Millisecond))
+		if call.Cache != "" {
+			line += ", cache " + call.Cache
+		}
+		if call.Error != "" {
+			line += ", error: " + call.Error
+		}
+		lines = append(lines, line)
+	}
+	if len(t.calls) == 0 {
+		lines = append(lines, "No upstream requests were made; the result came from the server's own caches or the arguments were rejected.")
+	}
+	return lines
+}
+
+// appendDebugTrace adds the trace of a tool call to its result, after any output limit was applied, so the
+// trace is always complete
+func appendDebugTrace(result *mcp.CallToolResult, trace *requestTrace) {
+	if result == nil || trace == nil {
+		return
+	}
+	result.Content = append(result.Content, mcp.NewTextContent(strings.Join(trace.lines(), "\n")))
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestDebugTrace(t *testing.T) {
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eli/acts/DU/2018/1000" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"ELI": "DU/2018/1000", "publisher": "DU", "year": 2018, "pos": 1000, "title": "Ustawa o ochronie danych osobowych"}`)
+	}))
+	t.Cleanup(mirror.Close)
+
+	call := func(server *SejmServer, arguments string) string {
+		message := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"eli_get_act_details","arguments":` + arguments + `}}`
+		response, ok := server.server.HandleMessage(context.Background(), json.RawMessage(message)).(mcp.JSONRPCResponse)
+		if !ok {
+			t.Fatalf("Unexpected response to %s", arguments)
+		}
+		return extractTextContent(response.Result.(*mcp.CallToolResult))
+	}
+
+	server := NewSejmServerWithConfig(Config{DebugMode: true, ELIBaseURL: mirror.URL + "/eli"})
+	output := call(server, `{"publisher": "DU", "year": "2018", "position": "1000", "debug": "true"}`)
+	for _, expected := range []string{"Debug trace:", "upstream requests: 1", "GET " + mirror.URL + "/eli/acts/DU/2018/1000 → 200", "cache MISS"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in the trace, got: %s", expected, output)
+		}
+	}
+	output = call(server, `{"publisher": "DU", "year": "2018", "position": "9999", "debug": "true"}`)
+	if !strings.Contains(output, "→ 404") || !strings.Contains(output, "1 failed") || !strings.Contains(output, "error: resource not found") {
+		t.Errorf("Expected the failed request in the trace, got: %s", output)
+	}
+	if output := call(server, `{"publisher": "DU", "year": "2018", "position": "1000"}`); strings.Contains(output, "Debug trace:") {
+		t.Errorf("Expected no trace without debug='true', got: %s", output)
+	}
+
+	// Without -debug the parameter is neither offered nor honored
+	server = NewSejmServerWithConfig(Config{ELIBaseURL: mirror.URL + "/eli"})
+	if output := call(server, `{"publisher": "DU", "year": "2018", "position": "1000", "debug": "true"}`); strings.Contains(output, "Debug trace:") {
+		t.Errorf("Expected debug to be ignored without -debug, got: %s", output)
+	}
+	for _, tool := range server.server.ListTools() {
+		if _, ok := tool.Tool.InputSchema.Properties["debug"]; ok {
+			t.Fatalf("Expected no debug parameter without -debug, found it on %s", tool.Tool.Name)
+		}
+	}
+}
@@ -0,0 +1,447 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultDefectionVotings is the number of votings analyzed when max_votings is not given
+const defaultDefectionVotings = 50
+
+// maxDefectionVotings caps the number of voting details downloaded by a single call
+const maxDefectionVotings = 200
+
+// minClubVotersForMajority is the smallest number of participating club members needed to establish a club line
+const minClubVotersForMajority = 3
+
+// defectionVoteValues are the vote values that express a position on the motion
+var defectionVoteValues = []sejm.VoteValue{sejm.VoteValueYES, sejm.VoteValueNO, sejm.VoteValueABSTAIN}
+
+// clubMajority describes how a club voted as a whole
+type clubMajority struct {
+	Vote   sejm.VoteValue
+	Count  int
+	Voters int
+}
+
+// defection is a single MP vote against the majority of their club
+type defection struct {
+	MP       int32
+	Name     string
+	Club     string
+	Vote     sejm.VoteValue
+	Majority clubMajority
+}
+
+// clubMajorities computes the majority position of each club in a voting.
+// Clubs with a tie between the most common positions or with too few participating members have no majority.
+func clubMajorities(votes []sejm.Vote) map[string]clubMajority {
+	counts := make(map[string]map[sejm.VoteValue]int)
+	for _, vote := range votes {
+		if vote.Club == nil || vote.Vote == nil || !isPositionVote(*vote.Vote) {
+			continue
+		}
+		if counts[*vote.Club] == nil {
+			counts[*vote.Club] = make(map[sejm.VoteValue]int)
+		}
+		counts[*vote.Club][*vote.Vote]++
+	}
+
+	majorities := make(map[string]clubMajority)
+	for club, clubCounts := range counts {
+		majority := clubMajority{}
+		tie := false
+		for _, value := range defectionVoteValues {
+			count := clubCounts[value]
+			majority.Voters += count
+			switch {
+			case count > majority.Count:
+				majority.Vote = value
+				majority.Count = count
+				tie = false
+			case count > 0 && count == majority.Count:
+				tie = true
+			}
+		}
+		if tie || majority.Voters < minClubVotersForMajority {
+			continue
+		}
+		majorities[club] = majority
+	}
+	return majorities
+}
+
+// findDefections lists MPs who voted differently from their club majority
+func findDefections(votes []sejm.Vote, clubFilter string) []defection {
+	majorities := clubMajorities(votes)
+
+	var defections []defection
+	for _, vote := range votes {
+		if vote.Club == nil || vote.Vote == nil || !isPositionVote(*vote.Vote) {
+			continue
+		}
+		if clubFilter != "" && !strings.EqualFold(*vote.Club, clubFilter) {
+			continue
+		}
+		majority, ok := majorities[*vote.Club]
+		if !ok || majority.Vote == *vote.Vote {
+			continue
+		}
+
+		d := defection{Club: *vote.Club, Vote: *vote.Vote, Majority: majority}
+		if vote.MP != nil {
+			d.MP = *vote.MP
+		}
+		var nameParts []string
+		for _, part := range []*string{vote.FirstName, vote.SecondName, vote.LastName} {
+			if part != nil && *part != "" {
+				nameParts = append(nameParts, *part)
+			}
+		}
+		d.Name = strings.Join(nameParts, " ")
+		defections = append(defections, d)
+	}
+
+	sort.SliceStable(defections, func(i, j int) bool {
+		if defections[i].Club != defections[j].Club {
+			return defections[i].Club < defections[j].Club
+		}
+		return defections[i].Name < defections[j].Name
+	})
+	return defections
+}
+
+func isPositionVote(value sejm.VoteValue) bool {
+	for _, v := range defectionVoteValues {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDefectionDate validates an optional YYYY-MM-DD date parameter
+func parseDefectionDate(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s '%s': use YYYY-MM-DD format", name, value)
+	}
+	return date, nil
+}
+
+// votingDateInRange reports whether a voting took place within the inclusive date range
+func votingDateInRange(voting sejm.Voting, from, to time.Time) bool {
+	if from.IsZero() && to.IsZero() {
+		return true
+	}
+	if voting.Date == nil {
+		return false
+	}
+	day := voting.Date.Format("2006-01-02")
+	if !from.IsZero() && day < from.Format("2006-01-02") {
+		return false
+	}
+	if !to.IsZero() && day > to.Format("2006-01-02") {
+		return false
+	}
+	return true
+}
+
+// defectionSittings returns the sittings to scan, either the requested one or those with votings in the date range
+func (s *SejmServer) defectionSittings(ctx context.Context, term int, sitting string, from, to time.Time) ([]int, error) {
+	if sitting != "" {
+		number, err := strconv.Atoi(sitting)
+		if err != nil || number < 1 {
+			return nil, fmt.Errorf("invalid sitting '%s': must be a positive number", sitting)
+		}
+		return []int{number}, nil
+	}
+
+	sessions, err := s.fetchVotingSessions(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+
+	var sittings []int
+	seen := make(map[int]bool)
+	for _, session := range sessions {
+		if session.VotingsNum == 0 || seen[session.Proceeding] {
+			continue
+		}
+		if !from.IsZero() && session.Date < from.Format("2006-01-02") {
+			continue
+		}
+		if !to.IsZero() && session.Date > to.Format("2006-01-02") {
+			continue
+		}
+		seen[session.Proceeding] = true
+		sittings = append(sittings, session.Proceeding)
+	}
+	sort.Ints(sittings)
+	return sittings, nil
+}
+
+func (s *SejmServer) handleFindDefections(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_find_defections called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+
+	sitting := request.GetString("sitting", "")
+	clubFilter := request.GetString("club", "")
+	from, err := parseDefectionDate("date_from", request.GetString("date_from", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	to, err := parseDefectionDate("date_to", request.GetString("date_to", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if sitting == "" && from.IsZero() && to.IsZero() {
+		return mcp.NewToolResultError("Provide 'sitting' (e.g., '15') or a date range with 'date_from' and/or 'date_to' (YYYY-MM-DD). Analyzing a whole term at once would require downloading thousands of votings."), nil
+	}
+
+	maxVotings := defaultDefectionVotings
+	if maxStr := request.GetString("max_votings", ""); maxStr != "" {
+		if parsed, err := fmt.Sscanf(maxStr, "%d", &maxVotings); parsed != 1 || err != nil || maxVotings <= 0 {
+			maxVotings = defaultDefectionVotings
+		}
+		if maxVotings > maxDefectionVotings {
+			maxVotings = maxDefectionVotings
+		}
+	}
+
+	sittings, err := s.defectionSittings(ctx, term, sitting, from, to)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to determine sittings to analyze: %v", err)), nil
+	}
+
+	// Failed sittings and votings are skipped to keep the range analysis going, and reported with the results
+	coverage := newSourceCoverage("sources")
+	endpoints := make([]string, len(sittings))
+	for i, number := range sittings {
+		endpoints[i] = fmt.Sprintf("%s/sejm/term%d/votings/%d", s.sejmBaseURL, term, number)
+	}
+	sittingsData, errs := s.fetchDefectionSources(ctx, endpoints)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var votings []sejm.Voting
+	for i, number := range sittings {
+		data, err := sittingsData[i], errs[i]
+		if err != nil {
+			if sitting != "" {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve votings from sitting %d in term %d: %v", number, term, err)), nil
+			}
+			coverage.fail(fmt.Sprintf("sitting %d", number), err)
+			continue
+		}
+		var sittingVotings []sejm.Voting
+		if err := s.decodeAPIResponse(data, &sittingVotings); err != nil {
+			coverage.fail(fmt.Sprintf("sitting %d", number), fmt.Errorf("failed to parse votings: %w", err))
+			continue
+		}
+		coverage.succeeded()
+		for _, voting := range sittingVotings {
+			if votingDateInRange(voting, from, to) {
+				votings = append(votings, voting)
+			}
+		}
+	}
+
+	truncated := len(votings) > maxVotings
+	if truncated {
+		votings = votings[:maxVotings]
+	}
+
+	var complete []sejm.Voting
+	for _, voting := range votings {
+		if voting.Sitting != nil && voting.VotingNumber != nil {
+			complete = append(complete, voting)
+		}
+	}
+	votings = complete
+	endpoints = make([]string, len(votings))
+	for i, voting := range votings {
+		endpoints[i] = fmt.Sprintf("%s/sejm/term%d/votings/%d/%d", s.sejmBaseURL, term, *voting.Sitting, *voting.VotingNumber)
+	}
+	votingsData, errs := s.fetchDefectionSources(ctx, endpoints)
+
+	var data []string
+	analyzed := 0
+	interrupted := false
+	votingsWithDefections := 0
+	totalDefections := 0
+	defectorCounts := make(map[string]int)
+	for i, voting := range votings {
+		detailsData, err := votingsData[i], errs[i]
+		if err != nil {
+			// Votings not fetched because the call was cancelled are not failed sources; the analysis stops there
+			if ctx.Err() != nil {
+				interrupted = true
+				break
+			}
+			s.logger.Warn("Skipping voting", slog.String("endpoint", endpoints[i]), slog.Any("error", err))
+			coverage.fail(fmt.Sprintf("voting %d/%d", *voting.Sitting, *voting.VotingNumber), err)
+			continue
+		}
+		var details sejm.VotingDetails
+		if err := s.decodeAPIResponse(detailsData, &details); err != nil {
+			coverage.fail(fmt.Sprintf("voting %d/%d", *voting.Sitting, *voting.VotingNumber), fmt.Errorf("failed to parse voting details: %w", err))
+			continue
+		}
+		coverage.succeeded()
+		if details.Votes == nil {
+			continue
+		}
+		analyzed++
+
+		defections := findDefections(*details.Votes, clubFilter)
+		if len(defections) == 0 {
+			continue
+		}
+		votingsWithDefections++
+		totalDefections += len(defections)
+
+		title := "No title"
+		if voting.Title != nil {
+			title = *voting.Title
+		}
+		header := fmt.Sprintf("Sitting %d, voting %d", *voting.Sitting, *voting.VotingNumber)
+		if voting.Date != nil {
+			header += fmt.Sprintf(" (%s)", voting.Date.Format("2006-01-02 15:04"))
+		}
+		data = append(data, fmt.Sprintf("%s: %s", header, title))
+		if voting.Topic != nil && *voting.Topic != "" {
+			data = append(data, fmt.Sprintf("  Topic: %s", *voting.Topic))
+		}
+		for _, d := range defections {
+			data = append(data, fmt.Sprintf("  • %s (ID: %d, %s) voted %s; club majority %s (%d of %d)",
+				d.Name, d.MP, d.Club, d.Vote, d.Majority.Vote, d.Majority.Count, d.Majority.Voters))
+			defectorCounts[fmt.Sprintf("%s (ID: %d, %s)", d.Name, d.MP, d.Club)]++
+		}
+		data = append(data, "")
+	}
+
+	scope := fmt.Sprintf("term %d", term)
+	if sitting != "" {
+		scope += fmt.Sprintf(", sitting %s", sitting)
+	}
+	if !from.IsZero() || !to.IsZero() {
+		scope += fmt.Sprintf(", dates %s to %s", formatOptionalDate(from, "start"), formatOptionalDate(to, "end"))
+	}
+	if clubFilter != "" {
+		scope += fmt.Sprintf(", club %s", clubFilter)
+	}
+
+	summary := []string{
+		fmt.Sprintf("Scope: %s", scope),
+		fmt.Sprintf("Votings analyzed: %d", analyzed),
+		fmt.Sprintf("Votings with defections: %d", votingsWithDefections),
+		fmt.Sprintf("Total defections: %d", totalDefections),
+	}
+	if len(defectorCounts) > 0 {
+		type defectorCount struct {
+			name  string
+			count int
+		}
+		var defectors []defectorCount
+		for name, count := range defectorCounts {
+			defectors = append(defectors, defectorCount{name, count})
+		}
+		sort.Slice(defectors, func(i, j int) bool {
+			if defectors[i].count != defectors[j].count {
+				return defectors[i].count > defectors[j].count
+			}
+			return defectors[i].name < defectors[j].name
+		})
+		var top []string
+		for i, d := range defectors {
+			if i >= 10 {
+				break
+			}
+			top = append(top, fmt.Sprintf("%s: %d", d.name, d.count))
+		}
+		summary = append(summary, fmt.Sprintf("Most frequent defectors: %s", strings.Join(top, "; ")))
+	}
+
+	status := "Analysis Completed Successfully"
+	if totalDefections == 0 {
+		status = "No Results Found"
+		data = append(data, "No MP voted against their club majority in the analyzed votings.")
+	}
+
+	note := fmt.Sprintf("A defection is a YES, NO or ABSTAIN vote that differs from the most common position of the MP's club. Absent MPs are ignored, and clubs with a tie or fewer than %d participating members have no club line.", minClubVotersForMajority)
+	if truncated {
+		note += fmt.Sprintf(" Only the first %d votings in the range were analyzed; narrow the range or raise max_votings (up to %d).", maxVotings, maxDefectionVotings)
+	}
+
+	if interrupted {
+		if analyzed == 0 {
+			return nil, ctx.Err()
+		}
+		note += fmt.Sprintf(" The analysis was interrupted before all voting details were downloaded; it covers %d of %d votings.", analyzed, len(votings))
+	}
+
+	response := StandardResponse{
+		Operation:   "Party-Line Defections",
+		Status:      coverage.status(status),
+		Summary:     summary,
+		Unavailable: coverage.unavailable(),
+		Data:        data,
+		NextActions: []string{
+			"Inspect a voting in full: sejm_get_voting_details with sitting and voting_number",
+			"Check an MP's overall record: sejm_get_mp_voting_stats with mp_id",
+		},
+		Note: note,
+	}
+	result := coverage.result(response.Format())
+	if interrupted {
+		markPartial(result)
+	}
+	return result, nil
+}
+
+// fetchDefectionSources downloads the given endpoints with limited concurrency, keeping the input order.
+// Endpoints not requested because ctx was cancelled report the context error.
+func (s *SejmServer) fetchDefectionSources(ctx context.Context, endpoints []string) ([][]byte, []error) {
+	bodies := make([][]byte, len(endpoints))
+	errs := make([]error, len(endpoints))
+	slots := make(chan struct{}, maxConcurrentBodyFetches)
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpoint string) {
+			defer wg.Done()
+			if err := acquireSlot(ctx, slots); err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { <-slots }()
+			bodies[i], errs[i] = s.makeAPIRequest(ctx, endpoint, nil)
+		}(i, endpoint)
+	}
+	wg.Wait()
+	return bodies, errs
+}
+
+func formatOptionalDate(date time.Time, fallback string) string {
+	if date.IsZero() {
+		return fallback
+	}
+	return date.Format("2006-01-02")
+}
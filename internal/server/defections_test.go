@@ -0,0 +1,180 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+func testVote(mp int32, club, lastName string, value sejm.VoteValue) sejm.Vote {
+	first := "Jan"
+	return sejm.Vote{MP: &mp, Club: &club, FirstName: &first, LastName: &lastName, Vote: &value}
+}
+
+func TestFindDefections(t *testing.T) {
+	votes := []sejm.Vote{
+		testVote(1, "KO", "Kowalski", sejm.VoteValueYES),
+		testVote(2, "KO", "Nowak", sejm.VoteValueYES),
+		testVote(3, "KO", "Wiśniewski", sejm.VoteValueNO),
+		testVote(4, "KO", "Absent", sejm.VoteValueABSENT),
+		testVote(5, "PiS", "Zieliński", sejm.VoteValueNO),
+		testVote(6, "PiS", "Lewandowski", sejm.VoteValueNO),
+		testVote(7, "PiS", "Wójcik", sejm.VoteValueNO),
+		testVote(8, "PiS", "Kamiński", sejm.VoteValueABSTAIN),
+		testVote(9, "Razem", "Tie", sejm.VoteValueYES),
+		testVote(10, "Razem", "Tie", sejm.VoteValueNO),
+		testVote(11, "Razem", "Tie", sejm.VoteValueABSTAIN),
+	}
+
+	defections := findDefections(votes, "")
+	if len(defections) != 2 {
+		t.Fatalf("Expected 2 defections, got %+v", defections)
+	}
+	if defections[0].Name != "Jan Wiśniewski" || defections[0].Vote != sejm.VoteValueNO || defections[0].Majority.Vote != sejm.VoteValueYES || defections[0].Majority.Voters != 3 {
+		t.Errorf("Unexpected KO defection: %+v", defections[0])
+	}
+	if defections[1].MP != 8 || defections[1].Majority.Count != 3 {
+		t.Errorf("Unexpected PiS defection: %+v", defections[1])
+	}
+
+	if filtered := findDefections(votes, "pis"); len(filtered) != 1 || filtered[0].Club != "PiS" {
+		t.Errorf("Expected club filter to keep only PiS defection, got %+v", filtered)
+	}
+}
+
+func TestHandleFindDefections(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/votings": `[
+			{"date": "2024-03-07", "proceeding": 7, "votingsNum": 2},
+			{"date": "2024-03-08", "proceeding": 7, "votingsNum": 1},
+			{"date": "2024-04-11", "proceeding": 9, "votingsNum": 1}
+		]`,
+		"/sejm/term10/votings/7": `[
+			{"sitting": 7, "votingNumber": 1, "date": "2024-03-07T10:00:00", "title": "Pkt 3. Projekt ustawy o zmianie ustawy"},
+			{"sitting": 7, "votingNumber": 2, "date": "2024-03-08T11:00:00", "title": "Pkt 4. Uchwała"}
+		]`,
+		"/sejm/term10/votings/7/1": `{"votes": [
+			{"MP": 1, "club": "KO", "firstName": "Anna", "lastName": "Nowak", "vote": "YES"},
+			{"MP": 2, "club": "KO", "firstName": "Jan", "lastName": "Kowalski", "vote": "YES"},
+			{"MP": 3, "club": "KO", "firstName": "Piotr", "lastName": "Zieliński", "vote": "NO"}
+		]}`,
+		"/sejm/term10/votings/7/2": `{"votes": [
+			{"MP": 1, "club": "KO", "firstName": "Anna", "lastName": "Nowak", "vote": "YES"},
+			{"MP": 2, "club": "KO", "firstName": "Jan", "lastName": "Kowalski", "vote": "YES"},
+			{"MP": 3, "club": "KO", "firstName": "Piotr", "lastName": "Zieliński", "vote": "YES"}
+		]}`,
+	})
+
+	result, err := server.handleFindDefections(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "date_from": "2024-03-01", "date_to": "2024-03-31",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Votings analyzed: 2",
+		"Total defections: 1",
+		"Sitting 7, voting 1",
+		"Piotr Zieliński (ID: 3, KO) voted NO; club majority YES (2 of 3)",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+
+	// Sitting 9 is in range but has no fixture; the analysis goes on and reports it
+	result, err = server.handleFindDefections(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "date_from": "2024-03-01", "date_to": "2024-04-30",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content = extractTextContent(result)
+	for _, expected := range []string{
+		"Party-Line Defections - Partially Retrieved",
+		"Unavailable Sources:",
+		"1 of 4 sources unavailable",
+		"sitting 9: resource not found (404)",
+		"Total defections: 1",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+
+	result, _ = server.handleFindDefections(context.Background(), createMockRequest(map[string]interface{}{"term": "10"}))
+	if !result.IsError {
+		t.Error("Expected error when neither sitting nor date range is given")
+	}
+	result, _ = server.handleFindDefections(context.Background(), createMockRequest(map[string]interface{}{"term": "10", "date_from": "03/2024"}))
+	if !result.IsError {
+		t.Error("Expected error for invalid date")
+	}
+}
+
+// cancelTransport cancels the call when the given path is requested, as a timeout would during a fan-out
+type cancelTransport struct {
+	next   http.RoundTripper
+	path   string
+	cancel context.CancelFunc
+}
+
+func (t *cancelTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == t.path {
+		t.cancel()
+		return nil, context.Canceled
+	}
+	return t.next.RoundTrip(req)
+}
+
+func TestHandleFindDefectionsCancelled(t *testing.T) {
+	fixtures := map[string]string{
+		"/sejm/term10/votings": `[{"date": "2024-03-07", "proceeding": 7, "votingsNum": 12}]`,
+	}
+	var votings []string
+	for number := 1; number <= 12; number++ {
+		votings = append(votings, fmt.Sprintf(`{"sitting": 7, "votingNumber": %d, "date": "2024-03-07T10:00:00", "title": "Głosowanie %d"}`, number, number))
+		fixtures[fmt.Sprintf("/sejm/term10/votings/7/%d", number)] = `{"votes": [
+			{"MP": 1, "club": "KO", "firstName": "Anna", "lastName": "Nowak", "vote": "YES"},
+			{"MP": 2, "club": "KO", "firstName": "Jan", "lastName": "Kowalski", "vote": "YES"},
+			{"MP": 3, "club": "KO", "firstName": "Piotr", "lastName": "Zieliński", "vote": "NO"}
+		]}`
+	}
+	fixtures["/sejm/term10/votings/7"] = "[" + strings.Join(votings, ",") + "]"
+	server := newServerWithFixtures(t, fixtures)
+	request := createMockRequest(map[string]interface{}{"term": "10", "date_from": "2024-03-07", "date_to": "2024-03-07"})
+
+	transport := server.client.Transport
+
+	// A call cancelled while the sittings are downloaded has nothing to report
+	ctx, cancel := context.WithCancel(context.Background())
+	server.client = &http.Client{Transport: &cancelTransport{next: transport, path: "/sejm/term10/votings/7", cancel: cancel}}
+	if result, err := server.handleFindDefections(ctx, request); !errors.Is(err, context.Canceled) || result != nil {
+		t.Fatalf("Expected the context error for a cancelled call, got %v %s", err, extractTextContent(result))
+	}
+
+	// Votings left when the call is cancelled halfway are not reported as unavailable sources
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	server.client = &http.Client{Transport: &cancelTransport{next: transport, path: "/sejm/term10/votings/7/6", cancel: cancel}}
+	result, err := server.handleFindDefections(ctx, request)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) || result != nil {
+			t.Fatalf("Expected the context error or partial results, got %v", err)
+		}
+		return
+	}
+	content := extractTextContent(result)
+	if !strings.Contains(content, "The analysis was interrupted") || strings.Contains(content, "Unavailable Sources:") {
+		t.Errorf("Expected interrupted results without unavailable sources, got: %s", content)
+	}
+	if result.Meta == nil || result.Meta.AdditionalFields[partialResultMeta] != true {
+		t.Error("Expected interrupted results to be marked partial")
+	}
+}
@@ -0,0 +1,619 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Document formats recognized by the extraction layer
+const (
+	documentFormatPDF     = "pdf"
+	documentFormatDOCX    = "docx"
+	documentFormatODT     = "odt"
+	documentFormatRTF     = "rtf"
+	documentFormatText    = "txt"
+	documentFormatHTML    = "html"
+	documentFormatUnknown = ""
+)
+
+// documentPageChars is the size of the virtual pages used to paginate formats without a page layout (DOCX, ODT, RTF, HTML)
+const documentPageChars = 3000
+
+// documentPreviewChars limits the extracted text included in attachment download responses
+const documentPreviewChars = 10000
+
+// supportedDocumentFormats lists the formats that can be converted to text, in order of preference
+var supportedDocumentFormats = []string{documentFormatPDF, documentFormatDOCX, documentFormatODT, documentFormatRTF, documentFormatText, documentFormatHTML}
+
+// documentFormatFromName guesses the document format from a file name extension
+func documentFormatFromName(name string) string {
+	switch strings.ToLower(strings.TrimPrefix(path.Ext(name), ".")) {
+	case "pdf":
+		return documentFormatPDF
+	case "docx":
+		return documentFormatDOCX
+	case "odt":
+		return documentFormatODT
+	case "rtf":
+		return documentFormatRTF
+	case "txt":
+		return documentFormatText
+	case "html", "htm":
+		return documentFormatHTML
+	default:
+		return documentFormatUnknown
+	}
+}
+
+// detectDocumentFormat determines the format from the file content, falling back to the file name.
+// Content wins because attachments are sometimes uploaded with a misleading extension.
+func detectDocumentFormat(name string, data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("%PDF")):
+		return documentFormatPDF
+	case bytes.HasPrefix(data, []byte(`{\rtf`)):
+		return documentFormatRTF
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		if format := detectZipDocumentFormat(data); format != documentFormatUnknown {
+			return format
+		}
+	}
+	return documentFormatFromName(name)
+}
+
+// detectZipDocumentFormat tells DOCX and ODT packages apart by their well-known entries
+func detectZipDocumentFormat(data []byte) string {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return documentFormatUnknown
+	}
+	for _, file := range reader.File {
+		switch file.Name {
+		case "word/document.xml":
+			return documentFormatDOCX
+		case "content.xml":
+			return documentFormatODT
+		}
+	}
+	return documentFormatUnknown
+}
+
+// extractAttachmentText converts a downloaded attachment to plain text regardless of its upload format
+func (s *SejmServer) extractAttachmentText(ctx context.Context, name string, data []byte) (string, string, error) {
+	format := detectDocumentFormat(name, data)
+	if format == documentFormatPDF {
+		text, err := s.extractTextFromPDF(ctx, data)
+		return text, format, err
+	}
+	text, err := extractDocumentText(format, data)
+	return text, format, err
+}
+
+// extractDocumentText extracts plain text from non-PDF documents
+func extractDocumentText(format string, data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", fmt.Errorf("document is empty")
+	}
+
+	var text string
+	var err error
+	switch format {
+	case documentFormatDOCX:
+		text, err = extractDOCXText(data)
+	case documentFormatODT:
+		text, err = extractODTText(data)
+	case documentFormatRTF:
+		text = extractRTFText(data)
+	case documentFormatText:
+		text = decodeLegacyText(data)
+	case documentFormatHTML:
+		text = htmlToPlainText(string(normalizeHTMLBody(data)))
+	default:
+		return "", fmt.Errorf("unsupported document format; supported formats are %s", strings.Join(supportedDocumentFormats, ", "))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	text = normalizeExtractedText(text)
+	if text == "" {
+		return "", fmt.Errorf("no text could be extracted from %s document", strings.ToUpper(format))
+	}
+	return text, nil
+}
+
+// readZipEntry returns the content of a single file inside a ZIP-based document package
+func readZipEntry(data []byte, name string) ([]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open document package: %w", err)
+	}
+	for _, file := range reader.File {
+		if file.Name != name {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", name, err)
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	return nil, fmt.Errorf("document package does not contain %s", name)
+}
+
+// extractDOCXText extracts paragraph text from the main part of a Word document
+func extractDOCXText(data []byte) (string, error) {
+	content, err := readZipEntry(data, "word/document.xml")
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	inText := false
+	inTabStops := false // w:tabs holds tab stop definitions, not tab characters
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse DOCX content: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tabs":
+				inTabStops = true
+			case "tab":
+				if !inTabStops {
+					builder.WriteString("\t")
+				}
+			case "br", "cr":
+				builder.WriteString("\n")
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "tabs":
+				inTabStops = false
+			case "p":
+				builder.WriteString("\n")
+			}
+		case xml.CharData:
+			if inText {
+				builder.Write(t)
+			}
+		}
+	}
+	return builder.String(), nil
+}
+
+// extractODTText extracts paragraph and heading text from an OpenDocument text file
+func extractODTText(data []byte) (string, error) {
+	content, err := readZipEntry(data, "content.xml")
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	inBody := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse ODT content: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "body":
+				inBody = true
+			case "s":
+				// text:s collapses repeated spaces, text:c holds the count
+				count := 1
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "c" {
+						if parsed, err := strconv.Atoi(attr.Value); err == nil && parsed > 0 {
+							count = parsed
+						}
+					}
+				}
+				builder.WriteString(strings.Repeat(" ", count))
+			case "tab":
+				builder.WriteString("\t")
+			case "line-break":
+				builder.WriteString("\n")
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "body":
+				inBody = false
+			case "p", "h":
+				builder.WriteString("\n")
+			}
+		case xml.CharData:
+			if inBody {
+				builder.Write(t)
+			}
+		}
+	}
+	return builder.String(), nil
+}
+
+// rtfSkippedDestinations are RTF groups that hold formatting or metadata rather than document text
+var rtfSkippedDestinations = map[string]bool{
+	"fonttbl":    true,
+	"colortbl":   true,
+	"stylesheet": true,
+	"info":       true,
+	"pict":       true,
+	"header":     true,
+	"footer":     true,
+	"listtable":  true,
+	"themedata":  true,
+	"datastore":  true,
+	"xmlnstbl":   true,
+}
+
+// extractRTFText strips RTF control words and groups, decoding \'hh escapes as Windows-1250
+// (the code page used by Polish documents) and \uN escapes as Unicode
+func extractRTFText(data []byte) string {
+	type groupState struct {
+		skip        bool
+		unicodeSkip int
+	}
+
+	var builder strings.Builder
+	stack := []groupState{{unicodeSkip: 1}}
+	pendingSkip := 0
+
+	for i := 0; i < len(data); i++ {
+		current := &stack[len(stack)-1]
+		c := data[i]
+
+		switch c {
+		case '{':
+			stack = append(stack, *current)
+			continue
+		case '}':
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		case '\r', '\n':
+			continue
+		case '\\':
+		default:
+			if pendingSkip > 0 {
+				pendingSkip--
+				continue
+			}
+			if !current.skip {
+				builder.WriteRune(decodeWindows1250(c))
+			}
+			continue
+		}
+
+		// Control sequence
+		if i+1 >= len(data) {
+			break
+		}
+		next := data[i+1]
+		switch {
+		case next == '\'':
+			if i+3 < len(data) {
+				if value, err := strconv.ParseUint(string(data[i+2:i+4]), 16, 8); err == nil {
+					if pendingSkip > 0 {
+						pendingSkip--
+					} else if !current.skip {
+						builder.WriteRune(decodeWindows1250(byte(value)))
+					}
+				}
+			}
+			i += 3
+		case next == '*':
+			current.skip = true
+			i++
+		case next == '\\' || next == '{' || next == '}':
+			if !current.skip {
+				builder.WriteByte(next)
+			}
+			i++
+		case next == '~':
+			if !current.skip {
+				builder.WriteString(" ")
+			}
+			i++
+		case next == '-' || next == '_':
+			i++
+		case next == '\n' || next == '\r':
+			if !current.skip {
+				builder.WriteString("\n")
+			}
+			i++
+		case isASCIILetter(next):
+			j := i + 1
+			for j < len(data) && isASCIILetter(data[j]) {
+				j++
+			}
+			word := string(data[i+1 : j])
+			paramStart := j
+			if j < len(data) && data[j] == '-' {
+				j++
+			}
+			for j < len(data) && data[j] >= '0' && data[j] <= '9' {
+				j++
+			}
+			param, hasParam := 0, false
+			if j > paramStart {
+				if parsed, err := strconv.Atoi(string(data[paramStart:j])); err == nil {
+					param, hasParam = parsed, true
+				}
+			}
+			// A single space delimits the control word and is not part of the text
+			if j < len(data) && data[j] == ' ' {
+				j++
+			}
+			i = j - 1
+
+			switch {
+			case rtfSkippedDestinations[word]:
+				current.skip = true
+			case word == "par" || word == "line" || word == "sect" || word == "page" || word == "row":
+				if !current.skip {
+					builder.WriteString("\n")
+				}
+			case word == "tab" || word == "cell":
+				if !current.skip {
+					builder.WriteString("\t")
+				}
+			case word == "uc" && hasParam:
+				current.unicodeSkip = param
+			case word == "u" && hasParam:
+				if param < 0 {
+					param += 65536
+				}
+				if !current.skip {
+					builder.WriteRune(rune(param))
+				}
+				pendingSkip = current.unicodeSkip
+			}
+		default:
+			i++
+		}
+	}
+	return builder.String()
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// windows1250 maps the upper half of the Windows-1250 (Central European) code page to Unicode
+var windows1250 = []rune("€\ufffd‚\ufffd„…†‡\ufffd‰Š‹ŚŤŽŹ" +
+	"\ufffd‘’“”•–—\ufffd™š›śťžź" +
+	"\u00a0ˇ˘Ł¤Ą¦§¨©Ş«¬\u00ad®Ż" +
+	"°±˛ł´µ¶·¸ąş»Ľ˝ľż" +
+	"ŔÁÂĂÄĹĆÇČÉĘËĚÍÎĎ" +
+	"ĐŃŇÓÔŐÖ×ŘŮÚŰÜÝŢß" +
+	"ŕáâăäĺćçčéęëěíîď" +
+	"đńňóôőö÷řůúűüýţ˙")
+
+// decodeWindows1250 converts a single Windows-1250 byte to its Unicode rune
+func decodeWindows1250(c byte) rune {
+	if c < 0x80 {
+		return rune(c)
+	}
+	return windows1250[c-0x80]
+}
+
+// decodeLegacyText returns UTF-8 text as-is and decodes anything else as Windows-1250
+func decodeLegacyText(data []byte) string {
+	data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	var builder strings.Builder
+	for _, c := range data {
+		builder.WriteRune(decodeWindows1250(c))
+	}
+	return builder.String()
+}
+
+// normalizeExtractedText trims trailing spaces and collapses runs of blank lines
+func normalizeExtractedText(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	var result []string
+	blank := 0
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t\u00a0")
+		if strings.TrimSpace(line) == "" {
+			blank++
+			if blank > 1 {
+				continue
+			}
+			line = ""
+		} else {
+			blank = 0
+		}
+		result = append(result, line)
+	}
+	return strings.TrimSpace(strings.Join(result, "\n"))
+}
+
+// splitTextIntoPages groups paragraphs into virtual pages of roughly documentPageChars characters
+func splitTextIntoPages(text string, pageChars int) []string {
+	var pages []string
+	var current strings.Builder
+	for _, paragraph := range strings.Split(text, "\n") {
+		if current.Len() > 0 && current.Len()+len(paragraph) > pageChars {
+			pages = append(pages, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+		// Paragraphs longer than a page are cut at rune boundaries
+		for len(paragraph) > pageChars {
+			cut := pageChars
+			for cut > 0 && !utf8.RuneStart(paragraph[cut]) {
+				cut--
+			}
+			pages = append(pages, strings.TrimSpace(paragraph[:cut]))
+			paragraph = paragraph[cut:]
+		}
+		current.WriteString(paragraph)
+		current.WriteString("\n")
+	}
+	if strings.TrimSpace(current.String()) != "" {
+		pages = append(pages, strings.TrimSpace(current.String()))
+	}
+	return pages
+}
+
+// documentTextWithPagination returns text of a non-PDF document split into virtual pages,
+// following the same page/pages_per_chunk/show_page_info conventions as PDF pagination
+func (s *SejmServer) documentTextWithPagination(_ context.Context, text, documentName, toolName, pageStr, pagesPerChunkStr, showPageInfo string) (*mcp.CallToolResult, error) {
+	pages := splitTextIntoPages(text, documentPageChars)
+	pageCount := len(pages)
+	if pageCount == 0 {
+		return mcp.NewToolResultError("Document contains no text"), nil
+	}
+
+	s.logger.Info("Paginating extracted document text",
+		slog.String("document", documentName),
+		slog.Int("characters", len(text)),
+		slog.Int("virtualPages", pageCount))
+
+	pagesPerChunk := 5 // default
+	if pagesPerChunkStr != "" {
+		if parsed, parseErr := fmt.Sscanf(pagesPerChunkStr, "%d", &pagesPerChunk); parsed == 1 && parseErr == nil {
+			if pagesPerChunk > 20 {
+				pagesPerChunk = 20 // max limit
+			} else if pagesPerChunk < 1 {
+				pagesPerChunk = 1 // min limit
+			}
+		} else {
+			pagesPerChunk = 5 // fallback to default
+		}
+	}
+
+	if showPageInfo == "true" {
+		response := StandardResponse{
+			Operation: "Document Page Information",
+			Status:    "Retrieved Successfully",
+			Summary: []string{
+				fmt.Sprintf("Document: %s", documentName),
+				fmt.Sprintf("Total pages: %d (virtual pages of about %d characters)", pageCount, documentPageChars),
+				fmt.Sprintf("Default pages per chunk: %d", pagesPerChunk),
+			},
+			Data: []string{
+				"Page Navigation Instructions:",
+				"• Use page='1' to start from first page",
+				fmt.Sprintf("• Use pages_per_chunk='%d' to get %d pages at once (max: 20)", pagesPerChunk, pagesPerChunk),
+				fmt.Sprintf("• Page ranges: 1-%d available", pageCount),
+			},
+			NextActions: []string{
+				fmt.Sprintf("Read specific pages: %s with page and pages_per_chunk parameters", toolName),
+			},
+			Note: "This document format has no fixed page layout, so text is split into virtual pages for navigation.",
+		}
+		return mcp.NewToolResultText(response.Format()), nil
+	}
+
+	startPage := 1 // default to first page
+	if pageStr != "" {
+		if parsed, parseErr := fmt.Sscanf(pageStr, "%d", &startPage); parsed == 1 && parseErr == nil {
+			if startPage < 1 {
+				startPage = 1
+			} else if startPage > pageCount {
+				return mcp.NewToolResultError(fmt.Sprintf("Page %d is out of range. Document has only %d pages. Use page numbers 1-%d.", startPage, pageCount, pageCount)), nil
+			}
+		} else {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid page number '%s'. Please use a number between 1 and %d.", pageStr, pageCount)), nil
+		}
+	}
+
+	endPage := startPage + pagesPerChunk - 1
+	if endPage > pageCount {
+		endPage = pageCount
+	}
+
+	var textBuilder strings.Builder
+	for pageNum := startPage; pageNum <= endPage; pageNum++ {
+		if pageNum > startPage {
+			textBuilder.WriteString(fmt.Sprintf("\n\n--- Page %d ---\n\n", pageNum))
+		}
+		textBuilder.WriteString(pages[pageNum-1])
+	}
+	extractedText := textBuilder.String()
+
+	var nextActions []string
+	if endPage < pageCount {
+		nextActions = append(nextActions, fmt.Sprintf("Read next pages: %s with page='%d' and pages_per_chunk='%d'", toolName, endPage+1, pagesPerChunk))
+	}
+	if startPage > 1 {
+		prevPageStart := startPage - pagesPerChunk
+		if prevPageStart < 1 {
+			prevPageStart = 1
+		}
+		nextActions = append(nextActions, fmt.Sprintf("Read previous pages: %s with page='%d' and pages_per_chunk='%d'", toolName, prevPageStart, pagesPerChunk))
+	}
+	nextActions = append(nextActions, fmt.Sprintf("Get page information: %s with show_page_info='true'", toolName))
+
+	response := StandardResponse{
+		Operation: "Document Text (Paginated)",
+		Status:    "Retrieved Successfully",
+		Summary: []string{
+			fmt.Sprintf("Document: %s", documentName),
+			fmt.Sprintf("Pages extracted: %d-%d of %d total pages", startPage, endPage, pageCount),
+			fmt.Sprintf("Text length: %d characters", len(extractedText)),
+		},
+		Data: []string{
+			fmt.Sprintf("=== DOCUMENT TEXT - PAGES %d-%d ===", startPage, endPage),
+			"",
+			extractedText,
+		},
+		NextActions: nextActions,
+		Note:        fmt.Sprintf("Showing pages %d-%d of %d. Pages are virtual (about %d characters each) because this document format has no fixed page layout.", startPage, endPage, pageCount, documentPageChars),
+	}
+
+	return mcp.NewToolResultText(response.Format()), nil
+}
+
+// attachmentTextPreview extracts readable text from an attachment for inclusion in download responses
+func (s *SejmServer) attachmentTextPreview(ctx context.Context, name string, data []byte) []string {
+	text, format, err := s.extractAttachmentText(ctx, name, data)
+	if err != nil {
+		return []string{fmt.Sprintf("Text extraction not available: %v", err)}
+	}
+
+	preview := []string{fmt.Sprintf("=== EXTRACTED TEXT (%s) ===", strings.ToUpper(format)), ""}
+	if len(text) > documentPreviewChars {
+		cut := documentPreviewChars
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+		preview = append(preview, text[:cut], "", fmt.Sprintf("... text truncated (%d of %d characters shown)", cut, len(text)))
+		return preview
+	}
+	return append(preview, text)
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// buildTestDocument creates a ZIP-based document package with the given entries
+func buildTestDocument(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range entries {
+		file, err := writer.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+		if _, err := file.Write([]byte(content)); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to close document package: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestDetectDocumentFormat(t *testing.T) {
+	docx := buildTestDocument(t, map[string]string{"word/document.xml": "<w:document/>"})
+	odt := buildTestDocument(t, map[string]string{"mimetype": "application/vnd.oasis.opendocument.text", "content.xml": "<office:document-content/>"})
+
+	testCases := []struct {
+		name     string
+		fileName string
+		data     []byte
+		expected string
+	}{
+		{"pdf magic", "druk.bin", []byte("%PDF-1.7"), documentFormatPDF},
+		{"rtf magic", "druk.doc", []byte(`{\rtf1\ansi}`), documentFormatRTF},
+		{"docx package", "druk.odt", docx, documentFormatDOCX},
+		{"odt package", "druk", odt, documentFormatODT},
+		{"extension fallback", "notatka.TXT", []byte("tekst"), documentFormatText},
+		{"unknown", "obraz.jpg", []byte{0xFF, 0xD8}, documentFormatUnknown},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if format := detectDocumentFormat(tc.fileName, tc.data); format != tc.expected {
+				t.Errorf("Expected format '%s', got '%s'", tc.expected, format)
+			}
+		})
+	}
+}
+
+func TestExtractDocumentText(t *testing.T) {
+	docx := buildTestDocument(t, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			`<w:p><w:pPr><w:tabs><w:tab w:val="left" w:pos="720"/></w:tabs></w:pPr><w:r><w:t>Art. 1.</w:t></w:r><w:r><w:tab/><w:t>Ustawa określa</w:t></w:r></w:p>` +
+			`<w:p><w:r><w:t xml:space="preserve">zasady </w:t></w:r><w:r><w:t>opodatkowania.</w:t></w:r></w:p>` +
+			`</w:body></w:document>`,
+	})
+	odt := buildTestDocument(t, map[string]string{
+		"content.xml": `<office:document-content xmlns:office="o" xmlns:text="t"><office:automatic-styles><style:style/></office:automatic-styles>` +
+			`<office:body><office:text><text:h>Uzasadnienie</text:h><text:p>Projekt<text:s text:c="2"/>ustawy</text:p></office:text></office:body></office:document-content>`,
+	})
+
+	testCases := []struct {
+		name     string
+		format   string
+		data     []byte
+		expected string
+	}{
+		{"docx", documentFormatDOCX, docx, "Art. 1.\tUstawa określa\nzasady opodatkowania."},
+		{"odt", documentFormatODT, odt, "Uzasadnienie\nProjekt  ustawy"},
+		{"rtf unicode", documentFormatRTF, []byte(`{\rtf1\ansi\uc1{\fonttbl{\f0 Arial;}}\f0 Ustawa o \u322?adzie\par Drugi akapit}`), "Ustawa o ładzie\nDrugi akapit"},
+		{"rtf code page", documentFormatRTF, []byte(`{\rtf1\ansi\ansicpg1250{\*\generator Writer;}Za\'bf\'f3\'b3\'e6 g\'ea\'9cl\'b9 ja\'9f\'f1}`), "Zażółć gęślą jaźń"},
+		{"windows-1250 text", documentFormatText, []byte("Pos\xb3anka"), "Posłanka"},
+		{"utf-8 text", documentFormatText, []byte("\xEF\xBB\xBFPosłanka\n\n\n\nPoseł"), "Posłanka\n\nPoseł"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			text, err := extractDocumentText(tc.format, tc.data)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if text != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, text)
+			}
+		})
+	}
+
+	if _, err := extractDocumentText(documentFormatUnknown, []byte("data")); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+	if _, err := extractDocumentText(documentFormatDOCX, []byte("not a zip")); err == nil {
+		t.Error("Expected error for corrupted DOCX")
+	}
+}
+
+func TestSplitTextIntoPages(t *testing.T) {
+	paragraph := strings.Repeat("ą", 40) // 80 bytes
+	text := strings.Join([]string{paragraph, paragraph, paragraph}, "\n")
+
+	pages := splitTextIntoPages(text, 170)
+	if len(pages) != 2 {
+		t.Fatalf("Expected 2 pages, got %d: %q", len(pages), pages)
+	}
+	if pages[0] != paragraph+"\n"+paragraph {
+		t.Errorf("Expected first page to hold two paragraphs, got %q", pages[0])
+	}
+
+	long := splitTextIntoPages(strings.Repeat("ż", 100), 51)
+	for i, page := range long {
+		if !strings.HasPrefix(page, "ż") || !strings.HasSuffix(page, "ż") {
+			t.Errorf("Page %d was cut inside a multi-byte character: %q", i+1, page)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// defaultElectoralResultsLimit is the number of MPs listed when no limit is given
+	defaultElectoralResultsLimit = 20
+	// maxElectoralResultsLimit covers every MP of a term, including those who replaced others
+	maxElectoralResultsLimit = 600
+)
+
+// electoralResult is the result of an MP in the election to the Sejm, as kept in the MP's profile
+type electoralResult struct {
+	ID           int32  `json:"id"`
+	Name         string `json:"name"`
+	Club         string `json:"club,omitempty"`
+	DistrictNum  int32  `json:"districtNum,omitempty"`
+	DistrictName string `json:"districtName,omitempty"`
+	Voivodeship  string `json:"voivodeship,omitempty"`
+	Votes        int32  `json:"votes"`
+	DistrictRank int    `json:"districtRank"`
+	Active       bool   `json:"active"`
+}
+
+// electoralGroup sums up the results of the MPs elected in a district or voivodeship, or of a club
+type electoralGroup struct {
+	Key    string `json:"key"`
+	MPs    int    `json:"mps"`
+	Votes  int64  `json:"votes"`
+	Median int32  `json:"median"`
+	Fewest string `json:"fewest"`
+	Most   string `json:"most"`
+}
+
+// electoralResultsFromMPs returns the results of the MPs with a vote count, ranked by votes within their district
+func electoralResultsFromMPs(mps []sejm.MP) ([]electoralResult, int) {
+	var results []electoralResult
+	missing := 0
+	for _, mp := range mps {
+		if mp.NumberOfVotes == nil || mp.Id == nil {
+			missing++
+			continue
+		}
+		result := electoralResult{
+			ID:           *mp.Id,
+			Name:         valueOrDefault(stringValue(mp.FirstLastName), strings.TrimSpace(stringValue(mp.FirstName)+" "+stringValue(mp.LastName))),
+			Club:         stringValue(mp.Club),
+			DistrictName: stringValue(mp.DistrictName),
+			Voivodeship:  stringValue(mp.Voivodeship),
+			Votes:        *mp.NumberOfVotes,
+			Active:       mp.Active == nil || *mp.Active,
+		}
+		if mp.DistrictNum != nil {
+			result.DistrictNum = *mp.DistrictNum
+		}
+		results = append(results, result)
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Votes > results[j].Votes })
+	ranks := make(map[int32]int)
+	for i := range results {
+		ranks[results[i].DistrictNum]++
+		results[i].DistrictRank = ranks[results[i].DistrictNum]
+	}
+	return results, missing
+}
+
+// matchesDistrict tells whether an MP was elected in a district given by its number or a part of its name
+func (r electoralResult) matchesDistrict(district string) bool {
+	if n, err := strconv.Atoi(district); err == nil {
+		return int(r.DistrictNum) == n
+	}
+	return strings.Contains(normalizePolish(r.DistrictName), normalizePolish(district))
+}
+
+// groupElectoralResults sums up results sorted by votes, most first, by district, voivodeship or club. Districts
+// keep their numbering; other groups are sorted by their total votes.
+func groupElectoralResults(results []electoralResult, groupBy string) []electoralGroup {
+	members := make(map[string][]electoralResult)
+	var keys []string
+	for _, result := range results {
+		key := valueOrDefault(result.Club, "no club")
+		switch groupBy {
+		case "district":
+			key = fmt.Sprintf("%d %s", result.DistrictNum, result.DistrictName)
+		case "voivodeship":
+			key = valueOrDefault(result.Voivodeship, "unknown")
+		}
+		if _, ok := members[key]; !ok {
+			keys = append(keys, key)
+		}
+		members[key] = append(members[key], result)
+	}
+
+	groups := make([]electoralGroup, 0, len(keys))
+	for _, key := range keys {
+		// Members keep the order of the results, so the first has the most votes
+		group := members[key]
+		summary := electoralGroup{
+			Key:    key,
+			MPs:    len(group),
+			Median: group[len(group)/2].Votes,
+			Most:   fmt.Sprintf("%s (%d)", group[0].Name, group[0].Votes),
+			Fewest: fmt.Sprintf("%s (%d)", group[len(group)-1].Name, group[len(group)-1].Votes),
+		}
+		if len(group)%2 == 0 {
+			summary.Median = (group[len(group)/2-1].Votes + group[len(group)/2].Votes) / 2
+		}
+		for _, result := range group {
+			summary.Votes += int64(result.Votes)
+		}
+		groups = append(groups, summary)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groupBy == "district" {
+			return members[groups[i].Key][0].DistrictNum < members[groups[j].Key][0].DistrictNum
+		}
+		return groups[i].Votes > groups[j].Votes
+	})
+	return groups
+}
+
+func (s *SejmServer) handleGetElectoralResults(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_electoral_results called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	order := strings.ToLower(request.GetString("sort", "most"))
+	if order != "most" && order != "fewest" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid sort '%s'. Use 'most' or 'fewest'.", order)), nil
+	}
+	groupBy := strings.ToLower(request.GetString("group_by", "mp"))
+	if groupBy != "mp" && groupBy != "district" && groupBy != "voivodeship" && groupBy != "club" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid group_by '%s'. Use 'mp', 'district', 'voivodeship' or 'club'.", groupBy)), nil
+	}
+	limit, err := parseBoundedInt(request, "limit", defaultElectoralResultsLimit, 1, maxElectoralResultsLimit)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid limit: %v.", err)), nil
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+	club := strings.TrimSpace(request.GetString("club", ""))
+	district := strings.TrimSpace(request.GetString("district", ""))
+	voivodeship := strings.TrimSpace(request.GetString("voivodeship", ""))
+	activeOnly := request.GetString("active_only", "false") == "true"
+
+	body, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/MP", s.sejmBaseURL, term), nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve MPs from Polish Parliament API: %v. Please try again.", err)), nil
+	}
+	var mps []sejm.MP
+	if err := s.decodeAPIResponse(body, &mps); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse MPs data: %v.", err)), nil
+	}
+	all, missing := electoralResultsFromMPs(mps)
+	if len(all) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("The Sejm API has no vote counts for the MPs of term %d. Try a newer term.", term)), nil
+	}
+
+	var results []electoralResult
+	for _, result := range all {
+		if club != "" && !strings.EqualFold(result.Club, club) {
+			continue
+		}
+		if district != "" && !result.matchesDistrict(district) {
+			continue
+		}
+		if voivodeship != "" && !strings.Contains(normalizePolish(result.Voivodeship), normalizePolish(voivodeship)) {
+			continue
+		}
+		if activeOnly && !result.Active {
+			continue
+		}
+		results = append(results, result)
+	}
+	// Results are still sorted by votes, most first, as the groups expect
+	var groups []electoralGroup
+	if groupBy != "mp" {
+		groups = groupElectoralResults(results, groupBy)
+	}
+	if order == "fewest" {
+		sort.SliceStable(results, func(i, j int) bool { return results[i].Votes < results[j].Votes })
+	}
+
+	var filters []string
+	for _, filter := range []struct{ name, value string }{{"club", club}, {"district", district}, {"voivodeship", voivodeship}} {
+		if filter.value != "" {
+			filters = append(filters, fmt.Sprintf("%s '%s'", filter.name, filter.value))
+		}
+	}
+	if activeOnly {
+		filters = append(filters, "active mandates")
+	}
+	listed := results
+	if len(listed) > limit {
+		listed = listed[:limit]
+	}
+
+	if format == "json" {
+		output := map[string]interface{}{
+			"term":         term,
+			"filters":      filters,
+			"matched":      len(results),
+			"withoutVotes": missing,
+		}
+		if groupBy == "mp" {
+			output["sort"] = order
+			output["mps"] = listed
+		} else {
+			output["groupBy"] = groupBy
+			output["groups"] = groups
+		}
+		out, _ := json.MarshalIndent(output, "", "  ")
+		return mcp.NewToolResultText(string(out)), nil
+	}
+
+	summary := []string{fmt.Sprintf("Term %d: %d MPs with vote counts", term, len(all))}
+	if len(filters) > 0 {
+		summary = append(summary, fmt.Sprintf("Matching %s: %d", strings.Join(filters, ", "), len(results)))
+	}
+	if missing > 0 {
+		summary = append(summary, fmt.Sprintf("Without a vote count: %d", missing))
+	}
+
+	var data []string
+	if groupBy != "mp" {
+		for _, group := range groups {
+			data = append(data, fmt.Sprintf("• %s: %d MPs, %d votes, median %d; most %s, fewest %s",
+				group.Key, group.MPs, group.Votes, group.Median, group.Most, group.Fewest))
+		}
+	} else {
+		for i, result := range listed {
+			line := fmt.Sprintf("%d. %s (%s) – %d votes, district %d %s, %s; #%d in the district",
+				i+1, result.Name, valueOrDefault(result.Club, "no club"), result.Votes, result.DistrictNum, result.DistrictName, result.Voivodeship, result.DistrictRank)
+			if !result.Active {
+				line += "; mandate expired"
+			}
+			data = append(data, line)
+		}
+	}
+
+	status := "Retrieved Successfully"
+	if len(results) == 0 {
+		status = "No Results Found"
+		data = append(data, "No MPs match the filters. Districts are given by number (1-41) or name, e.g. district='Warszawa'.")
+	}
+	var nextActions []string
+	if groupBy == "mp" && len(listed) > 0 {
+		nextActions = append(nextActions, fmt.Sprintf("Profile of the first MP: sejm_get_mp_details with term='%d', mp_id='%d'", term, listed[0].ID))
+	}
+	if groupBy != "district" {
+		nextActions = append(nextActions, fmt.Sprintf("Totals per district: sejm_get_electoral_results with term='%d', group_by='district'", term))
+	}
+	if order == "most" {
+		nextActions = append(nextActions, fmt.Sprintf("MPs elected with the fewest votes: sejm_get_electoral_results with term='%d', sort='fewest'", term))
+	}
+	nextActions = append(nextActions, fmt.Sprintf("Every profile field as a dataset: sejm_export_mps with term='%d'", term))
+
+	response := StandardResponse{
+		Operation:   "Electoral Results",
+		Status:      status,
+		Summary:     summary,
+		Data:        data,
+		NextActions: nextActions,
+		Note:        "Vote counts are the personal votes of each MP in the Sejm election, as kept in the MP profiles; MPs who took over an expired mandate carry the votes they got in the election too. The district rank compares MPs elected in the same district. The Sejm API publishes neither the results of candidates who were not elected nor referendum results; those are published by the National Electoral Commission (wybory.gov.pl).",
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
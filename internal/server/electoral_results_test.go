@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const electoralMPsFixture = `[
+	{"id": 1, "firstLastName": "Anna Nowak", "club": "KO", "districtNum": 19, "districtName": "Warszawa", "voivodeship": "mazowieckie", "numberOfVotes": 120000, "active": true},
+	{"id": 2, "firstLastName": "Jan Kowalski", "club": "PiS", "districtNum": 19, "districtName": "Warszawa", "voivodeship": "mazowieckie", "numberOfVotes": 4500, "active": true},
+	{"id": 3, "firstLastName": "Ewa Wiśniewska", "club": "PiS", "districtNum": 1, "districtName": "Legnica", "voivodeship": "dolnośląskie", "numberOfVotes": 8000, "active": false},
+	{"id": 4, "firstLastName": "Piotr Zieliński", "club": "KO", "districtNum": 1, "districtName": "Legnica", "voivodeship": "dolnośląskie", "numberOfVotes": 30000, "active": true},
+	{"id": 5, "firstLastName": "Adam Brak", "club": "KO", "districtNum": 1, "districtName": "Legnica", "voivodeship": "dolnośląskie", "active": true}
+]`
+
+func TestHandleGetElectoralResults(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{"/sejm/term10/MP": electoralMPsFixture})
+	call := func(arguments map[string]interface{}) string {
+		result, err := server.handleGetElectoralResults(context.Background(), createMockRequest(arguments))
+		if err != nil || result.IsError {
+			t.Fatalf("Unexpected error for %v: %v %s", arguments, err, extractTextContent(result))
+		}
+		return extractTextContent(result)
+	}
+
+	output := call(map[string]interface{}{"term": "10", "sort": "fewest"})
+	for _, expected := range []string{
+		"Term 10: 4 MPs with vote counts",
+		"Without a vote count: 1",
+		"1. Jan Kowalski (PiS) – 4500 votes, district 19 Warszawa, mazowieckie; #2 in the district",
+		"2. Ewa Wiśniewska (PiS) – 8000 votes, district 1 Legnica, dolnośląskie; #2 in the district; mandate expired",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output, got: %s", expected, output)
+		}
+	}
+
+	output = call(map[string]interface{}{"term": "10", "district": "legnica", "active_only": "true"})
+	if !strings.Contains(output, "1. Piotr Zieliński") || strings.Contains(output, "Wiśniewska") || strings.Contains(output, "Nowak") {
+		t.Errorf("Expected only the active MPs of Legnica, got: %s", output)
+	}
+
+	output = call(map[string]interface{}{"term": "10", "group_by": "district", "format": "json"})
+	var grouped struct {
+		Groups []electoralGroup `json:"groups"`
+	}
+	if err := json.Unmarshal([]byte(output), &grouped); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(grouped.Groups) != 2 || grouped.Groups[0].Key != "1 Legnica" || grouped.Groups[0].Votes != 38000 ||
+		grouped.Groups[0].Median != 19000 || grouped.Groups[0].Most != "Piotr Zieliński (30000)" || grouped.Groups[1].Fewest != "Jan Kowalski (4500)" {
+		t.Errorf("Unexpected district groups: %+v", grouped.Groups)
+	}
+
+	result, err := server.handleGetElectoralResults(context.Background(), createMockRequest(map[string]interface{}{"group_by": "party"}))
+	if err != nil || !result.IsError {
+		t.Errorf("Expected an invalid group_by to be rejected, got: %s", extractTextContent(result))
+	}
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+)
+
+// Keyword matching modes of eli_search_acts
+const (
+	keywordModeAll = "all"
+	keywordModeAny = "any"
+)
+
+// maxKeywordsAny caps the number of separate searches run for keyword_mode='any'
+const maxKeywordsAny = 5
+
+// normalizeLegalStatus matches a status filter against the ELI legal statuses, ignoring case and
+// accepting a unique prefix (e.g., 'uchylony' or 'obowiązujący')
+func normalizeLegalStatus(value string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	var matches []string
+	for _, status := range eliLegalStatuses {
+		if status == value {
+			return status, nil
+		}
+		if strings.HasPrefix(status, value) {
+			matches = append(matches, status)
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+	if len(matches) > 1 {
+		return "", fmt.Errorf("status '%s' is ambiguous: %s", value, strings.Join(matches, "; "))
+	}
+	return "", fmt.Errorf("unknown status '%s'. Valid statuses: %s", value, strings.Join(eliLegalStatuses, "; "))
+}
+
+// splitKeywords splits a comma-separated keyword list, dropping empty entries
+func splitKeywords(value string) []string {
+	var keywords []string
+	for _, keyword := range strings.Split(value, ",") {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	return keywords
+}
+
+// filterActsByStatus keeps the acts with the given legal status
+func filterActsByStatus(acts []eli.Act, status string) []eli.Act {
+	var filtered []eli.Act
+	for _, act := range acts {
+		if act.Status != nil && strings.EqualFold(*act.Status, status) {
+			filtered = append(filtered, act)
+		}
+	}
+	return filtered
+}
+
+// mergeActs joins act lists, keeping the first occurrence of each act
+func mergeActs(lists ...[]eli.Act) []eli.Act {
+	var merged []eli.Act
+	seen := make(map[string]bool)
+	for _, acts := range lists {
+		for _, act := range acts {
+			address := actAddress(act)
+			if seen[address] {
+				continue
+			}
+			seen[address] = true
+			merged = append(merged, act)
+		}
+	}
+	return merged
+}
+
+// valueOrDefault returns value, or fallback when it is empty
+func valueOrDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+)
+
+func TestNormalizeLegalStatus(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  string
+	}{
+		{input: "obowiązujący", expected: "obowiązujący"},
+		{input: " Uchylony ", expected: "uchylony"},
+		{input: "akt posiada", expected: "akt posiada tekst jednolity"},
+		{input: "nieobowiązujący", wantErr: "ambiguous"},
+		{input: "akt", wantErr: "ambiguous"},
+		{input: "w mocy", wantErr: "unknown status"},
+	}
+	for _, tt := range tests {
+		got, err := normalizeLegalStatus(tt.input)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("normalizeLegalStatus(%q): expected error containing %q, got %v", tt.input, tt.wantErr, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.expected {
+			t.Errorf("normalizeLegalStatus(%q) = %q, %v; expected %q", tt.input, got, err, tt.expected)
+		}
+	}
+}
+
+func TestSplitKeywords(t *testing.T) {
+	got := splitKeywords(" ochrona danych, ,prawo pracy,")
+	if strings.Join(got, "|") != "ochrona danych|prawo pracy" {
+		t.Errorf("Unexpected keywords: %q", got)
+	}
+	if splitKeywords("") != nil {
+		t.Error("Expected no keywords for empty input")
+	}
+}
+
+func TestMergeAndFilterActs(t *testing.T) {
+	publisher := "DU"
+	inForce, repealed := "obowiązujący", "uchylony"
+	act := func(year, pos int32, status *string) eli.Act {
+		return eli.Act{Publisher: &publisher, Year: &year, Pos: &pos, Status: status}
+	}
+	merged := mergeActs(
+		[]eli.Act{act(2020, 1, &inForce), act(2021, 2, &repealed)},
+		[]eli.Act{act(2021, 2, &repealed), act(2022, 3, nil)},
+	)
+	if len(merged) != 3 {
+		t.Fatalf("Expected 3 distinct acts, got %d", len(merged))
+	}
+	filtered := filterActsByStatus(merged, "uchylony")
+	if len(filtered) != 1 || actAddress(filtered[0]) != "DU/2021/2" {
+		t.Errorf("Expected only DU/2021/2 to be repealed, got %v", filtered)
+	}
+}
+
+func TestHandleSearchActsAdvancedFilters(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/search": `{"count": 2, "items": [
+			{"publisher": "DU", "year": 2023, "pos": 10, "title": "Rozporządzenie Ministra Zdrowia w sprawie szczepień", "status": "obowiązujący", "type": "Rozporządzenie"},
+			{"publisher": "DU", "year": 2019, "pos": 20, "title": "Rozporządzenie Ministra Zdrowia w sprawie recept", "status": "uchylony", "type": "Rozporządzenie"}
+		]}`,
+	})
+
+	result, err := server.handleSearchActs(context.Background(), createMockRequest(map[string]interface{}{
+		"keyword": "zdrowie, szczepienia", "keyword_mode": "any", "institution": "MIN. ZDROWIA",
+		"effective_from": "2019-01-01", "status": "obow",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Keywords (any): zdrowie, szczepienia",
+		"Issuing institution: MIN. ZDROWIA",
+		"Entered into force: 2019-01-01 to now",
+		"Legal status: obowiązujący",
+		"status filter kept 1 of 2 fetched acts",
+		"DU/2023/10",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+	if strings.Contains(content, "DU/2019/20") {
+		t.Errorf("Repealed act should be filtered out, got: %s", content)
+	}
+
+	result, _ = server.handleSearchActs(context.Background(), createMockRequest(map[string]interface{}{
+		"keyword": "zdrowie", "keyword_mode": "either",
+	}))
+	if !result.IsError {
+		t.Error("Expected an error for an unknown keyword_mode")
+	}
+}
@@ -14,9 +14,11 @@ import (
 	"github.com/gen2brain/go-fitz"
 	"github.com/janisz/sejm-mcp/pkg/eli"
 	"github.com/mark3labs/mcp-go/mcp"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-const eliBaseURL = "https://api.sejm.gov.pl/eli"
+// defaultELIBaseURL is the public ELI API used when no other base URL is configured
+const defaultELIBaseURL = "https://api.sejm.gov.pl/eli"
 
 var eliLegalStatuses = []string{
 	"akt indywidualny", "akt jednorazowy", "akt objęty tekstem jednolitym",
@@ -44,6 +46,7 @@ type StandardResponse struct {
 	Operation   string
 	Status      string
 	Summary     []string
+	Unavailable []string // Upstream sources that failed; set from sourceCoverage.unavailable for partial results
 	Data        []string
 	NextActions []string
 	Note        string
@@ -64,6 +67,14 @@ func (sr StandardResponse) Format() string {
 		}
 	}
 
+	// Unavailable sources section, kept ahead of the results so truncation never hides it
+	if len(sr.Unavailable) > 0 {
+		result.WriteString("\n\nUnavailable Sources:")
+		for _, item := range sr.Unavailable {
+			result.WriteString(fmt.Sprintf("\n• %s", item))
+		}
+	}
+
 	// Data section
 	if len(sr.Data) > 0 {
 		result.WriteString("\n\nResults:")
@@ -200,7 +211,7 @@ func buildPaginationHints(offset, limit string, totalCount int) []string {
 }
 
 func (s *SejmServer) registerELITools() {
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "eli_search_acts",
 		Description: "Search Poland's comprehensive legal acts database using European Legislation Identifier (ELI) standards. This powerful tool searches through all published Polish legal documents following the strict Polish legal hierarchy: Konstytucja (supreme law) → Ratified International Treaties → Ustawa (parliamentary acts including ordinary laws, organic laws, and comprehensive codes) → Rozporządzenie (executive regulations by ministries/government) → Local law (municipal/regional). Publisher codes reflect this hierarchy: DU (Dziennik Ustaw) for primary legislation and constitutional acts, MP (Monitor Polski) for secondary legislation and administrative acts. Legal acts progress through defined lifecycle stages from draft (projekt) through legislative process to publication, in-force status, potential amendments, and eventual repeal. Essential for legal research, citation verification, regulatory compliance analysis, understanding legal hierarchies, and building comprehensive legal knowledge bases.",
 		InputSchema: mcp.ToolInputSchema{
@@ -232,7 +243,11 @@ func (s *SejmServer) registerELITools() {
 				},
 				"sort_by": map[string]interface{}{
 					"type":        "string",
-					"description": "Sort results by field: 'date' (publication date), 'title' (alphabetical), 'year' (publication year), 'publisher' (publisher code). Default is relevance-based ordering. Combine with sort_dir to control order.",
+					"description": "Sort results by field: 'date' (publication date), 'title' (alphabetical), 'year' (publication year), 'publisher' (publisher code), or 'relevance' to re-rank the fetched results on the server (exact title matches, acts in force and original statutes first; amendments last). Default is the API's ordering. Combine with sort_dir to control order of API fields.",
+				},
+				"group_by_act": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to group results by underlying act: consolidated texts (tekst jednolity announcements) and amendments are folded under the act they concern and groups are ranked by relevance, so 'kodeks pracy' returns the Labour Code itself first. Grouping applies to the fetched page, so use a larger limit (e.g. '100') for best results.",
 				},
 				"sort_dir": map[string]interface{}{
 					"type":        "string",
@@ -254,11 +269,254 @@ func (s *SejmServer) registerELITools() {
 					"type":        "string",
 					"description": "Search for specific legal keywords/concepts in act content, separated by commas. Different from title search - searches deeper content and official legal keywords. Examples: 'ochrona przyrody' (nature protection), 'kodeks wyborczy' (electoral code), 'administracja samorządowa' (local government administration), 'prawo pracy' (labor law), 'podatek dochodowy' (income tax), 'ochrona danych' (data protection), 'bezpieczeństwo publiczne' (public safety). To discover all available keywords, use eli_get_keywords tool. Keywords are official legal concept tags assigned to acts.",
 				},
+				"keyword_mode": map[string]interface{}{
+					"type":        "string",
+					"description": "How multiple keywords are combined: 'all' (default, acts tagged with every keyword) or 'any' (acts tagged with at least one; runs one search per keyword, up to 5, and merges the results).",
+				},
+				"institution": map[string]interface{}{
+					"type":        "string",
+					"description": "Issuing institution (organ wydający) as named in ELI, e.g., 'MIN. ZDROWIA', 'RADA MINISTRÓW', 'SEJM'. Returns only acts released by this institution. Case and Polish diacritics are ignored; see eli_get_institutions for the full list.",
+				},
+				"effective_from": map[string]interface{}{
+					"type":        "string",
+					"description": "Start of the entry-into-force date range in YYYY-MM-DD format. Unlike date_from (announcement date), this filters by the date the act took effect.",
+				},
+				"effective_to": map[string]interface{}{
+					"type":        "string",
+					"description": "End of the entry-into-force date range in YYYY-MM-DD format.",
+				},
+				"status": map[string]interface{}{
+					"type":        "string",
+					"description": "Legal status, e.g., 'obowiązujący', 'uchylony', 'akt posiada tekst jednolity' (case-insensitive; a unique prefix is enough). See eli_get_statuses for the full list. Applied to the fetched page, so combine with a larger limit.",
+				},
 			},
 		},
 	}, s.handleSearchActs)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
+		Name:        "eli_get_search_facets",
+		Description: "Count the legal acts matching a search by year, type, publisher and legal status, without listing the acts. Use it before eli_search_acts to see how results are distributed and to pick a filter to drill down, instead of fetching long lists. Accepts the filters of eli_search_acts.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"title": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Words in act titles, e.g. 'ochrona danych'.",
+				},
+				"publisher": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Publisher code, e.g. 'DU' (Dziennik Ustaw) or 'MP' (Monitor Polski).",
+				},
+				"year": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Publication year, e.g. '2020'.",
+				},
+				"type": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Document type, e.g. 'ustawa' or 'rozporządzenie'. See eli_get_types.",
+				},
+				"keyword": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Comma-separated keywords the acts must be tagged with. See eli_get_keywords.",
+				},
+				"in_force": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. '1' for acts in force, '0' for acts not in force.",
+				},
+				"institution": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Issuing institution as named in ELI, e.g. 'MIN. ZDROWIA'. See eli_get_institutions.",
+				},
+				"date_from": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. First announcement date in YYYY-MM-DD format.",
+				},
+				"date_to": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Last announcement date in YYYY-MM-DD format.",
+				},
+				"facets": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Comma-separated facets to count: 'year', 'type', 'publisher', 'status' (default: all).",
+				},
+				"max_scan": map[string]interface{}{
+					"type":        "string",
+					"description": "Maximum number of matching acts to count (default: 1000, maximum: 5000). With more matches, the counts cover the first acts in the API order and show proportions only.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json' with every value of each facet.",
+				},
+			},
+		},
+	}, s.handleGetSearchFacets)
+
+	s.addTool(mcp.Tool{
+		Name:        "eli_get_legal_state",
+		Description: "Point-in-time view of Polish law on a topic: returns the acts matching a subject keyword or title words that were in force on a given date, using the entry-into-force, repeal and expiration dates from act metadata. Acts announced by that date are grouped into in force, not yet in force (vacatio legis), repealed or expired, and acts without dates. Unlike in_force='1' in eli_search_acts, which reflects today's status, this answers questions like 'which data protection laws applied in 2015?'. Each candidate act costs one metadata request, so narrow the topic with publisher or type for broad subjects.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"keyword": map[string]interface{}{
+					"type":        "string",
+					"description": "Subject keywords assigned to acts, separated by commas (acts must carry all of them), e.g., 'ochrona danych osobowych'. Use eli_get_keywords to discover keywords. Required unless title is given.",
+				},
+				"title": map[string]interface{}{
+					"type":        "string",
+					"description": "Words in act titles, e.g., 'prawo energetyczne'. Required unless keyword is given.",
+				},
+				"as_of": map[string]interface{}{
+					"type":        "string",
+					"description": "Date of the legal state in YYYY-MM-DD format (e.g., '2015-06-30').",
+				},
+				"publisher": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Publisher code, e.g., 'DU' (Dziennik Ustaw) or 'MP' (Monitor Polski).",
+				},
+				"type": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Document type, e.g., 'Ustawa' or 'Rozporządzenie'. See eli_get_types.",
+				},
+				"max_acts": map[string]interface{}{
+					"type":        "string",
+					"description": "Maximum number of matching acts to check (default: 40, maximum: 100).",
+				},
+			},
+			Required: []string{"as_of"},
+		},
+	}, s.handleGetLegalState)
+
+	s.addTool(mcp.Tool{
+		Name:        "eli_get_upcoming_entries",
+		Description: "Entry-into-force calendar: lists acts that enter into force within the coming days (e.g., the next 30, 60 or 90), grouped by publisher and document type and ordered by date. Uses the entry-into-force date from act metadata rather than the announcement date, so compliance teams can prepare for laws taking effect during vacatio legis.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"days": map[string]interface{}{
+					"type":        "string",
+					"description": "Length of the window in days (default: 30, maximum: 365), e.g., '30', '60' or '90'.",
+				},
+				"from": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. First day of the window in YYYY-MM-DD format. Default: today.",
+				},
+				"publisher": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Publisher code, e.g., 'DU' (Dziennik Ustaw) or 'MP' (Monitor Polski).",
+				},
+				"type": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Document type, e.g., 'Ustawa' or 'Rozporządzenie'. See eli_get_types.",
+				},
+				"limit": map[string]interface{}{
+					"type":        "string",
+					"description": "Maximum number of acts to fetch for the window (default: 200, maximum: 500).",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json' with the groups as structured data.",
+				},
+			},
+		},
+	}, s.handleGetUpcomingEntries)
+
+	s.addTool(mcp.Tool{
+		Name:        "eli_sample_acts",
+		Description: "Draw a uniform random sample of legal acts matching filters (publisher, type, year range, legal status, in force, keywords), for building datasets and benchmarks. Counts the matching acts and fetches only the drawn ones, instead of paging through tens of thousands of list entries. A seed makes the sample reproducible.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"count": map[string]interface{}{
+					"type":        "string",
+					"description": "Number of acts to draw (default: 10, maximum: 100).",
+				},
+				"publisher": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Publisher code, e.g. 'DU' (Dziennik Ustaw) or 'MP' (Monitor Polski).",
+				},
+				"type": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Document type, e.g. 'ustawa' or 'rozporządzenie'. See eli_get_types.",
+				},
+				"year_from": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. First year of announcement (e.g., '2000').",
+				},
+				"year_to": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Last year of announcement (e.g., '2010').",
+				},
+				"status": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Legal status, e.g. 'obowiązujący' or 'uchylony'. See eli_get_statuses. The API cannot filter by status, so rejected acts are replaced by further draws.",
+				},
+				"in_force": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. '1' for acts in force, '0' for acts not in force.",
+				},
+				"keyword": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Comma-separated keywords the acts must be tagged with. See eli_get_keywords.",
+				},
+				"seed": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Integer seed of the random draw. The same seed and filters give the same acts while the database is unchanged. Without it, a seed is chosen and reported.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json' with the full metadata of each act.",
+				},
+			},
+		},
+	}, s.handleSampleActs)
+
+	s.addTool(mcp.Tool{
+		Name:        "eli_random_act",
+		Description: "Draw one random legal act matching filters (publisher, type, year range, legal status, in force, keywords). The same as eli_sample_acts with count=1; a seed makes the draw reproducible.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"publisher": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Publisher code, e.g. 'DU' (Dziennik Ustaw) or 'MP' (Monitor Polski).",
+				},
+				"type": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Document type, e.g. 'ustawa' or 'rozporządzenie'. See eli_get_types.",
+				},
+				"year_from": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. First year of announcement (e.g., '2000').",
+				},
+				"year_to": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Last year of announcement (e.g., '2010').",
+				},
+				"status": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Legal status, e.g. 'obowiązujący' or 'uchylony'. See eli_get_statuses. The API cannot filter by status, so rejected acts are replaced by further draws.",
+				},
+				"in_force": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. '1' for acts in force, '0' for acts not in force.",
+				},
+				"keyword": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Comma-separated keywords the acts must be tagged with. See eli_get_keywords.",
+				},
+				"seed": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Integer seed of the random draw. The same seed and filters give the same acts while the database is unchanged. Without it, a seed is chosen and reported.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json' with the full metadata of each act.",
+				},
+			},
+		},
+	}, s.handleRandomAct)
+
+	s.addTool(mcp.Tool{
 		Name:        "eli_get_act_details",
 		Description: "Retrieve comprehensive metadata and legal information about a specific Polish legal act using its official publication identifiers. Returns detailed legal document profile including official title, ELI identifier, publication and effective dates, current legal status following the Polish legal lifecycle (w przygotowaniu → w trakcie procedury legislacyjnej → opublikowana → w mocy → zmieniona/uchylona), document type classification within the Polish legal hierarchy, issuing institution, legal keywords, amendment history, available text formats, and related document counts. Legal status determines binding effect: only acts 'w mocy' (in force) are legally binding, while 'uchylona' (repealed) acts have historical value only. Essential for legal citation verification, regulatory compliance checking, legal research validation, understanding document authority within Polish legal system, and building authoritative legal databases.",
 		InputSchema: mcp.ToolInputSchema{
@@ -285,9 +543,9 @@ func (s *SejmServer) registerELITools() {
 		},
 	}, s.handleGetActDetails)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "eli_get_act_text",
-		Description: "Download the complete official text of a Polish legal act in PDF or plain text format. PDF format delivers the official publication-quality document suitable for citations and archival. TEXT format extracts plain text from PDF, providing clean text perfect for AI processing. HTML format is rarely available in the Polish ELI system - most documents are only published in PDF format. The text includes the full legal content as published, with proper legal structure, amendment annotations, and official formatting. Critical for legal analysis, AI-powered legal research, compliance checking, academic studies, and legal document processing.",
+		Description: "Download the complete official text of a Polish legal act in PDF or plain text format. PDF format delivers the official publication-quality document suitable for citations and archival. TEXT format extracts plain text from PDF, providing clean text perfect for AI processing. HTML format is rarely available in the Polish ELI system - most documents are only published in PDF format. The text includes the full legal content as published, with proper legal structure, amendment annotations, and official formatting. Critical for legal analysis, AI-powered legal research, compliance checking, academic studies, and legal document processing. When the act has been republished as a consolidated text (tekst jednolity), the newest consolidated text is returned by default, with a note naming it; set consolidated='false' for the original.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -319,14 +577,104 @@ func (s *SejmServer) registerELITools() {
 					"type":        "string",
 					"description": "Optional. Set to 'true' to show page count and navigation info without retrieving full text (for text/html formats). Useful for understanding document structure before reading specific pages.",
 				},
+				"consolidated": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. 'true' (default) returns the newest consolidated text (tekst jednolity) of an act that has one, with a note saying which version was returned, because the original text misses later amendments. Set to 'false' for the act exactly as originally published.",
+				},
+				"summarize": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Set to 'true' to return an extractive summary instead of the text: the most representative sentences quoted verbatim, each with a pointer to the page it comes from. Computed on the server from word frequencies, without an LLM. Useful for very long documents.",
+				},
+				"summary_sentences": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Number of sentences in the summary when summarize='true' (default: 15, maximum: 50).",
+				},
+				"columns": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional, format='text' only. For bilingual acts such as international agreements printed with parallel Polish and foreign-language columns: 'all' (default) keeps the text as extracted, 'polish' keeps only the Polish text, 'foreign' only the foreign-language text, 'split' returns both separately for each page. Columns are detected from the page layout and the language is guessed from Polish diacritics and common words, so check the result on unusual layouts.",
+				},
 			},
 			Required: []string{"publisher", "year", "position"},
 		},
 	}, s.handleGetActText)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
+		Name:        "eli_list_act_texts",
+		Description: "List every text file attached to a legal act in the ELI database: the text as published, unified texts and separate files such as annexes (załączniki), with their file names and types. eli_get_act_text returns only the main text; read the other files with eli_get_act_file.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"publisher": map[string]interface{}{
+					"type":        "string",
+					"description": "Publisher code: 'DU' (Dziennik Ustaw) or 'MP' (Monitor Polski).",
+				},
+				"year": map[string]interface{}{
+					"type":        "string",
+					"description": "Year of publication (e.g., '2023').",
+				},
+				"position": map[string]interface{}{
+					"type":        "string",
+					"description": "Position number in the journal (e.g., '1465').",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json'.",
+				},
+			},
+			Required: []string{"publisher", "year", "position"},
+		},
+	}, s.handleListActTexts)
+
+	s.addTool(mcp.Tool{
+		Name:        "eli_get_act_file",
+		Description: "Download one text file of a legal act by its file name, as listed by eli_list_act_texts (e.g. an annex or a unified text), and extract its text with pagination. Can also return the file itself.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"publisher": map[string]interface{}{
+					"type":        "string",
+					"description": "Publisher code: 'DU' (Dziennik Ustaw) or 'MP' (Monitor Polski).",
+				},
+				"year": map[string]interface{}{
+					"type":        "string",
+					"description": "Year of publication (e.g., '2023').",
+				},
+				"position": map[string]interface{}{
+					"type":        "string",
+					"description": "Position number in the journal (e.g., '1465').",
+				},
+				"file_name": map[string]interface{}{
+					"type":        "string",
+					"description": "File name from eli_list_act_texts, e.g. 'D20231465.pdf'.",
+				},
+				"page": map[string]interface{}{
+					"type":        "string",
+					"description": "Starting page number (default: 1).",
+				},
+				"pages_per_chunk": map[string]interface{}{
+					"type":        "string",
+					"description": "Number of pages to return at once (default: 5, max: 20).",
+				},
+				"show_page_info": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to return only page count and navigation information instead of text.",
+				},
+				"return_content": map[string]interface{}{
+					"type":        "string",
+					"description": "How to return the file itself: 'none' (default, text only), 'blob' (embed the file as base64 content with its MIME type) or 'resource' (register the file as an MCP resource and return a link the client can read with resources/read).",
+				},
+				"max_size_bytes": map[string]interface{}{
+					"type":        "string",
+					"description": "Maximum file size returned with return_content (default: 5242880 bytes = 5 MB, max: 20971520 = 20 MB).",
+				},
+			},
+			Required: []string{"publisher", "year", "position", "file_name"},
+		},
+	}, s.handleGetActFile)
+
+	s.addTool(mcp.Tool{
 		Name:        "eli_get_act_references",
-		Description: "Explore the complex legal relationship network between Polish legal acts through citations, amendments, repeals, and references. Returns comprehensive mapping following EU ELI standards with specific relationship types: eli:amends (substantial legal changes), eli:repeals (cancellation/replacement), eli:corrects (technical corrections), eli:consolidates (editorial compilation), eli:transposes (EU directive implementation), eli:ensuresImplementationOf (EU regulation compliance), and podstawa_prawna (legal authorization for secondary legislation). The system maintains bidirectional references with automatic updates when new acts are published. Constitutional amendments create amendment chains, while EU directives show implementation patterns through national law. \n\n**PAGINATION SUPPORT**: Major laws like the Constitution have 3,519+ implementing regulations. Use pagination parameters to manage large datasets: limit (max 100 per category), offset (skip entries), and category filtering for focused analysis. Examples: limit='20' offset='0' for first 20 results, category='Akty wykonawcze' for implementing regulations only, offset='100' limit='50' for results 101-150. Essential for legal dependency analysis, understanding legislative genealogy, tracking constitutional development, analyzing EU law integration, regulatory impact assessment, and building comprehensive legal knowledge graphs that reflect Poland's complex legal architecture.",
+		Description: "Explore the complex legal relationship network between Polish legal acts through citations, amendments, repeals, and references. Returns comprehensive mapping following EU ELI standards with specific relationship types: eli:amends (substantial legal changes), eli:repeals (cancellation/replacement), eli:corrects (technical corrections), eli:consolidates (editorial compilation), eli:transposes (EU directive implementation), eli:ensuresImplementationOf (EU regulation compliance), and podstawa_prawna (legal authorization for secondary legislation). The system maintains bidirectional references with automatic updates when new acts are published. Constitutional amendments create amendment chains, while EU directives show implementation patterns through national law. \n\n**PAGINATION SUPPORT**: Major laws like the Constitution have 3,519+ implementing regulations. Use pagination parameters to manage large datasets: limit (max 100 per category), offset (skip entries), and category filtering for focused analysis. Examples: limit='20' offset='0' for first 20 results, category='Akty wykonawcze' for implementing regulations only, offset='100' limit='50' for results 101-150. Use direction='incoming' or 'outgoing' to keep only references to or from this act. Essential for legal dependency analysis, understanding legislative genealogy, tracking constitutional development, analyzing EU law integration, regulatory impact assessment, and building comprehensive legal knowledge graphs that reflect Poland's complex legal architecture.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -352,14 +700,173 @@ func (s *SejmServer) registerELITools() {
 				},
 				"category": map[string]interface{}{
 					"type":        "string",
-					"description": "Filter to specific reference category to focus analysis. Available categories: 'Akty wykonawcze' (implementing regulations), 'Akty zmieniające' (acts that amend this law), 'Akty uchylające' (acts that repeal this law), 'Akty uchylone' (acts repealed by this law), 'Akty zmieniane' (acts amended by this law), 'Akty podstawowe' (foundational acts this law is based on), 'Podstawa prawna' (legal authorization), 'Sprostowanie' (corrections), 'Akty uznane za uchylone' (acts deemed repealed). Leave empty to show all categories with pagination applied to each.",
+					"description": "Filter to specific reference categories to focus analysis; separate several with commas, case and Polish diacritics are ignored. Available categories: 'Akty wykonawcze' (implementing regulations), 'Akty zmieniające' (acts that amend this law), 'Akty uchylające' (acts that repeal this law), 'Akty uchylone' (acts repealed by this law), 'Akty zmieniane' (acts amended by this law), 'Akty podstawowe' (foundational acts this law is based on), 'Podstawa prawna' (legal authorization), 'Sprostowanie' (corrections), 'Akty uznane za uchylone' (acts deemed repealed). Leave empty to show all categories with pagination applied to each.",
+				},
+				"direction": map[string]interface{}{
+					"type":        "string",
+					"description": "Filter by direction: 'incoming' for acts referring to this act (amending, repealing and implementing acts, rulings), 'outgoing' for acts this act refers to (acts it amends or repeals, its legal basis), or 'all' (default).",
 				},
 			},
 			Required: []string{"publisher", "year", "position"},
 		},
 	}, s.handleGetActReferences)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
+		Name:        "eli_format_citation",
+		Description: "Format a citation of a Polish legal act, optionally of a single provision (article, paragraph, point, letter), in three styles: official as used in legislation ('art. 415 ustawy z dnia 23 kwietnia 1964 r. – Kodeks cywilny (Dz. U. z 2024 r. poz. 1061 i 1237)'), academic as used in legal writing ('(t.j. Dz.U. z 2024 r. poz. 1061 ze zm.)') and short ('art. 415 k.c.'). Cites the newest consolidated text (tekst jednolity) with the amendments published after it, adds the issue number (Nr) for journals from before 2012 and declines the act kind after a provision.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"publisher": map[string]interface{}{
+					"type":        "string",
+					"description": "Publisher code: 'DU' (Dziennik Ustaw, default) or 'MP' (Monitor Polski).",
+				},
+				"year": map[string]interface{}{
+					"type":        "string",
+					"description": "Year of publication of the act as originally published (e.g., '1964').",
+				},
+				"position": map[string]interface{}{
+					"type":        "string",
+					"description": "Position number in the journal (e.g., '93').",
+				},
+				"article": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional article number (e.g., '415' or '5a'); for regulations, the paragraph (§) number.",
+				},
+				"paragraph": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional subdivision of the article: ustęp (ust.), or paragraph (§) in codes. Requires 'article'.",
+				},
+				"point": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional point (pkt). Requires 'article'.",
+				},
+				"letter": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional letter (lit.) of the point, e.g. 'a'. Requires 'point'.",
+				},
+				"style": map[string]interface{}{
+					"type":        "string",
+					"description": "Citation styles, comma-separated: 'official', 'academic', 'short' or 'all' (default).",
+				},
+				"consolidated": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'false' to cite the act as originally published instead of its newest consolidated text (default: 'true').",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json'.",
+				},
+			},
+			Required: []string{"year", "position"},
+		},
+	}, s.handleFormatCitation)
+
+	s.addTool(mcp.Tool{
+		Name:        "eli_get_eu_references",
+		Description: "Find the European Union law a Polish legal act implements or cites. Extracts EU directive, regulation and decision references from the act's ELI metadata (the list of implemented directives) and, optionally, from the act's text (CELEX numbers and citations such as 'rozporządzenie Parlamentu Europejskiego i Rady (UE) 2016/679' or 'dyrektywa 95/46/WE'). Returns each reference with its type, CELEX number and a direct EUR-Lex link. Essential for tracing how EU law (e.g. GDPR, consumer protection or environmental directives) is transposed into Polish legislation.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"publisher": map[string]interface{}{
+					"type":        "string",
+					"description": "Publisher code of the legal act (e.g., 'DU' for Dziennik Ustaw, 'MP' for Monitor Polski).",
+				},
+				"year": map[string]interface{}{
+					"type":        "string",
+					"description": "Publication year of the legal act (e.g., '2018').",
+				},
+				"position": map[string]interface{}{
+					"type":        "string",
+					"description": "Position number of the legal act within the publisher's year (e.g., '1000').",
+				},
+				"scan_text": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'false' to use only ELI metadata. Default 'true' also scans the act's text (HTML, or PDF when HTML is unavailable) for EU law citations.",
+				},
+			},
+			Required: []string{"publisher", "year", "position"},
+		},
+	}, s.handleGetEUReferences)
+
+	s.addTool(mcp.Tool{
+		Name:        "eli_get_act_keywords",
+		Description: "Get how a Polish legal act is tagged: its official keywords (hasła), named entities, the EU law it implements (with CELEX numbers and EUR-Lex links) and its classification (type, legal status, issuing, authorized and obligated bodies). Also counts the other acts sharing each keyword and all of them together, with examples, to navigate to related legislation. Use it to explore a legal domain starting from one known act.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"publisher": map[string]interface{}{
+					"type":        "string",
+					"description": "Publisher code of the legal act (e.g., 'DU' for Dziennik Ustaw, 'MP' for Monitor Polski).",
+				},
+				"year": map[string]interface{}{
+					"type":        "string",
+					"description": "Publication year of the legal act (e.g., '2018').",
+				},
+				"position": map[string]interface{}{
+					"type":        "string",
+					"description": "Position number of the legal act within the publisher's year (e.g., '1000').",
+				},
+				"siblings": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'false' to skip searching for other acts with the same keywords. Default 'true'.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json' for the structured tagging data.",
+				},
+			},
+			Required: []string{"publisher", "year", "position"},
+		},
+	}, s.handleGetActKeywords)
+
+	s.addTool(mcp.Tool{
+		Name:        "eli_get_tk_rulings",
+		Description: "List Constitutional Tribunal (Trybunał Konstytucyjny) rulings affecting a Polish legal act. Extracts the 'Orzeczenie TK' relation from the act's ELI references and returns each ruling with its case number (e.g. 'K 1/20', 'SK 35/15'), ruling kind (judgment or decision), ruling date, affected provision and the ELI address of the published ruling. Optionally classifies the outcome (unconstitutional, constitutional, partially unconstitutional, discontinued) from the ruling's text. Essential for checking whether provisions of an act were struck down or upheld.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"publisher": map[string]interface{}{
+					"type":        "string",
+					"description": "Publisher code of the legal act (e.g., 'DU' for Dziennik Ustaw, 'MP' for Monitor Polski).",
+				},
+				"year": map[string]interface{}{
+					"type":        "string",
+					"description": "Publication year of the legal act (e.g., '1997').",
+				},
+				"position": map[string]interface{}{
+					"type":        "string",
+					"description": "Position number of the legal act within the publisher's year (e.g., '553').",
+				},
+				"include_outcome": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to download ruling texts and classify their outcome (up to 10 most recent rulings). Default 'false'.",
+				},
+			},
+			Required: []string{"publisher", "year", "position"},
+		},
+	}, s.handleGetTKRulings)
+
+	s.addTool(mcp.Tool{
+		Name:        "eli_get_tk_ruling_acts",
+		Description: "Reverse lookup for Constitutional Tribunal (Trybunał Konstytucyjny) rulings: given a case number such as 'K 1/20', 'SK 35/15' or 'P 7/18', find the ruling published in the ELI database and list the legal acts it affects, with the relation type and affected provisions. Optionally classifies the ruling's outcome from its text. Use eli_get_tk_rulings for the opposite direction (act to rulings).",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"case_number": map[string]interface{}{
+					"type":        "string",
+					"description": "Constitutional Tribunal case signature (e.g., 'K 1/20', 'SK 35/15', 'Kp 1/17'). Spacing and letter case are normalized.",
+				},
+				"include_outcome": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to download the ruling text and classify its outcome. Default 'false'.",
+				},
+			},
+			Required: []string{"case_number"},
+		},
+	}, s.handleGetTKRulingActs)
+
+	s.addTool(mcp.Tool{
 		Name:        "eli_get_publishers",
 		Description: "Retrieve comprehensive directory of all official Polish legal document publishers in the ELI system. Returns detailed information about each publishing authority including publisher codes, official names (Polish and English), descriptions, publication scope, document counts, active date ranges, and website links. Publishers represent different levels and types of legal authority: national legislature (DU), government administration (MP), individual ministries (ministry-specific codes), regional authorities, and specialized agencies. Essential for understanding the Polish legal publication system, determining appropriate search parameters, validating legal citations, building comprehensive legal databases, and navigating the hierarchical structure of Polish legal documentation. Use this as reference when working with other ELI tools.",
 		InputSchema: mcp.ToolInputSchema{
@@ -367,7 +874,7 @@ func (s *SejmServer) registerELITools() {
 		},
 	}, s.handleGetPublishers)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "eli_search_act_content",
 		Description: "Search for specific text within a Polish legal act and get precise page locations. This powerful tool downloads the complete legal document, searches for your specified terms, and returns a detailed map showing exactly which pages contain each search term. Perfect for quickly locating specific provisions, articles, concepts, or keywords within large legal documents without reading the entire text. Essential for legal research, finding relevant sections, preparing citations, analyzing specific legal concepts, and navigating complex legislation efficiently. Much faster than manual searching through hundreds of pages.",
 		InputSchema: mcp.ToolInputSchema{
@@ -397,12 +904,79 @@ func (s *SejmServer) registerELITools() {
 					"type":        "string",
 					"description": "Optional. Maximum number of matches to show per search term (default: 10, max: 50). Helps limit response size for common terms.",
 				},
+				"match_mode": map[string]interface{}{
+					"type":        "string",
+					"description": matchModeParamDescription,
+				},
+				"ignore_diacritics": map[string]interface{}{
+					"type":        "string",
+					"description": ignoreDiacriticsParamDescription,
+				},
 			},
 			Required: []string{"publisher", "year", "position", "search_terms"},
 		},
 	}, s.handleSearchActContent)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
+		Name:        "eli_fulltext_search",
+		Description: "Search inside the texts of all acts of one publisher and year, not their metadata. Downloads the PDF text of every act in a window of the year's listing, keeps the extracted pages in an in-memory index for reuse, and returns the acts containing the search terms ranked by the number of terms found, with page numbers and a highlighted excerpt per term. Use it for concepts an act mentions without being tagged for them, which keyword search in eli_search_acts misses. To search one known act, use eli_search_act_content.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"publisher": map[string]interface{}{
+					"type":        "string",
+					"description": "Publisher code: 'DU' (Dziennik Ustaw) or 'MP' (Monitor Polski). Get codes from eli_get_publishers.",
+				},
+				"year": map[string]interface{}{
+					"type":        "string",
+					"description": "Publication year as a 4-digit string (e.g., '2024').",
+				},
+				"search_terms": map[string]interface{}{
+					"type":        "string",
+					"description": "Search terms separated by commas (e.g., 'sztuczna inteligencja,algorytm' or 'dane biometryczne').",
+				},
+				"require_all": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to return only acts containing every search term (default: 'false', any term).",
+				},
+				"type": map[string]interface{}{
+					"type":        "string",
+					"description": "Only acts of this type (e.g., 'Ustawa', 'Rozporządzenie', 'Obwieszczenie').",
+				},
+				"max_acts": map[string]interface{}{
+					"type":        "string",
+					"description": fmt.Sprintf("Number of acts of the year's listing searched (default: %d, max: %d). Acts not yet in the index are downloaded, which takes a few seconds each.", defaultFulltextActs, maxFulltextActs),
+				},
+				"offset": map[string]interface{}{
+					"type":        "string",
+					"description": "Position in the year's listing to start from (default: 0). Use with max_acts to search a year in windows.",
+				},
+				"max_matches_per_term": map[string]interface{}{
+					"type":        "string",
+					"description": fmt.Sprintf("Maximum matches collected per term in each act (default: %d, max: %d).", defaultFulltextMatches, maxFulltextMatches),
+				},
+				"context_chars": map[string]interface{}{
+					"type":        "string",
+					"description": fmt.Sprintf("Characters of context around each match (default: %d, max: 500).", defaultFulltextContext),
+				},
+				"match_mode": map[string]interface{}{
+					"type":        "string",
+					"description": matchModeParamDescription,
+				},
+				"ignore_diacritics": map[string]interface{}{
+					"type":        "string",
+					"description": ignoreDiacriticsParamDescription,
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json' (every matching act with all collected matches).",
+				},
+			},
+			Required: []string{"publisher", "year", "search_terms"},
+		},
+	}, s.handleFulltextSearch)
+
+	s.addTool(mcp.Tool{
 		Name:        "eli_get_keywords",
 		Description: "Retrieve comprehensive list of all available legal keywords used in the Polish ELI acts database. Returns a complete directory of official legal concept tags that can be used for keyword searches. These keywords represent standardized legal terminology and subject classifications used to categorize Polish legal acts. Essential for discovering searchable legal concepts, building comprehensive legal searches, understanding legal topic coverage, and ensuring accurate keyword-based searches. Use this to find the exact keyword terms for eli_search_acts keyword parameter. Keywords are cached for performance and updated periodically.",
 		InputSchema: mcp.ToolInputSchema{
@@ -420,7 +994,7 @@ func (s *SejmServer) registerELITools() {
 		},
 	}, s.handleGetKeywords)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "eli_get_types",
 		Description: "Retrieve comprehensive list of all available legal document types in the Polish ELI system. Returns standardized document type classifications used to categorize Polish legal acts such as 'Ustawa' (statute), 'Rozporządzenie' (regulation), 'Dekret' (decree), 'Uchwała' (resolution), etc. Essential for discovering valid document types for eli_search_acts type parameter, understanding the Polish legal document hierarchy, building comprehensive searches, and ensuring accurate type-based filtering. Use this reference when working with document type searches.",
 		InputSchema: mcp.ToolInputSchema{
@@ -438,7 +1012,7 @@ func (s *SejmServer) registerELITools() {
 		},
 	}, s.handleGetTypes)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "eli_get_statuses",
 		Description: "Retrieve comprehensive list of all available legal status classifications in the Polish ELI system. Returns standardized legal status categories such as 'obowiązujący' (in force), 'uchylony' (repealed), 'nieobowiązujący' (not in force), 'wygaśnięcie aktu' (expired), etc. Essential for discovering valid legal statuses, understanding document lifecycle states, building status-based searches, and filtering acts by their current legal validity. Use this reference when working with legal status searches and compliance checking.",
 		InputSchema: mcp.ToolInputSchema{
@@ -456,7 +1030,47 @@ func (s *SejmServer) registerELITools() {
 		},
 	}, s.handleGetStatuses)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
+		Name:        "eli_get_institutions",
+		Description: "List the institutions that issue or release Polish legal acts (organy wydające), such as 'SEJM', 'RADA MINISTRÓW' or 'MIN. ZDROWIA', exactly as ELI names them. Use the names in the institution parameter of eli_search_acts to find the acts issued by one authority, e.g. all regulations of a ministry.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"filter": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only institutions containing this text (e.g., 'zdrowia', 'min.', 'rada'). Case and Polish diacritics are ignored.",
+				},
+				"sort": map[string]interface{}{
+					"type":        "string",
+					"description": "Sort alphabetically: 'asc' for A-Z (default), 'desc' for Z-A.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json'.",
+				},
+			},
+		},
+	}, s.handleGetInstitutions)
+
+	s.addTool(mcp.Tool{
+		Name:        "eli_get_act_aliases",
+		Description: "List the well-known acts that every act tool accepts by name in the act parameter, such as 'Konstytucja', 'kodeks cywilny', 'KPA' or 'KSH', with their ELI addresses and abbreviations. Use act='KPA' instead of publisher, year and position in eli_get_act_text, eli_get_act_details or eli_get_act_references; act texts are returned as their newest consolidated text (tekst jednolity).",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"filter": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only acts matching this name or abbreviation (e.g., 'kodeks', 'podatek', 'kpc'). Case, dots and Polish diacritics are ignored.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json'.",
+				},
+			},
+		},
+	}, s.handleGetActAliases)
+
+	s.addTool(mcp.Tool{
 		Name:        "eli_list_acts",
 		Description: "Retrieve basic listing of legal acts from the Polish ELI database with pagination support. Returns essential metadata for acts including titles, publishers, years, and identifiers. Use this for browsing available acts, getting overview of legal documents, or as starting point for more detailed searches. Complements eli_search_acts by providing simple listing functionality without search criteria requirements.",
 		InputSchema: mcp.ToolInputSchema{
@@ -474,7 +1088,7 @@ func (s *SejmServer) registerELITools() {
 		},
 	}, s.handleListActs)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "eli_get_acts_by_publisher",
 		Description: "Retrieve all legal acts published by a specific publisher authority. Returns comprehensive listing of acts from publishers like 'DU' (Dziennik Ustaw), 'MP' (Monitor Polski), or ministry codes. Essential for analyzing publisher-specific legislation, understanding institutional legal output, researching ministry-specific regulations, and building publisher-focused legal databases. Use eli_get_publishers to discover available publisher codes.",
 		InputSchema: mcp.ToolInputSchema{
@@ -497,7 +1111,7 @@ func (s *SejmServer) registerELITools() {
 		},
 	}, s.handleGetActsByPublisher)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "eli_get_acts_by_year",
 		Description: "Retrieve all legal acts published by a specific publisher in a given year. Returns comprehensive yearly legislation from specified publisher authorities. Essential for temporal legal analysis, understanding yearly legislative output, researching historical legal development, tracking regulatory activity by year, and building time-series legal databases. Useful for legislative trend analysis and historical legal research.",
 		InputSchema: mcp.ToolInputSchema{
@@ -513,16 +1127,69 @@ func (s *SejmServer) registerELITools() {
 				},
 				"limit": map[string]interface{}{
 					"type":        "string",
-					"description": "Maximum number of acts to return (default: 30). Use higher values for comprehensive yearly analysis, but be aware of context limits.",
+					"description": "Maximum number of acts to return (default: 30). Use higher values for comprehensive yearly analysis, but be aware of context limits.",
+				},
+				"offset": map[string]interface{}{
+					"type":        "string",
+					"description": "Number of results to skip for pagination (default: 0). Use with limit for browsing large yearly collections.",
+				},
+			},
+			Required: []string{"publisher", "year"},
+		},
+	}, s.handleGetActsByYear)
+
+	s.addTool(mcp.Tool{
+		Name:        "eli_get_publisher_years",
+		Description: "List the years in which a publisher has acts, with the number of acts per year and, for years up to 2012, the number of volumes. Use it to see a publisher's coverage before fetching acts by year instead of guessing year ranges. Years without acts between the first and the last are listed too.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"publisher": map[string]interface{}{
+					"type":        "string",
+					"description": "Publisher code (e.g., 'DU', 'MP'). Get codes from eli_get_publishers. Required parameter.",
+				},
+				"year_from": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. First year to list (e.g., '2000').",
+				},
+				"year_to": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Last year to list (e.g., '2010').",
+				},
+				"counts": map[string]interface{}{
+					"type":        "string",
+					"description": "Count the acts and volumes of each year, one request per year (default 'true'). Use 'false' for the list of years only.",
 				},
-				"offset": map[string]interface{}{
+				"format": map[string]interface{}{
 					"type":        "string",
-					"description": "Number of results to skip for pagination (default: 0). Use with limit for browsing large yearly collections.",
+					"description": "Output format: 'text' (default) or 'json'.",
 				},
 			},
-			Required: []string{"publisher", "year"},
+			Required: []string{"publisher"},
 		},
-	}, s.handleGetActsByYear)
+	}, s.handleGetPublisherYears)
+}
+
+// formatActSearchLine renders a single legal act as an eli_search_acts result line
+func formatActSearchLine(act eli.Act) string {
+	title := "No title"
+	if act.Title != nil {
+		title = *act.Title
+	}
+
+	status := "Unknown"
+	if act.InForce != nil {
+		switch *act.InForce {
+		case "IN_FORCE":
+			status = "In force"
+		case "NOT_IN_FORCE":
+			status = "Not in force"
+		default:
+			status = "Unknown status"
+		}
+	}
+
+	return fmt.Sprintf("• %s: %s (%s)", actAddress(act), title, status)
 }
 
 func (s *SejmServer) handleSearchActs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -557,7 +1224,8 @@ func (s *SejmServer) handleSearchActs(ctx context.Context, request mcp.CallToolR
 	}
 
 	sortBy := request.GetString("sort_by", "")
-	if sortBy != "" {
+	groupByAct := request.GetString("group_by_act", "false") == "true"
+	if sortBy != "" && sortBy != "relevance" {
 		params["sort"] = sortBy
 
 		// Handle sort direction
@@ -589,11 +1257,43 @@ func (s *SejmServer) handleSearchActs(ctx context.Context, request mcp.CallToolR
 		params["inForce"] = inForce
 	}
 
-	keyword := request.GetString("keyword", "")
+	keywords := splitKeywords(request.GetString("keyword", ""))
+	keyword := strings.Join(keywords, ",")
+	keywordMode := strings.ToLower(request.GetString("keyword_mode", keywordModeAll))
+	if keywordMode != keywordModeAll && keywordMode != keywordModeAny {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid keyword_mode '%s'. Use 'all' (acts tagged with every keyword) or 'any' (acts tagged with at least one).", keywordMode)), nil
+	}
+	if keywordMode == keywordModeAny && len(keywords) > maxKeywordsAny {
+		return mcp.NewToolResultError(fmt.Sprintf("keyword_mode='any' supports at most %d keywords, got %d.", maxKeywordsAny, len(keywords))), nil
+	}
 	if keyword != "" {
 		params["keyword"] = keyword
 	}
 
+	institution := request.GetString("institution", "")
+	if institution != "" {
+		params["releasedBy"] = institution
+	}
+
+	effectiveFrom := request.GetString("effective_from", "")
+	if effectiveFrom != "" {
+		params["dateEffectFrom"] = effectiveFrom
+	}
+
+	effectiveTo := request.GetString("effective_to", "")
+	if effectiveTo != "" {
+		params["dateEffectTo"] = effectiveTo
+	}
+
+	legalStatus := request.GetString("status", "")
+	if legalStatus != "" {
+		normalized, err := normalizeLegalStatus(legalStatus)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid status: %v. See eli_get_statuses.", err)), nil
+		}
+		legalStatus = normalized
+	}
+
 	s.logger.Info("eli_search_acts called",
 		slog.String("title", title),
 		slog.String("publisher", publisher),
@@ -606,7 +1306,13 @@ func (s *SejmServer) handleSearchActs(ctx context.Context, request mcp.CallToolR
 		slog.String("date_from", dateFrom),
 		slog.String("date_to", dateTo),
 		slog.String("in_force", inForce),
-		slog.String("keyword", keyword))
+		slog.String("keyword", keyword),
+		slog.String("keyword_mode", keywordMode),
+		slog.String("institution", institution),
+		slog.String("effective_from", effectiveFrom),
+		slog.String("effective_to", effectiveTo),
+		slog.String("status", legalStatus),
+		slog.Bool("group_by_act", groupByAct))
 
 	// Validate that at least one search parameter is provided
 	// Count only actual search parameters (not pagination/sorting parameters)
@@ -632,9 +1338,18 @@ func (s *SejmServer) handleSearchActs(ctx context.Context, request mcp.CallToolR
 	if inForce != "" {
 		searchParamCount++
 	}
+	if institution != "" {
+		searchParamCount++
+	}
+	if effectiveFrom != "" || effectiveTo != "" {
+		searchParamCount++
+	}
+	if legalStatus != "" {
+		searchParamCount++
+	}
 
 	if searchParamCount == 0 {
-		return mcp.NewToolResultError("Please provide at least one search parameter (title, publisher, year, type, keyword, date range, or in_force status) to search legal acts. Examples: 'konstytucja' for title, 'DU' for publisher, 'ochrona danych' for keyword, or '1' for in_force to find only active laws."), nil
+		return mcp.NewToolResultError("Please provide at least one search parameter (title, publisher, year, type, keyword, institution, date range, status or in_force) to search legal acts. Examples: 'konstytucja' for title, 'DU' for publisher, 'ochrona danych' for keyword, or '1' for in_force to find only active laws."), nil
 	}
 
 	// Validate publisher code if provided
@@ -648,6 +1363,20 @@ func (s *SejmServer) handleSearchActs(ctx context.Context, request mcp.CallToolR
 		}
 	}
 
+	// Resolve the institution to the spelling ELI filters by
+	if institution != "" {
+		if institutions, err := s.getCachedInstitutions(ctx); err != nil {
+			s.logger.Warn("Institution validation failed", slog.String("institution", institution), slog.Any("error", err))
+		} else if resolved, suggestions, ok := resolveInstitution(institutions, institution); ok {
+			institution = resolved
+			params["releasedBy"] = resolved
+		} else if len(suggestions) > 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown institution '%s'. Did you mean:\n• %s\nSee eli_get_institutions for the full list.", institution, strings.Join(suggestions, "\n• "))), nil
+		} else {
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown institution '%s'. Use eli_get_institutions with a fragment of the name (e.g. filter='zdrowia') to find it.", institution)), nil
+		}
+	}
+
 	// Validate document type if provided
 	if docType != "" {
 		isValid, suggestions, err := s.validateDocumentType(docType)
@@ -659,18 +1388,57 @@ func (s *SejmServer) handleSearchActs(ctx context.Context, request mcp.CallToolR
 		}
 	}
 
-	endpoint := fmt.Sprintf("%s/acts/search", eliBaseURL)
-	data, err := s.makeAPIRequest(ctx, endpoint, params)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to search Polish legal acts database: %v. Please verify your search parameters are valid.", err)), nil
-	}
-
-	var searchResult struct {
+	type actSearchResult struct {
 		Items []eli.Act `json:"items"`
 		Count int       `json:"count"`
 	}
-	if err := json.Unmarshal(data, &searchResult); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse legal acts search results: %v. The ELI API may have returned unexpected data format.", err)), nil
+	endpoint := fmt.Sprintf("%s/acts/search", s.eliBaseURL)
+	search := func(params map[string]string) (actSearchResult, *mcp.CallToolResult) {
+		var result actSearchResult
+		data, err := s.makeAPIRequest(ctx, endpoint, params)
+		if err != nil {
+			return result, mcp.NewToolResultError(fmt.Sprintf("Failed to search Polish legal acts database: %v. Please verify your search parameters are valid.", err))
+		}
+		if err := s.decodeAPIResponse(data, &result); err != nil {
+			return result, mcp.NewToolResultError(fmt.Sprintf("Failed to parse legal acts search results: %v. The ELI API may have returned unexpected data format.", err))
+		}
+		return result, nil
+	}
+
+	var searchResult actSearchResult
+	var filterNotes []string
+	if keywordMode == keywordModeAny && len(keywords) > 1 {
+		// The API requires every listed keyword, so 'any' runs one search per keyword and merges the results
+		var lists [][]eli.Act
+		for _, k := range keywords {
+			keywordParams := make(map[string]string, len(params))
+			for name, value := range params {
+				keywordParams[name] = value
+			}
+			keywordParams["keyword"] = k
+			result, errResult := search(keywordParams)
+			if errResult != nil {
+				return errResult, nil
+			}
+			lists = append(lists, result.Items)
+			filterNotes = append(filterNotes, fmt.Sprintf("keyword '%s' matched %d acts", k, result.Count))
+		}
+		searchResult.Items = mergeActs(lists...)
+		searchResult.Count = len(searchResult.Items)
+	} else {
+		result, errResult := search(params)
+		if errResult != nil {
+			return errResult, nil
+		}
+		searchResult = result
+	}
+
+	// The API has no legal status filter; it is applied to the fetched page
+	if legalStatus != "" {
+		fetched := len(searchResult.Items)
+		searchResult.Items = filterActsByStatus(searchResult.Items, legalStatus)
+		searchResult.Count = len(searchResult.Items)
+		filterNotes = append(filterNotes, fmt.Sprintf("status filter kept %d of %d fetched acts", searchResult.Count, fetched))
 	}
 
 	// Build search criteria summary
@@ -687,6 +1455,22 @@ func (s *SejmServer) handleSearchActs(ctx context.Context, request mcp.CallToolR
 	if docType != "" {
 		criteria = append(criteria, fmt.Sprintf("Document type: %s", docType))
 	}
+	if keyword != "" {
+		criteria = append(criteria, fmt.Sprintf("Keywords (%s): %s", keywordMode, strings.Join(keywords, ", ")))
+	}
+	if institution != "" {
+		criteria = append(criteria, fmt.Sprintf("Issuing institution: %s", institution))
+	}
+	if dateFrom != "" || dateTo != "" {
+		criteria = append(criteria, fmt.Sprintf("Announced: %s to %s", valueOrDefault(dateFrom, "start"), valueOrDefault(dateTo, "now")))
+	}
+	if effectiveFrom != "" || effectiveTo != "" {
+		criteria = append(criteria, fmt.Sprintf("Entered into force: %s to %s", valueOrDefault(effectiveFrom, "start"), valueOrDefault(effectiveTo, "now")))
+	}
+	if legalStatus != "" {
+		criteria = append(criteria, fmt.Sprintf("Legal status: %s", legalStatus))
+	}
+	criteria = append(criteria, filterNotes...)
 
 	// Add pagination and sorting info
 	if offset != "" {
@@ -700,6 +1484,10 @@ func (s *SejmServer) handleSearchActs(ctx context.Context, request mcp.CallToolR
 		criteria = append(criteria, sortInfo)
 	}
 
+	if groupByAct {
+		criteria = append(criteria, "Grouped by underlying act (consolidated texts and amendments folded)")
+	}
+
 	criteria = append(criteria, fmt.Sprintf("Found %d legal acts", searchResult.Count))
 
 	if searchResult.Count == 0 {
@@ -774,46 +1562,32 @@ func (s *SejmServer) handleSearchActs(ctx context.Context, request mcp.CallToolR
 
 	results = append(results, fmt.Sprintf("Showing first %d of %d legal acts:", displayCount, searchResult.Count))
 
-	for i, act := range searchResult.Items {
-		if i >= 10 { // Show only first 10 to save space
-			break
-		}
-
-		title := "No title"
-		if act.Title != nil {
-			title = *act.Title
-		}
-
-		publisher := "Unknown"
-		if act.Publisher != nil {
-			publisher = *act.Publisher
+	if groupByAct {
+		groups := groupActsByUnderlyingAct(searchResult.Items, title)
+		results[0] = fmt.Sprintf("Showing %d underlying acts grouped from %d fetched results (%d total matches), most relevant first:", min(len(groups), 10), len(searchResult.Items), searchResult.Count)
+		for i, group := range groups {
+			if i >= 10 {
+				break
+			}
+			results = append(results, formatActGroup(group)...)
 		}
-
-		year := "Unknown"
-		pos := "Unknown"
-		if act.Year != nil {
-			year = fmt.Sprintf("%d", *act.Year)
+		if len(groups) > 10 {
+			results = append(results, fmt.Sprintf("... and %d more underlying acts in the fetched results", len(groups)-10))
 		}
-		if act.Pos != nil {
-			pos = fmt.Sprintf("%d", *act.Pos)
+	} else {
+		items := searchResult.Items
+		if sortBy == "relevance" {
+			items = rankActsByRelevance(items, title)
 		}
-
-		status := "Unknown"
-		if act.InForce != nil {
-			switch *act.InForce {
-			case "IN_FORCE":
-				status = "In force"
-			case "NOT_IN_FORCE":
-				status = "Not in force"
-			default:
-				status = "Unknown status"
+		for i, act := range items {
+			if i >= 10 { // Show only first 10 to save space
+				break
 			}
+			results = append(results, formatActSearchLine(act))
 		}
-
-		results = append(results, fmt.Sprintf("• %s/%s/%s: %s (%s)", publisher, year, pos, title, status))
 	}
 
-	if searchResult.Count > 10 {
+	if searchResult.Count > 10 && !groupByAct {
 		results = append(results, fmt.Sprintf("... and %d more acts available", searchResult.Count-10))
 	}
 
@@ -848,14 +1622,14 @@ func (s *SejmServer) handleGetActDetails(ctx context.Context, request mcp.CallTo
 		return mcp.NewToolResultError(fmt.Sprintf("Year must be a 4-digit year (e.g., '1997', '2020'), but got '%s'.", year)), nil
 	}
 
-	endpoint := fmt.Sprintf("%s/acts/%s/%s/%s", eliBaseURL, publisher, year, position)
+	endpoint := fmt.Sprintf("%s/acts/%s/%s/%s", s.eliBaseURL, publisher, year, position)
 	apiData, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve legal act details from ELI database: %v. Please verify the legal act coordinates: publisher=%s, year=%s, position=%s. You can search for valid acts using eli_search_acts.", err, publisher, year, position)), nil
 	}
 
 	var act eli.Act
-	if err := json.Unmarshal(apiData, &act); err != nil {
+	if err := s.decodeAPIResponse(apiData, &act); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse legal act data from ELI API response: %v. The API may have returned unexpected data format.", err)), nil
 	}
 
@@ -994,23 +1768,41 @@ func (s *SejmServer) handleGetActText(ctx context.Context, request mcp.CallToolR
 	if format != "html" && format != "pdf" && format != "text" {
 		return mcp.NewToolResultError(fmt.Sprintf("Format must be 'html', 'pdf', or 'text', but got '%s'. HTML is recommended for AI analysis, PDF for official documentation, TEXT for plain text extraction when HTML is unavailable.", format)), nil
 	}
+	columns := strings.ToLower(request.GetString("columns", actColumnsAll))
+	if err := validActColumns(columns); err != nil {
+		return mcp.NewToolResultError(err.Error() + "."), nil
+	}
+	if columns != actColumnsAll && format != "text" {
+		return mcp.NewToolResultError("Parameter 'columns' only applies to format='text', where the text is extracted from the PDF page layout."), nil
+	}
 
 	// Check format availability before attempting download
-	detailsEndpoint := fmt.Sprintf("%s/acts/%s/%s/%s", eliBaseURL, publisher, year, position)
+	detailsEndpoint := fmt.Sprintf("%s/acts/%s/%s/%s", s.eliBaseURL, publisher, year, position)
 	detailsData, err := s.makeAPIRequest(ctx, detailsEndpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to verify legal act availability: %v. Please verify the coordinates: publisher=%s, year=%s, position=%s using eli_search_acts first.", err, publisher, year, position)), nil
 	}
 
 	var act eli.Act
-	if err := json.Unmarshal(detailsData, &act); err != nil {
+	if err := s.decodeAPIResponse(detailsData, &act); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse legal act details: %v. Please verify the act exists.", err)), nil
 	}
 
+	// Acts amended since publication are read from their newest consolidated text unless the original is asked for
+	if request.GetString("consolidated", "true") != "false" {
+		if texts := consolidatedTexts(act); len(texts) > 0 {
+			return s.actTextFromConsolidated(ctx, request, act, texts)
+		}
+	}
+
 	// Check format availability and provide helpful guidance
 	htmlAvailable := act.TextHTML != nil && *act.TextHTML
 	pdfAvailable := act.TextPDF != nil && *act.TextPDF
 
+	if request.GetString("summarize", "false") == "true" {
+		return s.summarizeActText(ctx, publisher, year, position, htmlAvailable, pdfAvailable, parseSummarySentences(request.GetString("summary_sentences", "")))
+	}
+
 	if format == "html" && !htmlAvailable {
 		if pdfAvailable {
 			return mcp.NewToolResultError(fmt.Sprintf("HTML format is not available for legal act %s/%s/%s. This document is only available in PDF format. Please retry with format='pdf' to get the document, or format='text' to extract plain text from PDF. Many older legal documents and regulations are only published in PDF format by the Polish legal system.", publisher, year, position)), nil
@@ -1049,7 +1841,7 @@ func (s *SejmServer) handleGetActText(ctx context.Context, request mcp.CallToolR
 			// Pagination requested - must use PDF for page-level control
 			if pdfAvailable {
 				s.logger.Info("Pagination requested, using PDF extraction route")
-				pdfEndpoint := fmt.Sprintf("%s/acts/%s/%s/%s/text.pdf", eliBaseURL, publisher, year, position)
+				pdfEndpoint := fmt.Sprintf("%s/acts/%s/%s/%s/text.pdf", s.eliBaseURL, publisher, year, position)
 				pdfData, pdfErr := s.makeTextRequest(ctx, pdfEndpoint, "pdf")
 				if pdfErr != nil {
 					s.logger.Error("Failed to retrieve PDF for pagination", slog.Any("error", pdfErr))
@@ -1057,7 +1849,7 @@ func (s *SejmServer) handleGetActText(ctx context.Context, request mcp.CallToolR
 				}
 
 				s.logger.Info("Retrieved PDF data, starting paginated text extraction", slog.Int("bytes", len(pdfData)))
-				return s.extractTextWithPagination(ctx, pdfData, publisher, year, position, pageStr, pagesPerChunkStr, showPageInfo)
+				return s.extractTextWithColumns(ctx, pdfData, publisher, year, position, pageStr, pagesPerChunkStr, showPageInfo, columns)
 			} else {
 				return mcp.NewToolResultError(fmt.Sprintf("Pagination requested but PDF format not available for legal act %s/%s/%s. Pagination requires PDF format for page-level control.", publisher, year, position)), nil
 			}
@@ -1066,12 +1858,12 @@ func (s *SejmServer) handleGetActText(ctx context.Context, request mcp.CallToolR
 		// No pagination - use the best available format (prefer HTML for faster processing)
 		if htmlAvailable {
 			s.logger.Info("Using HTML route for text extraction (no pagination)")
-			endpoint = fmt.Sprintf("%s/acts/%s/%s/%s/text.html", eliBaseURL, publisher, year, position)
+			endpoint = fmt.Sprintf("%s/acts/%s/%s/%s/text.html", s.eliBaseURL, publisher, year, position)
 			requestFormat = "html"
 		} else if pdfAvailable {
 			s.logger.Info("HTML not available, using direct PDF extraction route")
 			// Go directly to PDF extraction since HTML is not available
-			pdfEndpoint := fmt.Sprintf("%s/acts/%s/%s/%s/text.pdf", eliBaseURL, publisher, year, position)
+			pdfEndpoint := fmt.Sprintf("%s/acts/%s/%s/%s/text.pdf", s.eliBaseURL, publisher, year, position)
 			pdfData, pdfErr := s.makeTextRequest(ctx, pdfEndpoint, "pdf")
 			if pdfErr != nil {
 				s.logger.Error("Failed to retrieve PDF for direct text extraction", slog.Any("error", pdfErr))
@@ -1080,7 +1872,7 @@ func (s *SejmServer) handleGetActText(ctx context.Context, request mcp.CallToolR
 
 			s.logger.Info("Retrieved PDF data, starting text extraction with pagination support", slog.Int("bytes", len(pdfData)))
 			// Extract text from PDF with pagination support
-			return s.extractTextWithPagination(ctx, pdfData, publisher, year, position, pageStr, pagesPerChunkStr, showPageInfo)
+			return s.extractTextWithColumns(ctx, pdfData, publisher, year, position, pageStr, pagesPerChunkStr, showPageInfo, columns)
 		} else {
 			s.logger.Error("No text formats available",
 				slog.String("publisher", publisher),
@@ -1090,11 +1882,11 @@ func (s *SejmServer) handleGetActText(ctx context.Context, request mcp.CallToolR
 		}
 	case "pdf":
 		s.logger.Info("Using PDF format")
-		endpoint = fmt.Sprintf("%s/acts/%s/%s/%s/text.pdf", eliBaseURL, publisher, year, position)
+		endpoint = fmt.Sprintf("%s/acts/%s/%s/%s/text.pdf", s.eliBaseURL, publisher, year, position)
 		requestFormat = "pdf"
 	default:
 		s.logger.Info("Using HTML format")
-		endpoint = fmt.Sprintf("%s/acts/%s/%s/%s/text.html", eliBaseURL, publisher, year, position)
+		endpoint = fmt.Sprintf("%s/acts/%s/%s/%s/text.html", s.eliBaseURL, publisher, year, position)
 		requestFormat = "html"
 	}
 
@@ -1108,12 +1900,12 @@ func (s *SejmServer) handleGetActText(ctx context.Context, request mcp.CallToolR
 		if format == "text" && strings.Contains(err.Error(), "403") {
 			s.logger.Info("HTML failed with 403, attempting fallback to PDF extraction")
 			// HTML failed, try PDF and extract text
-			pdfEndpoint := fmt.Sprintf("%s/acts/%s/%s/%s/text.pdf", eliBaseURL, publisher, year, position)
+			pdfEndpoint := fmt.Sprintf("%s/acts/%s/%s/%s/text.pdf", s.eliBaseURL, publisher, year, position)
 			pdfData, pdfErr := s.makeTextRequest(ctx, pdfEndpoint, "pdf")
 			if pdfErr == nil {
 				s.logger.Info("Fallback PDF retrieval successful, starting text extraction with pagination", slog.Int("bytes", len(pdfData)))
 				// Extract text from PDF with pagination support
-				return s.extractTextWithPagination(ctx, pdfData, publisher, year, position, pageStr, pagesPerChunkStr, showPageInfo)
+				return s.extractTextWithColumns(ctx, pdfData, publisher, year, position, pageStr, pagesPerChunkStr, showPageInfo, columns)
 			} else {
 				s.logger.Error("Fallback PDF retrieval also failed", slog.Any("error", pdfErr))
 			}
@@ -1122,11 +1914,11 @@ func (s *SejmServer) handleGetActText(ctx context.Context, request mcp.CallToolR
 		// If HTML format failed, check if PDF format is available
 		if format == "html" && strings.Contains(err.Error(), "403") {
 			// Try to get act details to check available formats
-			detailsEndpoint := fmt.Sprintf("%s/acts/%s/%s/%s", eliBaseURL, publisher, year, position)
+			detailsEndpoint := fmt.Sprintf("%s/acts/%s/%s/%s", s.eliBaseURL, publisher, year, position)
 			detailsData, detailsErr := s.makeAPIRequest(ctx, detailsEndpoint, nil)
 			if detailsErr == nil {
 				var act eli.Act
-				if json.Unmarshal(detailsData, &act) == nil {
+				if s.decodeAPIResponse(detailsData, &act) == nil {
 					// Check if textPDF is available but textHTML is not
 					if act.TextPDF != nil && *act.TextPDF && (act.TextHTML == nil || !*act.TextHTML) {
 						return mcp.NewToolResultError(fmt.Sprintf("HTML format is not available for legal act %s/%s/%s, but PDF format is available. Please retry with format='pdf' to get the document text, or format='text' to extract plain text from PDF. Some older or special documents are only published in PDF format.", publisher, year, position)), nil
@@ -1206,35 +1998,31 @@ func (s *SejmServer) handleGetActReferences(ctx context.Context, request mcp.Cal
 		offset = 0
 	}
 
-	endpoint := fmt.Sprintf("%s/acts/%s/%s/%s/references", eliBaseURL, publisher, year, position)
+	endpoint := fmt.Sprintf("%s/acts/%s/%s/%s/references", s.eliBaseURL, publisher, year, position)
 	apiData, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve legal act references from ELI database: %v. Please verify the legal act exists with coordinates: publisher=%s, year=%s, position=%s. Use eli_get_act_details to verify the act exists first.", err, publisher, year, position)), nil
 	}
 
 	var references eli.CustomReferencesDetailsInfo
-	if err := json.Unmarshal(apiData, &references); err != nil {
+	if err := s.decodeAPIResponse(apiData, &references); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse legal references data from ELI API response: %v. The API may have returned unexpected data format.", err)), nil
 	}
 
-	// Apply category filtering if specified
-	if categoryFilter != "" {
-		if filteredRefs, exists := references[categoryFilter]; exists {
-			references = eli.CustomReferencesDetailsInfo{categoryFilter: filteredRefs}
-		} else {
-			availableCategories := make([]string, 0, len(references))
-			for category := range references {
-				availableCategories = append(availableCategories, category)
-			}
-			return mcp.NewToolResultError(fmt.Sprintf("Category '%s' not found. Available categories: %s", categoryFilter, strings.Join(availableCategories, ", "))), nil
-		}
+	// Apply category and direction filtering if specified
+	direction := request.GetString("direction", referenceDirectionAll)
+	if direction != referenceDirectionAll && direction != referenceDirectionIncoming && direction != referenceDirectionOutgoing {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid direction '%s'. Use 'incoming' (acts referring to this act), 'outgoing' (acts this act refers to) or 'all'.", direction)), nil
+	}
+	allReferences := references
+	references, unknownCategories := selectReferenceCategories(allReferences, categoryFilter, direction)
+	if len(unknownCategories) > 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Category '%s' not found. Available categories: %s", strings.Join(unknownCategories, ", "), strings.Join(orderedReferenceCategories(allReferences), ", "))), nil
 	}
 
 	// Analyze reference patterns by category
 	totalRefs := 0
-	totalAvailableRefs := 0
 	for _, refList := range references {
-		totalAvailableRefs += len(refList)
 		totalRefs += len(refList)
 	}
 
@@ -1244,8 +2032,11 @@ func (s *SejmServer) handleGetActReferences(ctx context.Context, request mcp.Cal
 	if categoryFilter != "" {
 		summary = append(summary, fmt.Sprintf("Filtered Category: %s", categoryFilter))
 	}
+	if direction != referenceDirectionAll {
+		summary = append(summary, fmt.Sprintf("Direction: %s", describeReferenceDirection(direction)))
+	}
 	summary = append(summary, fmt.Sprintf("Reference categories shown: %d", len(references)))
-	summary = append(summary, fmt.Sprintf("Total references found: %d", totalAvailableRefs))
+	summary = append(summary, fmt.Sprintf("Total references found: %d", totalRefs))
 	summary = append(summary, fmt.Sprintf("Pagination: showing %d references per category (offset: %d, limit: %d)", limit, offset, limit))
 
 	if totalRefs == 0 {
@@ -1260,6 +2051,9 @@ func (s *SejmServer) handleGetActReferences(ctx context.Context, request mcp.Cal
 			},
 			Note: "This legal act has no recorded relationships with other acts in the ELI database. This could mean it's a standalone regulation or the reference data hasn't been fully processed yet.",
 		}
+		if direction != referenceDirectionAll {
+			response.Note = fmt.Sprintf("No %s references in the selected categories. Use direction='all' to include both directions.", direction)
+		}
 		return mcp.NewToolResultText(response.Format()), nil
 	}
 
@@ -1269,103 +2063,78 @@ func (s *SejmServer) handleGetActReferences(ctx context.Context, request mcp.Cal
 
 	data = append(data, "Reference Categories:")
 
-	// Prioritize important reference types for navigation
-	priorityCategories := map[string]string{
-		"Akty uchylające":  "Acts that repeal this law",
-		"Akty zmieniające": "Acts that amend this law",
-		"Akty uchylone":    "Acts repealed by this law",
-		"Akty zmieniane":   "Acts amended by this law",
-		"Akty podstawowe":  "Foundational acts this law is based on",
-		"Akty wykonawcze":  "Implementing regulations for this law",
-	}
-
-	// Apply pagination to each category and show results
-	for category, description := range priorityCategories {
-		if refList, exists := references[category]; exists && len(refList) > 0 {
-			// Apply pagination to this category
-			totalInCategory := len(refList)
-			start := offset
-			end := offset + limit
-			if start >= totalInCategory {
-				continue // Skip this category if offset is beyond available data
-			}
-			if end > totalInCategory {
-				end = totalInCategory
-			}
+	// Apply pagination to each category and show results, the most useful categories first
+	for _, category := range orderedReferenceCategories(references) {
+		refList := references[category]
+		if len(refList) == 0 {
+			continue
+		}
+		totalInCategory := len(refList)
+		start := offset
+		end := offset + limit
+		if start >= totalInCategory {
+			data = append(data, fmt.Sprintf("• %s: %d total references (use offset=0 to view)", category, totalInCategory))
+			continue
+		}
+		if end > totalInCategory {
+			end = totalInCategory
+		}
 
-			paginatedRefs := refList[start:end]
-			data = append(data, fmt.Sprintf("• %s: showing %d-%d of %d total references (%s)",
-				category, start+1, end, totalInCategory, description))
+		header := fmt.Sprintf("• %s: showing %d-%d of %d total references", category, start+1, end, totalInCategory)
+		if description, ok := referenceCategoryDescriptions[category]; ok {
+			header += fmt.Sprintf(" (%s)", description)
+		}
+		data = append(data, header)
 
-			// Show paginated results
-			for i, ref := range paginatedRefs {
-				if ref.Act != nil && ref.Act.Title != nil {
-					// Extract coordinates for navigation
-					actPublisher := "Unknown"
-					actYear := "Unknown"
-					actPos := "Unknown"
+		// Show paginated results
+		for i, ref := range refList[start:end] {
+			if ref.Act != nil && ref.Act.Title != nil {
+				// Extract coordinates for navigation
+				actPublisher := "Unknown"
+				actYear := "Unknown"
+				actPos := "Unknown"
 
-					if ref.Act.Publisher != nil {
-						actPublisher = *ref.Act.Publisher
-					}
-					if ref.Act.Year != nil {
-						actYear = fmt.Sprintf("%d", *ref.Act.Year)
-					}
-					if ref.Act.Pos != nil {
-						actPos = fmt.Sprintf("%d", *ref.Act.Pos)
-					}
+				if ref.Act.Publisher != nil {
+					actPublisher = *ref.Act.Publisher
+				}
+				if ref.Act.Year != nil {
+					actYear = fmt.Sprintf("%d", *ref.Act.Year)
+				}
+				if ref.Act.Pos != nil {
+					actPos = fmt.Sprintf("%d", *ref.Act.Pos)
+				}
 
-					data = append(data, fmt.Sprintf("  %d. %s (%s/%s/%s)", start+i+1, *ref.Act.Title, actPublisher, actYear, actPos))
+				data = append(data, fmt.Sprintf("  %d. %s (%s/%s/%s)", start+i+1, *ref.Act.Title, actPublisher, actYear, actPos))
 
-					// Add navigation examples for first few results to avoid overwhelming next actions
-					if len(nextActions) < 5 {
-						nextActions = append(nextActions, fmt.Sprintf("Explore '%s': eli_get_act_details with publisher='%s', year='%s', position='%s'", *ref.Act.Title, actPublisher, actYear, actPos))
-					}
+				// Add navigation examples for first few results to avoid overwhelming next actions
+				if len(nextActions) < 5 {
+					nextActions = append(nextActions, fmt.Sprintf("Explore '%s': eli_get_act_details with publisher='%s', year='%s', position='%s'", *ref.Act.Title, actPublisher, actYear, actPos))
 				}
 			}
-
-			// Add pagination hints
-			if totalInCategory > end {
-				remaining := totalInCategory - end
-				data = append(data, fmt.Sprintf("  ... %d more references available (use offset=%d to continue)", remaining, end))
-			}
-			data = append(data, "") // Add spacing
 		}
-	}
 
-	// Show remaining categories with pagination
-	for category, refList := range references {
-		if _, isPriority := priorityCategories[category]; !isPriority && len(refList) > 0 {
-			totalInCategory := len(refList)
-			if offset < totalInCategory {
-				start := offset
-				end := offset + limit
-				if end > totalInCategory {
-					end = totalInCategory
-				}
-				data = append(data, fmt.Sprintf("• %s: showing %d-%d of %d total references", category, start+1, end, totalInCategory))
-
-				if totalInCategory > end {
-					remaining := totalInCategory - end
-					data = append(data, fmt.Sprintf("  ... %d more available (use category='%s' with offset=%d)", remaining, category, end))
-				}
-			} else {
-				data = append(data, fmt.Sprintf("• %s: %d total references (use offset=0 to view)", category, totalInCategory))
-			}
+		// Add pagination hints
+		if totalInCategory > end {
+			remaining := totalInCategory - end
+			data = append(data, fmt.Sprintf("  ... %d more references available (use category='%s' with offset=%d to continue)", remaining, category, end))
 		}
+		data = append(data, "") // Add spacing
 	}
 
-	// Add pagination navigation actions
+	// Add pagination navigation actions, repeating the filters
+	filters := fmt.Sprintf("publisher='%s', year='%s', position='%s'", publisher, year, position)
+	if categoryFilter != "" {
+		filters += fmt.Sprintf(", category='%s'", categoryFilter)
+	}
+	if direction != referenceDirectionAll {
+		filters += fmt.Sprintf(", direction='%s'", direction)
+	}
 	if offset > 0 {
 		prevOffset := offset - limit
 		if prevOffset < 0 {
 			prevOffset = 0
 		}
-		if categoryFilter != "" {
-			nextActions = append(nextActions, fmt.Sprintf("Previous page: eli_get_act_references with publisher='%s', year='%s', position='%s', category='%s', offset='%d', limit='%d'", publisher, year, position, categoryFilter, prevOffset, limit))
-		} else {
-			nextActions = append(nextActions, fmt.Sprintf("Previous page: eli_get_act_references with publisher='%s', year='%s', position='%s', offset='%d', limit='%d'", publisher, year, position, prevOffset, limit))
-		}
+		nextActions = append(nextActions, fmt.Sprintf("Previous page: eli_get_act_references with %s, offset='%d', limit='%d'", filters, prevOffset, limit))
 	}
 
 	hasMoreResults := false
@@ -1377,19 +2146,17 @@ func (s *SejmServer) handleGetActReferences(ctx context.Context, request mcp.Cal
 	}
 
 	if hasMoreResults {
-		nextOffset := offset + limit
-		if categoryFilter != "" {
-			nextActions = append(nextActions, fmt.Sprintf("Next page: eli_get_act_references with publisher='%s', year='%s', position='%s', category='%s', offset='%d', limit='%d'", publisher, year, position, categoryFilter, nextOffset, limit))
-		} else {
-			nextActions = append(nextActions, fmt.Sprintf("Next page: eli_get_act_references with publisher='%s', year='%s', position='%s', offset='%d', limit='%d'", publisher, year, position, nextOffset, limit))
-		}
+		nextActions = append(nextActions, fmt.Sprintf("Next page: eli_get_act_references with %s, offset='%d', limit='%d'", filters, offset+limit, limit))
+	}
+	if direction == referenceDirectionAll {
+		nextActions = append(nextActions, "Only acts referring to this act: add direction='incoming'; only acts it refers to: direction='outgoing'")
 	}
 
 	// Add general navigation actions
 	nextActions = append(nextActions,
 		fmt.Sprintf("Get full details of this act: eli_get_act_details with publisher='%s', year='%s', position='%s'", publisher, year, position),
 		fmt.Sprintf("Download text of this act: eli_get_act_text with publisher='%s', year='%s', position='%s'", publisher, year, position),
-		"Focus on specific category: use category parameter (e.g., category='Akty wykonawcze')",
+		"Focus on specific categories: use category parameter (e.g., category='Akty wykonawcze' or category='Akty zmieniające, Akty uchylające')",
 		"Search for acts by similar topics: eli_search_acts with relevant keywords",
 	)
 
@@ -1406,14 +2173,14 @@ func (s *SejmServer) handleGetActReferences(ctx context.Context, request mcp.Cal
 }
 
 func (s *SejmServer) handleGetPublishers(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	endpoint := fmt.Sprintf("%s/acts", eliBaseURL)
+	endpoint := fmt.Sprintf("%s/acts", s.eliBaseURL)
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve publishers directory from ELI database: %v. Please try again.", err)), nil
 	}
 
 	var publishers []eli.PublishingHouse
-	if err := json.Unmarshal(data, &publishers); err != nil {
+	if err := s.decodeAPIResponse(data, &publishers); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse publishers data from ELI API response: %v. The API may have returned unexpected data format.", err)), nil
 	}
 
@@ -1466,6 +2233,10 @@ func (s *SejmServer) handleSearchActContent(ctx context.Context, request mcp.Cal
 	if searchTerms == "" {
 		return mcp.NewToolResultError("Search terms are required. Provide comma-separated terms to search for (e.g., 'artykuł,konstytucja,prawa' or 'podatek,VAT')."), nil
 	}
+	options, err := parseTextSearchOptions(request)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid search options: %v.", err)), nil
+	}
 
 	// Parse parameters
 	contextCharsInt := 100
@@ -1494,22 +2265,13 @@ func (s *SejmServer) handleSearchActContent(ctx context.Context, request mcp.Cal
 		}
 	}
 
-	// Split and clean search terms
-	terms := strings.Split(searchTerms, ",")
-	var cleanTerms []string
-	for _, term := range terms {
-		cleaned := strings.TrimSpace(term)
-		if cleaned != "" {
-			cleanTerms = append(cleanTerms, cleaned)
-		}
-	}
-
+	cleanTerms := splitSearchTerms(searchTerms, options)
 	if len(cleanTerms) == 0 {
 		return mcp.NewToolResultError("No valid search terms found. Please provide comma-separated terms to search for."), nil
 	}
 
 	// First, get the PDF to extract text page by page
-	pdfEndpoint := fmt.Sprintf("%s/acts/%s/%s/%s/text.pdf", eliBaseURL, publisher, year, position)
+	pdfEndpoint := fmt.Sprintf("%s/acts/%s/%s/%s/text.pdf", s.eliBaseURL, publisher, year, position)
 	pdfData, err := s.makeTextRequest(ctx, pdfEndpoint, "pdf")
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve PDF for search: %v. Please verify the legal act coordinates: publisher=%s, year=%s, position=%s", err, publisher, year, position)), nil
@@ -1517,114 +2279,24 @@ func (s *SejmServer) handleSearchActContent(ctx context.Context, request mcp.Cal
 
 	s.logger.Info("Retrieved PDF for content search", slog.Int("bytes", len(pdfData)))
 
-	// Parse PDF and extract text page by page
-	doc, err := fitz.NewFromMemory(pdfData)
+	pageTexts, err := s.extractPDFPageTexts(ctx, pdfData)
 	if err != nil {
 		s.logger.Error("Failed to parse PDF for content search", slog.Any("error", err))
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse PDF document: %v", err)), nil
 	}
-	defer func() {
-		if err := doc.Close(); err != nil {
-			s.logger.Warn("Failed to close PDF document", slog.Any("error", err))
-		}
-	}()
-
-	pageCount := doc.NumPage()
+	pageCount := len(pageTexts)
 	s.logger.Info("PDF parsed for content search", slog.Int("totalPages", pageCount))
 
-	if pageCount == 0 {
-		return mcp.NewToolResultError("PDF document has no pages to search"), nil
-	}
-
-	// Search results structure
-	type SearchMatch struct {
-		Term     string
-		Page     int
-		Context  string
-		Position int // character position within page
-	}
-
-	termMatches := make(map[string][]SearchMatch)
-	totalMatches := 0
-
-	// Search each page
-	for pageNum := 0; pageNum < pageCount; pageNum++ {
-		pageText, err := doc.Text(pageNum)
-		if err != nil {
-			s.logger.Warn("Failed to extract text from page for search",
-				slog.Int("page", pageNum+1), slog.Any("error", err))
-			continue
-		}
-
-		pageTextLower := strings.ToLower(pageText)
-
-		// Search for each term on this page
-		for _, term := range cleanTerms {
-			termLower := strings.ToLower(term)
-
-			// Skip if we already have enough matches for this term
-			if len(termMatches[term]) >= maxMatchesInt {
-				continue
-			}
-
-			// Find all occurrences of this term on this page
-			startPos := 0
-			for {
-				pos := strings.Index(pageTextLower[startPos:], termLower)
-				if pos == -1 {
-					break
-				}
-
-				actualPos := startPos + pos
-
-				// Extract context around the match
-				contextStart := actualPos - contextCharsInt/2
-				if contextStart < 0 {
-					contextStart = 0
-				}
-				contextEnd := actualPos + len(term) + contextCharsInt/2
-				if contextEnd > len(pageText) {
-					contextEnd = len(pageText)
-				}
-
-				context := pageText[contextStart:contextEnd]
-				// Highlight the found term in context
-				context = strings.ReplaceAll(context, pageText[actualPos:actualPos+len(term)],
-					fmt.Sprintf("**%s**", pageText[actualPos:actualPos+len(term)]))
-
-				// Clean up context (remove excessive whitespace)
-				context = strings.ReplaceAll(context, "\n", " ")
-				context = strings.ReplaceAll(context, "\t", " ")
-				for strings.Contains(context, "  ") {
-					context = strings.ReplaceAll(context, "  ", " ")
-				}
-				context = strings.TrimSpace(context)
-
-				match := SearchMatch{
-					Term:     term,
-					Page:     pageNum + 1, // Convert to 1-based
-					Context:  context,
-					Position: actualPos,
-				}
-
-				termMatches[term] = append(termMatches[term], match)
-				totalMatches++
-
-				// Check if we have enough matches for this term
-				if len(termMatches[term]) >= maxMatchesInt {
-					break
-				}
-
-				// Move past this match to find next occurrence
-				startPos = actualPos + len(term)
-			}
-		}
+	termMatches, totalMatches, err := searchTermsInPages(pageTexts, cleanTerms, options, contextCharsInt, maxMatchesInt)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid search terms: %v.", err)), nil
 	}
 
 	// Build response
 	var summary []string
 	summary = append(summary, fmt.Sprintf("Document searched: %s/%s/%s", publisher, year, position))
 	summary = append(summary, fmt.Sprintf("Search terms: %d (%s)", len(cleanTerms), strings.Join(cleanTerms, ", ")))
+	summary = append(summary, fmt.Sprintf("Matching: %s", options.describe()))
 	summary = append(summary, fmt.Sprintf("Total pages searched: %d", pageCount))
 	summary = append(summary, fmt.Sprintf("Total matches found: %d", totalMatches))
 
@@ -1656,7 +2328,7 @@ func (s *SejmServer) handleSearchActContent(ctx context.Context, request mcp.Cal
 				data = append(data, fmt.Sprintf("🔍 '%s' - %d matches:", term, len(matches)))
 
 				// Group by page for cleaner display
-				pageGroups := make(map[int][]SearchMatch)
+				pageGroups := make(map[int][]textSearchMatch)
 				for _, match := range matches {
 					pageGroups[match.Page] = append(pageGroups[match.Page], match)
 				}
@@ -1725,7 +2397,9 @@ func (s *SejmServer) handleSearchActContent(ctx context.Context, request mcp.Cal
 }
 
 // extractTextFromPDF extracts plain text from PDF data using go-fitz
-func (s *SejmServer) extractTextFromPDF(pdfData []byte) (string, error) {
+func (s *SejmServer) extractTextFromPDF(ctx context.Context, pdfData []byte) (_ string, err error) {
+	_, span := s.startSpan(ctx, "pdf extract text", attribute.Int("pdf.bytes", len(pdfData)))
+	defer func() { endSpan(span, err) }()
 	s.logger.Info("Starting PDF text extraction", slog.Int("bytes", len(pdfData)))
 
 	if len(pdfData) == 0 {
@@ -1759,18 +2433,17 @@ func (s *SejmServer) extractTextFromPDF(pdfData []byte) (string, error) {
 	var extractedPages int
 	var failedPages int
 
-	// Extract text from each page
-	for i := 0; i < pageCount; i++ {
-		s.logger.Debug("Extracting text from page",
-			slog.Int("page", i+1),
-			slog.Int("totalPages", pageCount))
-		text, err := doc.Text(i)
-		if err != nil {
+	// Extract text from each page, in parallel for long documents
+	texts, errs := s.extractPDFPages(ctx, doc, pdfData, pageRange(0, pageCount), pdfPageText)
+	for i, text := range texts {
+		if err := errs[i]; err != nil {
 			s.logger.Warn("Failed to extract text from page",
 				slog.Int("page", i+1),
 				slog.Any("error", err))
 			failedPages++
-			// Don't fail completely, just log and continue with other pages
+			// Don't fail completely; mark the gap so readers know text is missing there
+			textBuilder.WriteString(fmt.Sprintf("[Page %d could not be extracted: %v]", i+1, err))
+			textBuilder.WriteString("\n\n")
 			continue
 		}
 
@@ -1798,11 +2471,11 @@ func (s *SejmServer) extractTextFromPDF(pdfData []byte) (string, error) {
 		slog.Int("failedPages", failedPages),
 		slog.Int("totalCharacters", len(extractedText)))
 
-	if len(extractedText) == 0 {
+	if extractedPages == 0 {
 		s.logger.Error("No text could be extracted from PDF document",
 			slog.Int("pages", pageCount),
 			slog.Int("extractablePages", extractedPages))
-		return "", fmt.Errorf("no text could be extracted from PDF document (%d pages, %d with extractable text)", pageCount, extractedPages)
+		return "", fmt.Errorf("no text could be extracted from PDF document (%d pages, %d failed to extract)", pageCount, failedPages)
 	}
 
 	return extractedText, nil
@@ -1810,6 +2483,12 @@ func (s *SejmServer) extractTextFromPDF(pdfData []byte) (string, error) {
 
 // extractTextWithPagination extracts text from PDF with pagination support
 func (s *SejmServer) extractTextWithPagination(ctx context.Context, pdfData []byte, publisher, year, position, pageStr, pagesPerChunkStr, showPageInfo string) (*mcp.CallToolResult, error) {
+	return s.extractTextWithColumns(ctx, pdfData, publisher, year, position, pageStr, pagesPerChunkStr, showPageInfo, actColumnsAll)
+}
+
+// extractTextWithColumns extracts text from PDF with pagination support, keeping only the requested columns of
+// bilingual documents (see selectPageColumns); actColumnsAll keeps the text as laid out in the PDF
+func (s *SejmServer) extractTextWithColumns(ctx context.Context, pdfData []byte, publisher, year, position, pageStr, pagesPerChunkStr, showPageInfo, columns string) (*mcp.CallToolResult, error) {
 	s.logger.Info("Starting paginated PDF text extraction",
 		slog.Int("bytes", len(pdfData)),
 		slog.String("publisher", publisher),
@@ -1817,7 +2496,8 @@ func (s *SejmServer) extractTextWithPagination(ctx context.Context, pdfData []by
 		slog.String("position", position),
 		slog.String("page", pageStr),
 		slog.String("pagesPerChunk", pagesPerChunkStr),
-		slog.String("showPageInfo", showPageInfo))
+		slog.String("showPageInfo", showPageInfo),
+		slog.String("columns", columns))
 
 	if len(pdfData) == 0 {
 		s.logger.Error("PDF data is empty for pagination")
@@ -1923,19 +2603,27 @@ func (s *SejmServer) extractTextWithPagination(ctx context.Context, pdfData []by
 	var extractedPages int
 	var failedPages int
 
-	for pageNum := startPage - 1; pageNum < endPage; pageNum++ { // Convert to 0-based indexing
-		s.logger.Debug("Extracting text from page",
-			slog.Int("page", pageNum+1),
-			slog.Int("totalPages", pageCount))
-
-		text, err := doc.Text(pageNum)
-		if err != nil {
+	extract := pdfPageText
+	if columns != actColumnsAll {
+		extract = func(doc *fitz.Document, page int) (string, error) {
+			pageHTML, err := doc.HTML(page, false)
+			return selectPageColumns(pageHTML, columns), err
+		}
+	}
+	pages := pageRange(startPage-1, endPage) // Convert to 0-based indexing
+	texts, errs := s.extractPDFPages(ctx, doc, pdfData, pages, extract)
+	coverage := newSourceCoverage("pages")
+	for i, text := range texts {
+		pageNum := pages[i]
+		if err := errs[i]; err != nil {
 			s.logger.Warn("Failed to extract text from page",
 				slog.Int("page", pageNum+1),
 				slog.Any("error", err))
 			failedPages++
+			coverage.fail(fmt.Sprintf("page %d", pageNum+1), err)
 			continue
 		}
+		coverage.succeeded()
 
 		textLength := len(strings.TrimSpace(text))
 		if textLength > 0 {
@@ -1968,7 +2656,11 @@ func (s *SejmServer) extractTextWithPagination(ctx context.Context, pdfData []by
 			slog.Int("startPage", startPage),
 			slog.Int("endPage", endPage),
 			slog.Int("extractablePages", extractedPages))
-		return mcp.NewToolResultError(fmt.Sprintf("No text could be extracted from pages %d-%d (%d pages, %d with extractable text)", startPage, endPage, endPage-startPage+1, extractedPages)), nil
+		message := fmt.Sprintf("No text could be extracted from pages %d-%d (%d pages, %d with extractable text)", startPage, endPage, endPage-startPage+1, extractedPages)
+		if failedPages > 0 {
+			message += fmt.Sprintf("; failed to extract %s. Other pages may still be readable", describePDFPageFailures(pdfPageFailures(pages, errs)))
+		}
+		return mcp.NewToolResultError(message), nil
 	}
 
 	// Build response with navigation information
@@ -1977,9 +2669,14 @@ func (s *SejmServer) extractTextWithPagination(ctx context.Context, pdfData []by
 	summary = append(summary, fmt.Sprintf("Pages extracted: %d-%d of %d total pages", startPage, endPage, pageCount))
 	summary = append(summary, fmt.Sprintf("Successfully extracted: %d pages", extractedPages))
 	if failedPages > 0 {
-		summary = append(summary, fmt.Sprintf("Failed to extract: %d pages", failedPages))
+		summary = append(summary, fmt.Sprintf("Failed to extract: %s", describePDFPageFailures(pdfPageFailures(pages, errs))))
 	}
 	summary = append(summary, fmt.Sprintf("Text length: %d characters", len(extractedText)))
+	columnsArg := ""
+	if columns != actColumnsAll {
+		summary = append(summary, fmt.Sprintf("Columns: %s (detected from the page layout and language)", columns))
+		columnsArg = fmt.Sprintf(", columns='%s'", columns)
+	}
 
 	var nextActions []string
 	if endPage < pageCount {
@@ -1988,22 +2685,23 @@ func (s *SejmServer) extractTextWithPagination(ctx context.Context, pdfData []by
 		if nextPageEnd > pageCount {
 			nextPageEnd = pageCount
 		}
-		nextActions = append(nextActions, fmt.Sprintf("Read next pages: eli_get_act_text with page='%d' and pages_per_chunk='%d' (pages %d-%d)", nextPageStart, pagesPerChunk, nextPageStart, nextPageEnd))
+		nextActions = append(nextActions, fmt.Sprintf("Read next pages: eli_get_act_text with page='%d' and pages_per_chunk='%d'%s (pages %d-%d)", nextPageStart, pagesPerChunk, columnsArg, nextPageStart, nextPageEnd))
 	}
 	if startPage > 1 {
 		prevPageStart := startPage - pagesPerChunk
 		if prevPageStart < 1 {
 			prevPageStart = 1
 		}
-		nextActions = append(nextActions, fmt.Sprintf("Read previous pages: eli_get_act_text with page='%d' and pages_per_chunk='%d'", prevPageStart, pagesPerChunk))
+		nextActions = append(nextActions, fmt.Sprintf("Read previous pages: eli_get_act_text with page='%d' and pages_per_chunk='%d'%s", prevPageStart, pagesPerChunk, columnsArg))
 	}
 	nextActions = append(nextActions, "Get page information: eli_get_act_text with show_page_info='true'")
 	nextActions = append(nextActions, "Read full document: eli_get_act_text without pagination parameters")
 
 	response := StandardResponse{
-		Operation: "Legal Act Text (Paginated)",
-		Status:    "Retrieved Successfully",
-		Summary:   summary,
+		Operation:   "Legal Act Text (Paginated)",
+		Status:      coverage.status("Retrieved Successfully"),
+		Unavailable: coverage.unavailable(),
+		Summary:     summary,
 		Data: []string{
 			fmt.Sprintf("=== LEGAL ACT TEXT - PAGES %d-%d ===", startPage, endPage),
 			"",
@@ -2013,7 +2711,7 @@ func (s *SejmServer) extractTextWithPagination(ctx context.Context, pdfData []by
 		Note:        fmt.Sprintf("Showing pages %d-%d of %d. Use pagination parameters to navigate through the document efficiently and avoid large token responses.", startPage, endPage, pageCount),
 	}
 
-	return mcp.NewToolResultText(response.Format()), nil
+	return coverage.result(response.Format()), nil
 }
 
 // getLastUpdateDate extracts the most recent update information from an act
@@ -2030,24 +2728,26 @@ func getLastUpdateDate(act eli.Act) string {
 	return "Unknown"
 }
 
-// searchPDFContent is a generic function to search within PDF documents and return page locations
-func (s *SejmServer) searchPDFContent(ctx context.Context, pdfData []byte, documentName, searchTerms string, contextCharsInt, maxMatchesInt int) (*mcp.CallToolResult, error) {
-	s.logger.Info("Starting PDF content search",
-		slog.String("document", documentName),
-		slog.String("searchTerms", searchTerms),
-		slog.Int("contextChars", contextCharsInt),
-		slog.Int("maxMatches", maxMatchesInt),
-		slog.Int("pdfBytes", len(pdfData)))
+// extractPDFPageTexts returns the text of every PDF page; pages that fail to extract are left empty
+func (s *SejmServer) extractPDFPageTexts(ctx context.Context, pdfData []byte) ([]string, error) {
+	pageTexts, _, err := s.extractPDFPageTextsWithFailures(ctx, pdfData)
+	return pageTexts, err
+}
 
+// extractPDFPageTextsWithFailures returns the text of every PDF page and the pages that failed to extract,
+// which are left empty. Only a document that cannot be opened at all fails the extraction.
+func (s *SejmServer) extractPDFPageTextsWithFailures(ctx context.Context, pdfData []byte) (pageTexts []string, failures []pdfPageFailure, err error) {
+	_, span := s.startSpan(ctx, "pdf extract pages", attribute.Int("pdf.bytes", len(pdfData)))
+	defer func() {
+		span.SetAttributes(attribute.Int("pdf.pages", len(pageTexts)), attribute.Int("pdf.failed_pages", len(failures)))
+		endSpan(span, err)
+	}()
 	if len(pdfData) == 0 {
-		return mcp.NewToolResultError("PDF data is empty"), nil
+		return nil, nil, fmt.Errorf("PDF data is empty")
 	}
-
-	// Parse PDF and extract text page by page
 	doc, err := fitz.NewFromMemory(pdfData)
 	if err != nil {
-		s.logger.Error("Failed to parse PDF for content search", slog.Any("error", err))
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse PDF document: %v", err)), nil
+		return nil, nil, fmt.Errorf("failed to parse PDF document: %w", err)
 	}
 	defer func() {
 		if err := doc.Close(); err != nil {
@@ -2056,115 +2756,62 @@ func (s *SejmServer) searchPDFContent(ctx context.Context, pdfData []byte, docum
 	}()
 
 	pageCount := doc.NumPage()
-	s.logger.Info("PDF parsed for content search", slog.Int("totalPages", pageCount))
-
 	if pageCount == 0 {
-		return mcp.NewToolResultError("PDF document has no pages to search"), nil
+		return nil, nil, fmt.Errorf("PDF document has no pages")
 	}
-
-	// Split and clean search terms
-	terms := strings.Split(searchTerms, ",")
-	var cleanTerms []string
-	for _, term := range terms {
-		cleaned := strings.TrimSpace(term)
-		if cleaned != "" {
-			cleanTerms = append(cleanTerms, cleaned)
+	pages := pageRange(0, pageCount)
+	pageTexts, errs := s.extractPDFPages(ctx, doc, pdfData, pages, pdfPageText)
+	for pageNum, err := range errs {
+		if err != nil {
+			s.logger.Warn("Failed to extract text from page", slog.Int("page", pageNum+1), slog.Any("error", err))
+			pageTexts[pageNum] = ""
 		}
 	}
+	return pageTexts, pdfPageFailures(pages, errs), nil
+}
 
-	if len(cleanTerms) == 0 {
-		return mcp.NewToolResultError("No valid search terms found. Please provide comma-separated terms to search for."), nil
-	}
+// searchPDFContent is a generic function to search within PDF documents and return page locations
+func (s *SejmServer) searchPDFContent(ctx context.Context, pdfData []byte, documentName, searchTerms string, options textSearchOptions, contextCharsInt, maxMatchesInt int) (*mcp.CallToolResult, error) {
+	s.logger.Info("Starting PDF content search",
+		slog.String("document", documentName),
+		slog.String("searchTerms", searchTerms),
+		slog.Int("contextChars", contextCharsInt),
+		slog.Int("maxMatches", maxMatchesInt),
+		slog.Int("pdfBytes", len(pdfData)))
 
-	// Search results structure
-	type SearchMatch struct {
-		Term     string
-		Page     int
-		Context  string
-		Position int // character position within page
+	pageTexts, failures, err := s.extractPDFPageTextsWithFailures(ctx, pdfData)
+	if err != nil {
+		s.logger.Error("Failed to extract PDF pages for content search", slog.Any("error", err))
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to search PDF content: %v", err)), nil
 	}
+	s.logger.Info("PDF parsed for content search", slog.Int("totalPages", len(pageTexts)), slog.Int("failedPages", len(failures)))
 
-	termMatches := make(map[string][]SearchMatch)
-	totalMatches := 0
-
-	// Search each page
-	for pageNum := 0; pageNum < pageCount; pageNum++ {
-		pageText, err := doc.Text(pageNum)
-		if err != nil {
-			s.logger.Warn("Failed to extract text from page for search",
-				slog.Int("page", pageNum+1), slog.Any("error", err))
-			continue
-		}
-
-		pageTextLower := strings.ToLower(pageText)
-
-		// Search for each term on this page
-		for _, term := range cleanTerms {
-			termLower := strings.ToLower(term)
-
-			// Skip if we already have enough matches for this term
-			if len(termMatches[term]) >= maxMatchesInt {
-				continue
-			}
-
-			// Find all occurrences of this term on this page
-			startPos := 0
-			for {
-				pos := strings.Index(pageTextLower[startPos:], termLower)
-				if pos == -1 {
-					break
-				}
-
-				actualPos := startPos + pos
-
-				// Extract context around the match
-				contextStart := actualPos - contextCharsInt/2
-				if contextStart < 0 {
-					contextStart = 0
-				}
-				contextEnd := actualPos + len(term) + contextCharsInt/2
-				if contextEnd > len(pageText) {
-					contextEnd = len(pageText)
-				}
-
-				context := pageText[contextStart:contextEnd]
-				// Highlight the found term in context
-				context = strings.ReplaceAll(context, pageText[actualPos:actualPos+len(term)],
-					fmt.Sprintf("**%s**", pageText[actualPos:actualPos+len(term)]))
-
-				// Clean up context (remove excessive whitespace)
-				context = strings.ReplaceAll(context, "\n", " ")
-				context = strings.ReplaceAll(context, "\t", " ")
-				for strings.Contains(context, "  ") {
-					context = strings.ReplaceAll(context, "  ", " ")
-				}
-				context = strings.TrimSpace(context)
-
-				match := SearchMatch{
-					Term:     term,
-					Page:     pageNum + 1, // Convert to 1-based
-					Context:  context,
-					Position: actualPos,
-				}
+	result, err := s.searchPageTexts("PDF Content Search", pageTexts, documentName, searchTerms, options, contextCharsInt, maxMatchesInt)
+	if err == nil && !result.IsError && len(failures) > 0 {
+		prependResultNote(result, fmt.Sprintf("Note: %d of %d pages could not be read, so matches on them are missing: %s.", len(failures), len(pageTexts), describePDFPageFailures(failures)))
+	}
+	return result, err
+}
 
-				termMatches[term] = append(termMatches[term], match)
-				totalMatches++
+// searchPageTexts searches already extracted page texts and reports matches with their page numbers
+func (s *SejmServer) searchPageTexts(operation string, pageTexts []string, documentName, searchTerms string, options textSearchOptions, contextCharsInt, maxMatchesInt int) (*mcp.CallToolResult, error) {
+	pageCount := len(pageTexts)
 
-				// Check if we have enough matches for this term
-				if len(termMatches[term]) >= maxMatchesInt {
-					break
-				}
+	cleanTerms := splitSearchTerms(searchTerms, options)
+	if len(cleanTerms) == 0 {
+		return mcp.NewToolResultError("No valid search terms found. Please provide comma-separated terms to search for."), nil
+	}
 
-				// Move past this match to find next occurrence
-				startPos = actualPos + len(term)
-			}
-		}
+	termMatches, totalMatches, err := searchTermsInPages(pageTexts, cleanTerms, options, contextCharsInt, maxMatchesInt)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid search terms: %v.", err)), nil
 	}
 
 	// Build response
 	var summary []string
 	summary = append(summary, fmt.Sprintf("Document searched: %s", documentName))
 	summary = append(summary, fmt.Sprintf("Search terms: %d (%s)", len(cleanTerms), strings.Join(cleanTerms, ", ")))
+	summary = append(summary, fmt.Sprintf("Matching: %s", options.describe()))
 	summary = append(summary, fmt.Sprintf("Total pages searched: %d", pageCount))
 	summary = append(summary, fmt.Sprintf("Total matches found: %d", totalMatches))
 
@@ -2195,7 +2842,7 @@ func (s *SejmServer) searchPDFContent(ctx context.Context, pdfData []byte, docum
 				data = append(data, fmt.Sprintf("🔍 '%s' - %d matches:", term, len(matches)))
 
 				// Group by page for cleaner display
-				pageGroups := make(map[int][]SearchMatch)
+				pageGroups := make(map[int][]textSearchMatch)
 				for _, match := range matches {
 					pageGroups[match.Page] = append(pageGroups[match.Page], match)
 				}
@@ -2244,7 +2891,7 @@ func (s *SejmServer) searchPDFContent(ctx context.Context, pdfData []byte, docum
 	}
 
 	response := StandardResponse{
-		Operation:   "PDF Content Search",
+		Operation:   operation,
 		Status:      "Search Completed Successfully",
 		Summary:     summary,
 		Data:        data,
@@ -2259,14 +2906,14 @@ func (s *SejmServer) handleGetKeywords(ctx context.Context, request mcp.CallTool
 	s.logger.Info("eli_get_keywords called", slog.Any("arguments", request.Params.Arguments))
 
 	// Fetch keywords from ELI API
-	endpoint := "https://api.sejm.gov.pl/eli/keywords"
+	endpoint := s.eliBaseURL + "/keywords"
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve keywords: %v", err)), nil
 	}
 
 	var keywords []string
-	if err := json.Unmarshal(data, &keywords); err != nil {
+	if err := s.decodeAPIResponse(data, &keywords); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse keywords: %v", err)), nil
 	}
 
@@ -2439,7 +3086,7 @@ func (s *SejmServer) handleListActs(ctx context.Context, request mcp.CallToolReq
 		params["offset"] = offset
 	}
 
-	endpoint := "https://api.sejm.gov.pl/eli/acts/search"
+	endpoint := s.eliBaseURL + "/acts/search"
 	data, err := s.makeAPIRequest(ctx, endpoint, params)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve acts listing: %v", err)), nil
@@ -2449,7 +3096,7 @@ func (s *SejmServer) handleListActs(ctx context.Context, request mcp.CallToolReq
 		Items []eli.Act `json:"items"`
 		Count int       `json:"count"`
 	}
-	if err := json.Unmarshal(data, &searchResult); err != nil {
+	if err := s.decodeAPIResponse(data, &searchResult); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse acts data: %v", err)), nil
 	}
 
@@ -2526,7 +3173,7 @@ func (s *SejmServer) handleGetActsByPublisher(ctx context.Context, request mcp.C
 		params["offset"] = offset
 	}
 
-	endpoint := "https://api.sejm.gov.pl/eli/acts/search"
+	endpoint := s.eliBaseURL + "/acts/search"
 	data, err := s.makeAPIRequest(ctx, endpoint, params)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve acts by publisher '%s': %v", publisher, err)), nil
@@ -2536,7 +3183,7 @@ func (s *SejmServer) handleGetActsByPublisher(ctx context.Context, request mcp.C
 		Items []eli.Act `json:"items"`
 		Count int       `json:"count"`
 	}
-	if err := json.Unmarshal(data, &searchResult); err != nil {
+	if err := s.decodeAPIResponse(data, &searchResult); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse acts data: %v", err)), nil
 	}
 
@@ -2638,14 +3285,14 @@ func (s *SejmServer) handleGetActsByYear(ctx context.Context, request mcp.CallTo
 		params["offset"] = offset
 	}
 
-	endpoint := fmt.Sprintf("https://api.sejm.gov.pl/eli/acts/%s/%s", publisher, year)
+	endpoint := fmt.Sprintf("%s/acts/%s/%s", s.eliBaseURL, publisher, year)
 	data, err := s.makeAPIRequestWithHeaders(ctx, endpoint, params, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve acts for %s/%s: %v", publisher, year, err)), nil
 	}
 
 	var actsResponse eli.Acts
-	if err := json.Unmarshal(data, &actsResponse); err != nil {
+	if err := s.decodeAPIResponse(data, &actsResponse); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse acts data: %v", err)), nil
 	}
 
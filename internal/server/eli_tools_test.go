@@ -41,7 +41,7 @@ func TestExtractTextFromPDF(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result, err := server.extractTextFromPDF(tc.pdfData)
+			result, err := server.extractTextFromPDF(context.Background(), tc.pdfData)
 
 			if tc.expectError {
 				if err == nil {
@@ -483,19 +483,19 @@ func BenchmarkExtractTextFromPDF(b *testing.B) {
 
 	b.Run("InvalidPDF", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
-			_, _ = server.extractTextFromPDF(invalidPDF)
+			_, _ = server.extractTextFromPDF(context.Background(), invalidPDF)
 		}
 	})
 
 	b.Run("EmptyPDF", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
-			_, _ = server.extractTextFromPDF([]byte{})
+			_, _ = server.extractTextFromPDF(context.Background(), []byte{})
 		}
 	})
 
 	b.Run("NilPDF", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
-			_, _ = server.extractTextFromPDF(nil)
+			_, _ = server.extractTextFromPDF(context.Background(), nil)
 		}
 	})
 }
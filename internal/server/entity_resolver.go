@@ -0,0 +1,354 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxDisambiguationEntries is the number of candidates listed when a name is ambiguous
+const maxDisambiguationEntries = 10
+
+// committeeCodePattern matches committee and subcommittee codes such as 'ASW' or 'ASW01N'
+var committeeCodePattern = regexp.MustCompile(`^[A-Z0-9]{2,12}$`)
+
+// entityParams maps the parameters that identify an entity to its kind, in every tool
+var entityParams = map[string]string{
+	"mp_id":          "mp",
+	"mp_ids":         "mp",
+	"committee_code": "committee",
+	"club":           "club",
+	"club_id":        "club",
+	"recipient":      "ministry",
+}
+
+// toolEntityParams maps parameters whose meaning differs between tools, e.g. 'from' is an MP in
+// sejm_get_written_questions but a date in eli_get_upcoming_entries
+var toolEntityParams = map[string]map[string]string{
+	"sejm_get_written_questions": {"from": "mp", "to": "ministry"},
+	"sejm_get_videos":            {"committee": "committee"},
+}
+
+// entityParamDescriptions are appended to the description of entity parameters
+var entityParamDescriptions = map[string]string{
+	"mp":        "An MP name (e.g. 'Jan Kowalski', or 'Kowalski KO' with the club) is also accepted and resolved to the ID.",
+	"committee": "A committee name (e.g. 'Komisja Zdrowia' or just 'zdrowia') is also accepted and resolved to the code.",
+	"club":      "A club name (e.g. 'Prawo i Sprawiedliwość') is also accepted and resolved to the club ID.",
+	"ministry":  "A ministry name or abbreviation (e.g. 'Ministerstwo Zdrowia', 'MSWiA') is also accepted and resolved to the recipient name used by the API.",
+}
+
+// ministryRecipients are the recipients of interpellations and written questions as the API names them, with
+// the ministry names and abbreviations they are looked up by. The API has no directory of recipients.
+var ministryRecipients = []completionCandidate{
+	{value: "prezes Rady Ministrów", label: "Premier Kancelaria Prezesa Rady Ministrów KPRM"},
+	{value: "minister aktywów państwowych", label: "Ministerstwo Aktywów Państwowych MAP"},
+	{value: "minister cyfryzacji", label: "Ministerstwo Cyfryzacji MC"},
+	{value: "minister do spraw Unii Europejskiej", label: "Ministerstwo do spraw Unii Europejskiej MdsUE"},
+	{value: "minister edukacji", label: "Ministerstwo Edukacji Narodowej MEN"},
+	{value: "minister edukacji i nauki", label: "Ministerstwo Edukacji i Nauki MEiN"},
+	{value: "minister energii", label: "Ministerstwo Energii ME"},
+	{value: "minister finansów", label: "Ministerstwo Finansów MF"},
+	{value: "minister funduszy i polityki regionalnej", label: "Ministerstwo Funduszy i Polityki Regionalnej MFiPR"},
+	{value: "minister infrastruktury", label: "Ministerstwo Infrastruktury MI"},
+	{value: "minister klimatu i środowiska", label: "Ministerstwo Klimatu i Środowiska MKiŚ"},
+	{value: "minister kultury i dziedzictwa narodowego", label: "Ministerstwo Kultury i Dziedzictwa Narodowego MKiDN"},
+	{value: "minister nauki i szkolnictwa wyższego", label: "Ministerstwo Nauki i Szkolnictwa Wyższego MNiSW"},
+	{value: "minister obrony narodowej", label: "Ministerstwo Obrony Narodowej MON"},
+	{value: "minister przemysłu", label: "Ministerstwo Przemysłu MP"},
+	{value: "minister rodziny, pracy i polityki społecznej", label: "Ministerstwo Rodziny Pracy i Polityki Społecznej MRPiPS"},
+	{value: "minister rolnictwa i rozwoju wsi", label: "Ministerstwo Rolnictwa i Rozwoju Wsi MRiRW"},
+	{value: "minister rozwoju i technologii", label: "Ministerstwo Rozwoju i Technologii MRiT"},
+	{value: "minister sportu i turystyki", label: "Ministerstwo Sportu i Turystyki MSiT"},
+	{value: "minister sprawiedliwości", label: "Ministerstwo Sprawiedliwości MS"},
+	{value: "minister spraw wewnętrznych i administracji", label: "Ministerstwo Spraw Wewnętrznych i Administracji MSWiA"},
+	{value: "minister spraw zagranicznych", label: "Ministerstwo Spraw Zagranicznych MSZ"},
+	{value: "minister zdrowia", label: "Ministerstwo Zdrowia MZ"},
+}
+
+// entityKind describes the entities that tool parameters identify by an ID or a code
+type entityKind struct {
+	noun   string
+	plural string
+	lookup string
+	// isID tells whether a value is already an identifier, so no lookup is needed; nil always looks it up
+	isID func(value string) bool
+	// candidates lists the entities with the labels they can be found by
+	candidates completionSource
+	// strict kinds reject names that match nothing; the others pass them on to the API unchanged
+	strict bool
+	// partial kinds are filtered by a part of the name, so a name matching several entities is passed on too
+	partial bool
+}
+
+// entityKinds returns the kinds of entities resolved from names, backed by the cached directory endpoints
+func (s *SejmServer) entityKinds() map[string]entityKind {
+	return map[string]entityKind{
+		"mp": {
+			noun: "MP", plural: "MPs", lookup: "sejm_get_mps",
+			isID: func(value string) bool {
+				return strings.IndexFunc(value, func(r rune) bool { return !unicode.IsDigit(r) }) < 0
+			},
+			candidates: s.mpCompletions,
+			strict:     true,
+		},
+		"committee": {
+			noun: "committee", plural: "committees", lookup: "sejm_get_committees",
+			isID:       committeeCodePattern.MatchString,
+			candidates: s.committeeCompletions,
+			strict:     true,
+		},
+		"club": {
+			noun: "club", plural: "clubs", lookup: "sejm_get_clubs",
+			candidates: s.clubCompletions,
+		},
+		"ministry": {
+			noun: "ministry", plural: "ministries", partial: true,
+			candidates: func(ctx context.Context, arguments map[string]string) ([]completionCandidate, error) {
+				return ministryRecipients, nil
+			},
+		},
+	}
+}
+
+func (s *SejmServer) mpCompletions(ctx context.Context, arguments map[string]string) ([]completionCandidate, error) {
+	term, err := s.validateTerm(arguments["term"])
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/MP", s.sejmBaseURL, term), nil)
+	if err != nil {
+		return nil, err
+	}
+	var mps []sejm.MP
+	if err := s.decodeAPIResponse(data, &mps); err != nil {
+		return nil, fmt.Errorf("failed to parse MPs: %w", err)
+	}
+	var candidates []completionCandidate
+	for _, mp := range mps {
+		if mp.Id == nil {
+			continue
+		}
+		label := stringValue(mp.FirstLastName)
+		if mp.Club != nil {
+			label += fmt.Sprintf(" (%s)", *mp.Club)
+		}
+		candidates = append(candidates, completionCandidate{value: fmt.Sprintf("%d", *mp.Id), label: label})
+	}
+	return candidates, nil
+}
+
+// entityKindOf returns the kind of entity a parameter of a tool identifies, "" for other parameters
+func entityKindOf(tool, param string) string {
+	if kind, ok := toolEntityParams[tool][param]; ok {
+		return kind
+	}
+	return entityParams[param]
+}
+
+// applyEntitySchema tells in the descriptions of entity parameters that names are accepted. MP IDs keep
+// accepting JSON integers.
+func applyEntitySchema(tool string, properties map[string]interface{}) {
+	for name, property := range properties {
+		kind := entityKindOf(tool, name)
+		schema, ok := property.(map[string]interface{})
+		if kind == "" || !ok {
+			continue
+		}
+		updated := make(map[string]interface{}, len(schema))
+		for k, v := range schema {
+			updated[k] = v
+		}
+		if description, ok := schema["description"].(string); ok {
+			updated["description"] = strings.TrimSpace(description + " " + entityParamDescriptions[kind])
+		}
+		if name == "mp_id" {
+			updated["type"] = []string{"integer", "string"}
+		}
+		properties[name] = updated
+	}
+}
+
+// entityTokens splits a name into lower-case tokens without Polish diacritics
+func entityTokens(text string) []string {
+	return strings.FieldsFunc(normalizePolish(text), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+}
+
+// tokenMatchScore rates how a query token matches a name token: 3 for the same token, 2 for a prefix
+// (e.g. an initial) and 1 for a typo, 0 for no match
+func tokenMatchScore(query, token string) int {
+	switch {
+	case query == token:
+		return 3
+	case strings.HasPrefix(token, query):
+		return 2
+	}
+	allowed := 0
+	if len(query) >= 5 {
+		allowed = 1
+	}
+	if len(query) >= 9 {
+		allowed = 2
+	}
+	if allowed > 0 && levenshteinDistance(query, token) <= allowed {
+		return 1
+	}
+	return 0
+}
+
+// matchEntities returns the candidates that match a name best. A value or label equal to the name wins;
+// otherwise every word of the name must match a word of the label or the value, and the candidates with the
+// highest score are kept.
+func matchEntities(candidates []completionCandidate, name string) []completionCandidate {
+	normalized := strings.Join(entityTokens(name), " ")
+	for _, candidate := range candidates {
+		if strings.EqualFold(candidate.value, strings.TrimSpace(name)) {
+			return []completionCandidate{candidate}
+		}
+	}
+	var exact []completionCandidate
+	for _, candidate := range candidates {
+		if normalized != "" && strings.Join(entityTokens(candidate.label), " ") == normalized {
+			exact = append(exact, candidate)
+		}
+	}
+	if len(exact) > 0 {
+		return exact
+	}
+
+	query := entityTokens(name)
+	if len(query) == 0 {
+		return nil
+	}
+	var best []completionCandidate
+	bestScore := 0
+	for _, candidate := range candidates {
+		tokens := entityTokens(candidate.label + " " + candidate.value)
+		score := 0
+		for _, q := range query {
+			tokenScore := 0
+			for _, token := range tokens {
+				tokenScore = max(tokenScore, tokenMatchScore(q, token))
+			}
+			if tokenScore == 0 {
+				score = 0
+				break
+			}
+			score += tokenScore
+		}
+		switch {
+		case score == 0 || score < bestScore:
+		case score > bestScore:
+			best, bestScore = []completionCandidate{candidate}, score
+		default:
+			best = append(best, candidate)
+		}
+	}
+	sort.SliceStable(best, func(i, j int) bool { return best[i].label < best[j].label })
+	return best
+}
+
+// describeCandidate renders a candidate as 'value (label)'
+func describeCandidate(candidate completionCandidate) string {
+	if candidate.label == "" {
+		return fmt.Sprintf("'%s'", candidate.value)
+	}
+	return fmt.Sprintf("'%s' (%s)", candidate.value, candidate.label)
+}
+
+// resolveEntityArguments replaces names in entity parameters with the IDs and codes the handlers expect, so
+// that 'mp_id=Jan Kowalski' works like 'mp_id=123'. It returns the resolutions made, for a note in the result,
+// and an error with a disambiguation list when a name matches several entities. Identifiers are passed on
+// without lookups. The original arguments map is left untouched.
+func (s *SejmServer) resolveEntityArguments(ctx context.Context, tool string, request mcp.CallToolRequest) (mcp.CallToolRequest, []string, error) {
+	args := request.GetArguments()
+	var params []string
+	for name, value := range args {
+		if text, ok := value.(string); ok && strings.TrimSpace(text) != "" && entityKindOf(tool, name) != "" {
+			params = append(params, name)
+		}
+	}
+	if len(params) == 0 {
+		return request, nil, nil
+	}
+	sort.Strings(params)
+
+	term := request.GetString("term", "")
+	termNumber, err := s.validateTerm(term)
+	if err != nil {
+		// The handler reports the invalid term
+		return request, nil, nil
+	}
+	kinds := s.entityKinds()
+	directories := make(map[string][]completionCandidate)
+
+	resolved := make(map[string]any, len(args))
+	for name, value := range args {
+		resolved[name] = value
+	}
+	var notes []string
+	for _, param := range params {
+		kind := kinds[entityKindOf(tool, param)]
+		values := []string{args[param].(string)}
+		if param == "mp_ids" {
+			values = strings.Split(values[0], ",")
+		}
+		for i, value := range values {
+			value = strings.TrimSpace(value)
+			values[i] = value
+			if value == "" || (kind.isID != nil && kind.isID(value)) {
+				continue
+			}
+			candidates, ok := directories[kind.noun]
+			if !ok {
+				candidates, err = kind.candidates(ctx, map[string]string{"term": term})
+				if err != nil {
+					if kind.strict {
+						return request, nil, fmt.Errorf("%s names cannot be looked up right now (%v); pass the ID from %s in '%s'", kind.noun, err, kind.lookup, param)
+					}
+					// The API receives the value as given
+					directories[kind.noun] = nil
+					continue
+				}
+				directories[kind.noun] = candidates
+			}
+
+			matches := matchEntities(candidates, value)
+			switch {
+			case len(matches) == 1:
+				values[i] = matches[0].value
+				if matches[0].value != value {
+					notes = append(notes, fmt.Sprintf("%s '%s' → %s", param, value, describeCandidate(matches[0])))
+				}
+			case len(matches) > 1 && !kind.partial:
+				listed := make([]string, 0, maxDisambiguationEntries)
+				for _, match := range matches[:min(len(matches), maxDisambiguationEntries)] {
+					listed = append(listed, describeCandidate(match))
+				}
+				if len(matches) > maxDisambiguationEntries {
+					listed = append(listed, fmt.Sprintf("and %d more", len(matches)-maxDisambiguationEntries))
+				}
+				return request, nil, fmt.Errorf("'%s' in '%s' matches %d %s in term %d: %s. Pass one of these values, or a more specific name", value, param, len(matches), kind.plural, termNumber, strings.Join(listed, "; "))
+			case kind.strict:
+				return request, nil, fmt.Errorf("no %s in term %d matches '%s' in '%s'. Check the spelling or look it up with %s", kind.noun, termNumber, value, param, kind.lookup)
+			}
+		}
+		resolved[param] = strings.Join(values, ",")
+	}
+
+	request.Params.Arguments = resolved
+	return request, notes, nil
+}
+
+// appendResolutionNote tells in a result which names were resolved to identifiers
+func appendResolutionNote(result *mcp.CallToolResult, notes []string) {
+	if result == nil || result.IsError || len(notes) == 0 {
+		return
+	}
+	result.Content = append(result.Content, mcp.NewTextContent("Resolved names: "+strings.Join(notes, "; ")))
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMatchEntities(t *testing.T) {
+	mps := []completionCandidate{
+		{value: "1", label: "Anna Nowak (KO)"},
+		{value: "2", label: "Anna Nowakowska (PiS)"},
+		{value: "3", label: "Jan Kowalski (KO)"},
+		{value: "4", label: "Jan Kowalski (PiS)"},
+		{value: "5", label: "Łukasz Żółw (Lewica)"},
+	}
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{"Nowak", "1"},
+		{"Nowakowska", "2"},
+		{"Kowalski", "3,4"},
+		{"kowalski pis", "4"},
+		{"Kowalsky Jan KO", "3"},
+		{"lukasz zolw", "5"},
+		{"J. Kowalski (KO)", "3"},
+		{"Wiśniewski", ""},
+	}
+	for _, tc := range testCases {
+		var values []string
+		for _, match := range matchEntities(mps, tc.name) {
+			values = append(values, match.value)
+		}
+		if got := strings.Join(values, ","); got != tc.expected {
+			t.Errorf("Expected %q to match %q, got %q", tc.name, tc.expected, got)
+		}
+	}
+
+	if matches := matchEntities(ministryRecipients, "MF"); len(matches) != 1 || matches[0].value != "minister finansów" {
+		t.Errorf("Expected an abbreviation to match its ministry, got %+v", matches)
+	}
+}
+
+func TestToolCallResolvesNames(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/MP": `[
+			{"id": 3, "firstLastName": "Jan Kowalski", "club": "KO"},
+			{"id": 4, "firstLastName": "Jan Kowalski", "club": "PiS"},
+			{"id": 5, "firstLastName": "Anna Nowak", "club": "KO"}
+		]`,
+		"/sejm/term10/MP/5":           `{"id": 5, "firstLastName": "Anna Nowak", "club": "KO"}`,
+		"/sejm/term10/committees":     `[{"code": "ZDR", "name": "Komisja Zdrowia"}, {"code": "ASW", "name": "Komisja Administracji i Spraw Wewnętrznych"}]`,
+		"/sejm/term10/committees/ZDR": `{"code": "ZDR", "name": "Komisja Zdrowia", "members": [{"id": 5, "lastFirstName": "Nowak Anna", "club": "KO"}]}`,
+	})
+	call := func(tool string, arguments map[string]interface{}) string {
+		encodedArguments, _ := json.Marshal(arguments)
+		message := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"` + tool + `","arguments":` + string(encodedArguments) + `}}`
+		encoded, err := json.Marshal(server.server.HandleMessage(context.Background(), json.RawMessage(message)))
+		if err != nil {
+			t.Fatalf("Failed to encode response: %v", err)
+		}
+		return string(encoded)
+	}
+
+	output := call("sejm_get_mp_details", map[string]interface{}{"mp_id": "Anna Nowak"})
+	if !strings.Contains(output, "Anna Nowak") || !strings.Contains(output, `Resolved names: mp_id 'Anna Nowak' → '5' (Anna Nowak (KO))`) {
+		t.Errorf("Expected the MP name to be resolved to ID 5, got: %s", output)
+	}
+
+	output = call("sejm_get_mp_details", map[string]interface{}{"mp_id": "Kowalski"})
+	for _, expected := range []string{"matches 2 MPs in term 10", "'3' (Jan Kowalski (KO))", "'4' (Jan Kowalski (PiS))"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in the disambiguation list, got: %s", expected, output)
+		}
+	}
+
+	output = call("sejm_get_committee_members", map[string]interface{}{"committee_code": "komisja zdrowia"})
+	if !strings.Contains(output, "mp_id 5") || !strings.Contains(output, "committee_code 'komisja zdrowia' → 'ZDR'") {
+		t.Errorf("Expected the committee name to be resolved to ZDR, got: %s", output)
+	}
+
+	if output := call("sejm_get_committee_members", map[string]interface{}{"committee_code": "komisja rybołówstwa"}); !strings.Contains(output, "no committee in term 10 matches") {
+		t.Errorf("Expected an unknown committee to be rejected, got: %s", output)
+	}
+}
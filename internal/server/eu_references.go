@@ -0,0 +1,354 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const eurLexCELEXURL = "https://eur-lex.europa.eu/legal-content/PL/TXT/?uri=CELEX:"
+
+// EU act kinds with their CELEX document type letters (sector 3, secondary legislation)
+var euActKinds = map[string]string{
+	"directive":  "L",
+	"regulation": "R",
+	"decision":   "D",
+}
+
+var (
+	// celexPattern matches CELEX numbers of directives, regulations and decisions, e.g. 32016R0679
+	celexPattern = regexp.MustCompile(`\b3(\d{4})([LRD])(\d{4})\b`)
+	// euSuffixCitationPattern matches year/number/institution citations, e.g. 95/46/WE or 2006/123/WE
+	euSuffixCitationPattern = regexp.MustCompile(`\b(\d{2,4})/(\d{1,4})/(UE|WE|EWG|EWWiS|Euratom|EU|EC|EEC)\b`)
+	// euParenCitationPattern matches (institution) [nr] a/b citations, e.g. (UE) 2016/679 or (WE) nr 1907/2006
+	euParenCitationPattern = regexp.MustCompile(`\((UE|WE|EWG|Euratom|EU|EC|EEC)(?:,\s*Euratom)?\)\s*(nr\.?|No\.?)?\s*(\d{1,4})/(\d{2,4})\b`)
+	// euKindPattern matches words naming the kind of EU act a nearby citation refers to
+	euKindPattern = regexp.MustCompile(`(?i)(dyrektyw|rozporządze|decyzj|directive|regulation|decision)`)
+	// htmlTagPattern strips markup before scanning HTML act texts
+	htmlTagPattern = regexp.MustCompile(`<[^>]+>`)
+)
+
+// euKindLookback is how far before a citation the act kind (directive, regulation, decision) is searched for
+const euKindLookback = 300
+
+// euReference is a single EU legal act referenced by a Polish act
+type euReference struct {
+	Kind     string
+	Year     int
+	Number   int
+	Citation string
+	Title    string
+	Sources  []string
+	Mentions int
+}
+
+// CELEX returns the CELEX identifier of the referenced act
+func (r euReference) CELEX() string {
+	return fmt.Sprintf("3%04d%s%04d", r.Year, euActKinds[r.Kind], r.Number)
+}
+
+// EURLexURL returns the EUR-Lex page of the referenced act
+func (r euReference) EURLexURL() string {
+	return eurLexCELEXURL + r.CELEX()
+}
+
+// euReferenceSet collects references, merging repeated mentions of the same act
+type euReferenceSet struct {
+	byCELEX map[string]*euReference
+}
+
+func newEUReferenceSet() *euReferenceSet {
+	return &euReferenceSet{byCELEX: make(map[string]*euReference)}
+}
+
+func (set *euReferenceSet) add(ref euReference, source string) {
+	key := ref.CELEX()
+	existing, ok := set.byCELEX[key]
+	if !ok {
+		ref.Sources = []string{source}
+		if source == "text" {
+			ref.Mentions = 1
+		}
+		set.byCELEX[key] = &ref
+		return
+	}
+	if existing.Title == "" {
+		existing.Title = ref.Title
+	}
+	if source == "text" {
+		existing.Mentions++
+	}
+	for _, s := range existing.Sources {
+		if s == source {
+			return
+		}
+	}
+	existing.Sources = append(existing.Sources, source)
+}
+
+// sorted returns references ordered by year and number
+func (set *euReferenceSet) sorted() []euReference {
+	refs := make([]euReference, 0, len(set.byCELEX))
+	for _, ref := range set.byCELEX {
+		refs = append(refs, *ref)
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Year != refs[j].Year {
+			return refs[i].Year < refs[j].Year
+		}
+		if refs[i].Number != refs[j].Number {
+			return refs[i].Number < refs[j].Number
+		}
+		return refs[i].Kind < refs[j].Kind
+	})
+	return refs
+}
+
+// normalizeEUYear expands two-digit years used in pre-1999 citations (e.g. 95/46/WE)
+func normalizeEUYear(year int) int {
+	switch {
+	case year >= 100:
+		return year
+	case year >= 50:
+		return 1900 + year
+	default:
+		return 2000 + year
+	}
+}
+
+// euKindBefore returns the act kind named closest before the given position in the text
+func euKindBefore(text string, position int) string {
+	start := position - euKindLookback
+	if start < 0 {
+		start = 0
+	}
+	matches := euKindPattern.FindAllString(text[start:position], -1)
+	if len(matches) == 0 {
+		return ""
+	}
+	switch strings.ToLower(matches[len(matches)-1]) {
+	case "dyrektyw", "directive":
+		return "directive"
+	case "rozporządze", "regulation":
+		return "regulation"
+	default:
+		return "decision"
+	}
+}
+
+// extractEUReferences finds EU directive, regulation and decision references in free text
+func extractEUReferences(text string) []euReference {
+	var refs []euReference
+
+	for _, match := range celexPattern.FindAllStringSubmatch(text, -1) {
+		year, _ := strconv.Atoi(match[1])
+		number, _ := strconv.Atoi(match[3])
+		for kind, letter := range euActKinds {
+			if letter == match[2] {
+				refs = append(refs, euReference{Kind: kind, Year: year, Number: number, Citation: match[0]})
+			}
+		}
+	}
+
+	// Year first: 95/46/WE, 2006/123/WE
+	for _, loc := range euSuffixCitationPattern.FindAllStringSubmatchIndex(text, -1) {
+		kind := euKindBefore(text, loc[0])
+		if kind == "" {
+			continue
+		}
+		year, _ := strconv.Atoi(text[loc[2]:loc[3]])
+		number, _ := strconv.Atoi(text[loc[4]:loc[5]])
+		refs = append(refs, euReference{Kind: kind, Year: normalizeEUYear(year), Number: number, Citation: text[loc[0]:loc[1]]})
+	}
+
+	// (UE) 2016/679 is year first, (WE) nr 1907/2006 and older regulations are number first
+	for _, loc := range euParenCitationPattern.FindAllStringSubmatchIndex(text, -1) {
+		kind := euKindBefore(text, loc[0])
+		if kind == "" {
+			continue
+		}
+		first, _ := strconv.Atoi(text[loc[6]:loc[7]])
+		second, _ := strconv.Atoi(text[loc[8]:loc[9]])
+		hasNumberMarker := loc[4] != -1
+
+		year, number := normalizeEUYear(second), first
+		if !hasNumberMarker && first >= 2015 && loc[7]-loc[6] == 4 {
+			year, number = first, second
+		}
+		refs = append(refs, euReference{Kind: kind, Year: year, Number: number, Citation: text[loc[0]:loc[1]]})
+	}
+
+	return refs
+}
+
+// euReferencesFromDirective extracts references from an ELI directive metadata entry
+func euReferencesFromDirective(directive eli.Directive) []euReference {
+	var parts []string
+	if directive.Address != nil {
+		parts = append(parts, *directive.Address)
+	}
+	if directive.Title != nil {
+		parts = append(parts, *directive.Title)
+	}
+	refs := extractEUReferences(strings.Join(parts, " "))
+	if directive.Title != nil {
+		for i := range refs {
+			refs[i].Title = *directive.Title
+		}
+	}
+	return refs
+}
+
+// htmlToPlainText strips tags and decodes entities of an HTML document
+func htmlToPlainText(content string) string {
+	return html.UnescapeString(htmlTagPattern.ReplaceAllString(content, " "))
+}
+
+func (s *SejmServer) handleGetEUReferences(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("eli_get_eu_references called", slog.Any("arguments", request.Params.Arguments))
+
+	publisher := request.GetString("publisher", "")
+	year := request.GetString("year", "")
+	position := request.GetString("position", "")
+	scanText := request.GetString("scan_text", "true")
+
+	if publisher == "" || year == "" || position == "" {
+		return mcp.NewToolResultError("All three parameters are required: publisher (e.g., 'DU'), year (e.g., '2018'), and position (e.g., '1000'). Get these from eli_search_acts results."), nil
+	}
+
+	endpoint := fmt.Sprintf("%s/acts/%s/%s/%s", s.eliBaseURL, publisher, year, position)
+	apiData, err := s.makeAPIRequest(ctx, endpoint, nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve legal act details from ELI database: %v. Please verify the legal act coordinates: publisher=%s, year=%s, position=%s.", err, publisher, year, position)), nil
+	}
+
+	var act eli.Act
+	if err := s.decodeAPIResponse(apiData, &act); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse legal act data from ELI API response: %v.", err)), nil
+	}
+
+	references := newEUReferenceSet()
+	var unparsedDirectives []string
+
+	if act.Directives != nil {
+		for _, directive := range *act.Directives {
+			refs := euReferencesFromDirective(directive)
+			if len(refs) == 0 {
+				if directive.Title != nil {
+					unparsedDirectives = append(unparsedDirectives, *directive.Title)
+				} else if directive.Address != nil {
+					unparsedDirectives = append(unparsedDirectives, *directive.Address)
+				}
+				continue
+			}
+			for _, ref := range refs {
+				references.add(ref, "metadata")
+			}
+		}
+	}
+
+	textStatus := "Act text not scanned (scan_text='false')"
+	if scanText != "false" {
+		text, source, err := s.fetchActPlainText(ctx, act, publisher, year, position)
+		switch {
+		case err != nil:
+			textStatus = fmt.Sprintf("Act text could not be scanned: %v", err)
+		case text == "":
+			textStatus = "Act has no HTML or PDF text to scan"
+		default:
+			for _, ref := range extractEUReferences(text) {
+				references.add(ref, "text")
+			}
+			textStatus = fmt.Sprintf("Act text scanned (%s, %d characters)", source, len(text))
+		}
+	}
+
+	refs := references.sorted()
+	actID := fmt.Sprintf("%s/%s/%s", publisher, year, position)
+
+	summary := []string{fmt.Sprintf("Act: %s", actID)}
+	if act.Title != nil {
+		summary = append(summary, fmt.Sprintf("Title: %s", *act.Title))
+	}
+	directiveCount := 0
+	if act.Directives != nil {
+		directiveCount = len(*act.Directives)
+	}
+	summary = append(summary, fmt.Sprintf("Directives listed in ELI metadata: %d", directiveCount))
+	summary = append(summary, textStatus)
+	summary = append(summary, fmt.Sprintf("EU acts referenced: %d", len(refs)))
+
+	var data []string
+	for i, ref := range refs {
+		label := strings.ToUpper(ref.Kind[:1]) + ref.Kind[1:]
+		data = append(data, fmt.Sprintf("%d. %s %d/%d (cited as '%s')", i+1, label, ref.Year, ref.Number, ref.Citation))
+		data = append(data, fmt.Sprintf("   CELEX: %s", ref.CELEX()))
+		data = append(data, fmt.Sprintf("   EUR-Lex: %s", ref.EURLexURL()))
+		source := strings.Join(ref.Sources, ", ")
+		if ref.Mentions > 0 {
+			source += fmt.Sprintf(" (%d mentions in text)", ref.Mentions)
+		}
+		data = append(data, fmt.Sprintf("   Source: %s", source))
+		if ref.Title != "" {
+			data = append(data, fmt.Sprintf("   Title: %s", ref.Title))
+		}
+		data = append(data, "")
+	}
+	if len(unparsedDirectives) > 0 {
+		data = append(data, "Directives from metadata without a recognizable citation:")
+		for _, title := range unparsedDirectives {
+			data = append(data, fmt.Sprintf("• %s", title))
+		}
+	}
+
+	status := "Retrieved Successfully"
+	if len(refs) == 0 && len(unparsedDirectives) == 0 {
+		status = "No References Found"
+		data = append(data, "No EU directive, regulation or decision references were found for this act.")
+	}
+
+	response := StandardResponse{
+		Operation: fmt.Sprintf("EU Law References: %s", actID),
+		Status:    status,
+		Summary:   summary,
+		Data:      data,
+		NextActions: []string{
+			fmt.Sprintf("See transposition links between Polish acts: eli_get_act_references with publisher='%s', year='%s', position='%s'", publisher, year, position),
+			fmt.Sprintf("Find where an EU act is cited: eli_search_act_content with publisher='%s', year='%s', position='%s' and search_terms (e.g. '2016/679')", publisher, year, position),
+		},
+		Note: fmt.Sprintf("CELEX numbers are derived from citations in the act; verify them on EUR-Lex. Retrieved on %s.", time.Now().Format("2006-01-02 15:04:05 MST")),
+	}
+
+	return mcp.NewToolResultText(response.Format()), nil
+}
+
+// fetchActPlainText downloads an act's text as plain text, preferring HTML over PDF
+func (s *SejmServer) fetchActPlainText(ctx context.Context, act eli.Act, publisher, year, position string) (string, string, error) {
+	if act.TextHTML != nil && *act.TextHTML {
+		endpoint := fmt.Sprintf("%s/acts/%s/%s/%s/text.html", s.eliBaseURL, publisher, year, position)
+		data, err := s.makeTextRequest(ctx, endpoint, "html")
+		if err != nil {
+			return "", "", err
+		}
+		return htmlToPlainText(string(data)), "HTML", nil
+	}
+	if act.TextPDF != nil && *act.TextPDF {
+		endpoint := fmt.Sprintf("%s/acts/%s/%s/%s/text.pdf", s.eliBaseURL, publisher, year, position)
+		data, err := s.makeTextRequest(ctx, endpoint, "pdf")
+		if err != nil {
+			return "", "", err
+		}
+		text, err := s.extractTextFromPDF(ctx, data)
+		return text, "PDF", err
+	}
+	return "", "", nil
+}
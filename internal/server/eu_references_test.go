@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+)
+
+func TestExtractEUReferences(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{"modern regulation", "rozporządzenia Parlamentu Europejskiego i Rady (UE) 2016/679 z dnia 27 kwietnia 2016 r.", []string{"32016R0679"}},
+		{"old directive two-digit year", "uchyla się dyrektywę 95/46/WE (ogólne rozporządzenie o ochronie danych)", []string{"31995L0046"}},
+		{"regulation number first", "rozporządzenie (WE) nr 1907/2006 w sprawie REACH", []string{"32006R1907"}},
+		{"multiple directives", "wdrożenie dyrektywy 2004/38/WE oraz 2006/123/WE", []string{"32004L0038", "32006L0123"}},
+		{"decision", "decyzja Komisji (UE) 2019/1234", []string{"32019D1234"}},
+		{"celex number", "zob. CELEX 32019L1937", []string{"32019L1937"}},
+		{"no kind nearby", "sygnatura akt 12/2020/UE", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			set := newEUReferenceSet()
+			for _, ref := range extractEUReferences(tc.text) {
+				set.add(ref, "text")
+			}
+			var celex []string
+			for _, ref := range set.sorted() {
+				celex = append(celex, ref.CELEX())
+			}
+			if strings.Join(celex, ",") != strings.Join(tc.expected, ",") {
+				t.Errorf("Expected %v, got %v", tc.expected, celex)
+			}
+		})
+	}
+}
+
+func TestEUReferenceSetMergesSources(t *testing.T) {
+	title := "Rozporządzenie Parlamentu Europejskiego i Rady (UE) 2016/679"
+	set := newEUReferenceSet()
+	for _, ref := range euReferencesFromDirective(eli.Directive{Title: &title}) {
+		set.add(ref, "metadata")
+	}
+	for _, ref := range extractEUReferences("rozporządzenia (UE) 2016/679 oraz rozporządzenia 2016/679") {
+		set.add(ref, "text")
+	}
+
+	refs := set.sorted()
+	if len(refs) != 1 {
+		t.Fatalf("Expected one merged reference, got %d", len(refs))
+	}
+	if refs[0].Title != title || strings.Join(refs[0].Sources, ",") != "metadata,text" || refs[0].Mentions != 1 {
+		t.Errorf("Unexpected merged reference: %+v", refs[0])
+	}
+	if refs[0].EURLexURL() != "https://eur-lex.europa.eu/legal-content/PL/TXT/?uri=CELEX:32016R0679" {
+		t.Errorf("Unexpected EUR-Lex URL: %s", refs[0].EURLexURL())
+	}
+}
+
+func TestHandleGetEUReferences(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/DU/2018/1000": `{
+			"title": "Ustawa o ochronie danych osobowych",
+			"textHTML": true,
+			"directives": [{"address": "Dz.Urz. UE L 119", "title": "Dyrektywa Parlamentu Europejskiego i Rady (UE) 2016/680"}]
+		}`,
+		"/eli/acts/DU/2018/1000/text.html": `<html><body><p>stosowania rozporządzenia Parlamentu Europejskiego i Rady (UE) 2016/679&nbsp;z dnia 27 kwietnia 2016 r.</p></body></html>`,
+	})
+
+	result, err := server.handleGetEUReferences(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "2018", "position": "1000",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{"CELEX: 32016L0680", "CELEX: 32016R0679", "Source: metadata", "Source: text (1 mentions in text)", "EU acts referenced: 2"} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// serverVersion is the version reported by the health endpoints and sejm_ping
+	serverVersion = "1.0.0"
+	// upstreamDownThreshold is the number of consecutive failed requests after which an upstream is reported down
+	upstreamDownThreshold = 5
+	// maxListedUnavailableSources bounds the failed sources listed in a partial result
+	maxListedUnavailableSources = 10
+)
+
+// Upstream states reported by the health endpoints
+const (
+	upstreamHealthy  = "healthy"
+	upstreamDegraded = "degraded"
+	upstreamDown     = "down"
+)
+
+// upstreamStatus is the recent request record of one upstream API
+type upstreamStatus struct {
+	State               string    `json:"state"`
+	Requests            int64     `json:"requests"`
+	Failures            int64     `json:"failures"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	LastSuccess         time.Time `json:"lastSuccess"`
+	LastFailure         time.Time `json:"lastFailure"`
+	LastError           string    `json:"lastError,omitempty"`
+}
+
+// upstreamHealth tracks the outcome of requests to the Sejm and ELI APIs, so the health endpoints can tell a
+// server that works from one whose upstream is failing. Only transport errors, server errors and rate limiting
+// count as failures; a 404 means the upstream answered.
+type upstreamHealth struct {
+	mu        sync.Mutex
+	upstreams map[string]*upstreamStatus
+}
+
+func newUpstreamHealth() *upstreamHealth {
+	return &upstreamHealth{upstreams: make(map[string]*upstreamStatus)}
+}
+
+// upstreamName names the API an endpoint belongs to by the first segment of its path
+func upstreamName(endpoint string) string {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return "unknown"
+	}
+	for _, segment := range strings.Split(parsed.Path, "/") {
+		switch segment {
+		case "sejm", "eli":
+			return segment
+		}
+	}
+	return parsed.Host
+}
+
+// isUpstreamFailure tells whether a request outcome says the upstream is unhealthy; requests cancelled by the
+// caller say nothing about it
+func isUpstreamFailure(statusCode int, err error) bool {
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return statusCode >= http.StatusInternalServerError || statusCode == http.StatusTooManyRequests
+}
+
+// record stores the outcome of one request attempt; servers built without a tracker record nothing
+func (h *upstreamHealth) record(endpoint string, statusCode int, err error) {
+	if h == nil || err != nil && !isUpstreamFailure(statusCode, err) {
+		return
+	}
+	name := upstreamName(endpoint)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	status, ok := h.upstreams[name]
+	if !ok {
+		status = &upstreamStatus{}
+		h.upstreams[name] = status
+	}
+	status.Requests++
+	if !isUpstreamFailure(statusCode, err) {
+		status.ConsecutiveFailures = 0
+		status.LastSuccess = time.Now()
+		return
+	}
+	status.Failures++
+	status.ConsecutiveFailures++
+	status.LastFailure = time.Now()
+	if err != nil {
+		status.LastError = err.Error()
+	} else {
+		status.LastError = fmt.Sprintf("HTTP %d", statusCode)
+	}
+}
+
+// snapshot returns the state of every upstream contacted so far
+func (h *upstreamHealth) snapshot() map[string]upstreamStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	snapshot := make(map[string]upstreamStatus, len(h.upstreams))
+	for name, status := range h.upstreams {
+		copied := *status
+		switch {
+		case copied.ConsecutiveFailures >= upstreamDownThreshold:
+			copied.State = upstreamDown
+		case copied.ConsecutiveFailures > 0:
+			copied.State = upstreamDegraded
+		default:
+			copied.State = upstreamHealthy
+		}
+		snapshot[name] = copied
+	}
+	return snapshot
+}
+
+// overallState is "healthy" unless an upstream is failing, in which case the worst upstream state is returned
+func (h *upstreamHealth) overallState() string {
+	state := upstreamHealthy
+	for _, status := range h.snapshot() {
+		if status.State == upstreamDown {
+			return upstreamDown
+		}
+		if status.State == upstreamDegraded {
+			state = upstreamDegraded
+		}
+	}
+	return state
+}
+
+// healthResponse is the body of the /health endpoints
+func (s *SejmServer) healthResponse() map[string]interface{} {
+	response := map[string]interface{}{
+		"status":    s.health.overallState(),
+		"service":   "sejm-mcp",
+		"version":   serverVersion,
+		"upstreams": s.health.snapshot(),
+	}
+	if s.connections != nil {
+		response["connections"] = s.connections.snapshot()
+	}
+	return response
+}
+
+// sourceCoverage counts the upstream sources a fan-out tool combines, so a failed source is reported with the
+// results instead of silently shrinking them
+type sourceCoverage struct {
+	noun   string
+	total  int
+	failed []string
+}
+
+// newSourceCoverage starts counting sources of one kind, e.g. "sittings"
+func newSourceCoverage(noun string) *sourceCoverage {
+	return &sourceCoverage{noun: noun}
+}
+
+// succeeded records a source that was retrieved
+func (c *sourceCoverage) succeeded() {
+	c.total++
+}
+
+// fail records a source that could not be retrieved or parsed
+func (c *sourceCoverage) fail(label string, err error) {
+	c.total++
+	c.failed = append(c.failed, fmt.Sprintf("%s: %v", label, err))
+}
+
+// partial tells whether any source failed
+func (c *sourceCoverage) partial() bool {
+	return len(c.failed) > 0
+}
+
+// headline states how many sources failed, e.g. "2 of 14 sittings unavailable"
+func (c *sourceCoverage) headline() string {
+	return fmt.Sprintf("%d of %d %s unavailable", len(c.failed), c.total, c.noun)
+}
+
+// status returns the given status, or "Partially Retrieved" when a source failed
+func (c *sourceCoverage) status(complete string) string {
+	if c.partial() {
+		return "Partially Retrieved"
+	}
+	return complete
+}
+
+// result returns text as a tool result, marked partial when a source failed so it is not cached
+func (c *sourceCoverage) result(text string) *mcp.CallToolResult {
+	result := mcp.NewToolResultText(text)
+	if c.partial() {
+		markPartial(result)
+	}
+	return result
+}
+
+// unavailable lists the failed sources for StandardResponse.Unavailable; it is empty when nothing failed
+func (c *sourceCoverage) unavailable() []string {
+	if !c.partial() {
+		return nil
+	}
+	lines := []string{c.headline() + "; the results below are partial and may be missing matches from them"}
+	for i, source := range c.failed {
+		if i == maxListedUnavailableSources {
+			lines = append(lines, fmt.Sprintf("... and %d more", len(c.failed)-maxListedUnavailableSources))
+			break
+		}
+		lines = append(lines, source)
+	}
+	return lines
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUpstreamName(t *testing.T) {
+	testCases := map[string]string{
+		"https://api.sejm.gov.pl/sejm/term10/MP":         "sejm",
+		"https://api.sejm.gov.pl/eli/acts/DU/2024/1":     "eli",
+		"http://127.0.0.1:8080/other/path":               "127.0.0.1:8080",
+		"https://api.sejm.gov.pl/sejm/term10/votings/12": "sejm",
+	}
+	for endpoint, expected := range testCases {
+		if name := upstreamName(endpoint); name != expected {
+			t.Errorf("upstreamName(%q) = %q, expected %q", endpoint, name, expected)
+		}
+	}
+}
+
+func TestUpstreamHealth(t *testing.T) {
+	health := newUpstreamHealth()
+	if state := health.overallState(); state != upstreamHealthy {
+		t.Errorf("Expected a fresh tracker to be healthy, got %s", state)
+	}
+
+	sejmURL := "https://api.sejm.gov.pl/sejm/term10/MP"
+	eliURL := "https://api.sejm.gov.pl/eli/acts/DU/2024/1"
+	health.record(sejmURL, http.StatusOK, nil)
+	health.record(eliURL, http.StatusNotFound, nil)
+	health.record(eliURL, 0, context.Canceled)
+	if state := health.overallState(); state != upstreamHealthy {
+		t.Errorf("Expected 404 and cancelled requests not to degrade health, got %s", state)
+	}
+	if eli := health.snapshot()["eli"]; eli.Requests != 1 {
+		t.Errorf("Expected cancelled requests not to be counted, got %d requests", eli.Requests)
+	}
+
+	health.record(sejmURL, http.StatusInternalServerError, nil)
+	if sejm := health.snapshot()["sejm"]; sejm.State != upstreamDegraded || sejm.LastError != "HTTP 500" || sejm.Failures != 1 {
+		t.Errorf("Expected sejm to be degraded after a server error, got %+v", sejm)
+	}
+	if state := health.overallState(); state != upstreamDegraded {
+		t.Errorf("Expected degraded overall state, got %s", state)
+	}
+
+	for i := 0; i < upstreamDownThreshold; i++ {
+		health.record(sejmURL, 0, errors.New("connection refused"))
+	}
+	if state := health.overallState(); state != upstreamDown {
+		t.Errorf("Expected down after %d consecutive failures, got %s", upstreamDownThreshold, state)
+	}
+
+	health.record(sejmURL, http.StatusOK, nil)
+	if sejm := health.snapshot()["sejm"]; sejm.State != upstreamHealthy || sejm.ConsecutiveFailures != 0 || sejm.Failures != 6 {
+		t.Errorf("Expected a success to restore health and keep the failure count, got %+v", sejm)
+	}
+
+	var untracked *upstreamHealth
+	untracked.record(sejmURL, http.StatusOK, nil)
+}
+
+func TestHealthTrackedByRequests(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{"/sejm/term10/MP/1": `{"id": 1}`})
+	if _, err := server.makeAPIRequest(context.Background(), server.sejmBaseURL+"/sejm/term10/MP/1", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	response := server.healthResponse()
+	if response["status"] != upstreamHealthy {
+		t.Errorf("Expected healthy status, got %v", response["status"])
+	}
+	upstreams := response["upstreams"].(map[string]upstreamStatus)
+	if upstreams["sejm"].Requests != 1 {
+		t.Errorf("Expected one recorded sejm request, got %+v", upstreams)
+	}
+}
+
+func TestSourceCoverage(t *testing.T) {
+	coverage := newSourceCoverage("sittings")
+	coverage.succeeded()
+	if coverage.partial() || coverage.unavailable() != nil || coverage.status("Retrieved Successfully") != "Retrieved Successfully" {
+		t.Errorf("Expected complete coverage, got %+v", coverage)
+	}
+	if result := coverage.result("complete"); result.Meta != nil {
+		t.Errorf("Expected a complete result without _meta, got %+v", result.Meta)
+	}
+
+	for i := 1; i <= maxListedUnavailableSources+2; i++ {
+		coverage.fail(fmt.Sprintf("sitting %d", i), errors.New("server error (500)"))
+	}
+	if status := coverage.status("Retrieved Successfully"); status != "Partially Retrieved" {
+		t.Errorf("Expected partial status, got %s", status)
+	}
+	if result := coverage.result("partial"); result.Meta == nil || result.Meta.AdditionalFields[partialResultMeta] != true {
+		t.Errorf("Expected the result to be marked partial, got %+v", result.Meta)
+	}
+	lines := coverage.unavailable()
+	if len(lines) != maxListedUnavailableSources+2 {
+		t.Fatalf("Expected headline, %d sources and an overflow line, got %v", maxListedUnavailableSources, lines)
+	}
+	if !strings.HasPrefix(lines[0], "12 of 13 sittings unavailable") {
+		t.Errorf("Unexpected headline: %s", lines[0])
+	}
+	if lines[1] != "sitting 1: server error (500)" || lines[len(lines)-1] != "... and 2 more" {
+		t.Errorf("Unexpected source lines: %v", lines)
+	}
+
+	formatted := localizeText(StandardResponse{Operation: "Daily Digest", Status: coverage.status("Retrieved Successfully"), Unavailable: lines}.Format(), LanguagePolish)
+	if !strings.Contains(formatted, "Niedostępne źródła:\n• 12 of 13 sittings unavailable") {
+		t.Errorf("Expected a localized unavailable sources section, got: %s", formatted)
+	}
+}
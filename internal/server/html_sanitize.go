@@ -0,0 +1,143 @@
+package server
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// sanitizeParamDescription documents the sanitize parameter of the tools returning HTML bodies
+const sanitizeParamDescription = "Optional. HTML bodies are sanitized by default: scripts, styles, images, forms and embedded frames are removed, only structural tags (paragraphs, headings, lists, tables, emphasis, links) are kept and every attribute except link targets and table spans is dropped. Set to 'false' for the body exactly as served by the API."
+
+var (
+	// htmlSpanAttributePattern captures the colspan and rowspan attributes of a table cell
+	htmlSpanAttributePattern = regexp.MustCompile(`(?i)\b(colspan|rowspan)\s*=\s*["']?(\d+)`)
+	// htmlURLSchemePattern captures the scheme of an absolute URL
+	htmlURLSchemePattern = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9+.\-]*):`)
+	// htmlBlankRunPattern matches whitespace between tags that spans several lines
+	htmlBlankRunPattern = regexp.MustCompile(`^\s*\n\s*$`)
+)
+
+// sanitizedElements are the tags kept by sanitizeHTML. Other tags are removed and their text is kept.
+var sanitizedElements = map[string]bool{
+	"p": true, "br": true, "hr": true, "div": true, "blockquote": true, "pre": true, "center": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "li": true, "dl": true, "dt": true, "dd": true,
+	"table": true, "caption": true, "thead": true, "tbody": true, "tfoot": true, "tr": true, "td": true, "th": true,
+	"b": true, "strong": true, "i": true, "em": true, "u": true, "sub": true, "sup": true, "a": true,
+}
+
+// sanitizedDroppedElements are removed together with their content
+var sanitizedDroppedElements = map[string]bool{
+	"head": true, "title": true, "script": true, "style": true, "noscript": true, "template": true,
+	"iframe": true, "frame": true, "frameset": true, "object": true, "embed": true, "applet": true,
+	"svg": true, "math": true, "form": true, "select": true, "textarea": true, "button": true,
+}
+
+// sanitizedVoidElements have no closing tag
+var sanitizedVoidElements = map[string]bool{"br": true, "hr": true}
+
+// safeLinkTarget tells whether a link target may be kept: relative links and http, https and mailto URLs.
+// Whitespace and control characters are ignored, as browsers do, so "java\nscript:" is not taken for a path.
+func safeLinkTarget(href string) bool {
+	compact := strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, href)
+	match := htmlURLSchemePattern.FindStringSubmatch(compact)
+	if match == nil {
+		return true
+	}
+	switch strings.ToLower(match[1]) {
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+// sanitizeHTML reduces an HTML body served by the Sejm API to an allow-list of structural tags. Scripts,
+// styles, tracking images and other active or decorative markup are removed, as are all attributes except
+// safe link targets and table cell spans, and comments. Like htmlToMarkdown it works on the tag stream
+// rather than a parsed tree, so unbalanced input stays unbalanced.
+func sanitizeHTML(content string) string {
+	var out strings.Builder
+	writeSanitizedHTML(&out, content)
+	return strings.TrimSpace(out.String())
+}
+
+// writeSanitizedHTML writes the sanitized tag stream of content to out
+func writeSanitizedHTML(out *strings.Builder, content string) {
+	dropDepth := 0
+	for _, token := range htmlTokenPattern.FindAllString(content, -1) {
+		if token == "<" {
+			if dropDepth == 0 {
+				out.WriteString("&lt;")
+			}
+			continue
+		}
+		if !strings.HasPrefix(token, "<") {
+			if dropDepth > 0 {
+				continue
+			}
+			if htmlBlankRunPattern.MatchString(token) {
+				token = "\n"
+			}
+			out.WriteString(token)
+			continue
+		}
+		if strings.HasPrefix(token, "<!--") {
+			continue
+		}
+
+		match := htmlTagNamePattern.FindStringSubmatch(token)
+		if match == nil {
+			// Doctypes and processing instructions are dropped; any other '<' is text, e.g. "1 < 2</p>"
+			if dropDepth == 0 && !strings.HasPrefix(token, "<!") && !strings.HasPrefix(token, "<?") {
+				out.WriteString("&lt;")
+				writeSanitizedHTML(out, token[1:])
+			}
+			continue
+		}
+		closing := match[1] == "/"
+		name := strings.ToLower(match[2])
+		if sanitizedDroppedElements[name] {
+			if closing {
+				if dropDepth > 0 {
+					dropDepth--
+				}
+			} else if !strings.HasSuffix(token, "/>") {
+				dropDepth++
+			}
+			continue
+		}
+		if dropDepth > 0 || !sanitizedElements[name] {
+			continue
+		}
+
+		switch {
+		case closing:
+			if !sanitizedVoidElements[name] {
+				out.WriteString("</" + name + ">")
+			}
+		case name == "a":
+			out.WriteString("<a")
+			if hrefMatch := htmlHrefPattern.FindStringSubmatch(token); hrefMatch != nil {
+				href := html.UnescapeString(hrefMatch[1] + hrefMatch[2] + hrefMatch[3])
+				if safeLinkTarget(href) {
+					out.WriteString(` href="` + html.EscapeString(href) + `"`)
+				}
+			}
+			out.WriteString(">")
+		case name == "td" || name == "th":
+			out.WriteString("<" + name)
+			for _, span := range htmlSpanAttributePattern.FindAllStringSubmatch(token, -1) {
+				out.WriteString(" " + strings.ToLower(span[1]) + `="` + span[2] + `"`)
+			}
+			out.WriteString(">")
+		default:
+			out.WriteString("<" + name + ">")
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "scripts, styles and the head are removed with their content",
+			input:    `<html><head><title>Interpelacja</title><style>p{color:red}</style></head><body><script>track("x")</script><p>Treść</p></body></html>`,
+			expected: `<p>Treść</p>`,
+		},
+		{
+			name:     "attributes are dropped and unknown tags unwrapped",
+			input:    `<p style="margin:0" onclick="x()"><span class="a"><font face="Arial">Pan <b>Marszałek</b></font></span></p>`,
+			expected: `<p>Pan <b>Marszałek</b></p>`,
+		},
+		{
+			name:     "tracking images and embedded frames are removed",
+			input:    `<p>a<img src="https://tracker.example/p.gif" width="1"></p><iframe src="https://example.com">x</iframe><form><input name="q"><button>Go</button></form>`,
+			expected: `<p>a</p>`,
+		},
+		{
+			name:     "safe links are kept, script links lose their target",
+			input:    `<a href="https://www.sejm.gov.pl/" target="_blank">Sejm</a> <a href='/druki/1'>druk</a> <a href="java&#10;script:alert(1)">x</a>`,
+			expected: `<a href="https://www.sejm.gov.pl/">Sejm</a> <a href="/druki/1">druk</a> <a>x</a>`,
+		},
+		{
+			name:     "table spans are kept",
+			input:    `<table border="1"><tr><td colspan="2" width="50%">Razem</td><th ROWSPAN=3>Rok</th></tr></table>`,
+			expected: `<table><tr><td colspan="2">Razem</td><th rowspan="3">Rok</th></tr></table>`,
+		},
+		{
+			name:     "comments are removed and blank runs collapsed",
+			input:    "<!DOCTYPE html><!-- [if mso]> x <![endif] --><p>a</p>\n\n   \n<br/><p>1 < 2</p>",
+			expected: "<p>a</p>\n<br><p>1 &lt; 2</p>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeHTML(tt.input); got != tt.expected {
+				t.Errorf("sanitizeHTML() =\n%q\nexpected\n%q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHandleGetInterpellationBodySanitized(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/interpellations/1/body": `<html><head><script src="https://stats.example/t.js"></script></head><body><p class="MsoNormal">Pytanie</p></body></html>`,
+	})
+	call := func(arguments map[string]interface{}) string {
+		result, err := server.handleGetInterpellationBody(context.Background(), createMockRequest(arguments))
+		if err != nil || result.IsError {
+			t.Fatalf("Unexpected error for %v: %v %s", arguments, err, extractTextContent(result))
+		}
+		return extractTextContent(result)
+	}
+
+	text := call(map[string]interface{}{"term": "10", "num": "1"})
+	if !strings.Contains(text, "Sanitized HTML content") || !strings.Contains(text, "<p>Pytanie</p>") || strings.Contains(text, "script") {
+		t.Errorf("Expected a sanitized body, got:\n%s", text)
+	}
+	text = call(map[string]interface{}{"term": "10", "num": "1", "sanitize": "false"})
+	if !strings.Contains(text, "Full HTML content") || !strings.Contains(text, `<script src="https://stats.example/t.js">`) {
+		t.Errorf("Expected the body as served with sanitize='false', got:\n%s", text)
+	}
+}
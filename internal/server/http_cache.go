@@ -0,0 +1,286 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Tool classes with their own HTTP cache lifetime
+const (
+	// httpCacheReference covers data that changes a few times per term, such as committees and act types
+	httpCacheReference = "reference"
+	// httpCacheDefault covers everything else that is read from the APIs
+	httpCacheDefault = "default"
+	// httpCacheLive covers tools that follow the current sitting or day
+	httpCacheLive = "live"
+)
+
+// defaultHTTPCacheTTLs are the cache lifetimes used when -http-cache-ttl does not set a class
+var defaultHTTPCacheTTLs = map[string]time.Duration{
+	httpCacheReference: 24 * time.Hour,
+	httpCacheDefault:   5 * time.Minute,
+	httpCacheLive:      30 * time.Second,
+}
+
+// referenceTools return data that rarely changes within a term
+var referenceTools = map[string]bool{
+	"sejm_get_terms":                   true,
+	"sejm_get_committees":              true,
+	"sejm_get_committee_details":       true,
+	"sejm_get_subcommittees":           true,
+	"sejm_get_clubs":                   true,
+	"sejm_get_club_details":            true,
+	"sejm_get_bilateral_groups":        true,
+	"sejm_get_bilateral_group_details": true,
+	"sejm_get_parliamentary_keywords":  true,
+	"sejm_get_mp_photo":                true,
+	"eli_get_publishers":               true,
+	"eli_get_keywords":                 true,
+	"eli_get_statuses":                 true,
+	"eli_get_types":                    true,
+	"eli_get_institutions":             true,
+	"eli_get_act_aliases":              true,
+}
+
+// liveTools return data tied to the current moment
+var liveTools = map[string]bool{
+	"sejm_get_current_proceeding": true,
+	"sejm_get_videos_today":       true,
+	"sejm_get_daily_digest":       true,
+}
+
+// statefulTools read or change server state, or draw random results, so their responses are never cacheable
+var statefulTools = map[string]bool{
+	"sejm_get_job_status":    true,
+	"sejm_get_job_result":    true,
+	"eli_watch_act":          true,
+	"sejm_get_watch_updates": true,
+	"eli_sample_acts":        true,
+	"eli_random_act":         true,
+	"sejm_ping":              true,
+	"sejm_list_capabilities": true,
+	"sejm_get_list_snapshot": true,
+}
+
+// ParseHTTPCacheTTLs parses the -http-cache-ttl flag, e.g. "reference=12h,default=10m,live=0". A lifetime of 0
+// turns caching off for the class; classes that are not listed keep their defaults.
+func ParseHTTPCacheTTLs(raw string) (map[string]time.Duration, error) {
+	ttls := make(map[string]time.Duration)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		class, value, ok := strings.Cut(entry, "=")
+		class = strings.TrimSpace(class)
+		if !ok {
+			return nil, fmt.Errorf("invalid entry '%s': use class=duration, e.g. default=5m", entry)
+		}
+		if _, known := defaultHTTPCacheTTLs[class]; !known {
+			return nil, fmt.Errorf("unknown tool class '%s': use 'reference', 'default' or 'live'", class)
+		}
+		ttl, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || ttl < 0 {
+			return nil, fmt.Errorf("invalid duration '%s' for class '%s'", value, class)
+		}
+		ttls[class] = ttl
+	}
+	return ttls, nil
+}
+
+// httpCacheClass returns the cache class of a tool
+func httpCacheClass(tool string) string {
+	switch {
+	case referenceTools[tool]:
+		return httpCacheReference
+	case liveTools[tool]:
+		return httpCacheLive
+	default:
+		return httpCacheDefault
+	}
+}
+
+// httpCacheTTL returns the configured lifetime of a tool class
+func (s *SejmServer) httpCacheTTL(class string) time.Duration {
+	if ttl, ok := s.config.HTTPCacheTTLs[class]; ok {
+		return ttl
+	}
+	return defaultHTTPCacheTTLs[class]
+}
+
+// partialResultMeta is the _meta flag of results that are incomplete, e.g. cut short by the tool timeout. They
+// are answered with no-store, so caches do not keep serving them once the upstream recovers.
+const partialResultMeta = "partial"
+
+// markPartial flags a result as incomplete and returns it
+func markPartial(result *mcp.CallToolResult) *mcp.CallToolResult {
+	if result.Meta == nil {
+		result.Meta = &mcp.Meta{}
+	}
+	if result.Meta.AdditionalFields == nil {
+		result.Meta.AdditionalFields = make(map[string]any)
+	}
+	result.Meta.AdditionalFields[partialResultMeta] = true
+	return result
+}
+
+// cacheableToolCall is the part of a JSON-RPC request that decides whether its response may be cached
+type cacheableToolCall struct {
+	Method string `json:"method"`
+	Params struct {
+		Name      string                 `json:"name"`
+		Arguments map[string]interface{} `json:"arguments"`
+		Meta      map[string]interface{} `json:"_meta"`
+	} `json:"params"`
+}
+
+// httpCacheTTLFor returns how long the response to a request body may be cached; ok is false for requests
+// that are not single tool calls, whose responses are left as they are
+func (s *SejmServer) httpCacheTTLFor(body []byte) (time.Duration, bool) {
+	var call cacheableToolCall
+	if err := json.Unmarshal(body, &call); err != nil || call.Method != "tools/call" {
+		return 0, false
+	}
+	// Background jobs, watches, snapshots, calls that stream progress and calls saving to a server-local file
+	// are not repeatable; debug traces describe a single request
+	if statefulTools[call.Params.Name] || call.Params.Meta["progressToken"] != nil || fmt.Sprint(call.Params.Arguments["async"]) == "true" ||
+		fmt.Sprint(call.Params.Arguments["materialize"]) == "true" || fmt.Sprint(call.Params.Arguments["save_to_file"]) == "true" ||
+		fmt.Sprint(call.Params.Arguments["debug"]) == "true" {
+		return 0, true
+	}
+	return s.httpCacheTTL(httpCacheClass(call.Params.Name)), true
+}
+
+// bufferedResponseWriter holds a JSON response until its cache headers are known. Streamed (SSE) and failed
+// responses are passed through untouched.
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	body        bytes.Buffer
+	passThrough bool
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	if status != http.StatusOK || strings.HasPrefix(w.Header().Get("Content-Type"), "text/event-stream") {
+		w.passThrough = true
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	w.status = status
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	if w.passThrough {
+		return w.ResponseWriter.Write(data)
+	}
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+		if w.passThrough {
+			return w.ResponseWriter.Write(data)
+		}
+	}
+	return w.body.Write(data)
+}
+
+func (w *bufferedResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok && w.passThrough {
+		flusher.Flush()
+	}
+}
+
+// toolResultETag derives a strong ETag from the result of a JSON-RPC response; the request ID is left out so
+// that repeated calls with the same arguments get the same tag. Error and partial results get no tag.
+func toolResultETag(body []byte) (string, bool) {
+	var response struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil || len(response.Result) == 0 {
+		return "", false
+	}
+	var result struct {
+		IsError bool                   `json:"isError"`
+		Meta    map[string]interface{} `json:"_meta"`
+	}
+	if err := json.Unmarshal(response.Result, &result); err != nil || result.IsError || result.Meta[partialResultMeta] == true {
+		return "", false
+	}
+	sum := sha256.Sum256(response.Result)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, true
+}
+
+// etagMatches tells whether an If-None-Match header names the ETag
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// withHTTPCaching adds Cache-Control and ETag headers to tool call responses in HTTP mode, so reverse proxies
+// and CDNs can cache repeated calls. Since tool calls are POST requests, a cache has to key them on the request
+// body. Responses to an If-None-Match with the current ETag are answered with 304 Not Modified.
+func (s *SejmServer) withHTTPCaching(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Body == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		ttl, isToolCall := s.httpCacheTTLFor(body)
+		if !isToolCall {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		buffered := &bufferedResponseWriter{ResponseWriter: w}
+		next.ServeHTTP(buffered, r)
+		if buffered.passThrough {
+			return
+		}
+
+		etag, cacheable := toolResultETag(buffered.body.Bytes())
+		if !cacheable || ttl <= 0 {
+			w.Header().Set("Cache-Control", "no-store")
+		} else {
+			w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(ttl.Seconds())))
+			w.Header().Set("ETag", etag)
+			if etagMatches(r.Header.Get("If-None-Match"), etag) {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(buffered.body.Bytes()); err != nil {
+			s.logger.Warn("Failed to write MCP response", slog.Any("error", err))
+		}
+	})
+}
+
+// describeHTTPCacheTTLs lists the effective lifetimes for the startup log, e.g. "default=5m0s, live=30s"
+func (s *SejmServer) describeHTTPCacheTTLs() string {
+	var entries []string
+	for class := range defaultHTTPCacheTTLs {
+		entries = append(entries, fmt.Sprintf("%s=%s", class, s.httpCacheTTL(class)))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ", ")
+}
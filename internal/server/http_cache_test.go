@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+func TestParseHTTPCacheTTLs(t *testing.T) {
+	ttls, err := ParseHTTPCacheTTLs("reference=12h, live=0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ttls[httpCacheReference] != 12*time.Hour || ttls[httpCacheLive] != 0 || len(ttls) != 2 {
+		t.Errorf("Unexpected lifetimes: %v", ttls)
+	}
+	for _, invalid := range []string{"default", "weekly=1h", "default=soon", "default=-1m"} {
+		if _, err := ParseHTTPCacheTTLs(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestWithHTTPCaching(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/committees": `[{"code": "ASW", "name": "Komisja Administracji i Spraw Wewnętrznych"}]`,
+		"/sejm/term10/MP":         `[{"id": 1, "firstLastName": "Anna Nowak", "club": "KO", "active": true}]`,
+	})
+	server.config.HTTPCacheTTLs = map[string]time.Duration{httpCacheDefault: 0}
+	handler := server.withHTTPCaching(mcpserver.NewStreamableHTTPServer(server.server, mcpserver.WithStateLess(true)))
+
+	call := func(body, ifNoneMatch string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Accept", "application/json, text/event-stream")
+		if ifNoneMatch != "" {
+			request.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	committees := `{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "sejm_get_committees", "arguments": {"term": "10"}}}`
+	first := call(committees, "")
+	if first.Code != http.StatusOK || first.Header().Get("Cache-Control") != "public, max-age=86400" {
+		t.Fatalf("Expected a reference response cached for a day, got %d %v: %s", first.Code, first.Header(), first.Body.String())
+	}
+	etag := first.Header().Get("ETag")
+	if etag == "" || !strings.Contains(first.Body.String(), "result") {
+		t.Fatalf("Expected an ETag and the tool result, got %v: %s", first.Header(), first.Body.String())
+	}
+
+	repeated := call(strings.Replace(committees, `"id": 1`, `"id": 2`, 1), etag)
+	if repeated.Code != http.StatusNotModified || repeated.Body.Len() != 0 {
+		t.Errorf("Expected 304 for a repeated call with the same ETag, got %d: %s", repeated.Code, repeated.Body.String())
+	}
+
+	for name, body := range map[string]string{
+		"disabled class": `{"jsonrpc": "2.0", "id": 3, "method": "tools/call", "params": {"name": "sejm_get_mps", "arguments": {"term": "10"}}}`,
+		"error result":   `{"jsonrpc": "2.0", "id": 4, "method": "tools/call", "params": {"name": "sejm_get_committees", "arguments": {"term": "99"}}}`,
+		"stateful tool":  `{"jsonrpc": "2.0", "id": 5, "method": "tools/call", "params": {"name": "sejm_get_job_status", "arguments": {"job_id": "x"}}}`,
+		"saved to file":  `{"jsonrpc": "2.0", "id": 7, "method": "tools/call", "params": {"name": "sejm_get_committees", "arguments": {"term": "10", "save_to_file": "true"}}}`,
+		"debug trace":    `{"jsonrpc": "2.0", "id": 8, "method": "tools/call", "params": {"name": "sejm_get_committees", "arguments": {"term": "10", "debug": "true"}}}`,
+	} {
+		response := call(body, "")
+		if response.Code != http.StatusOK || response.Header().Get("Cache-Control") != "no-store" || response.Header().Get("ETag") != "" {
+			t.Errorf("%s: expected an uncached response, got %d %v", name, response.Code, response.Header())
+		}
+	}
+
+	listed := call(`{"jsonrpc": "2.0", "id": 6, "method": "tools/list"}`, "")
+	if listed.Code != http.StatusOK || listed.Header().Get("Cache-Control") != "" {
+		t.Errorf("Expected other methods to pass through untouched, got %d %v", listed.Code, listed.Header())
+	}
+}
+
+func TestWithHTTPCachingTimedOutResult(t *testing.T) {
+	server := NewSejmServer()
+	server.config.ToolTimeouts = map[string]time.Duration{"default": 20 * time.Millisecond}
+	tools := mcpserver.NewMCPServer("test", "1.0.0", mcpserver.WithToolCapabilities(false))
+	tools.AddTool(mcp.NewTool("sejm_find_defections"), server.withToolTimeout("sejm_find_defections", func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		<-ctx.Done()
+		return mcp.NewToolResultText("Party-Line Defections - Retrieved Successfully"), nil
+	}))
+	handler := server.withHTTPCaching(mcpserver.NewStreamableHTTPServer(tools, mcpserver.WithStateLess(true)))
+
+	request := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "sejm_find_defections", "arguments": {}}}`))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json, text/event-stream")
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+
+	if response.Code != http.StatusOK || !strings.Contains(response.Body.String(), "results above are partial") {
+		t.Fatalf("Expected the partial result, got %d: %s", response.Code, response.Body.String())
+	}
+	if response.Header().Get("Cache-Control") != "no-store" || response.Header().Get("ETag") != "" {
+		t.Errorf("Expected a timed-out result not to be cached, got %v", response.Header())
+	}
+}
+
+func TestWithHTTPCachingPartialResult(t *testing.T) {
+	fixtures := map[string]string{
+		"/sejm/term10/votings":     `[{"date": "2024-03-07", "proceeding": 7, "votingsNum": 2}]`,
+		"/sejm/term10/votings/7":   `[{"sitting": 7, "votingNumber": 1, "date": "2024-03-07T10:00:00"}, {"sitting": 7, "votingNumber": 2, "date": "2024-03-07T11:00:00"}]`,
+		"/sejm/term10/votings/7/1": `{"votes": [{"MP": 1, "club": "KO", "vote": "YES"}, {"MP": 2, "club": "KO", "vote": "NO"}, {"MP": 3, "club": "KO", "vote": "YES"}]}`,
+	}
+	server := newServerWithFixtures(t, fixtures)
+	handler := server.withHTTPCaching(mcpserver.NewStreamableHTTPServer(server.server, mcpserver.WithStateLess(true)))
+	call := func() *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(`{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "sejm_find_defections", "arguments": {"term": "10", "date_from": "2024-03-07", "date_to": "2024-03-07"}}}`))
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Accept", "application/json, text/event-stream")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	partial := call()
+	if partial.Code != http.StatusOK || !strings.Contains(partial.Body.String(), "Partially Retrieved") {
+		t.Fatalf("Expected a partial result, got %d: %s", partial.Code, partial.Body.String())
+	}
+	if partial.Header().Get("Cache-Control") != "no-store" || partial.Header().Get("ETag") != "" {
+		t.Errorf("Expected a result with failed sources not to be cached, got %v", partial.Header())
+	}
+
+	fixtures["/sejm/term10/votings/7/2"] = `{"votes": [{"MP": 1, "club": "KO", "vote": "YES"}]}`
+	complete := call()
+	if complete.Header().Get("Cache-Control") != "public, max-age=300" || complete.Header().Get("ETag") == "" {
+		t.Errorf("Expected the complete result to be cached, got %v: %s", complete.Header(), complete.Body.String())
+	}
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Defaults of the upstream HTTP client, used when the configuration leaves them unset
+const (
+	defaultUpstreamTimeout     = 45 * time.Second
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 20
+)
+
+// acceptedEncodings is sent upstream so that large JSON lists and HTML transcripts travel compressed
+const acceptedEncodings = "gzip, deflate"
+
+// upstreamTimeout returns the configured timeout of a whole upstream request, including reading the body
+func upstreamTimeout(config Config) time.Duration {
+	if config.UpstreamTimeout > 0 {
+		return config.UpstreamTimeout
+	}
+	return defaultUpstreamTimeout
+}
+
+// newUpstreamTransport creates the pooled transport used for all API calls. Idle connections are kept
+// per host since nearly every request goes to api.sejm.gov.pl, and HTTP/2 is negotiated when offered.
+// Compression is handled by compressionTransport, which also understands deflate.
+func newUpstreamTransport(config Config) *http.Transport {
+	maxIdleConns := config.MaxIdleConns
+	if maxIdleConns <= 0 {
+		maxIdleConns = defaultMaxIdleConns
+	}
+	maxIdleConnsPerHost := config.MaxIdleConnsPerHost
+	if maxIdleConnsPerHost <= 0 {
+		maxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	}
+	if maxIdleConnsPerHost > maxIdleConns {
+		maxIdleConnsPerHost = maxIdleConns
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second, KeepAlive: 30 * time.Second}
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+		ResponseHeaderTimeout: upstreamTimeout(config),
+		DisableCompression:    true,
+	}
+}
+
+// compressionTransport asks for gzip or deflate encoded responses and decodes them, so the layers
+// above (revalidation, fixtures, response cache) only ever see plain bodies
+type compressionTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *compressionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Callers that choose an encoding themselves, or ask for a byte range, get the raw response
+	if req.Header.Get("Accept-Encoding") != "" || req.Header.Get("Range") != "" {
+		return t.transport.RoundTrip(req)
+	}
+	outgoing := req.Clone(req.Context())
+	outgoing.Header.Set("Accept-Encoding", acceptedEncodings)
+
+	resp, err := t.transport.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+	encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if encoding != "gzip" && encoding != "deflate" {
+		return resp, nil
+	}
+	resp.Body = &decompressingBody{body: resp.Body, encoding: encoding}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	resp.Request = req
+	return resp, nil
+}
+
+// decompressingBody decodes a compressed response body. The decoder is created on the first read,
+// so empty bodies (e.g., of a 304 answer) never fail on a missing header.
+type decompressingBody struct {
+	body     io.ReadCloser
+	encoding string
+	reader   io.Reader
+	err      error
+}
+
+func (b *decompressingBody) Read(p []byte) (int, error) {
+	if b.reader == nil && b.err == nil {
+		b.reader, b.err = newDecoder(b.body, b.encoding)
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.reader.Read(p)
+}
+
+func (b *decompressingBody) Close() error {
+	return b.body.Close()
+}
+
+// newDecoder returns a reader decoding the given content encoding. HTTP's deflate is zlib-wrapped,
+// but some servers send a raw deflate stream, so the zlib header is checked first.
+func newDecoder(body io.Reader, encoding string) (io.Reader, error) {
+	if encoding == "gzip" {
+		return gzip.NewReader(body)
+	}
+	buffered := bufio.NewReader(body)
+	header, err := buffered.Peek(2)
+	if err != nil && len(header) < 2 {
+		if err == io.EOF {
+			return buffered, nil
+		}
+		return nil, err
+	}
+	if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(buffered)
+	}
+	return flate.NewReader(buffered), nil
+}
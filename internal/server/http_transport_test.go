@@ -0,0 +1,95 @@
+package server
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCompressionTransport(t *testing.T) {
+	const payload = `[{"id": 1, "name": "Komisja do Spraw Energii"}]`
+	compress := map[string]func(io.Writer) io.WriteCloser{
+		"gzip":    func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) },
+		"deflate": func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) },
+		"deflate-raw": func(w io.Writer) io.WriteCloser {
+			writer, _ := flate.NewWriter(w, flate.DefaultCompression)
+			return writer
+		},
+		"identity":     nil,
+		"deflate-none": nil,
+	}
+
+	var acceptEncoding string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		mode := r.URL.Query().Get("mode")
+		var body bytes.Buffer
+		if newWriter := compress[mode]; newWriter != nil {
+			writer := newWriter(&body)
+			_, _ = writer.Write([]byte(payload))
+			_ = writer.Close()
+			encoding := mode
+			if mode == "deflate-raw" {
+				encoding = "deflate"
+			}
+			w.Header().Set("Content-Encoding", encoding)
+		} else if mode == "identity" {
+			body.WriteString(payload)
+		} else {
+			// Empty compressed body, as in a 204 or 304 answer
+			w.Header().Set("Content-Encoding", "deflate")
+		}
+		_, _ = w.Write(body.Bytes())
+	}))
+	defer upstream.Close()
+
+	client := &http.Client{Transport: &compressionTransport{transport: newUpstreamTransport(Config{})}}
+	for mode := range compress {
+		resp, err := client.Get(upstream.URL + "?mode=" + mode)
+		if err != nil {
+			t.Fatalf("%s: request failed: %v", mode, err)
+		}
+		data, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			t.Fatalf("%s: failed to read body: %v", mode, err)
+		}
+		if acceptEncoding != acceptedEncodings {
+			t.Errorf("%s: expected Accept-Encoding %q, got %q", mode, acceptedEncodings, acceptEncoding)
+		}
+		expected := payload
+		if mode == "deflate-none" {
+			expected = ""
+		}
+		if string(data) != expected {
+			t.Errorf("%s: expected decoded body %q, got %q", mode, expected, data)
+		}
+		if resp.Header.Get("Content-Encoding") != "" {
+			t.Errorf("%s: Content-Encoding should be removed after decoding", mode)
+		}
+	}
+}
+
+func TestNewUpstreamTransport(t *testing.T) {
+	transport := newUpstreamTransport(Config{})
+	if transport.MaxIdleConns != defaultMaxIdleConns || transport.MaxIdleConnsPerHost != defaultMaxIdleConnsPerHost {
+		t.Errorf("Unexpected default pool limits: %d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+	if !transport.ForceAttemptHTTP2 || !transport.DisableCompression {
+		t.Error("Expected HTTP/2 to be attempted and built-in compression to be replaced by compressionTransport")
+	}
+
+	transport = newUpstreamTransport(Config{MaxIdleConns: 8, MaxIdleConnsPerHost: 16, UpstreamTimeout: 5 * time.Second})
+	if transport.MaxIdleConns != 8 || transport.MaxIdleConnsPerHost != 8 {
+		t.Errorf("Per-host limit should not exceed the total, got %d/%d", transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	}
+	if transport.ResponseHeaderTimeout != 5*time.Second {
+		t.Errorf("Expected the configured timeout, got %v", transport.ResponseHeaderTimeout)
+	}
+}
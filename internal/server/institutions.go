@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxInstitutionSuggestions is the number of similar institutions suggested for an unknown name
+const maxInstitutionSuggestions = 10
+
+// getCachedInstitutions returns the issuing institutions (organy wydające) known to ELI from cache or fetches them
+func (s *SejmServer) getCachedInstitutions(ctx context.Context) ([]string, error) {
+	s.cache.mu.RLock()
+	if s.cache.Institutions != nil && time.Now().Before(s.cache.Institutions.ExpiresAt) {
+		institutions := s.cache.Institutions.Data.([]string)
+		s.cache.mu.RUnlock()
+		return institutions, nil
+	}
+	s.cache.mu.RUnlock()
+
+	data, err := s.makeAPIRequest(ctx, s.eliBaseURL+"/institutions", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch institutions: %w", err)
+	}
+	var institutions []string
+	if err := s.decodeAPIResponse(data, &institutions); err != nil {
+		return nil, fmt.Errorf("failed to parse institutions: %w", err)
+	}
+
+	// Cache for 24 hours like the other ELI directories
+	s.cache.mu.Lock()
+	s.cache.Institutions = &CacheEntry{
+		Data:      institutions,
+		ExpiresAt: time.Now().Add(24 * time.Hour),
+	}
+	s.cache.mu.Unlock()
+	return institutions, nil
+}
+
+// filterInstitutions keeps the institutions containing filter, ignoring case and Polish diacritics
+func filterInstitutions(institutions []string, filter string) []string {
+	filter = normalizePolish(strings.TrimSpace(filter))
+	var matches []string
+	for _, institution := range institutions {
+		if strings.Contains(normalizePolish(institution), filter) {
+			matches = append(matches, institution)
+		}
+	}
+	return matches
+}
+
+// resolveInstitution maps a name to the spelling ELI uses, e.g. "min. zdrowia" to "MIN. ZDROWIA". Names that
+// match no institution, or several after ignoring case and diacritics, are rejected with suggestions.
+func resolveInstitution(institutions []string, name string) (string, []string, bool) {
+	normalized := normalizePolish(strings.TrimSpace(name))
+	var exact []string
+	for _, institution := range institutions {
+		if institution == name {
+			return institution, nil, true
+		}
+		if normalizePolish(institution) == normalized {
+			exact = append(exact, institution)
+		}
+	}
+	if len(exact) == 1 {
+		return exact[0], nil, true
+	}
+	if len(exact) > 1 {
+		return "", exact, false
+	}
+	suggestions := filterInstitutions(institutions, name)
+	if len(suggestions) > maxInstitutionSuggestions {
+		suggestions = suggestions[:maxInstitutionSuggestions]
+	}
+	return "", suggestions, false
+}
+
+func (s *SejmServer) handleGetInstitutions(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("eli_get_institutions called", slog.Any("arguments", request.Params.Arguments))
+
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	institutions, err := s.getCachedInstitutions(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve institutions: %v", err)), nil
+	}
+	total := len(institutions)
+
+	filter := request.GetString("filter", "")
+	if filter != "" {
+		institutions = filterInstitutions(institutions, filter)
+	} else {
+		institutions = append([]string(nil), institutions...)
+	}
+	if request.GetString("sort", "asc") == "desc" {
+		sort.Sort(sort.Reverse(sort.StringSlice(institutions)))
+	} else {
+		sort.Strings(institutions)
+	}
+
+	if format == "json" {
+		out, _ := json.MarshalIndent(map[string]interface{}{
+			"total":        total,
+			"matched":      len(institutions),
+			"institutions": institutions,
+		}, "", "  ")
+		return mcp.NewToolResultText(string(out)), nil
+	}
+
+	var summary []string
+	if filter != "" {
+		summary = append(summary, fmt.Sprintf("Found %d of %d institutions containing '%s'", len(institutions), total, filter))
+	} else {
+		summary = append(summary, fmt.Sprintf("Retrieved all %d issuing institutions", total))
+	}
+
+	var data []string
+	if len(institutions) > 0 {
+		data = append(data, fmt.Sprintf("Issuing Institutions (%d):\n• %s", len(institutions), strings.Join(institutions, "\n• ")))
+	}
+
+	nextActions := []string{"Acts issued by an institution: eli_search_acts with institution set to its name as listed here"}
+	if len(institutions) > 0 {
+		nextActions = append(nextActions, fmt.Sprintf("Example: eli_search_acts with institution='%s', year='%d'", institutions[0], time.Now().Year()))
+	}
+	note := "Institutions are the bodies that issued or released acts (organy wydające). Filtering ignores case and Polish diacritics."
+	if len(institutions) == 0 {
+		note = "No institutions match the filter; try a shorter fragment such as 'zdrow' or 'rada'. " + note
+	}
+
+	response := StandardResponse{
+		Operation:   "ELI Institutions Directory",
+		Status:      "Retrieved Successfully",
+		Summary:     summary,
+		Data:        data,
+		NextActions: nextActions,
+		Note:        note,
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const institutionsFixture = `["SEJM", "RADA MINISTRÓW", "MIN. ZDROWIA", "MIN. SPRAWIEDLIWOŚCI", "PREZES RADY MINISTRÓW"]`
+
+func TestHandleGetInstitutions(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{"/eli/institutions": institutionsFixture})
+
+	result, err := server.handleGetInstitutions(context.Background(), createMockRequest(map[string]interface{}{"filter": "ministrow"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{
+		"Found 2 of 5 institutions containing 'ministrow'",
+		"• PREZES RADY MINISTRÓW",
+		"• RADA MINISTRÓW",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+	if strings.Contains(text, "MIN. ZDROWIA") {
+		t.Errorf("Expected the filter to exclude other institutions:\n%s", text)
+	}
+}
+
+func TestResolveInstitution(t *testing.T) {
+	institutions := []string{"SEJM", "MIN. ZDROWIA", "MIN. SPRAWIEDLIWOŚCI"}
+	testCases := []struct {
+		name        string
+		resolved    string
+		suggestions []string
+	}{
+		{"SEJM", "SEJM", nil},
+		{"min. sprawiedliwosci", "MIN. SPRAWIEDLIWOŚCI", nil},
+		{"min.", "", []string{"MIN. ZDROWIA", "MIN. SPRAWIEDLIWOŚCI"}},
+		{"senat", "", nil},
+	}
+	for _, tc := range testCases {
+		resolved, suggestions, ok := resolveInstitution(institutions, tc.name)
+		if ok != (tc.resolved != "") || resolved != tc.resolved || strings.Join(suggestions, "|") != strings.Join(tc.suggestions, "|") {
+			t.Errorf("resolveInstitution(%q) = %q, %v, %v", tc.name, resolved, suggestions, ok)
+		}
+	}
+}
@@ -0,0 +1,504 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Limits of sejm_cluster_interpellations
+const (
+	defaultClusterItems      = 200
+	maxClusterItems          = 1000
+	maxClusterItemsWithBody  = 100
+	maxTopicClusters         = 15
+	clusterPageSize          = 100
+	clusterKMeansIterations  = 25
+	clusterLabelTerms        = 4
+	clusterRepresentatives   = 3
+	clusterStemLength        = 6
+	clusterBodySentences     = 5
+	clusterMinDocumentFreq   = 2
+	clusterMaxDocumentShare  = 0.5
+	clusterTopRecipientCount = 3
+)
+
+// topicCluster is a group of documents sharing a topic
+type topicCluster struct {
+	members         []int
+	scores          []float64
+	centroid        map[string]float64
+	representatives []int
+}
+
+// clusterStem shortens a word to a fixed prefix, a crude stemmer that merges most Polish inflected forms
+func clusterStem(word string) string {
+	runes := []rune(word)
+	if len(runes) > clusterStemLength {
+		return string(runes[:clusterStemLength])
+	}
+	return word
+}
+
+// buildTFIDFVectors turns texts into L2-normalized TF-IDF vectors over word stems. Stems found in fewer
+// than two documents or in more than half of them carry no grouping signal and are dropped. surface maps
+// each stem to its most frequent full word, used for readable labels.
+func buildTFIDFVectors(texts []string) (vectors []map[string]float64, surface map[string]string) {
+	counts := make([]map[string]int, len(texts))
+	documentFreq := make(map[string]int)
+	wordFreq := make(map[string]map[string]int)
+	for i, text := range texts {
+		counts[i] = make(map[string]int)
+		for _, token := range summaryTokens(text) {
+			stem := clusterStem(token)
+			if counts[i][stem] == 0 {
+				documentFreq[stem]++
+			}
+			counts[i][stem]++
+			if wordFreq[stem] == nil {
+				wordFreq[stem] = make(map[string]int)
+			}
+			wordFreq[stem][token]++
+		}
+	}
+
+	surface = make(map[string]string, len(wordFreq))
+	for stem, words := range wordFreq {
+		best := ""
+		for word, count := range words {
+			if best == "" || count > words[best] || (count == words[best] && word < best) {
+				best = word
+			}
+		}
+		surface[stem] = best
+	}
+
+	n := float64(len(texts))
+	vectors = make([]map[string]float64, len(texts))
+	for i, termCounts := range counts {
+		vector := make(map[string]float64)
+		norm := 0.0
+		for stem, count := range termCounts {
+			df := documentFreq[stem]
+			if df < clusterMinDocumentFreq || float64(df) > clusterMaxDocumentShare*n {
+				continue
+			}
+			weight := (1 + math.Log(float64(count))) * math.Log(n/float64(df))
+			vector[stem] = weight
+			norm += weight * weight
+		}
+		norm = math.Sqrt(norm)
+		for stem := range vector {
+			vector[stem] /= norm
+		}
+		vectors[i] = vector
+	}
+	return vectors, surface
+}
+
+// cosine returns the dot product of two normalized sparse vectors
+func cosine(a, b map[string]float64) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	sum := 0.0
+	for term, weight := range a {
+		sum += weight * b[term]
+	}
+	return sum
+}
+
+// clusterVectors groups non-empty vectors into at most k clusters with spherical k-means. Initial centers
+// are chosen deterministically by farthest-first traversal, so the same input always gives the same topics.
+// Documents with empty vectors are left out and reported by the caller.
+func clusterVectors(vectors []map[string]float64, k int) []*topicCluster {
+	var indices []int
+	for i, vector := range vectors {
+		if len(vector) > 0 {
+			indices = append(indices, i)
+		}
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+	if k > len(indices) {
+		k = len(indices)
+	}
+
+	// Farthest-first initialization, starting from the document most similar to all others
+	centers := make([]map[string]float64, 0, k)
+	best, bestScore := indices[0], -1.0
+	for _, i := range indices {
+		score := 0.0
+		for _, j := range indices {
+			score += cosine(vectors[i], vectors[j])
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	centers = append(centers, vectors[best])
+	for len(centers) < k {
+		farthest, farthestSimilarity := -1, math.Inf(1)
+		for _, i := range indices {
+			similarity := 0.0
+			for _, center := range centers {
+				similarity = math.Max(similarity, cosine(vectors[i], center))
+			}
+			if similarity < farthestSimilarity {
+				farthest, farthestSimilarity = i, similarity
+			}
+		}
+		if farthestSimilarity >= 1 {
+			break // Remaining documents duplicate existing centers
+		}
+		centers = append(centers, vectors[farthest])
+	}
+
+	assignment := make(map[int]int, len(indices))
+	for iteration := 0; iteration < clusterKMeansIterations; iteration++ {
+		changed := false
+		for _, i := range indices {
+			bestCenter, bestSimilarity := 0, -1.0
+			for c, center := range centers {
+				if similarity := cosine(vectors[i], center); similarity > bestSimilarity {
+					bestCenter, bestSimilarity = c, similarity
+				}
+			}
+			if current, ok := assignment[i]; !ok || current != bestCenter {
+				assignment[i] = bestCenter
+				changed = true
+			}
+		}
+		if !changed && iteration > 0 {
+			break
+		}
+		for c := range centers {
+			centroid := make(map[string]float64)
+			for _, i := range indices {
+				if assignment[i] != c {
+					continue
+				}
+				for term, weight := range vectors[i] {
+					centroid[term] += weight
+				}
+			}
+			norm := 0.0
+			for _, weight := range centroid {
+				norm += weight * weight
+			}
+			if norm == 0 {
+				continue // Keep the previous center of an emptied cluster
+			}
+			norm = math.Sqrt(norm)
+			for term := range centroid {
+				centroid[term] /= norm
+			}
+			centers[c] = centroid
+		}
+	}
+
+	clusters := make([]*topicCluster, len(centers))
+	for c, center := range centers {
+		clusters[c] = &topicCluster{centroid: center}
+	}
+	for _, i := range indices {
+		cluster := clusters[assignment[i]]
+		cluster.members = append(cluster.members, i)
+		cluster.scores = append(cluster.scores, cosine(vectors[i], cluster.centroid))
+	}
+
+	var result []*topicCluster
+	for _, cluster := range clusters {
+		if len(cluster.members) == 0 {
+			continue
+		}
+		order := make([]int, len(cluster.members))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool { return cluster.scores[order[a]] > cluster.scores[order[b]] })
+		for _, position := range order {
+			if len(cluster.representatives) == clusterRepresentatives {
+				break
+			}
+			cluster.representatives = append(cluster.representatives, cluster.members[position])
+		}
+		result = append(result, cluster)
+	}
+	sort.SliceStable(result, func(a, b int) bool { return len(result[a].members) > len(result[b].members) })
+	return result
+}
+
+// labelCluster names a cluster after the heaviest terms of its centroid
+func labelCluster(cluster *topicCluster, surface map[string]string) []string {
+	terms := make([]string, 0, len(cluster.centroid))
+	for term := range cluster.centroid {
+		terms = append(terms, term)
+	}
+	sort.Slice(terms, func(a, b int) bool {
+		if cluster.centroid[terms[a]] != cluster.centroid[terms[b]] {
+			return cluster.centroid[terms[a]] > cluster.centroid[terms[b]]
+		}
+		return terms[a] < terms[b]
+	})
+	if len(terms) > clusterLabelTerms {
+		terms = terms[:clusterLabelTerms]
+	}
+	label := make([]string, len(terms))
+	for i, term := range terms {
+		label[i] = surface[term]
+	}
+	return label
+}
+
+// defaultClusterCount picks a number of topics that grows slowly with the number of documents
+func defaultClusterCount(documents int) int {
+	k := int(math.Round(math.Sqrt(float64(documents) / 2)))
+	if k < 2 {
+		k = 2
+	}
+	if k > maxTopicClusters {
+		k = maxTopicClusters
+	}
+	return k
+}
+
+// fetchInterpellationWindow lists interpellations received in a date window, page by page
+func (s *SejmServer) fetchInterpellationWindow(ctx context.Context, term int, since, till string, maxItems int) ([]sejm.Interpellation, error) {
+	filters := map[string]string{}
+	if since != "" {
+		filters["since"] = since
+	}
+	if till != "" {
+		filters["till"] = till
+	}
+	return s.fetchInterpellationPages(ctx, term, filters, maxItems)
+}
+
+// fetchInterpellationPages lists interpellations matching the API filters, newest first, page by page
+func (s *SejmServer) fetchInterpellationPages(ctx context.Context, term int, filters map[string]string, maxItems int) ([]sejm.Interpellation, error) {
+	var interpellations []sejm.Interpellation
+	for offset := 0; len(interpellations) < maxItems; offset += clusterPageSize {
+		params := map[string]string{
+			"limit":   strconv.Itoa(clusterPageSize),
+			"offset":  strconv.Itoa(offset),
+			"sort_by": "-receiptDate",
+		}
+		for key, value := range filters {
+			params[key] = value
+		}
+		data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/interpellations", s.sejmBaseURL, term), params)
+		if err != nil {
+			return nil, err
+		}
+		var page []sejm.Interpellation
+		if err := s.decodeAPIResponse(data, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse interpellations: %w", err)
+		}
+		interpellations = append(interpellations, page...)
+		if len(page) < clusterPageSize {
+			break
+		}
+	}
+	if len(interpellations) > maxItems {
+		interpellations = interpellations[:maxItems]
+	}
+	return interpellations, nil
+}
+
+func (s *SejmServer) handleClusterInterpellations(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_cluster_interpellations called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	from, err := parseDefectionDate("date_from", request.GetString("date_from", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	to, err := parseDefectionDate("date_to", request.GetString("date_to", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	includeBodies := request.GetString("include_bodies", "false") == "true"
+
+	maxItems := defaultClusterItems
+	limitCap := maxClusterItems
+	if includeBodies {
+		maxItems, limitCap = maxClusterItemsWithBody, maxClusterItemsWithBody
+	}
+	if limitStr := request.GetString("max_items", ""); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			maxItems = parsed
+		}
+	}
+	if maxItems > limitCap {
+		maxItems = limitCap
+	}
+	clusterCount := 0
+	if clustersStr := request.GetString("clusters", ""); clustersStr != "" {
+		parsed, err := strconv.Atoi(clustersStr)
+		if err != nil || parsed < 2 || parsed > maxTopicClusters {
+			return mcp.NewToolResultError(fmt.Sprintf("Parameter 'clusters' must be a number between 2 and %d.", maxTopicClusters)), nil
+		}
+		clusterCount = parsed
+	}
+
+	since, till := "", ""
+	if !from.IsZero() {
+		since = from.Format("2006-01-02")
+	}
+	if !to.IsZero() {
+		till = to.Format("2006-01-02")
+	}
+	interpellations, err := s.fetchInterpellationWindow(ctx, term, since, till, maxItems)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve interpellations for term %d: %v", term, err)), nil
+	}
+	window := fmt.Sprintf("%s to %s", formatOptionalDate(from, "start of term"), formatOptionalDate(to, "now"))
+	if len(interpellations) < 4 {
+		response := StandardResponse{
+			Operation:   "Interpellation Topics",
+			Status:      "No Results Found",
+			Summary:     []string{fmt.Sprintf("Only %d interpellations received in term %d (%s); at least 4 are needed to find topics", len(interpellations), term, window)},
+			NextActions: []string{"Widen the window: earlier date_from or later date_to"},
+		}
+		return mcp.NewToolResultText(response.Format()), nil
+	}
+
+	texts := make([]string, len(interpellations))
+	var fetches []*bodyFetch
+	bodies := make([]*bodyFetch, len(interpellations))
+	for i, interpellation := range interpellations {
+		if interpellation.Title != nil {
+			texts[i] = *interpellation.Title
+		}
+		if includeBodies && interpellation.Num != nil {
+			bodies[i] = &bodyFetch{endpoint: fmt.Sprintf("%s/sejm/term%d/interpellations/%d/body", s.sejmBaseURL, term, *interpellation.Num)}
+			fetches = append(fetches, bodies[i])
+		}
+	}
+	failedBodies := 0
+	if includeBodies {
+		s.fetchBodyDigests(ctx, fetches, clusterBodySentences)
+		for i, body := range bodies {
+			if body == nil {
+				continue
+			}
+			if body.digest.Err != nil {
+				failedBodies++
+				continue
+			}
+			texts[i] += ". " + strings.Join(body.digest.Sentences, " ")
+		}
+	}
+
+	vectors, surface := buildTFIDFVectors(texts)
+	if clusterCount == 0 {
+		clusterCount = defaultClusterCount(len(interpellations))
+	}
+	clusters := clusterVectors(vectors, clusterCount)
+
+	clustered := 0
+	var results []string
+	for n, cluster := range clusters {
+		clustered += len(cluster.members)
+		results = append(results, fmt.Sprintf("Topic %d: %s (%d interpellations, %.0f%%)", n+1, strings.Join(labelCluster(cluster, surface), ", "),
+			len(cluster.members), float64(len(cluster.members))*100/float64(len(interpellations))))
+
+		recipients := make(map[string]int)
+		for _, i := range cluster.members {
+			if interpellations[i].To != nil {
+				for _, recipient := range *interpellations[i].To {
+					recipients[recipient]++
+				}
+			}
+		}
+		if top := topRecipients(recipients); top != "" {
+			results = append(results, "  Main recipients: "+top)
+		}
+		for _, i := range cluster.representatives {
+			results = append(results, "  • "+formatClusterDocument(interpellations[i]))
+		}
+		results = append(results, "")
+	}
+
+	summary := []string{
+		fmt.Sprintf("Term %d, received %s: %d interpellations analyzed (newest first)", term, window, len(interpellations)),
+		fmt.Sprintf("Topics found: %d", len(clusters)),
+	}
+	if includeBodies {
+		summary = append(summary, "Text analyzed: titles and the most representative sentences of each body")
+	} else {
+		summary = append(summary, "Text analyzed: titles")
+	}
+	if unclustered := len(interpellations) - clustered; unclustered > 0 {
+		summary = append(summary, fmt.Sprintf("Interpellations without distinctive words (not clustered): %d", unclustered))
+	}
+	if failedBodies > 0 {
+		summary = append(summary, fmt.Sprintf("Bodies that could not be downloaded (title used): %d", failedBodies))
+	}
+	if len(interpellations) == maxItems {
+		summary = append(summary, fmt.Sprintf("Reached max_items=%d; older interpellations in the window were not analyzed", maxItems))
+	}
+
+	nextActions := []string{
+		"Inspect a representative interpellation: sejm_get_interpellation_details with term and num",
+		"Split broad topics: raise clusters (up to 15)",
+	}
+	if !includeBodies {
+		nextActions = append(nextActions, "Use the interpellation texts as well: include_bodies='true' (up to 100 interpellations)")
+	}
+
+	response := StandardResponse{
+		Operation:   "Interpellation Topics",
+		Status:      "Retrieved Successfully",
+		Summary:     summary,
+		Data:        results,
+		NextActions: nextActions,
+		Note:        "Topics come from TF-IDF weighting of word stems and k-means clustering, computed locally without an external language model. Labels are the most characteristic words of each topic; representative interpellations are the ones closest to the topic's center.",
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
+
+// topRecipients lists the most frequent recipients of a cluster
+func topRecipients(counts map[string]int) string {
+	recipients := make([]string, 0, len(counts))
+	for recipient := range counts {
+		recipients = append(recipients, recipient)
+	}
+	sort.Slice(recipients, func(a, b int) bool {
+		if counts[recipients[a]] != counts[recipients[b]] {
+			return counts[recipients[a]] > counts[recipients[b]]
+		}
+		return recipients[a] < recipients[b]
+	})
+	if len(recipients) > clusterTopRecipientCount {
+		recipients = recipients[:clusterTopRecipientCount]
+	}
+	for i, recipient := range recipients {
+		recipients[i] = fmt.Sprintf("%s (%d)", recipient, counts[recipient])
+	}
+	return strings.Join(recipients, "; ")
+}
+
+// formatClusterDocument renders a representative interpellation
+func formatClusterDocument(interpellation sejm.Interpellation) string {
+	line := ""
+	if interpellation.Num != nil {
+		line = fmt.Sprintf("#%d ", *interpellation.Num)
+	}
+	if interpellation.ReceiptDate != nil {
+		line += fmt.Sprintf("(%s) ", interpellation.ReceiptDate.Format("2006-01-02"))
+	}
+	return line + valueOrDefault(stringValue(interpellation.Title), "No title")
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var clusterTitles = []string{
+	"Interpelacja w sprawie opóźnień pociągów regionalnych na kolei",
+	"Interpelacja w sprawie likwidacji połączeń kolejowych i pociągów",
+	"Interpelacja w sprawie remontu linii kolejowej i opóźnień pociągów",
+	"Interpelacja w sprawie kolejek do lekarzy specjalistów w szpitalach",
+	"Interpelacja w sprawie finansowania szpitali powiatowych i lekarzy",
+	"Interpelacja w sprawie braku lekarzy w szpitalach dziecięcych",
+}
+
+func TestClusterVectors(t *testing.T) {
+	vectors, surface := buildTFIDFVectors(clusterTitles)
+	clusters := clusterVectors(vectors, 2)
+	if len(clusters) != 2 {
+		t.Fatalf("Expected 2 clusters, got %d", len(clusters))
+	}
+	groups := make(map[int]int)
+	for c, cluster := range clusters {
+		for _, member := range cluster.members {
+			groups[member] = c
+		}
+	}
+	if groups[0] != groups[1] || groups[1] != groups[2] || groups[3] != groups[4] || groups[4] != groups[5] || groups[0] == groups[3] {
+		t.Errorf("Expected railway and hospital interpellations in separate clusters, got %v", groups)
+	}
+
+	labels := strings.Join(labelCluster(clusters[groups[0]], surface), " ")
+	if !strings.Contains(labels, "pociągów") {
+		t.Errorf("Expected railway words in the label, got %q", labels)
+	}
+	if len(clusters[0].representatives) != clusterRepresentatives {
+		t.Errorf("Expected %d representatives, got %d", clusterRepresentatives, len(clusters[0].representatives))
+	}
+}
+
+func TestDefaultClusterCount(t *testing.T) {
+	for documents, expected := range map[int]int{4: 2, 50: 5, 200: 10, 1000: maxTopicClusters} {
+		if got := defaultClusterCount(documents); got != expected {
+			t.Errorf("defaultClusterCount(%d) = %d, expected %d", documents, got, expected)
+		}
+	}
+}
+
+func TestHandleClusterInterpellations(t *testing.T) {
+	var items []string
+	for i, title := range clusterTitles {
+		recipient := "minister infrastruktury"
+		if i >= 3 {
+			recipient = "minister zdrowia"
+		}
+		items = append(items, fmt.Sprintf(`{"num": %d, "title": %q, "receiptDate": "2024-03-0%d", "to": [%q]}`, i+1, title, i+1, recipient))
+	}
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/interpellations": "[" + strings.Join(items, ",") + "]",
+	})
+
+	result, err := server.handleClusterInterpellations(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "date_from": "2024-03-01", "clusters": "2",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Term 10, received 2024-03-01 to now: 6 interpellations analyzed",
+		"Topics found: 2",
+		"(3 interpellations, 50%)",
+		"Main recipients: minister zdrowia (3)",
+		"Main recipients: minister infrastruktury (3)",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+
+	result, _ = server.handleClusterInterpellations(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "clusters": "40",
+	}))
+	if !result.IsError {
+		t.Error("Expected an error for too many clusters")
+	}
+}
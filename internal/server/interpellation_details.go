@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// interpellationDetails is a single interpellation; the API also lists the question's own attachments,
+// which the generated type does not declare
+type interpellationDetails struct {
+	sejm.Interpellation
+	Attachments *[]sejm.Attachment `json:"attachments,omitempty"`
+}
+
+// attachmentKey extracts the key and file name expected by sejm_get_interpellation_attachment from an
+// attachment download URL (.../interpellations/attachment/{key}/{fileName})
+func attachmentKey(attachment sejm.Attachment) (key, fileName string) {
+	if attachment.Name != nil {
+		fileName = *attachment.Name
+	}
+	if attachment.URL == nil {
+		return "", fileName
+	}
+	parsed, err := url.Parse(*attachment.URL)
+	if err != nil {
+		return "", fileName
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] != "attachment" {
+			continue
+		}
+		key = segments[i+1]
+		if fileName == "" && i+2 < len(segments) {
+			fileName, _ = url.PathUnescape(segments[i+2])
+		}
+		break
+	}
+	return key, fileName
+}
+
+// formatAttachments lists attachments with the identifiers needed to download them
+func formatAttachments(indent string, attachments *[]sejm.Attachment) []string {
+	if attachments == nil {
+		return nil
+	}
+	var lines []string
+	for _, attachment := range *attachments {
+		key, fileName := attachmentKey(attachment)
+		line := fmt.Sprintf("%s📎 %s", indent, valueOrDefault(fileName, "unnamed file"))
+		if key != "" {
+			line += fmt.Sprintf(" (key: %s)", key)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (s *SejmServer) handleGetInterpellationDetails(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_interpellation_details called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	num := request.GetString("num", "")
+	if n, err := strconv.Atoi(num); err != nil || n < 1 {
+		return mcp.NewToolResultError("Parameter 'num' is required and must be a positive number. Get interpellation numbers from sejm_get_interpellations."), nil
+	}
+
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/interpellations/%s", s.sejmBaseURL, term, num), nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve interpellation %s in term %d: %v", num, term, err)), nil
+	}
+	var interpellation interpellationDetails
+	if err := s.decodeAPIResponse(data, &interpellation); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse interpellation data: %v", err)), nil
+	}
+
+	title := "No title"
+	if interpellation.Title != nil {
+		title = *interpellation.Title
+	}
+	summary := []string{fmt.Sprintf("Interpellation %s (term %d): %s", num, term, title)}
+	if interpellation.From != nil && len(*interpellation.From) > 0 {
+		summary = append(summary, fmt.Sprintf("Submitted by MP IDs: %s", strings.Join(*interpellation.From, ", ")))
+	}
+	if interpellation.ReceiptDate != nil {
+		summary = append(summary, fmt.Sprintf("Received: %s", interpellation.ReceiptDate.Format("2006-01-02")))
+	}
+	if interpellation.SentDate != nil {
+		summary = append(summary, fmt.Sprintf("Sent to the government: %s", interpellation.SentDate.Format("2006-01-02")))
+	}
+	if interpellation.LastModified != nil {
+		summary = append(summary, fmt.Sprintf("Last modified: %s", interpellation.LastModified.Format("2006-01-02 15:04")))
+	}
+	if interpellation.AnswerDelayedDays != nil && *interpellation.AnswerDelayedDays > 0 {
+		summary = append(summary, fmt.Sprintf("Answer delayed: %d days", *interpellation.AnswerDelayedDays))
+	}
+
+	var results []string
+	results = append(results, "Recipients:")
+	switch {
+	case interpellation.RecipientDetails != nil && len(*interpellation.RecipientDetails) > 0:
+		for _, recipient := range *interpellation.RecipientDetails {
+			line := "  • " + valueOrDefault(stringValue(recipient.Name), "Unknown recipient")
+			if recipient.Sent != nil {
+				line += fmt.Sprintf(", sent %s", recipient.Sent.Format("2006-01-02"))
+			}
+			if recipient.AnswerDelayedDays != nil && *recipient.AnswerDelayedDays > 0 {
+				line += fmt.Sprintf(", answer delayed %d days", *recipient.AnswerDelayedDays)
+			}
+			results = append(results, line)
+		}
+	case interpellation.To != nil && len(*interpellation.To) > 0:
+		for _, recipient := range *interpellation.To {
+			results = append(results, "  • "+recipient)
+		}
+	default:
+		results = append(results, "  None listed")
+	}
+
+	if attachments := formatAttachments("  ", interpellation.Attachments); len(attachments) > 0 {
+		results = append(results, "", "Attachments of the question:")
+		results = append(results, attachments...)
+	}
+
+	replyCount := 0
+	results = append(results, "", "Replies:")
+	if interpellation.Replies != nil {
+		replyCount = len(*interpellation.Replies)
+		for i, reply := range *interpellation.Replies {
+			line := fmt.Sprintf("  %d. %s", i+1, valueOrDefault(stringValue(reply.From), "Unknown author"))
+			if reply.ReceiptDate != nil {
+				line += fmt.Sprintf(" (%s)", reply.ReceiptDate.Format("2006-01-02"))
+			}
+			if reply.Key != nil {
+				line += fmt.Sprintf(" – key: %s", *reply.Key)
+			}
+			var flags []string
+			if reply.Prolongation != nil && *reply.Prolongation {
+				flags = append(flags, "prolongation of the deadline, text not published")
+			}
+			if reply.OnlyAttachment != nil && *reply.OnlyAttachment {
+				flags = append(flags, "attachment only, no text body")
+			}
+			if len(flags) > 0 {
+				line += fmt.Sprintf(" [%s]", strings.Join(flags, "; "))
+			}
+			results = append(results, line)
+			results = append(results, formatAttachments("     ", reply.Attachments)...)
+		}
+	}
+	if replyCount == 0 {
+		results = append(results, "  No replies yet")
+	}
+	summary = append(summary, fmt.Sprintf("Replies: %d", replyCount))
+
+	if interpellation.RepeatedInterpellation != nil && len(*interpellation.RepeatedInterpellation) > 0 {
+		results = append(results, "", "Repeated interpellations:")
+		for _, repeated := range *interpellation.RepeatedInterpellation {
+			if repeated.Num != nil {
+				results = append(results, fmt.Sprintf("  • #%d %s", *repeated.Num, stringValue(repeated.Title)))
+			}
+		}
+	}
+
+	nextActions := []string{fmt.Sprintf("Read the question: sejm_get_interpellation_body with term='%d' and num='%s'", term, num)}
+	if replyCount > 0 {
+		nextActions = append(nextActions, fmt.Sprintf("Read a reply: sejm_get_interpellation_reply_body with term='%d', num='%s' and key from the list above", term, num))
+	}
+	nextActions = append(nextActions, fmt.Sprintf("Download an attachment: sejm_get_interpellation_attachment with term='%d', key and file_name from the list above", term))
+
+	response := StandardResponse{
+		Operation:   "Interpellation Details",
+		Status:      "Retrieved Successfully",
+		Summary:     summary,
+		Data:        results,
+		NextActions: nextActions,
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+func TestAttachmentKey(t *testing.T) {
+	url := "https://api.sejm.gov.pl/sejm/term10/interpellations/attachment/A1B2C3/odpowied%C5%BA.pdf"
+	key, fileName := attachmentKey(sejm.Attachment{URL: &url})
+	if key != "A1B2C3" || fileName != "odpowiedź.pdf" {
+		t.Errorf("Unexpected key and file name: %q %q", key, fileName)
+	}
+
+	name := "załącznik.docx"
+	key, fileName = attachmentKey(sejm.Attachment{Name: &name})
+	if key != "" || fileName != name {
+		t.Errorf("Expected only the file name without a URL, got %q %q", key, fileName)
+	}
+}
+
+func TestHandleGetInterpellationDetails(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/interpellations/42": `{
+			"num": 42, "title": "Interpelacja w sprawie kolei", "from": ["12", "34"],
+			"receiptDate": "2024-03-01", "sentDate": "2024-03-04", "answerDelayedDays": 5,
+			"recipientDetails": [{"name": "minister infrastruktury", "sent": "2024-03-04", "answerDelayedDays": 5}],
+			"attachments": [{"name": "mapa.pdf", "URL": "https://api.sejm.gov.pl/sejm/term10/interpellations/attachment/QKEY/mapa.pdf"}],
+			"replies": [
+				{"key": "R1", "from": "Sekretarz stanu w MI", "receiptDate": "2024-04-10", "prolongation": true},
+				{"key": "R2", "from": "Minister infrastruktury", "receiptDate": "2024-05-02", "onlyAttachment": true,
+				 "attachments": [{"name": "odpowiedz.pdf", "URL": "https://api.sejm.gov.pl/sejm/term10/interpellations/attachment/RKEY/odpowiedz.pdf"}]}
+			]
+		}`,
+	})
+
+	result, err := server.handleGetInterpellationDetails(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "num": "42",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Interpellation 42 (term 10): Interpelacja w sprawie kolei",
+		"Submitted by MP IDs: 12, 34",
+		"• minister infrastruktury, sent 2024-03-04, answer delayed 5 days",
+		"📎 mapa.pdf (key: QKEY)",
+		"1. Sekretarz stanu w MI (2024-04-10) – key: R1 [prolongation of the deadline, text not published]",
+		"2. Minister infrastruktury (2024-05-02) – key: R2 [attachment only, no text body]",
+		"📎 odpowiedz.pdf (key: RKEY)",
+		"Replies: 2",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+
+	result, _ = server.handleGetInterpellationDetails(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "num": "abc",
+	}))
+	if !result.IsError {
+		t.Error("Expected an error for a non-numeric interpellation number")
+	}
+}
@@ -0,0 +1,253 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Limits of sejm_get_mp_interpellation_texts
+const (
+	defaultMPInterpellationTexts = 20
+	maxMPInterpellationTexts     = 100
+	defaultDigestSentences       = 3
+	maxDigestSentences           = 10
+	maxDigestSentenceChars       = 300
+)
+
+// maxConcurrentBodyFetches limits parallel body downloads so a bulk fetch does not flood the API
+const maxConcurrentBodyFetches = 4
+
+// documentDigest is a compact description of one interpellation body or reply
+type documentDigest struct {
+	Chars     int
+	Questions int
+	Sentences []string
+	Err       error
+}
+
+// digestHTMLBody reduces an HTML body to its length, number of questions and most representative sentences
+func digestHTMLBody(content string, maxSentences int) documentDigest {
+	text := htmlToMarkdown(content)
+	digest := documentDigest{Chars: len([]rune(text)), Questions: strings.Count(text, "?")}
+	if maxSentences == 0 {
+		return digest
+	}
+	sentences, _ := extractiveSummary([]string{text}, maxSentences)
+	for _, sentence := range sentences {
+		digest.Sentences = append(digest.Sentences, truncateRunes(sentence.Text, maxDigestSentenceChars))
+	}
+	return digest
+}
+
+// bodyFetch is one body download in a bulk fetch
+type bodyFetch struct {
+	endpoint string
+	digest   documentDigest
+}
+
+// fetchBodyDigests downloads HTML bodies with limited concurrency and digests them, keeping the input order
+func (s *SejmServer) fetchBodyDigests(ctx context.Context, fetches []*bodyFetch, maxSentences int) {
+	slots := make(chan struct{}, maxConcurrentBodyFetches)
+	var wg sync.WaitGroup
+	for _, fetch := range fetches {
+		wg.Add(1)
+		go func(fetch *bodyFetch) {
+			defer wg.Done()
+			if err := acquireSlot(ctx, slots); err != nil {
+				fetch.digest.Err = err
+				return
+			}
+			defer func() { <-slots }()
+
+			data, err := s.makeTextRequest(ctx, fetch.endpoint, "html")
+			if err != nil {
+				fetch.digest.Err = err
+				return
+			}
+			fetch.digest = digestHTMLBody(string(data), maxSentences)
+		}(fetch)
+	}
+	wg.Wait()
+}
+
+// formatDigest renders a digest below its document heading
+func formatDigest(label string, digest documentDigest) []string {
+	if digest.Err != nil {
+		return []string{fmt.Sprintf("  %s: not available (%v)", label, digest.Err)}
+	}
+	lines := []string{fmt.Sprintf("  %s: %d characters, %d question marks", label, digest.Chars, digest.Questions)}
+	for _, sentence := range digest.Sentences {
+		lines = append(lines, "    › "+sentence)
+	}
+	return lines
+}
+
+func (s *SejmServer) handleGetMPInterpellationTexts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_mp_interpellation_texts called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	mpID := request.GetString("mp_id", "")
+	if id, err := strconv.Atoi(mpID); err != nil || id < 1 {
+		return mcp.NewToolResultError("Parameter 'mp_id' is required and must be a positive number. Get MP IDs from sejm_get_mps."), nil
+	}
+	includeReplies := request.GetString("include_replies", "false") == "true"
+
+	limit := defaultMPInterpellationTexts
+	if limitStr := request.GetString("limit", ""); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			limit = parsed
+		}
+		if limit > maxMPInterpellationTexts {
+			limit = maxMPInterpellationTexts
+		}
+	}
+	offset := request.GetString("offset", "0")
+	maxSentences := defaultDigestSentences
+	if sentencesStr := request.GetString("digest_sentences", ""); sentencesStr != "" {
+		if parsed, err := strconv.Atoi(sentencesStr); err == nil && parsed >= 0 {
+			maxSentences = parsed
+		}
+		if maxSentences > maxDigestSentences {
+			maxSentences = maxDigestSentences
+		}
+	}
+
+	params := map[string]string{"from": mpID, "limit": strconv.Itoa(limit), "offset": offset, "sort_by": "-receiptDate"}
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/interpellations", s.sejmBaseURL, term), params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve interpellations of MP %s in term %d: %v", mpID, term, err)), nil
+	}
+	var interpellations []sejm.Interpellation
+	if err := s.decodeAPIResponse(data, &interpellations); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse interpellations: %v", err)), nil
+	}
+	if len(interpellations) == 0 {
+		response := StandardResponse{
+			Operation:   "MP Interpellation Texts",
+			Status:      "No Results Found",
+			Summary:     []string{fmt.Sprintf("MP %s has no interpellations in term %d (offset %s)", mpID, term, offset)},
+			NextActions: []string{"Check the MP ID: sejm_get_mp_details with mp_id"},
+		}
+		return mcp.NewToolResultText(response.Format()), nil
+	}
+
+	bodies := make([]*bodyFetch, len(interpellations))
+	replies := make([][]*bodyFetch, len(interpellations))
+	var fetches []*bodyFetch
+	attachmentOnlyReplies := 0
+	for i, interpellation := range interpellations {
+		if interpellation.Num == nil {
+			continue
+		}
+		bodies[i] = &bodyFetch{endpoint: fmt.Sprintf("%s/sejm/term%d/interpellations/%d/body", s.sejmBaseURL, term, *interpellation.Num)}
+		fetches = append(fetches, bodies[i])
+		if !includeReplies || interpellation.Replies == nil {
+			continue
+		}
+		for _, reply := range *interpellation.Replies {
+			if reply.Key == nil {
+				continue
+			}
+			if reply.OnlyAttachment != nil && *reply.OnlyAttachment {
+				attachmentOnlyReplies++
+				continue
+			}
+			fetch := &bodyFetch{endpoint: fmt.Sprintf("%s/sejm/term%d/interpellations/%d/reply/%s/body", s.sejmBaseURL, term, *interpellation.Num, *reply.Key)}
+			replies[i] = append(replies[i], fetch)
+			fetches = append(fetches, fetch)
+		}
+	}
+	s.fetchBodyDigests(ctx, fetches, maxSentences)
+
+	var results []string
+	failed := 0
+	totalReplies := 0
+	for i, interpellation := range interpellations {
+		if bodies[i] == nil {
+			continue
+		}
+		title := "No title"
+		if interpellation.Title != nil {
+			title = *interpellation.Title
+		}
+		header := fmt.Sprintf("#%d", *interpellation.Num)
+		if interpellation.ReceiptDate != nil {
+			header += fmt.Sprintf(" (%s)", interpellation.ReceiptDate.Format("2006-01-02"))
+		}
+		results = append(results, fmt.Sprintf("%s %s", header, title))
+		if interpellation.To != nil && len(*interpellation.To) > 0 {
+			results = append(results, fmt.Sprintf("  To: %s", strings.Join(*interpellation.To, "; ")))
+		}
+		if bodies[i].digest.Err != nil {
+			failed++
+		}
+		results = append(results, formatDigest("Question", bodies[i].digest)...)
+
+		replyCount := 0
+		if interpellation.Replies != nil {
+			replyCount = len(*interpellation.Replies)
+		}
+		totalReplies += replyCount
+		if !includeReplies {
+			results = append(results, fmt.Sprintf("  Replies: %d", replyCount))
+		}
+		for j, reply := range replies[i] {
+			if reply.digest.Err != nil {
+				failed++
+			}
+			results = append(results, formatDigest(fmt.Sprintf("Reply %d", j+1), reply.digest)...)
+		}
+		results = append(results, "")
+	}
+
+	summary := []string{
+		fmt.Sprintf("MP %s, term %d: %d interpellations (offset %s, newest first)", mpID, term, len(interpellations), offset),
+		fmt.Sprintf("Documents downloaded: %d (up to %d at a time)", len(fetches), maxConcurrentBodyFetches),
+		fmt.Sprintf("Replies received: %d", totalReplies),
+	}
+	if failed > 0 {
+		summary = append(summary, fmt.Sprintf("Documents that could not be downloaded: %d", failed))
+	}
+
+	nextActions := []string{
+		"Read a full question: sejm_get_interpellation_body with term and num (render='markdown' for compact text)",
+		fmt.Sprintf("Next batch: sejm_get_mp_interpellation_texts with mp_id='%s' and offset='%d'", mpID, atoiOrZero(offset)+len(interpellations)),
+	}
+	if !includeReplies {
+		nextActions = append(nextActions, "Include government replies: include_replies='true'")
+	}
+
+	note := "Digests list each document's length, the number of question marks (a rough count of the questions asked) and its most representative sentences, chosen by word frequency."
+	if attachmentOnlyReplies > 0 {
+		note += fmt.Sprintf(" %d replies were published only as attachments and have no text body; use sejm_get_interpellation_attachment for them.", attachmentOnlyReplies)
+	}
+
+	response := StandardResponse{
+		Operation:   "MP Interpellation Texts",
+		Status:      "Retrieved Successfully",
+		Summary:     summary,
+		Data:        results,
+		NextActions: nextActions,
+		Note:        note,
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
+
+// atoiOrZero parses a validated numeric parameter, treating anything else as 0
+func atoiOrZero(value string) int {
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0
+	}
+	return number
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestDigestHTMLBody(t *testing.T) {
+	body := `<html><head><style>p{}</style></head><body><p>Szanowny Panie Ministrze!</p>
+<p>W związku z likwidacją szkół wiejskich w powiecie olsztyńskim mieszkańcy zgłaszają liczne problemy z dojazdem dzieci.</p>
+<p>Ile szkół wiejskich zlikwidowano w powiecie olsztyńskim w ostatnich pięciu latach? Jakie środki przeznaczono na dowóz dzieci do szkół?</p></body></html>`
+
+	digest := digestHTMLBody(body, 2)
+	if digest.Questions != 2 || len(digest.Sentences) != 2 || digest.Chars == 0 {
+		t.Fatalf("Unexpected digest: %+v", digest)
+	}
+	if strings.Contains(strings.Join(digest.Sentences, " "), "p{}") {
+		t.Errorf("Digest should skip styles: %+v", digest.Sentences)
+	}
+
+	if lengthOnly := digestHTMLBody(body, 0); len(lengthOnly.Sentences) != 0 || lengthOnly.Questions != 2 {
+		t.Errorf("Expected counts only, got %+v", lengthOnly)
+	}
+}
+
+func TestHandleGetMPInterpellationTexts(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/interpellations": `[
+			{"num": 12, "title": "Interpelacja w sprawie likwidacji szkół wiejskich", "receiptDate": "2024-03-01", "to": ["minister edukacji"],
+			 "replies": [{"key": "R1", "from": "minister edukacji"}, {"key": "R2", "onlyAttachment": true}]},
+			{"num": 7, "title": "Interpelacja w sprawie dróg lokalnych", "receiptDate": "2024-02-01", "to": ["minister infrastruktury"]}
+		]`,
+		"/sejm/term10/interpellations/12/body":          `<p>Ile szkół wiejskich zlikwidowano w powiecie olsztyńskim w ostatnich pięciu latach?</p>`,
+		"/sejm/term10/interpellations/12/reply/R1/body": `<p>W ostatnich pięciu latach w powiecie olsztyńskim zlikwidowano trzy szkoły wiejskie.</p>`,
+	})
+
+	result, err := server.handleGetMPInterpellationTexts(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "mp_id": "5", "include_replies": "true",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"MP 5, term 10: 2 interpellations",
+		"Documents downloaded: 3",
+		"#12 (2024-03-01) Interpelacja w sprawie likwidacji szkół wiejskich",
+		"To: minister edukacji",
+		"› Ile szkół wiejskich zlikwidowano",
+		"Reply 1: ",
+		"› W ostatnich pięciu latach w powiecie olsztyńskim zlikwidowano trzy szkoły wiejskie.",
+		"Question: not available",
+		"Documents that could not be downloaded: 1",
+		"1 replies were published only as attachments",
+		"offset='2'",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected %q in output, got:\n%s", expected, content)
+		}
+	}
+
+	missing, _ := server.handleGetMPInterpellationTexts(context.Background(), createMockRequest(map[string]interface{}{"term": "10"}))
+	if !missing.IsError {
+		t.Errorf("Expected an error without mp_id, got:\n%s", extractTextContent(missing))
+	}
+}
@@ -0,0 +1,445 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Job states reported by sejm_get_job_status
+const (
+	jobStatusQueued    = "queued"
+	jobStatusRunning   = "running"
+	jobStatusCompleted = "completed"
+	jobStatusFailed    = "failed"
+)
+
+// maxConcurrentJobs limits how many background jobs call the upstream APIs at the same time
+const maxConcurrentJobs = 2
+
+// maxRetainedJobs is the number of jobs kept before the oldest finished ones are discarded
+const maxRetainedJobs = 100
+
+// jobTimeout bounds the run time of a single background job
+const jobTimeout = 15 * time.Minute
+
+// asyncParamDescription documents the async parameter added to long-running tools
+const asyncParamDescription = "Optional. Set to 'true' to run this analysis as a background job: the call returns a job ID immediately, then poll sejm_get_job_status and fetch the output with sejm_get_job_result. Use for large ranges that may exceed the client's request timeout."
+
+// asyncTools lists tools that fan out into many API calls and accept async='true'
+var asyncTools = map[string]bool{
+	"sejm_find_defections":                true,
+	"sejm_get_club_changes":               true,
+	"sejm_get_committee_attendance":       true,
+	"sejm_get_committee_workload":         true,
+	"sejm_list_committee_transcripts":     true,
+	"sejm_compare_mps":                    true,
+	"sejm_get_mp_interpellation_texts":    true,
+	"sejm_export_oversight_corpus":        true,
+	"sejm_cluster_interpellations":        true,
+	"sejm_get_unanswered_interpellations": true,
+	"sejm_search_votings":                 true,
+	"eli_get_eu_references":               true,
+	"eli_get_tk_rulings":                  true,
+	"eli_get_tk_ruling_acts":              true,
+	"eli_fulltext_search":                 true,
+	"eli_get_publisher_years":             true,
+}
+
+// job is a tool call executed in the background. Finished jobs are persisted as JSON when a jobs directory is configured;
+// Output holds the complete tool result, including images, embedded files and structured content.
+type job struct {
+	ID         string            `json:"id"`
+	Tool       string            `json:"tool"`
+	Arguments  map[string]string `json:"arguments,omitempty"`
+	Status     string            `json:"status"`
+	CreatedAt  time.Time         `json:"createdAt"`
+	StartedAt  *time.Time        `json:"startedAt,omitempty"`
+	FinishedAt *time.Time        `json:"finishedAt,omitempty"`
+	Output     json.RawMessage   `json:"output,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// jobManager runs background jobs and keeps their state in memory and, optionally, on disk
+type jobManager struct {
+	mu     sync.Mutex
+	jobs   map[string]*job
+	dir    string
+	slots  chan struct{}
+	logger *slog.Logger
+}
+
+// newJobManager creates a job manager. When dir is not empty, jobs stored there by a previous run are loaded;
+// jobs that had not finished are marked as failed because their goroutines did not survive the restart.
+func newJobManager(dir string, logger *slog.Logger) *jobManager {
+	m := &jobManager{
+		jobs:   make(map[string]*job),
+		dir:    dir,
+		slots:  make(chan struct{}, maxConcurrentJobs),
+		logger: logger,
+	}
+	if dir == "" {
+		return m
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		logger.Warn("Cannot create jobs directory, jobs will not be persisted", slog.String("dir", dir), slog.Any("error", err))
+		m.dir = ""
+		return m
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return m
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var j job
+		if err := json.Unmarshal(data, &j); err != nil || j.ID == "" {
+			logger.Warn("Skipping unreadable job file", slog.String("file", file), slog.Any("error", err))
+			continue
+		}
+		if j.Status == jobStatusQueued || j.Status == jobStatusRunning {
+			now := time.Now()
+			j.Status = jobStatusFailed
+			j.Error = "interrupted by server restart"
+			j.FinishedAt = &now
+			m.persist(&j)
+		}
+		m.jobs[j.ID] = &j
+	}
+	logger.Info("Loaded persisted jobs", slog.String("dir", dir), slog.Int("count", len(m.jobs)))
+	return m
+}
+
+// submit registers a job and starts it in the background. It returns a snapshot of the queued job.
+func (m *jobManager) submit(tool string, arguments map[string]string, run func(ctx context.Context) (*mcp.CallToolResult, error)) job {
+	j := &job{
+		ID:        newJobID(),
+		Tool:      tool,
+		Arguments: arguments,
+		Status:    jobStatusQueued,
+		CreatedAt: time.Now(),
+	}
+
+	m.mu.Lock()
+	m.jobs[j.ID] = j
+	m.pruneLocked()
+	m.persist(j)
+	snapshot := *j
+	m.mu.Unlock()
+
+	go m.run(j, run)
+	return snapshot
+}
+
+func (m *jobManager) run(j *job, run func(ctx context.Context) (*mcp.CallToolResult, error)) {
+	m.slots <- struct{}{}
+	defer func() { <-m.slots }()
+
+	m.update(j, func(j *job) {
+		now := time.Now()
+		j.Status = jobStatusRunning
+		j.StartedAt = &now
+	})
+	m.logger.Info("Background job started", slog.String("job", j.ID), slog.String("tool", j.Tool))
+
+	ctx, cancel := context.WithTimeout(context.Background(), jobTimeout)
+	defer cancel()
+
+	result, err := func() (result *mcp.CallToolResult, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("job panicked: %v", r)
+			}
+		}()
+		return run(ctx)
+	}()
+
+	var output []byte
+	if err == nil && result != nil {
+		output, err = json.Marshal(result)
+	}
+	m.update(j, func(j *job) {
+		now := time.Now()
+		j.FinishedAt = &now
+		switch {
+		case err != nil:
+			j.Status = jobStatusFailed
+			j.Error = err.Error()
+		case result == nil:
+			j.Status = jobStatusFailed
+			j.Error = "tool returned no result"
+		default:
+			j.Status = jobStatusCompleted
+			j.Output = output
+		}
+	})
+	m.logger.Info("Background job finished", slog.String("job", j.ID), slog.String("status", j.Status))
+}
+
+// update applies a change to a job under the lock and persists it
+func (m *jobManager) update(j *job, change func(j *job)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	change(j)
+	m.persist(j)
+}
+
+// get returns a copy of a job so callers can read it without holding the lock
+func (m *jobManager) get(id string) (job, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j, ok := m.jobs[id]
+	if !ok {
+		return job{}, false
+	}
+	return *j, true
+}
+
+// pruneLocked discards the oldest finished jobs above maxRetainedJobs. The caller must hold the lock.
+func (m *jobManager) pruneLocked() {
+	if len(m.jobs) <= maxRetainedJobs {
+		return
+	}
+	var finished []*job
+	for _, j := range m.jobs {
+		if j.Status == jobStatusCompleted || j.Status == jobStatusFailed {
+			finished = append(finished, j)
+		}
+	}
+	sort.Slice(finished, func(i, k int) bool {
+		return finished[i].CreatedAt.Before(finished[k].CreatedAt)
+	})
+	for _, j := range finished {
+		if len(m.jobs) <= maxRetainedJobs {
+			break
+		}
+		delete(m.jobs, j.ID)
+		if m.dir != "" {
+			_ = os.Remove(m.jobFile(j.ID))
+		}
+	}
+}
+
+// persist writes a job to the jobs directory, replacing the previous file atomically
+func (m *jobManager) persist(j *job) {
+	if m.dir == "" {
+		return
+	}
+	data, err := json.Marshal(j)
+	if err != nil {
+		m.logger.Warn("Failed to encode job", slog.String("job", j.ID), slog.Any("error", err))
+		return
+	}
+	tmp := m.jobFile(j.ID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		m.logger.Warn("Failed to persist job", slog.String("job", j.ID), slog.Any("error", err))
+		return
+	}
+	if err := os.Rename(tmp, m.jobFile(j.ID)); err != nil {
+		m.logger.Warn("Failed to persist job", slog.String("job", j.ID), slog.Any("error", err))
+	}
+}
+
+func (m *jobManager) jobFile(id string) string {
+	return filepath.Join(m.dir, id+".json")
+}
+
+// newJobID returns a random job ID. The ID is a bearer secret: anyone who knows it can read the job's arguments
+// and output, so jobs are never listed and IDs carry 128 random bits.
+func newJobID() string {
+	buf := make([]byte, 16)
+	// crypto/rand does not fail on supported platforms; a predictable fallback would make IDs guessable
+	_, _ = rand.Read(buf)
+	return "job-" + hex.EncodeToString(buf)
+}
+
+// resultText joins the text content of a tool result
+func resultText(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, content := range result.Content {
+		if textContent, ok := mcp.AsTextContent(content); ok {
+			parts = append(parts, textContent.Text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// jobArguments converts normalized tool arguments into strings for display and persistence
+func jobArguments(request mcp.CallToolRequest) map[string]string {
+	arguments := make(map[string]string)
+	for name, value := range request.GetArguments() {
+		if name == "async" {
+			continue
+		}
+		arguments[name] = fmt.Sprint(value)
+	}
+	return arguments
+}
+
+// withoutAsync returns a copy of the request with the async flag removed, so the job runs the tool synchronously
+func withoutAsync(request mcp.CallToolRequest) mcp.CallToolRequest {
+	arguments := make(map[string]any)
+	for name, value := range request.GetArguments() {
+		if name != "async" {
+			arguments[name] = value
+		}
+	}
+	request.Params.Arguments = arguments
+	return request
+}
+
+// jobSubmittedResult describes a freshly submitted job
+func jobSubmittedResult(j job) *mcp.CallToolResult {
+	response := StandardResponse{
+		Operation: fmt.Sprintf("Background Job %s", j.ID),
+		Status:    "Job Submitted",
+		Summary: []string{
+			fmt.Sprintf("Job ID: %s", j.ID),
+			fmt.Sprintf("Tool: %s", j.Tool),
+			fmt.Sprintf("Status: %s", j.Status),
+		},
+		NextActions: []string{
+			fmt.Sprintf("Check progress: sejm_get_job_status with job_id='%s'", j.ID),
+			fmt.Sprintf("Fetch the output when completed: sejm_get_job_result with job_id='%s'", j.ID),
+		},
+		Note: fmt.Sprintf("The job runs on the server for up to %s. Results are kept for the %d most recent jobs. The job ID is the only key to the job and its output; anyone who has it can read them.", jobTimeout, maxRetainedJobs),
+	}
+	return mcp.NewToolResultText(response.Format())
+}
+
+// formatJob renders job metadata for status responses
+func formatJob(j job) []string {
+	lines := []string{
+		fmt.Sprintf("Job ID: %s", j.ID),
+		fmt.Sprintf("Tool: %s", j.Tool),
+		fmt.Sprintf("Status: %s", j.Status),
+		fmt.Sprintf("Created: %s", j.CreatedAt.Format("2006-01-02 15:04:05")),
+	}
+	if j.StartedAt != nil {
+		lines = append(lines, fmt.Sprintf("Started: %s", j.StartedAt.Format("2006-01-02 15:04:05")))
+	}
+	if j.FinishedAt != nil {
+		lines = append(lines, fmt.Sprintf("Finished: %s", j.FinishedAt.Format("2006-01-02 15:04:05")))
+		if j.StartedAt != nil {
+			lines = append(lines, fmt.Sprintf("Duration: %s", j.FinishedAt.Sub(*j.StartedAt).Round(time.Millisecond)))
+		}
+	}
+	if len(j.Arguments) > 0 {
+		names := make([]string, 0, len(j.Arguments))
+		for name := range j.Arguments {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var arguments []string
+		for _, name := range names {
+			arguments = append(arguments, fmt.Sprintf("%s=%s", name, j.Arguments[name]))
+		}
+		lines = append(lines, fmt.Sprintf("Arguments: %s", strings.Join(arguments, ", ")))
+	}
+	if j.Error != "" {
+		lines = append(lines, fmt.Sprintf("Error: %s", j.Error))
+	}
+	return lines
+}
+
+func (s *SejmServer) registerJobTools() {
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_job_status",
+		Description: "Check the status of a background job started with async='true' on long-running analysis tools (e.g. sejm_find_defections, sejm_search_votings, eli_get_tk_ruling_acts). Returns the job state (queued, running, completed, failed), timing and arguments. Jobs are not listed: the job ID returned on submission is the only way to reach a job, so keep it private.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"job_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Job ID returned when the job was submitted (e.g., 'job-1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d').",
+				},
+			},
+			Required: []string{"job_id"},
+		},
+	}, s.handleGetJobStatus)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_job_result",
+		Description: "Fetch the output of a completed background job started with async='true'. Returns exactly what the original tool would have returned. If the job is still running, reports its status instead.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"job_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Job ID returned when the job was submitted.",
+				},
+			},
+			Required: []string{"job_id"},
+		},
+	}, s.handleGetJobResult)
+}
+
+func (s *SejmServer) handleGetJobStatus(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	jobID := request.GetString("job_id", "")
+	if jobID == "" {
+		return mcp.NewToolResultError("Parameter 'job_id' is required. Use the job ID returned when the job was submitted."), nil
+	}
+
+	j, ok := s.jobs.get(jobID)
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("Job '%s' not found. It may have expired; only the %d most recent jobs are kept.", jobID, maxRetainedJobs)), nil
+	}
+
+	var nextActions []string
+	switch j.Status {
+	case jobStatusCompleted:
+		nextActions = append(nextActions, fmt.Sprintf("Fetch the output: sejm_get_job_result with job_id='%s'", j.ID))
+	case jobStatusQueued, jobStatusRunning:
+		nextActions = append(nextActions, fmt.Sprintf("Poll again shortly: sejm_get_job_status with job_id='%s'", j.ID))
+	default:
+		nextActions = append(nextActions, fmt.Sprintf("Retry synchronously or resubmit: %s with async='true'", j.Tool))
+	}
+
+	response := StandardResponse{
+		Operation:   fmt.Sprintf("Background Job %s", j.ID),
+		Status:      "Retrieved Successfully",
+		Summary:     formatJob(j),
+		NextActions: nextActions,
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
+
+func (s *SejmServer) handleGetJobResult(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	jobID := request.GetString("job_id", "")
+	if jobID == "" {
+		return mcp.NewToolResultError("Parameter 'job_id' is required. Use the job ID returned when the job was submitted."), nil
+	}
+
+	j, ok := s.jobs.get(jobID)
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("Job '%s' not found. It may have expired; only the %d most recent jobs are kept.", jobID, maxRetainedJobs)), nil
+	}
+
+	switch j.Status {
+	case jobStatusCompleted:
+		result, err := mcp.ParseCallToolResult(&j.Output)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("The stored output of job %s (%s) cannot be read: %v. Resubmit the job.", j.ID, j.Tool, err)), nil
+		}
+		return result, nil
+	case jobStatusFailed:
+		return mcp.NewToolResultError(fmt.Sprintf("Job %s (%s) failed: %s", j.ID, j.Tool, j.Error)), nil
+	default:
+		return mcp.NewToolResultText(fmt.Sprintf("Job %s (%s) is %s. Poll sejm_get_job_status with job_id='%s' and fetch the result once it is completed.", j.ID, j.Tool, j.Status, j.ID)), nil
+	}
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// waitForJob polls a job until it finishes or the test deadline passes
+func waitForJob(t *testing.T, m *jobManager, id string) job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if j, ok := m.get(id); ok && (j.Status == jobStatusCompleted || j.Status == jobStatusFailed) {
+			return j
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Job %s did not finish in time", id)
+	return job{}
+}
+
+func TestAsyncToolCallRunsAsJob(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/DU/1993/78/references": `{
+			"Orzeczenie TK": [
+				{"id": "DU/2021/175", "act": {"ELI": "DU/2021/175", "title": "Wyrok Trybunału Konstytucyjnego z dnia 22 października 2020 r. sygn. akt K 1/20"}}
+			]
+		}`,
+	})
+
+	message := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"eli_get_tk_rulings","arguments":{"publisher":"DU","year":1993,"position":78,"async":"true"}}}`
+	response := server.server.HandleMessage(context.Background(), json.RawMessage(message))
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("Failed to encode response: %v", err)
+	}
+	jobID := regexp.MustCompile(`job-[0-9a-f]{32}`).FindString(string(encoded))
+	if jobID == "" || !strings.Contains(string(encoded), "Job Submitted") {
+		t.Fatalf("Expected job submission, got: %s", encoded)
+	}
+
+	j := waitForJob(t, server.jobs, jobID)
+	if j.Status != jobStatusCompleted || j.Arguments["year"] != "1993" || j.Arguments["async"] != "" {
+		t.Fatalf("Unexpected job state: %+v", j)
+	}
+
+	result, err := server.handleGetJobResult(context.Background(), createMockRequest(map[string]interface{}{"job_id": jobID}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	if content := extractTextContent(result); !strings.Contains(content, "K 1/20 - judgment (wyrok)") {
+		t.Errorf("Expected tool output in job result, got: %s", content)
+	}
+
+	status, _ := server.handleGetJobStatus(context.Background(), createMockRequest(map[string]interface{}{"job_id": jobID}))
+	if content := extractTextContent(status); !strings.Contains(content, "Status: completed") || !strings.Contains(content, "Tool: eli_get_tk_rulings") {
+		t.Errorf("Unexpected job status output: %s", content)
+	}
+
+	missing, _ := server.handleGetJobResult(context.Background(), createMockRequest(map[string]interface{}{"job_id": "job-unknown"}))
+	if !missing.IsError {
+		t.Error("Expected error for unknown job")
+	}
+
+	// Jobs are reachable only by their ID, so other clients of a shared server cannot find them
+	listed, _ := server.handleGetJobStatus(context.Background(), createMockRequest(map[string]interface{}{}))
+	if !listed.IsError || strings.Contains(extractTextContent(listed), jobID) {
+		t.Errorf("Expected job_id to be required, got: %s", extractTextContent(listed))
+	}
+}
+
+func TestJobManagerPersistence(t *testing.T) {
+	dir := t.TempDir()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	manager := newJobManager(dir, logger)
+	submitted := manager.submit("sejm_find_defections", map[string]string{"sitting": "7"}, func(context.Context) (*mcp.CallToolResult, error) {
+		result := mcp.NewToolResultText("analysis output")
+		result.Content = append(result.Content, mcp.NewImageContent("aW1hZ2U=", "image/png"))
+		result.StructuredContent = map[string]interface{}{"defections": 2}
+		return result, nil
+	})
+	waitForJob(t, manager, submitted.ID)
+
+	interrupted := job{ID: "job-interrupted", Tool: "sejm_search_votings", Status: jobStatusRunning, CreatedAt: time.Now()}
+	data, _ := json.Marshal(interrupted)
+	if err := os.WriteFile(filepath.Join(dir, interrupted.ID+".json"), data, 0o644); err != nil {
+		t.Fatalf("Failed to write job file: %v", err)
+	}
+
+	reloaded := newJobManager(dir, logger)
+	if j, ok := reloaded.get(submitted.ID); !ok || j.Status != jobStatusCompleted {
+		t.Errorf("Expected completed job to survive restart, got %+v", j)
+	}
+	server := NewSejmServer()
+	server.jobs = reloaded
+	result, _ := server.handleGetJobResult(context.Background(), createMockRequest(map[string]interface{}{"job_id": submitted.ID}))
+	if result.IsError || len(result.Content) != 2 || extractTextContent(result) != "analysis output" {
+		t.Fatalf("Expected the text and the image of the stored result, got %+v", result)
+	}
+	if image, ok := result.Content[1].(mcp.ImageContent); !ok || image.MIMEType != "image/png" || result.StructuredContent == nil {
+		t.Errorf("Expected image and structured content to survive restart, got %+v", result)
+	}
+	if j, ok := reloaded.get(interrupted.ID); !ok || j.Status != jobStatusFailed || !strings.Contains(j.Error, "restart") {
+		t.Errorf("Expected running job to be marked as failed after restart, got %+v", j)
+	}
+}
@@ -0,0 +1,350 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxJointSittingDays bounds the days sejm_list_joint_sittings scans, one request per day
+const maxJointSittingDays = 31
+
+const (
+	// jointByAPI marks meetings linked by the jointWith field of their sittings
+	jointByAPI = "jointWith"
+	// jointByMatch marks meetings whose sittings share the date, start time, room and agenda
+	jointByMatch = "same date, start time, room and agenda"
+)
+
+// committeeMeeting is one meeting of one or more committees. The Sejm API lists a joint sitting once under
+// each committee, with its own sitting number; a meeting brings them together.
+type committeeMeeting struct {
+	Committees []string `json:"committees"`
+	Date       string   `json:"date,omitempty"`
+	Start      string   `json:"start,omitempty"`
+	End        string   `json:"end,omitempty"`
+	Room       string   `json:"room,omitempty"`
+	Agenda     string   `json:"agenda,omitempty"`
+	Cancelled  bool     `json:"cancelled,omitempty"`
+	DetectedBy string   `json:"detectedBy,omitempty"`
+}
+
+// joint tells whether more than one committee met
+func (m committeeMeeting) joint() bool {
+	return len(m.Committees) > 1
+}
+
+// committeeSittingRef names a committee sitting, e.g. "ENM #12"
+func committeeSittingRef(code *string, num *int32) string {
+	ref := valueOrDefault(stringValue(code), "?")
+	if num != nil {
+		ref += fmt.Sprintf(" #%d", *num)
+	}
+	return ref
+}
+
+// jointMatchKey identifies the meeting a sitting belongs to when the API does not link it: sittings at the same
+// time in the same room with the same agenda are one meeting. Sittings missing any of these are not matched.
+func jointMatchKey(sitting sejm.CommitteeSitting) string {
+	if sitting.StartDateTime == nil || stringValue(sitting.Room) == "" || stringValue(sitting.Agenda) == "" {
+		return ""
+	}
+	agenda := normalizePolish(strings.Join(strings.Fields(htmlToPlainText(*sitting.Agenda)), " "))
+	room := normalizePolish(strings.Join(strings.Fields(*sitting.Room), " "))
+	return sitting.StartDateTime.Format("2006-01-02T15:04") + "|" + room + "|" + agenda
+}
+
+// mergeJointSittings groups the sittings of a listing into meetings, in the order of their first sitting. Sittings
+// are joined when one lists the other in jointWith, or when they share the date, start time, room and agenda.
+// Committees named in jointWith but missing from the listing are still named in the meeting.
+func mergeJointSittings(sittings []sejm.CommitteeSitting) []committeeMeeting {
+	parent := make([]int, len(sittings))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(a, b int) {
+		if ra, rb := find(a), find(b); ra != rb {
+			parent[max(ra, rb)] = min(ra, rb)
+		}
+	}
+
+	byRef := make(map[string]int, len(sittings))
+	for i, sitting := range sittings {
+		byRef[committeeSittingRef(sitting.Code, sitting.Num)] = i
+	}
+	linked := make(map[int]bool)
+	byMatch := make(map[string]int)
+	for i, sitting := range sittings {
+		if sitting.JointWith != nil {
+			for _, other := range *sitting.JointWith {
+				if j, ok := byRef[committeeSittingRef(other.Code, other.Num)]; ok {
+					union(i, j)
+					linked[i], linked[j] = true, true
+				}
+			}
+		}
+		if key := jointMatchKey(sitting); key != "" {
+			if j, ok := byMatch[key]; ok {
+				union(i, j)
+			} else {
+				byMatch[key] = i
+			}
+		}
+	}
+
+	var meetings []committeeMeeting
+	index := make(map[int]int)
+	for i, sitting := range sittings {
+		root := find(i)
+		position, ok := index[root]
+		if !ok {
+			meeting := committeeMeeting{Room: stringValue(sitting.Room), Cancelled: sitting.Status != nil && *sitting.Status == sejm.SittingStatusCANCELLED}
+			if sitting.Date != nil {
+				meeting.Date = sitting.Date.Format("2006-01-02")
+			}
+			if sitting.StartDateTime != nil {
+				meeting.Start = sitting.StartDateTime.Format("15:04")
+				if meeting.Date == "" {
+					meeting.Date = sitting.StartDateTime.Format("2006-01-02")
+				}
+			}
+			if sitting.EndDateTime != nil {
+				meeting.End = sitting.EndDateTime.Format("15:04")
+			}
+			if sitting.Agenda != nil {
+				meeting.Agenda = strings.Join(strings.Fields(htmlToPlainText(*sitting.Agenda)), " ")
+			}
+			position = len(meetings)
+			index[root] = position
+			meetings = append(meetings, meeting)
+		}
+		meeting := &meetings[position]
+		if ref := committeeSittingRef(sitting.Code, sitting.Num); !containsString(meeting.Committees, ref) {
+			meeting.Committees = append(meeting.Committees, ref)
+		}
+		if sitting.JointWith != nil {
+			for _, other := range *sitting.JointWith {
+				if ref := committeeSittingRef(other.Code, other.Num); !containsString(meeting.Committees, ref) {
+					meeting.Committees = append(meeting.Committees, ref)
+				}
+			}
+			if len(*sitting.JointWith) > 0 {
+				meeting.DetectedBy = jointByAPI
+			}
+		}
+		if linked[i] {
+			meeting.DetectedBy = jointByAPI
+		}
+	}
+	for i := range meetings {
+		sort.Strings(meetings[i].Committees)
+		if meetings[i].joint() && meetings[i].DetectedBy == "" {
+			meetings[i].DetectedBy = jointByMatch
+		}
+	}
+	return meetings
+}
+
+// includes tells whether a committee took part in the meeting
+func (m committeeMeeting) includes(code string) bool {
+	for _, ref := range m.Committees {
+		if strings.HasPrefix(ref, code+" #") || ref == code {
+			return true
+		}
+	}
+	return false
+}
+
+// line renders a meeting for listings, e.g. "ENM #12 + SUE #30 (joint) 10:00 in sala 118: agenda"
+func (m committeeMeeting) line(agendaRunes int) string {
+	line := strings.Join(m.Committees, " + ")
+	if m.joint() {
+		line += " (joint)"
+	}
+	if m.Start != "" {
+		line += " " + m.Start
+		if m.End != "" {
+			line += "-" + m.End
+		}
+	}
+	if m.Room != "" {
+		line += " in " + m.Room
+	}
+	if m.Cancelled {
+		line += " [cancelled]"
+	}
+	if m.Agenda != "" && agendaRunes > 0 {
+		line += ": " + truncateRunes(m.Agenda, agendaRunes)
+	}
+	return line
+}
+
+func (s *SejmServer) handleListJointSittings(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_list_joint_sittings called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	from, err := parseDefectionDate("date_from", request.GetString("date_from", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if from.IsZero() {
+		return mcp.NewToolResultError("Parameter 'date_from' is required (YYYY-MM-DD), e.g. date_from='2024-03-04'."), nil
+	}
+	to, err := parseDefectionDate("date_to", request.GetString("date_to", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if to.IsZero() {
+		to = from
+	}
+	if to.Before(from) {
+		return mcp.NewToolResultError("Parameter 'date_to' must not be earlier than 'date_from'."), nil
+	}
+	days := int(to.Sub(from).Hours()/24) + 1
+	if days > maxJointSittingDays {
+		return mcp.NewToolResultError(fmt.Sprintf("The range spans %d days; at most %d days can be scanned at once, one request per day.", days, maxJointSittingDays)), nil
+	}
+	committee := strings.ToUpper(strings.TrimSpace(request.GetString("committee_code", "")))
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	dates := make([]string, days)
+	for i := range dates {
+		dates[i] = from.AddDate(0, 0, i).Format("2006-01-02")
+	}
+	perDay := make([][]committeeMeeting, days)
+	sittingCounts := make([]int, days)
+	errs := make([]error, days)
+	slots := make(chan struct{}, maxConcurrentBodyFetches)
+	var wg sync.WaitGroup
+	for i, date := range dates {
+		wg.Add(1)
+		go func(i int, date string) {
+			defer wg.Done()
+			if err := acquireSlot(ctx, slots); err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { <-slots }()
+			data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/committees/sittings/%s", s.sejmBaseURL, term, date), nil)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			var sittings []sejm.CommitteeSitting
+			if err := s.decodeAPIResponse(data, &sittings); err != nil {
+				errs[i] = fmt.Errorf("failed to parse committee sittings: %w", err)
+				return
+			}
+			sittingCounts[i] = len(sittings)
+			perDay[i] = mergeJointSittings(sittings)
+		}(i, date)
+	}
+	wg.Wait()
+
+	coverage := newSourceCoverage("days")
+	sittings, meetings := 0, 0
+	var joint []committeeMeeting
+	for i, date := range dates {
+		if errs[i] != nil {
+			coverage.fail(date, errs[i])
+			continue
+		}
+		coverage.succeeded()
+		sittings += sittingCounts[i]
+		meetings += len(perDay[i])
+		for _, meeting := range perDay[i] {
+			if !meeting.joint() {
+				continue
+			}
+			if committee != "" && !meeting.includes(committee) {
+				continue
+			}
+			joint = append(joint, meeting)
+		}
+	}
+	if coverage.total == len(coverage.failed) {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve committee sittings: %s. Please try again.", strings.Join(coverage.unavailable()[1:], "; "))), nil
+	}
+
+	period := from.Format("2006-01-02")
+	if days > 1 {
+		period += " to " + to.Format("2006-01-02")
+	}
+	if format == "json" {
+		out, _ := json.MarshalIndent(map[string]interface{}{
+			"term":              term,
+			"from":              from.Format("2006-01-02"),
+			"to":                to.Format("2006-01-02"),
+			"committeeSittings": sittings,
+			"meetings":          meetings,
+			"jointMeetings":     joint,
+			"unavailable":       coverage.unavailable(),
+		}, "", "  ")
+		return coverage.result(string(out)), nil
+	}
+
+	summary := []string{
+		fmt.Sprintf("Term %d, %s: %d committee sittings listed, %d meetings after merging joint sittings", term, period, sittings, meetings),
+		fmt.Sprintf("Joint meetings: %d", len(joint)),
+	}
+	if committee != "" {
+		summary[1] = fmt.Sprintf("Joint meetings of %s: %d", committee, len(joint))
+	}
+	var data []string
+	date := ""
+	for _, meeting := range joint {
+		if meeting.Date != date {
+			if date != "" {
+				data = append(data, "")
+			}
+			date = meeting.Date
+			data = append(data, date+":")
+		}
+		line := "• " + meeting.line(250)
+		if meeting.DetectedBy == jointByMatch {
+			line += " [matched by " + jointByMatch + "]"
+		}
+		data = append(data, line)
+	}
+	status := coverage.status("Retrieved Successfully")
+	if len(joint) == 0 {
+		status = coverage.status("No Results Found")
+		data = append(data, "No joint committee sittings in this period.")
+	}
+	nextActions := []string{fmt.Sprintf("All meetings of a day: sejm_get_committee_sittings_by_date with term='%d', date='%s'", term, from.Format("2006-01-02"))}
+	if len(joint) > 0 {
+		code, num, _ := strings.Cut(joint[0].Committees[0], " #")
+		nextActions = append(nextActions, fmt.Sprintf("Details of the first meeting: sejm_get_committee_sitting_details with term='%d', committee_code='%s', sitting_number='%s'", term, code, num))
+	}
+
+	response := StandardResponse{
+		Operation:   "Joint Committee Sittings",
+		Status:      status,
+		Summary:     summary,
+		Data:        data,
+		NextActions: nextActions,
+		Unavailable: coverage.unavailable(),
+		Note:        fmt.Sprintf("The Sejm API lists a joint sitting once under each committee, with its own sitting number. Sittings are merged when the API links them (jointWith), or when they share the date, start time, room and agenda. Count meetings rather than sittings to avoid counting a joint sitting more than once. Dates are scanned day by day, at most %d days per call.", maxJointSittingDays),
+	}
+	return coverage.result(response.Format()), nil
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+const jointSittingsFixture = `[
+	{"code": "FPB", "num": 40, "date": "2024-03-06", "startDateTime": "2024-03-06T10:00:00", "room": "sala 118", "agenda": "<p>Projekt ustawy budżetowej</p>", "jointWith": [{"code": "GOS", "num": 22}]},
+	{"code": "GOS", "num": 22, "date": "2024-03-06", "startDateTime": "2024-03-06T10:00:00", "room": "sala 118", "agenda": "<p>Projekt ustawy budżetowej</p>", "jointWith": [{"code": "FPB", "num": 40}]},
+	{"code": "ASW", "num": 12, "date": "2024-03-06", "startDateTime": "2024-03-06T12:00:00", "room": "sala 24", "agenda": "Informacja ministra"},
+	{"code": "OBN", "num": 9, "date": "2024-03-06", "startDateTime": "2024-03-06T12:00:00", "room": "Sala 24 ", "agenda": "<p>Informacja  ministra</p>"},
+	{"code": "ENM", "num": 4, "date": "2024-03-06", "startDateTime": "2024-03-06T12:00:00", "room": "sala 24", "agenda": "Inny punkt"}
+]`
+
+func TestMergeJointSittings(t *testing.T) {
+	var sittings []sejm.CommitteeSitting
+	if err := json.Unmarshal([]byte(jointSittingsFixture), &sittings); err != nil {
+		t.Fatalf("Failed to parse fixture: %v", err)
+	}
+	meetings := mergeJointSittings(sittings)
+	if len(meetings) != 3 {
+		t.Fatalf("Expected 3 meetings from 5 sittings, got %+v", meetings)
+	}
+	if strings.Join(meetings[0].Committees, ",") != "FPB #40,GOS #22" || meetings[0].DetectedBy != jointByAPI {
+		t.Errorf("Expected the linked sittings to be merged, got %+v", meetings[0])
+	}
+	if strings.Join(meetings[1].Committees, ",") != "ASW #12,OBN #9" || meetings[1].DetectedBy != jointByMatch {
+		t.Errorf("Expected the sittings with the same time, room and agenda to be merged, got %+v", meetings[1])
+	}
+	if meetings[2].joint() {
+		t.Errorf("Expected a sitting with another agenda to stay on its own, got %+v", meetings[2])
+	}
+}
+
+func TestHandleListJointSittings(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/committees/sittings/2024-03-06": jointSittingsFixture,
+		"/sejm/term10/committees/sittings/2024-03-07": `[{"code": "ZDR", "num": 3, "date": "2024-03-07", "jointWith": [{"code": "SPC", "num": 5}]}]`,
+	})
+
+	result, err := server.handleListJointSittings(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "date_from": "2024-03-06", "date_to": "2024-03-08",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	output := extractTextContent(result)
+	for _, expected := range []string{
+		"Term 10, 2024-03-06 to 2024-03-08: 6 committee sittings listed, 4 meetings after merging joint sittings",
+		"Joint meetings: 3",
+		"• FPB #40 + GOS #22 (joint) 10:00 in sala 118: Projekt ustawy budżetowej",
+		"• ASW #12 + OBN #9 (joint) 12:00 in sala 24: Informacja ministra [matched by same date, start time, room and agenda]",
+		"• SPC #5 + ZDR #3 (joint)",
+		"1 of 3 days unavailable",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output, got: %s", expected, output)
+		}
+	}
+
+	result, err = server.handleListJointSittings(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "date_from": "2024-03-06", "committee_code": "gos", "format": "json",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	var listed struct {
+		Meetings      int                `json:"meetings"`
+		JointMeetings []committeeMeeting `json:"jointMeetings"`
+	}
+	if err := json.Unmarshal([]byte(extractTextContent(result)), &listed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if listed.Meetings != 3 || len(listed.JointMeetings) != 1 || listed.JointMeetings[0].Committees[1] != "GOS #22" {
+		t.Errorf("Unexpected joint meetings of GOS: %+v", listed)
+	}
+
+	if result, _ := server.handleListJointSittings(context.Background(), createMockRequest(map[string]interface{}{
+		"date_from": "2024-01-01", "date_to": "2024-03-01",
+	})); !result.IsError {
+		t.Errorf("Expected a range over %d days to be rejected", maxJointSittingDays)
+	}
+}
@@ -0,0 +1,185 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"unicode"
+)
+
+// maxLegacyTerm is the last term whose API responses carry the quirks of the data migrated from the old
+// Sejm systems
+const maxLegacyTerm = 7
+
+var (
+	// legacyTermPattern captures the term of a Sejm API endpoint
+	legacyTermPattern = regexp.MustCompile(`/sejm/term(\d+)(?:/|$)`)
+	// legacyDottedDatePattern matches Polish dates such as '5.11.2007' or '05.11.2007 r.'
+	legacyDottedDatePattern = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})\.(\d{4})(?: r\.)?$`)
+	// legacyDateTimePattern matches date-times with a space separator, without seconds or with fractions,
+	// e.g. '2007-11-05 10:00' or '2007-11-05T10:00:00.000'
+	legacyDateTimePattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})[ T](\d{2}):(\d{2})(?::(\d{2}))?(\.\d+)?$`)
+)
+
+// latin2Remnants maps the characters ISO-8859-2 Polish letters turn into when read as Windows-1250 back to
+// the letters; the two code pages differ only in these positions
+var latin2Remnants = map[rune]rune{
+	'ˇ': 'Ą', '¦': 'Ś', '¬': 'Ź', '±': 'ą', '¶': 'ś', 'Ľ': 'ź',
+}
+
+// legacyIdentifier fills identifier fields that old records leave out with the values of the endpoint path
+type legacyIdentifier struct {
+	pattern *regexp.Regexp
+	// fields are the JSON fields set from the submatches of pattern, in order
+	fields  []string
+	numeric bool
+	// list tells that the identifiers belong to every item of a list rather than to a single record
+	list bool
+}
+
+var legacyIdentifiers = []legacyIdentifier{
+	{pattern: regexp.MustCompile(`/MP/(\d+)$`), fields: []string{"id"}, numeric: true},
+	{pattern: regexp.MustCompile(`/votings/(\d+)/(\d+)$`), fields: []string{"sitting", "votingNumber"}, numeric: true},
+	{pattern: regexp.MustCompile(`/votings/(\d+)$`), fields: []string{"sitting"}, numeric: true, list: true},
+	{pattern: regexp.MustCompile(`/proceedings/(\d+)$`), fields: []string{"number"}, numeric: true},
+	{pattern: regexp.MustCompile(`/(?:interpellations|writtenQuestions)/(\d+)$`), fields: []string{"num"}, numeric: true},
+	{pattern: regexp.MustCompile(`/(?:prints|processes)/([^/]+)$`), fields: []string{"number"}},
+	{pattern: regexp.MustCompile(`/committees/([^/]+)$`), fields: []string{"code"}},
+	{pattern: regexp.MustCompile(`/clubs/([^/]+)$`), fields: []string{"id"}},
+}
+
+// isLegacyTermEndpoint reports whether an endpoint belongs to the Sejm API of terms 1-7
+func isLegacyTermEndpoint(endpoint string) bool {
+	match := legacyTermPattern.FindStringSubmatch(endpoint)
+	if match == nil {
+		return false
+	}
+	term, err := strconv.Atoi(match[1])
+	return err == nil && term <= maxLegacyTerm
+}
+
+// repairLatin2Remnants restores the Polish letters of ISO-8859-2 text that was read as Windows-1250
+// ('Wiadomo¶ci' for 'Wiadomości'). A character is only replaced next to a letter, so that '±' and '¶'
+// used as symbols stay.
+func repairLatin2Remnants(text string) string {
+	runes := []rune(text)
+	changed := false
+	for i, r := range runes {
+		letter, ok := latin2Remnants[r]
+		if !ok {
+			continue
+		}
+		if (i > 0 && unicode.IsLetter(runes[i-1])) || (i+1 < len(runes) && unicode.IsLetter(runes[i+1])) {
+			runes[i] = letter
+			changed = true
+		}
+	}
+	if !changed {
+		return text
+	}
+	return string(runes)
+}
+
+// normalizeLegacyDate rewrites the date formats of old records in the forms the API uses for newer terms:
+// '2006-01-02' for dates, including date-times at midnight, and '2006-01-02T15:04:05' for date-times
+func normalizeLegacyDate(value string) (string, bool) {
+	if match := legacyDottedDatePattern.FindStringSubmatch(value); match != nil {
+		day, _ := strconv.Atoi(match[1])
+		month, _ := strconv.Atoi(match[2])
+		if day < 1 || day > 31 || month < 1 || month > 12 {
+			return value, false
+		}
+		return fmt.Sprintf("%s-%02d-%02d", match[3], month, day), true
+	}
+	match := legacyDateTimePattern.FindStringSubmatch(value)
+	// Date-times already in the API's own form are left as they are
+	if match == nil || (value[10] == 'T' && match[4] != "" && match[5] == "") {
+		return value, false
+	}
+	seconds := match[4]
+	if seconds == "" {
+		seconds = "00"
+	}
+	if match[2] == "00" && match[3] == "00" && seconds == "00" {
+		return match[1], true
+	}
+	return fmt.Sprintf("%sT%s:%s:%s", match[1], match[2], match[3], seconds), true
+}
+
+// normalizeLegacyValue repairs the strings of a generic JSON value: text encodings and date formats
+func normalizeLegacyValue(value any) any {
+	switch typed := value.(type) {
+	case string:
+		text := repairLatin2Remnants(repairMojibake(typed))
+		if date, ok := normalizeLegacyDate(text); ok {
+			return date
+		}
+		return text
+	case []any:
+		for i, item := range typed {
+			typed[i] = normalizeLegacyValue(item)
+		}
+	case map[string]any:
+		for key, item := range typed {
+			typed[key] = normalizeLegacyValue(item)
+		}
+	}
+	return value
+}
+
+// fillLegacyIdentifiers sets the identifiers of the endpoint path on records that lack them
+func fillLegacyIdentifiers(endpoint string, value any) {
+	for _, identifier := range legacyIdentifiers {
+		match := identifier.pattern.FindStringSubmatch(endpoint)
+		if match == nil {
+			continue
+		}
+		records := []any{value}
+		if identifier.list {
+			records, _ = value.([]any)
+		}
+		for _, item := range records {
+			record, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			for i, field := range identifier.fields {
+				if current, ok := record[field]; ok && current != nil && current != "" {
+					continue
+				}
+				if identifier.numeric {
+					record[field] = json.Number(match[i+1])
+				} else {
+					record[field] = match[i+1]
+				}
+			}
+		}
+		return
+	}
+}
+
+// normalizeLegacyResponse makes a JSON response of terms 1-7 look like one of the newer terms, so tools can
+// decode it the same way: bodies that are not UTF-8 are decoded as Windows-1250, Latin-2 remnants and
+// mojibake are repaired, old date formats are rewritten and identifiers missing from single records are
+// taken from the endpoint path. Responses of newer terms and anything that is not JSON are returned as-is.
+func normalizeLegacyResponse(endpoint string, data []byte) []byte {
+	if !isLegacyTermEndpoint(endpoint) {
+		return data
+	}
+	text := []byte(decodeLegacyText(data))
+	decoder := json.NewDecoder(bytes.NewReader(text))
+	decoder.UseNumber()
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return data
+	}
+	generic = normalizeLegacyValue(generic)
+	fillLegacyIdentifiers(endpoint, generic)
+	normalized, err := json.Marshal(generic)
+	if err != nil {
+		return text
+	}
+	return normalized
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeLegacyDate(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+		changed  bool
+	}{
+		{"05.11.2007", "2007-11-05", true},
+		{"5.11.2007 r.", "2007-11-05", true},
+		{"2007-11-05 10:30", "2007-11-05T10:30:00", true},
+		{"2007-11-05 00:00:00", "2007-11-05", true},
+		{"2007-11-05T10:30:15.000", "2007-11-05T10:30:15", true},
+		{"2007-11-05T10:30:15", "2007-11-05T10:30:15", false},
+		{"2007-11-05", "2007-11-05", false},
+		{"31.13.2007", "31.13.2007", false},
+		{"druk nr 5.11", "druk nr 5.11", false},
+	}
+	for _, tc := range testCases {
+		if got, changed := normalizeLegacyDate(tc.value); got != tc.expected || changed != tc.changed {
+			t.Errorf("normalizeLegacyDate(%q) = %q, %v; want %q, %v", tc.value, got, changed, tc.expected, tc.changed)
+		}
+	}
+}
+
+func TestRepairLatin2Remnants(t *testing.T) {
+	testCases := map[string]string{
+		"Wiadomo¶ci":       "Wiadomości",
+		"¦więtokrzyskie":   "Świętokrzyskie",
+		"ż±danie":          "żądanie",
+		"wynik ± 5 głosów": "wynik ± 5 głosów",
+		"Zażółć gęślą":     "Zażółć gęślą",
+	}
+	for input, expected := range testCases {
+		if got := repairLatin2Remnants(input); got != expected {
+			t.Errorf("repairLatin2Remnants(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
+
+func TestNormalizeLegacyResponse(t *testing.T) {
+	// ISO-8859-2 bytes of 'Kraków' and 'Wiadomości' in a body that is not UTF-8
+	latin2 := []byte("{\"districtName\": \"Krak\xf3w\", \"profession\": \"Wiadomo\xb6ci\", \"birthDate\": \"05.11.1960\"}")
+	normalized := string(normalizeLegacyResponse("https://api.sejm.gov.pl/sejm/term4/MP/12", latin2))
+	for _, expected := range []string{`"districtName":"Kraków"`, `"profession":"Wiadomości"`, `"birthDate":"1960-11-05"`, `"id":12`} {
+		if !strings.Contains(normalized, expected) {
+			t.Errorf("Expected %s in %s", expected, normalized)
+		}
+	}
+
+	votings := `[{"votingNumber": 1, "date": "2005-03-01 11:15"}, {"votingNumber": 2, "sitting": 7}]`
+	normalized = string(normalizeLegacyResponse("https://api.sejm.gov.pl/sejm/term4/votings/7", []byte(votings)))
+	if strings.Count(normalized, `"sitting":7`) != 2 || !strings.Contains(normalized, `"date":"2005-03-01T11:15:00"`) {
+		t.Errorf("Expected every voting to get the sitting and a normalized date, got %s", normalized)
+	}
+
+	current := `{"birthDate": "05.11.1960"}`
+	if normalized := string(normalizeLegacyResponse("https://api.sejm.gov.pl/sejm/term10/MP/12", []byte(current))); normalized != current {
+		t.Errorf("Expected responses of newer terms to be left alone, got %s", normalized)
+	}
+}
+
+func TestLegacyTermMPDetails(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term3/MP/45": `{"firstName": "Jan", "lastName": "Kowalski", "club": "AWS", "districtName": "Wrocław", "birthDate": "1950-02-03 00:00:00", "active": false}`,
+	})
+	result, err := server.handleGetMPDetails(context.Background(), createMockRequest(map[string]interface{}{"term": "3", "mp_id": "45"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{`"id": 45`, `"birthDate": "1950-02-03"`, "Political Party/Club: AWS"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// defaultLegalStateActs is the number of candidate acts checked when max_acts is not given
+	defaultLegalStateActs = 40
+	// maxLegalStateActs caps the candidates, each of which costs a details request
+	maxLegalStateActs = 100
+)
+
+// Point-in-time states of an act relative to the as-of date
+const (
+	legalStateInForce      = "in force"
+	legalStateNotYet       = "not yet in force"
+	legalStateEnded        = "no longer in force"
+	legalStateUndetermined = "undetermined"
+)
+
+// actValidity is the period an act was binding, taken from its metadata
+type actValidity struct {
+	act   eli.Act
+	from  time.Time
+	until time.Time
+	ended string
+}
+
+// newActValidity reads the entry-into-force date and the end of validity (repeal or expiration) of an act
+func newActValidity(act eli.Act) actValidity {
+	validity := actValidity{act: act}
+	switch {
+	case act.EntryIntoForce != nil:
+		validity.from = act.EntryIntoForce.Time
+	case act.ValidFrom != nil:
+		validity.from = act.ValidFrom.Time
+	}
+	if act.RepealDate != nil {
+		validity.until, validity.ended = act.RepealDate.Time, "repealed"
+	}
+	if act.ExpirationDate != nil && (validity.until.IsZero() || act.ExpirationDate.Before(validity.until)) {
+		validity.until, validity.ended = act.ExpirationDate.Time, "expired"
+	}
+	return validity
+}
+
+// stateOn classifies the act on the given day; an act stops being binding on its repeal or expiration date
+func (v actValidity) stateOn(day time.Time) string {
+	date := day.Format("2006-01-02")
+	if v.from.IsZero() {
+		return legalStateUndetermined
+	}
+	if v.from.Format("2006-01-02") > date {
+		return legalStateNotYet
+	}
+	if !v.until.IsZero() && v.until.Format("2006-01-02") <= date {
+		return legalStateEnded
+	}
+	return legalStateInForce
+}
+
+// describe formats the act with its validity period
+func (v actValidity) describe() string {
+	line := fmt.Sprintf("• %s: %s", actAddress(v.act), valueOrDefault(stringValue(v.act.Title), "No title"))
+	var period []string
+	if !v.from.IsZero() {
+		period = append(period, "in force from "+v.from.Format("2006-01-02"))
+	}
+	if !v.until.IsZero() {
+		period = append(period, fmt.Sprintf("%s %s", v.ended, v.until.Format("2006-01-02")))
+	}
+	if v.act.Status != nil && *v.act.Status != "" {
+		period = append(period, "current status: "+*v.act.Status)
+	}
+	if len(period) > 0 {
+		line += fmt.Sprintf(" [%s]", strings.Join(period, "; "))
+	}
+	return line
+}
+
+// fetchActsDetails completes search hits with full metadata, which carries the validity dates, keeping the
+// search hit when its details cannot be retrieved
+func (s *SejmServer) fetchActsDetails(ctx context.Context, acts []eli.Act) ([]eli.Act, int) {
+	detailed := make([]eli.Act, len(acts))
+	failed := make([]bool, len(acts))
+	slots := make(chan struct{}, maxConcurrentBodyFetches)
+	var wg sync.WaitGroup
+	for i, act := range acts {
+		detailed[i] = act
+		if act.Publisher == nil || act.Year == nil || act.Pos == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, act eli.Act) {
+			defer wg.Done()
+			if err := acquireSlot(ctx, slots); err != nil {
+				failed[i] = true
+				return
+			}
+			defer func() { <-slots }()
+			data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/acts/%s/%d/%d", s.eliBaseURL, *act.Publisher, *act.Year, *act.Pos), nil)
+			if err == nil {
+				var details eli.Act
+				if err = s.decodeAPIResponse(data, &details); err == nil {
+					detailed[i] = details
+					return
+				}
+			}
+			s.logger.Warn("Failed to retrieve act details", slog.String("act", actAddress(act)), slog.Any("error", err))
+			failed[i] = true
+		}(i, act)
+	}
+	wg.Wait()
+	failures := 0
+	for _, f := range failed {
+		if f {
+			failures++
+		}
+	}
+	return detailed, failures
+}
+
+func (s *SejmServer) handleGetLegalState(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("eli_get_legal_state called", slog.Any("arguments", request.Params.Arguments))
+
+	keywords := splitKeywords(request.GetString("keyword", ""))
+	title := strings.TrimSpace(request.GetString("title", ""))
+	if len(keywords) == 0 && title == "" {
+		return mcp.NewToolResultError("Provide a topic as 'keyword' (ELI subject keywords, see eli_get_keywords) or 'title' (words in act titles)."), nil
+	}
+	asOf, err := parseDefectionDate("as_of", request.GetString("as_of", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if asOf.IsZero() {
+		return mcp.NewToolResultError("Parameter 'as_of' is required in YYYY-MM-DD format (e.g., '2015-06-30')."), nil
+	}
+	maxActs := defaultLegalStateActs
+	if value := request.GetString("max_acts", ""); value != "" {
+		maxActs, err = strconv.Atoi(value)
+		if err != nil || maxActs < 1 || maxActs > maxLegalStateActs {
+			return mcp.NewToolResultError(fmt.Sprintf("Parameter 'max_acts' must be a number between 1 and %d.", maxLegalStateActs)), nil
+		}
+	}
+
+	// Acts announced after the as-of date cannot have been binding on it
+	params := map[string]string{
+		"dateTo": asOf.Format("2006-01-02"),
+		"limit":  strconv.Itoa(maxActs),
+	}
+	if len(keywords) > 0 {
+		params["keyword"] = strings.Join(keywords, ",")
+	}
+	if title != "" {
+		params["title"] = title
+	}
+	publisher := request.GetString("publisher", "")
+	if publisher != "" {
+		params["publisher"] = publisher
+	}
+	docType := request.GetString("type", "")
+	if docType != "" {
+		params["type"] = docType
+	}
+
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/acts/search", s.eliBaseURL), params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to search Polish legal acts database: %v. Please verify your search parameters are valid.", err)), nil
+	}
+	var searchResult struct {
+		Items []eli.Act `json:"items"`
+		Count int       `json:"count"`
+	}
+	if err := s.decodeAPIResponse(data, &searchResult); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse legal acts search results: %v.", err)), nil
+	}
+
+	var topic []string
+	if len(keywords) > 0 {
+		topic = append(topic, "keywords: "+strings.Join(keywords, ", "))
+	}
+	if title != "" {
+		topic = append(topic, fmt.Sprintf("title: '%s'", title))
+	}
+	if publisher != "" {
+		topic = append(topic, "publisher: "+publisher)
+	}
+	if docType != "" {
+		topic = append(topic, "type: "+docType)
+	}
+	summary := []string{
+		fmt.Sprintf("Legal state as of %s for %s", asOf.Format("2006-01-02"), strings.Join(topic, "; ")),
+		fmt.Sprintf("Acts announced by that date: %d, checked: %d", searchResult.Count, len(searchResult.Items)),
+	}
+
+	acts, failures := s.fetchActsDetails(ctx, searchResult.Items)
+	groups := make(map[string][]actValidity)
+	for _, act := range acts {
+		validity := newActValidity(act)
+		state := validity.stateOn(asOf)
+		groups[state] = append(groups[state], validity)
+	}
+	for _, state := range []string{legalStateInForce, legalStateEnded} {
+		// Most recent first: the newest acts usually shape the legal state the most
+		sort.SliceStable(groups[state], func(i, j int) bool {
+			return groups[state][i].from.After(groups[state][j].from)
+		})
+	}
+
+	var results []string
+	for _, state := range []string{legalStateInForce, legalStateNotYet, legalStateEnded, legalStateUndetermined} {
+		summary = append(summary, fmt.Sprintf("%s: %d", strings.ToUpper(state[:1])+state[1:], len(groups[state])))
+		if len(groups[state]) == 0 {
+			continue
+		}
+		if len(results) > 0 {
+			results = append(results, "")
+		}
+		switch state {
+		case legalStateInForce:
+			results = append(results, fmt.Sprintf("In force on %s:", asOf.Format("2006-01-02")))
+		case legalStateNotYet:
+			results = append(results, "Announced but not yet in force:")
+		case legalStateEnded:
+			results = append(results, "Repealed or expired by that date:")
+		default:
+			results = append(results, "No entry-into-force date in the metadata (e.g., announcements of consolidated texts):")
+		}
+		for _, validity := range groups[state] {
+			results = append(results, validity.describe())
+		}
+	}
+
+	status := "Retrieved Successfully"
+	if len(acts) == 0 {
+		status = "No Results Found"
+	}
+	var notes []string
+	if searchResult.Count > len(searchResult.Items) {
+		notes = append(notes, fmt.Sprintf("Only %d of %d matching acts were checked; raise max_acts or narrow the topic with publisher or type.", len(searchResult.Items), searchResult.Count))
+	}
+	if failures > 0 {
+		notes = append(notes, fmt.Sprintf("Details of %d acts could not be retrieved, so their dates may be incomplete.", failures))
+	}
+
+	response := StandardResponse{
+		Operation: "Legal State as of Date",
+		Status:    status,
+		Summary:   summary,
+		Data:      results,
+		NextActions: []string{
+			"Act metadata: eli_get_act_details with publisher, year and position",
+			"Text of an act: eli_get_act_text with publisher, year and position",
+			"Amendments and repeals: eli_get_act_references with publisher, year and position",
+			"Find subject keywords: eli_get_keywords",
+		},
+		Note: strings.Join(notes, " "),
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHandleGetLegalState(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/search": `{"count": 4, "items": [
+			{"publisher": "DU", "year": 1997, "pos": 883, "title": "Ustawa o ochronie danych osobowych"},
+			{"publisher": "DU", "year": 2018, "pos": 1000, "title": "Ustawa o ochronie danych osobowych (nowa)"},
+			{"publisher": "DU", "year": 2014, "pos": 1662, "title": "Ustawa o zmianie ustawy o ochronie danych"},
+			{"publisher": "DU", "year": 2016, "pos": 922, "title": "Obwieszczenie w sprawie tekstu jednolitego"}
+		]}`,
+		"/eli/acts/DU/1997/883": `{"publisher": "DU", "year": 1997, "pos": 883, "title": "Ustawa o ochronie danych osobowych",
+			"entryIntoForce": "1998-04-30", "repealDate": "2018-05-25", "status": "uchylony"}`,
+		"/eli/acts/DU/2018/1000": `{"publisher": "DU", "year": 2018, "pos": 1000, "title": "Ustawa o ochronie danych osobowych (nowa)",
+			"entryIntoForce": "2018-05-25", "status": "obowiązujący"}`,
+		"/eli/acts/DU/2014/1662": `{"publisher": "DU", "year": 2014, "pos": 1662, "title": "Ustawa o zmianie ustawy o ochronie danych",
+			"entryIntoForce": "2015-01-01"}`,
+		"/eli/acts/DU/2016/922": `{"publisher": "DU", "year": 2016, "pos": 922, "title": "Obwieszczenie w sprawie tekstu jednolitego"}`,
+	})
+
+	result, err := server.handleGetLegalState(context.Background(), createMockRequest(map[string]interface{}{
+		"keyword": "ochrona danych osobowych", "as_of": "2016-06-30",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Legal state as of 2016-06-30 for keywords: ochrona danych osobowych",
+		"In force: 2",
+		"Not yet in force: 1",
+		"Undetermined: 1",
+		"• DU/2014/1662: Ustawa o zmianie ustawy o ochronie danych [in force from 2015-01-01]",
+		"• DU/1997/883: Ustawa o ochronie danych osobowych [in force from 1998-04-30; repealed 2018-05-25; current status: uchylony]",
+		"• DU/2018/1000: Ustawa o ochronie danych osobowych (nowa) [in force from 2018-05-25; current status: obowiązujący]",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+
+	result, _ = server.handleGetLegalState(context.Background(), createMockRequest(map[string]interface{}{"keyword": "podatki"}))
+	if !result.IsError {
+		t.Error("Expected an error without as_of")
+	}
+}
+
+func TestActValidityStateOn(t *testing.T) {
+	day := func(value string) time.Time {
+		parsed, _ := time.Parse("2006-01-02", value)
+		return parsed
+	}
+	validity := actValidity{from: day("2010-01-01"), until: day("2020-01-01"), ended: "repealed"}
+	for date, expected := range map[string]string{
+		"2009-12-31": legalStateNotYet,
+		"2010-01-01": legalStateInForce,
+		"2019-12-31": legalStateInForce,
+		"2020-01-01": legalStateEnded,
+	} {
+		if got := validity.stateOn(day(date)); got != expected {
+			t.Errorf("stateOn(%s) = %s, expected %s", date, got, expected)
+		}
+	}
+}
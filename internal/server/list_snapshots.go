@@ -0,0 +1,411 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Limits of list snapshots created with materialize='true'
+const (
+	snapshotFetchLimit      = 500
+	maxSnapshotItems        = 50000
+	defaultSnapshotPageSize = 100
+	maxSnapshotPageSize     = 500
+	maxRetainedSnapshots    = 10
+)
+
+// snapshotTTL is how long a snapshot is kept after it was last read
+const snapshotTTL = 2 * time.Hour
+
+// materializeParamDescription documents the materialize parameter added to the list tools in listSnapshotSources
+const materializeParamDescription = "Optional. Set to 'true' to download the complete result set once, with the filters of this call, and keep it on the server as a snapshot. The call returns a sejm://snapshots/... resource link to the first page instead of a list. Each page holds a nextCursor and nextUri; read the following pages with resources/read or sejm_get_list_snapshot, without querying the Sejm API again. offset and limit are ignored. Snapshots are kept for 2 hours after their last read."
+
+// pageSizeParamDescription documents the page_size parameter of snapshots
+const pageSizeParamDescription = "Optional, with materialize='true'. Number of items per snapshot page (default: 100, maximum: 500)."
+
+// listSnapshotSource describes how a list tool's complete result set is downloaded
+type listSnapshotSource struct {
+	// collection is the API path under /sejm/term{N}/
+	collection string
+	noun       string
+	// filters maps the tool's filter parameters to API parameters
+	filters map[string]string
+	// order is the sort_by used when the call sets none, so upstream pages do not shift while downloading
+	order string
+}
+
+// listSnapshotSources lists the tools whose results can exceed the context and accept materialize='true'
+var listSnapshotSources = map[string]listSnapshotSource{
+	"sejm_get_processes": {
+		collection: "processes",
+		noun:       "legislative processes",
+		filters:    map[string]string{"title": "title", "document_type": "documentType", "sort_by": "sort_by"},
+		order:      "number",
+	},
+	"sejm_get_processes_passed": {
+		collection: "processes/passed",
+		noun:       "passed legislative processes",
+		filters:    map[string]string{"title": "title", "document_type": "documentType", "sort_by": "sort_by"},
+		order:      "number",
+	},
+	"sejm_get_interpellations": {
+		collection: "interpellations",
+		noun:       "interpellations",
+		filters:    map[string]string{"sort_by": "sort_by"},
+		order:      "num",
+	},
+	"sejm_get_written_questions": {
+		collection: "writtenQuestions",
+		noun:       "written questions",
+		filters:    map[string]string{"from": "from", "to": "to", "title": "title", "since": "since", "till": "till", "delayed": "delayed"},
+		order:      "num",
+	},
+}
+
+// listSnapshot is the complete result set of a list tool call, frozen when it was downloaded. Items are kept
+// as the API returned them, so every field is preserved.
+type listSnapshot struct {
+	ID        string
+	Tool      string
+	Term      int
+	Query     map[string]string
+	Items     []json.RawMessage
+	PageSize  int
+	Truncated bool
+	CreatedAt time.Time
+	lastRead  time.Time
+}
+
+// snapshotPage is one page of a snapshot as served by the resource and sejm_get_list_snapshot
+type snapshotPage struct {
+	Snapshot   string            `json:"snapshot"`
+	Tool       string            `json:"tool"`
+	Term       int               `json:"term"`
+	Query      map[string]string `json:"query,omitempty"`
+	CreatedAt  string            `json:"createdAt"`
+	Total      int               `json:"total"`
+	Truncated  bool              `json:"truncated,omitempty"`
+	Offset     int               `json:"offset"`
+	Count      int               `json:"count"`
+	Items      []json.RawMessage `json:"items"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+	NextURI    string            `json:"nextUri,omitempty"`
+}
+
+// snapshotCursor encodes an offset into a snapshot. Snapshots never change, so a cursor always points to the
+// same items; it is opaque so clients do not build their own.
+func snapshotCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+// parseSnapshotCursor decodes a cursor; an empty cursor is the first page
+func parseSnapshotCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("malformed cursor '%s'", cursor)
+	}
+	value, ok := strings.CutPrefix(string(raw), "offset:")
+	offset, err := strconv.Atoi(value)
+	if !ok || err != nil || offset < 0 {
+		return 0, fmt.Errorf("malformed cursor '%s'", cursor)
+	}
+	return offset, nil
+}
+
+// snapshotPageURI identifies a page of a snapshot as an MCP resource
+func snapshotPageURI(id, cursor string) string {
+	return fmt.Sprintf("%ssnapshots/%s/pages/%s", attachmentResourceScheme, id, cursor)
+}
+
+// page returns the page of the snapshot that starts at the cursor
+func (snapshot *listSnapshot) page(cursor string) (snapshotPage, error) {
+	offset, err := parseSnapshotCursor(cursor)
+	if err != nil {
+		return snapshotPage{}, err
+	}
+	if offset > len(snapshot.Items) {
+		return snapshotPage{}, fmt.Errorf("cursor '%s' is past the end of snapshot %s", cursor, snapshot.ID)
+	}
+	end := min(offset+snapshot.PageSize, len(snapshot.Items))
+	page := snapshotPage{
+		Snapshot:  snapshot.ID,
+		Tool:      snapshot.Tool,
+		Term:      snapshot.Term,
+		Query:     snapshot.Query,
+		CreatedAt: snapshot.CreatedAt.Format(time.RFC3339),
+		Total:     len(snapshot.Items),
+		Truncated: snapshot.Truncated,
+		Offset:    offset,
+		Count:     end - offset,
+		Items:     snapshot.Items[offset:end],
+	}
+	if end < len(snapshot.Items) {
+		page.NextCursor = snapshotCursor(end)
+		page.NextURI = snapshotPageURI(snapshot.ID, page.NextCursor)
+	}
+	return page, nil
+}
+
+// snapshotStore keeps the snapshots in memory; the least recently read ones are discarded first
+type snapshotStore struct {
+	mu        sync.Mutex
+	snapshots map[string]*listSnapshot
+}
+
+func newSnapshotStore() *snapshotStore {
+	return &snapshotStore{snapshots: make(map[string]*listSnapshot)}
+}
+
+// add stores a snapshot, discarding expired snapshots and the least recently read ones over the limit
+func (st *snapshotStore) add(snapshot *listSnapshot) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	snapshot.lastRead = time.Now()
+	st.snapshots[snapshot.ID] = snapshot
+
+	var ids []string
+	for id, stored := range st.snapshots {
+		if time.Since(stored.lastRead) > snapshotTTL {
+			delete(st.snapshots, id)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return st.snapshots[ids[i]].lastRead.Before(st.snapshots[ids[j]].lastRead)
+	})
+	for i := 0; i < len(ids)-maxRetainedSnapshots; i++ {
+		delete(st.snapshots, ids[i])
+	}
+}
+
+// get returns a snapshot that has not expired and marks it as read
+func (st *snapshotStore) get(id string) (*listSnapshot, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	snapshot, ok := st.snapshots[id]
+	if !ok || time.Since(snapshot.lastRead) > snapshotTTL {
+		delete(st.snapshots, id)
+		return nil, false
+	}
+	snapshot.lastRead = time.Now()
+	return snapshot, true
+}
+
+func newSnapshotID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("snap-%d", time.Now().UnixNano())
+	}
+	return "snap-" + hex.EncodeToString(buf)
+}
+
+// withListSnapshot lets the list tools in listSnapshotSources materialize their complete result set; calls
+// without materialize='true' go to the tool's handler
+func (s *SejmServer) withListSnapshot(tool string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	source, ok := listSnapshotSources[tool]
+	if !ok {
+		return handler
+	}
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if request.GetString("materialize", "false") != "true" {
+			return handler(ctx, request)
+		}
+		return s.materializeList(ctx, tool, source, request)
+	}
+}
+
+// fetchAllItems downloads every page of an API list. The download stops at maxSnapshotItems; truncated
+// reports whether items were left out.
+func (s *SejmServer) fetchAllItems(ctx context.Context, endpoint string, query map[string]string, progress *progressReporter) (items []json.RawMessage, truncated bool, err error) {
+	for len(items) < maxSnapshotItems {
+		params := map[string]string{"offset": strconv.Itoa(len(items)), "limit": strconv.Itoa(snapshotFetchLimit)}
+		for name, value := range query {
+			params[name] = value
+		}
+		data, err := s.makeAPIRequest(ctx, endpoint, params)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to retrieve items from offset %d: %w", len(items), err)
+		}
+		var page []json.RawMessage
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, false, fmt.Errorf("failed to parse items from offset %d: %w", len(items), err)
+		}
+		items = append(items, page...)
+		progress.report(len(items), 0, fmt.Sprintf("Downloaded %d items", len(items)))
+		if len(page) < snapshotFetchLimit {
+			return items, false, nil
+		}
+	}
+	return items[:maxSnapshotItems], true, nil
+}
+
+// materializeList downloads the complete result set of a list tool call into a snapshot and returns a link
+// to its first page
+func (s *SejmServer) materializeList(ctx context.Context, tool string, source listSnapshotSource, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info(tool+" materialize called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	pageSize := defaultSnapshotPageSize
+	if value := request.GetString("page_size", ""); value != "" {
+		pageSize, err = strconv.Atoi(value)
+		if err != nil || pageSize < 1 || pageSize > maxSnapshotPageSize {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid page_size '%s': use a number between 1 and %d.", value, maxSnapshotPageSize)), nil
+		}
+	}
+	query := make(map[string]string)
+	for param, apiParam := range source.filters {
+		if value := request.GetString(param, ""); value != "" {
+			query[apiParam] = value
+		}
+	}
+	if query["sort_by"] == "" && source.order != "" {
+		query["sort_by"] = source.order
+	}
+
+	endpoint := fmt.Sprintf("%s/sejm/term%d/%s", s.sejmBaseURL, term, source.collection)
+	items, truncated, err := s.fetchAllItems(ctx, endpoint, query, s.newProgressReporter(ctx, request))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to download all %s of term %d: %v. Please try again.", source.noun, term, err)), nil
+	}
+	snapshot := &listSnapshot{
+		ID:        newSnapshotID(),
+		Tool:      tool,
+		Term:      term,
+		Query:     query,
+		Items:     items,
+		PageSize:  pageSize,
+		Truncated: truncated,
+		CreatedAt: time.Now(),
+	}
+	s.snapshots.add(snapshot)
+
+	first := snapshotCursor(0)
+	pages := (len(items) + pageSize - 1) / pageSize
+	summary := []string{
+		fmt.Sprintf("Snapshot: %s", snapshot.ID),
+		fmt.Sprintf("Term: %d", term),
+		fmt.Sprintf("Items: %d %s", len(items), source.noun),
+		fmt.Sprintf("Pages: %d of up to %d items", pages, pageSize),
+	}
+	var filters []string
+	for name, value := range query {
+		filters = append(filters, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(filters)
+	summary = append(summary, fmt.Sprintf("Query: %s", strings.Join(filters, ", ")))
+
+	notes := []string{fmt.Sprintf("The snapshot holds the %s as they were at %s and does not change, so cursors stay valid; it is kept for %s after its last read.", source.noun, snapshot.CreatedAt.Format("2006-01-02 15:04"), snapshotTTL)}
+	status := "Retrieved Successfully"
+	if truncated {
+		status = "Partially Retrieved"
+		notes = append(notes, fmt.Sprintf("The download stopped at %d items; narrow the filters to cover the rest.", maxSnapshotItems))
+	}
+	response := StandardResponse{
+		Operation: "List Snapshot",
+		Status:    status,
+		Summary:   summary,
+		Data:      []string{fmt.Sprintf("First page: %s", snapshotPageURI(snapshot.ID, first))},
+		NextActions: []string{
+			fmt.Sprintf("Read the first page: resources/read %s, or sejm_get_list_snapshot with snapshot_id='%s'", snapshotPageURI(snapshot.ID, first), snapshot.ID),
+			"Read the following pages: pass the nextCursor of each page as cursor, or read its nextUri",
+		},
+		Note: strings.Join(notes, " "),
+	}
+	description := fmt.Sprintf("%d %s of term %d, %d per page", len(items), source.noun, term, pageSize)
+	return &mcp.CallToolResult{Content: []mcp.Content{
+		mcp.NewTextContent(response.Format()),
+		mcp.NewResourceLink(snapshotPageURI(snapshot.ID, first), snapshot.ID, description, "application/json"),
+	}}, nil
+}
+
+// snapshotPageJSON reads a page of a stored snapshot as JSON
+func (s *SejmServer) snapshotPageJSON(id, cursor string) (string, error) {
+	snapshot, ok := s.snapshots.get(id)
+	if !ok {
+		return "", fmt.Errorf("snapshot %s does not exist or has expired; create a new one with materialize='true'", id)
+	}
+	page, err := snapshot.page(cursor)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(page, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// registerSnapshotTools exposes the pages of snapshots as a resource template and through a tool, for clients
+// that cannot read resources
+func (s *SejmServer) registerSnapshotTools() {
+	s.server.AddResourceTemplate(
+		mcp.NewResourceTemplate(attachmentResourceScheme+"snapshots/{snapshot_id}/pages/{cursor}", "List snapshot page",
+			mcp.WithTemplateDescription("One page of a complete list result set downloaded with materialize='true'. Each page gives the nextUri of the following one."),
+			mcp.WithTemplateMIMEType("application/json")),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			s.logger.Info("Snapshot resource read", slog.String("uri", request.Params.URI))
+			path := strings.TrimPrefix(request.Params.URI, attachmentResourceScheme+"snapshots/")
+			id, cursor, ok := strings.Cut(path, "/pages/")
+			if !ok {
+				return nil, fmt.Errorf("invalid snapshot page URI %s", request.Params.URI)
+			}
+			text, err := s.snapshotPageJSON(id, cursor)
+			if err != nil {
+				return nil, err
+			}
+			return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "application/json", Text: text}}, nil
+		})
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_list_snapshot",
+		Description: "Read one page of a snapshot created by calling a list tool (sejm_get_processes, sejm_get_processes_passed, sejm_get_interpellations, sejm_get_written_questions) with materialize='true'. Returns the page as JSON with the items exactly as the API returned them, the total, and the nextCursor for the following page. Pages are served from the server's memory, so iterating over them does not query the Sejm API again.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"snapshot_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Snapshot ID returned by the materialize='true' call (e.g., 'snap-1a2b3c4d5e6f7a8b').",
+				},
+				"cursor": map[string]interface{}{
+					"type":        "string",
+					"description": "The nextCursor of the previous page. Omit for the first page.",
+				},
+			},
+			Required: []string{"snapshot_id"},
+		},
+	}, s.handleGetListSnapshot)
+}
+
+func (s *SejmServer) handleGetListSnapshot(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_list_snapshot called", slog.Any("arguments", request.Params.Arguments))
+
+	id := request.GetString("snapshot_id", "")
+	if id == "" {
+		return mcp.NewToolResultError("The 'snapshot_id' parameter is required. Create a snapshot by calling a list tool with materialize='true'."), nil
+	}
+	text, err := s.snapshotPageJSON(id, request.GetString("cursor", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Cannot read the snapshot: %v.", err)), nil
+	}
+	return mcp.NewToolResultText(text), nil
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestSnapshotCursor(t *testing.T) {
+	for _, offset := range []int{0, 100, 12345} {
+		if decoded, err := parseSnapshotCursor(snapshotCursor(offset)); err != nil || decoded != offset {
+			t.Errorf("Cursor of offset %d decoded to %d, %v", offset, decoded, err)
+		}
+	}
+	for _, cursor := range []string{"100", "!!", snapshotCursor(-1)} {
+		if _, err := parseSnapshotCursor(cursor); err == nil {
+			t.Errorf("Expected cursor %q to be rejected", cursor)
+		}
+	}
+}
+
+func TestMaterializeInterpellations(t *testing.T) {
+	const total = 1201
+	var requests atomic.Int32
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sejm/term10/interpellations" || r.URL.Query().Get("sort_by") != "num" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		requests.Add(1)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var items []string
+		for num := offset + 1; num <= min(offset+limit, total); num++ {
+			items = append(items, fmt.Sprintf(`{"num": %d, "title": "Interpelacja %d", "from": ["12"]}`, num, num))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, "[%s]", strings.Join(items, ","))
+	}))
+	t.Cleanup(mirror.Close)
+	server := NewSejmServerWithConfig(Config{SejmBaseURL: mirror.URL})
+
+	message := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"sejm_get_interpellations","arguments":{"materialize":"true","page_size":500}}}`
+	response, ok := server.server.HandleMessage(context.Background(), json.RawMessage(message)).(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("Unexpected response to the materialize call")
+	}
+	result := response.Result.(*mcp.CallToolResult)
+	text := extractTextContent(result)
+	for _, expected := range []string{"Items: 1201 interpellations", "Pages: 3 of up to 500 items", "Query: sort_by=num"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+	if len(result.Content) != 2 {
+		t.Fatalf("Expected the summary and a resource link, got %d contents", len(result.Content))
+	}
+	link, ok := result.Content[1].(mcp.ResourceLink)
+	if !ok || !strings.HasPrefix(link.URI, "sejm://snapshots/snap-") {
+		t.Fatalf("Unexpected resource link: %+v", result.Content[1])
+	}
+	downloads := requests.Load()
+
+	// Follow the pages through the resource until the last one
+	var items []json.RawMessage
+	uri := link.URI
+	for pages := 0; uri != "" && pages < 5; pages++ {
+		message := `{"jsonrpc":"2.0","id":2,"method":"resources/read","params":{"uri":"` + uri + `"}}`
+		response, ok := server.server.HandleMessage(context.Background(), json.RawMessage(message)).(mcp.JSONRPCResponse)
+		if !ok {
+			t.Fatalf("Failed to read %s", uri)
+		}
+		contents := response.Result.(mcp.ReadResourceResult).Contents
+		var page snapshotPage
+		if err := json.Unmarshal([]byte(contents[0].(mcp.TextResourceContents).Text), &page); err != nil {
+			t.Fatalf("Failed to parse page %s: %v", uri, err)
+		}
+		items = append(items, page.Items...)
+		uri = page.NextURI
+	}
+	if len(items) != total || !strings.Contains(string(items[total-1]), `"num": 1201`) {
+		t.Errorf("Expected all %d items in order across the pages, got %d", total, len(items))
+	}
+	if requests.Load() != downloads {
+		t.Errorf("Expected the pages to be served without querying the API again")
+	}
+
+	id := strings.TrimSuffix(strings.TrimPrefix(link.URI, "sejm://snapshots/"), "/pages/"+snapshotCursor(0))
+	result, _ = server.handleGetListSnapshot(context.Background(), createMockRequest(map[string]interface{}{"snapshot_id": id, "cursor": snapshotCursor(1000)}))
+	if text := extractTextContent(result); result.IsError || !strings.Contains(text, `"count": 201`) || strings.Contains(text, "nextCursor") {
+		t.Errorf("Expected the last page through the tool, got:\n%s", text)
+	}
+	result, _ = server.handleGetListSnapshot(context.Background(), createMockRequest(map[string]interface{}{"snapshot_id": "snap-missing"}))
+	if !result.IsError || !strings.Contains(extractTextContent(result), "has expired") {
+		t.Errorf("Expected an unknown snapshot to be rejected, got: %s", extractTextContent(result))
+	}
+}
+
+func TestSnapshotStoreLimit(t *testing.T) {
+	store := newSnapshotStore()
+	for i := 0; i <= maxRetainedSnapshots; i++ {
+		store.add(&listSnapshot{ID: fmt.Sprintf("snap-%d", i)})
+	}
+	if _, ok := store.get("snap-0"); ok {
+		t.Errorf("Expected the least recently read snapshot to be discarded")
+	}
+	if _, ok := store.get(fmt.Sprintf("snap-%d", maxRetainedSnapshots)); !ok {
+		t.Errorf("Expected the newest snapshot to be kept")
+	}
+}
@@ -0,0 +1,278 @@
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Supported output languages
+const (
+	LanguageEnglish = "en"
+	LanguagePolish  = "pl"
+)
+
+// languageParamDescription documents the language parameter shared by all tools
+const languageParamDescription = "Optional. Output language for headers, section labels, status strings, summary labels and next-step hints: 'en' (English) or 'pl' (Polish). Defaults to the server-wide setting (-lang flag, English unless configured otherwise). Data returned by the APIs (names, titles, legal text) is always in Polish."
+
+// polishSectionLabels translates the section headings emitted by StandardResponse.Format
+// and the most common headings of older free-form responses
+var polishSectionLabels = map[string]string{
+	"Summary:":             "Podsumowanie:",
+	"Unavailable Sources:": "Niedostępne źródła:",
+	"Results:":             "Wyniki:",
+	"Next Actions:":        "Następne kroki:",
+	"Next actions:":        "Następne kroki:",
+	"Note:":                "Uwaga:",
+}
+
+// polishStatuses translates status strings used in response headers
+var polishStatuses = map[string]string{
+	"Retrieved Successfully":                 "Pobrano pomyślnie",
+	"Retrieved Successfully (Detailed View)": "Pobrano pomyślnie (widok szczegółowy)",
+	"Search Completed Successfully":          "Wyszukiwanie zakończone pomyślnie",
+	"Analysis Completed Successfully":        "Analiza zakończona pomyślnie",
+	"No Results Found":                       "Brak wyników",
+	"No References Found":                    "Nie znaleziono powiązań",
+	"Job Submitted":                          "Zadanie przyjęte",
+	"Partially Retrieved":                    "Pobrano częściowo",
+	"Watch Added":                            "Dodano obserwację",
+	"Watch Removed":                          "Usunięto obserwację",
+	"No Changes":                             "Brak zmian",
+}
+
+// polishOperations translates fixed operation names used in response headers
+var polishOperations = map[string]string{
+	"Legal Acts Search":                          "Wyszukiwanie aktów prawnych",
+	"Legal Act Details":                          "Szczegóły aktu prawnego",
+	"Legal Act Text (Paginated)":                 "Tekst aktu prawnego (stronicowany)",
+	"Legal Act Content Search":                   "Wyszukiwanie w treści aktu prawnego",
+	"Legal Reference Network Analysis":           "Analiza powiązań aktu prawnego",
+	"PDF Page Information":                       "Informacje o stronach PDF",
+	"PDF Content Search":                         "Wyszukiwanie w treści PDF",
+	"Document Text (Paginated)":                  "Tekst dokumentu (stronicowany)",
+	"Document Page Information":                  "Informacje o stronach dokumentu",
+	"Document Content Search":                    "Wyszukiwanie w treści dokumentu",
+	"Document Summary":                           "Streszczenie dokumentu",
+	"List Snapshot":                              "Zrzut listy",
+	"Party-Line Defections":                      "Głosowania wbrew klubowi",
+	"Club Positions":                             "Stanowiska klubów",
+	"Voting Sessions":                            "Dni głosowań",
+	"Regulatory Impact Assessment":               "Ocena skutków regulacji",
+	"Club Changes":                               "Zmiany w klubach",
+	"MP Voting Comparison":                       "Porównanie głosowań posłów",
+	"Voting PDF Records":                         "Głosy posłów z protokołu PDF",
+	"Committee Attendance":                       "Obecność na posiedzeniach komisji",
+	"Committee Workload":                         "Obciążenie komisji projektami",
+	"Interpellation Details":                     "Szczegóły interpelacji",
+	"Interpellation Topics":                      "Tematy interpelacji",
+	"Unanswered Interpellations":                 "Interpelacje bez odpowiedzi",
+	"Subcommittees":                              "Podkomisje",
+	"Subcommittee Details":                       "Szczegóły podkomisji",
+	"Daily Digest":                               "Przegląd dnia",
+	"Recent Prints":                              "Najnowsze druki",
+	"Sitting Timeline":                           "Przebieg dnia posiedzenia",
+	"Sitting Turnout":                            "Frekwencja na posiedzeniu",
+	"Oversight Corpus Export":                    "Eksport korpusu interpelacji i zapytań",
+	"Print Attachments":                          "Załączniki druków",
+	"Server Health":                              "Stan serwera",
+	"Committee Transcripts":                      "Stenogramy komisji",
+	"Act Text Files":                             "Pliki tekstów aktu",
+	"Upcoming Entries Into Force":                "Akty wchodzące w życie",
+	"Random Act Sample":                          "Losowa próba aktów",
+	"Legal Citation":                             "Cytowanie aktu prawnego",
+	"Search Facets":                              "Rozkład wyników wyszukiwania",
+	"Transcript Table of Contents":               "Spis treści stenogramu",
+	"MP Financial Disclosures":                   "Oświadczenia majątkowe i rejestr korzyści",
+	"Legal State as of Date":                     "Stan prawny na dzień",
+	"Act Watch":                                  "Obserwowanie aktu",
+	"Watch Updates":                              "Zmiany obserwowanych aktów",
+	"Print Document Graph":                       "Powiązania druków sejmowych",
+	"MP Interpellation Texts":                    "Treści interpelacji posła",
+	"MP Contact Information":                     "Dane kontaktowe posłów",
+	"Committee Transcript Agenda Items":          "Punkty porządku obrad w zapisie posiedzenia komisji",
+	"ELI Legal Statuses Directory":               "Katalog statusów prawnych ELI",
+	"ELI Legal Keywords Directory":               "Katalog słów kluczowych ELI",
+	"ELI Document Types Directory":               "Katalog typów dokumentów ELI",
+	"ELI Institutions Directory":                 "Katalog organów wydających ELI",
+	"Well-Known Act Names":                       "Nazwy znanych aktów",
+	"Electoral Results":                          "Wyniki wyborcze posłów",
+	"MP Demographics":                            "Struktura społeczna posłów",
+	"Joint Committee Sittings":                   "Wspólne posiedzenia komisji",
+	"MP Participation Trend":                     "Frekwencja posła w czasie",
+	"Publisher Years":                            "Roczniki wydawcy",
+	"Print Search":                               "Wyszukiwanie druków",
+	"Committee Documents":                        "Dezyderaty i opinie komisji",
+	"Server Capabilities":                        "Możliwości serwera",
+	"Act Keywords":                               "Słowa kluczowe aktu",
+	"ELI Acts Listing":                           "Lista aktów ELI",
+	"ELI Full-Text Search":                       "Wyszukiwanie pełnotekstowe w aktach ELI",
+	"Parliamentary Legislative Processes":        "Procesy legislacyjne",
+	"Passed Parliamentary Legislative Processes": "Zakończone procesy legislacyjne",
+	"Legislative Process Lineage":                "Ciągłość procesów legislacyjnych między kadencjami",
+	"Parliamentary Written Questions":            "Zapytania poselskie",
+	"Parliamentary Video Transmissions":          "Transmisje wideo z Sejmu",
+	"Parliamentary Transcript Statements":        "Wypowiedzi ze stenogramu",
+	"Parliamentary Bilateral Groups":             "Grupy bilateralne",
+}
+
+// polishOperationPrefixes translates the fixed part of parameterized operation names.
+// Order matters: longer prefixes must come before shorter ones sharing the same start.
+var polishOperationPrefixes = []struct {
+	english string
+	polish  string
+}{
+	{"Acts by Publisher: ", "Akty według wydawcy: "},
+	{"Acts by Year: ", "Akty według roku: "},
+	{"Background Job ", "Zadanie w tle "},
+	{"Bilateral Group #", "Grupa bilateralna nr "},
+	{"Club Details: ", "Szczegóły klubu: "},
+	{"Committee Details: ", "Szczegóły komisji: "},
+	{"Committee Members: ", "Członkowie komisji: "},
+	{"Current Proceeding", "Bieżące posiedzenie"},
+	{"EU Law References: ", "Odniesienia do prawa UE: "},
+	{"Constitutional Tribunal Rulings: ", "Orzeczenia TK: "},
+	{"Constitutional Tribunal Case ", "Sprawa TK "},
+	{"Legislative Process #", "Proces legislacyjny nr "},
+	{"Parliamentary MPs", "Posłowie"},
+	{"Print #", "Druk nr "},
+	{"Interpellation #", "Interpelacja nr "},
+	{"Interpellation Attachment: ", "Załącznik do interpelacji: "},
+	{"Print Attachment: ", "Załącznik do druku: "},
+}
+
+// polishOperationFragments translates recurring fragments inside parameterized operation names
+var polishOperationFragments = []struct {
+	english string
+	polish  string
+}{
+	{" Reply Body (Term ", " treść odpowiedzi (kadencja "},
+	{" Body (Term ", " treść (kadencja "},
+	{" Details (Term ", " szczegóły (kadencja "},
+	{" Details", " szczegóły"},
+	{"(Term ", "(kadencja "},
+	{", Key ", ", klucz "},
+	{", Print #", ", druk nr "},
+}
+
+// NormalizeLanguage converts a user-supplied language name ("pl", "polish", "en", ...) to a supported language code.
+func NormalizeLanguage(language string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(language)) {
+	case "en", "eng", "english", "angielski":
+		return LanguageEnglish, nil
+	case "pl", "pol", "polish", "polski":
+		return LanguagePolish, nil
+	default:
+		return "", fmt.Errorf("unsupported language '%s': must be 'en' or 'pl'", language)
+	}
+}
+
+// resolveLanguage returns the language for a request, falling back to the server default
+func (s *SejmServer) resolveLanguage(requested string) (string, error) {
+	if strings.TrimSpace(requested) != "" {
+		return NormalizeLanguage(requested)
+	}
+	if s.config.Language != "" {
+		return NormalizeLanguage(s.config.Language)
+	}
+	return LanguageEnglish, nil
+}
+
+// localizeText translates the response header, section labels, status strings, summary labels and next action
+// descriptions into the given language. Content coming from the APIs is left untouched.
+func localizeText(text, language string) string {
+	if language != LanguagePolish || text == "" {
+		return text
+	}
+
+	lines := strings.Split(text, "\n")
+	lines[0] = localizeHeader(lines[0])
+	section := ""
+	for i := 1; i < len(lines); i++ {
+		line := lines[i]
+		if translated, ok := polishSectionLabels[line]; ok {
+			lines[i] = translated
+			section = line
+			continue
+		}
+		if strings.HasPrefix(line, "Note: ") && lines[i-1] == "" {
+			lines[i] = polishSectionLabels["Note:"] + strings.TrimPrefix(line, "Note:")
+		}
+		switch {
+		case line == "":
+			section = ""
+		case section == "Summary:":
+			lines[i] = localizeItem(line, polishSummaryLabels)
+		case section == "Next Actions:" || section == "Next actions:":
+			lines[i] = localizeItem(line, polishNextActions)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// localizeItem translates a bulleted line, either whole or up to its first colon; lines that are not in labels
+// are left alone
+func localizeItem(line string, labels map[string]string) string {
+	item, ok := strings.CutPrefix(line, "• ")
+	if !ok {
+		return line
+	}
+	if translated, ok := labels[item]; ok {
+		return "• " + translated
+	}
+	label, value, ok := strings.Cut(item, ":")
+	if !ok {
+		return line
+	}
+	if translated, ok := labels[label]; ok {
+		return "• " + translated + ":" + value
+	}
+	return line
+}
+
+// localizeHeader translates a StandardResponse header line of the form "Operation - Status"
+func localizeHeader(header string) string {
+	separator := strings.LastIndex(header, " - ")
+	if separator == -1 {
+		return header
+	}
+	operation := header[:separator]
+	status := header[separator+3:]
+
+	translatedStatus, ok := polishStatuses[status]
+	if !ok {
+		// Not a StandardResponse header, leave free-form text alone
+		return header
+	}
+	return fmt.Sprintf("%s - %s", localizeOperation(operation), translatedStatus)
+}
+
+// localizeOperation translates an operation name using exact matches first, then known prefixes
+func localizeOperation(operation string) string {
+	if translated, ok := polishOperations[operation]; ok {
+		return translated
+	}
+	for _, prefix := range polishOperationPrefixes {
+		if strings.HasPrefix(operation, prefix.english) {
+			operation = prefix.polish + strings.TrimPrefix(operation, prefix.english)
+			break
+		}
+	}
+	for _, fragment := range polishOperationFragments {
+		operation = strings.Replace(operation, fragment.english, fragment.polish, 1)
+	}
+	return operation
+}
+
+// localizeResult translates all text content of a tool result in place
+func localizeResult(result *mcp.CallToolResult, language string) {
+	if result == nil || language == LanguageEnglish {
+		return
+	}
+	for i, content := range result.Content {
+		if textContent, ok := mcp.AsTextContent(content); ok {
+			result.Content[i] = mcp.NewTextContent(localizeText(textContent.Text, language))
+		}
+	}
+}
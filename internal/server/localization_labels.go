@@ -0,0 +1,424 @@
+package server
+
+// polishSummaryLabels translates the labels of "Label: value" lines in the Summary section of StandardResponse.
+// Values are left as they are, since they mostly come from the APIs.
+var polishSummaryLabels = map[string]string{
+	"Act":                         "Akt",
+	"Act ID":                      "ID aktu",
+	"Active filters":              "Aktywne filtry",
+	"Activity":                    "Aktywność",
+	"Acts announced by that date": "Akty ogłoszone do tego dnia",
+	"Acts entering into force":    "Akty wchodzące w życie",
+	"Acts in total":               "Akty łącznie",
+	"Acts with matches":           "Akty z trafieniami",
+	"Affected act references":     "Odwołania do aktów, których dotyczy",
+	"Agenda item":                 "Punkt porządku obrad",
+	"Agenda items detected":       "Wykryte punkty porządku obrad",
+	"Agenda items detected in the transcript":                  "Punkty porządku obrad wykryte w stenogramie",
+	"Agenda items on the sitting agenda":                       "Punkty w porządku obrad posiedzenia",
+	"Already reported, awaiting the next reading (not listed)": "Już sprawozdane, czekają na kolejne czytanie (niewymienione)",
+	"Amendments published after it":                            "Nowelizacje ogłoszone później",
+	"Answer delayed":                                           "Opóźniona odpowiedź",
+	"Appointed":                                                "Powołano",
+	"Asset declarations (oświadczenia majątkowe)":              "Oświadczenia majątkowe",
+	"Attachments available":                                    "Dostępne załączniki",
+	"Benefits register entries (rejestr korzyści)":             "Wpisy w rejestrze korzyści",
+	"Bodies": "Treści",
+	"Bodies that could not be downloaded (title used)": "Treści, których nie udało się pobrać (użyto tytułu)",
+	"By club":        "Według klubów",
+	"By extension":   "Według rozszerzenia",
+	"By type":        "Według typu",
+	"Case number":    "Sygnatura",
+	"Changes found":  "Znalezione zmiany",
+	"Check interval": "Odstęp sprawdzania",
+	"Checked now":    "Sprawdzono teraz",
+	"Cited text":     "Cytowany tekst",
+	"Club":           "Klub",
+	"Club ID":        "ID klubu",
+	"Club Name":      "Nazwa klubu",
+	"Club changes":   "Zmiany klubów",
+	"Club filter":    "Filtr klubu",
+	"Clubs":          "Kluby",
+	"Clubs formed":   "Utworzone kluby",
+	"Columns":        "Kolumny",
+	"Committee":      "Komisja",
+	"Committee Code": "Kod komisji",
+	"Committee Name": "Nazwa komisji",
+	"Committee Type": "Typ komisji",
+	"Committee list (code, name, type, member count)": "Lista komisji (kod, nazwa, typ, liczba członków)",
+	"Committee page": "Strona komisji",
+	"Constitutional Tribunal rulings affecting this act": "Orzeczenia TK dotyczące tego aktu",
+	"Counted":                                "Policzono",
+	"Current status":                         "Obecny status",
+	"Date":                                   "Data",
+	"Days in committee":                      "Dni w komisji",
+	"Default pages per chunk":                "Domyślna liczba stron na fragment",
+	"Delayed interpellations analyzed":       "Przeanalizowane opóźnione interpelacje",
+	"Delivery Date":                          "Data doręczenia",
+	"Desiderata (dezyderaty)":                "Dezyderaty",
+	"Direction":                              "Kierunek",
+	"Directives listed in ELI metadata":      "Dyrektywy wymienione w metadanych ELI",
+	"Document":                               "Dokument",
+	"Document searched":                      "Przeszukany dokument",
+	"Document type":                          "Typ dokumentu",
+	"Document types":                         "Typy dokumentów",
+	"Documents downloaded":                   "Pobrane dokumenty",
+	"Documents that could not be downloaded": "Dokumenty, których nie udało się pobrać",
+	"EU acts referenced":                     "Przywołane akty UE",
+	"End Time":                               "Godzina zakończenia",
+	"English name":                           "Nazwa angielska",
+	"Entry Into Force":                       "Wejście w życie",
+	"Entry into force window":                "Okres wejścia w życie",
+	"Estimated response size":                "Szacowany rozmiar odpowiedzi",
+	"Estimated response sizes":               "Szacowane rozmiary odpowiedzi",
+	"Export complete":                        "Eksport zakończony",
+	"Failed to extract":                      "Nie udało się wyodrębnić",
+	"File":                                   "Plik",
+	"File size":                              "Rozmiar pliku",
+	"Filtered Category":                      "Filtrowana kategoria",
+	"Filters":                                "Filtry",
+	"Final title":                            "Tytuł końcowy",
+	"From MP ID":                             "Od posła o ID",
+	"HTML transcripts":                       "Stenogramy HTML",
+	"Interpellations without distinctive words (not clustered)": "Interpelacje bez charakterystycznych słów (niepogrupowane)",
+	"Items":                               "Pozycje",
+	"Job ID":                              "ID zadania",
+	"Joint meetings":                      "Wspólne posiedzenia",
+	"Key tables (amounts by year)":        "Kluczowe tabele (kwoty według lat)",
+	"Keywords":                            "Słowa kluczowe",
+	"Known references":                    "Znane powiązania",
+	"Last modified":                       "Ostatnia zmiana",
+	"Legislative process":                 "Proces legislacyjny",
+	"Linked prints":                       "Powiązane druki",
+	"Longest low-participation period":    "Najdłuższy okres niskiej frekwencji",
+	"Lowest turnout":                      "Najniższa frekwencja",
+	"MP ID":                               "ID posła",
+	"MPs":                                 "Posłowie",
+	"MPs compared":                        "Porównani posłowie",
+	"Matching":                            "Pasujące",
+	"Matching acts":                       "Pasujące akty",
+	"Matching attachments":                "Pasujące załączniki",
+	"Matching members":                    "Pasujący członkowie",
+	"Meetings":                            "Posiedzenia",
+	"Members":                             "Członkowie",
+	"Members with expired mandate hidden": "Ukryci członkowie z wygasłym mandatem",
+	"Membership snapshots compared":       "Porównane stany członkostwa",
+	"Mode":                                "Tryb",
+	"Most frequent defectors":             "Najczęściej głosujący wbrew klubowi",
+	"Name":                                "Nazwa",
+	"Next offset":                         "Następny offset",
+	"Not detected (possibly discussed jointly with another item)": "Niewykryte (możliwe rozpatrzenie łącznie z innym punktem)",
+	"OSR found in attachment":                                     "OSR znaleziona w załączniku",
+	"Observed since start":                                        "Zaobserwowane od uruchomienia",
+	"Open processes checked":                                      "Sprawdzone otwarte procesy",
+	"Opinions (opinie)":                                           "Opinie",
+	"Options":                                                     "Opcje",
+	"Overall Performance":                                         "Wyniki ogólne",
+	"Pages":                                                       "Strony",
+	"Pages extracted":                                             "Wyodrębnione strony",
+	"Pagination":                                                  "Stronicowanie",
+	"Parent committee":                                            "Komisja macierzysta",
+	"Passed":                                                      "Uchwalone",
+	"Print Number":                                                "Numer druku",
+	"Proceeding":                                                  "Posiedzenie",
+	"Proceeding Number":                                           "Numer posiedzenia",
+	"Process title":                                               "Tytuł procesu",
+	"Processes in the committee's queue":                          "Procesy oczekujące w komisji",
+	"Profile":                                                     "Profil",
+	"Promulgation Date":                                           "Data ogłoszenia",
+	"Provision":                                                   "Przepis",
+	"Published rulings found":                                     "Znalezione opublikowane orzeczenia",
+	"Query":                                                       "Zapytanie",
+	"Received":                                                    "Otrzymano",
+	"Recent sitting breakdown (last 10 sittings)": "Ostatnie posiedzenia (10 ostatnich)",
+	"Recipient filter":           "Filtr adresata",
+	"Records":                    "Rekordy",
+	"Reference categories shown": "Pokazane kategorie powiązań",
+	"Replies":                    "Odpowiedzi",
+	"Replies received":           "Otrzymane odpowiedzi",
+	"Resource":                   "Zasób",
+	"Response cache":             "Pamięć podręczna odpowiedzi",
+	"Result":                     "Wynik",
+	"Role filter":                "Filtr funkcji",
+	"Room":                       "Sala",
+	"Sampled":                    "Wylosowano",
+	"Scope":                      "Zakres",
+	"Search terms":               "Szukane wyrażenia",
+	"Sections recognized":        "Rozpoznane sekcje",
+	"Seed":                       "Ziarno losowania",
+	"Sent to the government":     "Wysłane do rządu",
+	"Sentences analyzed":         "Przeanalizowane zdania",
+	"Server":                     "Serwer",
+	"Showing":                    "Wyświetlono",
+	"Since":                      "Od",
+	"Sittings":                   "Posiedzenia",
+	"Sittings analyzed":          "Przeanalizowane posiedzenia",
+	"Sittings checked":           "Sprawdzone posiedzenia",
+	"Sittings without a transcript (excluded)": "Posiedzenia bez stenogramu (pominięte)",
+	"Snapshot":                        "Zrzut",
+	"Source Act":                      "Akt źródłowy",
+	"Start Time":                      "Godzina rozpoczęcia",
+	"Statements":                      "Wypowiedzi",
+	"Status":                          "Status",
+	"Subcommittee":                    "Podkomisja",
+	"Subcommittees":                   "Podkomisje",
+	"Submitted by MP IDs":             "Złożone przez posłów o ID",
+	"Successfully extracted":          "Wyodrębniono",
+	"Summary sentences":               "Zdania streszczenia",
+	"Term":                            "Kadencja",
+	"Terms":                           "Kadencje",
+	"Text analyzed":                   "Przeanalizowany tekst",
+	"Text files":                      "Pliki tekstowe",
+	"Text length":                     "Długość tekstu",
+	"Title":                           "Tytuł",
+	"Title filter":                    "Filtr tytułu",
+	"To":                              "Do",
+	"Tool":                            "Narzędzie",
+	"Tools listed":                    "Wymienione narzędzia",
+	"Topic":                           "Temat",
+	"Topics found":                    "Znalezione tematy",
+	"Total defections":                "Głosy wbrew klubowi łącznie",
+	"Total matches found":             "Znalezione trafienia łącznie",
+	"Total members":                   "Członkowie łącznie",
+	"Total pages":                     "Strony łącznie",
+	"Total pages searched":            "Przeszukane strony łącznie",
+	"Total references found":          "Znalezione powiązania łącznie",
+	"Total statements":                "Wypowiedzi łącznie",
+	"Type":                            "Typ",
+	"Upstream state":                  "Stan źródeł danych",
+	"Votes cast according to the API": "Głosy oddane według API",
+	"Voting Summary":                  "Podsumowanie głosowania",
+	"Votings":                         "Głosowania",
+	"Votings analyzed":                "Przeanalizowane głosowania",
+	"Votings with defections":         "Głosowania z głosami wbrew klubowi",
+	"Watched acts":                    "Obserwowane akty",
+	"Without a vote count":            "Bez liczby głosów",
+	"Years":                           "Lata",
+	"Years without acts between the first and the last": "Lata bez aktów między pierwszym a ostatnim",
+}
+
+// polishNextActions translates the descriptions of "Description: tool call" hints in the Next Actions section
+var polishNextActions = map[string]string{
+	"Act counts per year":                "Liczba aktów w poszczególnych latach",
+	"Act metadata":                       "Metadane aktu",
+	"Acts issued by an institution":      "Akty wydane przez organ",
+	"Acts it amends":                     "Akty przez niego zmieniane",
+	"Acts of the newest year":            "Akty z najnowszego roku",
+	"Acts sharing every keyword":         "Akty mające wszystkie słowa kluczowe",
+	"Acts tagged with a keyword instead": "Zamiast tego akty oznaczone słowem kluczowym",
+	"Acts with any of the keywords":      "Akty z którymkolwiek ze słów kluczowych",
+	"All keywords in use":                "Wszystkie używane słowa kluczowe",
+	"All matches in the top act":         "Wszystkie trafienia w najlepiej dopasowanym akcie",
+	"All meetings of a day":              "Wszystkie posiedzenia danego dnia",
+	"All tools":                          "Wszystkie narzędzia",
+	"All watched acts":                   "Wszystkie obserwowane akty",
+	"Amendments and repeals":             "Nowelizacje i uchylenia",
+	"Amendments in detail":               "Szczegóły nowelizacji",
+	"Amendments of an act":               "Nowelizacje aktu",
+	"Another sample":                     "Kolejna próba",
+	"Available legal relationship types in eli_get_act_references": "Typy powiązań prawnych dostępne w eli_get_act_references",
+	"Browse all statements":                                    "Przeglądaj wszystkie wypowiedzi",
+	"Change across terms":                                      "Zmiana między kadencjami",
+	"Check an MP's overall record":                             "Sprawdź ogólny bilans posła",
+	"Check party discipline in the same range":                 "Sprawdź dyscyplinę klubową w tym samym okresie",
+	"Check progress":                                           "Sprawdź postęp",
+	"Check the MP ID":                                          "Sprawdź ID posła",
+	"Check the MP's mandate":                                   "Sprawdź mandat posła",
+	"Check the amendments":                                     "Sprawdź nowelizacje",
+	"Check voting record":                                      "Sprawdź historię głosowań",
+	"Cite the act as originally published":                     "Cytuj akt w brzmieniu pierwotnym",
+	"Classify ruling outcomes from their texts":                "Sklasyfikuj rozstrzygnięcia na podstawie treści orzeczeń",
+	"Committee filter":                                         "Filtr komisji",
+	"Committee sitting details":                                "Szczegóły posiedzenia komisji",
+	"Committee sittings":                                       "Posiedzenia komisji",
+	"Committees with subcommittees":                            "Komisje z podkomisjami",
+	"Common types":                                             "Najczęstsze typy",
+	"Compare with club positions":                              "Porównaj ze stanowiskami klubów",
+	"Compare with other years":                                 "Porównaj z innymi latami",
+	"Continue browsing":                                        "Przeglądaj dalej",
+	"Current clubs":                                            "Obecne kluby",
+	"Current metadata":                                         "Aktualne metadane",
+	"Data for plotting":                                        "Dane do wykresu",
+	"Details of a voting":                                      "Szczegóły głosowania",
+	"Details of one interpellation":                            "Szczegóły jednej interpelacji",
+	"Details of the first meeting":                             "Szczegóły pierwszego posiedzenia",
+	"Detected changes":                                         "Wykryte zmiany",
+	"Documents of a process":                                   "Dokumenty procesu",
+	"Documents of the latest process":                          "Dokumenty najnowszego procesu",
+	"Download an attachment":                                   "Pobierz załącznik",
+	"Download attachments":                                     "Pobierz załączniki",
+	"Download full transcript":                                 "Pobierz pełny stenogram",
+	"Download or read an attachment":                           "Pobierz lub przeczytaj załącznik",
+	"Download text of this act":                                "Pobierz tekst tego aktu",
+	"Drill down":                                               "Szczegóły",
+	"EU acts cited in the text too":                            "Także akty UE przywołane w treści",
+	"Every profile field as a dataset":                         "Wszystkie pola profilu jako zbiór danych",
+	"Everything else that happened on a day":                   "Wszystko inne, co działo się danego dnia",
+	"Example":                                                  "Przykład",
+	"Explore Constitutional law relationships":                 "Powiązania z Konstytucją",
+	"Explore legal relationships":                              "Powiązania prawne",
+	"Explore related legal codes":                              "Powiązane kodeksy",
+	"Fetch the output":                                         "Pobierz wynik",
+	"Fetch the output when completed":                          "Pobierz wynik po zakończeniu",
+	"Filter by MP":                                             "Filtruj według posła",
+	"Filter by ministry":                                       "Filtruj według ministerstwa",
+	"Filter by passed legislation":                             "Filtruj według uchwalonych ustaw",
+	"Filter by type":                                           "Filtruj według typu",
+	"Find Constitutional amendments":                           "Znajdź zmiany Konstytucji",
+	"Find an MP's line in the original document":               "Znajdź wiersz posła w dokumencie źródłowym",
+	"Find delayed answers":                                     "Znajdź opóźnione odpowiedzi",
+	"Find implementing regulations for codes":                  "Znajdź akty wykonawcze do kodeksów",
+	"Find other acts affected by a ruling":                     "Znajdź inne akty, których dotyczy orzeczenie",
+	"Find parent laws for regulations":                         "Znajdź ustawy, na podstawie których wydano rozporządzenia",
+	"Find passed legislation":                                  "Znajdź uchwalone ustawy",
+	"Find subject keywords":                                    "Znajdź słowa kluczowe",
+	"Find where an EU act is cited":                            "Znajdź, gdzie przywołano akt UE",
+	"Focus on specific categories":                             "Ogranicz do wybranych kategorii",
+	"Full committee profile":                                   "Pełny profil komisji",
+	"Full description of a tool and its parameters":            "Pełny opis narzędzia i jego parametrów",
+	"Full process record":                                      "Pełny przebieg procesu",
+	"Full profile of one club":                                 "Pełny profil jednego klubu",
+	"Full text of a statement":                                 "Pełna treść wypowiedzi",
+	"Get MP details":                                           "Szczegóły posła",
+	"Get MP profile":                                           "Profil posła",
+	"Get MP voting stats":                                      "Statystyki głosowań posła",
+	"Get complete metadata":                                    "Pełne metadane",
+	"Get condensed list":                                       "Skrócona lista",
+	"Get full details":                                         "Pełne szczegóły",
+	"Get full details of this act":                             "Pełne szczegóły tego aktu",
+	"Get full statement text":                                  "Pełna treść wypowiedzi",
+	"Get group details":                                        "Szczegóły grupy",
+	"Get interpellation details":                               "Szczegóły interpelacji",
+	"Get legal text":                                           "Tekst prawny",
+	"Get original question":                                    "Pierwotne pytanie",
+	"Get page information":                                     "Informacje o stronach",
+	"Get print details":                                        "Szczegóły druku",
+	"Get process details":                                      "Szczegóły procesu",
+	"Get replies":                                              "Odpowiedzi",
+	"Get the graph as nodes and edges":                         "Graf jako węzły i krawędzie",
+	"Get video details":                                        "Szczegóły transmisji",
+	"Get yearly breakdown":                                     "Podział na lata",
+	"Include government replies":                               "Uwzględnij odpowiedzi rządu",
+	"Inspect a divergent voting":                               "Sprawdź rozbieżne głosowanie",
+	"Inspect a representative interpellation":                  "Sprawdź reprezentatywną interpelację",
+	"Inspect a voting in full":                                 "Sprawdź całe głosowanie",
+	"Interpellation details and replies":                       "Szczegóły interpelacji i odpowiedzi",
+	"Key statuses":                                             "Najważniejsze statusy",
+	"Legislative process of the bill":                          "Proces legislacyjny projektu",
+	"List all rulings for an affected act":                     "Wszystkie orzeczenia dotyczące aktu",
+	"List committees":                                          "Lista komisji",
+	"List the acts":                                            "Lista aktów",
+	"List the committee's sittings":                            "Lista posiedzeń komisji",
+	"List the subcommittee's sittings":                         "Lista posiedzeń podkomisji",
+	"Looser matching of reworded titles":                       "Luźniejsze dopasowanie zmienionych tytułów",
+	"MP profile":                                               "Profil posła",
+	"MP-by-MP votes":                                           "Głosy poszczególnych posłów",
+	"MPs elected with the fewest votes":                        "Posłowie wybrani najmniejszą liczbą głosów",
+	"Main text of the act":                                     "Główny tekst aktu",
+	"Member details":                                           "Szczegóły członka",
+	"Members and sittings of a subcommittee":                   "Członkowie i posiedzenia podkomisji",
+	"Names of the dissenting MPs":                              "Nazwiska posłów głosujących inaczej",
+	"Names, chairs and sizes of one committee's subcommittees": "Nazwy, przewodniczący i liczebność podkomisji jednej komisji",
+	"Navigate through document":                                "Nawigacja po dokumencie",
+	"Next acts of the year":                                    "Kolejne akty z roku",
+	"Next batch":                                               "Następna partia",
+	"Next chunk":                                               "Następny fragment",
+	"Next page":                                                "Następna strona",
+	"Older sittings":                                           "Starsze posiedzenia",
+	"Oldest cases only":                                        "Tylko najstarsze sprawy",
+	"One ministry only":                                        "Tylko jedno ministerstwo",
+	"Only acts referring to this act":                          "Tylko akty odwołujące się do tego aktu",
+	"Only flagged votings":                                     "Tylko oznaczone głosowania",
+	"Other publishers":                                         "Inni wydawcy",
+	"Parent committee members":                                 "Członkowie komisji macierzystej",
+	"Per-day numbers and excuses":                              "Liczby i usprawiedliwienia w poszczególnych dniach",
+	"Poll again shortly":                                       "Sprawdź ponownie za chwilę",
+	"Previous day":                                             "Poprzedni dzień",
+	"Previous page":                                            "Poprzednia strona",
+	"Print details":                                            "Szczegóły druku",
+	"Print text":                                               "Tekst druku",
+	"Print text, including the signatories of MPs' bills":      "Tekst druku, z podpisami posłów pod projektami poselskimi",
+	"Profile of the first MP":                                  "Profil pierwszego posła",
+	"Public finance table only":                                "Tylko tabela finansów publicznych",
+	"Read a file":                                              "Odczytaj plik",
+	"Read a full question":                                     "Przeczytaj całe zapytanie",
+	"Read a linked print":                                      "Przeczytaj powiązany druk",
+	"Read a reply":                                             "Przeczytaj odpowiedź",
+	"Read a sitting transcript":                                "Przeczytaj stenogram posiedzenia",
+	"Read a transcript":                                        "Przeczytaj stenogram",
+	"Read document text":                                       "Przeczytaj tekst dokumentu",
+	"Read full document":                                       "Przeczytaj cały dokument",
+	"Read next pages":                                          "Przeczytaj kolejne strony",
+	"Read one item":                                            "Przeczytaj jeden punkt",
+	"Read previous pages":                                      "Przeczytaj poprzednie strony",
+	"Read specific pages":                                      "Przeczytaj wybrane strony",
+	"Read the document text":                                   "Przeczytaj tekst dokumentu",
+	"Read the first page":                                      "Przeczytaj pierwszą stronę",
+	"Read the following pages":                                 "Przeczytaj kolejne strony",
+	"Read the full text with pagination":                       "Przeczytaj pełny tekst ze stronicowaniem",
+	"Read the question":                                        "Przeczytaj zapytanie",
+	"Read the ruling":                                          "Przeczytaj orzeczenie",
+	"Remaining watches":                                        "Pozostałe obserwacje",
+	"Retry synchronously or resubmit":                          "Ponów synchronicznie lub zleć ponownie",
+	"Search all processes":                                     "Przeszukaj wszystkie procesy",
+	"Search by title":                                          "Szukaj według tytułu",
+	"Search by topic":                                          "Szukaj według tematu",
+	"Search document content":                                  "Przeszukaj treść dokumentu",
+	"Search for acts by similar topics":                        "Szukaj aktów o podobnej tematyce",
+	"Search for related regulations":                           "Szukaj powiązanych rozporządzeń",
+	"Search related acts":                                      "Szukaj powiązanych aktów",
+	"Search transcript content":                                "Przeszukaj treść stenogramu",
+	"See all legal relationships":                              "Wszystkie powiązania prawne",
+	"See transposition links between Polish acts":              "Powiązania transpozycyjne między polskimi aktami",
+	"Server health and upstream latency":                       "Stan serwera i opóźnienia źródeł danych",
+	"Show only live streams":                                   "Tylko transmisje na żywo",
+	"Show only videos with streaming":                          "Tylko nagrania z transmisją",
+	"Sitting agenda":                                           "Porządek obrad posiedzenia",
+	"Sitting details":                                          "Szczegóły posiedzenia",
+	"Sitting transcript":                                       "Stenogram posiedzenia",
+	"Sort by date":                                             "Sortuj według daty",
+	"Sort by recent":                                           "Sortuj od najnowszych",
+	"Split broad topics":                                       "Podziel szerokie tematy",
+	"Stage timeline of a process":                              "Przebieg etapów procesu",
+	"Stages of the latest process":                             "Etapy najnowszego procesu",
+	"Statements of one item":                                   "Wypowiedzi w jednym punkcie",
+	"Statements of the day":                                    "Wypowiedzi z danego dnia",
+	"Stop watching":                                            "Zakończ obserwowanie",
+	"Structured counts":                                        "Liczby w formie ustrukturyzowanej",
+	"Structured output":                                        "Wynik ustrukturyzowany",
+	"Structured output for datasets":                           "Wynik ustrukturyzowany do zbiorów danych",
+	"Structured output with download URLs":                     "Wynik ustrukturyzowany z adresami do pobrania",
+	"Structured stage timeline with durations":                 "Ustrukturyzowany przebieg etapów z czasem trwania",
+	"Switch to detailed view":                                  "Przełącz na widok szczegółowy",
+	"Switch to summary view":                                   "Przełącz na widok skrócony",
+	"Table of contents by agenda item":                         "Spis treści według punktów porządku obrad",
+	"Text of a document":                                       "Tekst dokumentu",
+	"Text of an act":                                           "Tekst aktu",
+	"The PDF itself":                                           "Sam plik PDF",
+	"The series for charting":                                  "Seria danych do wykresu",
+	"Today's videos":                                           "Dzisiejsze transmisje",
+	"Totals per district":                                      "Sumy według okręgów",
+	"Use document types in eli_search_acts parameter":          "Użyj typów dokumentów w parametrze eli_search_acts",
+	"Use keywords in eli_search_acts parameter":                "Użyj słów kluczowych w parametrze eli_search_acts",
+	"Use the interpellation texts as well":                     "Uwzględnij także treść interpelacji",
+	"View all MPs":                                             "Wszyscy posłowie",
+	"View all bilateral groups":                                "Wszystkie grupy bilateralne",
+	"View all clubs":                                           "Wszystkie kluby",
+	"View all committees":                                      "Wszystkie komisje",
+	"View all prints":                                          "Wszystkie druki",
+	"View all proceedings":                                     "Wszystkie posiedzenia",
+	"View all processes":                                       "Wszystkie procesy",
+	"View club MPs":                                            "Posłowie klubu",
+	"View committee sittings":                                  "Posiedzenia komisji",
+	"View interpellation list":                                 "Lista interpelacji",
+	"View transcripts":                                         "Stenogramy",
+	"Votes against the club line":                              "Głosy wbrew linii klubu",
+	"Voting details":                                           "Szczegóły głosowania",
+	"Votings of a sitting":                                     "Głosowania z posiedzenia",
+	"Watch another act":                                        "Obserwuj inny akt",
+	"Watched acts":                                             "Obserwowane akty",
+	"Who did not vote":                                         "Kto nie głosował",
+	"Whole attachment with pages":                              "Cały załącznik ze stronami",
+	"Widen the window":                                         "Poszerz okres",
+	"Without network requests":                                 "Bez zapytań sieciowych",
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestNormalizeLanguage(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		hasError bool
+	}{
+		{"en", LanguageEnglish, false},
+		{"EN", LanguageEnglish, false},
+		{"english", LanguageEnglish, false},
+		{"pl", LanguagePolish, false},
+		{" Polski ", LanguagePolish, false},
+		{"de", "", true},
+		{"", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			result, err := NormalizeLanguage(tc.input)
+			if tc.hasError {
+				if err == nil {
+					t.Errorf("Expected error for input '%s', but got none", tc.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error for input '%s': %v", tc.input, err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected '%s' for input '%s', got '%s'", tc.expected, tc.input, result)
+			}
+		})
+	}
+}
+
+func TestResolveLanguage(t *testing.T) {
+	server := &SejmServer{config: Config{Language: LanguagePolish}}
+
+	language, err := server.resolveLanguage("")
+	if err != nil || language != LanguagePolish {
+		t.Errorf("Expected server default 'pl', got '%s' (err: %v)", language, err)
+	}
+
+	language, err = server.resolveLanguage("en")
+	if err != nil || language != LanguageEnglish {
+		t.Errorf("Expected request override 'en', got '%s' (err: %v)", language, err)
+	}
+
+	if _, err := server.resolveLanguage("fr"); err == nil {
+		t.Error("Expected error for unsupported language")
+	}
+
+	defaultServer := &SejmServer{}
+	language, err = defaultServer.resolveLanguage("")
+	if err != nil || language != LanguageEnglish {
+		t.Errorf("Expected fallback 'en', got '%s' (err: %v)", language, err)
+	}
+}
+
+func TestLocalizeText(t *testing.T) {
+	response := StandardResponse{
+		Operation:   "Club Details: KO (Term 10)",
+		Status:      "Retrieved Successfully",
+		Summary:     []string{"Members: 157", "Term: 10", "Chair: Jan Kowalski"},
+		Data:        []string{"• Term: data from the API"},
+		NextActions: []string{"View club MPs: sejm_get_mps with club='KO'", "Use sejm_get_mps"},
+		Note:        "Data from Sejm API",
+	}
+	text := response.Format()
+
+	if localizeText(text, LanguageEnglish) != text {
+		t.Error("English output should not be modified")
+	}
+
+	polish := localizeText(text, LanguagePolish)
+	expected := []string{
+		"Szczegóły klubu: KO (kadencja 10) - Pobrano pomyślnie",
+		"\n\nPodsumowanie:\n• Członkowie: 157\n• Kadencja: 10\n• Chair: Jan Kowalski",
+		"\n\nWyniki:\n• Term: data from the API",
+		"\n\nNastępne kroki:\n• Posłowie klubu: sejm_get_mps with club='KO'\n• Use sejm_get_mps",
+		"\n\nUwaga: Data from Sejm API",
+	}
+	for _, fragment := range expected {
+		if !strings.Contains(polish, fragment) {
+			t.Errorf("Expected localized output to contain %q, got:\n%s", fragment, polish)
+		}
+	}
+
+	// Free-form text without a known status must be left alone
+	freeForm := "Posiedzenie - 12 Results:\nSummary: inline"
+	if localizeText(freeForm, LanguagePolish) != freeForm {
+		t.Errorf("Free-form text should not be modified, got: %s", localizeText(freeForm, LanguagePolish))
+	}
+}
+
+func TestLocalizeResult(t *testing.T) {
+	result := mcp.NewToolResultText("Legal Acts Search - No Results Found\n\nSummary:\n• Found 0 legal acts")
+	localizeResult(result, LanguagePolish)
+
+	content := extractTextContent(result)
+	if !strings.HasPrefix(content, "Wyszukiwanie aktów prawnych - Brak wyników") {
+		t.Errorf("Expected localized header, got: %s", content)
+	}
+	if !strings.Contains(content, "Podsumowanie:") {
+		t.Errorf("Expected localized summary label, got: %s", content)
+	}
+}
+
+func TestLocalizeJobSubmittedResult(t *testing.T) {
+	result := jobSubmittedResult(job{ID: "job-1", Tool: "sejm_search_votings", Status: jobStatusQueued})
+	localizeResult(result, LanguagePolish)
+
+	if content := extractTextContent(result); !strings.HasPrefix(content, "Zadanie w tle job-1 - Zadanie przyjęte") {
+		t.Errorf("Expected the job submission header in Polish, got: %s", content)
+	}
+}
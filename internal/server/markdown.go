@@ -0,0 +1,236 @@
+package server
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Supported values of the render parameter of HTML body tools
+const (
+	renderHTML     = "html"
+	renderMarkdown = "markdown"
+)
+
+var (
+	// htmlTokenPattern splits HTML into comments, tags and the text between them
+	htmlTokenPattern = regexp.MustCompile(`(?s)<!--.*?-->|<[^>]*>|[^<]+|<`)
+	// htmlTagNamePattern captures the name of an opening or closing tag
+	htmlTagNamePattern = regexp.MustCompile(`^<\s*(/?)\s*([a-zA-Z][a-zA-Z0-9]*)`)
+	// htmlHrefPattern captures the href attribute of a link
+	htmlHrefPattern = regexp.MustCompile(`(?i)\bhref\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+	// markdownBlankLinesPattern matches runs of blank lines left between blocks
+	markdownBlankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// markdownSkippedElements are elements whose content is not part of the readable text
+var markdownSkippedElements = map[string]bool{"head": true, "script": true, "style": true, "title": true}
+
+// markdownBlockElements start and end a paragraph
+var markdownBlockElements = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "blockquote": true, "table": true,
+	"center": true, "pre": true, "address": true, "body": true, "dl": true, "dd": true, "dt": true,
+}
+
+// markdownList tracks an open <ul>/<ol> and the number of its next item
+type markdownList struct {
+	ordered bool
+	next    int
+}
+
+// htmlToMarkdown converts the HTML bodies served by the Sejm API (interpellations, replies, statements)
+// to Markdown. Headings, lists, emphasis, links and line breaks are kept; styling, scripts and the
+// document head are dropped. It is a tolerant tag-stream conversion, not a full HTML parser.
+func htmlToMarkdown(content string) string {
+	var out strings.Builder
+	var lists []markdownList
+	var links []string
+	skipDepth := 0
+	pendingSpace := false
+
+	// ensureBreak ends the current line (lines=1) or paragraph (lines=2) unless already done
+	ensureBreak := func(lines int) {
+		text := out.String()
+		if text == "" {
+			return
+		}
+		trailing := len(text) - len(strings.TrimRight(text, "\n"))
+		for ; trailing < lines; trailing++ {
+			out.WriteString("\n")
+		}
+		pendingSpace = false
+	}
+	writeInline := func(text string) {
+		if pendingSpace && out.Len() > 0 && !strings.HasSuffix(out.String(), "\n") {
+			out.WriteString(" ")
+		}
+		pendingSpace = false
+		out.WriteString(text)
+	}
+
+	for _, token := range htmlTokenPattern.FindAllString(content, -1) {
+		if !strings.HasPrefix(token, "<") || token == "<" {
+			if skipDepth > 0 {
+				continue
+			}
+			text := html.UnescapeString(token)
+			leading := strings.TrimLeftFunc(text, unicode.IsSpace) != text
+			trailing := strings.TrimRightFunc(text, unicode.IsSpace) != text
+			words := strings.Fields(text)
+			if len(words) == 0 {
+				pendingSpace = pendingSpace || leading
+				continue
+			}
+			pendingSpace = pendingSpace || leading
+			writeInline(strings.Join(words, " "))
+			pendingSpace = trailing
+			continue
+		}
+		if strings.HasPrefix(token, "<!--") {
+			continue
+		}
+
+		match := htmlTagNamePattern.FindStringSubmatch(token)
+		if match == nil {
+			continue
+		}
+		closing := match[1] == "/"
+		name := strings.ToLower(match[2])
+
+		if markdownSkippedElements[name] {
+			if closing {
+				if skipDepth > 0 {
+					skipDepth--
+				}
+			} else if !strings.HasSuffix(token, "/>") {
+				skipDepth++
+			}
+			continue
+		}
+		if skipDepth > 0 {
+			continue
+		}
+
+		switch {
+		case len(name) == 2 && name[0] == 'h' && name[1] >= '1' && name[1] <= '6':
+			ensureBreak(2)
+			if !closing {
+				out.WriteString(strings.Repeat("#", int(name[1]-'0')) + " ")
+			}
+		case markdownBlockElements[name]:
+			ensureBreak(2)
+		case name == "br":
+			ensureBreak(1)
+		case name == "hr":
+			ensureBreak(2)
+			out.WriteString("---")
+			ensureBreak(2)
+		case name == "ul" || name == "ol":
+			if closing {
+				if len(lists) > 0 {
+					lists = lists[:len(lists)-1]
+				}
+				if len(lists) == 0 {
+					ensureBreak(2)
+				}
+			} else {
+				if len(lists) == 0 {
+					ensureBreak(2)
+				}
+				lists = append(lists, markdownList{ordered: name == "ol", next: 1})
+			}
+		case name == "li":
+			if closing {
+				continue
+			}
+			ensureBreak(1)
+			indent := ""
+			marker := "- "
+			if len(lists) > 0 {
+				indent = strings.Repeat("  ", len(lists)-1)
+				current := &lists[len(lists)-1]
+				if current.ordered {
+					marker = fmt.Sprintf("%d. ", current.next)
+					current.next++
+				}
+			}
+			out.WriteString(indent + marker)
+		case name == "tr":
+			ensureBreak(1)
+		case name == "td" || name == "th":
+			if !closing && !strings.HasSuffix(out.String(), "\n") && out.Len() > 0 {
+				out.WriteString(" | ")
+				pendingSpace = false
+			}
+		case name == "b" || name == "strong" || name == "i" || name == "em":
+			marker := "**"
+			if name == "i" || name == "em" {
+				marker = "*"
+			}
+			// A closing marker sticks to the emphasized text; the space after it is kept pending
+			if closing {
+				out.WriteString(marker)
+			} else {
+				writeInline(marker)
+			}
+		case name == "a":
+			if closing {
+				if len(links) > 0 {
+					href := links[len(links)-1]
+					links = links[:len(links)-1]
+					if href != "" {
+						out.WriteString("](" + href + ")")
+					}
+				}
+				continue
+			}
+			href := ""
+			if hrefMatch := htmlHrefPattern.FindStringSubmatch(token); hrefMatch != nil {
+				href = html.UnescapeString(hrefMatch[1] + hrefMatch[2] + hrefMatch[3])
+			}
+			if strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+				href = ""
+			}
+			links = append(links, href)
+			if href != "" {
+				writeInline("[")
+			}
+		}
+	}
+
+	lines := strings.Split(out.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	markdown := markdownBlankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(markdown)
+}
+
+// renderHTMLBody returns an HTML body in the requested rendering. HTML is passed through sanitizeHTML unless
+// sanitize is false.
+func renderHTMLBody(content, render string, sanitize bool) (string, error) {
+	switch strings.ToLower(render) {
+	case "", renderHTML:
+		if sanitize {
+			return sanitizeHTML(content), nil
+		}
+		return content, nil
+	case renderMarkdown:
+		return htmlToMarkdown(content), nil
+	default:
+		return "", fmt.Errorf("invalid render '%s'. Use 'html' or 'markdown'", render)
+	}
+}
+
+// renderedContentLabel describes a body returned in the given rendering
+func renderedContentLabel(render string, sanitize bool) string {
+	if strings.ToLower(render) == renderMarkdown {
+		return "Markdown rendering"
+	}
+	if sanitize {
+		return "Sanitized HTML content"
+	}
+	return "Full HTML content"
+}
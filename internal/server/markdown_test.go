@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestHTMLToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{
+			name:     "headings and paragraphs",
+			html:     "<html><head><title>Interpelacja</title><style>p{color:red}</style></head><body><h2>Interpelacja nr 1</h2><p>Szanowny  Panie\n Ministrze,</p><p>uprzejmie proszę o odpowiedź.</p></body></html>",
+			expected: "## Interpelacja nr 1\n\nSzanowny Panie Ministrze,\n\nuprzejmie proszę o odpowiedź.",
+		},
+		{
+			name:     "nested and ordered lists",
+			html:     "<p>Pytania:</p><ol><li>Ile szkół?</li><li>Jakie koszty?<ul><li>w 2024 r.</li><li>w 2025 r.</li></ul></li></ol><p>Z poważaniem</p>",
+			expected: "Pytania:\n\n1. Ile szkół?\n2. Jakie koszty?\n  - w 2024 r.\n  - w 2025 r.\n\nZ poważaniem",
+		},
+		{
+			name:     "emphasis, links and entities",
+			html:     `<p>Zgodnie z <b>art. 115</b> <i>Konstytucji</i>, zob. <a href="https://www.sejm.gov.pl/">stronę Sejmu</a> &amp; <a href="#top">górę</a>.</p>`,
+			expected: "Zgodnie z **art. 115** *Konstytucji*, zob. [stronę Sejmu](https://www.sejm.gov.pl/) & górę.",
+		},
+		{
+			name:     "line breaks and scripts",
+			html:     "<div>Warszawa,<br/>12 marca 2024 r.<script>alert(1)</script></div><hr><p>Poseł Jan Kowalski</p>",
+			expected: "Warszawa,\n12 marca 2024 r.\n\n---\n\nPoseł Jan Kowalski",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := htmlToMarkdown(tt.html); got != tt.expected {
+				t.Errorf("htmlToMarkdown() =\n%q\nexpected\n%q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenderHTMLBody(t *testing.T) {
+	if got, err := renderHTMLBody("<p class=\"x\">a</p>", "", false); err != nil || got != "<p class=\"x\">a</p>" {
+		t.Errorf("Expected raw HTML without sanitizing, got %q, %v", got, err)
+	}
+	if got, err := renderHTMLBody("<p class=\"x\">a</p>", "", true); err != nil || got != "<p>a</p>" {
+		t.Errorf("Expected sanitized HTML, got %q, %v", got, err)
+	}
+	if got, err := renderHTMLBody("<p>a</p>", "Markdown", true); err != nil || got != "a" {
+		t.Errorf("Expected Markdown, got %q, %v", got, err)
+	}
+	if _, err := renderHTMLBody("<p>a</p>", "pdf", true); err == nil {
+		t.Error("Expected an error for an unknown rendering")
+	}
+}
+
+func TestHandleGetInterpellationBodyMarkdown(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/interpellations/1/body": "<html><body><h1>Interpelacja nr 1</h1><ul><li>pierwsze pytanie</li></ul></body></html>",
+	})
+
+	result, err := server.handleGetInterpellationBody(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "num": "1", "render": "markdown",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	if !strings.Contains(text, "# Interpelacja nr 1\n\n- pierwsze pytanie") || strings.Contains(text, "<li>") {
+		t.Errorf("Expected Markdown body, got:\n%s", text)
+	}
+
+	invalid, _ := server.handleGetInterpellationBody(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "num": "1", "render": "text",
+	}))
+	if !invalid.IsError {
+		t.Errorf("Expected an error for an invalid render value, got:\n%s", extractTextContent(invalid))
+	}
+}
@@ -0,0 +1,327 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxComparedMPs caps the number of MPs in one comparison, since the number of pairs grows quadratically
+const maxComparedMPs = 10
+
+// defaultComparisonDivergences is the number of divergent votings listed per pair when max_divergences is not given
+const defaultComparisonDivergences = 10
+
+// comparedVoting is a voting with the votes cast by each compared MP
+type comparedVoting struct {
+	Sitting int32
+	Number  int32
+	Date    string
+	Title   string
+	Votes   map[int32]sejm.VoteValue
+}
+
+// mpPairAgreement summarizes how two MPs voted relative to each other
+type mpPairAgreement struct {
+	First       int32
+	Second      int32
+	Agreed      int
+	Compared    int
+	Skipped     int
+	Divergences []comparedVoting
+}
+
+// Percentage returns the share of jointly cast votes on which the pair agreed
+func (p mpPairAgreement) Percentage() float64 {
+	if p.Compared == 0 {
+		return 0
+	}
+	return float64(p.Agreed) * 100 / float64(p.Compared)
+}
+
+// parseMPIDs parses a comma-separated list of distinct MP IDs
+func parseMPIDs(value string) ([]int32, error) {
+	var ids []int32
+	seen := make(map[int32]bool)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil || id < 1 {
+			return nil, fmt.Errorf("invalid MP ID '%s': must be a positive number", part)
+		}
+		if !seen[int32(id)] {
+			seen[int32(id)] = true
+			ids = append(ids, int32(id))
+		}
+	}
+	if len(ids) < 2 {
+		return nil, fmt.Errorf("at least two different MP IDs are required")
+	}
+	if len(ids) > maxComparedMPs {
+		return nil, fmt.Errorf("at most %d MPs can be compared at once", maxComparedMPs)
+	}
+	return ids, nil
+}
+
+// compareMPVotes computes the agreement of every pair of MPs over the aligned votings.
+// Only votings in which both MPs voted YES, NO or ABSTAIN count; absences are reported as skipped.
+func compareMPVotes(ids []int32, votings []comparedVoting) []mpPairAgreement {
+	var pairs []mpPairAgreement
+	for i := 0; i < len(ids); i++ {
+		for j := i + 1; j < len(ids); j++ {
+			pair := mpPairAgreement{First: ids[i], Second: ids[j]}
+			for _, voting := range votings {
+				first, firstOK := voting.Votes[ids[i]]
+				second, secondOK := voting.Votes[ids[j]]
+				if !firstOK || !secondOK || !isPositionVote(first) || !isPositionVote(second) {
+					pair.Skipped++
+					continue
+				}
+				pair.Compared++
+				if first == second {
+					pair.Agreed++
+				} else {
+					pair.Divergences = append(pair.Divergences, voting)
+				}
+			}
+			pairs = append(pairs, pair)
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Percentage() > pairs[j].Percentage()
+	})
+	return pairs
+}
+
+func (s *SejmServer) handleCompareMPs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_compare_mps called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+
+	ids, err := parseMPIDs(request.GetString("mp_ids", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Parameter 'mp_ids' is invalid: %v. Provide comma-separated MP IDs from sejm_get_mps (e.g., '1,25,130').", err)), nil
+	}
+
+	sitting := request.GetString("sitting", "")
+	from, err := parseDefectionDate("date_from", request.GetString("date_from", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	to, err := parseDefectionDate("date_to", request.GetString("date_to", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if sitting == "" && from.IsZero() && to.IsZero() {
+		return mcp.NewToolResultError("Provide 'sitting' (e.g., '15') or a date range with 'date_from' and/or 'date_to' (YYYY-MM-DD). Comparing a whole term at once would require downloading thousands of votings."), nil
+	}
+
+	maxVotings := defaultDefectionVotings
+	if maxStr := request.GetString("max_votings", ""); maxStr != "" {
+		if parsed, err := fmt.Sscanf(maxStr, "%d", &maxVotings); parsed != 1 || err != nil || maxVotings <= 0 {
+			maxVotings = defaultDefectionVotings
+		}
+		if maxVotings > maxDefectionVotings {
+			maxVotings = maxDefectionVotings
+		}
+	}
+	maxDivergences := defaultComparisonDivergences
+	if maxStr := request.GetString("max_divergences", ""); maxStr != "" {
+		if parsed, err := fmt.Sscanf(maxStr, "%d", &maxDivergences); parsed != 1 || err != nil || maxDivergences < 0 {
+			maxDivergences = defaultComparisonDivergences
+		}
+	}
+
+	sittings, err := s.defectionSittings(ctx, term, sitting, from, to)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to determine sittings to analyze: %v", err)), nil
+	}
+
+	// Failed sittings and votings are skipped to keep the range analysis going, and reported with the results
+	coverage := newSourceCoverage("sources")
+	var votings []sejm.Voting
+	for _, number := range sittings {
+		data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/votings/%d", s.sejmBaseURL, term, number), nil)
+		if err != nil {
+			if sitting != "" {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve votings from sitting %d in term %d: %v", number, term, err)), nil
+			}
+			coverage.fail(fmt.Sprintf("sitting %d", number), err)
+			continue
+		}
+		var sittingVotings []sejm.Voting
+		if err := s.decodeAPIResponse(data, &sittingVotings); err != nil {
+			coverage.fail(fmt.Sprintf("sitting %d", number), fmt.Errorf("failed to parse votings: %w", err))
+			continue
+		}
+		coverage.succeeded()
+		for _, voting := range sittingVotings {
+			if votingDateInRange(voting, from, to) {
+				votings = append(votings, voting)
+			}
+		}
+	}
+
+	truncated := len(votings) > maxVotings
+	if truncated {
+		votings = votings[:maxVotings]
+	}
+
+	wanted := make(map[int32]bool)
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	names := make(map[int32]string)
+	var aligned []comparedVoting
+	for _, voting := range votings {
+		if voting.Sitting == nil || voting.VotingNumber == nil {
+			continue
+		}
+		endpoint := fmt.Sprintf("%s/sejm/term%d/votings/%d/%d", s.sejmBaseURL, term, *voting.Sitting, *voting.VotingNumber)
+		detailsData, err := s.makeAPIRequest(ctx, endpoint, nil)
+		if err != nil {
+			s.logger.Warn("Skipping voting", slog.String("endpoint", endpoint), slog.Any("error", err))
+			coverage.fail(fmt.Sprintf("voting %d/%d", *voting.Sitting, *voting.VotingNumber), err)
+			continue
+		}
+		var details sejm.VotingDetails
+		if err := s.decodeAPIResponse(detailsData, &details); err != nil {
+			coverage.fail(fmt.Sprintf("voting %d/%d", *voting.Sitting, *voting.VotingNumber), fmt.Errorf("failed to parse voting details: %w", err))
+			continue
+		}
+		coverage.succeeded()
+		if details.Votes == nil {
+			continue
+		}
+
+		compared := comparedVoting{Sitting: *voting.Sitting, Number: *voting.VotingNumber, Title: "No title", Votes: make(map[int32]sejm.VoteValue)}
+		if voting.Date != nil {
+			compared.Date = voting.Date.Format("2006-01-02 15:04")
+		}
+		if voting.Title != nil {
+			compared.Title = *voting.Title
+		}
+		for _, vote := range *details.Votes {
+			if vote.MP == nil || vote.Vote == nil || !wanted[*vote.MP] {
+				continue
+			}
+			compared.Votes[*vote.MP] = *vote.Vote
+			if _, ok := names[*vote.MP]; !ok {
+				names[*vote.MP] = voteLabel(vote)
+			}
+		}
+		aligned = append(aligned, compared)
+	}
+
+	mpLabel := func(id int32) string {
+		if name, ok := names[id]; ok {
+			return name
+		}
+		return fmt.Sprintf("MP %d", id)
+	}
+
+	pairs := compareMPVotes(ids, aligned)
+
+	var data []string
+	data = append(data, "Pairwise agreement:")
+	for _, pair := range pairs {
+		if pair.Compared == 0 {
+			data = append(data, fmt.Sprintf("  • %s vs %s: no votings where both voted (%d skipped)", mpLabel(pair.First), mpLabel(pair.Second), pair.Skipped))
+			continue
+		}
+		data = append(data, fmt.Sprintf("  • %s vs %s: %.1f%% agreement (%d of %d votings where both voted, %d skipped)",
+			mpLabel(pair.First), mpLabel(pair.Second), pair.Percentage(), pair.Agreed, pair.Compared, pair.Skipped))
+	}
+
+	for _, pair := range pairs {
+		if len(pair.Divergences) == 0 || maxDivergences == 0 {
+			continue
+		}
+		data = append(data, "", fmt.Sprintf("Divergences: %s vs %s (%d):", mpLabel(pair.First), mpLabel(pair.Second), len(pair.Divergences)))
+		for i, voting := range pair.Divergences {
+			if i >= maxDivergences {
+				data = append(data, fmt.Sprintf("  ... and %d more", len(pair.Divergences)-maxDivergences))
+				break
+			}
+			header := fmt.Sprintf("Sitting %d, voting %d", voting.Sitting, voting.Number)
+			if voting.Date != "" {
+				header += fmt.Sprintf(" (%s)", voting.Date)
+			}
+			data = append(data, fmt.Sprintf("  • %s: %s — %s %s, %s %s", header, voting.Title,
+				mpLabel(pair.First), voting.Votes[pair.First], mpLabel(pair.Second), voting.Votes[pair.Second]))
+		}
+	}
+
+	var compared []string
+	for _, id := range ids {
+		compared = append(compared, fmt.Sprintf("%s (ID: %d)", mpLabel(id), id))
+	}
+	scope := fmt.Sprintf("term %d", term)
+	if sitting != "" {
+		scope += fmt.Sprintf(", sitting %s", sitting)
+	}
+	if !from.IsZero() || !to.IsZero() {
+		scope += fmt.Sprintf(", dates %s to %s", formatOptionalDate(from, "start"), formatOptionalDate(to, "end"))
+	}
+
+	summary := []string{
+		fmt.Sprintf("Scope: %s", scope),
+		fmt.Sprintf("MPs compared: %s", strings.Join(compared, ", ")),
+		fmt.Sprintf("Votings analyzed: %d", len(aligned)),
+	}
+
+	status := "Analysis Completed Successfully"
+	if len(aligned) == 0 {
+		status = "No Results Found"
+	}
+
+	note := "Agreement counts only votings where both MPs voted YES, NO or ABSTAIN; votings where either was absent or did not vote are skipped."
+	if truncated {
+		note += fmt.Sprintf(" Only the first %d votings in the range were analyzed; narrow the range or raise max_votings (up to %d).", maxVotings, maxDefectionVotings)
+	}
+
+	response := StandardResponse{
+		Operation:   "MP Voting Comparison",
+		Status:      coverage.status(status),
+		Summary:     summary,
+		Unavailable: coverage.unavailable(),
+		Data:        data,
+		NextActions: []string{
+			"Inspect a divergent voting: sejm_get_voting_details with sitting and voting_number",
+			"Check party discipline in the same range: sejm_find_defections",
+		},
+		Note: note,
+	}
+	return coverage.result(response.Format()), nil
+}
+
+// voteLabel returns the name and club of the MP who cast a vote
+func voteLabel(vote sejm.Vote) string {
+	var nameParts []string
+	for _, part := range []*string{vote.FirstName, vote.SecondName, vote.LastName} {
+		if part != nil && *part != "" {
+			nameParts = append(nameParts, *part)
+		}
+	}
+	label := strings.Join(nameParts, " ")
+	if label == "" && vote.MP != nil {
+		label = fmt.Sprintf("MP %d", *vote.MP)
+	}
+	if vote.Club != nil && *vote.Club != "" {
+		label += fmt.Sprintf(" (%s)", *vote.Club)
+	}
+	return label
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+func TestCompareMPVotes(t *testing.T) {
+	votings := []comparedVoting{
+		{Number: 1, Votes: map[int32]sejm.VoteValue{1: sejm.VoteValueYES, 2: sejm.VoteValueYES, 3: sejm.VoteValueNO}},
+		{Number: 2, Votes: map[int32]sejm.VoteValue{1: sejm.VoteValueNO, 2: sejm.VoteValueNO, 3: sejm.VoteValueNO}},
+		{Number: 3, Votes: map[int32]sejm.VoteValue{1: sejm.VoteValueYES, 2: sejm.VoteValueABSENT, 3: sejm.VoteValueABSTAIN}},
+	}
+
+	pairs := compareMPVotes([]int32{1, 2, 3}, votings)
+	if len(pairs) != 3 {
+		t.Fatalf("Expected 3 pairs, got %+v", pairs)
+	}
+	first := pairs[0]
+	if first.First != 1 || first.Second != 2 || first.Agreed != 2 || first.Compared != 2 || first.Skipped != 1 || first.Percentage() != 100 {
+		t.Errorf("Unexpected agreement of MPs 1 and 2: %+v", first)
+	}
+	for _, pair := range pairs[1:] {
+		if pair.First == 1 && pair.Second == 3 && (pair.Agreed != 1 || pair.Compared != 3 || len(pair.Divergences) != 2) {
+			t.Errorf("Unexpected agreement of MPs 1 and 3: %+v", pair)
+		}
+	}
+}
+
+func TestParseMPIDs(t *testing.T) {
+	ids, err := parseMPIDs(" 5, 7,5 ")
+	if err != nil || len(ids) != 2 || ids[0] != 5 || ids[1] != 7 {
+		t.Errorf("Unexpected IDs %v, error %v", ids, err)
+	}
+	for _, invalid := range []string{"", "5", "5,x", "5,-1", "1,2,3,4,5,6,7,8,9,10,11"} {
+		if _, err := parseMPIDs(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestHandleCompareMPs(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/votings/7": `[
+			{"sitting": 7, "votingNumber": 1, "date": "2024-03-07T10:00:00", "title": "Pkt 3. Projekt ustawy o zmianie ustawy"},
+			{"sitting": 7, "votingNumber": 2, "date": "2024-03-08T11:00:00", "title": "Pkt 4. Uchwała"}
+		]`,
+		"/sejm/term10/votings/7/1": `{"votes": [
+			{"MP": 1, "club": "KO", "firstName": "Anna", "lastName": "Nowak", "vote": "YES"},
+			{"MP": 2, "club": "PSL-TD", "firstName": "Jan", "lastName": "Kowalski", "vote": "NO"}
+		]}`,
+		"/sejm/term10/votings/7/2": `{"votes": [
+			{"MP": 1, "club": "KO", "firstName": "Anna", "lastName": "Nowak", "vote": "YES"},
+			{"MP": 2, "club": "PSL-TD", "firstName": "Jan", "lastName": "Kowalski", "vote": "YES"}
+		]}`,
+	})
+
+	result, err := server.handleCompareMPs(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "mp_ids": "1,2", "sitting": "7",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Votings analyzed: 2",
+		"Anna Nowak (KO) vs Jan Kowalski (PSL-TD): 50.0% agreement (1 of 2 votings where both voted, 0 skipped)",
+		"Sitting 7, voting 1 (2024-03-07 10:00): Pkt 3. Projekt ustawy o zmianie ustawy — Anna Nowak (KO) YES, Jan Kowalski (PSL-TD) NO",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected %q in output, got:\n%s", expected, content)
+		}
+	}
+
+	missingRange, _ := server.handleCompareMPs(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "mp_ids": "1,2",
+	}))
+	if !missingRange.IsError {
+		t.Errorf("Expected an error without a sitting or date range, got:\n%s", extractTextContent(missingRange))
+	}
+}
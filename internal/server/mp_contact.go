@@ -0,0 +1,278 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxContactsInText caps the MPs listed in the text view of a bulk contact export; csv and json are not capped
+const maxContactsInText = 100
+
+// mpContact is the outreach-oriented view of an MP: who they are and how to reach them or their club
+type mpContact struct {
+	ID          int32  `json:"id"`
+	Name        string `json:"name"`
+	Club        string `json:"club,omitempty"`
+	ClubName    string `json:"clubName,omitempty"`
+	District    string `json:"district,omitempty"`
+	DistrictNum int32  `json:"districtNum,omitempty"`
+	Voivodeship string `json:"voivodeship,omitempty"`
+	Active      bool   `json:"active"`
+	Email       string `json:"email,omitempty"`
+	ProfileURL  string `json:"profileUrl,omitempty"`
+	PhotoURL    string `json:"photoUrl,omitempty"`
+	ClubEmail   string `json:"clubEmail,omitempty"`
+	ClubPhone   string `json:"clubPhone,omitempty"`
+	ClubFax     string `json:"clubFax,omitempty"`
+}
+
+// mpContactCSVHeader lists the columns of the csv export, in mpContact field order
+var mpContactCSVHeader = []string{
+	"id", "name", "club", "club_name", "district", "district_num", "voivodeship", "active",
+	"email", "profile_url", "photo_url", "club_email", "club_phone", "club_fax",
+}
+
+// mpProfileURL returns the MP's page on sejm.gov.pl. The page address pattern is only stable from term 7 on.
+func mpProfileURL(term int, id int32) string {
+	if term < 7 {
+		return ""
+	}
+	return fmt.Sprintf("https://www.sejm.gov.pl/Sejm%d.nsf/posel.xsp?id=%03d&type=A", term, id)
+}
+
+// contactFromMP builds the contact view of an MP, adding club contact data when the club is known
+func (s *SejmServer) contactFromMP(term int, mp sejm.MP, clubs map[string]sejm.Club) mpContact {
+	contact := mpContact{
+		Name:        getFullName(mp),
+		Club:        stringValue(mp.Club),
+		District:    stringValue(mp.DistrictName),
+		Voivodeship: stringValue(mp.Voivodeship),
+		Email:       stringValue(mp.Email),
+		Active:      mp.Active == nil || *mp.Active,
+	}
+	if mp.DistrictNum != nil {
+		contact.DistrictNum = *mp.DistrictNum
+	}
+	if mp.Id != nil {
+		contact.ID = *mp.Id
+		contact.ProfileURL = mpProfileURL(term, *mp.Id)
+		contact.PhotoURL = fmt.Sprintf("%s/sejm/term%d/MP/%d/photo", s.sejmBaseURL, term, *mp.Id)
+	}
+	if club, ok := clubs[contact.Club]; ok {
+		contact.ClubName = stringValue(club.Name)
+		contact.ClubEmail = stringValue(club.Email)
+		contact.ClubPhone = stringValue(club.Phone)
+		contact.ClubFax = stringValue(club.Fax)
+	}
+	return contact
+}
+
+// stringValue dereferences an optional API string
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// fetchClubsByID returns the clubs of a term keyed by club ID. Club contact data is optional, so
+// failures are logged by makeAPIRequest and an empty map is returned.
+func (s *SejmServer) fetchClubsByID(ctx context.Context, term int) map[string]sejm.Club {
+	clubs := make(map[string]sejm.Club)
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/clubs", s.sejmBaseURL, term), nil)
+	if err != nil {
+		return clubs
+	}
+	var list []sejm.Club
+	if err := s.decodeAPIResponse(data, &list); err != nil {
+		return clubs
+	}
+	for _, club := range list {
+		if club.Id != nil {
+			clubs[*club.Id] = club
+		}
+	}
+	return clubs
+}
+
+// formatContactsCSV renders contacts as CSV with a header row
+func formatContactsCSV(contacts []mpContact) (string, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.Write(mpContactCSVHeader); err != nil {
+		return "", err
+	}
+	for _, c := range contacts {
+		districtNum := ""
+		if c.DistrictNum != 0 {
+			districtNum = strconv.Itoa(int(c.DistrictNum))
+		}
+		record := []string{
+			strconv.Itoa(int(c.ID)), c.Name, c.Club, c.ClubName, c.District, districtNum, c.Voivodeship,
+			strconv.FormatBool(c.Active), c.Email, c.ProfileURL, c.PhotoURL, c.ClubEmail, c.ClubPhone, c.ClubFax,
+		}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	return buffer.String(), writer.Error()
+}
+
+// formatContactLines renders one contact for the text view
+func formatContactLines(c mpContact) []string {
+	header := fmt.Sprintf("%s (ID: %d)", c.Name, c.ID)
+	if c.Club != "" {
+		header += fmt.Sprintf(" – %s", c.Club)
+	}
+	if !c.Active {
+		header += " [inactive]"
+	}
+	lines := []string{header}
+	if c.Email != "" {
+		lines = append(lines, fmt.Sprintf("   Email: %s", c.Email))
+	}
+	if c.District != "" {
+		lines = append(lines, fmt.Sprintf("   District: %d %s (%s)", c.DistrictNum, c.District, c.Voivodeship))
+	}
+	if c.ProfileURL != "" {
+		lines = append(lines, fmt.Sprintf("   Profile: %s", c.ProfileURL))
+	}
+	if c.ClubEmail != "" || c.ClubPhone != "" {
+		lines = append(lines, fmt.Sprintf("   Club office: %s", strings.Join(nonEmpty(c.ClubEmail, c.ClubPhone), ", ")))
+	}
+	return lines
+}
+
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func (s *SejmServer) handleGetMPContact(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+
+	mpID := request.GetString("mp_id", "")
+	clubFilter := strings.TrimSpace(request.GetString("club", ""))
+	activeOnly := request.GetString("active_only", "true") != "false"
+	format := strings.ToLower(request.GetString("format", "text"))
+	if mpID == "" && clubFilter == "" {
+		return mcp.NewToolResultError("Provide either mp_id for a single MP or club for a bulk export (a club ID such as 'KO', or 'all' for every MP). Get IDs from sejm_get_mps or sejm_get_clubs."), nil
+	}
+	if format != "text" && format != "csv" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text', 'csv', or 'json'.", format)), nil
+	}
+
+	var mps []sejm.MP
+	if mpID != "" {
+		data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/MP/%s", s.sejmBaseURL, term, mpID), nil)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve MP details: %v. Please verify the MP ID (%s) exists in term %d.", err, mpID, term)), nil
+		}
+		var mp sejm.MP
+		if err := s.decodeAPIResponse(data, &mp); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to parse MP data: %v.", err)), nil
+		}
+		mps = []sejm.MP{mp}
+	} else {
+		data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/MP", s.sejmBaseURL, term), nil)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve MPs for term %d: %v.", term, err)), nil
+		}
+		var all []sejm.MP
+		if err := s.decodeAPIResponse(data, &all); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to parse MPs data: %v.", err)), nil
+		}
+		for _, mp := range all {
+			if !strings.EqualFold(clubFilter, "all") && !strings.EqualFold(stringValue(mp.Club), clubFilter) {
+				continue
+			}
+			if activeOnly && mp.Active != nil && !*mp.Active {
+				continue
+			}
+			mps = append(mps, mp)
+		}
+		if len(mps) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("No MPs found in club '%s' for term %d. Check the club ID with sejm_get_clubs.", clubFilter, term)), nil
+		}
+	}
+
+	clubs := s.fetchClubsByID(ctx, term)
+	contacts := make([]mpContact, 0, len(mps))
+	for _, mp := range mps {
+		contacts = append(contacts, s.contactFromMP(term, mp, clubs))
+	}
+	sort.SliceStable(contacts, func(i, j int) bool {
+		if contacts[i].Club != contacts[j].Club {
+			return contacts[i].Club < contacts[j].Club
+		}
+		return contacts[i].ID < contacts[j].ID
+	})
+
+	switch format {
+	case "csv":
+		output, err := formatContactsCSV(contacts)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to build CSV export: %v.", err)), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	case "json":
+		output, _ := json.MarshalIndent(contacts, "", "  ")
+		return mcp.NewToolResultText(string(output)), nil
+	}
+
+	withEmail := 0
+	var data []string
+	for i, contact := range contacts {
+		if contact.Email != "" {
+			withEmail++
+		}
+		if i < maxContactsInText {
+			data = append(data, formatContactLines(contact)...)
+		}
+	}
+	if len(contacts) > maxContactsInText {
+		data = append(data, fmt.Sprintf("... and %d more MPs (use format='csv' or format='json' for the full export)", len(contacts)-maxContactsInText))
+	}
+
+	scope := fmt.Sprintf("MP ID %s", mpID)
+	if mpID == "" {
+		scope = fmt.Sprintf("club %s", clubFilter)
+		if strings.EqualFold(clubFilter, "all") {
+			scope = "all clubs"
+		}
+	}
+	response := StandardResponse{
+		Operation: "MP Contact Information",
+		Status:    "Retrieved Successfully",
+		Summary: []string{
+			fmt.Sprintf("Term: %d", term),
+			fmt.Sprintf("Scope: %s", scope),
+			fmt.Sprintf("MPs: %d (with e-mail: %d)", len(contacts), withEmail),
+		},
+		Data: data,
+		NextActions: []string{
+			"Export the same list with format='csv' or format='json'",
+			"Use sejm_get_mp_details for the full MP profile",
+		},
+		Note: "The Sejm API publishes MP e-mail addresses and club office contacts only. Constituency office addresses and social media accounts are not available through the API; see the official profile page.",
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+)
+
+func mpContactFixtures(t *testing.T) *SejmServer {
+	t.Helper()
+	return newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/MP": `[
+			{"id":1,"firstLastName":"Jan Kowalski","club":"KO","active":true,"email":"Jan.Kowalski@sejm.pl","districtName":"Warszawa","districtNum":19,"voivodeship":"mazowieckie"},
+			{"id":2,"firstLastName":"Anna Nowak","club":"PiS","active":true,"email":"Anna.Nowak@sejm.pl","districtName":"Kraków","districtNum":13,"voivodeship":"małopolskie"},
+			{"id":3,"firstLastName":"Piotr Wiśniewski","club":"KO","active":false,"districtName":"Gdańsk","districtNum":25,"voivodeship":"pomorskie"}
+		]`,
+		"/sejm/term10/MP/1":  `{"id":1,"firstLastName":"Jan Kowalski","club":"KO","active":true,"email":"Jan.Kowalski@sejm.pl","districtName":"Warszawa","districtNum":19,"voivodeship":"mazowieckie"}`,
+		"/sejm/term10/clubs": `[{"id":"KO","name":"Klub Parlamentarny Koalicja Obywatelska","email":"ko@sejm.pl","phone":"22 694 00 00"}]`,
+	})
+}
+
+func TestHandleGetMPContactSingle(t *testing.T) {
+	server := mpContactFixtures(t)
+	result, err := server.handleGetMPContact(context.Background(), createMockRequest(map[string]interface{}{"mp_id": "1"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{
+		"MP Contact Information - Retrieved Successfully",
+		"Jan Kowalski (ID: 1) – KO",
+		"Email: Jan.Kowalski@sejm.pl",
+		"Profile: https://www.sejm.gov.pl/Sejm10.nsf/posel.xsp?id=001&type=A",
+		"Club office: ko@sejm.pl, 22 694 00 00",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output, got:\n%s", expected, text)
+		}
+	}
+}
+
+func TestHandleGetMPContactClubCSV(t *testing.T) {
+	server := mpContactFixtures(t)
+	result, err := server.handleGetMPContact(context.Background(), createMockRequest(map[string]interface{}{"club": "ko", "format": "csv"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	records, err := csv.NewReader(strings.NewReader(extractTextContent(result))).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV: %v", err)
+	}
+	// Header plus the active KO MP; the inactive one and the PiS MP are excluded
+	if len(records) != 2 {
+		t.Fatalf("Expected header and 1 row, got %v", records)
+	}
+	if records[1][1] != "Jan Kowalski" || records[1][8] != "Jan.Kowalski@sejm.pl" || records[1][12] != "22 694 00 00" {
+		t.Errorf("Unexpected CSV row: %v", records[1])
+	}
+
+	all, _ := server.handleGetMPContact(context.Background(), createMockRequest(map[string]interface{}{"club": "all", "active_only": "false", "format": "json"}))
+	if !strings.Contains(extractTextContent(all), `"name": "Piotr Wiśniewski"`) || !strings.Contains(extractTextContent(all), `"name": "Anna Nowak"`) {
+		t.Errorf("Expected every MP in the json export, got:\n%s", extractTextContent(all))
+	}
+
+	missing, _ := server.handleGetMPContact(context.Background(), createMockRequest(map[string]interface{}{}))
+	if !missing.IsError {
+		t.Error("Expected an error without mp_id or club")
+	}
+}
@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Kinds of MP financial disclosures published on sejm.gov.pl
+const (
+	declarationKindAssets   = "assets"
+	declarationKindBenefits = "benefits"
+)
+
+// maxDeclarationTabs bounds the pages linked from the MP profile that are followed to find disclosures
+const maxDeclarationTabs = 3
+
+var (
+	htmlLinkPattern        = regexp.MustCompile(`(?is)<a\s[^>]*href\s*=\s*["']([^"']+)["'][^>]*>(.*?)</a>`)
+	declarationYearPattern = regexp.MustCompile(`\b(?:19|20)\d{2}\b`)
+)
+
+// mpDeclaration is a financial disclosure document of an MP: an asset declaration (oświadczenie majątkowe)
+// or an entry of the benefits register (rejestr korzyści)
+type mpDeclaration struct {
+	Kind  string `json:"kind"`
+	Title string `json:"title"`
+	Year  int    `json:"year,omitempty"`
+	URL   string `json:"url"`
+}
+
+// isSejmWebsiteURL tells whether a URL points at the Sejm website (sejm.gov.pl or one of its subdomains,
+// e.g. orka.sejm.gov.pl where documents are stored)
+func isSejmWebsiteURL(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	return (u.Scheme == "https" || u.Scheme == "http") && (host == "sejm.gov.pl" || strings.HasSuffix(host, ".sejm.gov.pl"))
+}
+
+// declarationKind classifies a link by its text and address; it returns an empty kind for other links
+func declarationKind(href, text string) string {
+	label := normalizePolish(text + " " + href)
+	switch {
+	case strings.Contains(label, "majatk") || strings.Contains(label, "/osw"):
+		return declarationKindAssets
+	case strings.Contains(label, "korzysc") || strings.Contains(label, "rejestr"):
+		return declarationKindBenefits
+	}
+	return ""
+}
+
+// isDocumentLink tells whether a link points at a document rather than another page
+func isDocumentLink(u *url.URL) bool {
+	ext := strings.ToLower(path.Ext(u.Path))
+	return ext == ".pdf" || strings.Contains(strings.ToLower(u.Path), "$file")
+}
+
+// parseDeclarationLinks finds disclosure documents linked from a page of the Sejm website, and the pages
+// (e.g. the 'Oświadczenia majątkowe' tab) that may list more of them
+func parseDeclarationLinks(page string, base *url.URL) ([]mpDeclaration, []string) {
+	var documents []mpDeclaration
+	var tabs []string
+	for _, match := range htmlLinkPattern.FindAllStringSubmatch(page, -1) {
+		link, err := base.Parse(strings.TrimSpace(htmlToPlainText(match[1])))
+		if err != nil || !isSejmWebsiteURL(link) {
+			continue
+		}
+		text := strings.Join(strings.Fields(htmlToPlainText(match[2])), " ")
+		kind := declarationKind(link.String(), text)
+		if kind == "" {
+			continue
+		}
+		if !isDocumentLink(link) {
+			tabs = append(tabs, link.String())
+			continue
+		}
+		document := mpDeclaration{Kind: kind, Title: valueOrDefault(text, path.Base(link.Path)), URL: link.String()}
+		if year := declarationYearPattern.FindString(text + " " + path.Base(link.Path)); year != "" {
+			document.Year, _ = strconv.Atoi(year)
+		}
+		documents = append(documents, document)
+	}
+	return documents, tabs
+}
+
+// fetchMPDeclarations lists the disclosure documents linked from an MP's profile on sejm.gov.pl and the
+// pages it links to, newest first. The Sejm API does not publish them.
+func (s *SejmServer) fetchMPDeclarations(ctx context.Context, term int, mpID int32) ([]mpDeclaration, error) {
+	profile := mpProfileURL(term, mpID)
+	if profile == "" {
+		return nil, fmt.Errorf("MP profiles on sejm.gov.pl are only available from term 7")
+	}
+	base, _ := url.Parse(profile)
+	data, err := s.makeTextRequest(ctx, profile, "html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve MP profile page: %w", err)
+	}
+	documents, tabs := parseDeclarationLinks(string(data), base)
+
+	visited := map[string]bool{profile: true}
+	for _, tab := range tabs {
+		if visited[tab] || len(visited) > maxDeclarationTabs {
+			continue
+		}
+		visited[tab] = true
+		tabURL, _ := url.Parse(tab)
+		data, err := s.makeTextRequest(ctx, tab, "html")
+		if err != nil {
+			s.logger.Warn("Failed to retrieve disclosure page", slog.String("url", tab), slog.Any("error", err))
+			continue
+		}
+		more, _ := parseDeclarationLinks(string(data), tabURL)
+		documents = append(documents, more...)
+	}
+
+	seen := make(map[string]bool)
+	var unique []mpDeclaration
+	for _, document := range documents {
+		if !seen[document.URL] {
+			seen[document.URL] = true
+			unique = append(unique, document)
+		}
+	}
+	sort.SliceStable(unique, func(i, j int) bool {
+		if unique[i].Kind != unique[j].Kind {
+			return unique[i].Kind == declarationKindAssets
+		}
+		return unique[i].Year > unique[j].Year
+	})
+	return unique, nil
+}
+
+func (s *SejmServer) handleGetMPDeclarations(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_mp_declarations called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	id, err := strconv.Atoi(request.GetString("mp_id", ""))
+	if err != nil || id < 1 {
+		return mcp.NewToolResultError("Parameter 'mp_id' is required and must be a positive number. Find MP IDs with sejm_get_mps."), nil
+	}
+	kind := strings.ToLower(request.GetString("kind", "all"))
+	if kind != "all" && kind != declarationKindAssets && kind != declarationKindBenefits {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid kind '%s'. Use 'assets', 'benefits' or 'all'.", kind)), nil
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	documents, err := s.fetchMPDeclarations(ctx, term, int32(id))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list disclosures of MP %d in term %d: %v.", id, term, err)), nil
+	}
+	if kind != "all" {
+		var filtered []mpDeclaration
+		for _, document := range documents {
+			if document.Kind == kind {
+				filtered = append(filtered, document)
+			}
+		}
+		documents = filtered
+	}
+
+	if format == "json" {
+		result, _ := json.MarshalIndent(map[string]interface{}{
+			"term":      term,
+			"mpId":      id,
+			"profile":   mpProfileURL(term, int32(id)),
+			"documents": documents,
+		}, "", "  ")
+		return mcp.NewToolResultText(string(result)), nil
+	}
+
+	counts := make(map[string]int)
+	for _, document := range documents {
+		counts[document.Kind]++
+	}
+	summary := []string{
+		fmt.Sprintf("MP ID: %d, term %d", id, term),
+		fmt.Sprintf("Asset declarations (oświadczenia majątkowe): %d", counts[declarationKindAssets]),
+		fmt.Sprintf("Benefits register entries (rejestr korzyści): %d", counts[declarationKindBenefits]),
+		fmt.Sprintf("Profile: %s", mpProfileURL(term, int32(id))),
+	}
+	var results []string
+	status := "Retrieved Successfully"
+	if len(documents) == 0 {
+		status = "No Results Found"
+		results = append(results, "No disclosure documents are linked from the MP's profile page. They may not be published yet for this term.")
+	}
+	for _, document := range documents {
+		label := "Asset declaration"
+		if document.Kind == declarationKindBenefits {
+			label = "Benefits register"
+		}
+		results = append(results, fmt.Sprintf("• %s: %s", label, document.Title), "  "+document.URL)
+	}
+
+	response := StandardResponse{
+		Operation: "MP Financial Disclosures",
+		Status:    status,
+		Summary:   summary,
+		Data:      results,
+		NextActions: []string{
+			"Text of a document: sejm_get_mp_declaration_text with url",
+			"The PDF itself: sejm_get_mp_declaration_text with url and return_content='blob'",
+			"MP profile: sejm_get_mp_details with mp_id",
+		},
+		Note: "Disclosures are read from the MP's page on sejm.gov.pl, because the Sejm API does not publish them. Many declarations are scanned handwritten forms without a text layer.",
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
+
+func (s *SejmServer) handleGetMPDeclarationText(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_mp_declaration_text called", slog.Any("arguments", request.Params.Arguments))
+
+	document, err := url.Parse(strings.TrimSpace(request.GetString("url", "")))
+	if err != nil || !isSejmWebsiteURL(document) || !isDocumentLink(document) {
+		return mcp.NewToolResultError("Parameter 'url' must be the address of a disclosure document on sejm.gov.pl, as listed by sejm_get_mp_declarations."), nil
+	}
+	if _, _, err := parseAttachmentDelivery(request.GetString("return_content", ""), request.GetString("max_size_bytes", "")); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	endpoint := document.String()
+	data, err := s.makeAPIRequestWithHeaders(ctx, endpoint, nil, map[string]string{"Accept": "*/*"})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to download the disclosure document: %v.", err)), nil
+	}
+	uri := fmt.Sprintf("%sdeclarations/%s", attachmentResourceScheme, strings.TrimPrefix(document.Host+document.Path, "/"))
+	return s.documentFileResult(ctx, request, "sejm_get_mp_declaration_text", path.Base(document.Path), uri, endpoint, data,
+		"; declarations are often scanned handwritten forms")
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const mpProfileFixture = `<html><body>
+<h1>Jan Kowalski</h1>
+<a href="/Sejm10.nsf/komisje.xsp">Komisje</a>
+<a href="/Sejm10.nsf/oswiadczenia.xsp?id=001">Oświadczenia majątkowe</a>
+<a href="https://orka.sejm.gov.pl/rejestrk.nsf/2024/001.pdf">Rejestr korzyści 2024</a>
+<a href="https://example.com/majatek.pdf">Oświadczenie majątkowe (kopia)</a>
+</body></html>`
+
+const mpDisclosuresTabFixture = `<html><body>
+<a href="https://orka.sejm.gov.pl/osw10.nsf/2023/001.pdf">Oświadczenie majątkowe za rok 2023</a>
+<a href='https://orka.sejm.gov.pl/osw10.nsf/2024/001.pdf'>Oświadczenie majątkowe za rok 2024</a>
+<a href="https://orka.sejm.gov.pl/rejestrk.nsf/2024/001.pdf">Rejestr korzyści 2024</a>
+</body></html>`
+
+func TestParseDeclarationLinks(t *testing.T) {
+	base, _ := url.Parse(mpProfileURL(10, 1))
+	documents, tabs := parseDeclarationLinks(mpProfileFixture, base)
+
+	if len(tabs) != 1 || tabs[0] != "https://www.sejm.gov.pl/Sejm10.nsf/oswiadczenia.xsp?id=001" {
+		t.Errorf("Expected the disclosures tab to be followed, got %v", tabs)
+	}
+	if len(documents) != 1 {
+		t.Fatalf("Expected only the benefits register document on sejm.gov.pl, got %+v", documents)
+	}
+	if documents[0].Kind != declarationKindBenefits || documents[0].Year != 2024 {
+		t.Errorf("Expected a 2024 benefits register entry, got %+v", documents[0])
+	}
+}
+
+func TestHandleGetMPDeclarations(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/Sejm10.nsf/posel.xsp":        mpProfileFixture,
+		"/Sejm10.nsf/oswiadczenia.xsp": mpDisclosuresTabFixture,
+	})
+
+	result, err := server.handleGetMPDeclarations(context.Background(), createMockRequest(map[string]interface{}{
+		"mp_id": "1", "format": "json",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	var response struct {
+		Documents []mpDeclaration `json:"documents"`
+	}
+	if err := json.Unmarshal([]byte(extractTextContent(result)), &response); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(response.Documents) != 3 {
+		t.Fatalf("Expected 3 unique documents, got %+v", response.Documents)
+	}
+	if response.Documents[0].Year != 2024 || response.Documents[0].Kind != declarationKindAssets ||
+		response.Documents[2].Kind != declarationKindBenefits {
+		t.Errorf("Expected asset declarations newest first, then the benefits register, got %+v", response.Documents)
+	}
+
+	result, _ = server.handleGetMPDeclarations(context.Background(), createMockRequest(map[string]interface{}{
+		"mp_id": "1", "kind": "benefits",
+	}))
+	text := extractTextContent(result)
+	if !strings.Contains(text, "Benefits register entries (rejestr korzyści): 1") || strings.Contains(text, "osw10.nsf") {
+		t.Errorf("Expected only the benefits register, got: %s", text)
+	}
+
+	for _, args := range []map[string]interface{}{
+		{"mp_id": "abc"},
+		{"mp_id": "1", "kind": "salary"},
+		{"mp_id": "1", "term": "6"},
+	} {
+		result, _ := server.handleGetMPDeclarations(context.Background(), createMockRequest(args))
+		if !result.IsError {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
+
+func TestHandleGetMPDeclarationText(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/osw10.nsf/2024/001.pdf": "not a text layer",
+	})
+
+	for _, address := range []string{"", "https://example.com/osw.pdf", "https://www.sejm.gov.pl/Sejm10.nsf/posel.xsp?id=001"} {
+		result, _ := server.handleGetMPDeclarationText(context.Background(), createMockRequest(map[string]interface{}{"url": address}))
+		if !result.IsError {
+			t.Errorf("Expected an error for url '%s'", address)
+		}
+	}
+
+	document := "https://orka.sejm.gov.pl/osw10.nsf/2024/001.pdf"
+	result, _ := server.handleGetMPDeclarationText(context.Background(), createMockRequest(map[string]interface{}{"url": document}))
+	if !result.IsError || !strings.Contains(extractTextContent(result), "return_content='blob'") {
+		t.Errorf("Expected a scanned document to suggest downloading the file, got: %s", extractTextContent(result))
+	}
+
+	result, _ = server.handleGetMPDeclarationText(context.Background(), createMockRequest(map[string]interface{}{
+		"url": document, "return_content": "blob",
+	}))
+	if result.IsError {
+		t.Fatalf("Expected the file to be returned, got: %s", extractTextContent(result))
+	}
+	var embedded bool
+	for _, content := range result.Content {
+		if resource, ok := content.(mcp.EmbeddedResource); ok {
+			blob, ok := resource.Resource.(mcp.BlobResourceContents)
+			embedded = ok && blob.URI == "sejm://declarations/orka.sejm.gov.pl/osw10.nsf/2024/001.pdf"
+		}
+	}
+	if !embedded {
+		t.Errorf("Expected the document as an embedded blob, got %+v", result.Content)
+	}
+}
@@ -0,0 +1,394 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// maxDemographicTerms bounds the terms compared in one call
+	maxDemographicTerms = 10
+	// maxDemographicProfessions is the number of professions listed per group
+	maxDemographicProfessions = 10
+)
+
+// masculineNamesEndingInA are male first names that end in 'a', the ending of female Polish first names
+var masculineNamesEndingInA = []string{"kuba", "barnaba", "bonawentura", "jarema", "kosma", "dyzma", "zawisza", "sasza", "misza", "nikita"}
+
+// inferGender guesses the gender of a Polish first name from its ending
+func inferGender(firstName string) string {
+	name := strings.ToLower(strings.TrimSpace(firstName))
+	switch {
+	case name == "":
+		return "unknown"
+	case strings.HasSuffix(name, "a") && !containsString(masculineNamesEndingInA, name):
+		return "women"
+	default:
+		return "men"
+	}
+}
+
+// mpAgeStats describes the ages of a group of MPs at the start of the term
+type mpAgeStats struct {
+	Median   float64 `json:"median"`
+	Mean     float64 `json:"mean"`
+	Youngest int     `json:"youngest"`
+	Oldest   int     `json:"oldest"`
+}
+
+// mpDemographics are the distributions of the profile fields of a group of MPs
+type mpDemographics struct {
+	Group        string       `json:"group"`
+	MPs          int          `json:"mps"`
+	Gender       []facetValue `json:"gender,omitempty"`
+	Age          *mpAgeStats  `json:"age,omitempty"`
+	BirthDecades []facetValue `json:"birthDecades,omitempty"`
+	Education    []facetValue `json:"education"`
+	Professions  []facetValue `json:"professions"`
+}
+
+// termDemographics are the demographics of a term, overall and per club
+type termDemographics struct {
+	Term    int              `json:"term"`
+	From    string           `json:"from,omitempty"`
+	Overall mpDemographics   `json:"overall"`
+	Clubs   []mpDemographics `json:"clubs,omitempty"`
+}
+
+// sortedFacetValues orders counted values by count and then by value
+func sortedFacetValues(counts map[string]int) []facetValue {
+	values := make([]facetValue, 0, len(counts))
+	for value, count := range counts {
+		values = append(values, facetValue{Value: value, Count: count})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].Count != values[j].Count {
+			return values[i].Count > values[j].Count
+		}
+		return values[i].Value < values[j].Value
+	})
+	return values
+}
+
+// ageAt returns the age on a date of someone born on birth
+func ageAt(birth, date time.Time) int {
+	age := date.Year() - birth.Year()
+	if date.Month() < birth.Month() || (date.Month() == birth.Month() && date.Day() < birth.Day()) {
+		age--
+	}
+	return age
+}
+
+// buildMPDemographics aggregates the profile fields of a group of MPs. Ages are computed at termStart and left
+// out when it is zero; gender is only inferred when asked for.
+func buildMPDemographics(group string, mps []sejm.MP, termStart time.Time, withGender bool) mpDemographics {
+	demographics := mpDemographics{Group: group, MPs: len(mps)}
+	gender := make(map[string]int)
+	decades := make(map[string]int)
+	education := make(map[string]int)
+	professions := make(map[string]int)
+	var ages []int
+	for _, mp := range mps {
+		if withGender {
+			gender[inferGender(stringValue(mp.FirstName))]++
+		}
+		if mp.BirthDate != nil {
+			birth := mp.BirthDate.Time
+			decades[fmt.Sprintf("%ds", birth.Year()/10*10)]++
+			if !termStart.IsZero() {
+				ages = append(ages, ageAt(birth, termStart))
+			}
+		}
+		education[valueOrDefault(strings.ToLower(strings.TrimSpace(stringValue(mp.EducationLevel))), "not given")]++
+		if profession := strings.ToLower(strings.Join(strings.Fields(stringValue(mp.Profession)), " ")); profession != "" {
+			professions[profession]++
+		}
+	}
+
+	if withGender {
+		demographics.Gender = sortedFacetValues(gender)
+	}
+	if len(ages) > 0 {
+		sort.Ints(ages)
+		sum := 0
+		for _, age := range ages {
+			sum += age
+		}
+		median := float64(ages[len(ages)/2])
+		if len(ages)%2 == 0 {
+			median = float64(ages[len(ages)/2-1]+ages[len(ages)/2]) / 2
+		}
+		demographics.Age = &mpAgeStats{Median: median, Mean: float64(sum) / float64(len(ages)), Youngest: ages[0], Oldest: ages[len(ages)-1]}
+	}
+	demographics.BirthDecades = sortedFacetValues(decades)
+	sort.Slice(demographics.BirthDecades, func(i, j int) bool { return demographics.BirthDecades[i].Value < demographics.BirthDecades[j].Value })
+	demographics.Education = sortedFacetValues(education)
+	demographics.Professions = sortedFacetValues(professions)
+	if len(demographics.Professions) > maxDemographicProfessions {
+		demographics.Professions = demographics.Professions[:maxDemographicProfessions]
+	}
+	return demographics
+}
+
+// demographicShares renders counted values with their share of the group, e.g. "wyższe 430 (86%)"
+func demographicShares(values []facetValue, total int) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = fmt.Sprintf("%s %d (%.0f%%)", value.Value, value.Count, 100*float64(value.Count)/float64(max(total, 1)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// lines renders the full demographics of a group
+func (d mpDemographics) lines() []string {
+	var lines []string
+	if len(d.Gender) > 0 {
+		lines = append(lines, "• Gender (inferred from first names): "+demographicShares(d.Gender, d.MPs))
+	}
+	if d.Age != nil {
+		lines = append(lines, fmt.Sprintf("• Age at the start of the term: median %.0f, mean %.1f, youngest %d, oldest %d", d.Age.Median, d.Age.Mean, d.Age.Youngest, d.Age.Oldest))
+	}
+	if len(d.BirthDecades) > 0 {
+		decades := make([]string, len(d.BirthDecades))
+		for i, decade := range d.BirthDecades {
+			decades[i] = fmt.Sprintf("%s %d", decade.Value, decade.Count)
+		}
+		lines = append(lines, "• Born in the: "+strings.Join(decades, ", "))
+	}
+	lines = append(lines, "• Education: "+demographicShares(d.Education, d.MPs))
+	if len(d.Professions) > 0 {
+		professions := make([]string, len(d.Professions))
+		for i, profession := range d.Professions {
+			professions[i] = fmt.Sprintf("%s %d", profession.Value, profession.Count)
+		}
+		lines = append(lines, "• Most common professions: "+strings.Join(professions, ", "))
+	}
+	return lines
+}
+
+// line renders the demographics of a club in one line
+func (d mpDemographics) line() string {
+	parts := []string{fmt.Sprintf("%d MPs", d.MPs)}
+	for _, gender := range d.Gender {
+		if gender.Value == "women" {
+			parts = append(parts, fmt.Sprintf("women %.0f%%", 100*float64(gender.Count)/float64(max(d.MPs, 1))))
+		}
+	}
+	if d.Age != nil {
+		parts = append(parts, fmt.Sprintf("median age %.0f", d.Age.Median))
+	}
+	if len(d.Education) > 0 {
+		parts = append(parts, fmt.Sprintf("%s education %.0f%%", d.Education[0].Value, 100*float64(d.Education[0].Count)/float64(max(d.MPs, 1))))
+	}
+	var professions []string
+	for i, profession := range d.Professions {
+		if i == 3 {
+			break
+		}
+		professions = append(professions, profession.Value)
+	}
+	if len(professions) > 0 {
+		parts = append(parts, "professions: "+strings.Join(professions, ", "))
+	}
+	return fmt.Sprintf("• %s: %s", d.Group, strings.Join(parts, "; "))
+}
+
+// fetchTermStarts returns the start dates of the Sejm terms
+func (s *SejmServer) fetchTermStarts(ctx context.Context) (map[int]time.Time, error) {
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term", s.sejmBaseURL), nil)
+	if err != nil {
+		return nil, err
+	}
+	var terms []sejm.Term
+	if err := s.decodeAPIResponse(data, &terms); err != nil {
+		return nil, fmt.Errorf("failed to parse terms: %w", err)
+	}
+	starts := make(map[int]time.Time, len(terms))
+	for _, term := range terms {
+		if term.Num != nil && term.From != nil {
+			starts[int(*term.Num)] = term.From.Time
+		}
+	}
+	return starts, nil
+}
+
+func (s *SejmServer) handleGetMPsDemographics(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_mps_demographics called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	fromTerm := term
+	if request.GetString("from_term", "") != "" {
+		if fromTerm, err = s.validateTerm(request.GetString("from_term", "")); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid from_term: %v. Please use term numbers 1-10.", err)), nil
+		}
+		if fromTerm > term {
+			return mcp.NewToolResultError(fmt.Sprintf("from_term %d is after term %d. Give the earlier term in from_term.", fromTerm, term)), nil
+		}
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+	club := strings.TrimSpace(request.GetString("club", ""))
+	byClub := request.GetString("by_club", "true") != "false"
+	withGender := request.GetString("infer_gender", "true") != "false"
+	activeOnly := request.GetString("active_only", "false") == "true"
+
+	var terms []int
+	for t := fromTerm; t <= term; t++ {
+		terms = append(terms, t)
+	}
+	if len(terms) > maxDemographicTerms {
+		return mcp.NewToolResultError(fmt.Sprintf("At most %d terms can be compared at once.", maxDemographicTerms)), nil
+	}
+
+	coverage := newSourceCoverage("sources")
+	starts, err := s.fetchTermStarts(ctx)
+	if err != nil {
+		coverage.fail("term start dates (ages are left out)", err)
+	} else {
+		coverage.succeeded()
+	}
+
+	// One request per term: the MP list carries every profile field
+	lists := make([][]sejm.MP, len(terms))
+	errs := make([]error, len(terms))
+	slots := make(chan struct{}, maxConcurrentBodyFetches)
+	var wg sync.WaitGroup
+	for i, t := range terms {
+		wg.Add(1)
+		go func(i, t int) {
+			defer wg.Done()
+			if err := acquireSlot(ctx, slots); err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { <-slots }()
+			data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/MP", s.sejmBaseURL, t), nil)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			if err := s.decodeAPIResponse(data, &lists[i]); err != nil {
+				errs[i] = fmt.Errorf("failed to parse MPs: %w", err)
+			}
+		}(i, t)
+	}
+	wg.Wait()
+
+	var results []termDemographics
+	for i, t := range terms {
+		if errs[i] != nil {
+			coverage.fail(fmt.Sprintf("MPs of term %d", t), errs[i])
+			continue
+		}
+		coverage.succeeded()
+		clubs := make(map[string][]sejm.MP)
+		var selected []sejm.MP
+		for _, mp := range lists[i] {
+			if club != "" && !strings.EqualFold(stringValue(mp.Club), club) {
+				continue
+			}
+			if activeOnly && mp.Active != nil && !*mp.Active {
+				continue
+			}
+			selected = append(selected, mp)
+			clubKey := valueOrDefault(stringValue(mp.Club), "no club")
+			clubs[clubKey] = append(clubs[clubKey], mp)
+		}
+		result := termDemographics{Term: t, Overall: buildMPDemographics(fmt.Sprintf("term %d", t), selected, starts[t], withGender)}
+		if start, ok := starts[t]; ok {
+			result.From = start.Format("2006-01-02")
+		}
+		if byClub && club == "" {
+			for name, members := range clubs {
+				result.Clubs = append(result.Clubs, buildMPDemographics(name, members, starts[t], withGender))
+			}
+			sort.Slice(result.Clubs, func(i, j int) bool {
+				if result.Clubs[i].MPs != result.Clubs[j].MPs {
+					return result.Clubs[i].MPs > result.Clubs[j].MPs
+				}
+				return result.Clubs[i].Group < result.Clubs[j].Group
+			})
+		}
+		results = append(results, result)
+	}
+	if len(results) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve MPs from Polish Parliament API: %s. Please try again.", strings.Join(coverage.unavailable()[1:], "; "))), nil
+	}
+
+	if format == "json" {
+		out, _ := json.MarshalIndent(map[string]interface{}{
+			"terms":       results,
+			"unavailable": coverage.unavailable(),
+		}, "", "  ")
+		return coverage.result(string(out)), nil
+	}
+
+	var summary []string
+	if len(terms) == 1 {
+		summary = append(summary, fmt.Sprintf("Term %d: %d MPs", term, results[0].Overall.MPs))
+	} else {
+		summary = append(summary, fmt.Sprintf("Terms %d-%d compared", fromTerm, term))
+	}
+	if club != "" {
+		summary = append(summary, fmt.Sprintf("Club: %s", club))
+	}
+	if activeOnly {
+		summary = append(summary, "Active mandates only")
+	}
+
+	var data []string
+	for i, result := range results {
+		if i > 0 {
+			data = append(data, "")
+		}
+		heading := fmt.Sprintf("Term %d (%d MPs", result.Term, result.Overall.MPs)
+		if result.From != "" {
+			heading += ", from " + result.From
+		}
+		data = append(data, heading+"):")
+		data = append(data, result.Overall.lines()...)
+		if len(result.Clubs) > 0 {
+			data = append(data, "By club:")
+			for _, clubDemographics := range result.Clubs {
+				data = append(data, "  "+clubDemographics.line())
+			}
+		}
+	}
+
+	nextActions := []string{fmt.Sprintf("Every profile field as a dataset: sejm_export_mps with term='%d'", term)}
+	if len(terms) == 1 && term > 1 {
+		nextActions = append(nextActions, fmt.Sprintf("Change across terms: sejm_get_mps_demographics with from_term='%d', term='%d'", max(1, term-3), term))
+	}
+	if club == "" && len(results[0].Clubs) > 0 {
+		nextActions = append(nextActions, fmt.Sprintf("Full profile of one club: sejm_get_mps_demographics with term='%d', club='%s'", term, results[0].Clubs[0].Group))
+	}
+	note := "Counts cover every MP who held a mandate in the term, including those who replaced others, unless active_only='true'. Education and professions are as declared in the MP profiles; professions are counted by their exact wording."
+	if withGender {
+		note += " Gender is inferred from first names ending in -a, which is not always right; set infer_gender='false' to leave it out."
+	}
+
+	response := StandardResponse{
+		Operation:   "MP Demographics",
+		Status:      coverage.status("Retrieved Successfully"),
+		Summary:     summary,
+		Data:        data,
+		NextActions: nextActions,
+		Unavailable: coverage.unavailable(),
+		Note:        note,
+	}
+	return coverage.result(response.Format()), nil
+}
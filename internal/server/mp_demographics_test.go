@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInferGender(t *testing.T) {
+	for name, expected := range map[string]string{"Anna": "women", "Kuba": "men", "Jan": "men", "MAGDALENA": "women", "": "unknown"} {
+		if gender := inferGender(name); gender != expected {
+			t.Errorf("Expected '%s' to be counted as %s, got %s", name, expected, gender)
+		}
+	}
+	birth := time.Date(1980, 10, 20, 0, 0, 0, 0, time.UTC)
+	if age := ageAt(birth, time.Date(2023, 11, 13, 0, 0, 0, 0, time.UTC)); age != 43 {
+		t.Errorf("Expected age 43, got %d", age)
+	}
+	if age := ageAt(birth, time.Date(2023, 10, 19, 0, 0, 0, 0, time.UTC)); age != 42 {
+		t.Errorf("Expected age 42 the day before the birthday, got %d", age)
+	}
+}
+
+func TestHandleGetMPsDemographics(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term": `[{"num": 9, "from": "2019-11-12"}, {"num": 10, "from": "2023-11-13", "current": true}]`,
+		"/sejm/term10/MP": `[
+			{"id": 1, "firstName": "Anna", "club": "KO", "birthDate": "1980-01-01", "educationLevel": "wyższe", "profession": "prawnik", "active": true},
+			{"id": 2, "firstName": "Jan", "club": "KO", "birthDate": "1960-06-01", "educationLevel": "Wyższe", "profession": "Prawnik", "active": true},
+			{"id": 3, "firstName": "Kuba", "club": "PiS", "birthDate": "1990-12-31", "educationLevel": "średnie", "profession": "rolnik", "active": false}
+		]`,
+		"/sejm/term9/MP": `[{"id": 1, "firstName": "Ewa", "club": "PiS", "birthDate": "1970-05-05", "educationLevel": "wyższe"}]`,
+	})
+	call := func(arguments map[string]interface{}) string {
+		result, err := server.handleGetMPsDemographics(context.Background(), createMockRequest(arguments))
+		if err != nil || result.IsError {
+			t.Fatalf("Unexpected error for %v: %v %s", arguments, err, extractTextContent(result))
+		}
+		return extractTextContent(result)
+	}
+
+	output := call(map[string]interface{}{"term": "10"})
+	for _, expected := range []string{
+		"Term 10 (3 MPs, from 2023-11-13):",
+		"Gender (inferred from first names): men 2 (67%), women 1 (33%)",
+		"Age at the start of the term: median 43, mean 46.0, youngest 32, oldest 63",
+		"Born in the: 1960s 1, 1980s 1, 1990s 1",
+		"Education: wyższe 2 (67%), średnie 1 (33%)",
+		"Most common professions: prawnik 2, rolnik 1",
+		"• KO: 2 MPs; women 50%; median age 53; wyższe education 100%; professions: prawnik",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output, got: %s", expected, output)
+		}
+	}
+
+	output = call(map[string]interface{}{"term": "10", "from_term": "9", "infer_gender": "false", "active_only": "true", "format": "json"})
+	var compared struct {
+		Terms []termDemographics `json:"terms"`
+	}
+	if err := json.Unmarshal([]byte(output), &compared); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(compared.Terms) != 2 || compared.Terms[0].Term != 9 || compared.Terms[1].Overall.MPs != 2 || compared.Terms[1].Overall.Gender != nil {
+		t.Errorf("Unexpected term comparison: %+v", compared.Terms)
+	}
+}
@@ -0,0 +1,268 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Delivery modes of sejm_export_mps
+const (
+	mpExportDeliveryText     = "text"
+	mpExportDeliveryBlob     = "blob"
+	mpExportDeliveryResource = "resource"
+)
+
+// mpExportFields lists the MP fields of the API in export column order; fields the API adds later are appended
+// after them in alphabetical order
+var mpExportFields = []string{
+	"id", "firstName", "secondName", "lastName", "firstLastName", "lastFirstName", "accusativeName", "genitiveName",
+	"active", "inactiveCause", "waiverDesc", "club", "districtNum", "districtName", "voivodeship",
+	"birthDate", "birthLocation", "educationLevel", "profession", "email", "numberOfVotes",
+}
+
+// mpExport is the MP dataset of a term restricted to the selected fields
+type mpExport struct {
+	fields  []string
+	records []map[string]any
+}
+
+// exportFieldOrder returns the known fields followed by any other fields present in the records
+func exportFieldOrder(records []map[string]any) []string {
+	known := make(map[string]bool, len(mpExportFields))
+	for _, field := range mpExportFields {
+		known[field] = true
+	}
+	extra := make(map[string]bool)
+	for _, record := range records {
+		for field := range record {
+			if !known[field] {
+				extra[field] = true
+			}
+		}
+	}
+	fields := append([]string(nil), mpExportFields...)
+	var added []string
+	for field := range extra {
+		added = append(added, field)
+	}
+	sort.Strings(added)
+	return append(fields, added...)
+}
+
+// selectExportFields validates a comma-separated field selection against the available fields; an empty
+// selection keeps all fields
+func selectExportFields(selection string, available []string) ([]string, error) {
+	if strings.TrimSpace(selection) == "" {
+		return available, nil
+	}
+	byName := make(map[string]string, len(available))
+	for _, field := range available {
+		byName[strings.ToLower(field)] = field
+	}
+	var fields []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(selection, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		field, ok := byName[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown field '%s'. Available fields: %s", name, strings.Join(available, ", "))
+		}
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields selected. Available fields: %s", strings.Join(available, ", "))
+	}
+	return fields, nil
+}
+
+// exportCSVValue formats a JSON value for a CSV cell; missing values are empty
+func exportCSVValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		encoded, _ := json.Marshal(v)
+		return string(encoded)
+	}
+}
+
+// csv renders the export with a header row of field names
+func (e mpExport) csv() (string, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.Write(e.fields); err != nil {
+		return "", err
+	}
+	for _, record := range e.records {
+		row := make([]string, len(e.fields))
+		for i, field := range e.fields {
+			row[i] = exportCSVValue(record[field])
+		}
+		if err := writer.Write(row); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	return buffer.String(), writer.Error()
+}
+
+// json renders the export as an array of objects holding the selected fields; missing values are null
+func (e mpExport) json() (string, error) {
+	selected := make([]map[string]any, len(e.records))
+	for i, record := range e.records {
+		selected[i] = make(map[string]any, len(e.fields))
+		for _, field := range e.fields {
+			selected[i][field] = record[field]
+		}
+	}
+	encoded, err := json.MarshalIndent(selected, "", "  ")
+	return string(encoded), err
+}
+
+// render renders the export in the given format
+func (e mpExport) render(format string) (string, error) {
+	if format == "csv" {
+		return e.csv()
+	}
+	return e.json()
+}
+
+// buildMPExport downloads all MPs of a term with every field the API returns and keeps the selected fields
+func (s *SejmServer) buildMPExport(ctx context.Context, term int, fieldSelection, club string, activeOnly bool) (mpExport, error) {
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/MP", s.sejmBaseURL, term), nil)
+	if err != nil {
+		return mpExport{}, fmt.Errorf("failed to retrieve MPs for term %d: %w", term, err)
+	}
+	// Records are decoded generically so fields missing from sejm.MP are exported too
+	var all []map[string]any
+	if err := s.decodeAPIResponse(data, &all); err != nil {
+		return mpExport{}, fmt.Errorf("failed to parse MPs data: %w", err)
+	}
+
+	var records []map[string]any
+	for _, record := range all {
+		if club != "" {
+			recordClub, _ := record["club"].(string)
+			if !strings.EqualFold(recordClub, club) {
+				continue
+			}
+		}
+		if active, ok := record["active"].(bool); activeOnly && ok && !active {
+			continue
+		}
+		records = append(records, record)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		idI, _ := records[i]["id"].(float64)
+		idJ, _ := records[j]["id"].(float64)
+		return idI < idJ
+	})
+
+	fields, err := selectExportFields(fieldSelection, exportFieldOrder(all))
+	if err != nil {
+		return mpExport{}, err
+	}
+	return mpExport{fields: fields, records: records}, nil
+}
+
+func (s *SejmServer) handleExportMPs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_export_mps called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	format := strings.ToLower(request.GetString("format", "json"))
+	if format != "json" && format != "csv" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'json' or 'csv'.", format)), nil
+	}
+	delivery := strings.ToLower(request.GetString("return_content", mpExportDeliveryText))
+	if delivery != mpExportDeliveryText && delivery != mpExportDeliveryBlob && delivery != mpExportDeliveryResource {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid return_content '%s'. Use 'text', 'blob' or 'resource'.", delivery)), nil
+	}
+	fieldSelection := request.GetString("fields", "")
+	club := strings.TrimSpace(request.GetString("club", ""))
+	activeOnly := request.GetString("active_only", "false") == "true"
+
+	export, err := s.buildMPExport(ctx, term, fieldSelection, club, activeOnly)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to export MPs: %v.", err)), nil
+	}
+	if len(export.records) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("No MPs match the export filters in term %d. Check the club ID with sejm_get_clubs.", term)), nil
+	}
+	output, err := export.render(format)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to build %s export: %v.", format, err)), nil
+	}
+	if delivery == mpExportDeliveryText {
+		return mcp.NewToolResultText(output), nil
+	}
+
+	mimeType := "application/json"
+	if format == "csv" {
+		mimeType = "text/csv"
+	}
+	query := url.Values{}
+	for name, value := range map[string]string{"fields": fieldSelection, "club": club} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+	if activeOnly {
+		query.Set("active_only", "true")
+	}
+	uri := fmt.Sprintf("%sterm%d/mps.%s", attachmentResourceScheme, term, format)
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+	name := fmt.Sprintf("mps-term%d.%s", term, format)
+	description := fmt.Sprintf("%d MPs of term %d, %d fields, %s", len(export.records), term, len(export.fields), format)
+	summary := fmt.Sprintf("Exported %s (%d bytes): %s", description, len(output), strings.Join(export.fields, ", "))
+
+	if delivery == mpExportDeliveryBlob {
+		blob := mcp.BlobResourceContents{URI: uri, MIMEType: mimeType, Blob: base64.StdEncoding.EncodeToString([]byte(output))}
+		return &mcp.CallToolResult{Content: []mcp.Content{mcp.NewTextContent(summary), mcp.NewEmbeddedResource(blob)}}, nil
+	}
+
+	// The resource rebuilds the export on read, from the HTTP cache, instead of keeping it in memory
+	s.server.AddResource(
+		mcp.NewResource(uri, name, mcp.WithResourceDescription(description), mcp.WithMIMEType(mimeType)),
+		func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			s.logger.Info("MP export resource read", slog.String("uri", request.Params.URI))
+			export, err := s.buildMPExport(ctx, term, fieldSelection, club, activeOnly)
+			if err != nil {
+				return nil, err
+			}
+			output, err := export.render(format)
+			if err != nil {
+				return nil, err
+			}
+			return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, MIMEType: mimeType, Text: output}}, nil
+		})
+	link := mcp.NewResourceLink(uri, name, description, mimeType)
+	return &mcp.CallToolResult{Content: []mcp.Content{mcp.NewTextContent(summary + fmt.Sprintf("\nRead it with resources/read: %s", uri)), link}}, nil
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const mpExportFixture = `[
+	{"id": 2, "firstName": "Anna", "lastName": "Nowak", "lastFirstName": "Nowak Anna", "club": "PiS", "active": false,
+	 "inactiveCause": "Wygaśnięcie mandatu", "districtNum": 5, "numberOfVotes": 12345, "birthDate": "1970-01-02"},
+	{"id": 1, "firstName": "Jan", "lastName": "Kowalski", "lastFirstName": "Kowalski Jan", "club": "KO", "active": true,
+	 "districtNum": 19, "numberOfVotes": 54321, "profession": "prawnik, \"radca\"", "committeeCount": 3}
+]`
+
+func TestHandleExportMPs(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{"/sejm/term10/MP": mpExportFixture})
+
+	result, err := server.handleExportMPs(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "format": "csv", "fields": "id, lastFirstName,club,numberOfVotes,profession",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	expected := "id,lastFirstName,club,numberOfVotes,profession\n" +
+		"1,Kowalski Jan,KO,54321,\"prawnik, \"\"radca\"\"\"\n" +
+		"2,Nowak Anna,PiS,12345,\n"
+	if content := extractTextContent(result); content != expected {
+		t.Errorf("Unexpected CSV:\n%s\nwant:\n%s", content, expected)
+	}
+
+	result, _ = server.handleExportMPs(context.Background(), createMockRequest(map[string]interface{}{"term": "10", "active_only": "true"}))
+	var records []map[string]any
+	if err := json.Unmarshal([]byte(extractTextContent(result)), &records); err != nil {
+		t.Fatalf("Expected a JSON array, got %v: %s", err, extractTextContent(result))
+	}
+	if len(records) != 1 || records[0]["lastName"] != "Kowalski" {
+		t.Fatalf("Expected only the active MP, got %+v", records)
+	}
+	// Every known field is present, and fields unknown to the server are exported as well
+	if _, ok := records[0]["waiverDesc"]; !ok {
+		t.Errorf("Expected all known fields in the export, got %+v", records[0])
+	}
+	if records[0]["committeeCount"] != float64(3) {
+		t.Errorf("Expected extra API fields in the export, got %+v", records[0])
+	}
+
+	result, _ = server.handleExportMPs(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "format": "csv", "club": "pis", "return_content": "blob",
+	}))
+	if result.IsError || len(result.Content) != 2 {
+		t.Fatalf("Expected a summary and an embedded file, got %+v", result.Content)
+	}
+	resource, ok := result.Content[1].(mcp.EmbeddedResource)
+	if !ok {
+		t.Fatalf("Expected an embedded resource, got %T", result.Content[1])
+	}
+	if blob, ok := resource.Resource.(mcp.BlobResourceContents); !ok || blob.MIMEType != "text/csv" || blob.URI != "sejm://term10/mps.csv?club=pis" {
+		t.Errorf("Unexpected embedded file: %+v", resource.Resource)
+	}
+
+	result, _ = server.handleExportMPs(context.Background(), createMockRequest(map[string]interface{}{"term": "10", "fields": "id,shoeSize"}))
+	if !result.IsError || !strings.Contains(extractTextContent(result), "unknown field 'shoeSize'") {
+		t.Errorf("Expected an unknown field error, got: %s", extractTextContent(result))
+	}
+}
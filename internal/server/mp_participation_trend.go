@@ -0,0 +1,277 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// defaultLowParticipation is the monthly participation, in percent, below which a month counts as low
+	defaultLowParticipation = 80
+	// participationBarWidth is the number of characters of the bar drawn for a month in the text view
+	participationBarWidth = 20
+)
+
+// participationMonth sums up an MP's voting days of one calendar month
+type participationMonth struct {
+	Month         string  `json:"month"`
+	SittingDays   int     `json:"sittingDays"`
+	Votings       int     `json:"votings"`
+	Voted         int     `json:"voted"`
+	Missed        int     `json:"missed"`
+	ExcusedDays   int     `json:"excusedDays"`
+	Participation float64 `json:"participation"`
+	Low           bool    `json:"low"`
+}
+
+// participationPeriod is a run of consecutive low-participation months
+type participationPeriod struct {
+	From          string  `json:"from"`
+	To            string  `json:"to"`
+	Months        int     `json:"months"`
+	Votings       int     `json:"votings"`
+	Missed        int     `json:"missed"`
+	Participation float64 `json:"participation"`
+}
+
+// participationCSVHeader lists the columns of the csv series, in participationMonth field order
+var participationCSVHeader = []string{"month", "sitting_days", "votings", "voted", "missed", "excused_days", "participation", "low"}
+
+// participationRate returns the share of votings an MP took part in, in percent
+func participationRate(voted, votings int) float64 {
+	if votings == 0 {
+		return 0
+	}
+	return float64(voted) / float64(votings) * 100
+}
+
+// participationByMonth groups voting days into calendar months, oldest first, and marks months below the threshold.
+// Days without votings and months without sittings are left out, so recesses do not show up as disengagement.
+func participationByMonth(stats []sejm.VotingStat, threshold float64) []participationMonth {
+	months := make(map[string]*participationMonth)
+	for _, stat := range stats {
+		if stat.Date == nil || stat.NumVotings == nil || *stat.NumVotings == 0 {
+			continue
+		}
+		key := stat.Date.Format("2006-01")
+		month, ok := months[key]
+		if !ok {
+			month = &participationMonth{Month: key}
+			months[key] = month
+		}
+		votings := int(*stat.NumVotings)
+		voted := 0
+		if stat.NumVoted != nil {
+			voted = int(*stat.NumVoted)
+		}
+		missed := votings - voted
+		if stat.NumMissed != nil {
+			missed = int(*stat.NumMissed)
+		}
+		month.SittingDays++
+		month.Votings += votings
+		month.Voted += voted
+		month.Missed += missed
+		if stat.AbsenceExcuse != nil && *stat.AbsenceExcuse {
+			month.ExcusedDays++
+		}
+	}
+
+	series := make([]participationMonth, 0, len(months))
+	for _, month := range months {
+		month.Participation = participationRate(month.Voted, month.Votings)
+		month.Low = month.Participation < threshold
+		series = append(series, *month)
+	}
+	sort.Slice(series, func(i, j int) bool { return series[i].Month < series[j].Month })
+	return series
+}
+
+// lowParticipationPeriods joins consecutive low months of a series into periods. Months without votings are not in
+// the series, so a recess between two low months does not end a period.
+func lowParticipationPeriods(series []participationMonth) []participationPeriod {
+	var periods []participationPeriod
+	var current *participationPeriod
+	voted := 0
+	for _, month := range series {
+		if !month.Low {
+			current = nil
+			continue
+		}
+		if current == nil {
+			periods = append(periods, participationPeriod{From: month.Month})
+			current = &periods[len(periods)-1]
+			voted = 0
+		}
+		current.To = month.Month
+		current.Months++
+		current.Votings += month.Votings
+		current.Missed += month.Missed
+		voted += month.Voted
+		current.Participation = participationRate(voted, current.Votings)
+	}
+	return periods
+}
+
+// participationBar draws a month's participation as a bar of participationBarWidth characters
+func participationBar(participation float64) string {
+	filled := int(participation/100*participationBarWidth + 0.5)
+	return strings.Repeat("█", filled) + strings.Repeat("░", participationBarWidth-filled)
+}
+
+// participationCSV renders the monthly series with a header row
+func participationCSV(series []participationMonth) (string, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	if err := writer.Write(participationCSVHeader); err != nil {
+		return "", err
+	}
+	for _, month := range series {
+		record := []string{
+			month.Month, strconv.Itoa(month.SittingDays), strconv.Itoa(month.Votings), strconv.Itoa(month.Voted),
+			strconv.Itoa(month.Missed), strconv.Itoa(month.ExcusedDays), strconv.FormatFloat(month.Participation, 'f', 1, 64),
+			strconv.FormatBool(month.Low),
+		}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	return buffer.String(), writer.Error()
+}
+
+func (s *SejmServer) handleGetMPParticipationTrend(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_mp_participation_trend called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	mpID := request.GetString("mp_id", "")
+	if mpID == "" {
+		return mcp.NewToolResultError("MP ID is required. Please provide the mp_id parameter with a valid MP identification number. You can get MP IDs from the sejm_get_mps tool."), nil
+	}
+	threshold, err := parseBoundedInt(request, "low_threshold", defaultLowParticipation, 1, 100)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid low_threshold: %v.", err)), nil
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" && format != "csv" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text', 'csv', or 'json'.", format)), nil
+	}
+
+	endpoint := fmt.Sprintf("%s/sejm/term%d/MP/%s/votings/stats", s.sejmBaseURL, term, mpID)
+	body, err := s.makeAPIRequest(ctx, endpoint, nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve MP voting statistics: %v. Please verify the MP ID (%s) exists in term %d.", err, mpID, term)), nil
+	}
+	var stats []sejm.VotingStat
+	if err := s.decodeAPIResponse(body, &stats); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse voting statistics data: %v.", err)), nil
+	}
+
+	series := participationByMonth(stats, float64(threshold))
+	periods := lowParticipationPeriods(series)
+	votings, voted, lowMonths := 0, 0, 0
+	for _, month := range series {
+		votings += month.Votings
+		voted += month.Voted
+		if month.Low {
+			lowMonths++
+		}
+	}
+	overall := participationRate(voted, votings)
+
+	switch format {
+	case "csv":
+		out, err := participationCSV(series)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write CSV: %v", err)), nil
+		}
+		return mcp.NewToolResultText(out), nil
+	case "json":
+		out, _ := json.MarshalIndent(map[string]interface{}{
+			"term":          term,
+			"mpId":          mpID,
+			"lowThreshold":  threshold,
+			"votings":       votings,
+			"voted":         voted,
+			"participation": overall,
+			"months":        series,
+			"lowPeriods":    periods,
+		}, "", "  ")
+		return mcp.NewToolResultText(string(out)), nil
+	}
+
+	if len(series) == 0 {
+		response := StandardResponse{
+			Operation: "MP Participation Trend",
+			Status:    "No Results Found",
+			Summary:   []string{fmt.Sprintf("MP %s has no voting days in term %d", mpID, term)},
+			NextActions: []string{
+				fmt.Sprintf("Check the MP's mandate: sejm_get_mp_details with term='%d', mp_id='%s'", term, mpID),
+			},
+		}
+		return mcp.NewToolResultText(response.Format()), nil
+	}
+
+	summary := []string{
+		fmt.Sprintf("MP %s, term %d: %.1f%% participation in %d votings over %d months (%s to %s)",
+			mpID, term, overall, votings, len(series), series[0].Month, series[len(series)-1].Month),
+		fmt.Sprintf("Months below %d%%: %d", threshold, lowMonths),
+	}
+	if len(periods) > 0 {
+		longest := periods[0]
+		for _, period := range periods[1:] {
+			if period.Months > longest.Months {
+				longest = period
+			}
+		}
+		summary = append(summary, fmt.Sprintf("Longest low-participation period: %s to %s (%d months, %.1f%%)",
+			longest.From, longest.To, longest.Months, longest.Participation))
+	}
+
+	var data []string
+	for _, month := range series {
+		line := fmt.Sprintf("%s %s %5.1f%% (%d/%d votings, sitting days: %d", month.Month, participationBar(month.Participation),
+			month.Participation, month.Voted, month.Votings, month.SittingDays)
+		if month.ExcusedDays > 0 {
+			line += fmt.Sprintf(", excused: %d", month.ExcusedDays)
+		}
+		line += ")"
+		if month.Low {
+			line += " ← low"
+		}
+		data = append(data, line)
+	}
+	if len(periods) > 0 {
+		data = append(data, "", "Low-participation periods:")
+		for _, period := range periods {
+			data = append(data, fmt.Sprintf("• %s to %s: %d months, %d of %d votings missed (%.1f%% participation)",
+				period.From, period.To, period.Months, period.Missed, period.Votings, period.Participation))
+		}
+	}
+
+	response := StandardResponse{
+		Operation: "MP Participation Trend",
+		Status:    "Retrieved Successfully",
+		Summary:   summary,
+		Data:      data,
+		NextActions: []string{
+			fmt.Sprintf("Per-day numbers and excuses: sejm_get_mp_voting_stats with term='%d', mp_id='%s'", term, mpID),
+			fmt.Sprintf("The series for charting: sejm_get_mp_participation_trend with term='%d', mp_id='%s', format='csv'", term, mpID),
+		},
+		Note: "Participation is the share of votings the MP took part in, per calendar month of sitting days with votings; months without votings, such as recesses, are left out and do not break a low-participation period. Excused days are sitting days for which the MP's absence was excused.",
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
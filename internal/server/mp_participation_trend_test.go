@@ -0,0 +1,59 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const participationStatsFixture = `[
+	{"date": "2024-01-10", "sitting": 3, "numVotings": 50, "numVoted": 50, "numMissed": 0},
+	{"date": "2024-01-11", "sitting": 3, "numVotings": 50, "numVoted": 48, "numMissed": 2},
+	{"date": "2024-02-07", "sitting": 4, "numVotings": 40, "numVoted": 10, "numMissed": 30, "absenceExcuse": true},
+	{"date": "2024-02-08", "sitting": 4, "numVotings": 0, "numVoted": 0, "numMissed": 0},
+	{"date": "2024-04-05", "sitting": 6, "numVotings": 60, "numVoted": 30, "numMissed": 30},
+	{"date": "2024-05-15", "sitting": 8, "numVotings": 20, "numVoted": 20, "numMissed": 0}
+]`
+
+func TestHandleGetMPParticipationTrend(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{"/sejm/term10/MP/12/votings/stats": participationStatsFixture})
+	call := func(arguments map[string]interface{}) string {
+		result, err := server.handleGetMPParticipationTrend(context.Background(), createMockRequest(arguments))
+		if err != nil || result.IsError {
+			t.Fatalf("Unexpected error for %v: %v %s", arguments, err, extractTextContent(result))
+		}
+		return extractTextContent(result)
+	}
+
+	output := call(map[string]interface{}{"term": "10", "mp_id": "12"})
+	for _, expected := range []string{
+		"MP 12, term 10: 71.8% participation in 220 votings over 4 months (2024-01 to 2024-05)",
+		"Months below 80%: 2",
+		"Longest low-participation period: 2024-02 to 2024-04 (2 months, 40.0%)",
+		"2024-01 ████████████████████  98.0% (98/100 votings, sitting days: 2)",
+		"2024-02 █████░░░░░░░░░░░░░░░  25.0% (10/40 votings, sitting days: 1, excused: 1) ← low",
+		"• 2024-02 to 2024-04: 2 months, 60 of 100 votings missed (40.0% participation)",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output, got: %s", expected, output)
+		}
+	}
+
+	output = call(map[string]interface{}{"term": "10", "mp_id": "12", "low_threshold": "30", "format": "json"})
+	var trend struct {
+		Months     []participationMonth  `json:"months"`
+		LowPeriods []participationPeriod `json:"lowPeriods"`
+	}
+	if err := json.Unmarshal([]byte(output), &trend); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(trend.Months) != 4 || len(trend.LowPeriods) != 1 || trend.LowPeriods[0].From != "2024-02" || trend.LowPeriods[0].To != "2024-02" {
+		t.Errorf("Unexpected series with a 30%% threshold: %+v", trend)
+	}
+
+	output = call(map[string]interface{}{"term": "10", "mp_id": "12", "format": "csv"})
+	if !strings.HasPrefix(output, "month,sitting_days,votings,voted,missed,excused_days,participation,low\n2024-01,2,100,98,2,0,98.0,false\n") {
+		t.Errorf("Unexpected CSV series: %s", output)
+	}
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// minOutputChars is the smallest accepted output budget; anything lower could not fit the summary and navigation hints
+const minOutputChars = 500
+
+// maxOutputCharsParamDescription documents the max_output_chars parameter shared by all tools
+const maxOutputCharsParamDescription = "Optional. Maximum length of the response in characters (minimum 500, '0' for no limit). Longer responses are cut line by line in the results section, keeping the summary, next actions and note, and a notice reports how much was omitted. Defaults to the server-wide setting (-max-output-chars flag, unlimited unless configured)."
+
+// responseResultsLabels and responseTailLabels are the StandardResponse.Format section headings
+// around the results, in English and Polish, since the budget is applied to localized output
+var (
+	responseResultsLabels = []string{"\n\nResults:", "\n\nWyniki:"}
+	responseTailLabels    = []string{"\n\nNext Actions:", "\n\nNote:", "\n\nNastępne kroki:", "\n\nUwaga:"}
+)
+
+// resolveMaxOutputChars returns the output budget for a tool call; 0 means unlimited
+func (s *SejmServer) resolveMaxOutputChars(requested string) (int, error) {
+	limit := s.config.MaxOutputChars
+	if requested = strings.TrimSpace(requested); requested != "" {
+		var err error
+		if limit, err = strconv.Atoi(requested); err != nil || limit < 0 {
+			return 0, fmt.Errorf("'%s' is not a non-negative number", requested)
+		}
+	}
+	if limit > 0 && limit < minOutputChars {
+		limit = minOutputChars
+	}
+	return limit, nil
+}
+
+// limitResult applies the output budget to every text content of a tool result
+func limitResult(result *mcp.CallToolResult, limit int) {
+	if result == nil || limit <= 0 {
+		return
+	}
+	for i, content := range result.Content {
+		if textContent, ok := mcp.AsTextContent(content); ok {
+			result.Content[i] = mcp.NewTextContent(limitOutput(textContent.Text, limit))
+		}
+	}
+}
+
+// limitOutput shortens text to at most limit characters. For StandardResponse output only the
+// results section is shortened, dropping whole lines from its end, so the header, summary, next
+// actions and note survive. Other text is cut at the last line break that fits.
+func limitOutput(text string, limit int) string {
+	if limit <= 0 || utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+
+	head, results, tail := splitResponseSections(text)
+	if results != "" {
+		lines := strings.Split(results, "\n")
+		total := len(lines) - 1
+		omittedChars := utf8.RuneCountInString(results) - utf8.RuneCountInString(lines[0])
+		// The section heading ("Results:") is always kept, followed by as many lines as fit
+		used := utf8.RuneCountInString(head) + utf8.RuneCountInString(lines[0]) + utf8.RuneCountInString(tail) + 1
+		kept := 0
+		for kept < total {
+			next := utf8.RuneCountInString(lines[kept+1]) + 1
+			notice := truncationNotice(total-kept-1, total, omittedChars-next)
+			if used+next+utf8.RuneCountInString(notice) > limit {
+				break
+			}
+			used += next
+			omittedChars -= next
+			kept++
+		}
+		notice := truncationNotice(total-kept, total, omittedChars)
+		if used+utf8.RuneCountInString(notice) <= limit {
+			return head + strings.Join(lines[:kept+1], "\n") + "\n" + notice + tail
+		}
+	}
+
+	// Not a standard response, or the kept sections alone exceed the budget
+	runes := []rune(text)
+	cut := limit - utf8.RuneCountInString(cutNotice(limit, len(runes)))
+	if cut < 0 {
+		cut = 0
+	}
+	kept := string(runes[:cut])
+	if lineEnd := strings.LastIndex(kept, "\n"); lineEnd > len(kept)/2 {
+		kept = kept[:lineEnd]
+	}
+	return kept + cutNotice(limit, len(runes)-utf8.RuneCountInString(kept))
+}
+
+// splitResponseSections splits StandardResponse output into the part before the results section,
+// the results section itself and the sections after it. Results is empty for other text.
+func splitResponseSections(text string) (head, results, tail string) {
+	start := -1
+	for _, label := range responseResultsLabels {
+		if index := strings.Index(text, label); index >= 0 && (start < 0 || index < start) {
+			start = index
+		}
+	}
+	if start < 0 {
+		return text, "", ""
+	}
+	start += 2
+	end := len(text)
+	for _, label := range responseTailLabels {
+		if index := strings.LastIndex(text, label); index > start && index < end {
+			end = index
+		}
+	}
+	return text[:start], text[start:end], text[end:]
+}
+
+// truncationNotice reports the result lines dropped to fit the output budget
+func truncationNotice(omittedLines, totalLines, omittedChars int) string {
+	return fmt.Sprintf("... [Output truncated: %d of %d result lines omitted (%d characters). Raise max_output_chars, request a smaller page or narrow the filters to see them.]",
+		omittedLines, totalLines, omittedChars)
+}
+
+// cutNotice reports text cut off at the output budget outside the results section
+func cutNotice(limit, omittedChars int) string {
+	return fmt.Sprintf("\n\n[Output truncated to %d characters; %d characters omitted. Raise max_output_chars or narrow the request.]", limit, omittedChars)
+}
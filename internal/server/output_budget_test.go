@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLimitOutputKeepsSummaryAndNavigation(t *testing.T) {
+	var data []string
+	for i := 1; i <= 100; i++ {
+		data = append(data, fmt.Sprintf("• Pozycja %d: ustawa o zmianie niektórych ustaw", i))
+	}
+	text := StandardResponse{
+		Operation:   "Legal Acts Search",
+		Status:      "Search Completed Successfully",
+		Summary:     []string{"Found 100 acts"},
+		Data:        data,
+		NextActions: []string{"Get details: eli_get_act_details"},
+		Note:        "Data from the ELI API.",
+	}.Format()
+
+	limited := limitOutput(text, 1000)
+	if length := utf8.RuneCountInString(limited); length > 1000 {
+		t.Errorf("Expected at most 1000 characters, got %d", length)
+	}
+	for _, expected := range []string{"• Found 100 acts", "• Pozycja 1: ", "Next Actions:\n• Get details", "Note: Data from the ELI API.", "result lines omitted"} {
+		if !strings.Contains(limited, expected) {
+			t.Errorf("Expected %q in limited output:\n%s", expected, limited)
+		}
+	}
+	if strings.Contains(limited, "Pozycja 100:") {
+		t.Errorf("Expected the last results to be omitted:\n%s", limited)
+	}
+
+	if unchanged := limitOutput(text, 0); unchanged != text {
+		t.Error("Expected no limit for 0")
+	}
+	if unchanged := limitOutput(text, len(text)); unchanged != text {
+		t.Error("Expected text within the limit to be unchanged")
+	}
+}
+
+func TestLimitOutputPlainText(t *testing.T) {
+	text := strings.Repeat("wiersz tekstu jednolitego\n", 100)
+	limited := limitOutput(text, 600)
+	if length := utf8.RuneCountInString(limited); length > 600 {
+		t.Errorf("Expected at most 600 characters, got %d", length)
+	}
+	if !strings.Contains(limited, "[Output truncated to 600 characters;") || !strings.HasPrefix(limited, "wiersz tekstu jednolitego\n") {
+		t.Errorf("Unexpected plain text truncation:\n%s", limited)
+	}
+}
+
+func TestResolveMaxOutputChars(t *testing.T) {
+	server := NewSejmServerWithConfig(Config{MaxOutputChars: 8000})
+	tests := []struct {
+		requested string
+		expected  int
+		wantErr   bool
+	}{
+		{"", 8000, false},
+		{"0", 0, false},
+		{"100", minOutputChars, false},
+		{"2500", 2500, false},
+		{"-1", 0, true},
+		{"many", 0, true},
+	}
+	for _, tt := range tests {
+		limit, err := server.resolveMaxOutputChars(tt.requested)
+		if (err != nil) != tt.wantErr || limit != tt.expected {
+			t.Errorf("resolveMaxOutputChars(%q) = %d, %v; expected %d", tt.requested, limit, err, tt.expected)
+		}
+	}
+}
+
+func TestToolCallHonorsMaxOutputChars(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/interpellations/1/body": "<p>" + strings.Repeat("Pytanie o stan szkół. ", 500) + "</p>",
+	})
+
+	message := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"sejm_get_interpellation_body","arguments":{"term":"10","num":"1","max_output_chars":800}}}`
+	response := server.server.HandleMessage(context.Background(), json.RawMessage(message))
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		t.Fatalf("Failed to encode response: %v", err)
+	}
+	var decoded struct {
+		Result struct {
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(encoded, &decoded); err != nil || len(decoded.Result.Content) == 0 {
+		t.Fatalf("Unexpected response: %s", encoded)
+	}
+	text := decoded.Result.Content[0].Text
+	if utf8.RuneCountInString(text) > 800 || !strings.Contains(text, "Output truncated") {
+		t.Errorf("Expected a truncated response of at most 800 characters, got %d:\n%s", utf8.RuneCountInString(text), text)
+	}
+}
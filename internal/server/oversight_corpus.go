@@ -0,0 +1,347 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Limits of sejm_export_oversight_corpus; chunks with bodies are smaller since every record costs a download
+const (
+	defaultOversightChunk       = 100
+	maxOversightChunk           = 500
+	maxOversightChunkWithBodies = 100
+)
+
+// Delivery modes of sejm_export_oversight_corpus
+const (
+	oversightDeliveryText     = "text"
+	oversightDeliveryResource = "resource"
+	oversightDeliveryFile     = "file"
+)
+
+// oversightKinds maps the kinds of sejm_export_oversight_corpus to their API collections
+var oversightKinds = map[string]string{
+	"interpellations":   "interpellations",
+	"written_questions": "writtenQuestions",
+}
+
+// oversightExport selects one chunk of the oversight corpus of a term
+type oversightExport struct {
+	term           int
+	kind           string
+	offset         int
+	limit          int
+	includeBodies  bool
+	includeReplies bool
+}
+
+// uri identifies the chunk as an MCP resource
+func (e oversightExport) uri() string {
+	query := url.Values{}
+	query.Set("offset", strconv.Itoa(e.offset))
+	query.Set("limit", strconv.Itoa(e.limit))
+	if e.includeBodies {
+		query.Set("bodies", "true")
+	}
+	if e.includeReplies {
+		query.Set("replies", "true")
+	}
+	return fmt.Sprintf("%sterm%d/oversight/%s.jsonl?%s", attachmentResourceScheme, e.term, e.kind, query.Encode())
+}
+
+// fileName is the corpus file a whole export is appended to, chunk by chunk
+func (e oversightExport) fileName() string {
+	name := fmt.Sprintf("oversight_term%d_%s", e.term, e.kind)
+	if e.includeBodies {
+		name += "_bodies"
+	}
+	if e.includeReplies {
+		name += "_replies"
+	}
+	return name + ".jsonl"
+}
+
+// oversightChunk is one chunk of the corpus as JSON Lines
+type oversightChunk struct {
+	records      int
+	bodies       int
+	bodyFailures int
+	jsonl        []byte
+}
+
+// textFetch is one body download of a chunk; the text is stored in the body field of target
+type textFetch struct {
+	endpoint string
+	target   map[string]any
+}
+
+// buildOversightChunk downloads one page of interpellations or written questions, ordered by number so
+// offsets stay stable while new items arrive, and renders it as JSON Lines. Records are decoded generically,
+// so every field of the API is kept; bodies and replies are added as plain text.
+func (s *SejmServer) buildOversightChunk(ctx context.Context, export oversightExport, progress *progressReporter) (oversightChunk, error) {
+	collection := oversightKinds[export.kind]
+	params := map[string]string{"offset": strconv.Itoa(export.offset), "limit": strconv.Itoa(export.limit), "sort_by": "num"}
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/%s", s.sejmBaseURL, export.term, collection), params)
+	if err != nil {
+		return oversightChunk{}, fmt.Errorf("failed to retrieve %s of term %d: %w", export.kind, export.term, err)
+	}
+	var records []map[string]any
+	if err := s.decodeAPIResponse(data, &records); err != nil {
+		return oversightChunk{}, fmt.Errorf("failed to parse %s: %w", export.kind, err)
+	}
+
+	var fetches []textFetch
+	for _, record := range records {
+		record["term"] = export.term
+		record["kind"] = export.kind
+		num, ok := record["num"].(float64)
+		if !ok || !export.includeBodies {
+			continue
+		}
+		base := fmt.Sprintf("%s/sejm/term%d/%s/%d", s.sejmBaseURL, export.term, collection, int(num))
+		fetches = append(fetches, textFetch{endpoint: base + "/body", target: record})
+		replies, _ := record["replies"].([]any)
+		for _, item := range replies {
+			reply, ok := item.(map[string]any)
+			if !ok || !export.includeReplies {
+				continue
+			}
+			key, _ := reply["key"].(string)
+			if onlyAttachment, _ := reply["onlyAttachment"].(bool); key == "" || onlyAttachment {
+				continue
+			}
+			fetches = append(fetches, textFetch{endpoint: fmt.Sprintf("%s/reply/%s/body", base, key), target: reply})
+		}
+	}
+
+	chunk := oversightChunk{records: len(records)}
+	slots := make(chan struct{}, maxConcurrentBodyFetches)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for _, fetch := range fetches {
+		wg.Add(1)
+		go func(fetch textFetch) {
+			defer wg.Done()
+			var body []byte
+			err := acquireSlot(ctx, slots)
+			if err == nil {
+				body, err = s.makeTextRequest(ctx, fetch.endpoint, "html")
+				<-slots
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				fetch.target["bodyError"] = err.Error()
+				chunk.bodyFailures++
+			} else {
+				fetch.target["body"] = htmlToTextLines(string(body))
+				chunk.bodies++
+			}
+			done := chunk.bodies + chunk.bodyFailures
+			progress.report(done, len(fetches), fmt.Sprintf("Downloaded %d of %d bodies", done, len(fetches)))
+		}(fetch)
+	}
+	wg.Wait()
+
+	var buffer bytes.Buffer
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return oversightChunk{}, fmt.Errorf("failed to encode record: %w", err)
+		}
+		buffer.Write(line)
+		buffer.WriteByte('\n')
+	}
+	chunk.jsonl = buffer.Bytes()
+	return chunk, nil
+}
+
+// countLines returns the number of lines in a file, or 0 when it does not exist
+func countLines(path string) (int, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	lines := 0
+	for scanner.Scan() {
+		lines++
+	}
+	return lines, scanner.Err()
+}
+
+// appendOversightChunk writes a chunk to the corpus file; the first chunk replaces an existing file
+func (s *SejmServer) appendOversightChunk(path string, offset int, chunk oversightChunk) error {
+	if err := os.MkdirAll(s.config.OutputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if offset == 0 {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	}
+	file, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(chunk.jsonl); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (s *SejmServer) handleExportOversightCorpus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_export_oversight_corpus called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	export := oversightExport{
+		term:           term,
+		kind:           strings.ToLower(request.GetString("kind", "interpellations")),
+		includeBodies:  request.GetString("include_bodies", "false") == "true",
+		includeReplies: request.GetString("include_replies", "false") == "true",
+	}
+	if _, ok := oversightKinds[export.kind]; !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid kind '%s'. Use 'interpellations' or 'written_questions'.", export.kind)), nil
+	}
+	if export.includeReplies && !export.includeBodies {
+		return mcp.NewToolResultError("include_replies='true' requires include_bodies='true'."), nil
+	}
+	delivery := strings.ToLower(request.GetString("return_content", oversightDeliveryText))
+	if delivery != oversightDeliveryText && delivery != oversightDeliveryResource && delivery != oversightDeliveryFile {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid return_content '%s'. Use 'text', 'resource' or 'file'.", delivery)), nil
+	}
+	if delivery == oversightDeliveryFile && s.config.OutputDir == "" {
+		return mcp.NewToolResultError("return_content='file' requires the server to be started with -output-dir. Use 'text' or 'resource' instead."), nil
+	}
+	maxChunk := maxOversightChunk
+	if export.includeBodies {
+		maxChunk = maxOversightChunkWithBodies
+	}
+	export.limit = min(defaultOversightChunk, maxChunk)
+	if limitStr := request.GetString("limit", ""); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid limit '%s'. Use a positive number (max %d, %d with bodies).", limitStr, maxOversightChunk, maxOversightChunkWithBodies)), nil
+		}
+		export.limit = min(parsed, maxChunk)
+	}
+
+	// In file mode, an export without an offset resumes after the records already in the file
+	var path string
+	existing := 0
+	if delivery == oversightDeliveryFile {
+		path, err = filepath.Abs(filepath.Join(s.config.OutputDir, export.fileName()))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid output path: %v.", err)), nil
+		}
+		if existing, err = countLines(path); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to read %s: %v.", path, err)), nil
+		}
+		export.offset = existing
+	}
+	if offsetStr := request.GetString("offset", ""); offsetStr != "" {
+		export.offset, err = strconv.Atoi(offsetStr)
+		if err != nil || export.offset < 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid offset '%s'. Use a number of records to skip (0 or more).", offsetStr)), nil
+		}
+		if delivery == oversightDeliveryFile && export.offset != 0 && export.offset != existing {
+			return mcp.NewToolResultError(fmt.Sprintf("%s holds %d records, so the export can continue at offset %d. Omit offset to resume, or use offset='0' to start over.", path, existing, existing)), nil
+		}
+	}
+
+	chunk, err := s.buildOversightChunk(ctx, export, s.newProgressReporter(ctx, request))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to export the oversight corpus: %v.", err)), nil
+	}
+	complete := chunk.records < export.limit
+	nextOffset := export.offset + chunk.records
+
+	summary := []string{
+		fmt.Sprintf("Term: %d, kind: %s", term, export.kind),
+		fmt.Sprintf("Records: %d (offset %d to %d)", chunk.records, export.offset, nextOffset),
+	}
+	if export.includeBodies {
+		summary = append(summary, fmt.Sprintf("Bodies: %d downloaded, %d unavailable", chunk.bodies, chunk.bodyFailures))
+	}
+	var nextActions []string
+	if complete {
+		summary = append(summary, "Export complete: no records after this chunk")
+	} else {
+		summary = append(summary, fmt.Sprintf("Next offset: %d", nextOffset))
+		next := fmt.Sprintf("Next chunk: the same call with offset='%d'", nextOffset)
+		if delivery == oversightDeliveryFile {
+			next = "Next chunk: the same call without offset (it resumes after the last record in the file)"
+		}
+		nextActions = append(nextActions, next)
+	}
+	nextActions = append(nextActions, "Details of one interpellation: sejm_get_interpellation_details with num")
+	note := "Records are JSON Lines ordered by number, with every field the API returns plus term and kind; bodies are plain text in 'body', failed downloads in 'bodyError'."
+	status := "Retrieved Successfully"
+	if chunk.bodyFailures > 0 {
+		status = "Partially Retrieved"
+	}
+
+	var content []mcp.Content
+	switch delivery {
+	case oversightDeliveryFile:
+		if err := s.appendOversightChunk(path, export.offset, chunk); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write %s: %v.", path, err)), nil
+		}
+		s.logger.Info("Oversight corpus chunk saved", slog.String("file", path), slog.Int("records", chunk.records))
+		summary = append(summary, fmt.Sprintf("File: %s (%d records)", path, nextOffset))
+		content = append(content, mcp.NewResourceLink("file://"+filepath.ToSlash(path), filepath.Base(path), "Oversight corpus", "application/x-ndjson"))
+	case oversightDeliveryResource:
+		uri := export.uri()
+		description := fmt.Sprintf("%d %s of term %d from offset %d, JSON Lines", chunk.records, export.kind, term, export.offset)
+		// The resource rebuilds the chunk on read, from the HTTP cache, instead of keeping it in memory
+		s.server.AddResource(
+			mcp.NewResource(uri, export.fileName(), mcp.WithResourceDescription(description), mcp.WithMIMEType("application/x-ndjson")),
+			func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+				s.logger.Info("Oversight corpus resource read", slog.String("uri", request.Params.URI))
+				chunk, err := s.buildOversightChunk(ctx, export, nil)
+				if err != nil {
+					return nil, err
+				}
+				return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "application/x-ndjson", Text: string(chunk.jsonl)}}, nil
+			})
+		summary = append(summary, "Resource: "+uri)
+		content = append(content, mcp.NewResourceLink(uri, export.fileName(), description, "application/x-ndjson"))
+	default:
+		content = append(content, mcp.NewEmbeddedResource(mcp.TextResourceContents{URI: export.uri(), MIMEType: "application/x-ndjson", Text: string(chunk.jsonl)}))
+	}
+
+	response := StandardResponse{
+		Operation:   "Oversight Corpus Export",
+		Status:      status,
+		Summary:     summary,
+		NextActions: nextActions,
+		Note:        note,
+	}
+	result := &mcp.CallToolResult{Content: append([]mcp.Content{mcp.NewTextContent(response.Format())}, content...)}
+	if chunk.bodyFailures > 0 {
+		markPartial(result)
+	}
+	return result, nil
+}
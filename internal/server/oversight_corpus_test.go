@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const oversightInterpellations = `[
+	{"num": 1, "title": "Interpelacja w sprawie dróg", "receiptDate": "2024-01-10", "newField": "kept", "replies": [
+		{"key": "ABC", "from": "Minister Infrastruktury", "onlyAttachment": false},
+		{"key": "DEF", "onlyAttachment": true}
+	]},
+	{"num": 2, "title": "Interpelacja w sprawie szkół", "receiptDate": "2024-01-11"}
+]`
+
+func TestHandleExportOversightCorpus(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/interpellations":                  oversightInterpellations,
+		"/sejm/term10/interpellations/1/body":           "<p>Treść pytania</p><p>Drugi akapit</p>",
+		"/sejm/term10/interpellations/1/reply/ABC/body": "<p>Odpowiedź ministra</p>",
+	})
+
+	result, err := server.handleExportOversightCorpus(context.Background(), createMockRequest(map[string]interface{}{
+		"limit": "2", "include_bodies": "true", "include_replies": "true",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{"Records: 2 (offset 0 to 2)", "Bodies: 2 downloaded, 1 unavailable", "Next offset: 2"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output: %s", expected, text)
+		}
+	}
+	resource, ok := result.Content[1].(mcp.EmbeddedResource)
+	if !ok {
+		t.Fatalf("Expected the JSONL as an embedded resource, got %T", result.Content[1])
+	}
+	lines := strings.Split(strings.TrimSpace(resource.Resource.(mcp.TextResourceContents).Text), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 JSON lines, got %d", len(lines))
+	}
+	var record struct {
+		Num      int    `json:"num"`
+		Kind     string `json:"kind"`
+		NewField string `json:"newField"`
+		Body     string `json:"body"`
+		Replies  []struct {
+			Key  string `json:"key"`
+			Body string `json:"body"`
+		} `json:"replies"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("Failed to parse record: %v", err)
+	}
+	if record.Num != 1 || record.Kind != "interpellations" || record.NewField != "kept" || strings.Join(strings.Fields(record.Body), " ") != "Treść pytania Drugi akapit" {
+		t.Errorf("Unexpected record: %+v", record)
+	}
+	if record.Replies[0].Body != "Odpowiedź ministra" || record.Replies[1].Body != "" {
+		t.Errorf("Expected only the reply with a body to be downloaded, got %+v", record.Replies)
+	}
+
+	result, _ = server.handleExportOversightCorpus(context.Background(), createMockRequest(map[string]interface{}{"include_replies": "true"}))
+	if !result.IsError {
+		t.Errorf("Expected replies without bodies to be rejected")
+	}
+	result, _ = server.handleExportOversightCorpus(context.Background(), createMockRequest(map[string]interface{}{"return_content": "file"}))
+	if !result.IsError || !strings.Contains(extractTextContent(result), "-output-dir") {
+		t.Errorf("Expected file mode to require an output directory, got: %s", extractTextContent(result))
+	}
+}
+
+func TestHandleExportOversightCorpusFile(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{"/sejm/term10/interpellations": oversightInterpellations})
+	server.config.OutputDir = t.TempDir()
+	request := map[string]interface{}{"limit": "2", "return_content": "file"}
+
+	for i := 0; i < 2; i++ {
+		result, err := server.handleExportOversightCorpus(context.Background(), createMockRequest(request))
+		if err != nil || result.IsError {
+			t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(server.config.OutputDir, "oversight_term10_interpellations.jsonl"))
+	if err != nil {
+		t.Fatalf("Expected the corpus file: %v", err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 4 {
+		t.Errorf("Expected the second chunk to be appended, got %d lines", lines)
+	}
+
+	result, _ := server.handleExportOversightCorpus(context.Background(), createMockRequest(map[string]interface{}{
+		"limit": "2", "return_content": "file", "offset": "2",
+	}))
+	if !result.IsError || !strings.Contains(extractTextContent(result), "continue at offset 4") {
+		t.Errorf("Expected an offset that does not match the file to be rejected, got: %s", extractTextContent(result))
+	}
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxListLimit caps the page size of list tools so a single page stays within context limits
+const maxListLimit = 100
+
+// listPage is the offset/limit window requested from a list tool
+type listPage struct {
+	offset int
+	limit  int
+}
+
+// parseListPage reads the 'offset' and 'limit' parameters, applying defaultLimit when limit is absent
+func parseListPage(request mcp.CallToolRequest, defaultLimit int) (listPage, error) {
+	page := listPage{limit: defaultLimit}
+	if value := request.GetString("limit", ""); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxListLimit {
+			return page, fmt.Errorf("parameter 'limit' must be a number between 1 and %d", maxListLimit)
+		}
+		page.limit = limit
+	}
+	if value := request.GetString("offset", ""); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return page, fmt.Errorf("parameter 'offset' must be a non-negative number")
+		}
+		page.offset = offset
+	}
+	return page, nil
+}
+
+// apiParams asks the API for one item more than the page holds; its presence tells whether a next page exists
+func (p listPage) apiParams() map[string]string {
+	return map[string]string{
+		"limit":  strconv.Itoa(p.limit + 1),
+		"offset": strconv.Itoa(p.offset),
+	}
+}
+
+// fetched trims an API result fetched with apiParams to the page size and reports whether more items follow
+func (p listPage) fetched(count int) (shown int, more bool) {
+	if count > p.limit {
+		return p.limit, true
+	}
+	return count, false
+}
+
+// bounds returns the slice bounds of the page within a fully downloaded list of total items
+func (p listPage) bounds(total int) (start, end int) {
+	start = min(p.offset, total)
+	end = min(start+p.limit, total)
+	return start, end
+}
+
+// describe reports which items of the list the page holds; total is -1 when the API does not report it
+func (p listPage) describe(noun string, shown, total int) string {
+	if shown == 0 {
+		return fmt.Sprintf("No %s at offset %d", noun, p.offset)
+	}
+	if total < 0 {
+		return fmt.Sprintf("Showing %s %d-%d", noun, p.offset+1, p.offset+shown)
+	}
+	return fmt.Sprintf("Showing %s %d-%d of %d", noun, p.offset+1, p.offset+shown, total)
+}
+
+// navigation returns previous/next page hints for tool; call holds the other arguments to repeat, if any
+func (p listPage) navigation(tool, call string, more bool) []string {
+	prefix := tool + " with "
+	if call != "" {
+		prefix += call + ", "
+	}
+	var actions []string
+	if more {
+		actions = append(actions, fmt.Sprintf("Next page (next_offset=%d): %soffset='%d', limit='%d'", p.offset+p.limit, prefix, p.offset+p.limit, p.limit))
+	}
+	if p.offset > 0 {
+		actions = append(actions, fmt.Sprintf("Previous page: %soffset='%d', limit='%d'", prefix, max(p.offset-p.limit, 0), p.limit))
+	}
+	return actions
+}
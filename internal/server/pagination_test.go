@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseListPage(t *testing.T) {
+	page, err := parseListPage(createMockRequest(map[string]interface{}{"offset": "40", "limit": "20"}), 30)
+	if err != nil || page.offset != 40 || page.limit != 20 {
+		t.Fatalf("Unexpected page %+v, error %v", page, err)
+	}
+	if params := page.apiParams(); params["limit"] != "21" || params["offset"] != "40" {
+		t.Errorf("Expected one extra item requested, got %v", params)
+	}
+	if shown, more := page.fetched(21); shown != 20 || !more {
+		t.Errorf("Expected 20 shown with more available, got %d %v", shown, more)
+	}
+	if start, end := page.bounds(50); start != 40 || end != 50 {
+		t.Errorf("Expected bounds 40-50, got %d-%d", start, end)
+	}
+
+	page, err = parseListPage(createMockRequest(map[string]interface{}{}), 30)
+	if err != nil || page.offset != 0 || page.limit != 30 {
+		t.Errorf("Expected defaults, got %+v, error %v", page, err)
+	}
+	for _, args := range []map[string]interface{}{{"limit": "0"}, {"limit": "500"}, {"offset": "-1"}, {"offset": "x"}} {
+		if _, err := parseListPage(createMockRequest(args), 30); err == nil {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
+
+func TestHandleGetProceedingsPaging(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/proceedings": `[
+			{"number": 1, "title": "1. Posiedzenie", "dates": ["2023-11-13"]},
+			{"number": 3, "title": "3. Posiedzenie", "dates": ["2023-12-12"]},
+			{"number": 2, "title": "2. Posiedzenie", "dates": ["2023-11-28"]}
+		]`,
+	})
+
+	result, err := server.handleGetProceedings(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "offset": "1", "limit": "1",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Showing proceedings 2-2 of 3",
+		"Proceeding 2:",
+		"Next page (next_offset=2): sejm_get_proceedings with term='10', sort_by='-number', offset='2', limit='1'",
+		"Previous page: sejm_get_proceedings with term='10', sort_by='-number', offset='0', limit='1'",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+	if strings.Contains(content, "Proceeding 3:") || strings.Contains(content, "Proceeding 1:") {
+		t.Errorf("Expected only one proceeding on the page, got: %s", content)
+	}
+}
+
+func TestHandleGetProceedingsDateFilterAndAgenda(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/proceedings": `[
+			{"number": 7, "title": "7. Posiedzenie", "dates": ["2024-02-29", "2024-03-01"],
+				"agenda": "<ol><li>Pierwsze czytanie projektu ustawy o podatku</li><li>Informacja bieżąca</li></ol>"},
+			{"number": 8, "title": "8. Posiedzenie", "dates": ["2024-03-06"]},
+			{"number": 9, "title": "9. Posiedzenie", "dates": ["2024-04-10"]}
+		]`,
+		"/sejm/term10/proceedings/8": `{"number": 8, "agenda": "<p>1. Sprawozdanie komisji o projekcie ustawy</p>"}`,
+	})
+
+	result, err := server.handleGetProceedings(context.Background(), createMockRequest(map[string]interface{}{
+		"since": "2024-03-01", "till": "2024-03-31", "include_agenda": "true",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Sitting days from 2024-03-01 to 2024-03-31",
+		"Proceeding 7:",
+		"    1. Pierwsze czytanie projektu ustawy o podatku",
+		"    2. Informacja bieżąca",
+		"Proceeding 8:",
+		"    1. Sprawozdanie komisji o projekcie ustawy",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+	if strings.Contains(content, "Proceeding 9:") {
+		t.Errorf("Expected proceeding 9 to be filtered out, got: %s", content)
+	}
+
+	result, _ = server.handleGetProceedings(context.Background(), createMockRequest(map[string]interface{}{
+		"since": "2024-04-01", "till": "2024-03-01",
+	}))
+	if !result.IsError {
+		t.Error("Expected an error for an inverted date window")
+	}
+}
+
+func TestHandleGetPrintsPaging(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/prints": `[
+			{"number": "10", "title": "Projekt ustawy A"},
+			{"number": "11", "title": "Projekt ustawy B"},
+			{"number": "12", "title": "Projekt ustawy C"}
+		]`,
+	})
+
+	result, err := server.handleGetPrints(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "limit": "2", "sort_by": "-number",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Showing prints 1-2",
+		"Print 11: Projekt ustawy B",
+		"Next page (next_offset=2): sejm_get_prints with term='10', sort_by='-number', offset='2', limit='2'",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+	if strings.Contains(content, "Projekt ustawy C") {
+		t.Errorf("Expected the extra item to be trimmed, got: %s", content)
+	}
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// integerParams lists tool parameters that always carry whole numbers. Their schema accepts both JSON
+// integers and numeric strings, and string values are validated before handlers run. Identifiers that
+// may contain letters (e.g. print numbers like '1234-A') are not listed, but numeric values sent for
+// them are still converted to strings by normalizeArguments. MP IDs are not listed either, because
+// they also accept names (see resolveEntityArguments).
+var integerParams = map[string]bool{
+	"term":                 true,
+	"from_term":            true,
+	"to_term":              true,
+	"limit":                true,
+	"offset":               true,
+	"year":                 true,
+	"position":             true,
+	"sitting":              true,
+	"sitting_number":       true,
+	"proceeding_id":        true,
+	"voting_number":        true,
+	"statement_num":        true,
+	"group_id":             true,
+	"page":                 true,
+	"pages_per_chunk":      true,
+	"chunk_size":           true,
+	"chunk_number":         true,
+	"context_chars":        true,
+	"max_matches_per_term": true,
+	"max_size_bytes":       true,
+	"max_votings":          true,
+	"min_votings":          true,
+	"summary_sentences":    true,
+	"days":                 true,
+	"max_output_chars":     true,
+	"digest_sentences":     true,
+	"max_sittings":         true,
+	"max_items":            true,
+	"clusters":             true,
+	"max_acts":             true,
+	"page_size":            true,
+	"low_threshold":        true,
+}
+
+// applyIntegerSchema declares integer parameters as accepting both integers and numeric strings
+func applyIntegerSchema(properties map[string]interface{}) {
+	for name, property := range properties {
+		if !integerParams[name] {
+			continue
+		}
+		schema, ok := property.(map[string]interface{})
+		if !ok || schema["type"] != "string" {
+			continue
+		}
+		updated := make(map[string]interface{}, len(schema))
+		for k, v := range schema {
+			updated[k] = v
+		}
+		updated["type"] = []string{"integer", "string"}
+		properties[name] = updated
+	}
+}
+
+// normalizeArguments converts numeric and boolean argument values into the string form the
+// handlers read with request.GetString, and rejects values that cannot be used as whole numbers.
+// The original arguments map is left untouched.
+func normalizeArguments(request mcp.CallToolRequest) (mcp.CallToolRequest, error) {
+	args := request.GetArguments()
+	if len(args) == 0 {
+		return request, nil
+	}
+
+	normalized := make(map[string]any, len(args))
+	for name, value := range args {
+		converted, err := normalizeArgument(name, value)
+		if err != nil {
+			return request, err
+		}
+		normalized[name] = converted
+	}
+
+	request.Params.Arguments = normalized
+	return request, nil
+}
+
+// maxExactInteger is the largest magnitude up to which every whole number is exactly representable as float64
+const maxExactInteger = 1 << 53
+
+// normalizeArgument converts a single argument value, validating integer parameters
+func normalizeArgument(name string, value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if integerParams[name] {
+			trimmed := strings.TrimSpace(v)
+			if trimmed != "" {
+				if _, err := strconv.Atoi(trimmed); err != nil {
+					return nil, fmt.Errorf("parameter '%s' must be a whole number (e.g. 20 or \"20\"), got \"%s\"", name, v)
+				}
+			}
+			return trimmed, nil
+		}
+		return v, nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) || v != math.Trunc(v) || math.Abs(v) > maxExactInteger {
+			return nil, fmt.Errorf("parameter '%s' must be a whole number, got %v", name, v)
+		}
+		return strconv.FormatInt(int64(v), 10), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case json.Number:
+		if _, err := v.Int64(); err != nil {
+			return nil, fmt.Errorf("parameter '%s' must be a whole number, got %s", name, v.String())
+		}
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeArgument(t *testing.T) {
+	testCases := []struct {
+		name     string
+		param    string
+		value    any
+		expected any
+		hasError bool
+	}{
+		{"integer as float", "term", float64(10), "10", false},
+		{"integer string", "limit", "25", "25", false},
+		{"integer string with spaces", "offset", " 5 ", "5", false},
+		{"empty integer string", "term", "", "", false},
+		{"fractional number", "limit", 2.5, nil, true},
+		{"number out of range", "limit", 1e20, nil, true},
+		{"negative number out of range", "offset", -1e20, nil, true},
+		{"largest exact number", "num", float64(1 << 53), "9007199254740992", false},
+		{"non-numeric string", "statement_num", "abc", nil, true},
+		{"name for MP ID", "mp_id", "Jan Kowalski", "Jan Kowalski", false},
+		{"json number", "page", json.Number("3"), "3", false},
+		{"boolean", "detailed", true, "true", false},
+		{"number for free-form param", "num", float64(123), "123", false},
+		{"letters allowed in free-form param", "num", "123-A", "123-A", false},
+		{"plain string", "title", "kodeks pracy", "kodeks pracy", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := normalizeArgument(tc.param, tc.value)
+			if tc.hasError {
+				if err == nil {
+					t.Errorf("Expected error for %s=%v, got none", tc.param, tc.value)
+				} else if !strings.Contains(err.Error(), tc.param) {
+					t.Errorf("Error should name the parameter, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Unexpected error for %s=%v: %v", tc.param, tc.value, err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected %v for %s=%v, got %v", tc.expected, tc.param, tc.value, result)
+			}
+		})
+	}
+}
+
+func TestNormalizeArgumentsDoesNotMutateInput(t *testing.T) {
+	args := map[string]interface{}{"term": float64(9)}
+	request, err := normalizeArguments(createMockRequest(args))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if request.GetString("term", "") != "9" {
+		t.Errorf("Expected normalized term '9', got '%s'", request.GetString("term", ""))
+	}
+	if _, ok := args["term"].(float64); !ok {
+		t.Error("Original arguments map should not be modified")
+	}
+}
+
+func TestApplyIntegerSchema(t *testing.T) {
+	properties := map[string]interface{}{
+		"term":  map[string]interface{}{"type": "string", "description": "term"},
+		"title": map[string]interface{}{"type": "string", "description": "title"},
+	}
+	applyIntegerSchema(properties)
+
+	termType := properties["term"].(map[string]interface{})["type"]
+	if types, ok := termType.([]string); !ok || len(types) != 2 || types[0] != "integer" || types[1] != "string" {
+		t.Errorf("Expected term to accept integer and string, got %v", termType)
+	}
+	if properties["title"].(map[string]interface{})["type"] != "string" {
+		t.Error("Non-integer parameters should keep string type")
+	}
+}
+
+func TestToolCallAcceptsIntegerArguments(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term9/committees/ASW": `{"code": "ASW", "name": "Komisja", "members": [{"id": 7, "lastFirstName": "Nowak Anna", "club": "KO"}]}`,
+	})
+
+	call := func(arguments string) string {
+		message := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"sejm_get_committee_members","arguments":` + arguments + `}}`
+		response := server.server.HandleMessage(context.Background(), json.RawMessage(message))
+		encoded, err := json.Marshal(response)
+		if err != nil {
+			t.Fatalf("Failed to encode response: %v", err)
+		}
+		return string(encoded)
+	}
+
+	if output := call(`{"term": 9, "committee_code": "ASW"}`); !strings.Contains(output, "mp_id 7") {
+		t.Errorf("Expected integer term to be honored, got: %s", output)
+	}
+
+	if output := call(`{"term": 9.5, "committee_code": "ASW"}`); !strings.Contains(output, "whole number") {
+		t.Errorf("Expected coercion error for fractional term, got: %s", output)
+	}
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/gen2brain/go-fitz"
+)
+
+const (
+	// maxPDFWorkers caps the documents opened in parallel for one extraction
+	maxPDFWorkers = 8
+	// minPagesPerPDFWorker avoids opening extra documents for short page ranges
+	minPagesPerPDFWorker = 4
+	// maxPDFExtractionMemory bounds the estimated memory of the documents opened for one extraction
+	maxPDFExtractionMemory = 512 << 20
+	// pdfDocumentOverhead and pdfDocumentExpansion estimate the memory of an opened document: a fixed
+	// context cost plus the parsed objects, fonts and page trees, a few times the file size
+	pdfDocumentOverhead  = 16 << 20
+	pdfDocumentExpansion = 4
+)
+
+// pdfPageExtractor returns the text of one page (0-based) of an opened document
+type pdfPageExtractor func(doc *fitz.Document, page int) (string, error)
+
+// pdfPageText extracts the plain text of a page
+func pdfPageText(doc *fitz.Document, page int) (string, error) {
+	return doc.Text(page)
+}
+
+// pdfPageFailure is a page that could not be extracted (1-based) and why
+type pdfPageFailure struct {
+	Page  int    `json:"page"`
+	Error string `json:"error"`
+}
+
+// extractPDFPage extracts one page, turning a panic on a corrupted page into an error of that page
+func extractPDFPage(extract pdfPageExtractor, doc *fitz.Document, page int) (text string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			text, err = "", fmt.Errorf("page %d is corrupted: %v", page+1, r)
+		}
+	}()
+	return extract(doc, page)
+}
+
+// pdfPageFailures lists the pages whose extraction failed, in page order
+func pdfPageFailures(pages []int, errs []error) []pdfPageFailure {
+	var failures []pdfPageFailure
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, pdfPageFailure{Page: pages[i] + 1, Error: err.Error()})
+		}
+	}
+	return failures
+}
+
+// describePDFPageFailures names the failed pages and the first error, e.g. "pages 3, 7 (page 3 is corrupted)"
+func describePDFPageFailures(failures []pdfPageFailure) string {
+	numbers := make([]string, len(failures))
+	for i, failure := range failures {
+		numbers[i] = fmt.Sprint(failure.Page)
+	}
+	noun := "pages"
+	if len(failures) == 1 {
+		noun = "page"
+	}
+	return fmt.Sprintf("%s %s (%s)", noun, strings.Join(numbers, ", "), failures[0].Error)
+}
+
+// pdfWorkerCount picks how many documents to open for extracting pages of a PDF: one per CPU, at most
+// maxPDFWorkers, at least minPagesPerPDFWorker pages each, and within the memory budget
+func pdfWorkerCount(pdfBytes, pages int) int {
+	workers := runtime.NumCPU()
+	if workers > maxPDFWorkers {
+		workers = maxPDFWorkers
+	}
+	if byPages := pages / minPagesPerPDFWorker; byPages < workers {
+		workers = byPages
+	}
+	if byMemory := maxPDFExtractionMemory / (pdfDocumentOverhead + pdfDocumentExpansion*pdfBytes); byMemory < workers {
+		workers = byMemory
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return workers
+}
+
+// extractPDFPages extracts the given pages with a pool of workers. A go-fitz document serializes all calls,
+// so each worker opens its own document from the shared PDF bytes once and reuses it for all the pages it
+// takes; the first worker reuses doc, which the caller already opened. A page that fails is retried once on
+// a freshly opened document, since a corrupted page can leave the document it was read from unusable; the
+// worker goes on with the fresh document. Texts and errors are returned in the order of pages, so a failed
+// page costs only its own text. Pages not extracted because ctx was cancelled report the context error.
+func (s *SejmServer) extractPDFPages(ctx context.Context, doc *fitz.Document, pdfData []byte, pages []int, extract pdfPageExtractor) ([]string, []error) {
+	texts := make([]string, len(pages))
+	errs := make([]error, len(pages))
+	workers := pdfWorkerCount(len(pdfData), len(pages))
+
+	next := make(chan int, len(pages))
+	for i := range pages {
+		next <- i
+	}
+	close(next)
+
+	work := func(doc *fitz.Document) {
+		var reopened *fitz.Document
+		defer func() {
+			if reopened != nil {
+				if err := reopened.Close(); err != nil {
+					s.logger.Warn("Failed to close PDF document", slog.Any("error", err))
+				}
+			}
+		}()
+		for i := range next {
+			if err := ctx.Err(); err != nil {
+				errs[i] = err
+				continue
+			}
+			texts[i], errs[i] = extractPDFPage(extract, doc, pages[i])
+			if errs[i] == nil || ctx.Err() != nil {
+				continue
+			}
+			fresh, err := fitz.NewFromMemory(pdfData)
+			if err != nil {
+				continue
+			}
+			if reopened != nil {
+				if err := reopened.Close(); err != nil {
+					s.logger.Warn("Failed to close PDF document", slog.Any("error", err))
+				}
+			}
+			reopened, doc = fresh, fresh
+			if texts[i], err = extractPDFPage(extract, doc, pages[i]); err == nil {
+				s.logger.Info("Recovered PDF page on a reopened document", slog.Int("page", pages[i]+1), slog.Any("error", errs[i]))
+			}
+			errs[i] = err
+		}
+	}
+
+	var wg sync.WaitGroup
+	for w := 1; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			workerDoc, err := fitz.NewFromMemory(pdfData)
+			if err != nil {
+				// The remaining workers take over the pages
+				s.logger.Warn("Failed to open PDF document for a parallel worker", slog.Any("error", err))
+				return
+			}
+			defer func() {
+				if err := workerDoc.Close(); err != nil {
+					s.logger.Warn("Failed to close PDF document", slog.Any("error", err))
+				}
+			}()
+			work(workerDoc)
+		}()
+	}
+	work(doc)
+	wg.Wait()
+
+	s.logger.Debug("Extracted PDF pages in parallel", slog.Int("pages", len(pages)), slog.Int("workers", workers))
+	return texts, errs
+}
+
+// pageRange lists the 0-based page numbers from start to end, exclusive
+func pageRange(start, end int) []int {
+	pages := make([]int, 0, end-start)
+	for page := start; page < end; page++ {
+		pages = append(pages, page)
+	}
+	return pages
+}
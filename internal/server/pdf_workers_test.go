@@ -0,0 +1,137 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gen2brain/go-fitz"
+)
+
+// multiPagePDF builds a PDF whose pages each contain the text "Page N"
+func multiPagePDF(pages int) []byte {
+	var objects []string
+	kids := make([]string, pages)
+	for i := 0; i < pages; i++ {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), pages),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>")
+	for i := 0; i < pages; i++ {
+		content := fmt.Sprintf("BT /F1 12 Tf 72 720 Td (Page %d) Tj ET", i+1)
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", 5+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = pdf.Len()
+		fmt.Fprintf(&pdf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := pdf.Len()
+	fmt.Fprintf(&pdf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&pdf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&pdf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return pdf.Bytes()
+}
+
+func TestExtractPDFPageTextsInParallel(t *testing.T) {
+	server := NewSejmServer()
+	pageTexts, err := server.extractPDFPageTexts(context.Background(), multiPagePDF(40))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(pageTexts) != 40 {
+		t.Fatalf("Expected 40 pages, got %d", len(pageTexts))
+	}
+	for i, text := range pageTexts {
+		if strings.TrimSpace(text) != fmt.Sprintf("Page %d", i+1) {
+			t.Errorf("Page %d has text %q", i+1, text)
+		}
+	}
+
+	text, err := server.extractTextFromPDF(context.Background(), multiPagePDF(12))
+	if err != nil || !strings.HasPrefix(text, "Page 1\n") || !strings.HasSuffix(text, "Page 12") {
+		t.Errorf("Expected pages in order, got %q (%v)", text, err)
+	}
+}
+
+func TestExtractPDFPagesRecoversFromCorruptedPages(t *testing.T) {
+	server := NewSejmServer()
+	pdfData := multiPagePDF(6)
+	doc, err := fitz.NewFromMemory(pdfData)
+	if err != nil {
+		t.Fatalf("Failed to open PDF: %v", err)
+	}
+	defer doc.Close()
+
+	// Page 2 always panics, page 4 fails on the first document only and page 6 always fails
+	var mu sync.Mutex
+	attempts := make(map[int]int)
+	extract := func(doc *fitz.Document, page int) (string, error) {
+		mu.Lock()
+		attempts[page]++
+		attempt := attempts[page]
+		mu.Unlock()
+		switch {
+		case page == 1:
+			panic("broken content stream")
+		case page == 3 && attempt == 1:
+			return "", errors.New("document state lost")
+		case page == 5:
+			return "", errors.New("unreadable page")
+		}
+		return pdfPageText(doc, page)
+	}
+
+	pages := pageRange(0, 6)
+	texts, errs := server.extractPDFPages(context.Background(), doc, pdfData, pages, extract)
+	for i, text := range texts {
+		readable := i != 1 && i != 5
+		if readable && (errs[i] != nil || strings.TrimSpace(text) != fmt.Sprintf("Page %d", i+1)) {
+			t.Errorf("Expected page %d to be read, got %q (%v)", i+1, text, errs[i])
+		}
+		if !readable && errs[i] == nil {
+			t.Errorf("Expected page %d to fail", i+1)
+		}
+	}
+	if attempts[3] != 2 {
+		t.Errorf("Expected page 4 to be retried once, got %d attempts", attempts[3])
+	}
+
+	failures := pdfPageFailures(pages, errs)
+	if len(failures) != 2 || failures[0].Page != 2 || failures[1].Page != 6 {
+		t.Fatalf("Unexpected failures: %+v", failures)
+	}
+	if described := describePDFPageFailures(failures); described != "pages 2, 6 (page 2 is corrupted: broken content stream)" {
+		t.Errorf("Unexpected description: %s", described)
+	}
+}
+
+func TestPDFWorkerCount(t *testing.T) {
+	cpus := runtime.NumCPU()
+	if cpus > maxPDFWorkers {
+		cpus = maxPDFWorkers
+	}
+	if got := pdfWorkerCount(1<<20, 3); got != 1 {
+		t.Errorf("Expected a single worker for a few pages, got %d", got)
+	}
+	if got := pdfWorkerCount(1<<20, 400); got != cpus {
+		t.Errorf("Expected %d workers for a long document, got %d", cpus, got)
+	}
+	if got := pdfWorkerCount(200<<20, 400); got != 1 {
+		t.Errorf("Expected the memory budget to limit a 200 MB document to one worker, got %d", got)
+	}
+}
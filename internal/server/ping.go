@@ -0,0 +1,295 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// pingTimeout bounds each upstream probe of sejm_ping
+const pingTimeout = 10 * time.Second
+
+// upstreamProbe is the outcome of one live request to an upstream API
+type upstreamProbe struct {
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	Reachable bool   `json:"reachable"`
+	Status    int    `json:"status,omitempty"`
+	LatencyMs int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+// probeUpstream requests a small endpoint of an upstream API past the response cache, so the latency is that
+// of the network and the API. The outcome is recorded in the upstream health like any other request.
+func (s *SejmServer) probeUpstream(ctx context.Context, name, endpoint string) upstreamProbe {
+	probe := upstreamProbe{Name: name, URL: endpoint}
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		probe.Error = err.Error()
+		return probe
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Cache-Control", "no-cache, no-store")
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	probe.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		s.health.record(endpoint, 0, err)
+		probe.Error = err.Error()
+		return probe
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	s.health.record(endpoint, resp.StatusCode, nil)
+	probe.Status = resp.StatusCode
+	probe.Reachable = resp.StatusCode < http.StatusInternalServerError && resp.StatusCode != http.StatusTooManyRequests
+	if resp.StatusCode >= http.StatusBadRequest {
+		probe.Error = fmt.Sprintf("HTTP %d", resp.StatusCode)
+	}
+	return probe
+}
+
+// httpCacheSnapshot returns a copy of the upstream response cache statistics
+func (s *SejmServer) httpCacheSnapshot() HTTPCacheStats {
+	s.cache.mu.RLock()
+	defer s.cache.mu.RUnlock()
+	return *s.cache.HTTPStats
+}
+
+// enabledFeatures lists the optional features turned on by the server configuration
+func (s *SejmServer) enabledFeatures() map[string]string {
+	features := map[string]string{
+		"defaultLanguage": valueOrDefault(s.config.Language, "en"),
+		"upstreamTimeout": upstreamTimeout(s.config).String(),
+	}
+	optional := []struct {
+		name, value string
+	}{
+		{"outputDir", s.config.OutputDir},
+		{"jobsDir", s.config.JobsDir},
+		{"watchDir", s.config.WatchDir},
+		{"votingIndexDir", s.config.VotingIndexDir},
+		{"mockDir", s.config.MockDir},
+		{"recordDir", s.config.RecordDir},
+		{"otlpEndpoint", s.config.OTLPEndpoint},
+	}
+	for _, feature := range optional {
+		if feature.value != "" {
+			features[feature.name] = feature.value
+		}
+	}
+	if s.config.MaxOutputChars > 0 {
+		features["maxOutputChars"] = fmt.Sprint(s.config.MaxOutputChars)
+	}
+	if timeout := s.config.ToolTimeouts[defaultToolTimeoutKey]; timeout > 0 {
+		features["toolTimeout"] = timeout.String()
+	}
+	if s.config.RateLimit > 0 {
+		features["rateLimitPerMinute"] = fmt.Sprint(s.config.RateLimit)
+	}
+	if s.config.MaxConnections > 0 {
+		features["maxConnections"] = fmt.Sprint(s.config.MaxConnections)
+	}
+	if s.config.MaxMessagesPerMinute > 0 {
+		features["maxMessagesPerMinute"] = fmt.Sprint(s.config.MaxMessagesPerMinute)
+	}
+	if s.config.IdleTimeout > 0 {
+		features["idleTimeout"] = s.config.IdleTimeout.String()
+	}
+	if len(s.profiles) > 0 {
+		features["profiles"] = strings.Join(s.profileNames(), ", ")
+	}
+	if s.config.DebugMode {
+		features["debug"] = "true"
+	}
+	return features
+}
+
+func (s *SejmServer) registerPingTool() {
+	s.addTool(mcp.Tool{
+		Name:        "sejm_ping",
+		Description: "Check the health of this server from inside a chat: server version and tool count, live reachability and latency of the Sejm and ELI APIs (bypassing the response cache), upstream error counts since start, response cache statistics and the enabled optional features. Use it first when other tools fail or are slow.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"check_upstreams": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'false' to skip the live requests to the Sejm and ELI APIs and report only the server state (default: 'true').",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json'.",
+				},
+			},
+		},
+	}, s.handlePing)
+}
+
+func (s *SejmServer) handlePing(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_ping called", slog.Any("arguments", request.Params.Arguments))
+
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+	probeUpstreams := request.GetString("check_upstreams", "true") != "false"
+
+	var probes []upstreamProbe
+	if probeUpstreams {
+		endpoints := []struct{ name, url string }{
+			{"sejm", s.sejmBaseURL + "/sejm/term"},
+			{"eli", s.eliBaseURL + "/acts"},
+		}
+		probes = make([]upstreamProbe, len(endpoints))
+		var wg sync.WaitGroup
+		for i, endpoint := range endpoints {
+			wg.Add(1)
+			go func(i int, name, url string) {
+				defer wg.Done()
+				probes[i] = s.probeUpstream(ctx, name, url)
+			}(i, endpoint.name, endpoint.url)
+		}
+		wg.Wait()
+	}
+	stats := s.httpCacheSnapshot()
+	features := s.enabledFeatures()
+	tools := len(s.server.ListTools())
+	state := s.health.overallState()
+	drift := s.drift.snapshot()
+
+	if format == "json" {
+		output := map[string]interface{}{
+			"service":   "sejm-mcp",
+			"version":   serverVersion,
+			"status":    state,
+			"tools":     tools,
+			"probes":    probes,
+			"upstreams": s.health.snapshot(),
+			"httpCache": map[string]interface{}{
+				"requests":    stats.Requests,
+				"hits":        stats.Hits,
+				"misses":      stats.Misses,
+				"revalidated": stats.Revalidated,
+			},
+			"features":    features,
+			"schemaDrift": drift,
+		}
+		if s.connections != nil {
+			output["connections"] = s.connections.snapshot()
+		}
+		result, _ := json.MarshalIndent(output, "", "  ")
+		return mcp.NewToolResultText(string(result)), nil
+	}
+
+	summary := []string{
+		fmt.Sprintf("Server: sejm-mcp %s, %d tools", serverVersion, tools),
+		fmt.Sprintf("Upstream state: %s", state),
+	}
+	hitRate := 0.0
+	if stats.Requests > 0 {
+		hitRate = float64(stats.Hits) * 100 / float64(stats.Requests)
+	}
+	summary = append(summary, fmt.Sprintf("Response cache: %d requests, %d hits (%.1f%%), %d revalidated", stats.Requests, stats.Hits, hitRate, stats.Revalidated))
+	if s.connections != nil {
+		connections := s.connections.snapshot()
+		summary = append(summary, fmt.Sprintf("Connections (%s): %d active, %d peak, %d accepted, %d rejected, %d rate limited, %d closed as idle",
+			connections.Mode, connections.Active, connections.Peak, connections.Accepted, connections.Rejected, connections.RateLimited, connections.Reaped))
+	}
+
+	var results []string
+	unreachable := 0
+	if probeUpstreams {
+		results = append(results, "Upstream APIs (live request, cache bypassed):")
+		for _, probe := range probes {
+			line := fmt.Sprintf("  %s: ", probe.Name)
+			if probe.Reachable {
+				line += fmt.Sprintf("reachable, %d ms", probe.LatencyMs)
+			} else {
+				unreachable++
+				line += fmt.Sprintf("UNREACHABLE after %d ms", probe.LatencyMs)
+			}
+			if probe.Error != "" {
+				line += " (" + probe.Error + ")"
+			}
+			results = append(results, line+" – "+probe.URL)
+		}
+	}
+	if snapshot := s.health.snapshot(); len(snapshot) > 0 {
+		names := make([]string, 0, len(snapshot))
+		for name := range snapshot {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		results = append(results, "", "Requests since start:")
+		for _, name := range names {
+			status := snapshot[name]
+			line := fmt.Sprintf("  %s: %s, %d requests, %d failures", name, status.State, status.Requests, status.Failures)
+			if status.LastError != "" {
+				line += ", last error: " + status.LastError
+			}
+			results = append(results, line)
+		}
+	}
+	if len(drift) > 0 {
+		results = append(results, "", "API schema drift (fields that differ from the expected types):")
+		for _, entry := range drift {
+			line := fmt.Sprintf("  %s: %s, seen %d times", entry.Field, entry.Kind, entry.Count)
+			if entry.Detail != "" {
+				line += " (" + entry.Detail + ")"
+			}
+			results = append(results, line)
+		}
+	}
+	names := make([]string, 0, len(features))
+	for name := range features {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	results = append(results, "", "Configuration:")
+	for _, name := range names {
+		results = append(results, fmt.Sprintf("  %s: %s", name, features[name]))
+	}
+
+	status := "Healthy"
+	if unreachable > 0 || state != upstreamHealthy {
+		status = "Degraded"
+	}
+	var hints []string
+	if unreachable > 0 {
+		hints = append(hints, "An upstream API is unreachable, so tools using it will fail until it recovers; cached responses may still be served.")
+	}
+	for _, entry := range drift {
+		if entry.Kind == driftTypeMismatch {
+			hints = append(hints, "A field changed its type in the API, so it is left empty in tool output; sejm_get_raw_json shows the response as sent.")
+			break
+		}
+	}
+	if !probeUpstreams {
+		hints = append(hints, "Upstream APIs were not contacted; omit check_upstreams to measure their latency.")
+	}
+
+	response := StandardResponse{
+		Operation: "Server Health",
+		Status:    status,
+		Summary:   summary,
+		Data:      results,
+		NextActions: []string{
+			"Structured output: add format='json'",
+			"Without network requests: check_upstreams='false'",
+		},
+		Note: strings.Join(hints, " "),
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
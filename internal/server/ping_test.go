@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestHandlePing(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term": `[{"num": 10, "current": true}]`,
+	})
+	server.config.OutputDir = t.TempDir()
+
+	result, err := server.handlePing(context.Background(), createMockRequest(map[string]interface{}{"format": "json"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	var response struct {
+		Version  string            `json:"version"`
+		Tools    int               `json:"tools"`
+		Probes   []upstreamProbe   `json:"probes"`
+		Features map[string]string `json:"features"`
+	}
+	if err := json.Unmarshal([]byte(extractTextContent(result)), &response); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if response.Version != serverVersion || response.Tools == 0 {
+		t.Errorf("Expected the version and tool count, got %+v", response)
+	}
+	if len(response.Probes) != 2 || !response.Probes[0].Reachable || response.Probes[1].Status != 404 || !response.Probes[1].Reachable {
+		t.Errorf("Expected both upstreams to answer, the ELI one with 404, got %+v", response.Probes)
+	}
+	if response.Features["outputDir"] == "" {
+		t.Errorf("Expected the output directory among enabled features, got %v", response.Features)
+	}
+
+	result, _ = server.handlePing(context.Background(), createMockRequest(map[string]interface{}{"check_upstreams": "false"}))
+	text := extractTextContent(result)
+	if strings.Contains(text, "Upstream APIs (live request") || !strings.Contains(text, "Requests since start:") {
+		t.Errorf("Expected only the recorded upstream state without live requests, got: %s", text)
+	}
+}
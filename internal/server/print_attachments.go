@@ -0,0 +1,283 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// printAttachmentTypes recognizes kinds of print attachments by their file names, folded to lowercase without
+// diacritics
+var printAttachmentTypes = map[string]*regexp.Regexp{
+	// Regulatory impact assessments (ocena skutków regulacji, OSR)
+	"ria": regexp.MustCompile(`(^|[^a-z])(osr|ria)([^a-z]|$)|ocena[ _-]?skutkow`),
+	// Annexes (załączniki)
+	"annex": regexp.MustCompile(`(^|[^a-z])zal([^a-z]|$)|zalacznik`),
+	// Opinions of institutions and experts
+	"opinion": regexp.MustCompile(`opini`),
+	// Drafts of implementing regulations (projekty rozporządzeń)
+	"regulation_draft": regexp.MustCompile(`rozporzadz|(^|[^a-z])rozp([^a-z]|$)`),
+}
+
+// printAttachmentMatch is an attachment found by sejm_search_print_attachments with its download coordinates
+type printAttachmentMatch struct {
+	Print        string   `json:"print"`
+	Title        string   `json:"title"`
+	DocumentDate string   `json:"documentDate,omitempty"`
+	FileName     string   `json:"fileName"`
+	Extension    string   `json:"extension"`
+	Types        []string `json:"types,omitempty"`
+	URL          string   `json:"url"`
+}
+
+// printAttachmentFilter selects print attachments by type, extension and file name
+type printAttachmentFilter struct {
+	types        []string
+	extensions   map[string]bool
+	nameContains string
+}
+
+// attachmentTypes returns the known types a file name belongs to
+func attachmentTypes(fileName string) []string {
+	folded, _ := foldText(fileName, true)
+	var types []string
+	for name, pattern := range printAttachmentTypes {
+		if pattern.MatchString(folded) {
+			types = append(types, name)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// matches tells whether an attachment passes the filter; a file must have one of the requested types
+func (f printAttachmentFilter) matches(fileName string, types []string) bool {
+	if len(f.extensions) > 0 && !f.extensions[attachmentExtension(fileName)] {
+		return false
+	}
+	if f.nameContains != "" {
+		folded, _ := foldText(fileName, true)
+		if !strings.Contains(folded, f.nameContains) {
+			return false
+		}
+	}
+	if len(f.types) == 0 {
+		return true
+	}
+	for _, wanted := range f.types {
+		for _, found := range types {
+			if wanted == found {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// attachmentExtension returns the lowercase extension of a file name without the dot
+func attachmentExtension(fileName string) string {
+	return strings.TrimPrefix(strings.ToLower(path.Ext(fileName)), ".")
+}
+
+// parsePrintAttachmentFilter validates the filter parameters of sejm_search_print_attachments
+func parsePrintAttachmentFilter(typeList, extensionList, nameContains string) (printAttachmentFilter, error) {
+	filter := printAttachmentFilter{extensions: make(map[string]bool)}
+	known := make([]string, 0, len(printAttachmentTypes))
+	for name := range printAttachmentTypes {
+		known = append(known, name)
+	}
+	sort.Strings(known)
+	for _, name := range strings.Split(typeList, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if _, ok := printAttachmentTypes[name]; !ok {
+			return filter, fmt.Errorf("unknown attachment type '%s'. Use %s", name, strings.Join(known, ", "))
+		}
+		filter.types = append(filter.types, name)
+	}
+	for _, ext := range strings.Split(extensionList, ",") {
+		ext = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(ext)), ".")
+		if ext != "" {
+			filter.extensions[ext] = true
+		}
+	}
+	filter.nameContains, _ = foldText(strings.TrimSpace(nameContains), true)
+	if len(filter.types) == 0 && len(filter.extensions) == 0 && filter.nameContains == "" {
+		return filter, fmt.Errorf("set at least one of 'type', 'extension' or 'name_contains'")
+	}
+	return filter, nil
+}
+
+// findPrintAttachments lists the attachments of prints, and of their additional prints, that pass the filter,
+// newest prints first
+func (s *SejmServer) findPrintAttachments(prints []sejm.Print, term int, filter printAttachmentFilter, from, to time.Time) (matches []printAttachmentMatch, scanned int) {
+	var visit func(print sejm.Print, title string)
+	visit = func(print sejm.Print, title string) {
+		if print.Number == nil {
+			return
+		}
+		var documentDate time.Time
+		if print.DocumentDate != nil {
+			documentDate = print.DocumentDate.Time
+		}
+		if (!from.IsZero() || !to.IsZero()) && (documentDate.IsZero() || (!from.IsZero() && documentDate.Before(from)) || (!to.IsZero() && documentDate.After(to))) {
+			return
+		}
+		title = valueOrDefault(stringValue(print.Title), title)
+		scanned++
+		if print.Attachments != nil {
+			for _, fileName := range *print.Attachments {
+				types := attachmentTypes(fileName)
+				if !filter.matches(fileName, types) {
+					continue
+				}
+				match := printAttachmentMatch{
+					Print:     *print.Number,
+					Title:     truncateRunes(title, 150),
+					FileName:  fileName,
+					Extension: attachmentExtension(fileName),
+					Types:     types,
+					URL:       fmt.Sprintf("%s/sejm/term%d/prints/%s/%s", s.sejmBaseURL, term, *print.Number, fileName),
+				}
+				if !documentDate.IsZero() {
+					match.DocumentDate = documentDate.Format("2006-01-02")
+				}
+				matches = append(matches, match)
+			}
+		}
+		if print.AdditionalPrints != nil {
+			for _, additional := range *print.AdditionalPrints {
+				visit(additional, title)
+			}
+		}
+	}
+	for _, print := range prints {
+		visit(print, "")
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].DocumentDate > matches[j].DocumentDate
+	})
+	return matches, scanned
+}
+
+func (s *SejmServer) handleSearchPrintAttachments(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_search_print_attachments called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	filter, err := parsePrintAttachmentFilter(request.GetString("type", ""), request.GetString("extension", ""), request.GetString("name_contains", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid filter: %v.", err)), nil
+	}
+	from, err := parseDefectionDate("date_from", request.GetString("date_from", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	to, err := parseDefectionDate("date_to", request.GetString("date_to", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	page, err := parseListPage(request, 50)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid pagination: %v.", err)), nil
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/prints", s.sejmBaseURL, term), nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve prints of term %d: %v.", term, err)), nil
+	}
+	var prints []sejm.Print
+	if err := s.decodeAPIResponse(data, &prints); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse prints data: %v.", err)), nil
+	}
+	matches, scanned := s.findPrintAttachments(prints, term, filter, from, to)
+	start, end := page.bounds(len(matches))
+	shown := matches[start:end]
+
+	if format == "json" {
+		result, _ := json.MarshalIndent(map[string]interface{}{
+			"term":          term,
+			"printsScanned": scanned,
+			"total":         len(matches),
+			"offset":        page.offset,
+			"attachments":   shown,
+		}, "", "  ")
+		return mcp.NewToolResultText(string(result)), nil
+	}
+
+	byExtension := make(map[string]int)
+	printNumbers := make(map[string]bool)
+	for _, match := range matches {
+		byExtension[match.Extension]++
+		printNumbers[match.Print] = true
+	}
+	extensions := make([]string, 0, len(byExtension))
+	for ext, count := range byExtension {
+		extensions = append(extensions, fmt.Sprintf("%s: %d", valueOrDefault(ext, "none"), count))
+	}
+	sort.Strings(extensions)
+
+	summary := []string{
+		fmt.Sprintf("Term: %d, prints scanned: %d (with additional prints)", term, scanned),
+		fmt.Sprintf("Matching attachments: %d in %d prints", len(matches), len(printNumbers)),
+	}
+	if len(extensions) > 0 {
+		summary = append(summary, "By extension: "+strings.Join(extensions, ", "))
+	}
+	summary = append(summary, page.describe("attachments", len(shown), len(matches)))
+
+	var results []string
+	for _, match := range shown {
+		line := fmt.Sprintf("• Print %s", match.Print)
+		if match.DocumentDate != "" {
+			line += fmt.Sprintf(" (%s)", match.DocumentDate)
+		}
+		line += fmt.Sprintf(": %s", match.FileName)
+		if len(match.Types) > 0 {
+			line += fmt.Sprintf(" [%s]", strings.Join(match.Types, ", "))
+		}
+		results = append(results, line, "  "+match.Title, fmt.Sprintf("  term='%d', num='%s', attach_name='%s'", term, match.Print, match.FileName))
+	}
+	status := "Retrieved Successfully"
+	if len(matches) == 0 {
+		status = "No Results Found"
+		results = append(results, "No print attachment matches the filter. Try another type, an extension such as 'docx', or a wider date range.")
+	}
+
+	var call []string
+	for _, name := range []string{"type", "extension", "name_contains", "date_from", "date_to"} {
+		if value := request.GetString(name, ""); value != "" {
+			call = append(call, fmt.Sprintf("%s='%s'", name, value))
+		}
+	}
+	nextActions := []string{"Download or read an attachment: sejm_get_print_attachment with term, num and attach_name (extract_text='true' for its text)"}
+	nextActions = append(nextActions, page.navigation("sejm_search_print_attachments", strings.Join(call, ", "), end < len(matches))...)
+	nextActions = append(nextActions, "Structured output with download URLs: add format='json'")
+
+	response := StandardResponse{
+		Operation:   "Print Attachments",
+		Status:      status,
+		Summary:     summary,
+		Data:        results,
+		NextActions: nextActions,
+		Note:        "Attachment types are recognized by file name (e.g. 'OSR' for regulatory impact assessments, 'zał' for annexes), so an assessment included in the main print document is not found.",
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const printAttachmentsFixture = `[
+	{"number": "100", "title": "Rządowy projekt ustawy o drogach", "documentDate": "2024-03-01",
+	 "attachments": ["100.pdf", "100-OSR.docx", "100-zał. 1.pdf", "Opinia KRS.pdf"],
+	 "additionalPrints": [{"number": "100-A", "documentDate": "2024-03-10", "attachments": ["100-A.pdf", "100-A-ocena skutków.pdf"]}]},
+	{"number": "200", "title": "Poselski projekt ustawy o szkołach", "documentDate": "2024-01-15",
+	 "attachments": ["200.pdf", "projekt rozporządzenia.docx"]}
+]`
+
+func TestAttachmentTypes(t *testing.T) {
+	testCases := map[string]string{
+		"100-OSR.docx":                "ria",
+		"ocena_skutkow_regulacji.pdf": "ria",
+		"100-zał. 1.pdf":              "annex",
+		"Załącznik nr 2.docx":         "annex",
+		"Opinia SN.pdf":               "opinion",
+		"projekt rozporządzenia.docx": "regulation_draft",
+		"100.pdf":                     "",
+		"materiały.pdf":               "",
+	}
+	for fileName, expected := range testCases {
+		if got := strings.Join(attachmentTypes(fileName), ","); got != expected {
+			t.Errorf("attachmentTypes(%q) = %q, expected %q", fileName, got, expected)
+		}
+	}
+}
+
+func TestHandleSearchPrintAttachments(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{"/sejm/term10/prints": printAttachmentsFixture})
+
+	result, err := server.handleSearchPrintAttachments(context.Background(), createMockRequest(map[string]interface{}{"type": "ria", "format": "json"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	var response struct {
+		Total         int                    `json:"total"`
+		PrintsScanned int                    `json:"printsScanned"`
+		Attachments   []printAttachmentMatch `json:"attachments"`
+	}
+	if err := json.Unmarshal([]byte(extractTextContent(result)), &response); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if response.Total != 2 || response.PrintsScanned != 3 {
+		t.Fatalf("Expected 2 assessments in 3 prints, got %+v", response)
+	}
+	if first := response.Attachments[0]; first.Print != "100-A" || first.Title != "Rządowy projekt ustawy o drogach" || !strings.HasSuffix(first.URL, "/sejm/term10/prints/100-A/100-A-ocena skutków.pdf") {
+		t.Errorf("Expected the newest assessment first with the title of its main print, got %+v", first)
+	}
+
+	result, _ = server.handleSearchPrintAttachments(context.Background(), createMockRequest(map[string]interface{}{"extension": "docx", "date_to": "2024-02-01"}))
+	text := extractTextContent(result)
+	if !strings.Contains(text, "Matching attachments: 1 in 1 prints") || !strings.Contains(text, "attach_name='projekt rozporządzenia.docx'") {
+		t.Errorf("Expected the docx of print 200 only, got: %s", text)
+	}
+
+	result, _ = server.handleSearchPrintAttachments(context.Background(), createMockRequest(map[string]interface{}{}))
+	if !result.IsError {
+		t.Error("Expected a search without filters to be rejected")
+	}
+	result, _ = server.handleSearchPrintAttachments(context.Background(), createMockRequest(map[string]interface{}{"type": "memo"}))
+	if !result.IsError || !strings.Contains(extractTextContent(result), "annex, opinion, regulation_draft, ria") {
+		t.Errorf("Expected an unknown type to list the known ones, got: %s", extractTextContent(result))
+	}
+}
@@ -0,0 +1,389 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxGraphPrints caps the number of linked prints whose details are downloaded for one graph
+const maxGraphPrints = 25
+
+// Relations between prints in the document graph
+const (
+	printRelationAdditional = "additional print"
+	printRelationAssociated = "associated with"
+	printRelationProcess    = "part of process"
+	printRelationJoint      = "considered jointly"
+	printRelationReport     = "committee report"
+)
+
+// printGraphNode is a print in the document graph
+type printGraphNode struct {
+	Number  string   `json:"number"`
+	Title   string   `json:"title,omitempty"`
+	Date    string   `json:"date,omitempty"`
+	Roles   []string `json:"roles"`
+	Fetched bool     `json:"-"`
+}
+
+// printGraphEdge links two prints; From is the print the relation is stated for
+type printGraphEdge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+	Stage    string `json:"stage,omitempty"`
+}
+
+// printGraphStage is a legislative process stage with the print and committee fields the API sends for some stages
+type printGraphStage struct {
+	StageName     string            `json:"stageName,omitempty"`
+	Date          string            `json:"date,omitempty"`
+	PrintNumber   string            `json:"printNumber,omitempty"`
+	CommitteeCode string            `json:"committeeCode,omitempty"`
+	Decision      string            `json:"decision,omitempty"`
+	Children      []printGraphStage `json:"children,omitempty"`
+}
+
+// printGraph is the network of prints and process stages around a print
+type printGraph struct {
+	Term         int               `json:"term"`
+	Print        string            `json:"print"`
+	Process      string            `json:"process,omitempty"`
+	ProcessTitle string            `json:"processTitle,omitempty"`
+	Passed       bool              `json:"passed,omitempty"`
+	Nodes        []*printGraphNode `json:"nodes"`
+	Edges        []printGraphEdge  `json:"edges"`
+	Stages       []printGraphStage `json:"stages,omitempty"`
+	Truncated    bool              `json:"truncated,omitempty"`
+
+	nodes map[string]*printGraphNode
+	edges map[printGraphEdge]bool
+}
+
+func newPrintGraph(term int, number string) *printGraph {
+	return &printGraph{Term: term, Print: number, nodes: make(map[string]*printGraphNode), edges: make(map[printGraphEdge]bool)}
+}
+
+// node returns the node of a print, creating it if needed, and records its role
+func (g *printGraph) node(number, role string) *printGraphNode {
+	n, ok := g.nodes[number]
+	if !ok {
+		n = &printGraphNode{Number: number}
+		g.nodes[number] = n
+		g.Nodes = append(g.Nodes, n)
+	}
+	if role != "" {
+		for _, existing := range n.Roles {
+			if existing == role {
+				return n
+			}
+		}
+		n.Roles = append(n.Roles, role)
+	}
+	return n
+}
+
+// link records a relation between two prints once
+func (g *printGraph) link(from, to, relation, stage string) {
+	if from == "" || to == "" || from == to {
+		return
+	}
+	edge := printGraphEdge{From: from, To: to, Relation: relation, Stage: stage}
+	if g.edges[edge] {
+		return
+	}
+	g.edges[edge] = true
+	g.Edges = append(g.Edges, edge)
+}
+
+// addPrint records a print's own details and the relations stated in them
+func (g *printGraph) addPrint(p sejm.Print, role string) {
+	if p.Number == nil {
+		return
+	}
+	number := *p.Number
+	n := g.node(number, role)
+	n.Fetched = true
+	if p.Title != nil {
+		n.Title = *p.Title
+	}
+	if p.DocumentDate != nil {
+		n.Date = p.DocumentDate.Format("2006-01-02")
+	} else if p.DeliveryDate != nil {
+		n.Date = p.DeliveryDate.Format("2006-01-02")
+	}
+
+	if p.AdditionalPrints != nil {
+		for _, additional := range *p.AdditionalPrints {
+			if additional.Number == nil {
+				continue
+			}
+			g.addPrint(additional, printRelationAdditional)
+			g.link(number, *additional.Number, printRelationAdditional, "")
+		}
+	}
+	if p.NumberAssociated != nil {
+		for _, associated := range *p.NumberAssociated {
+			g.node(associated, printRelationAssociated)
+			g.link(number, associated, printRelationAssociated, "")
+		}
+	}
+	if p.ProcessPrint != nil {
+		for _, processPrint := range *p.ProcessPrint {
+			g.node(processPrint, "process print")
+			g.link(number, processPrint, printRelationProcess, "")
+		}
+	}
+}
+
+// addStages records the prints referenced by process stages as committee reports of the process print
+func (g *printGraph) addStages(stages []printGraphStage) {
+	for _, stage := range stages {
+		if stage.PrintNumber != "" {
+			g.node(stage.PrintNumber, printRelationReport)
+			g.link(stage.PrintNumber, g.Process, printRelationReport, stage.StageName)
+		}
+		g.addStages(stage.Children)
+	}
+}
+
+// unfetched returns the numbers of linked prints whose details are not known yet, in print number order
+func (g *printGraph) unfetched() []string {
+	var numbers []string
+	for _, n := range g.Nodes {
+		if !n.Fetched {
+			numbers = append(numbers, n.Number)
+		}
+	}
+	sort.Strings(numbers)
+	return numbers
+}
+
+// fetchPrint downloads a print's details
+func (s *SejmServer) fetchPrint(ctx context.Context, term int, number string) (sejm.Print, error) {
+	var p sejm.Print
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/prints/%s", s.sejmBaseURL, term, number), nil)
+	if err != nil {
+		return p, err
+	}
+	if err := s.decodeAPIResponse(data, &p); err != nil {
+		return p, fmt.Errorf("failed to parse print %s: %w", number, err)
+	}
+	return p, nil
+}
+
+// buildPrintGraph assembles the prints linked to a print and the stages of its legislative process
+func (s *SejmServer) buildPrintGraph(ctx context.Context, term int, number string) (*printGraph, error) {
+	root, err := s.fetchPrint(ctx, term, number)
+	if err != nil {
+		return nil, err
+	}
+	graph := newPrintGraph(term, number)
+	graph.addPrint(root, "requested print")
+
+	// Processes are numbered after the print that started them
+	graph.Process = number
+	if root.ProcessPrint != nil && len(*root.ProcessPrint) > 0 {
+		graph.Process = (*root.ProcessPrint)[0]
+	}
+
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/processes/%s", s.sejmBaseURL, term, graph.Process), nil)
+	if err == nil {
+		var process struct {
+			Title                   string            `json:"title"`
+			Passed                  bool              `json:"passed"`
+			PrintsConsideredJointly []string          `json:"printsConsideredJointly"`
+			Stages                  []printGraphStage `json:"stages"`
+		}
+		if err := s.decodeAPIResponse(data, &process); err == nil {
+			graph.ProcessTitle = process.Title
+			graph.Passed = process.Passed
+			graph.Stages = process.Stages
+			graph.node(graph.Process, "process print")
+			for _, joint := range process.PrintsConsideredJointly {
+				graph.node(joint, printRelationJoint)
+				graph.link(joint, graph.Process, printRelationJoint, "")
+			}
+			graph.addStages(process.Stages)
+		}
+	} else {
+		// Prints outside the legislative procedure (e.g., resolutions on reports) have no process
+		s.logger.Debug("No legislative process for print", slog.String("print", number), slog.Any("error", err))
+		graph.Process = ""
+	}
+
+	fetched := 0
+	for {
+		pending := graph.unfetched()
+		if len(pending) == 0 {
+			break
+		}
+		for _, linked := range pending {
+			if fetched >= maxGraphPrints {
+				graph.Truncated = true
+				break
+			}
+			fetched++
+			p, err := s.fetchPrint(ctx, term, linked)
+			graph.nodes[linked].Fetched = true
+			if err != nil {
+				s.logger.Warn("Skipping linked print", slog.String("print", linked), slog.Any("error", err))
+				continue
+			}
+			graph.addPrint(p, "")
+		}
+		if graph.Truncated {
+			break
+		}
+	}
+
+	sort.SliceStable(graph.Nodes, func(i, j int) bool {
+		return comparePrintNumbers(graph.Nodes[i].Number, graph.Nodes[j].Number)
+	})
+	return graph, nil
+}
+
+// comparePrintNumbers orders print numbers numerically, with suffixed prints ('100-A') after their base print
+func comparePrintNumbers(a, b string) bool {
+	var baseA, baseB int
+	fmt.Sscanf(a, "%d", &baseA)
+	fmt.Sscanf(b, "%d", &baseB)
+	if baseA != baseB {
+		return baseA < baseB
+	}
+	return a < b
+}
+
+// formatPrintGraphStages renders the stage tree with the prints and committees it references
+func formatPrintGraphStages(stages []printGraphStage, depth int) []string {
+	var lines []string
+	for _, stage := range stages {
+		line := strings.Repeat("  ", depth) + "• " + stage.StageName
+		if stage.StageName == "" {
+			line += "Unknown stage"
+		}
+		if stage.Date != "" {
+			line += fmt.Sprintf(" (%s)", stage.Date)
+		}
+		if stage.CommitteeCode != "" {
+			line += fmt.Sprintf(" [committee %s]", stage.CommitteeCode)
+		}
+		if stage.Decision != "" {
+			line += fmt.Sprintf(" — %s", stage.Decision)
+		}
+		if stage.PrintNumber != "" {
+			line += fmt.Sprintf(" → print %s", stage.PrintNumber)
+		}
+		lines = append(lines, line)
+		lines = append(lines, formatPrintGraphStages(stage.Children, depth+1)...)
+	}
+	return lines
+}
+
+// formatPrintGraph renders the graph as a print list, a link list and the stage tree
+func formatPrintGraph(graph *printGraph) []string {
+	var data []string
+	data = append(data, fmt.Sprintf("Prints (%d):", len(graph.Nodes)))
+	for _, n := range graph.Nodes {
+		line := fmt.Sprintf("  • Print %s", n.Number)
+		if n.Date != "" {
+			line += fmt.Sprintf(" (%s)", n.Date)
+		}
+		if n.Title != "" {
+			line += ": " + n.Title
+		}
+		if len(n.Roles) > 0 {
+			line += fmt.Sprintf(" [%s]", strings.Join(n.Roles, ", "))
+		}
+		data = append(data, line)
+	}
+
+	if len(graph.Edges) > 0 {
+		data = append(data, "", "Links:")
+		for _, edge := range graph.Edges {
+			line := fmt.Sprintf("  • %s —%s→ %s", edge.From, edge.Relation, edge.To)
+			if edge.Stage != "" {
+				line += fmt.Sprintf(" (stage: %s)", edge.Stage)
+			}
+			data = append(data, line)
+		}
+	}
+
+	if len(graph.Stages) > 0 {
+		data = append(data, "", fmt.Sprintf("Process %s stages:", graph.Process))
+		data = append(data, formatPrintGraphStages(graph.Stages, 1)...)
+	}
+	return data
+}
+
+func (s *SejmServer) handleGetPrintGraph(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_print_graph called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	num := strings.TrimSpace(request.GetString("num", ""))
+	if num == "" {
+		return mcp.NewToolResultError("Parameter 'num' is required. Get print numbers from sejm_get_prints results."), nil
+	}
+	format := strings.ToLower(request.GetString("format", "text"))
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	graph, err := s.buildPrintGraph(ctx, term, num)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve print %s: %v. Please verify the print exists in term %d.", num, err, term)), nil
+	}
+
+	if format == "json" {
+		output, _ := json.MarshalIndent(graph, "", "  ")
+		return mcp.NewToolResultText(string(output)), nil
+	}
+
+	summary := []string{fmt.Sprintf("Print %s (term %d)", num, term)}
+	if graph.Process != "" {
+		status := "in progress"
+		if graph.Passed {
+			status = "passed"
+		}
+		summary = append(summary, fmt.Sprintf("Legislative process: %s (%s)", graph.Process, status))
+		if graph.ProcessTitle != "" {
+			summary = append(summary, fmt.Sprintf("Process title: %s", graph.ProcessTitle))
+		}
+	} else {
+		summary = append(summary, "Legislative process: none found for this print")
+	}
+	summary = append(summary, fmt.Sprintf("Linked prints: %d, links: %d", len(graph.Nodes), len(graph.Edges)))
+
+	note := "Links come from the print's additional and associated prints, the process's jointly considered prints and the prints referenced by process stages (committee reports). Arrows point from the print the relation is stated for."
+	if graph.Truncated {
+		note += fmt.Sprintf(" Only %d linked prints were downloaded; prints without titles were not fetched.", maxGraphPrints)
+	}
+
+	nextActions := []string{
+		fmt.Sprintf("Read a linked print: sejm_get_print_text with term='%d' and num", term),
+	}
+	if graph.Process != "" {
+		nextActions = append(nextActions, fmt.Sprintf("Full process record: sejm_get_process_details with process_number='%s'", graph.Process))
+	}
+	nextActions = append(nextActions, "Get the graph as nodes and edges: format='json'")
+
+	response := StandardResponse{
+		Operation:   "Print Document Graph",
+		Status:      "Retrieved Successfully",
+		Summary:     summary,
+		Data:        formatPrintGraph(graph),
+		NextActions: nextActions,
+		Note:        note,
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
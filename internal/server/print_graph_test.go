@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func testPrintGraphServer(t *testing.T) *SejmServer {
+	return newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/prints/100": `{"number": "100", "title": "Rządowy projekt ustawy o zmianie ustawy o podatku", "documentDate": "2024-01-10",
+			"additionalPrints": [{"number": "100-A", "title": "Autopoprawka do projektu", "documentDate": "2024-01-20"}]}`,
+		"/sejm/term10/prints/120": `{"number": "120", "title": "Poselski projekt ustawy o zmianie ustawy o podatku", "documentDate": "2024-01-15"}`,
+		"/sejm/term10/prints/200": `{"number": "200", "title": "Sprawozdanie Komisji Finansów Publicznych", "documentDate": "2024-02-01", "processPrint": ["100"], "numberAssociated": ["100", "120"]}`,
+		"/sejm/term10/processes/100": `{"number": "100", "title": "Rządowy projekt ustawy o zmianie ustawy o podatku", "passed": true,
+			"printsConsideredJointly": ["120"],
+			"stages": [
+				{"stageName": "Projekt ustawy", "date": "2024-01-10"},
+				{"stageName": "Praca w komisjach po I czytaniu", "children": [
+					{"stageName": "Posiedzenie komisji", "date": "2024-01-25", "committeeCode": "FPB"},
+					{"stageName": "Sprawozdanie komisji", "date": "2024-02-01", "printNumber": "200"}
+				]}
+			]}`,
+	})
+}
+
+func TestHandleGetPrintGraph(t *testing.T) {
+	server := testPrintGraphServer(t)
+
+	result, err := server.handleGetPrintGraph(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "num": "200",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Legislative process: 100 (passed)",
+		"Linked prints: 4",
+		"Print 100-A (2024-01-20): Autopoprawka do projektu [additional print]",
+		"Print 120 (2024-01-15): Poselski projekt ustawy o zmianie ustawy o podatku [associated with, considered jointly]",
+		"Print 200 (2024-02-01): Sprawozdanie Komisji Finansów Publicznych [requested print, committee report]",
+		"120 —considered jointly→ 100",
+		"200 —committee report→ 100 (stage: Sprawozdanie komisji)",
+		"• Posiedzenie komisji (2024-01-25) [committee FPB]",
+		"• Sprawozdanie komisji (2024-02-01) → print 200",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected %q in output, got:\n%s", expected, content)
+		}
+	}
+}
+
+func TestHandleGetPrintGraphJSON(t *testing.T) {
+	server := testPrintGraphServer(t)
+
+	result, err := server.handleGetPrintGraph(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "num": "100", "format": "json",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	var graph printGraph
+	if err := json.Unmarshal([]byte(extractTextContent(result)), &graph); err != nil {
+		t.Fatalf("Expected JSON output: %v", err)
+	}
+	if graph.Process != "100" || len(graph.Nodes) != 4 || len(graph.Stages) != 2 {
+		t.Errorf("Unexpected graph: %+v", graph)
+	}
+	if graph.Nodes[0].Number != "100" || graph.Nodes[1].Number != "100-A" {
+		t.Errorf("Expected prints ordered by number, got %+v", graph.Nodes)
+	}
+}
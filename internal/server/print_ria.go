@@ -0,0 +1,305 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxRIAScannedAttachments limits how many attachments are downloaded while looking for the OSR section
+const maxRIAScannedAttachments = 4
+
+// riaHeadingPattern finds the heading of the OSR form in text folded to lowercase without diacritics
+var riaHeadingPattern = regexp.MustCompile(`ocena\s+skutkow\s+regulacji`)
+
+// riaSectionLinePattern matches the numbered section headings of the OSR form, e.g. "6. Wpływ na sektor finansów"
+var riaSectionLinePattern = regexp.MustCompile(`^\s*(\d{1,2})\.\s+\S`)
+
+// riaSection is one numbered section of the standard OSR form
+type riaSection struct {
+	Number   int    `json:"number"`
+	Title    string `json:"title"`
+	Text     string `json:"text"`
+	KeyTable bool   `json:"keyTable,omitempty"`
+}
+
+// riaSectionTitles names the sections of the OSR form by number; keywords identify their headings in folded text
+var riaSectionTitles = []struct {
+	number  int
+	keyword string
+	title   string
+	table   bool
+}{
+	{1, "problem", "Problem to be solved", false},
+	{2, "rekomendowane rozwiazanie", "Recommended solution and expected effect", false},
+	{3, "innych krajach", "Solutions in other countries", false},
+	{4, "podmioty", "Affected entities", false},
+	{5, "konsultacji", "Consultations", false},
+	{6, "finansow publicznych", "Impact on public finances", true},
+	{7, "konkurencyjnosc", "Impact on competitiveness, businesses, families and citizens", true},
+	{8, "obciazen regulacyjnych", "Change of regulatory burdens", false},
+	{9, "rynek pracy", "Impact on the labour market", false},
+	{10, "pozostale obszary", "Impact on other areas", false},
+	{11, "planowane wykonanie", "Planned implementation", false},
+	{12, "ewaluacja", "Evaluation of the effects", false},
+	{13, "zalacznik", "Annexes", false},
+}
+
+// locateRIA returns the OSR part of an attachment's text. The heading must stand on its own line, since the
+// justification of a bill usually mentions the assessment in running text before the form itself. Attachments
+// named as an OSR are returned whole when the heading cannot be found.
+func locateRIA(text string, namedRIA bool) (string, bool) {
+	folded, offsets := foldText(text, true)
+	for _, match := range riaHeadingPattern.FindAllStringIndex(folded, -1) {
+		lineStart := strings.LastIndex(folded[:match[0]], "\n") + 1
+		lineEnd := len(folded)
+		if end := strings.Index(folded[match[1]:], "\n"); end >= 0 {
+			lineEnd = match[1] + end
+		}
+		before := strings.TrimSpace(folded[lineStart:match[0]])
+		if len(before) <= 3 && lineEnd-lineStart <= 80 {
+			return strings.TrimSpace(text[offsets[lineStart]:]), true
+		}
+	}
+	if namedRIA {
+		return text, true
+	}
+	return "", false
+}
+
+// splitRIASections divides OSR text into the numbered sections of the form, in order; text before the first
+// recognized section (the form header with the title, the ministry and contact details) is returned as header
+func splitRIASections(text string) (header string, sections []riaSection) {
+	lines := strings.Split(text, "\n")
+	next := 0
+	var current *riaSection
+	var body []string
+	flush := func() {
+		if current != nil {
+			current.Text = strings.TrimSpace(strings.Join(body, "\n"))
+			sections = append(sections, *current)
+		} else {
+			header = strings.TrimSpace(strings.Join(body, "\n"))
+		}
+		body = nil
+	}
+	for _, line := range lines {
+		if match := riaSectionLinePattern.FindStringSubmatch(line); match != nil && next < len(riaSectionTitles) {
+			number, _ := strconv.Atoi(match[1])
+			folded, _ := foldText(line, true)
+			// Sections may be missing from shortened forms, so any later section can follow
+			for i := next; i < len(riaSectionTitles); i++ {
+				known := riaSectionTitles[i]
+				if known.number == number && strings.Contains(folded, known.keyword) {
+					flush()
+					current = &riaSection{Number: known.number, Title: known.title, KeyTable: known.table}
+					next = i + 1
+					break
+				}
+			}
+		}
+		body = append(body, line)
+	}
+	flush()
+	return header, sections
+}
+
+// printRIA is the OSR found in one attachment of a print
+type printRIA struct {
+	Attachment string
+	Header     string
+	Sections   []riaSection
+	Text       string
+}
+
+// riaCandidates orders the attachments to search: those named as an OSR first, then other documents in the
+// order of preference of their formats
+func riaCandidates(attachments []string) []string {
+	var named, others []string
+	for _, name := range attachments {
+		if documentFormatFromName(name) != documentFormatUnknown && containsString(attachmentTypes(name), "ria") {
+			named = append(named, name)
+		}
+	}
+	for _, format := range supportedDocumentFormats {
+		for _, name := range attachments {
+			if documentFormatFromName(name) == format && !containsString(named, name) {
+				others = append(others, name)
+			}
+		}
+	}
+	return append(named, others...)
+}
+
+// findPrintRIA downloads the candidate attachments of a print until one holds the OSR; scanned lists the
+// attachments that were read, and failures those that could not be downloaded or converted to text
+func (s *SejmServer) findPrintRIA(ctx context.Context, term int, num string, attachments []string) (ria *printRIA, scanned, failures []string) {
+	candidates := riaCandidates(attachments)
+	if len(candidates) > maxRIAScannedAttachments {
+		candidates = candidates[:maxRIAScannedAttachments]
+	}
+	for _, name := range candidates {
+		if ctx.Err() != nil {
+			break
+		}
+		endpoint := fmt.Sprintf("%s/sejm/term%d/prints/%s/%s", s.sejmBaseURL, term, num, name)
+		data, err := s.makeAPIRequestWithHeaders(ctx, endpoint, nil, map[string]string{"Accept": "*/*"})
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		text, _, err := s.extractAttachmentText(ctx, name, data)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		scanned = append(scanned, name)
+		if section, ok := locateRIA(text, containsString(attachmentTypes(name), "ria")); ok {
+			header, sections := splitRIASections(section)
+			return &printRIA{Attachment: name, Header: header, Sections: sections, Text: section}, scanned, failures
+		}
+	}
+	return nil, scanned, failures
+}
+
+func (s *SejmServer) handleGetPrintRIA(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_print_ria called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	num := request.GetString("num", "")
+	if num == "" {
+		return mcp.NewToolResultError("Parameter 'num' is required. Get print numbers from sejm_get_prints results."), nil
+	}
+	sectionNumber := 0
+	if value := request.GetString("section", ""); value != "" {
+		sectionNumber, err = strconv.Atoi(value)
+		if err != nil || sectionNumber < 1 || sectionNumber > len(riaSectionTitles) {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid section '%s': use a section number of the OSR form from 1 to %d (e.g. '6' for the impact on public finances).", value, len(riaSectionTitles))), nil
+		}
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/prints/%s", s.sejmBaseURL, term, num), nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve details of print #%s: %v", num, err)), nil
+	}
+	var printData sejm.Print
+	if err := s.decodeAPIResponse(data, &printData); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse details of print #%s: %v", num, err)), nil
+	}
+	var attachments []string
+	if printData.Attachments != nil {
+		attachments = *printData.Attachments
+	}
+	if len(attachments) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Print #%s has no attachments, so it has no regulatory impact assessment to extract.", num)), nil
+	}
+
+	ria, scanned, failures := s.findPrintRIA(ctx, term, num, attachments)
+	if ria == nil {
+		message := fmt.Sprintf("No regulatory impact assessment (Ocena Skutków Regulacji) was found in print #%s.", num)
+		if len(scanned) > 0 {
+			message += fmt.Sprintf(" Searched: %s.", strings.Join(scanned, ", "))
+		}
+		if len(failures) > 0 {
+			message += fmt.Sprintf(" Could not read: %s.", strings.Join(failures, "; "))
+		}
+		message += " Bills submitted by MPs, committees or citizens usually come without an OSR; government bills attach it to the justification. Check the attachments with sejm_get_print_details."
+		return mcp.NewToolResultError(message), nil
+	}
+
+	sections := ria.Sections
+	if sectionNumber > 0 {
+		sections = nil
+		for _, section := range ria.Sections {
+			if section.Number == sectionNumber {
+				sections = append(sections, section)
+			}
+		}
+		if len(sections) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Section %d was not found in the OSR of print #%s (attachment %s). Call without 'section' to see the sections that were recognized.", sectionNumber, num, ria.Attachment)), nil
+		}
+	}
+
+	if format == "json" {
+		result := map[string]interface{}{
+			"term":       term,
+			"print":      num,
+			"title":      stringValue(printData.Title),
+			"attachment": ria.Attachment,
+			"sections":   sections,
+		}
+		if sectionNumber == 0 {
+			result["header"] = ria.Header
+			if len(ria.Sections) == 0 {
+				result["text"] = ria.Text
+			}
+		}
+		out, _ := json.MarshalIndent(result, "", "  ")
+		return mcp.NewToolResultText(string(out)), nil
+	}
+
+	summary := []string{
+		fmt.Sprintf("Print #%s (Term %d): %s", num, term, stringValue(printData.Title)),
+		fmt.Sprintf("OSR found in attachment: %s", ria.Attachment),
+	}
+	var recognized []string
+	for _, section := range ria.Sections {
+		recognized = append(recognized, strconv.Itoa(section.Number))
+	}
+	if len(recognized) > 0 {
+		summary = append(summary, fmt.Sprintf("Sections recognized: %s of %d", strings.Join(recognized, ", "), len(riaSectionTitles)))
+	}
+
+	var results []string
+	switch {
+	case len(ria.Sections) == 0:
+		results = append(results, "The form's numbered sections could not be recognized; the full OSR text follows.", "", ria.Text)
+	default:
+		if sectionNumber == 0 && ria.Header != "" {
+			results = append(results, "Form header:", ria.Header, "")
+		}
+		var keyTables []string
+		for _, section := range sections {
+			results = append(results, fmt.Sprintf("Section %d. %s:", section.Number, section.Title), section.Text, "")
+			if section.KeyTable {
+				keyTables = append(keyTables, strconv.Itoa(section.Number))
+			}
+		}
+		if len(keyTables) > 0 {
+			summary = append(summary, fmt.Sprintf("Key tables (amounts by year): section %s", strings.Join(keyTables, " and ")))
+		}
+	}
+
+	nextActions := []string{
+		fmt.Sprintf("Public finance table only: sejm_get_print_ria with term='%d', num='%s', section='6'", term, num),
+		fmt.Sprintf("Whole attachment with pages: sejm_get_print_text with term='%d', num='%s', attach_name='%s'", term, num, ria.Attachment),
+		fmt.Sprintf("Legislative process of the bill: sejm_get_print_details with term='%d', num='%s'", term, num),
+	}
+	note := "The OSR is read from the attachment text, so table columns are flattened into lines. Figures in section 6 are usually in millions of PLN in current prices over 10 years."
+	if len(failures) > 0 {
+		note += fmt.Sprintf(" Some attachments could not be read: %s.", strings.Join(failures, "; "))
+	}
+	response := StandardResponse{
+		Operation:   "Regulatory Impact Assessment",
+		Status:      "Retrieved Successfully",
+		Summary:     summary,
+		Data:        results,
+		NextActions: nextActions,
+		Note:        note,
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const riaJustificationFixture = `UZASADNIENIE
+Projekt ustawy ma na celu uproszczenie procedur. Skutki opisano w ocenie skutków regulacji dołączonej do projektu.
+
+OCENA SKUTKÓW REGULACJI (OSR)
+Nazwa projektu: Ustawa o zmianie ustawy o drogach publicznych
+Ministerstwo Infrastruktury
+1. Jaki problem jest rozwiązywany?
+Długie procedury wydawania zezwoleń.
+2. Rekomendowane rozwiązanie, w tym planowane narzędzia interwencji, i oczekiwany efekt
+Skrócenie terminów do 14 dni.
+4. Podmioty, na które oddziałuje projekt
+Zarządcy dróg 380
+6. Wpływ na sektor finansów publicznych
+Dochody ogółem 0 0 0
+Wydatki ogółem 1,5 2,0 3,5
+7. Wpływ na konkurencyjność gospodarki i przedsiębiorczość, w tym funkcjonowanie przedsiębiorców oraz na rodzinę, obywateli i gospodarstwa domowe
+Duże przedsiębiorstwa 0,5 1,0
+`
+
+func TestHandleGetPrintRIA(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/prints/100":                      `{"number": "100", "title": "Rządowy projekt ustawy o drogach", "attachments": ["100.pdf.zip", "100-uzasadnienie.txt"]}`,
+		"/sejm/term10/prints/100/100-uzasadnienie.txt": riaJustificationFixture,
+		"/sejm/term10/prints/200":                      `{"number": "200", "title": "Poselski projekt", "attachments": ["200.txt"]}`,
+		"/sejm/term10/prints/200/200.txt":              "UZASADNIENIE\nProjekt nie wymaga oceny skutków regulacji.",
+	})
+
+	result, err := server.handleGetPrintRIA(context.Background(), createMockRequest(map[string]interface{}{"num": "100"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{
+		"OSR found in attachment: 100-uzasadnienie.txt",
+		"Sections recognized: 1, 2, 4, 6, 7 of 13",
+		"Key tables (amounts by year): section 6 and 7",
+		"Nazwa projektu: Ustawa o zmianie ustawy o drogach publicznych",
+		"Section 6. Impact on public finances:",
+		"Wydatki ogółem 1,5 2,0 3,5",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+	if strings.Contains(text, "Projekt ustawy ma na celu") {
+		t.Errorf("Expected the justification before the OSR heading to be left out:\n%s", text)
+	}
+
+	result, _ = server.handleGetPrintRIA(context.Background(), createMockRequest(map[string]interface{}{"num": "100", "section": "6"}))
+	text = extractTextContent(result)
+	if !strings.Contains(text, "Dochody ogółem") || strings.Contains(text, "Zarządcy dróg") {
+		t.Errorf("Expected only section 6:\n%s", text)
+	}
+
+	result, _ = server.handleGetPrintRIA(context.Background(), createMockRequest(map[string]interface{}{"num": "200"}))
+	if !result.IsError || !strings.Contains(extractTextContent(result), "Searched: 200.txt") {
+		t.Errorf("Expected a print without an OSR to be reported, got: %s", extractTextContent(result))
+	}
+}
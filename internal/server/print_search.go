@@ -0,0 +1,288 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// printSponsorPattern finds who submitted a bill in a normalized print title: "rządowy projekt", and in reports,
+// opinions and self-amendments the bill they concern, "o poselskim projekcie", "do rządowego projektu"
+var printSponsorPattern = regexp.MustCompile(`\b(rzadow|poselsk|senack|prezydenck|obywatelsk|komisyjn)\w*\s+projek|przedstawiony przez (prezydenta|senat)`)
+
+// printSponsors maps the stems matched by printSponsorPattern to the accepted values of the author filter
+var printSponsors = map[string]string{
+	"rzadow":     "government",
+	"poselsk":    "mps",
+	"senack":     "senate",
+	"senat":      "senate",
+	"prezydenck": "president",
+	"prezydenta": "president",
+	"obywatelsk": "citizens",
+	"komisyjn":   "committee",
+}
+
+// printSponsorKeys lists the accepted values of the author filter
+var printSponsorKeys = []string{"citizens", "committee", "government", "mps", "president", "senate"}
+
+// printSearchResult is a print matching sejm_search_prints
+type printSearchResult struct {
+	Number    string   `json:"number"`
+	Title     string   `json:"title"`
+	Type      string   `json:"type"`
+	TypeLabel string   `json:"typeLabel"`
+	Sponsors  []string `json:"sponsors,omitempty"`
+	Delivered string   `json:"deliveryDate,omitempty"`
+	Process   string   `json:"process,omitempty"`
+}
+
+// sponsorsOfPrint returns who submitted the bill a print is or concerns, as inferred from its title. A report
+// on bills considered jointly can name several sponsors.
+func sponsorsOfPrint(title string) []string {
+	var sponsors []string
+	for _, match := range printSponsorPattern.FindAllStringSubmatch(normalizePolish(title), -1) {
+		sponsor := printSponsors[match[1]+match[2]]
+		if !containsString(sponsors, sponsor) {
+			sponsors = append(sponsors, sponsor)
+		}
+	}
+	return sponsors
+}
+
+// parseKeyList splits a comma-separated filter and checks every value against the accepted ones
+func parseKeyList(name, value string, accepted []string) ([]string, error) {
+	var keys []string
+	for _, key := range strings.Split(strings.ToLower(value), ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if !containsString(accepted, key) {
+			return nil, fmt.Errorf("unknown %s '%s'. Use one of: %s", name, key, strings.Join(accepted, ", "))
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// searchPrints filters prints by title words, which must all occur in the title ignoring case and Polish
+// diacritics, type, sponsor and delivery date. Results are sorted by delivery date, newest first unless oldest.
+func searchPrints(prints []sejm.Print, words, types, sponsors []string, from, to time.Time, oldest bool) []printSearchResult {
+	var results []printSearchResult
+	for _, print := range prints {
+		title := stringValue(print.Title)
+		normalized := normalizePolish(title)
+		matched := true
+		for _, word := range words {
+			if !strings.Contains(normalized, word) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		key, label := classifyPrint(title)
+		if len(types) > 0 && !containsString(types, key) {
+			continue
+		}
+		printSponsors := sponsorsOfPrint(title)
+		if len(sponsors) > 0 {
+			found := false
+			for _, sponsor := range printSponsors {
+				found = found || containsString(sponsors, sponsor)
+			}
+			if !found {
+				continue
+			}
+		}
+		result := printSearchResult{
+			Number:    stringValue(print.Number),
+			Title:     valueOrDefault(title, "No title"),
+			Type:      key,
+			TypeLabel: label,
+			Sponsors:  printSponsors,
+		}
+		if print.DeliveryDate != nil {
+			if (!from.IsZero() && print.DeliveryDate.Time.Before(from)) || (!to.IsZero() && print.DeliveryDate.Time.After(to)) {
+				continue
+			}
+			result.Delivered = print.DeliveryDate.Format("2006-01-02")
+		} else if !from.IsZero() || !to.IsZero() {
+			continue
+		}
+		if print.ProcessPrint != nil && len(*print.ProcessPrint) > 0 && (*print.ProcessPrint)[0] != result.Number {
+			result.Process = (*print.ProcessPrint)[0]
+		}
+		results = append(results, result)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if oldest {
+			a, b = b, a
+		}
+		if a.Delivered != b.Delivered {
+			return a.Delivered > b.Delivered
+		}
+		return comparePrintNumbers(b.Number, a.Number)
+	})
+	return results
+}
+
+func (s *SejmServer) handleSearchPrints(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_search_prints called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	query := strings.TrimSpace(request.GetString("query", ""))
+	words := strings.Fields(normalizePolish(query))
+	types, err := parseKeyList("type", request.GetString("type", ""), printTypeKeys())
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid type filter: %v.", err)), nil
+	}
+	sponsors, err := parseKeyList("author", request.GetString("author", ""), printSponsorKeys)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid author filter: %v.", err)), nil
+	}
+	if len(words) == 0 && len(types) == 0 && len(sponsors) == 0 {
+		return mcp.NewToolResultError("Provide at least one of query, type or author. To page through all prints, use sejm_get_prints."), nil
+	}
+	from, err := parseDefectionDate("date_from", request.GetString("date_from", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	to, err := parseDefectionDate("date_to", request.GetString("date_to", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	order := strings.ToLower(request.GetString("sort", "newest"))
+	if order != "newest" && order != "oldest" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid sort '%s'. Use 'newest' or 'oldest'.", order)), nil
+	}
+	page, err := parseListPage(request, 30)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid pagination: %v.", err)), nil
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/prints", s.sejmBaseURL, term), nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve prints of term %d: %v.", term, err)), nil
+	}
+	var prints []sejm.Print
+	if err := s.decodeAPIResponse(data, &prints); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse prints data: %v.", err)), nil
+	}
+	matches := searchPrints(prints, words, types, sponsors, from, to, order == "oldest")
+	start, end := page.bounds(len(matches))
+	shown := matches[start:end]
+
+	if format == "json" {
+		out, _ := json.MarshalIndent(map[string]interface{}{
+			"term":    term,
+			"scanned": len(prints),
+			"total":   len(matches),
+			"offset":  page.offset,
+			"prints":  shown,
+		}, "", "  ")
+		return mcp.NewToolResultText(string(out)), nil
+	}
+
+	var filters []string
+	if query != "" {
+		filters = append(filters, fmt.Sprintf("title '%s'", query))
+	}
+	if len(types) > 0 {
+		filters = append(filters, "type "+strings.Join(types, "/"))
+	}
+	if len(sponsors) > 0 {
+		filters = append(filters, "author "+strings.Join(sponsors, "/"))
+	}
+	if !from.IsZero() || !to.IsZero() {
+		filters = append(filters, fmt.Sprintf("delivered %s to %s", valueOrDefault(request.GetString("date_from", ""), "start"), valueOrDefault(request.GetString("date_to", ""), "now")))
+	}
+	summary := []string{
+		fmt.Sprintf("Term %d: %d of %d prints match %s", term, len(matches), len(prints), strings.Join(filters, ", ")),
+	}
+	byType := make(map[string]int)
+	for _, match := range matches {
+		byType[match.TypeLabel]++
+	}
+	labels := make([]string, 0, len(byType))
+	for label := range byType {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if byType[labels[i]] != byType[labels[j]] {
+			return byType[labels[i]] > byType[labels[j]]
+		}
+		return labels[i] < labels[j]
+	})
+	var counts []string
+	for _, label := range labels {
+		counts = append(counts, fmt.Sprintf("%s %d", label, byType[label]))
+	}
+	if len(counts) > 0 {
+		summary = append(summary, "By type: "+strings.Join(counts, ", "))
+	}
+	summary = append(summary, page.describe("prints", len(shown), len(matches)))
+
+	var results []string
+	for _, match := range shown {
+		line := fmt.Sprintf("• Print %s", valueOrDefault(match.Number, "?"))
+		if match.Delivered != "" {
+			line += fmt.Sprintf(" (%s)", match.Delivered)
+		}
+		line += fmt.Sprintf(" [%s", match.TypeLabel)
+		if len(match.Sponsors) > 0 {
+			line += "; author: " + strings.Join(match.Sponsors, ", ")
+		}
+		line += "]: " + truncateRunes(match.Title, 250)
+		if match.Process != "" {
+			line += fmt.Sprintf(" (process %s)", match.Process)
+		}
+		results = append(results, line)
+	}
+	status := "Retrieved Successfully"
+	if len(matches) == 0 {
+		status = "No Results Found"
+		results = append(results, "No print matches. Title words are matched as fragments, so use word stems such as 'mieszkani' to find 'mieszkaniowy' and 'mieszkaniach'.")
+	}
+
+	var call []string
+	for _, name := range []string{"query", "type", "author", "date_from", "date_to", "sort"} {
+		if value := request.GetString(name, ""); value != "" {
+			call = append(call, fmt.Sprintf("%s='%s'", name, value))
+		}
+	}
+	var nextActions []string
+	if len(shown) > 0 {
+		nextActions = append(nextActions,
+			fmt.Sprintf("Print details: sejm_get_print_details with term='%d', num='%s'", term, shown[0].Number),
+			fmt.Sprintf("Print text, including the signatories of MPs' bills: sejm_get_print_text with term='%d', num='%s'", term, shown[0].Number))
+	}
+	nextActions = append(nextActions, page.navigation("sejm_search_prints", strings.Join(call, ", "), end < len(matches))...)
+
+	response := StandardResponse{
+		Operation:   "Print Search",
+		Status:      status,
+		Summary:     summary,
+		Data:        results,
+		NextActions: nextActions,
+		Note:        "Types and authors are inferred from the titles: 'Rządowy projekt' is a government bill, and a report 'o poselskim projekcie' concerns an MPs' bill. The print data does not name the MPs or the club behind an MPs' bill; they sign its text, which sejm_get_print_text returns.",
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
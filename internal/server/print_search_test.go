@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const printSearchFixture = `[
+	{"number": "100", "title": "Rządowy projekt ustawy o zmianie ustawy o spółdzielniach mieszkaniowych", "deliveryDate": "2024-02-01", "processPrint": ["100"]},
+	{"number": "120", "title": "Poselski projekt ustawy o najmie mieszkań", "deliveryDate": "2024-02-10", "processPrint": ["120"]},
+	{"number": "150", "title": "Sprawozdanie Komisji Infrastruktury o rządowym projekcie ustawy o zmianie ustawy o spółdzielniach mieszkaniowych", "deliveryDate": "2024-03-05", "processPrint": ["100"]},
+	{"number": "160", "title": "Rządowy projekt ustawy o podatku dochodowym", "deliveryDate": "2024-03-05"},
+	{"number": "170", "title": "Projekt ustawy o zmianie ustawy o Trybunale Stanu przedstawiony przez Prezydenta", "deliveryDate": "2024-04-01"}
+]`
+
+func TestSponsorsOfPrint(t *testing.T) {
+	for title, expected := range map[string]string{
+		"Rządowy projekt ustawy o podatku":                                               "government",
+		"Sprawozdanie Komisji o poselskim projekcie ustawy":                              "mps",
+		"Autopoprawka do rządowego projektu ustawy":                                      "government",
+		"Sprawozdanie Komisji o rządowym projekcie ustawy oraz o senackim projekcie ...": "government,senate",
+		"Projekt ustawy przedstawiony przez Senat":                                       "senate",
+		"Informacja o działalności Rzecznika Praw Obywatelskich":                         "",
+	} {
+		if got := strings.Join(sponsorsOfPrint(title), ","); got != expected {
+			t.Errorf("sponsorsOfPrint(%q) = %q, expected %q", title, got, expected)
+		}
+	}
+}
+
+func TestHandleSearchPrints(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{"/sejm/term10/prints": printSearchFixture})
+	call := func(arguments map[string]interface{}) string {
+		result, err := server.handleSearchPrints(context.Background(), createMockRequest(arguments))
+		if err != nil || result.IsError {
+			t.Fatalf("Unexpected error for %v: %v %s", arguments, err, extractTextContent(result))
+		}
+		return extractTextContent(result)
+	}
+
+	output := call(map[string]interface{}{"term": "10", "query": "Mieszkań", "author": "government"})
+	for _, expected := range []string{
+		"Term 10: 2 of 5 prints match title 'Mieszkań', author government",
+		"By type: committee report 1, government bill 1",
+		"• Print 150 (2024-03-05) [committee report; author: government]: Sprawozdanie Komisji Infrastruktury",
+		"(process 100)",
+		"• Print 100 (2024-02-01) [government bill; author: government]",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output, got: %s", expected, output)
+		}
+	}
+	if strings.Contains(output, "Print 120") || strings.Index(output, "Print 150") > strings.Index(output, "Print 100") {
+		t.Errorf("Expected only government prints, newest first, got: %s", output)
+	}
+
+	output = call(map[string]interface{}{"term": "10", "type": "government_bill,presidential_bill", "date_from": "2024-03-01", "sort": "oldest", "format": "json"})
+	var found struct {
+		Total  int                 `json:"total"`
+		Prints []printSearchResult `json:"prints"`
+	}
+	if err := json.Unmarshal([]byte(output), &found); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if found.Total != 2 || found.Prints[0].Number != "160" || found.Prints[1].Sponsors[0] != "president" {
+		t.Errorf("Unexpected bills delivered from March: %+v", found)
+	}
+
+	for _, arguments := range []map[string]interface{}{
+		{"term": "10"},
+		{"term": "10", "author": "club"},
+		{"term": "10", "type": "bill"},
+	} {
+		if result, _ := server.handleSearchPrints(context.Background(), createMockRequest(arguments)); !result.IsError {
+			t.Errorf("Expected %v to be rejected, got: %s", arguments, extractTextContent(result))
+		}
+	}
+}
@@ -0,0 +1,354 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Limits and defaults of sejm_track_process_across_terms
+const (
+	defaultLineageFromTerm      = 7
+	defaultLineageMinSimilarity = 0.5
+	maxLineageProcessesPerTerm  = 200
+	maxListedLineages           = 15
+)
+
+// processTitleBoilerplate are the words every bill title shares, such as the sponsor and "projekt ustawy o
+// zmianie ustawy", which would make unrelated bills look alike
+var processTitleBoilerplate = map[string]bool{
+	"projekt": true, "ustawy": true, "ustawa": true, "uchwały": true, "uchwała": true, "zmianie": true,
+	"niektórych": true, "innych": true, "ustaw": true, "rządowy": true, "poselski": true, "senacki": true,
+	"obywatelski": true, "prezydencki": true, "komisyjny": true, "sprawie": true, "dnia": true,
+}
+
+// lineageProcess is a process of one term and the process of an earlier term it continues
+type lineageProcess struct {
+	Term        int                 `json:"term"`
+	Number      string              `json:"number"`
+	Title       string              `json:"title"`
+	StartDate   string              `json:"startDate,omitempty"`
+	Passed      bool                `json:"passed"`
+	ELI         string              `json:"eli,omitempty"`
+	Predecessor *lineagePredecessor `json:"predecessor,omitempty"`
+
+	stems map[string]bool
+}
+
+// lineagePredecessor points to the process of an earlier term a process was linked to
+type lineagePredecessor struct {
+	Term       int     `json:"term"`
+	Number     string  `json:"number"`
+	Similarity float64 `json:"similarity"`
+}
+
+// processLineage is a chain of processes about the same bill across terms, ordered by term
+type processLineage struct {
+	Terms     []int            `json:"terms"`
+	Passed    bool             `json:"passed"`
+	Processes []lineageProcess `json:"processes"`
+}
+
+// processTitleStems returns the word stems of a title without boilerplate and without the stems of the
+// searched topic, which every found title contains
+func processTitleStems(title string, topic map[string]bool) map[string]bool {
+	stems := make(map[string]bool)
+	for _, token := range summaryTokens(title) {
+		if processTitleBoilerplate[token] {
+			continue
+		}
+		if stem := clusterStem(token); !topic[stem] {
+			stems[stem] = true
+		}
+	}
+	return stems
+}
+
+// titleSimilarity is the Dice coefficient of two stem sets. Titles that consist of the topic alone are
+// treated as identical.
+func titleSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	shared := 0
+	for stem := range a {
+		if b[stem] {
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(a)+len(b))
+}
+
+// linkProcessLineages links each process to the most similar process of the nearest earlier term scoring
+// at least minSimilarity, and groups the linked processes into lineages. A bill resubmitted twice forms one
+// lineage over three terms; one split into two bills gives a lineage with two processes in the later term.
+// The processes without a link are only counted.
+func linkProcessLineages(byTerm map[int][]lineageProcess, terms []int, minSimilarity float64) ([]processLineage, int) {
+	type ref struct{ term, index int }
+	parent := make(map[ref]ref)
+	linked := make(map[ref]bool)
+	var find func(r ref) ref
+	find = func(r ref) ref {
+		if p, ok := parent[r]; ok && p != r {
+			root := find(p)
+			parent[r] = root
+			return root
+		}
+		return r
+	}
+
+	for ti, term := range terms {
+		for i := range byTerm[term] {
+			process := &byTerm[term][i]
+			for pj := ti - 1; pj >= 0 && process.Predecessor == nil; pj-- {
+				earlier := terms[pj]
+				best, bestScore := -1, 0.0
+				for j, candidate := range byTerm[earlier] {
+					if score := titleSimilarity(process.stems, candidate.stems); score >= minSimilarity && score > bestScore {
+						best, bestScore = j, score
+					}
+				}
+				if best >= 0 {
+					process.Predecessor = &lineagePredecessor{Term: earlier, Number: byTerm[earlier][best].Number, Similarity: bestScore}
+					linked[ref{term, i}], linked[ref{earlier, best}] = true, true
+					a, b := find(ref{term, i}), find(ref{earlier, best})
+					if a != b {
+						parent[a] = b
+					}
+				}
+			}
+		}
+	}
+
+	groups := make(map[ref][]lineageProcess)
+	var roots []ref
+	unlinked := 0
+	for _, term := range terms {
+		for i, process := range byTerm[term] {
+			r := ref{term, i}
+			if !linked[r] {
+				unlinked++
+				continue
+			}
+			root := find(r)
+			if groups[root] == nil {
+				roots = append(roots, root)
+			}
+			groups[root] = append(groups[root], process)
+		}
+	}
+
+	lineages := make([]processLineage, 0, len(roots))
+	for _, root := range roots {
+		lineage := processLineage{Processes: groups[root]}
+		for _, process := range lineage.Processes {
+			if len(lineage.Terms) == 0 || lineage.Terms[len(lineage.Terms)-1] != process.Term {
+				lineage.Terms = append(lineage.Terms, process.Term)
+			}
+			lineage.Passed = lineage.Passed || process.Passed
+		}
+		lineages = append(lineages, lineage)
+	}
+	// Longest lineages first, then those reaching the most recent term
+	sort.SliceStable(lineages, func(i, j int) bool {
+		if len(lineages[i].Terms) != len(lineages[j].Terms) {
+			return len(lineages[i].Terms) > len(lineages[j].Terms)
+		}
+		return lineages[i].Terms[len(lineages[i].Terms)-1] > lineages[j].Terms[len(lineages[j].Terms)-1]
+	})
+	return lineages, unlinked
+}
+
+// fetchTopicProcesses returns the processes of a term whose title contains topic
+func (s *SejmServer) fetchTopicProcesses(ctx context.Context, term int, topic, documentType string) ([]sejm.ProcessHeader, error) {
+	params := map[string]string{"title": topic, "limit": strconv.Itoa(maxLineageProcessesPerTerm)}
+	if documentType != "" {
+		params["documentType"] = documentType
+	}
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/processes", s.sejmBaseURL, term), params)
+	if err != nil {
+		return nil, err
+	}
+	var processes []sejm.ProcessHeader
+	if err := s.decodeAPIResponse(data, &processes); err != nil {
+		return nil, fmt.Errorf("failed to parse processes: %w", err)
+	}
+	return processes, nil
+}
+
+// formatLineageProcess renders one process of a lineage with its link to the predecessor
+func formatLineageProcess(process lineageProcess) string {
+	line := fmt.Sprintf("  Term %d, print %s", process.Term, process.Number)
+	if process.StartDate != "" {
+		line += " (" + process.StartDate + ")"
+	}
+	line += ": " + process.Title
+	if process.Passed {
+		line += " [passed"
+		if process.ELI != "" {
+			line += ", " + process.ELI
+		}
+		line += "]"
+	}
+	if process.Predecessor != nil {
+		line += fmt.Sprintf(" ← term %d print %s, %.0f%% similar", process.Predecessor.Term, process.Predecessor.Number, process.Predecessor.Similarity*100)
+	}
+	return line
+}
+
+func (s *SejmServer) handleTrackProcessAcrossTerms(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_track_process_across_terms called", slog.Any("arguments", request.Params.Arguments))
+
+	topic := strings.TrimSpace(request.GetString("topic", ""))
+	if topic == "" {
+		return mcp.NewToolResultError("topic is required: a fragment of the bill title, e.g. 'ochronie zwierząt' or 'związkach partnerskich'."), nil
+	}
+	fromTerm, err := s.validateTerm(request.GetString("from_term", strconv.Itoa(defaultLineageFromTerm)))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid from_term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	toTerm, err := s.validateTerm(request.GetString("to_term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid to_term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	if fromTerm >= toTerm {
+		return mcp.NewToolResultError(fmt.Sprintf("from_term (%d) must be lower than to_term (%d) to span several terms.", fromTerm, toTerm)), nil
+	}
+	minSimilarity := defaultLineageMinSimilarity
+	if value := request.GetString("min_similarity", ""); value != "" {
+		if minSimilarity, err = strconv.ParseFloat(value, 64); err != nil || minSimilarity <= 0 || minSimilarity > 1 {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid min_similarity '%s': must be a number above 0 and at most 1, e.g. '0.4'.", value)), nil
+		}
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+	documentType := request.GetString("document_type", "")
+
+	topicStems := make(map[string]bool)
+	for _, token := range summaryTokens(topic) {
+		topicStems[clusterStem(token)] = true
+	}
+
+	coverage := newSourceCoverage("terms")
+	byTerm := make(map[int][]lineageProcess)
+	var terms []int
+	found, truncated := 0, 0
+	for term := fromTerm; term <= toTerm; term++ {
+		processes, err := s.fetchTopicProcesses(ctx, term, topic, documentType)
+		if err != nil {
+			coverage.fail(fmt.Sprintf("term %d", term), err)
+			continue
+		}
+		coverage.succeeded()
+		terms = append(terms, term)
+		if len(processes) >= maxLineageProcessesPerTerm {
+			truncated++
+		}
+		for _, header := range processes {
+			if header.Number == nil || header.Title == nil {
+				continue
+			}
+			process := lineageProcess{
+				Term:   term,
+				Number: *header.Number,
+				Title:  strings.TrimSpace(*header.Title),
+				Passed: header.Passed != nil && *header.Passed,
+				ELI:    stringValue(header.ELI),
+			}
+			if header.ProcessStartDate != nil {
+				process.StartDate = header.ProcessStartDate.Format("2006-01-02")
+			}
+			process.stems = processTitleStems(process.Title, topicStems)
+			byTerm[term] = append(byTerm[term], process)
+			found++
+		}
+	}
+	if len(terms) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve processes of terms %d-%d: %s", fromTerm, toTerm, strings.Join(coverage.failed, "; "))), nil
+	}
+
+	lineages, unlinked := linkProcessLineages(byTerm, terms, minSimilarity)
+
+	if format == "json" {
+		result := map[string]interface{}{
+			"topic":         topic,
+			"fromTerm":      fromTerm,
+			"toTerm":        toTerm,
+			"minSimilarity": minSimilarity,
+			"processes":     found,
+			"unlinked":      unlinked,
+			"lineages":      lineages,
+		}
+		if coverage.partial() {
+			result["unavailable"] = coverage.failed
+		}
+		out, _ := json.MarshalIndent(result, "", "  ")
+		return coverage.result(string(out)), nil
+	}
+
+	summary := []string{
+		fmt.Sprintf("Topic '%s', terms %d-%d: %d processes found", topic, fromTerm, toTerm, found),
+		fmt.Sprintf("%d lineages spanning several terms, %d processes without a counterpart in another term", len(lineages), unlinked),
+	}
+	if truncated > 0 {
+		summary = append(summary, fmt.Sprintf("%d terms have more than %d matching processes; only the first %d were compared", truncated, maxLineageProcessesPerTerm, maxLineageProcessesPerTerm))
+	}
+
+	var data []string
+	for i, lineage := range lineages {
+		if i == maxListedLineages {
+			data = append(data, fmt.Sprintf("... and %d more lineages; narrow the topic or use format='json'", len(lineages)-maxListedLineages))
+			break
+		}
+		terms := make([]string, len(lineage.Terms))
+		for j, term := range lineage.Terms {
+			terms[j] = strconv.Itoa(term)
+		}
+		outcome := "never passed"
+		if lineage.Passed {
+			outcome = "passed"
+		}
+		if i > 0 {
+			data = append(data, "")
+		}
+		data = append(data, fmt.Sprintf("Lineage %d: terms %s, %s", i+1, strings.Join(terms, ", "), outcome))
+		for _, process := range lineage.Processes {
+			data = append(data, formatLineageProcess(process))
+		}
+	}
+
+	var nextActions []string
+	if len(lineages) > 0 {
+		latest := lineages[0].Processes[len(lineages[0].Processes)-1]
+		nextActions = append(nextActions,
+			fmt.Sprintf("Stages of the latest process: sejm_get_process_details with term='%d', process_number='%s'", latest.Term, latest.Number),
+			fmt.Sprintf("Documents of the latest process: sejm_get_print_graph with term='%d', num='%s'", latest.Term, latest.Number))
+	}
+	if minSimilarity > 0.3 {
+		nextActions = append(nextActions, fmt.Sprintf("Looser matching of reworded titles: min_similarity='%.1f'", minSimilarity-0.2))
+	}
+
+	note := "Bills not passed by the end of a term lapse (zasada dyskontynuacji) and must be submitted again; citizens' bills are the exception and continue in the next term under a new print number. Processes are linked by the similarity of their titles, ignoring the sponsor, 'projekt ustawy o zmianie ustawy' and the topic words, so check linked titles before relying on them."
+	if len(lineages) == 0 {
+		note = "No process has a similar counterpart in another term. Try a shorter topic or a lower min_similarity. " + note
+	}
+	response := StandardResponse{
+		Operation:   "Legislative Process Lineage",
+		Status:      coverage.status("Retrieved Successfully"),
+		Summary:     summary,
+		Data:        data,
+		NextActions: nextActions,
+		Note:        note,
+		Unavailable: coverage.unavailable(),
+	}
+	return coverage.result(response.Format()), nil
+}
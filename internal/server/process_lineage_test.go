@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestHandleTrackProcessAcrossTerms(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term8/processes": `[
+			{"number": "120", "title": "Poselski projekt ustawy o zmianie ustawy o ochronie zwierząt w zakresie hodowli zwierząt futerkowych", "processStartDate": "2016-03-01"},
+			{"number": "300", "title": "Rządowy projekt ustawy o ochronie zwierząt laboratoryjnych"}
+		]`,
+		"/sejm/term9/processes": `[
+			{"number": "45", "title": "Obywatelski projekt ustawy o zmianie ustawy o ochronie zwierząt (hodowla zwierząt futerkowych)", "processStartDate": "2020-01-15"},
+			{"number": "77", "title": "Poselski projekt ustawy o ochronie zwierząt bezdomnych"}
+		]`,
+		"/sejm/term10/processes": `[
+			{"number": "12", "title": "Senacki projekt ustawy o ochronie zwierząt - zakaz hodowli zwierząt futerkowych", "processStartDate": "2024-02-01", "passed": true, "ELI": "DU/2025/10"}
+		]`,
+	})
+
+	result, err := server.handleTrackProcessAcrossTerms(context.Background(), createMockRequest(map[string]interface{}{
+		"topic": "ochronie zwierząt",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{
+		"Partially Retrieved",
+		"1 of 4 terms unavailable",
+		"Topic 'ochronie zwierząt', terms 7-10: 5 processes found",
+		"1 lineages spanning several terms, 2 processes without a counterpart in another term",
+		"Lineage 1: terms 8, 9, 10, passed",
+		"  Term 9, print 45 (2020-01-15): Obywatelski projekt",
+		"← term 8 print 120",
+		"[passed, DU/2025/10] ← term 9 print 45",
+		"sejm_get_process_details with term='10', process_number='12'",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+
+	result, _ = server.handleTrackProcessAcrossTerms(context.Background(), createMockRequest(map[string]interface{}{
+		"topic": "ochronie zwierząt", "from_term": "10",
+	}))
+	if !result.IsError {
+		t.Errorf("Expected a single term to be rejected, got: %s", extractTextContent(result))
+	}
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	topic := map[string]bool{clusterStem("ochronie"): true, clusterStem("zwierząt"): true}
+	a := processTitleStems("Rządowy projekt ustawy o ochronie zwierząt", topic)
+	b := processTitleStems("Poselski projekt ustawy o zmianie ustawy o ochronie zwierząt", topic)
+	if score := titleSimilarity(a, b); score != 1 {
+		t.Errorf("Expected titles differing only in boilerplate to be identical, got %.2f", score)
+	}
+	c := processTitleStems("Poselski projekt ustawy o ochronie zwierząt bezdomnych", topic)
+	if score := titleSimilarity(a, c); score != 0 {
+		t.Errorf("Expected no similarity with a different subject, got %.2f", score)
+	}
+}
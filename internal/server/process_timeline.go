@@ -0,0 +1,222 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// processTimelineStageSource is a process stage as sent by the API, with the committee code some stages carry
+type processTimelineStageSource struct {
+	StageName     string                       `json:"stageName"`
+	StageType     string                       `json:"stageType"`
+	Date          string                       `json:"date"`
+	CommitteeCode string                       `json:"committeeCode"`
+	Children      []processTimelineStageSource `json:"children"`
+}
+
+// processTimelineStage is a top-level stage of a legislative process with its duration. A stage lasts from its
+// date until the next stage starts; the last stage ends when the process is closed.
+type processTimelineStage struct {
+	Stage        string   `json:"stage"`
+	Type         string   `json:"type,omitempty"`
+	Start        string   `json:"start,omitempty"`
+	End          string   `json:"end,omitempty"`
+	DurationDays *int     `json:"durationDays,omitempty"`
+	Ongoing      bool     `json:"ongoing,omitempty"`
+	Committees   []string `json:"committees,omitempty"`
+	Substages    int      `json:"substages,omitempty"`
+}
+
+// processTimeline is the structured timeline of a legislative process
+type processTimeline struct {
+	Process       string                 `json:"process"`
+	Term          int                    `json:"term"`
+	Title         string                 `json:"title,omitempty"`
+	Passed        bool                   `json:"passed"`
+	Start         string                 `json:"start,omitempty"`
+	End           string                 `json:"end,omitempty"`
+	TotalDays     *int                   `json:"totalDays,omitempty"`
+	DaysToPassage *int                   `json:"daysToPassage,omitempty"`
+	Ongoing       bool                   `json:"ongoing,omitempty"`
+	AsOf          string                 `json:"asOf"`
+	Stages        []processTimelineStage `json:"stages"`
+}
+
+// parseProcessDate reads the date part of an API date or date-time
+func parseProcessDate(value string) (time.Time, bool) {
+	if len(value) < len("2006-01-02") {
+		return time.Time{}, false
+	}
+	date, err := time.Parse("2006-01-02", value[:len("2006-01-02")])
+	return date, err == nil
+}
+
+// daysBetween counts calendar days between two dates
+func daysBetween(from, to time.Time) *int {
+	days := int(to.Sub(from).Hours() / 24)
+	return &days
+}
+
+// earliestStageDate returns the date of a stage, or the earliest date of its substages when it has none
+func earliestStageDate(stage processTimelineStageSource) (time.Time, bool) {
+	if date, ok := parseProcessDate(stage.Date); ok {
+		return date, true
+	}
+	var earliest time.Time
+	found := false
+	for _, child := range stage.Children {
+		if date, ok := earliestStageDate(child); ok && (!found || date.Before(earliest)) {
+			earliest, found = date, true
+		}
+	}
+	return earliest, found
+}
+
+// collectStageCommittees appends the committee codes of a stage and its substages, each once
+func collectStageCommittees(stage processTimelineStageSource, committees []string) []string {
+	for _, code := range strings.Split(stage.CommitteeCode, ",") {
+		code = strings.TrimSpace(code)
+		if code != "" && !containsString(committees, code) {
+			committees = append(committees, code)
+		}
+	}
+	for _, child := range stage.Children {
+		committees = collectStageCommittees(child, committees)
+	}
+	return committees
+}
+
+// countSubstages counts the stages nested under a stage
+func countSubstages(stage processTimelineStageSource) int {
+	count := len(stage.Children)
+	for _, child := range stage.Children {
+		count += countSubstages(child)
+	}
+	return count
+}
+
+// buildProcessTimeline computes stage durations and the total time of a process from its API record. Durations
+// of a process that is still open run until asOf.
+func buildProcessTimeline(term int, processNumber string, data []byte, asOf time.Time) (processTimeline, error) {
+	var source struct {
+		Title            string                       `json:"title"`
+		Passed           bool                         `json:"passed"`
+		ProcessStartDate string                       `json:"processStartDate"`
+		ClosureDate      string                       `json:"closureDate"`
+		Stages           []processTimelineStageSource `json:"stages"`
+	}
+	if err := json.Unmarshal(data, &source); err != nil {
+		return processTimeline{}, fmt.Errorf("failed to parse process stages: %w", err)
+	}
+	asOf = time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	timeline := processTimeline{
+		Process: processNumber,
+		Term:    term,
+		Title:   source.Title,
+		Passed:  source.Passed,
+		AsOf:    asOf.Format("2006-01-02"),
+		Stages:  []processTimelineStage{},
+	}
+
+	starts := make([]time.Time, len(source.Stages))
+	hasStart := make([]bool, len(source.Stages))
+	for i, stage := range source.Stages {
+		starts[i], hasStart[i] = earliestStageDate(stage)
+	}
+	closure, closed := parseProcessDate(source.ClosureDate)
+	if !closed && source.Passed {
+		// Some passed processes have no closure date; the last dated stage ends them
+		for i := len(starts) - 1; i >= 0 && !closed; i-- {
+			closure, closed = starts[i], hasStart[i]
+		}
+	}
+
+	for i, stage := range source.Stages {
+		entry := processTimelineStage{
+			Stage:      stage.StageName,
+			Type:       stage.StageType,
+			Committees: collectStageCommittees(stage, nil),
+			Substages:  countSubstages(stage),
+		}
+		if entry.Stage == "" {
+			entry.Stage = "Unknown stage"
+		}
+		if hasStart[i] {
+			entry.Start = starts[i].Format("2006-01-02")
+			// The stage ends when the next dated stage starts
+			end, ended := time.Time{}, false
+			for j := i + 1; j < len(source.Stages); j++ {
+				if hasStart[j] {
+					end, ended = starts[j], true
+					break
+				}
+			}
+			if !ended && closed {
+				end, ended = closure, true
+			}
+			if ended {
+				entry.End = end.Format("2006-01-02")
+				entry.DurationDays = daysBetween(starts[i], end)
+			} else {
+				entry.Ongoing = true
+				entry.DurationDays = daysBetween(starts[i], asOf)
+			}
+		}
+		timeline.Stages = append(timeline.Stages, entry)
+	}
+
+	start, started := parseProcessDate(source.ProcessStartDate)
+	for i := range starts {
+		if !started && hasStart[i] {
+			start, started = starts[i], true
+		}
+	}
+	if started {
+		timeline.Start = start.Format("2006-01-02")
+		if closed {
+			timeline.End = closure.Format("2006-01-02")
+			timeline.TotalDays = daysBetween(start, closure)
+			if source.Passed {
+				timeline.DaysToPassage = timeline.TotalDays
+			}
+		} else {
+			timeline.Ongoing = true
+			timeline.TotalDays = daysBetween(start, asOf)
+		}
+	}
+	return timeline, nil
+}
+
+// formatProcessTimelineStage renders a stage with its dates, duration and committees
+func formatProcessTimelineStage(number int, stage processTimelineStage) string {
+	line := fmt.Sprintf("%d. %s", number, stage.Stage)
+	switch {
+	case stage.Start != "" && stage.Ongoing:
+		line += fmt.Sprintf(" (since %s, %d days so far)", stage.Start, *stage.DurationDays)
+	case stage.Start != "":
+		line += fmt.Sprintf(" (%s → %s, %d days)", stage.Start, stage.End, *stage.DurationDays)
+	}
+	if stage.Type != "" {
+		line += fmt.Sprintf(" [%s]", stage.Type)
+	}
+	if len(stage.Committees) > 0 {
+		line += fmt.Sprintf(" — committees: %s", strings.Join(stage.Committees, ", "))
+	}
+	return line
+}
+
+// timelineSummary states the total time of a process, e.g. "Time to passage: 52 days (2024-01-10 to 2024-03-02)"
+func (t processTimeline) timelineSummary() string {
+	switch {
+	case t.TotalDays == nil:
+		return ""
+	case t.DaysToPassage != nil:
+		return fmt.Sprintf("Time to passage: %d days (%s to %s)", *t.DaysToPassage, t.Start, t.End)
+	case t.Ongoing:
+		return fmt.Sprintf("In progress for %d days (since %s)", *t.TotalDays, t.Start)
+	default:
+		return fmt.Sprintf("Closed without passage after %d days (%s to %s)", *t.TotalDays, t.Start, t.End)
+	}
+}
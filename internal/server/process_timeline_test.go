@@ -0,0 +1,101 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testProcessRecord = `{"number": "100", "title": "Rządowy projekt ustawy o zmianie ustawy o podatku", "passed": true,
+	"processStartDate": "2024-01-10", "closureDate": "2024-03-15",
+	"stages": [
+		{"stageName": "Projekt ustawy", "stageType": "Start", "date": "2024-01-10"},
+		{"stageName": "Praca w komisjach po I czytaniu", "children": [
+			{"stageName": "Posiedzenie komisji", "date": "2024-01-25", "committeeCode": "FPB"},
+			{"stageName": "Posiedzenie komisji", "date": "2024-01-30", "committeeCode": "FPB,GOR"},
+			{"stageName": "Sprawozdanie komisji", "date": "2024-02-01", "printNumber": "200"}
+		]},
+		{"stageName": "II czytanie na posiedzeniu Sejmu", "date": "2024-02-14"},
+		{"stageName": "Stanowisko Senatu"},
+		{"stageName": "Przekazanie ustawy Prezydentowi do podpisu", "date": "2024-03-01"}
+	]}`
+
+func TestBuildProcessTimeline(t *testing.T) {
+	asOf := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	timeline, err := buildProcessTimeline(10, "100", []byte(testProcessRecord), asOf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if timeline.DaysToPassage == nil || *timeline.DaysToPassage != 65 || timeline.Ongoing {
+		t.Errorf("Expected 65 days to passage, got %+v", timeline)
+	}
+	if len(timeline.Stages) != 5 {
+		t.Fatalf("Expected 5 top-level stages, got %d", len(timeline.Stages))
+	}
+
+	committeeWork := timeline.Stages[1]
+	if committeeWork.Start != "2024-01-25" || committeeWork.End != "2024-02-14" || *committeeWork.DurationDays != 20 {
+		t.Errorf("Expected the committee stage to start at its first substage and end at the second reading, got %+v", committeeWork)
+	}
+	if strings.Join(committeeWork.Committees, ",") != "FPB,GOR" || committeeWork.Substages != 3 {
+		t.Errorf("Unexpected committees or substages: %+v", committeeWork)
+	}
+	if senate := timeline.Stages[3]; senate.Start != "" || senate.DurationDays != nil {
+		t.Errorf("Expected an undated stage without duration, got %+v", senate)
+	}
+	if reading := timeline.Stages[2]; reading.End != "2024-03-01" || *reading.DurationDays != 16 {
+		t.Errorf("Expected the second reading to last until the next dated stage, got %+v", reading)
+	}
+	if last := timeline.Stages[4]; last.End != "2024-03-15" || *last.DurationDays != 14 {
+		t.Errorf("Expected the last stage to end at closure, got %+v", last)
+	}
+
+	open, err := buildProcessTimeline(10, "101", []byte(`{"passed": false, "stages": [{"stageName": "Projekt ustawy", "date": "2024-05-01"}]}`), asOf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !open.Ongoing || *open.TotalDays != 31 || open.DaysToPassage != nil || !open.Stages[0].Ongoing {
+		t.Errorf("Expected an ongoing process of 31 days, got %+v", open)
+	}
+	if summary := open.timelineSummary(); summary != "In progress for 31 days (since 2024-05-01)" {
+		t.Errorf("Unexpected summary %q", summary)
+	}
+}
+
+func TestHandleGetProcessDetailsTimeline(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{"/sejm/term10/processes/100": testProcessRecord})
+
+	result, err := server.handleGetProcessDetails(context.Background(), createMockRequest(map[string]interface{}{"term": "10", "process_number": "100"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Time to passage: 65 days (2024-01-10 to 2024-03-15)",
+		"2. Praca w komisjach po I czytaniu (2024-01-25 → 2024-02-14, 20 days) — committees: FPB, GOR",
+		"4. Stanowisko Senatu",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+
+	result, err = server.handleGetProcessDetails(context.Background(), createMockRequest(map[string]interface{}{"term": "10", "process_number": "100", "format": "json"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	var timeline processTimeline
+	if err := json.Unmarshal([]byte(extractTextContent(result)), &timeline); err != nil {
+		t.Fatalf("Expected JSON timeline: %v", err)
+	}
+	if timeline.Process != "100" || len(timeline.Stages) != 5 || *timeline.DaysToPassage != 65 {
+		t.Errorf("Unexpected timeline %+v", timeline)
+	}
+
+	result, _ = server.handleGetProcessDetails(context.Background(), createMockRequest(map[string]interface{}{"process_number": "100", "format": "xml"}))
+	if !result.IsError {
+		t.Error("Expected error for an unknown format")
+	}
+}
@@ -0,0 +1,283 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProfileHeader selects a configuration profile for a request to the shared /mcp endpoint in HTTP mode
+const ProfileHeader = "X-Sejm-Profile"
+
+// profilePathPrefix is the path under which each profile has its own MCP endpoint, /profiles/<name>/mcp
+const profilePathPrefix = "/profiles/"
+
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// ProfileConfig overrides the server configuration for one profile; unset fields keep the value of the
+// command line flags
+type ProfileConfig struct {
+	Language       string `json:"language"`
+	JobsDir        string `json:"jobsDir"`
+	WatchDir       string `json:"watchDir"`
+	VotingIndexDir string `json:"votingIndexDir"`
+	OutputDir      string `json:"outputDir"`
+	MaxOutputChars *int   `json:"maxOutputChars"`
+	// UpstreamTimeout, HTTPCacheTTL and ToolTimeout use the syntax of the -upstream-timeout, -http-cache-ttl
+	// and -tool-timeout flags
+	UpstreamTimeout string `json:"upstreamTimeout"`
+	HTTPCacheTTL    string `json:"httpCacheTTL"`
+	ToolTimeout     string `json:"toolTimeout"`
+	// RateLimit is the number of MCP requests per minute the profile accepts; 0 means unlimited
+	RateLimit int `json:"rateLimit"`
+}
+
+// LoadProfiles reads a JSON file mapping profile names to their overrides and returns the configuration of
+// each profile, built on top of base
+func LoadProfiles(path string, base Config) (map[string]Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var profiles map[string]ProfileConfig
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&profiles); err != nil {
+		return nil, fmt.Errorf("invalid profiles file %s: %w", path, err)
+	}
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("profiles file %s defines no profiles", path)
+	}
+	configs := make(map[string]Config, len(profiles))
+	for name, profile := range profiles {
+		if !profileNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid profile name %q: use lowercase letters, digits, '-' and '_'", name)
+		}
+		config, err := profile.apply(base)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", name, err)
+		}
+		configs[name] = config
+	}
+	return configs, nil
+}
+
+// apply returns base with the overrides of the profile
+func (p ProfileConfig) apply(base Config) (Config, error) {
+	config := base
+	if p.Language != "" {
+		language, err := NormalizeLanguage(p.Language)
+		if err != nil {
+			return Config{}, err
+		}
+		config.Language = language
+	}
+	for _, dir := range []struct {
+		target *string
+		value  string
+	}{
+		{&config.JobsDir, p.JobsDir},
+		{&config.WatchDir, p.WatchDir},
+		{&config.VotingIndexDir, p.VotingIndexDir},
+		{&config.OutputDir, p.OutputDir},
+	} {
+		if dir.value != "" {
+			*dir.target = dir.value
+		}
+	}
+	if p.MaxOutputChars != nil {
+		if *p.MaxOutputChars < 0 {
+			return Config{}, fmt.Errorf("maxOutputChars must not be negative")
+		}
+		config.MaxOutputChars = *p.MaxOutputChars
+	}
+	if p.UpstreamTimeout != "" {
+		timeout, err := time.ParseDuration(p.UpstreamTimeout)
+		if err != nil || timeout <= 0 {
+			return Config{}, fmt.Errorf("invalid upstreamTimeout %q: use a positive duration such as 30s", p.UpstreamTimeout)
+		}
+		config.UpstreamTimeout = timeout
+	}
+	if p.HTTPCacheTTL != "" {
+		ttls, err := ParseHTTPCacheTTLs(p.HTTPCacheTTL)
+		if err != nil {
+			return Config{}, fmt.Errorf("httpCacheTTL: %w", err)
+		}
+		config.HTTPCacheTTLs = ttls
+	}
+	if p.ToolTimeout != "" {
+		timeouts, err := ParseToolTimeouts(p.ToolTimeout)
+		if err != nil {
+			return Config{}, fmt.Errorf("toolTimeout: %w", err)
+		}
+		config.ToolTimeouts = timeouts
+	}
+	if p.RateLimit < 0 {
+		return Config{}, fmt.Errorf("rateLimit must not be negative")
+	}
+	if p.RateLimit > 0 {
+		config.RateLimit = p.RateLimit
+	}
+	return config, nil
+}
+
+// AddProfile serves another server under the profile name in HTTP mode. Each profile server has its own
+// configuration, caches, jobs and watches, while sharing the listener of this server.
+func (s *SejmServer) AddProfile(name string, profile *SejmServer) {
+	if s.profiles == nil {
+		s.profiles = make(map[string]*SejmServer)
+	}
+	s.profiles[name] = profile
+}
+
+// profileNames returns the names of the configured profiles in order
+func (s *SejmServer) profileNames() []string {
+	names := make([]string, 0, len(s.profiles))
+	for name := range s.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// startProfiles prepares the MCP endpoint of every profile and starts its background watch checks
+func (s *SejmServer) startProfiles(ctx context.Context) map[string]http.Handler {
+	handlers := make(map[string]http.Handler, len(s.profiles))
+	for name, profile := range s.profiles {
+		handlers[name] = profile.mcpHTTPHandler()
+		go profile.runWatchChecker(ctx)
+		s.logger.Info("Profile enabled",
+			slog.String("profile", name),
+			slog.String("mcp", profilePathPrefix+name+"/mcp"),
+			slog.String("language", valueOrDefault(profile.config.Language, LanguageEnglish)))
+	}
+	return handlers
+}
+
+// handleProfileHTTP serves /profiles/<name>/mcp and /profiles/<name>/health
+func (s *SejmServer) handleProfileHTTP(handlers map[string]http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name, endpoint, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, profilePathPrefix), "/")
+		profile, ok := s.profiles[name]
+		if !ok {
+			s.writeUnknownProfile(w, name)
+			return
+		}
+		switch endpoint {
+		case "mcp":
+			s.logger.Info("MCP HTTP request received",
+				slog.String("profile", name),
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path))
+			handlers[name].ServeHTTP(w, r)
+		case "health":
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(profile.healthResponse()); err != nil {
+				s.logger.Warn("Failed to write health check response", slog.Any("error", err))
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+// handleProfileHeader serves the shared /mcp endpoint: a request naming a profile in the X-Sejm-Profile header
+// is served by that profile, any other by this server. Since the same URL then answers with different tools,
+// languages and limits, responses carry Vary: X-Sejm-Profile, so a shared cache does not hand one profile's
+// response to a client of another.
+func (s *SejmServer) handleProfileHeader(defaultHandler http.Handler, handlers map[string]http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.logger.Info("MCP HTTP request received",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("userAgent", r.Header.Get("User-Agent")),
+			slog.String("contentType", r.Header.Get("Content-Type")))
+
+		if len(handlers) > 0 {
+			w.Header().Add("Vary", ProfileHeader)
+		}
+		if name := r.Header.Get(ProfileHeader); name != "" {
+			handler, ok := handlers[name]
+			if !ok {
+				s.writeUnknownProfile(w, name)
+				return
+			}
+			handler.ServeHTTP(w, r)
+			return
+		}
+		defaultHandler.ServeHTTP(w, r)
+	}
+}
+
+// writeUnknownProfile answers a request for a profile that is not configured
+func (s *SejmServer) writeUnknownProfile(w http.ResponseWriter, name string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	response := map[string]interface{}{
+		"error":    fmt.Sprintf("unknown profile %q", name),
+		"profiles": s.profileNames(),
+	}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.logger.Warn("Failed to write profile error", slog.Any("error", err))
+	}
+}
+
+// rateLimiter admits a fixed number of requests per minute
+type rateLimiter struct {
+	mu          sync.Mutex
+	perMinute   int
+	windowStart time.Time
+	count       int
+	now         func() time.Time
+}
+
+func newRateLimiter(perMinute int) *rateLimiter {
+	return &rateLimiter{perMinute: perMinute, now: time.Now}
+}
+
+// allow counts a request and tells whether it fits in the current minute, and otherwise how long to wait
+func (l *rateLimiter) allow() (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if now.Sub(l.windowStart) >= time.Minute {
+		l.windowStart = now
+		l.count = 0
+	}
+	if l.count >= l.perMinute {
+		return false, l.windowStart.Add(time.Minute).Sub(now)
+	}
+	l.count++
+	return true, 0
+}
+
+// withRateLimit rejects MCP requests over the configured rate with 429 Too Many Requests
+func (s *SejmServer) withRateLimit(next http.Handler) http.Handler {
+	if s.config.RateLimit <= 0 {
+		return next
+	}
+	limiter := newRateLimiter(s.config.RateLimit)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if ok, wait := limiter.allow(); !ok {
+			seconds := int(wait.Seconds()) + 1
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			http.Error(w, fmt.Sprintf("rate limit of %d requests per minute exceeded; retry in %d s", s.config.RateLimit, seconds), http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
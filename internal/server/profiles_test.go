@@ -0,0 +1,136 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	content := `{
+		"team-a": {"language": "pl", "jobsDir": "/data/a/jobs", "maxOutputChars": 20000, "rateLimit": 60},
+		"team-b": {"upstreamTimeout": "20s", "httpCacheTTL": "live=0"}
+	}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write profiles: %v", err)
+	}
+	base := Config{Language: LanguageEnglish, JobsDir: "/data/jobs", OutputDir: "/data/out", MaxOutputChars: 5000}
+	profiles, err := LoadProfiles(path, base)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	teamA, teamB := profiles["team-a"], profiles["team-b"]
+	if teamA.Language != LanguagePolish || teamA.JobsDir != "/data/a/jobs" || teamA.MaxOutputChars != 20000 || teamA.RateLimit != 60 {
+		t.Errorf("Expected team-a overrides, got %+v", teamA)
+	}
+	if teamA.OutputDir != "/data/out" {
+		t.Errorf("Expected team-a to inherit the output dir, got %q", teamA.OutputDir)
+	}
+	if teamB.Language != LanguageEnglish || teamB.MaxOutputChars != 5000 || teamB.UpstreamTimeout != 20*time.Second || teamB.HTTPCacheTTLs["live"] != 0 {
+		t.Errorf("Expected team-b overrides on top of the base, got %+v", teamB)
+	}
+
+	for _, invalid := range []string{
+		`{"Team A": {}}`,
+		`{"team": {"language": "de"}}`,
+		`{"team": {"rateLimit": -1}}`,
+		`{"team": {"unknown": true}}`,
+		`{}`,
+	} {
+		if err := os.WriteFile(path, []byte(invalid), 0o600); err != nil {
+			t.Fatalf("Failed to write profiles: %v", err)
+		}
+		if _, err := LoadProfiles(path, base); err == nil {
+			t.Errorf("Expected an error for %s", invalid)
+		}
+	}
+}
+
+func TestHandleProfileHTTP(t *testing.T) {
+	s := NewSejmServerWithConfig(Config{})
+	s.AddProfile("team-a", NewSejmServerWithConfig(Config{Language: LanguagePolish}))
+	served := ""
+	handlers := map[string]http.Handler{
+		"team-a": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { served = "team-a" }),
+	}
+	handler := s.handleProfileHTTP(handlers)
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodPost, "/profiles/team-a/mcp", nil))
+	if served != "team-a" {
+		t.Errorf("Expected the team-a endpoint to be served, got %q", served)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/profiles/team-a/health", nil))
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), `"service":"sejm-mcp"`) {
+		t.Errorf("Expected the profile health, got %d %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodPost, "/profiles/team-b/mcp", nil))
+	if recorder.Code != http.StatusNotFound || !strings.Contains(recorder.Body.String(), "team-a") {
+		t.Errorf("Expected an unknown profile to list the configured ones, got %d %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleProfileHeader(t *testing.T) {
+	s := NewSejmServerWithConfig(Config{})
+	served := ""
+	serve := func(name string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			served = name
+			w.Header().Set("Cache-Control", "public, max-age=300")
+		})
+	}
+	handler := s.handleProfileHeader(serve("default"), map[string]http.Handler{"team-a": serve("team-a")})
+
+	for header, expected := range map[string]string{"": "default", "team-a": "team-a"} {
+		request := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		if header != "" {
+			request.Header.Set(ProfileHeader, header)
+		}
+		recorder := httptest.NewRecorder()
+		handler(recorder, request)
+		if served != expected || recorder.Header().Get("Vary") != ProfileHeader {
+			t.Errorf("Expected %s to be served with Vary: %s, got %q and %v", expected, ProfileHeader, served, recorder.Header())
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	s.handleProfileHeader(serve("default"), nil)(recorder, httptest.NewRequest(http.MethodPost, "/mcp", nil))
+	if recorder.Header().Get("Vary") != "" {
+		t.Errorf("Expected no Vary header without profiles, got %v", recorder.Header())
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	s := NewSejmServerWithConfig(Config{RateLimit: 2})
+	handler := s.withRateLimit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	var codes []int
+	for i := 0; i < 3; i++ {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/mcp", nil))
+		codes = append(codes, recorder.Code)
+	}
+	if codes[0] != http.StatusOK || codes[1] != http.StatusOK || codes[2] != http.StatusTooManyRequests {
+		t.Errorf("Expected the third request to be rejected, got %v", codes)
+	}
+
+	limiter := newRateLimiter(1)
+	now := time.Date(2024, 7, 10, 9, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+	limiter.allow()
+	if ok, wait := limiter.allow(); ok || wait != time.Minute {
+		t.Errorf("Expected to wait a minute, got %v %v", ok, wait)
+	}
+	now = now.Add(time.Minute)
+	if ok, _ := limiter.allow(); !ok {
+		t.Error("Expected a new window after a minute")
+	}
+}
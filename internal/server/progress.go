@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	mcpserver "github.com/mark3labs/mcp-go/server"
+)
+
+// progressReporter sends MCP progress notifications for a tool call. Notifications are only sent when the
+// client passed a progress token with the request; otherwise, and in background jobs, reporting is a no-op.
+type progressReporter struct {
+	ctx    context.Context
+	token  mcp.ProgressToken
+	server *mcpserver.MCPServer
+	logger *slog.Logger
+}
+
+// newProgressReporter prepares progress reporting for the tool call in request
+func (s *SejmServer) newProgressReporter(ctx context.Context, request mcp.CallToolRequest) *progressReporter {
+	reporter := &progressReporter{ctx: ctx, logger: s.logger}
+	if request.Params.Meta != nil {
+		reporter.token = request.Params.Meta.ProgressToken
+	}
+	if reporter.token != nil {
+		reporter.server = mcpserver.ServerFromContext(ctx)
+	}
+	return reporter
+}
+
+// report sends the progress of done out of total steps with a short message
+func (p *progressReporter) report(done, total int, message string) {
+	if p == nil || p.token == nil || p.server == nil {
+		return
+	}
+	params := map[string]any{
+		"progressToken": p.token,
+		"progress":      done,
+		"message":       message,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+	if err := p.server.SendNotificationToClient(p.ctx, "notifications/progress", params); err != nil {
+		p.logger.Debug("Failed to send progress notification", slog.Any("error", err))
+	}
+}
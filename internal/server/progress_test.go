@@ -0,0 +1,30 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestProgressReporterWithoutClient(t *testing.T) {
+	server := NewSejmServer()
+
+	reporter := server.newProgressReporter(context.Background(), createMockRequest(map[string]interface{}{}))
+	if reporter.token != nil {
+		t.Errorf("Expected no progress token without _meta, got %v", reporter.token)
+	}
+	reporter.report(1, 2, "step")
+
+	request := createMockRequest(map[string]interface{}{})
+	request.Params.Meta = &mcp.Meta{ProgressToken: "token-1"}
+	reporter = server.newProgressReporter(context.Background(), request)
+	if reporter.token != "token-1" || reporter.server != nil {
+		t.Errorf("Expected the token without an MCP server outside a session, got %+v", reporter)
+	}
+	// Reporting outside a client session must be a no-op
+	reporter.report(1, 2, "step")
+
+	var missing *progressReporter
+	missing.report(1, 2, "step")
+}
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// lastVolumeYear is the last year in which the journals were published in numbered volumes
+const lastVolumeYear = 2012
+
+// publisherYear is the coverage of one year of a publisher
+type publisherYear struct {
+	Year    int  `json:"year"`
+	Acts    *int `json:"acts,omitempty"`
+	Volumes *int `json:"volumes,omitempty"`
+}
+
+// missingYears lists the years between the first and the last year of a sorted list that have no acts
+func missingYears(years []int) []int {
+	var missing []int
+	for i := 1; i < len(years); i++ {
+		for year := years[i-1] + 1; year < years[i]; year++ {
+			missing = append(missing, year)
+		}
+	}
+	return missing
+}
+
+// yearRanges renders sorted years as ranges, e.g. "1918-1939, 1944-2025"
+func yearRanges(years []int) string {
+	var ranges []string
+	for i := 0; i < len(years); {
+		j := i
+		for j+1 < len(years) && years[j+1] == years[j]+1 {
+			j++
+		}
+		if i == j {
+			ranges = append(ranges, strconv.Itoa(years[i]))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", years[i], years[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(ranges, ", ")
+}
+
+// fetchPublisherYear counts the acts of a year with a one-item page sorted by position, last first. Until 2012
+// positions run on across volumes, so the volume of the last act is the number of volumes of the year.
+func (s *SejmServer) fetchPublisherYear(ctx context.Context, publisher string, year int) (publisherYear, error) {
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/acts/%s/%d", s.eliBaseURL, publisher, year), map[string]string{
+		"limit":   "1",
+		"sortBy":  "position",
+		"sortDir": "desc",
+	})
+	if err != nil {
+		return publisherYear{}, err
+	}
+	var listing eli.Acts
+	if err := s.decodeAPIResponse(data, &listing); err != nil {
+		return publisherYear{}, fmt.Errorf("failed to parse acts: %w", err)
+	}
+	result := publisherYear{Year: year}
+	acts := 0
+	if listing.TotalCount != nil {
+		acts = int(*listing.TotalCount)
+	} else if listing.Count != nil {
+		acts = int(*listing.Count)
+	}
+	result.Acts = &acts
+	if year <= lastVolumeYear && listing.Items != nil && len(*listing.Items) > 0 && (*listing.Items)[0].Volume != nil {
+		volumes := int(*(*listing.Items)[0].Volume)
+		result.Volumes = &volumes
+	}
+	return result, nil
+}
+
+func (s *SejmServer) handleGetPublisherYears(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("eli_get_publisher_years called", slog.Any("arguments", request.Params.Arguments))
+
+	publisher := strings.ToUpper(strings.TrimSpace(request.GetString("publisher", "")))
+	if publisher == "" {
+		return mcp.NewToolResultError("Publisher parameter is required. Get publisher codes from eli_get_publishers."), nil
+	}
+	yearFrom, yearTo := 0, 0
+	for _, bound := range []struct {
+		name   string
+		target *int
+	}{{"year_from", &yearFrom}, {"year_to", &yearTo}} {
+		if value := request.GetString(bound.name, ""); value != "" {
+			year, err := strconv.Atoi(value)
+			if err != nil || year < 1918 {
+				return mcp.NewToolResultError(fmt.Sprintf("Parameter '%s' must be a year from 1918 on.", bound.name)), nil
+			}
+			*bound.target = year
+		}
+	}
+	if yearFrom != 0 && yearTo != 0 && yearFrom > yearTo {
+		return mcp.NewToolResultError(fmt.Sprintf("year_from (%d) must not be after year_to (%d).", yearFrom, yearTo)), nil
+	}
+	counts := request.GetString("counts", "true") == "true"
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	publishers, err := s.getCachedPublishers(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve publishers directory from ELI database: %v. Please try again.", err)), nil
+	}
+	var house *eli.PublishingHouse
+	var codes []string
+	for i, pub := range publishers {
+		if pub.Code == nil {
+			continue
+		}
+		codes = append(codes, *pub.Code)
+		if strings.EqualFold(*pub.Code, publisher) {
+			house = &publishers[i]
+		}
+	}
+	if house == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown publisher '%s'. Valid publisher codes: %s.", publisher, strings.Join(codes, ", "))), nil
+	}
+
+	var allYears, years []int
+	if house.Years != nil {
+		for _, year := range *house.Years {
+			allYears = append(allYears, int(year))
+		}
+	}
+	sort.Ints(allYears)
+	for _, year := range allYears {
+		if (yearFrom == 0 || year >= yearFrom) && (yearTo == 0 || year <= yearTo) {
+			years = append(years, year)
+		}
+	}
+
+	results := make([]publisherYear, len(years))
+	coverage := newSourceCoverage("years")
+	if counts {
+		errs := make([]error, len(years))
+		slots := make(chan struct{}, maxConcurrentBodyFetches)
+		var wg sync.WaitGroup
+		for i, year := range years {
+			wg.Add(1)
+			go func(i, year int) {
+				defer wg.Done()
+				if err := acquireSlot(ctx, slots); err != nil {
+					errs[i] = err
+					return
+				}
+				defer func() { <-slots }()
+				results[i], errs[i] = s.fetchPublisherYear(ctx, publisher, year)
+			}(i, year)
+		}
+		wg.Wait()
+		for i, year := range years {
+			if errs[i] != nil {
+				coverage.fail(strconv.Itoa(year), errs[i])
+				results[i] = publisherYear{Year: year}
+				continue
+			}
+			coverage.succeeded()
+		}
+	} else {
+		for i, year := range years {
+			results[i] = publisherYear{Year: year}
+		}
+	}
+	missing := missingYears(allYears)
+	name := valueOrDefault(stringValue(house.Name), publisher)
+
+	if format == "json" {
+		out, _ := json.MarshalIndent(map[string]interface{}{
+			"publisher":    publisher,
+			"name":         name,
+			"actsCount":    house.ActsCount,
+			"years":        results,
+			"missingYears": missing,
+			"unavailable":  coverage.unavailable(),
+		}, "", "  ")
+		return coverage.result(string(out)), nil
+	}
+
+	summary := []string{fmt.Sprintf("%s (%s): acts in %d years", publisher, name, len(allYears))}
+	if len(allYears) > 0 {
+		summary = append(summary, "Years: "+yearRanges(allYears))
+	}
+	if len(missing) > 0 {
+		summary = append(summary, fmt.Sprintf("Years without acts between the first and the last: %d", len(missing)))
+	}
+	if house.ActsCount != nil {
+		summary = append(summary, fmt.Sprintf("Acts in total: %d", *house.ActsCount))
+	}
+	switch {
+	case yearFrom != 0 && yearTo != 0:
+		summary = append(summary, fmt.Sprintf("Listed %d to %d: %d years", yearFrom, yearTo, len(years)))
+	case yearFrom != 0:
+		summary = append(summary, fmt.Sprintf("Listed from %d: %d years", yearFrom, len(years)))
+	case yearTo != 0:
+		summary = append(summary, fmt.Sprintf("Listed up to %d: %d years", yearTo, len(years)))
+	}
+
+	var data []string
+	// Newest years first, as they are the ones most often browsed
+	for i := len(results) - 1; i >= 0; i-- {
+		result := results[i]
+		line := fmt.Sprintf("• %d", result.Year)
+		if result.Acts != nil {
+			line += fmt.Sprintf(": %d acts", *result.Acts)
+			if result.Volumes != nil {
+				line += fmt.Sprintf(" in %d volumes", *result.Volumes)
+			}
+		} else if counts {
+			line += ": count unavailable"
+		}
+		data = append(data, line)
+	}
+	if len(missing) > 0 {
+		data = append(data, "", "Years without acts: "+yearRanges(missing))
+	}
+
+	status := coverage.status("Retrieved Successfully")
+	if len(years) == 0 {
+		status = "No Results Found"
+		data = append(data, fmt.Sprintf("No years of %s in the requested range. Years with acts: %s.", publisher, valueOrDefault(yearRanges(allYears), "none")))
+	}
+	var nextActions []string
+	if len(years) > 0 {
+		nextActions = append(nextActions, fmt.Sprintf("Acts of the newest year: eli_get_acts_by_year with publisher='%s', year='%d'", publisher, years[len(years)-1]))
+	}
+	if !counts {
+		nextActions = append(nextActions, fmt.Sprintf("Act counts per year: eli_get_publisher_years with publisher='%s', counts='true'", publisher))
+	}
+	nextActions = append(nextActions, "Other publishers: eli_get_publishers")
+
+	response := StandardResponse{
+		Operation:   "Publisher Years",
+		Status:      status,
+		Summary:     summary,
+		Data:        data,
+		NextActions: nextActions,
+		Note:        fmt.Sprintf("Years come from the ELI publishers directory; act counts take one request per year. Until %d Dziennik Ustaw and Monitor Polski were published in numbered volumes (numery), so positions are cited with the volume for those years; later years have a single volume 0.", lastVolumeYear),
+		Unavailable: coverage.unavailable(),
+	}
+	return coverage.result(response.Format()), nil
+}
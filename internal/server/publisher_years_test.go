@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestYearRanges(t *testing.T) {
+	years := []int{1918, 1919, 1920, 1939, 1944, 1945}
+	if ranges := yearRanges(years); ranges != "1918-1920, 1939, 1944-1945" {
+		t.Errorf("Unexpected year ranges: %s", ranges)
+	}
+	if missing := yearRanges(missingYears(years)); missing != "1921-1938, 1940-1943" {
+		t.Errorf("Unexpected missing years: %s", missing)
+	}
+}
+
+func TestHandleGetPublisherYears(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts": `[
+			{"code": "DU", "name": "Dziennik Ustaw", "shortName": "Dz.U.", "actsCount": 4000, "years": [2013, 2011, 2010, 2008]},
+			{"code": "MP", "name": "Monitor Polski", "actsCount": 100, "years": [2024]}
+		]`,
+		"/eli/acts/DU/2013": `{"count": 1, "totalCount": 1650, "items": [{"ELI": "DU/2013/1650", "volume": 0, "pos": 1650}]}`,
+		"/eli/acts/DU/2011": `{"count": 1, "totalCount": 1700, "items": [{"ELI": "DU/2011/1700", "volume": 299, "pos": 1700}]}`,
+		"/eli/acts/DU/2010": `{"count": 1, "totalCount": 1650, "items": [{"ELI": "DU/2010/1650", "volume": 257, "pos": 1650}]}`,
+	})
+	call := func(arguments map[string]interface{}) string {
+		result, err := server.handleGetPublisherYears(context.Background(), createMockRequest(arguments))
+		if err != nil || result.IsError {
+			t.Fatalf("Unexpected error for %v: %v %s", arguments, err, extractTextContent(result))
+		}
+		return extractTextContent(result)
+	}
+
+	output := call(map[string]interface{}{"publisher": "du"})
+	for _, expected := range []string{
+		"DU (Dziennik Ustaw): acts in 4 years",
+		"Years: 2008, 2010-2011, 2013",
+		"Years without acts between the first and the last: 2",
+		"• 2013: 1650 acts\n",
+		"• 2011: 1700 acts in 299 volumes",
+		"• 2008: count unavailable",
+		"Years without acts: 2009, 2012",
+		"1 of 4 years unavailable",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output, got: %s", expected, output)
+		}
+	}
+
+	output = call(map[string]interface{}{"publisher": "DU", "year_from": "2010", "year_to": "2011", "format": "json"})
+	var listed struct {
+		Years []publisherYear `json:"years"`
+	}
+	if err := json.Unmarshal([]byte(output), &listed); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if len(listed.Years) != 2 || listed.Years[0].Year != 2010 || *listed.Years[0].Volumes != 257 || *listed.Years[1].Acts != 1700 {
+		t.Errorf("Unexpected years of DU: %+v", listed.Years)
+	}
+
+	output = call(map[string]interface{}{"publisher": "MP", "counts": "false"})
+	if !strings.Contains(output, "• 2024\n") {
+		t.Errorf("Expected the years of MP without counts, got: %s", output)
+	}
+
+	result, err := server.handleGetPublisherYears(context.Background(), createMockRequest(map[string]interface{}{"publisher": "XX"}))
+	if err != nil || !result.IsError || !strings.Contains(extractTextContent(result), "DU, MP") {
+		t.Errorf("Expected an unknown publisher to be rejected with the valid codes, got: %s", extractTextContent(result))
+	}
+}
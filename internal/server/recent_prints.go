@@ -0,0 +1,252 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// defaultRecentPrintDays is the window of sejm_get_recent_prints when no days are given
+	defaultRecentPrintDays = 7
+	// maxRecentPrintDays bounds the window, since prints are scanned page by page back to its start
+	maxRecentPrintDays = 90
+	// maxRecentPrintPages bounds the scan of the date-sorted print list
+	maxRecentPrintPages = 10
+)
+
+// printTypes classify prints by the opening words of their titles. Reports and positions come first, since
+// their titles go on to name the bill they concern ("Sprawozdanie Komisji o rządowym projekcie...").
+var printTypes = []struct {
+	prefix string
+	key    string
+	label  string
+}{
+	{"sprawozdanie komisji", "committee_report", "committee report"},
+	{"dodatkowe sprawozdanie", "committee_report", "committee report"},
+	{"uchwała senatu", "senate_position", "Senate position"},
+	{"stanowisko senatu", "senate_position", "Senate position"},
+	{"autopoprawka", "self_amendment", "self-amendment"},
+	{"rządowy projekt", "government_bill", "government bill"},
+	{"poselski projekt", "mps_bill", "MPs' bill"},
+	{"senacki projekt", "senate_bill", "Senate bill"},
+	{"prezydencki projekt", "presidential_bill", "presidential bill"},
+	{"obywatelski projekt", "citizens_bill", "citizens' bill"},
+	{"komisyjny projekt", "committee_bill", "committee bill"},
+	{"przedstawiony przez prezydenta", "presidential_bill", "presidential bill"},
+	{"przedstawiony przez senat", "senate_bill", "Senate bill"},
+	{"wniosek", "motion", "motion"},
+	{"opinia", "opinion", "opinion"},
+	{"informacja", "report", "report or information"},
+	{"sprawozdanie", "report", "report or information"},
+	{"projekt uchwały", "draft_resolution", "draft resolution"},
+}
+
+// classifyPrint returns the type key and label of a print from its title
+func classifyPrint(title string) (string, string) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(title), " "))
+	for _, printType := range printTypes {
+		if strings.HasPrefix(normalized, printType.prefix) {
+			return printType.key, printType.label
+		}
+		// Bills of the President and the Senate are titled "Projekt ustawy o ... przedstawiony przez Prezydenta"
+		if strings.HasPrefix(printType.prefix, "przedstawiony") && strings.HasPrefix(normalized, "projekt ustawy") &&
+			strings.Contains(normalized, printType.prefix) {
+			return printType.key, printType.label
+		}
+	}
+	return "other", "other"
+}
+
+// printTypeKeys lists the accepted values of the type filter
+func printTypeKeys() []string {
+	keys := []string{"other"}
+	for _, printType := range printTypes {
+		if !containsString(keys, printType.key) {
+			keys = append(keys, printType.key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// recentPrint is a print of the feed with its inferred type
+type recentPrint struct {
+	Number    string `json:"number"`
+	Title     string `json:"title"`
+	Type      string `json:"type"`
+	TypeLabel string `json:"typeLabel"`
+	Delivered string `json:"deliveryDate"`
+	Process   string `json:"process,omitempty"`
+}
+
+// fetchRecentPrints scans the prints of a term newest first until their delivery dates fall before since. It
+// tells whether the scan stopped at the page limit before reaching that date.
+func (s *SejmServer) fetchRecentPrints(ctx context.Context, term int, since string) ([]recentPrint, bool, error) {
+	var prints []recentPrint
+	for page := 0; page < maxRecentPrintPages; page++ {
+		params := map[string]string{
+			"limit":   strconv.Itoa(digestPageSize),
+			"offset":  strconv.Itoa(page * digestPageSize),
+			"sort_by": "-deliveryDate",
+		}
+		data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/prints", s.sejmBaseURL, term), params)
+		if err != nil {
+			return nil, false, err
+		}
+		var batch []sejm.Print
+		if err := s.decodeAPIResponse(data, &batch); err != nil {
+			return nil, false, fmt.Errorf("failed to parse prints: %w", err)
+		}
+		older := false
+		for _, print := range batch {
+			if print.DeliveryDate == nil {
+				continue
+			}
+			delivered := print.DeliveryDate.Format("2006-01-02")
+			if delivered < since {
+				older = true
+				continue
+			}
+			title := valueOrDefault(stringValue(print.Title), "No title")
+			key, label := classifyPrint(title)
+			item := recentPrint{Number: stringValue(print.Number), Title: title, Type: key, TypeLabel: label, Delivered: delivered}
+			if print.ProcessPrint != nil && len(*print.ProcessPrint) > 0 && (*print.ProcessPrint)[0] != item.Number {
+				item.Process = (*print.ProcessPrint)[0]
+			}
+			prints = append(prints, item)
+		}
+		if older || len(batch) < digestPageSize {
+			return prints, false, nil
+		}
+	}
+	return prints, true, nil
+}
+
+func (s *SejmServer) handleGetRecentPrints(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_recent_prints called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	days, err := parseBoundedInt(request, "days", defaultRecentPrintDays, 1, maxRecentPrintDays)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid days: %v.", err)), nil
+	}
+	typeFilter := strings.ToLower(strings.TrimSpace(request.GetString("type", "")))
+	if typeFilter != "" && !containsString(printTypeKeys(), typeFilter) {
+		return mcp.NewToolResultError(fmt.Sprintf("Unknown print type '%s'. Use one of: %s.", typeFilter, strings.Join(printTypeKeys(), ", "))), nil
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	today := warsawToday()
+	since := today.AddDate(0, 0, -(days - 1)).Format("2006-01-02")
+
+	prints, truncated, err := s.fetchRecentPrints(ctx, term, since)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve prints from Polish Parliament API: %v. Please try again.", err)), nil
+	}
+
+	counts := make(map[string]int)
+	var shown []recentPrint
+	for _, print := range prints {
+		counts[print.TypeLabel]++
+		if typeFilter == "" || print.Type == typeFilter {
+			shown = append(shown, print)
+		}
+	}
+
+	if format == "json" {
+		out, _ := json.MarshalIndent(map[string]interface{}{
+			"term":      term,
+			"since":     since,
+			"until":     today.Format("2006-01-02"),
+			"delivered": len(prints),
+			"byType":    counts,
+			"truncated": truncated,
+			"prints":    shown,
+		}, "", "  ")
+		return mcp.NewToolResultText(string(out)), nil
+	}
+
+	window := fmt.Sprintf("today (%s)", since)
+	if days > 1 {
+		window = fmt.Sprintf("the last %d days (%s to %s)", days, since, today.Format("2006-01-02"))
+	}
+	summary := []string{fmt.Sprintf("Term %d: %d prints delivered %s", term, len(prints), window)}
+	labels := make([]string, 0, len(counts))
+	for label := range counts {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if counts[labels[i]] != counts[labels[j]] {
+			return counts[labels[i]] > counts[labels[j]]
+		}
+		return labels[i] < labels[j]
+	})
+	var byType []string
+	for _, label := range labels {
+		byType = append(byType, fmt.Sprintf("%s %d", label, counts[label]))
+	}
+	if len(byType) > 0 {
+		summary = append(summary, "By type: "+strings.Join(byType, ", "))
+	}
+	if typeFilter != "" {
+		summary = append(summary, fmt.Sprintf("Type '%s': %d prints", typeFilter, len(shown)))
+	}
+
+	var data []string
+	date := ""
+	for _, print := range shown {
+		if print.Delivered != date {
+			if date != "" {
+				data = append(data, "")
+			}
+			date = print.Delivered
+			data = append(data, date+":")
+		}
+		line := fmt.Sprintf("• Print %s [%s]: %s", valueOrDefault(print.Number, "?"), print.TypeLabel, truncateRunes(print.Title, 250))
+		if print.Process != "" {
+			line += fmt.Sprintf(" (process %s)", print.Process)
+		}
+		data = append(data, line)
+	}
+
+	status := "Retrieved Successfully"
+	if len(shown) == 0 {
+		status = "No Results Found"
+		data = append(data, "No prints were delivered in this window. Try more days, e.g. days='30'.")
+	}
+	var nextActions []string
+	if len(shown) > 0 {
+		nextActions = append(nextActions,
+			fmt.Sprintf("Print details: sejm_get_print_details with term='%d', num='%s'", term, shown[0].Number),
+			fmt.Sprintf("Print text: sejm_get_print_text with term='%d', num='%s'", term, shown[0].Number))
+	}
+	nextActions = append(nextActions, fmt.Sprintf("Everything else that happened on a day: sejm_get_daily_digest with term='%d'", term))
+	note := "Types are inferred from the opening words of the titles: 'Rządowy projekt' is a government bill, 'Poselski projekt' an MPs' bill, 'Sprawozdanie Komisji' a committee report."
+	if truncated {
+		note += fmt.Sprintf(" Only the newest %d prints were scanned, so the start of the window may be missing; use fewer days.", maxRecentPrintPages*digestPageSize)
+	}
+
+	response := StandardResponse{
+		Operation:   "Recent Prints",
+		Status:      status,
+		Summary:     summary,
+		Data:        data,
+		NextActions: nextActions,
+		Note:        note,
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestClassifyPrint(t *testing.T) {
+	for title, expected := range map[string]string{
+		"Rządowy projekt ustawy o zmianie ustawy o podatku":                     "government_bill",
+		"Poselski projekt ustawy o ochronie zwierząt":                           "mps_bill",
+		"Sprawozdanie Komisji Finansów Publicznych o rządowym projekcie ustawy": "committee_report",
+		"Uchwała Senatu w sprawie ustawy o podatku":                             "senate_position",
+		"Informacja Rady Ministrów o realizacji ustawy":                         "report",
+		"Projekt uchwały w sprawie upamiętnienia":                               "draft_resolution",
+		"Wniosek o wyrażenie wotum nieufności wobec ministra":                   "motion",
+		"Kandydat na członka Krajowej Rady Radiofonii i Telewizji":              "other",
+	} {
+		if key, _ := classifyPrint(title); key != expected {
+			t.Errorf("Expected '%s' to be %s, got %s", title, expected, key)
+		}
+	}
+}
+
+func TestHandleGetRecentPrints(t *testing.T) {
+	today := warsawToday().Format("2006-01-02")
+	yesterday := warsawToday().AddDate(0, 0, -1).Format("2006-01-02")
+	old := warsawToday().AddDate(0, 0, -30).Format("2006-01-02")
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/prints": `[
+			{"number": "300", "title": "Rządowy projekt ustawy o podatku", "deliveryDate": "` + today + `", "processPrint": ["300"]},
+			{"number": "300-A", "title": "Sprawozdanie Komisji o rządowym projekcie ustawy o podatku", "deliveryDate": "` + yesterday + `", "processPrint": ["300"]},
+			{"number": "299", "title": "Poselski projekt ustawy o ochronie zwierząt", "deliveryDate": "` + yesterday + `"},
+			{"number": "100", "title": "Rządowy projekt ustawy o drogach", "deliveryDate": "` + old + `"}
+		]`,
+	})
+
+	result, err := server.handleGetRecentPrints(context.Background(), createMockRequest(map[string]interface{}{"days": "7"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{
+		"Term 10: 3 prints delivered the last 7 days",
+		"By type: MPs' bill 1, committee report 1, government bill 1",
+		today + ":",
+		"• Print 300 [government bill]: Rządowy projekt ustawy o podatku",
+		"• Print 300-A [committee report]: Sprawozdanie Komisji o rządowym projekcie ustawy o podatku (process 300)",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+	if strings.Contains(text, "Print 100") || strings.Index(text, "Print 300 ") > strings.Index(text, "Print 299") {
+		t.Errorf("Expected only the prints of the window, newest first:\n%s", text)
+	}
+
+	result, _ = server.handleGetRecentPrints(context.Background(), createMockRequest(map[string]interface{}{"days": "1", "type": "mps_bill"}))
+	text = extractTextContent(result)
+	if !strings.Contains(text, "1 prints delivered today") || !strings.Contains(text, "No prints were delivered") {
+		t.Errorf("Expected no MPs' bills today:\n%s", text)
+	}
+
+	result, _ = server.handleGetRecentPrints(context.Background(), createMockRequest(map[string]interface{}{"type": "law"}))
+	if !result.IsError {
+		t.Errorf("Expected an unknown type to be rejected, got: %s", extractTextContent(result))
+	}
+}
@@ -0,0 +1,140 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+// Limits of the validator store, which outlives the response cache so that old transcripts, acts and
+// prints can be revalidated with a cheap conditional request instead of being downloaded again
+const (
+	validatorStoreEntries  = 500
+	validatorStoreTTL      = 24 * time.Hour
+	validatorStoreMaxBytes = 256 << 20
+	validatorMaxBodyBytes  = 32 << 20
+)
+
+// revalidatedHeader marks responses whose body was served from the validator store after a 304
+const revalidatedHeader = "X-Revalidated"
+
+// validatedResponse is a stored upstream response together with its validators
+type validatedResponse struct {
+	etag         string
+	lastModified string
+	header       http.Header
+	body         []byte
+}
+
+// conditionalTransport remembers the ETag and Last-Modified validators of successful upstream responses
+// and turns repeated requests for the same URL into conditional ones. A 304 Not Modified answer is
+// replaced by the stored body, so callers always see a complete 200 response.
+type conditionalTransport struct {
+	transport http.RoundTripper
+	logger    *slog.Logger
+
+	mu    sync.Mutex
+	store *expirable.LRU[string, validatedResponse]
+	// bytes is updated from the store's eviction callback, which also runs on its expiry goroutine
+	bytes atomic.Int64
+}
+
+func newConditionalTransport(transport http.RoundTripper, logger *slog.Logger) *conditionalTransport {
+	t := &conditionalTransport{transport: transport, logger: logger}
+	t.store = expirable.NewLRU[string, validatedResponse](validatorStoreEntries, func(_ string, stored validatedResponse) {
+		t.bytes.Add(-int64(len(stored.body)))
+	}, validatorStoreTTL)
+	return t
+}
+
+func (t *conditionalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Requests that already carry validators (e.g., from the response cache) or are not plain GETs pass through
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" || req.Header.Get("Range") != "" {
+		return t.transport.RoundTrip(req)
+	}
+
+	key := req.URL.String() + "|" + req.Header.Get("Accept")
+	t.mu.Lock()
+	stored, found := t.store.Get(key)
+	t.mu.Unlock()
+
+	outgoing := req
+	if found {
+		outgoing = req.Clone(req.Context())
+		if stored.etag != "" {
+			outgoing.Header.Set("If-None-Match", stored.etag)
+		}
+		if stored.lastModified != "" {
+			outgoing.Header.Set("If-Modified-Since", stored.lastModified)
+		}
+	}
+
+	resp, err := t.transport.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+
+	if found && resp.StatusCode == http.StatusNotModified {
+		_ = resp.Body.Close()
+		t.logger.Debug("Upstream resource not modified, serving stored body", slog.String("url", req.URL.String()))
+		header := stored.header.Clone()
+		header.Set(revalidatedHeader, "1")
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(stored.body)),
+			ContentLength: int64(len(stored.body)),
+			Request:       req,
+		}, nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "") || resp.ContentLength > validatorMaxBodyBytes {
+		if found {
+			t.mu.Lock()
+			t.store.Remove(key)
+			t.mu.Unlock()
+		}
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, validatorMaxBodyBytes+1))
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > validatorMaxBodyBytes {
+		// Too large to keep; hand out what was read followed by the rest of the stream
+		resp.Body = readCloser{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.mu.Lock()
+	t.store.Remove(key)
+	t.store.Add(key, validatedResponse{etag: etag, lastModified: lastModified, header: resp.Header.Clone(), body: body})
+	t.bytes.Add(int64(len(body)))
+	for t.bytes.Load() > validatorStoreMaxBytes && t.store.Len() > 1 {
+		t.store.RemoveOldest()
+	}
+	t.mu.Unlock()
+	return resp, nil
+}
+
+// readCloser joins a reader with the closer of the stream it reads from
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
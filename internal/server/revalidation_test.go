@@ -0,0 +1,67 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConditionalTransportRevalidates(t *testing.T) {
+	var full, notModified int
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/etag":
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				notModified++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+		case "/modified":
+			if r.Header.Get("If-Modified-Since") == "Mon, 01 Jan 2024 00:00:00 GMT" {
+				notModified++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("Last-Modified", "Mon, 01 Jan 2024 00:00:00 GMT")
+		}
+		full++
+		_, _ = w.Write([]byte("body of " + r.URL.Path))
+	}))
+	defer upstream.Close()
+
+	transport := newConditionalTransport(http.DefaultTransport, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	client := &http.Client{Transport: transport}
+	get := func(path string) (string, bool) {
+		resp, err := client.Get(upstream.URL + path)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Expected 200 for %s, got %d", path, resp.StatusCode)
+		}
+		return string(body), resp.Header.Get(revalidatedHeader) == "1"
+	}
+
+	for _, path := range []string{"/etag", "/modified"} {
+		if body, revalidated := get(path); body != "body of "+path || revalidated {
+			t.Errorf("Unexpected first response for %s: %q, revalidated=%v", path, body, revalidated)
+		}
+		if body, revalidated := get(path); body != "body of "+path || !revalidated {
+			t.Errorf("Expected stored body after 304 for %s, got %q, revalidated=%v", path, body, revalidated)
+		}
+	}
+	if full != 2 || notModified != 2 {
+		t.Errorf("Expected 2 full downloads and 2 conditional hits, got %d and %d", full, notModified)
+	}
+
+	// Responses without validators are not stored
+	get("/plain")
+	if _, revalidated := get("/plain"); revalidated || full != 4 {
+		t.Errorf("Expected responses without validators to be downloaded again, got %d downloads", full)
+	}
+}
@@ -0,0 +1,448 @@
+package server
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultFeedDays is how far ahead a schedule feed looks when days is not given
+const defaultFeedDays = 30
+
+// maxFeedDays caps the days parameter; without a committee code every day costs one API request
+const maxFeedDays = 60
+
+// Supported schedule feed formats
+const (
+	feedFormatICal = "ical"
+	feedFormatRSS  = "rss"
+)
+
+// scheduleEvent is one entry of a schedule feed: a plenary sitting day or a committee sitting
+type scheduleEvent struct {
+	UID         string
+	Title       string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+}
+
+// scheduleFeedOptions selects the events of a schedule feed
+type scheduleFeedOptions struct {
+	Term              int
+	CommitteeCode     string
+	Days              int
+	IncludeCommittees bool
+}
+
+// warsawLocation returns the time zone in which the Sejm API reports sitting times. The API sends
+// wall-clock times without an offset; when the zone database is unavailable times stay floating.
+func warsawLocation() *time.Location {
+	loc, err := time.LoadLocation("Europe/Warsaw")
+	if err != nil {
+		return nil
+	}
+	return loc
+}
+
+// warsawToday returns the current date in Warsaw, as midnight UTC
+func warsawToday() time.Time {
+	now := time.Now()
+	if loc := warsawLocation(); loc != nil {
+		now = now.In(loc)
+	}
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// inWarsaw reinterprets a wall-clock time from the API as Warsaw local time
+func inWarsaw(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+}
+
+// proceedingEvents turns plenary sittings into one all-day event per sitting day within [from, to)
+func proceedingEvents(term int, proceedings []sejm.Proceeding, from, to time.Time) []scheduleEvent {
+	var events []scheduleEvent
+	for _, proceeding := range proceedings {
+		if proceeding.Number == nil || proceeding.Dates == nil {
+			continue
+		}
+		title := fmt.Sprintf("Posiedzenie Sejmu nr %d", *proceeding.Number)
+		description := ""
+		if proceeding.Title != nil {
+			description = *proceeding.Title
+		}
+		for i, date := range *proceeding.Dates {
+			day := date.Time
+			if day.Before(from) || !day.Before(to) {
+				continue
+			}
+			events = append(events, scheduleEvent{
+				UID:         fmt.Sprintf("sejm-term%d-proceeding%d-%s@sejm-mcp", term, *proceeding.Number, day.Format("20060102")),
+				Title:       fmt.Sprintf("%s (dzień %d)", title, i+1),
+				Description: description,
+				Location:    "Sejm RP, Warszawa",
+				Start:       day,
+				End:         day.AddDate(0, 0, 1),
+				AllDay:      true,
+			})
+		}
+	}
+	return events
+}
+
+// committeeSittingEvents turns committee sittings within [from, to) into timed events, skipping cancelled ones
+func committeeSittingEvents(term int, sittings []sejm.CommitteeSitting, from, to time.Time, loc *time.Location) []scheduleEvent {
+	var events []scheduleEvent
+	for _, sitting := range sittings {
+		if sitting.Code == nil || sitting.Num == nil || sitting.StartDateTime == nil {
+			continue
+		}
+		if sitting.Status != nil && *sitting.Status == sejm.SittingStatusCANCELLED {
+			continue
+		}
+		start := inWarsaw(sitting.StartDateTime.Time, loc)
+		if start.Before(from) || !start.Before(to) {
+			continue
+		}
+		end := start.Add(2 * time.Hour)
+		if sitting.EndDateTime != nil && sitting.EndDateTime.After(sitting.StartDateTime.Time) {
+			end = inWarsaw(sitting.EndDateTime.Time, loc)
+		}
+
+		var description []string
+		if sitting.Agenda != nil {
+			if agenda := strings.Join(strings.Fields(htmlToPlainText(*sitting.Agenda)), " "); agenda != "" {
+				description = append(description, agenda)
+			}
+		}
+		if sitting.Remote != nil && *sitting.Remote {
+			description = append(description, "Posiedzenie zdalne")
+		}
+		if sitting.Closed != nil && *sitting.Closed {
+			description = append(description, "Posiedzenie zamknięte")
+		}
+
+		location := "Sejm RP"
+		if sitting.Room != nil && *sitting.Room != "" {
+			location = fmt.Sprintf("Sejm RP, %s", *sitting.Room)
+		}
+		if sitting.City != nil && *sitting.City != "" && !strings.EqualFold(*sitting.City, "Warszawa") {
+			location = *sitting.City
+		}
+
+		events = append(events, scheduleEvent{
+			UID:         fmt.Sprintf("sejm-term%d-committee-%s-%d@sejm-mcp", term, *sitting.Code, *sitting.Num),
+			Title:       fmt.Sprintf("Posiedzenie komisji %s nr %d", *sitting.Code, *sitting.Num),
+			Description: strings.Join(description, "\n"),
+			Location:    location,
+			Start:       start,
+			End:         end,
+		})
+	}
+	return events
+}
+
+// scheduleEvents collects upcoming plenary and committee sittings starting on the day of from.
+// With a committee code only that committee's sittings are listed; otherwise committee sittings are
+// fetched day by day, which costs one API request per day.
+func (s *SejmServer) scheduleEvents(ctx context.Context, options scheduleFeedOptions, from time.Time) ([]scheduleEvent, error) {
+	loc := warsawLocation()
+	if loc != nil {
+		from = from.In(loc)
+	}
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	to := from.AddDate(0, 0, options.Days)
+
+	var events []scheduleEvent
+	if options.CommitteeCode == "" {
+		data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/proceedings", s.sejmBaseURL, options.Term), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve proceedings: %w", err)
+		}
+		var proceedings []sejm.Proceeding
+		if err := s.decodeAPIResponse(data, &proceedings); err != nil {
+			return nil, fmt.Errorf("failed to parse proceedings: %w", err)
+		}
+		// Sitting dates are calendar days; compare them in UTC like the API decodes them
+		utcFrom := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+		events = append(events, proceedingEvents(options.Term, proceedings, utcFrom, utcFrom.AddDate(0, 0, options.Days))...)
+	}
+
+	if options.CommitteeCode != "" {
+		data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings", s.sejmBaseURL, options.Term, options.CommitteeCode), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve sittings for committee %s: %w", options.CommitteeCode, err)
+		}
+		var sittings []sejm.CommitteeSitting
+		if err := s.decodeAPIResponse(data, &sittings); err != nil {
+			return nil, fmt.Errorf("failed to parse committee sittings: %w", err)
+		}
+		events = append(events, committeeSittingEvents(options.Term, sittings, from, to, loc)...)
+	} else if options.IncludeCommittees {
+		for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+			data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/committees/sittings/%s", s.sejmBaseURL, options.Term, day.Format("2006-01-02")), nil)
+			if err != nil {
+				s.logger.Warn("Failed to retrieve committee sittings for schedule feed", slog.String("date", day.Format("2006-01-02")), slog.Any("error", err))
+				continue
+			}
+			var sittings []sejm.CommitteeSitting
+			if err := s.decodeAPIResponse(data, &sittings); err != nil {
+				s.logger.Warn("Failed to parse committee sittings for schedule feed", slog.String("date", day.Format("2006-01-02")), slog.Any("error", err))
+				continue
+			}
+			events = append(events, committeeSittingEvents(options.Term, sittings, from, to, loc)...)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Start.Format("20060102") != events[j].Start.Format("20060102") {
+			return events[i].Start.Format("20060102") < events[j].Start.Format("20060102")
+		}
+		// Plenary days first, then committee sittings by start time
+		if events[i].AllDay != events[j].AllDay {
+			return events[i].AllDay
+		}
+		return events[i].Start.Before(events[j].Start)
+	})
+	return events, nil
+}
+
+// escapeICalText escapes a TEXT value as required by RFC 5545
+func escapeICalText(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(text)
+}
+
+// foldICalLine splits content lines longer than 75 octets, continuing them with a leading space
+// that counts towards the limit of the continuation line
+func foldICalLine(line string) string {
+	limit := 75
+	var folded strings.Builder
+	for len(line) > limit {
+		cut := truncateRunes(line, limit)
+		folded.WriteString(cut)
+		folded.WriteString("\r\n ")
+		line = line[len(cut):]
+		limit = 74
+	}
+	folded.WriteString(line)
+	return folded.String()
+}
+
+// formatICalTime formats an event time as UTC, or as floating local time when its zone is unknown
+func formatICalTime(t time.Time) string {
+	if t.Location() == time.UTC {
+		return t.Format("20060102T150405")
+	}
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// formatICal renders events as an iCalendar (RFC 5545) document
+func formatICal(calendarName string, events []scheduleEvent, generated time.Time) string {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//sejm-mcp//Sejm schedule//PL",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:" + escapeICalText(calendarName),
+		"X-WR-TIMEZONE:Europe/Warsaw",
+	}
+	stamp := generated.UTC().Format("20060102T150405Z")
+	for _, event := range events {
+		lines = append(lines, "BEGIN:VEVENT", "UID:"+event.UID, "DTSTAMP:"+stamp)
+		if event.AllDay {
+			lines = append(lines,
+				"DTSTART;VALUE=DATE:"+event.Start.Format("20060102"),
+				"DTEND;VALUE=DATE:"+event.End.Format("20060102"))
+		} else {
+			lines = append(lines, "DTSTART:"+formatICalTime(event.Start), "DTEND:"+formatICalTime(event.End))
+		}
+		lines = append(lines, "SUMMARY:"+escapeICalText(event.Title))
+		if event.Description != "" {
+			lines = append(lines, "DESCRIPTION:"+escapeICalText(event.Description))
+		}
+		if event.Location != "" {
+			lines = append(lines, "LOCATION:"+escapeICalText(event.Location))
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var calendar strings.Builder
+	for _, line := range lines {
+		calendar.WriteString(foldICalLine(line))
+		calendar.WriteString("\r\n")
+	}
+	return calendar.String()
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Language      string    `xml:"language"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Description string  `xml:"description,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+// formatRSS renders events as an RSS 2.0 feed; each item is dated with the event start
+func formatRSS(feedTitle string, events []scheduleEvent, generated time.Time) (string, error) {
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         feedTitle,
+			Link:          "https://www.sejm.gov.pl",
+			Description:   "Upcoming plenary and committee sittings of the Polish Sejm",
+			Language:      "pl",
+			LastBuildDate: generated.Format(time.RFC1123Z),
+		},
+	}
+	for _, event := range events {
+		title := event.Title
+		if event.AllDay {
+			title = fmt.Sprintf("%s – %s", event.Start.Format("2006-01-02"), title)
+		} else {
+			title = fmt.Sprintf("%s – %s", event.Start.Format("2006-01-02 15:04"), title)
+		}
+		description := event.Description
+		if event.Location != "" {
+			description = strings.TrimSpace(description + "\n" + event.Location)
+		}
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       title,
+			Description: description,
+			GUID:        rssGUID{Value: event.UID},
+			PubDate:     event.Start.Format(time.RFC1123Z),
+		})
+	}
+
+	output, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(output) + "\n", nil
+}
+
+// scheduleFeedTitle names the feed after its term and committee
+func scheduleFeedTitle(options scheduleFeedOptions) string {
+	if options.CommitteeCode != "" {
+		return fmt.Sprintf("Sejm RP – posiedzenia komisji %s (kadencja %d)", options.CommitteeCode, options.Term)
+	}
+	return fmt.Sprintf("Sejm RP – harmonogram posiedzeń (kadencja %d)", options.Term)
+}
+
+// parseScheduleFeedOptions validates the parameters shared by the feed tool and the HTTP endpoints
+func (s *SejmServer) parseScheduleFeedOptions(termStr, committeeCode, daysStr, includeCommittees string) (scheduleFeedOptions, error) {
+	term, err := s.validateTerm(termStr)
+	if err != nil {
+		return scheduleFeedOptions{}, fmt.Errorf("invalid parliamentary term: %v. Please use term numbers 1-10", err)
+	}
+	days := defaultFeedDays
+	if daysStr != "" {
+		days, err = strconv.Atoi(daysStr)
+		if err != nil || days < 1 {
+			return scheduleFeedOptions{}, fmt.Errorf("invalid days '%s': must be a positive number", daysStr)
+		}
+	}
+	if days > maxFeedDays {
+		days = maxFeedDays
+	}
+	return scheduleFeedOptions{
+		Term:              term,
+		CommitteeCode:     strings.ToUpper(strings.TrimSpace(committeeCode)),
+		Days:              days,
+		IncludeCommittees: includeCommittees != "false",
+	}, nil
+}
+
+// renderScheduleFeed builds the feed document in the requested format
+func (s *SejmServer) renderScheduleFeed(ctx context.Context, options scheduleFeedOptions, format string, now time.Time) (string, error) {
+	events, err := s.scheduleEvents(ctx, options, now)
+	if err != nil {
+		return "", err
+	}
+	switch format {
+	case "", feedFormatICal:
+		return formatICal(scheduleFeedTitle(options), events, now), nil
+	case feedFormatRSS:
+		return formatRSS(scheduleFeedTitle(options), events, now)
+	default:
+		return "", fmt.Errorf("unsupported feed format '%s': must be 'ical' or 'rss'", format)
+	}
+}
+
+func (s *SejmServer) handleGetScheduleFeed(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	options, err := s.parseScheduleFeedOptions(
+		request.GetString("term", ""),
+		request.GetString("committee_code", ""),
+		request.GetString("days", ""),
+		request.GetString("include_committees", "true"))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid schedule feed parameters: %v.", err)), nil
+	}
+
+	format := strings.ToLower(request.GetString("format", feedFormatICal))
+	feed, err := s.renderScheduleFeed(ctx, options, format, time.Now())
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to build schedule feed: %v. Check the committee code with sejm_get_committees.", err)), nil
+	}
+	return mcp.NewToolResultText(feed), nil
+}
+
+// handleScheduleFeedHTTP serves /feeds/schedule.ics and /feeds/schedule.rss in HTTP mode so that
+// calendar apps and feed readers can subscribe directly. Query parameters mirror the tool's.
+func (s *SejmServer) handleScheduleFeedHTTP(format, contentType string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.logger.Debug("Schedule feed request received", slog.String("path", r.URL.Path), slog.String("query", r.URL.RawQuery))
+		query := r.URL.Query()
+		options, err := s.parseScheduleFeedOptions(query.Get("term"), query.Get("committee"), query.Get("days"), query.Get("include_committees"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		feed, err := s.renderScheduleFeed(r.Context(), options, format, time.Now())
+		if err != nil {
+			s.logger.Warn("Failed to build schedule feed", slog.Any("error", err))
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		if _, err := w.Write([]byte(feed)); err != nil {
+			s.logger.Warn("Failed to write schedule feed", slog.Any("error", err))
+		}
+	}
+}
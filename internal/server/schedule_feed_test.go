@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatICalEscapingAndFolding(t *testing.T) {
+	start := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	calendar := formatICal("Test", []scheduleEvent{{
+		UID:         "test@sejm-mcp",
+		Title:       "Posiedzenie; komisji, nr 1",
+		Description: strings.Repeat("Rozpatrzenie projektu ustawy ", 5) + "\npkt 2",
+		Start:       start,
+		End:         start.AddDate(0, 0, 1),
+		AllDay:      true,
+	}}, start)
+
+	for _, line := range strings.Split(strings.TrimSuffix(calendar, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Content line longer than 75 octets: %q", line)
+		}
+	}
+	if !strings.Contains(calendar, `SUMMARY:Posiedzenie\; komisji\, nr 1`) {
+		t.Errorf("Expected escaped SUMMARY, got:\n%s", calendar)
+	}
+	if !strings.Contains(calendar, "DTSTART;VALUE=DATE:20250304\r\nDTEND;VALUE=DATE:20250305") {
+		t.Errorf("Expected all-day dates, got:\n%s", calendar)
+	}
+	unfolded := strings.ReplaceAll(calendar, "\r\n ", "")
+	if !strings.Contains(unfolded, `ustawy \npkt 2`) {
+		t.Errorf("Expected escaped newline in unfolded DESCRIPTION, got:\n%s", unfolded)
+	}
+}
+
+func TestScheduleFeedForCommittee(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/committees/ENM/sittings": `[
+			{"code":"ENM","num":41,"startDateTime":"2025-03-04T10:00:00","endDateTime":"2025-03-04T12:30:00","room":"sala nr 24","agenda":"<p>Rozpatrzenie projektu ustawy</p>","status":"PLANNED"},
+			{"code":"ENM","num":42,"startDateTime":"2025-03-05T09:00:00","status":"CANCELLED"},
+			{"code":"ENM","num":40,"startDateTime":"2025-02-20T09:00:00","status":"FINISHED"},
+			{"code":"ENM","num":43,"startDateTime":"2025-05-20T09:00:00","status":"PLANNED"}
+		]`,
+	})
+
+	options, err := server.parseScheduleFeedOptions("10", "enm", "14", "")
+	if err != nil {
+		t.Fatalf("Unexpected options error: %v", err)
+	}
+	now := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	calendar, err := server.renderScheduleFeed(context.Background(), options, feedFormatICal, now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if strings.Count(calendar, "BEGIN:VEVENT") != 1 {
+		t.Fatalf("Expected only the planned sitting within 14 days, got:\n%s", calendar)
+	}
+	for _, expected := range []string{
+		"UID:sejm-term10-committee-ENM-41@sejm-mcp",
+		"SUMMARY:Posiedzenie komisji ENM nr 41",
+		"DESCRIPTION:Rozpatrzenie projektu ustawy",
+		"LOCATION:Sejm RP\\, sala nr 24",
+	} {
+		if !strings.Contains(calendar, expected) {
+			t.Errorf("Expected %q in calendar, got:\n%s", expected, calendar)
+		}
+	}
+	if warsawLocation() != nil && !strings.Contains(calendar, "DTSTART:20250304T090000Z\r\nDTEND:20250304T113000Z") {
+		t.Errorf("Expected Warsaw times converted to UTC, got:\n%s", calendar)
+	}
+}
+
+func TestScheduleFeedHTTPRSS(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/proceedings": `[
+			{"number":30,"title":"30. Posiedzenie Sejmu RP w dniach 4, 5 i 6 marca 2099 r.","dates":["2099-03-04","2099-03-05"]},
+			{"number":1,"title":"1. Posiedzenie Sejmu RP","dates":["2023-11-13"]}
+		]`,
+	})
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodGet, "/feeds/schedule.rss?term=10&days=60&include_committees=false", nil)
+	server.handleScheduleFeedHTTP(feedFormatRSS, "application/rss+xml; charset=utf-8").ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	// Only future sitting days are listed; the 2099 ones lie beyond the 60-day window from today
+	var feed rssFeed
+	if err := xml.Unmarshal(recorder.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("Invalid RSS: %v\n%s", err, recorder.Body.String())
+	}
+	if len(feed.Channel.Items) != 0 {
+		t.Errorf("Expected no items within the window, got %+v", feed.Channel.Items)
+	}
+
+	badRequest := httptest.NewRecorder()
+	server.handleScheduleFeedHTTP(feedFormatRSS, "application/rss+xml").ServeHTTP(badRequest, httptest.NewRequest(http.MethodGet, "/feeds/schedule.rss?days=abc", nil))
+	if badRequest.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for invalid days, got %d", badRequest.Code)
+	}
+}
+
+func TestProceedingEvents(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/proceedings": `[{"number":30,"title":"30. Posiedzenie Sejmu RP","dates":["2025-03-04","2025-03-05","2025-03-06"]}]`,
+	})
+	options := scheduleFeedOptions{Term: 10, Days: 5}
+	feed, err := server.renderScheduleFeed(context.Background(), options, feedFormatRSS, time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var parsed rssFeed
+	if err := xml.Unmarshal([]byte(feed), &parsed); err != nil {
+		t.Fatalf("Invalid RSS: %v", err)
+	}
+	if len(parsed.Channel.Items) != 2 {
+		t.Fatalf("Expected the two sitting days within 5 days, got %+v", parsed.Channel.Items)
+	}
+	if parsed.Channel.Items[0].Title != "2025-03-04 – Posiedzenie Sejmu nr 30 (dzień 1)" {
+		t.Errorf("Unexpected item title: %q", parsed.Channel.Items[0].Title)
+	}
+}
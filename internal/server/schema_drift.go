@@ -0,0 +1,341 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Kinds of schema drift between an API response and the types the server decodes it into
+const (
+	driftUnknownField = "unknown field"
+	driftTypeMismatch = "type mismatch"
+)
+
+// maxUnknownFieldChecks bounds how many elements of a list are compared with the expected type
+const maxUnknownFieldChecks = 50
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// schemaDriftEntry is one field of an API response that does not match the expected type
+type schemaDriftEntry struct {
+	Kind      string    `json:"kind"`
+	Field     string    `json:"field"`
+	Detail    string    `json:"detail,omitempty"`
+	Count     int64     `json:"count"`
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
+// schemaDriftLog collects the schema drift seen since the server started; each field is logged once
+type schemaDriftLog struct {
+	mu      sync.Mutex
+	entries map[string]*schemaDriftEntry
+	logger  *slog.Logger
+}
+
+func newSchemaDriftLog(logger *slog.Logger) *schemaDriftLog {
+	return &schemaDriftLog{entries: make(map[string]*schemaDriftEntry), logger: logger}
+}
+
+// record counts one occurrence of drift of a field; servers built without a drift log record nothing
+func (d *schemaDriftLog) record(kind, field, detail string) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := time.Now()
+	key := kind + " " + field
+	entry, ok := d.entries[key]
+	if !ok {
+		entry = &schemaDriftEntry{Kind: kind, Field: field, Detail: detail, FirstSeen: now}
+		d.entries[key] = entry
+		// New fields are usually harmless additions; a changed type loses data
+		level := slog.LevelWarn
+		if kind == driftUnknownField {
+			level = slog.LevelInfo
+		}
+		d.logger.Log(context.Background(), level, "API schema drift detected", slog.String("kind", kind), slog.String("field", field), slog.String("detail", detail))
+	}
+	entry.Count++
+	entry.LastSeen = now
+}
+
+// snapshot returns the recorded drift ordered by field
+func (d *schemaDriftLog) snapshot() []schemaDriftEntry {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	entries := make([]schemaDriftEntry, 0, len(d.entries))
+	for _, entry := range d.entries {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Field != entries[j].Field {
+			return entries[i].Field < entries[j].Field
+		}
+		return entries[i].Kind < entries[j].Kind
+	})
+	return entries
+}
+
+// decodeAPIResponse decodes an API response into v without failing on schema drift. A field whose value no
+// longer fits its type is left empty and the rest of the response is kept; fields the types do not know are
+// ignored. Both are recorded as drift. Only malformed JSON and a response of an entirely different shape
+// are errors.
+func (s *SejmServer) decodeAPIResponse(data []byte, v any) error {
+	err := json.Unmarshal(data, v)
+	var syntaxErr *json.SyntaxError
+	if err != nil && errors.As(err, &syntaxErr) {
+		return err
+	}
+	target := reflect.TypeOf(v).Elem()
+	var generic any
+	if json.Unmarshal(data, &generic) != nil {
+		return err
+	}
+	if err != nil {
+		// One value of an unexpected type fails the whole response or leaves a zero value behind, so the
+		// offending fields are removed and the rest is decoded again
+		dropped := make(map[string]string)
+		generic = repairJSONValue(generic, target, typeName(target), dropped)
+		if generic == nil {
+			return err
+		}
+		repaired, marshalErr := json.Marshal(generic)
+		reflect.ValueOf(v).Elem().SetZero()
+		if marshalErr != nil || json.Unmarshal(repaired, v) != nil {
+			return err
+		}
+		for field, expected := range dropped {
+			s.drift.record(driftTypeMismatch, field, "value does not fit "+expected)
+		}
+	}
+
+	unknown := make(map[string]bool)
+	collectUnknownFields(generic, target, typeName(target), unknown)
+	for field := range unknown {
+		s.drift.record(driftUnknownField, field, "")
+	}
+	return nil
+}
+
+// typeName names a type for drift reports, e.g. 'sejm.MP' for []sejm.MP
+func typeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	return t.String()
+}
+
+// jsonFields maps the JSON names of the fields of a struct type, lowercased as the decoder matches them, to
+// their types; fields of embedded structs are included
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for key, value := range jsonFields(embedded) {
+					fields[key] = value
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+	return fields
+}
+
+// decodesItself tells whether a type has its own JSON decoder, whose input is not checked field by field
+func decodesItself(t reflect.Type) bool {
+	return t.Implements(jsonUnmarshalerType) || reflect.PointerTo(t).Implements(jsonUnmarshalerType)
+}
+
+// collectUnknownFields walks a generic JSON value alongside the type it is decoded into and collects the
+// object keys the type has no field for
+func collectUnknownFields(value any, t reflect.Type, path string, unknown map[string]bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if decodesItself(t) {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for key, item := range object {
+			fieldType, ok := fields[strings.ToLower(key)]
+			if !ok {
+				unknown[path+"."+key] = true
+				continue
+			}
+			collectUnknownFields(item, fieldType, path+"."+key, unknown)
+		}
+	case reflect.Slice, reflect.Array:
+		items, _ := value.([]any)
+		for i, item := range items {
+			if i == maxUnknownFieldChecks {
+				break
+			}
+			collectUnknownFields(item, t.Elem(), path, unknown)
+		}
+	case reflect.Map:
+		object, _ := value.(map[string]any)
+		for _, item := range object {
+			collectUnknownFields(item, t.Elem(), path, unknown)
+		}
+	}
+}
+
+// fitsType tells whether a generic JSON value decodes into a type
+func fitsType(value any, t reflect.Type) bool {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, reflect.New(t).Interface()) == nil
+}
+
+// repairJSONValue removes the fields of a generic JSON value that do not decode into their type, descending
+// into objects and lists so that only the offending fields are lost. The paths of removed fields are added to
+// dropped with the type they were expected to have.
+func repairJSONValue(value any, t reflect.Type, path string, dropped map[string]string) any {
+	if fitsType(value, t) {
+		return value
+	}
+	base := t
+	for base.Kind() == reflect.Pointer {
+		base = base.Elem()
+	}
+	switch base.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok || decodesItself(base) {
+			break
+		}
+		fields := jsonFields(base)
+		for key, item := range object {
+			fieldType, ok := fields[strings.ToLower(key)]
+			if !ok || fitsType(item, fieldType) {
+				continue
+			}
+			repaired := repairJSONValue(item, fieldType, path+"."+key, dropped)
+			if repaired == nil {
+				delete(object, key)
+			} else {
+				object[key] = repaired
+			}
+		}
+		return object
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]any)
+		if !ok {
+			break
+		}
+		for i, item := range items {
+			items[i] = repairJSONValue(item, base.Elem(), path, dropped)
+		}
+		return items
+	case reflect.Map:
+		object, ok := value.(map[string]any)
+		if !ok {
+			break
+		}
+		for key, item := range object {
+			object[key] = repairJSONValue(item, base.Elem(), path, dropped)
+		}
+		return object
+	}
+	dropped[path] = t.String()
+	return nil
+}
+
+func (s *SejmServer) registerRawJSONTool() {
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_raw_json",
+		Description: "Get the unprocessed JSON response of any Sejm or ELI API endpoint. Use it when another tool leaves out a field, reports that the API schema changed, or returns data that looks incomplete: the raw response shows every field exactly as the API sends it.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"api": map[string]interface{}{
+					"type":        "string",
+					"description": "Which API to call: 'sejm' (default) or 'eli'.",
+				},
+				"path": map[string]interface{}{
+					"type":        "string",
+					"description": "Endpoint path relative to the API base URL, e.g. '/sejm/term10/MP/1' for the Sejm API or '/acts/DU/2024/1' for the ELI API.",
+				},
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Query string of the request, e.g. 'limit=5&offset=10'.",
+				},
+			},
+			Required: []string{"path"},
+		},
+	}, s.handleGetRawJSON)
+}
+
+func (s *SejmServer) handleGetRawJSON(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_raw_json called", slog.Any("arguments", request.Params.Arguments))
+
+	base := s.sejmBaseURL
+	switch api := strings.ToLower(request.GetString("api", "sejm")); api {
+	case "sejm":
+	case "eli":
+		base = s.eliBaseURL
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid api '%s'. Use 'sejm' or 'eli'.", api)), nil
+	}
+	path := strings.TrimSpace(request.GetString("path", ""))
+	if !strings.HasPrefix(path, "/") || strings.Contains(path, "..") || strings.ContainsAny(path, "?#") {
+		return mcp.NewToolResultError("Parameter 'path' is required and must be an endpoint path starting with '/', e.g. '/sejm/term10/MP/1'. Pass query parameters in 'query'."), nil
+	}
+	query, err := url.ParseQuery(request.GetString("query", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid query: %v.", err)), nil
+	}
+	params := make(map[string]string, len(query))
+	for name := range query {
+		params[name] = query.Get(name)
+	}
+
+	// The response is passed on without the normalization of old terms' records done by makeAPIRequest
+	data, err := s.makeAPIRequestWithHeaders(ctx, base+path, params, map[string]string{"Accept": "application/json"})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve %s: %v.", path, err)), nil
+	}
+	return mcp.NewToolResultText(string(data)), nil
+}
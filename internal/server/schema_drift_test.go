@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+func TestDecodeAPIResponse(t *testing.T) {
+	server := NewSejmServer()
+
+	var votings []sejm.Voting
+	data := `[
+		{"votingNumber": 1, "date": "not a date", "title": "A", "newField": 1},
+		{"votingNumber": "2", "date": "2024-07-10T10:25:00", "title": "B"}
+	]`
+	if err := server.decodeAPIResponse([]byte(data), &votings); err != nil {
+		t.Fatalf("Expected drift to be tolerated, got %v", err)
+	}
+	if len(votings) != 2 || stringValue(votings[0].Title) != "A" || votings[0].Date != nil || stringValue(votings[1].Title) != "B" || votings[1].Date == nil {
+		t.Fatalf("Expected only the invalid date to be lost, got %+v", votings)
+	}
+
+	var voting sejm.Voting
+	if err := server.decodeAPIResponse([]byte(`{"votingNumber": "x", "title": "C"}`), &voting); err != nil {
+		t.Fatalf("Expected a type mismatch to be tolerated, got %v", err)
+	}
+	if stringValue(voting.Title) != "C" || voting.VotingNumber != nil {
+		t.Errorf("Expected the other fields to be decoded, got %+v", voting)
+	}
+
+	drift := make(map[string]string)
+	for _, entry := range server.drift.snapshot() {
+		drift[entry.Field] = entry.Kind
+	}
+	expected := map[string]string{
+		"sejm.Voting.date":         driftTypeMismatch,
+		"sejm.Voting.votingNumber": driftTypeMismatch,
+		"sejm.Voting.newField":     driftUnknownField,
+	}
+	for field, kind := range expected {
+		if drift[field] != kind {
+			t.Errorf("Expected %s drift of %s, got %v", kind, field, drift)
+		}
+	}
+
+	if err := server.decodeAPIResponse([]byte(`[{"title": `), &votings); err == nil {
+		t.Error("Expected malformed JSON to fail")
+	}
+	if err := server.decodeAPIResponse([]byte(`{"title": "D"}`), &votings); err == nil {
+		t.Error("Expected an object instead of a list to fail")
+	}
+}
+
+func TestHandleGetRawJSON(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{"/sejm/term10/MP/1": `{"id": 1, "newField": "raw"}`})
+
+	result, err := server.handleGetRawJSON(context.Background(), createMockRequest(map[string]interface{}{"path": "/sejm/term10/MP/1"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	if text := extractTextContent(result); !strings.Contains(text, `"newField": "raw"`) {
+		t.Errorf("Expected the raw response, got %s", text)
+	}
+
+	for _, path := range []string{"", "sejm/term10/MP", "/sejm/../MP", "/sejm/term10/MP?limit=1"} {
+		result, _ := server.handleGetRawJSON(context.Background(), createMockRequest(map[string]interface{}{"path": path}))
+		if !result.IsError {
+			t.Errorf("Expected path %q to be rejected", path)
+		}
+	}
+}
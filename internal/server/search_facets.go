@@ -0,0 +1,269 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// facetPageSize is the number of acts requested per search page while counting facets
+	facetPageSize = 500
+	// defaultFacetScan and maxFacetScan bound the acts eli_get_search_facets counts
+	defaultFacetScan = 1000
+	maxFacetScan     = 5000
+	// maxFacetValues is the number of values listed per facet in text output
+	maxFacetValues = 20
+)
+
+// searchFacetNames are the facets of eli_get_search_facets in output order
+var searchFacetNames = []string{"year", "type", "publisher", "status"}
+
+// facetValue is one value of a facet with the number of acts that have it
+type facetValue struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// actFacetValue returns the value of a facet for an act; acts without it are counted as 'none'
+func actFacetValue(act eli.Act, facet string) string {
+	var value string
+	switch facet {
+	case "year":
+		if act.Year != nil {
+			value = strconv.Itoa(int(*act.Year))
+		}
+	case "type":
+		value = stringValue(act.Type)
+	case "publisher":
+		value = stringValue(act.Publisher)
+	case "status":
+		value = stringValue(act.Status)
+	}
+	return valueOrDefault(value, "none")
+}
+
+// countFacets counts the acts per value of each facet. Years are ordered newest first, the other facets by
+// count and then by value.
+func countFacets(acts []eli.Act, facets []string) map[string][]facetValue {
+	counted := make(map[string][]facetValue, len(facets))
+	for _, facet := range facets {
+		counts := make(map[string]int)
+		for _, act := range acts {
+			counts[actFacetValue(act, facet)]++
+		}
+		values := make([]facetValue, 0, len(counts))
+		for value, count := range counts {
+			values = append(values, facetValue{Value: value, Count: count})
+		}
+		sort.Slice(values, func(i, j int) bool {
+			if facet == "year" {
+				return values[i].Value > values[j].Value
+			}
+			if values[i].Count != values[j].Count {
+				return values[i].Count > values[j].Count
+			}
+			return values[i].Value < values[j].Value
+		})
+		counted[facet] = values
+	}
+	return counted
+}
+
+// parseFacetNames validates a comma-separated list of facets; empty selects all of them
+func parseFacetNames(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return searchFacetNames, nil
+	}
+	wanted := make(map[string]bool)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		known := false
+		for _, facet := range searchFacetNames {
+			known = known || facet == name
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown facet '%s'. Use %s", name, strings.Join(searchFacetNames, ", "))
+		}
+		wanted[name] = true
+	}
+	var facets []string
+	for _, facet := range searchFacetNames {
+		if wanted[facet] {
+			facets = append(facets, facet)
+		}
+	}
+	return facets, nil
+}
+
+// scanSearchResults pages through the acts matching a search, up to limit acts. A failed page after the first
+// ends the scan with the acts read so far and the error.
+func (s *SejmServer) scanSearchResults(ctx context.Context, params map[string]string, limit int) (acts []eli.Act, matches int, err error) {
+	for offset := 0; offset < limit; offset += facetPageSize {
+		query := withParam(params, "limit", strconv.Itoa(min(facetPageSize, limit-offset)))
+		query["offset"] = strconv.Itoa(offset)
+		data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/acts/search", s.eliBaseURL), query)
+		if err != nil {
+			return acts, matches, err
+		}
+		var page actSearchPage
+		if err := s.decodeAPIResponse(data, &page); err != nil {
+			return acts, matches, fmt.Errorf("failed to parse search results: %w", err)
+		}
+		matches = max(matches, page.matches())
+		acts = append(acts, page.Items...)
+		if len(page.Items) == 0 || len(acts) >= matches {
+			break
+		}
+	}
+	return acts, matches, nil
+}
+
+func (s *SejmServer) handleGetSearchFacets(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("eli_get_search_facets called", slog.Any("arguments", request.Params.Arguments))
+
+	facets, err := parseFacetNames(request.GetString("facets", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid facets: %v.", err)), nil
+	}
+	maxScan := defaultFacetScan
+	if value := request.GetString("max_scan", ""); value != "" {
+		maxScan, err = strconv.Atoi(value)
+		if err != nil || maxScan < 1 || maxScan > maxFacetScan {
+			return mcp.NewToolResultError(fmt.Sprintf("Parameter 'max_scan' must be a number between 1 and %d.", maxFacetScan)), nil
+		}
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	// The filters use the names and API parameters of eli_search_acts, so a drill-down can be run there
+	params := make(map[string]string)
+	var filters []string
+	for _, filter := range []struct{ name, param string }{
+		{"title", "title"},
+		{"publisher", "publisher"},
+		{"year", "year"},
+		{"type", "type"},
+		{"keyword", "keyword"},
+		{"in_force", "inForce"},
+		{"institution", "releasedBy"},
+		{"date_from", "dateFrom"},
+		{"date_to", "dateTo"},
+	} {
+		value := strings.TrimSpace(request.GetString(filter.name, ""))
+		if filter.name == "keyword" {
+			value = strings.Join(splitKeywords(value), ",")
+		}
+		if value == "" {
+			continue
+		}
+		params[filter.param] = value
+		filters = append(filters, fmt.Sprintf("%s='%s'", filter.name, value))
+	}
+	if inForce := params["inForce"]; inForce != "" && inForce != "0" && inForce != "1" {
+		return mcp.NewToolResultError("Parameter 'in_force' must be '1' (acts in force) or '0' (acts not in force)."), nil
+	}
+
+	acts, matches, scanErr := s.scanSearchResults(ctx, params, maxScan)
+	if scanErr != nil && len(acts) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to search Polish legal acts database: %v. Please verify your filters are valid.", scanErr)), nil
+	}
+	if matches == 0 {
+		return mcp.NewToolResultError("No acts match the filters. Remove a filter or use a shorter title fragment."), nil
+	}
+	counted := countFacets(acts, facets)
+	complete := len(acts) >= matches
+
+	if format == "json" {
+		result := map[string]interface{}{
+			"matches":  matches,
+			"counted":  len(acts),
+			"complete": complete,
+			"filters":  filters,
+			"facets":   counted,
+		}
+		if scanErr != nil {
+			result["error"] = scanErr.Error()
+		}
+		data, _ := json.MarshalIndent(result, "", "  ")
+		if scanErr != nil {
+			return markPartial(mcp.NewToolResultText(string(data))), nil
+		}
+		return mcp.NewToolResultText(string(data)), nil
+	}
+
+	summary := []string{fmt.Sprintf("Matching acts: %d", matches)}
+	if complete {
+		summary = append(summary, fmt.Sprintf("Counted: all %d acts", len(acts)))
+	} else {
+		summary = append(summary, fmt.Sprintf("Counted: the first %d of %d acts in the API order", len(acts), matches))
+	}
+	if len(filters) > 0 {
+		summary = append(summary, "Filters: "+strings.Join(filters, ", "))
+	}
+
+	var results []string
+	for _, facet := range facets {
+		values := counted[facet]
+		results = append(results, fmt.Sprintf("By %s (%d values):", facet, len(values)))
+		for i, value := range values {
+			if i == maxFacetValues {
+				results = append(results, fmt.Sprintf("  ... and %d more; use format='json' for all values", len(values)-maxFacetValues))
+				break
+			}
+			results = append(results, fmt.Sprintf("  %s: %d (%.1f%%)", value.Value, value.Count, 100*float64(value.Count)/float64(len(acts))))
+		}
+	}
+
+	var nextActions []string
+	call := strings.Join(filters, ", ")
+	if call != "" {
+		call += ", "
+	}
+	for _, facet := range facets {
+		// The API cannot filter by status, so only the other facets can be drilled into
+		if values := counted[facet]; facet != "status" && len(values) > 1 && values[0].Value != "none" {
+			nextActions = append(nextActions,
+				fmt.Sprintf("Drill down: eli_get_search_facets with %s%s='%s'", call, facet, values[0].Value),
+				fmt.Sprintf("List the acts: eli_search_acts with %s%s='%s'", call, facet, values[0].Value))
+			break
+		}
+	}
+	nextActions = append(nextActions, "Structured counts: add format='json'")
+
+	var notes []string
+	if scanErr != nil {
+		notes = append(notes, fmt.Sprintf("Counting stopped after %d acts: %v.", len(acts), scanErr))
+	}
+	if !complete {
+		notes = append(notes, fmt.Sprintf("The counts cover only the acts read, so they show proportions rather than totals; raise max_scan (up to %d) or add filters for exact counts.", maxFacetScan))
+	}
+
+	response := StandardResponse{
+		Operation:   "Search Facets",
+		Status:      "Retrieved Successfully",
+		Summary:     summary,
+		Data:        results,
+		NextActions: nextActions,
+		Note:        strings.Join(notes, " "),
+	}
+	if !complete {
+		response.Status = "Partially Retrieved"
+	}
+	if scanErr != nil {
+		return markPartial(mcp.NewToolResultText(response.Format())), nil
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestHandleGetSearchFacets(t *testing.T) {
+	// 700 acts: years cycle through 2020-2022, every fourth act is a statute, and there is no status
+	const total = 700
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eli/acts/search" || r.URL.Query().Get("title") != "podatek" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var items []string
+		for i := offset; i < min(offset+limit, total); i++ {
+			docType := "Rozporządzenie"
+			if i%4 == 0 {
+				docType = "Ustawa"
+			}
+			items = append(items, fmt.Sprintf(`{"publisher": "DU", "year": %d, "pos": %d, "type": "%s"}`, 2020+i%3, i+1, docType))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"count": %d, "totalCount": %d, "items": [%s]}`, len(items), total, strings.Join(items, ","))
+	}))
+	t.Cleanup(mirror.Close)
+	server := NewSejmServerWithConfig(Config{ELIBaseURL: mirror.URL + "/eli"})
+
+	result, err := server.handleGetSearchFacets(context.Background(), createMockRequest(map[string]interface{}{"title": "podatek", "format": "json"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	var response struct {
+		Matches  int                     `json:"matches"`
+		Counted  int                     `json:"counted"`
+		Complete bool                    `json:"complete"`
+		Facets   map[string][]facetValue `json:"facets"`
+	}
+	if err := json.Unmarshal([]byte(extractTextContent(result)), &response); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if response.Matches != total || response.Counted != total || !response.Complete {
+		t.Fatalf("Expected all %d acts to be counted over two pages, got %+v", total, response)
+	}
+	expected := map[string]string{
+		"year":      "[{2022 233} {2021 233} {2020 234}]",
+		"type":      "[{Rozporządzenie 525} {Ustawa 175}]",
+		"publisher": "[{DU 700}]",
+		"status":    "[{none 700}]",
+	}
+	for facet, values := range expected {
+		if got := fmt.Sprint(response.Facets[facet]); got != values {
+			t.Errorf("Expected %s counts %s, got %s", facet, values, got)
+		}
+	}
+
+	result, _ = server.handleGetSearchFacets(context.Background(), createMockRequest(map[string]interface{}{"title": "podatek", "facets": "type", "max_scan": "100"}))
+	text := extractTextContent(result)
+	for _, expected := range []string{"Partially Retrieved", "the first 100 of 700 acts", "Rozporządzenie: 75 (75.0%)", "eli_search_acts with title='podatek', type='Rozporządzenie'"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+	if strings.Contains(text, "By year") {
+		t.Errorf("Expected only the requested facet, got:\n%s", text)
+	}
+
+	result, _ = server.handleGetSearchFacets(context.Background(), createMockRequest(map[string]interface{}{"facets": "author"}))
+	if !result.IsError {
+		t.Error("Expected an unknown facet to be rejected")
+	}
+}
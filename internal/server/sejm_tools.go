@@ -2,9 +2,12 @@ package server
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,12 +16,13 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
-const sejmBaseURL = "https://api.sejm.gov.pl"
+// defaultSejmBaseURL is the public Sejm API used when no other base URL is configured
+const defaultSejmBaseURL = "https://api.sejm.gov.pl"
 
 func (s *SejmServer) registerSejmTools() {
 	s.registerProcessesTools()
 	s.registerBilateralGroupsTools()
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_terms",
 		Description: "Retrieve list of all parliamentary terms with their duration, dates, and status information. Returns comprehensive information about each Sejm term including start/end dates, current status, number of sittings, and key statistics. Each term represents a 4-year electoral cycle with distinct political compositions, coalition arrangements, and legislative priorities. Terms reflect Poland's democratic development: earlier terms show the transition from communist rule, while recent terms demonstrate established democratic institutions. Term boundaries determine committee structures, club formations, and MP relationships. Current Term 10 (2019-2023) represents contemporary Polish parliamentary dynamics with established party system and EU integration framework. Essential for understanding Polish parliamentary history, analyzing legislative periods, contextualizing political developments, and tracking democratic institution evolution over time.",
 		InputSchema: mcp.ToolInputSchema{
@@ -26,7 +30,7 @@ func (s *SejmServer) registerSejmTools() {
 		},
 	}, s.handleGetTerms)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_clubs",
 		Description: "Retrieve comprehensive list of parliamentary clubs (kluby poselskie) and circles (koła poselskie) for a specific term. Returns detailed information about each political grouping including full names, membership counts, formation dates, logos, and current status. Clubs (minimum 15 MPs) receive proportional committee representation, allocated speaking time in debates, and stronger procedural rights compared to circles (minimum 3 MPs). These structures determine coalition formation, committee leadership distribution, and parliamentary influence patterns. Essential for understanding political dynamics, coalition structures, voting patterns, party discipline analysis, and the balance of power in the Sejm.",
 		InputSchema: mcp.ToolInputSchema{
@@ -40,7 +44,7 @@ func (s *SejmServer) registerSejmTools() {
 		},
 	}, s.handleGetClubs)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_club_details",
 		Description: "Retrieve detailed information about a specific parliamentary club (political party/group). Returns comprehensive club data including full name, abbreviations, membership details, formation history, leadership structure, contact information, and current status. Essential for detailed political analysis, understanding party structures, researching specific political organizations, and analyzing club composition changes over time.",
 		InputSchema: mcp.ToolInputSchema{
@@ -59,9 +63,9 @@ func (s *SejmServer) registerSejmTools() {
 		},
 	}, s.handleGetClubDetails)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_voting_details",
-		Description: "Get detailed information about a specific parliamentary voting including vote counts, MP-by-MP voting records, voting title, topic, date, and outcome. When PDF format is available, automatically converts to searchable text with page location mapping. Individual MP votes reveal party discipline patterns, coalition alignment, and potential cross-party cooperation. Analyzing vote-by-vote records can identify MPs who vote against party lines, abstain on controversial issues, or form temporary alliances across political divides. Essential for analyzing voting patterns, party discipline effectiveness, individual MP behavior, coalition stability assessment, and understanding specific legislative decisions that shaped Polish policy.",
+		Description: "Get detailed information about a specific parliamentary voting including vote counts, MP-by-MP voting records, voting title, topic, date, and outcome. Resolves the prints (druki) named in the voting title or topic and the legislative processes they belong to, so one call tells what exactly was voted on. When PDF format is available, automatically converts to searchable text with page location mapping. Individual MP votes reveal party discipline patterns, coalition alignment, and potential cross-party cooperation. Analyzing vote-by-vote records can identify MPs who vote against party lines, abstain on controversial issues, or form temporary alliances across political divides. Essential for analyzing voting patterns, party discipline effectiveness, individual MP behavior, coalition stability assessment, and understanding specific legislative decisions that shaped Polish policy.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -79,14 +83,26 @@ func (s *SejmServer) registerSejmTools() {
 				},
 				"format": map[string]interface{}{
 					"type":        "string",
-					"description": "Response format: 'json' for structured data (default), 'text' for PDF converted to searchable text with page numbers, 'pdf' for raw PDF download.",
+					"description": "Response format: 'json' for structured data (default; when the API lacks MP-level votes, as in older terms, they are parsed from the PDF), 'records' for MP-by-MP votes (name, club, vote) parsed from the official voting PDF with per-club tallies, 'text' for PDF converted to searchable text with page numbers, 'pdf' for raw PDF download.",
+				},
+				"include_context": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'false' to skip resolving the prints (druki) named in the voting title and topic and their legislative processes. Default: true, so the response tells what exactly was voted on.",
+				},
+				"include_roll_call": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'false' to skip the roll-call metadata: who chaired the voting, the item voted on (amendment, minority motion, whole bill...), the reading, amendment and motion numbers, the voting method and the majority required, read from the API, the voting title and topic and the PDF header. Default: true.",
+				},
+				"verify": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to cross-check the tallies of the API (votes cast, yes, no, abstentions, absent, overall and per club) against the numbers printed in the official voting PDF and list any discrepancies. Works with the 'json', 'records' and 'text' formats. Default: false.",
 				},
 			},
 			Required: []string{"sitting", "voting_number"},
 		},
 	}, s.handleGetVotingDetails)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_written_questions",
 		Description: "Retrieve parliamentary written questions (zapytania) - formal written inquiries submitted by MPs to government ministers. Written questions are similar to interpellations but typically require shorter response times. Returns detailed information including question title, submitting MP(s), target ministry/minister, submission and response dates, current status, and government replies. Essential for monitoring government accountability, tracking ministerial responsiveness, analyzing MP oversight activity, and researching specific policy concerns.",
 		InputSchema: mcp.ToolInputSchema{
@@ -98,7 +114,7 @@ func (s *SejmServer) registerSejmTools() {
 				},
 				"limit": map[string]interface{}{
 					"type":        "string",
-					"description": "Maximum number of written questions to return (default: 20). Use higher values (e.g., '50', '100') for comprehensive oversight analysis, but be aware of context limits.",
+					"description": "Maximum number of written questions to return (default: 20, maximum: 100). Use higher values (e.g., '50', '100') for comprehensive oversight analysis, but be aware of context limits.",
 				},
 				"offset": map[string]interface{}{
 					"type":        "string",
@@ -136,7 +152,7 @@ func (s *SejmServer) registerSejmTools() {
 		},
 	}, s.handleGetWrittenQuestions)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_search_voting_content",
 		Description: "Search for specific text within parliamentary voting documents and get precise page locations. Downloads voting PDFs, searches for specified terms, and returns detailed map showing exactly which pages contain each search term. Perfect for quickly locating specific MPs, voting topics, or legislative details within large voting documents without reading the entire text.",
 		InputSchema: mcp.ToolInputSchema{
@@ -166,14 +182,22 @@ func (s *SejmServer) registerSejmTools() {
 					"type":        "string",
 					"description": "Optional. Maximum number of matches to show per search term (default: 10, max: 50).",
 				},
+				"match_mode": map[string]interface{}{
+					"type":        "string",
+					"description": matchModeParamDescription,
+				},
+				"ignore_diacritics": map[string]interface{}{
+					"type":        "string",
+					"description": ignoreDiacriticsParamDescription,
+				},
 			},
 			Required: []string{"sitting", "voting_number", "search_terms"},
 		},
 	}, s.handleSearchVotingContent)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_proceedings",
-		Description: "Retrieve list of parliamentary proceedings (sessions) for a specific term, sorted by most recent first. Returns detailed information about each proceeding including dates, duration, topics discussed, and current status. Parliamentary proceedings represent the main sessions where MPs gather to debate, vote, and conduct official business. Essential for understanding current parliamentary activity, tracking legislative progress, and analyzing the timing of political decisions. Term 10 includes current 2025 parliamentary sessions.",
+		Description: "Retrieve list of parliamentary proceedings (sessions) for a specific term, sorted by most recent first. Returns detailed information about each proceeding including dates, duration, topics discussed, and current status. Parliamentary proceedings represent the main sessions where MPs gather to debate, vote, and conduct official business. Filter by sitting days with since and till, and add include_agenda='true' to list each proceeding's agenda (e.g. sessions in March 2024 with their agendas). Essential for understanding current parliamentary activity, tracking legislative progress, and analyzing the timing of political decisions. Term 10 includes current 2025 parliamentary sessions.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -183,17 +207,33 @@ func (s *SejmServer) registerSejmTools() {
 				},
 				"limit": map[string]interface{}{
 					"type":        "string",
-					"description": "Maximum number of proceedings to return (default: 20). Use higher values for comprehensive analysis but be aware of context limits.",
+					"description": "Maximum number of proceedings to return (default: 20, maximum: 100). Use higher values for comprehensive analysis but be aware of context limits.",
 				},
 				"offset": map[string]interface{}{
 					"type":        "string",
-					"description": "Starting position within the collection of results (default: 0). Use with limit for pagination. Since results are sorted by most recent first, offset='20' with limit='20' shows proceedings 21-40.",
+					"description": "Starting position within the collection of results (default: 0). Use with limit for pagination. Since results are sorted by most recent first, offset='20' with limit='20' shows proceedings 21-40. The output ends with the next_offset to use.",
+				},
+				"sort_by": map[string]interface{}{
+					"type":        "string",
+					"description": "Sort order: '-number' for most recent first (default) or 'number' for oldest first.",
+				},
+				"since": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only proceedings with a sitting day on or after this date (YYYY-MM-DD), e.g., '2024-03-01'.",
+				},
+				"till": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only proceedings with a sitting day on or before this date (YYYY-MM-DD), e.g., '2024-03-31'.",
+				},
+				"include_agenda": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to list the agenda items of each proceeding (default: 'false').",
 				},
 			},
 		},
 	}, s.handleGetProceedings)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_current_proceeding",
 		Description: "Retrieve information about the current active parliamentary proceeding (session). Returns details about the proceeding currently in progress or most recently concluded, including proceeding number, date, status, topics being discussed, and timing information. Parliamentary proceedings represent the main sessions where MPs gather for debates, voting, and official business following constitutional procedures. Sessions typically span multiple days with structured agendas covering legislative readings, government questions, committee reports, and formal votes. Current proceedings reflect ongoing political dynamics, coalition cooperation, and government-opposition interactions. Essential for real-time parliamentary monitoring, understanding current legislative activity, tracking live democratic processes, following political developments, and staying updated on immediate parliamentary business.",
 		InputSchema: mcp.ToolInputSchema{
@@ -208,7 +248,25 @@ func (s *SejmServer) registerSejmTools() {
 		},
 	}, s.handleGetCurrentProceeding)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_daily_digest",
+		Description: "Compose a single report of everything that happened in the Sejm on one day: plenary votings with outcomes and tallies, committee sittings held with their agendas, prints (bills, reports) delivered, interpellations and written questions submitted, and video transmissions recorded. Replaces six separate calls, which makes it the starting point for morning briefings and news monitoring. Each section lists up to 25 entries and names the tool that returns the rest; a source that cannot be reached is reported without failing the whole digest.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
+				},
+				"date": map[string]interface{}{
+					"type":        "string",
+					"description": "Day to report on in YYYY-MM-DD format (e.g., '2024-03-08'). Defaults to today in Warsaw time.",
+				},
+			},
+		},
+	}, s.handleGetDailyDigest)
+
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_prints",
 		Description: "Retrieve parliamentary prints (legislative documents, bills, reports) for a specific term. Returns comprehensive information about each print including title, type, submitting MPs/institutions, submission date, current status in legislative process, and document details. Prints represent the entry point of the legislative process, containing proposed legislation that will progress through defined stages: committee assignment and review → first reading (general debate) → second reading (detailed examination, amendments) → third reading (final passage) → Senate review (30-day period) → Presidential action (21-day period). Prints submitted by government often have higher passage rates than MP-initiated legislation. Committee reports attached to prints show detailed analysis, expert testimonies, and amendment recommendations. Critical for tracking legislative proposals, analyzing lawmaking process efficiency, understanding political initiative patterns, and monitoring the complete journey from legislative idea to enacted law.",
 		InputSchema: mcp.ToolInputSchema{
@@ -220,11 +278,11 @@ func (s *SejmServer) registerSejmTools() {
 				},
 				"limit": map[string]interface{}{
 					"type":        "string",
-					"description": "Maximum number of prints to return (default: 30). Use higher values for comprehensive legislative analysis, but be aware of context limits.",
+					"description": "Maximum number of prints to return (default: 30, maximum: 100). Use higher values for comprehensive legislative analysis, but be aware of context limits.",
 				},
 				"offset": map[string]interface{}{
 					"type":        "string",
-					"description": "Starting position within the collection of results (default: 0). Use with limit for pagination through legislative documents.",
+					"description": "Starting position within the collection of results (default: 0). Use with limit for pagination through legislative documents. The output ends with the next_offset to use when more prints are available.",
 				},
 				"sort_by": map[string]interface{}{
 					"type":        "string",
@@ -234,354 +292,1337 @@ func (s *SejmServer) registerSejmTools() {
 		},
 	}, s.handleGetPrints)
 
-	s.server.AddTool(mcp.Tool{
-		Name:        "sejm_get_print_details",
-		Description: "Retrieve detailed information about a specific parliamentary print (legislative document). Returns comprehensive information including print title, description, submitting institution/MPs, submission date, current status in legislative process, document type, related proceedings, and complete metadata. Essential for tracking specific legislation, analyzing legislative proposals, understanding document flow through parliament, and researching the history and details of particular bills or reports.",
-		InputSchema: mcp.ToolInputSchema{
-			Type: "object",
-			Properties: map[string]interface{}{
-				"term": map[string]interface{}{
-					"type":        "string",
-					"description": "Parliamentary term number (1-10). Must match the term where the print was submitted.",
-				},
-				"num": map[string]interface{}{
-					"type":        "string",
-					"description": "Print number. Get this from sejm_get_prints results (the 'number' field).",
-				},
-			},
-			Required: []string{"term", "num"},
-		},
-	}, s.handleGetPrintDetails)
-
-	s.server.AddTool(mcp.Tool{
-		Name:        "sejm_get_print_attachment",
-		Description: "Download attachment files associated with parliamentary prints. Returns binary file content (PDFs, documents, images) that are attached to legislative documents and bills. Essential for accessing the full text of proposed legislation, supporting documentation, amendments, committee reports, legal analyses, and other materials that supplement the print metadata. Use this to get complete context and detailed content for print analysis.",
+	s.addTool(mcp.Tool{
+		Name:        "sejm_search_prints",
+		Description: "Search all prints of a term by title words, document type and author. Title words must all occur in the title, ignoring case and Polish diacritics, and match as fragments, so stems find every inflection. Types and authors are inferred from the titles, so 'housing bills submitted by the government' is query='mieszkani', type='government_bill' or author='government'. The author also matches the reports, opinions and self-amendments concerning a bill. Results are sorted by delivery date and paginated.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
 				"term": map[string]interface{}{
 					"type":        "string",
-					"description": "Parliamentary term number (1-10). Must match the term where the print was submitted.",
+					"description": "Parliamentary term number (1-10). Defaults to the current term.",
 				},
-				"num": map[string]interface{}{
+				"query": map[string]interface{}{
 					"type":        "string",
-					"description": "Print number. Get this from sejm_get_prints results.",
+					"description": "Words that must all occur in the title, e.g. 'mieszkani' or 'podatek dochodowy'. Use word stems to match all inflections.",
 				},
-				"attach_name": map[string]interface{}{
+				"type": map[string]interface{}{
 					"type":        "string",
-					"description": "Attachment file name. Get this from print details (attachments array).",
+					"description": "Optional. Comma-separated document types: government_bill, mps_bill, senate_bill, presidential_bill, citizens_bill, committee_bill, committee_report, senate_position, self_amendment, motion, opinion, report, draft_resolution, other.",
 				},
-			},
-			Required: []string{"term", "num", "attach_name"},
-		},
-	}, s.handleGetPrintAttachment)
-
-	s.server.AddTool(mcp.Tool{
-		Name:        "sejm_get_mps",
-		Description: "Retrieve comprehensive list of Members of Parliament (MPs) for a specific parliamentary term. Returns detailed information about all MPs including their personal details, political party affiliation (kluby poselskie and koła poselskie), electoral district, contact information, and current activity status. MPs organize into parliamentary clubs (kluby - minimum 15 MPs) and circles (koła - minimum 3 MPs) that determine committee representation, speaking time, and political influence. Current Term 10 includes major clubs: PiS (190 MPs), KO (156 MPs), Polska2050-TD (32 MPs), PSL-TD (32 MPs), Lewica (26 MPs), and Konfederacja (18 MPs). Essential for political analysis, research on parliamentary composition, coalition dynamics, party discipline analysis, and understanding the current makeup of the Polish Parliament.",
-		InputSchema: mcp.ToolInputSchema{
-			Type: "object",
-			Properties: map[string]interface{}{
-				"term": map[string]interface{}{
+				"author": map[string]interface{}{
 					"type":        "string",
-					"description": "Parliamentary term number (1-10). Each term lasts 4 years. Term 10 is current (2019-2023). Term 9 was 2015-2019, Term 8 was 2011-2015, etc. If not specified, defaults to current term (10). Use '10' for most recent data.",
+					"description": "Optional. Comma-separated authors of the bill a print is or concerns: government, mps, senate, president, citizens, committee. The print data does not name the MPs or club behind an MPs' bill.",
 				},
-				"limit": map[string]interface{}{
+				"date_from": map[string]interface{}{
 					"type":        "string",
-					"description": "Maximum number of MPs to return (default: 50, max: 500). Term 10 has 498 MPs total. Use higher values for comprehensive analysis, lower for focused research.",
+					"description": "Optional. Earliest delivery date (YYYY-MM-DD).",
 				},
-				"offset": map[string]interface{}{
+				"date_to": map[string]interface{}{
 					"type":        "string",
-					"description": "Number of MPs to skip for pagination (default: 0). Use with limit for browsing large MP lists. Example: offset='100' with limit='50' shows MPs 101-150.",
+					"description": "Optional. Latest delivery date (YYYY-MM-DD).",
 				},
-				"club": map[string]interface{}{
+				"sort": map[string]interface{}{
 					"type":        "string",
-					"description": "Filter by parliamentary club/party (e.g., 'PiS', 'KO', 'Lewica', 'PSL-TD', 'Polska2050-TD', 'Konfederacja'). Shows only MPs from specified political grouping.",
+					"description": "Optional. 'newest' (default) or 'oldest' delivery date first.",
 				},
-				"active": map[string]interface{}{
+				"limit": map[string]interface{}{
 					"type":        "string",
-					"description": "Filter by MP status: 'true' for active MPs only, 'false' for inactive, empty for all. Active MPs are currently serving in parliament.",
+					"description": "Maximum number of prints to return (default: 30).",
 				},
-				"last_name": map[string]interface{}{
+				"offset": map[string]interface{}{
 					"type":        "string",
-					"description": "Filter by MP last name (case-insensitive partial match). Examples: 'Kowal' finds Kowalski, 'Tusk' finds Donald Tusk. Useful for finding specific MPs or name patterns.",
+					"description": "Number of matching prints to skip (default: 0).",
 				},
-				"summary_only": map[string]interface{}{
+				"format": map[string]interface{}{
 					"type":        "string",
-					"description": "Return condensed information: 'true' for summary mode (name, club, district only), 'false' for full details (default). Summary mode provides faster responses with essential information for large datasets.",
+					"description": "Output format: 'text' (default) or 'json'.",
 				},
 			},
 		},
-	}, s.handleGetMPs)
+	}, s.handleSearchPrints)
 
-	s.server.AddTool(mcp.Tool{
-		Name:        "sejm_get_mp_details",
-		Description: "Get comprehensive biographical and political information about a specific Member of Parliament. Returns detailed profile including full name variations (for Polish grammar cases), birth information, education level, profession, electoral district details, political party membership (klub/koło affiliation), voting statistics, contact information, and current mandate status. Club membership determines committee assignments, leadership opportunities, speaking time allocation, and parliamentary influence. MP data includes relationships to committees, voting patterns that may reflect party discipline, bill authorship, and interpellation activity. Essential for creating MP profiles, analyzing individual political careers, understanding party dynamics, verifying MP credentials, or researching specific politicians and their political networks.",
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_recent_prints",
+		Description: "Legislative news feed: the prints (druki) delivered to the Sejm today or in the last N days, newest first and grouped by delivery date, each classified from its title as a government bill, MPs' bill, Senate, presidential, citizens' or committee bill, committee report, Senate position, self-amendment, motion, opinion, report or draft resolution. Counts per type tell at a glance what reached the Sejm, without paging sejm_get_prints by date.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
 				"term": map[string]interface{}{
 					"type":        "string",
-					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified. Different terms may have different MPs due to elections or mandate changes.",
+					"description": "Parliamentary term number (1-10). Defaults to the current term.",
 				},
-				"mp_id": map[string]interface{}{
+				"days": map[string]interface{}{
 					"type":        "string",
-					"description": "Unique MP identification number within the specified term. Get this ID from sejm_get_mps tool first. Each MP has a unique numeric ID that identifies them within their term (e.g., '1', '2', '123').",
+					"description": "Number of days back from today, including today (default: 7, maximum: 90). Use '1' for the prints delivered today.",
 				},
-			},
-			Required: []string{"mp_id"},
-		},
-	}, s.handleGetMPDetails)
-
-	s.server.AddTool(mcp.Tool{
-		Name:        "sejm_get_mp_complete_profile",
-		Description: "Get comprehensive MP profile combining biographical information, voting statistics, and committee memberships in a single request. This composite endpoint reduces the number of API calls from 4+ to 1 for complete MP analysis. Returns detailed MP profile including personal information, political party affiliation, electoral district, voting statistics (attendance rates, participation patterns), committee memberships with roles and appointment dates, and performance metrics. Essential for journalists researching MPs, citizens evaluating their representatives, academics studying parliamentary behavior, and transparency organizations creating accountability dashboards. Provides complete MP overview for democratic oversight and political analysis.",
-		InputSchema: mcp.ToolInputSchema{
-			Type: "object",
-			Properties: map[string]interface{}{
-				"term": map[string]interface{}{
+				"type": map[string]interface{}{
 					"type":        "string",
-					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified. Different terms may have different MPs due to elections or mandate changes.",
+					"description": "Optional. Only prints of one type: 'government_bill', 'mps_bill', 'senate_bill', 'presidential_bill', 'citizens_bill', 'committee_bill', 'committee_report', 'senate_position', 'self_amendment', 'motion', 'opinion', 'report', 'draft_resolution' or 'other'. The counts per type still cover every print.",
 				},
-				"mp_id": map[string]interface{}{
+				"format": map[string]interface{}{
 					"type":        "string",
-					"description": "Unique MP identification number within the specified term. Get this ID from sejm_get_mps tool first. Each MP has a unique numeric ID that identifies them within their term (e.g., '1', '2', '123').",
+					"description": "Output format: 'text' (default) or 'json'.",
 				},
 			},
-			Required: []string{"mp_id"},
 		},
-	}, s.handleGetMPCompleteProfile)
+	}, s.handleGetRecentPrints)
 
-	s.server.AddTool(mcp.Tool{
-		Name:        "sejm_get_committees",
-		Description: "Retrieve complete list of parliamentary committees with their structure, membership, and operational details. Returns information about standing committees (komisje stałe - permanent, 29 in Term 10), extraordinary committees (komisje nadzwyczajne - special purpose), and investigative committees (komisje śledcze - parliamentary inquiry bodies). Committee membership reflects proportional representation from parliamentary clubs, with leadership positions distributed based on political strength. Key committees include UST (Legislative - reviews all bills for legal consistency), FPB (Public Finance - budget oversight), SPC (Justice - legal system oversight), SUE (EU Affairs - European legislation). Each committee entry includes official name, code, appointed members with their roles, scope of work, and subcommittees. Critical for understanding parliamentary workflow, policy expertise distribution, and cross-party cooperation patterns.",
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_print_details",
+		Description: "Retrieve detailed information about a specific parliamentary print (legislative document). Returns comprehensive information including print title, description, submitting institution/MPs, submission date, current status in legislative process, document type, related proceedings, and complete metadata. Essential for tracking specific legislation, analyzing legislative proposals, understanding document flow through parliament, and researching the history and details of particular bills or reports.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
 				"term": map[string]interface{}{
 					"type":        "string",
-					"description": "Parliamentary term number (1-10). Committee structure can change between terms. Current term is 10. Use this to see how committee organization has evolved over different parliamentary periods.",
+					"description": "Parliamentary term number (1-10). Must match the term where the print was submitted.",
+				},
+				"num": map[string]interface{}{
+					"type":        "string",
+					"description": "Print number. Get this from sejm_get_prints results (the 'number' field).",
 				},
 			},
+			Required: []string{"term", "num"},
 		},
-	}, s.handleGetCommittees)
+	}, s.handleGetPrintDetails)
 
-	s.server.AddTool(mcp.Tool{
-		Name:        "sejm_get_committee_details",
-		Description: "Retrieve detailed information about a specific parliamentary committee. Returns comprehensive committee data including full name, description, scope of work, complete membership list with roles (chairperson, deputy chairpersons from different parties for balance), appointment dates, contact information, subcommittees, and current status. Committee leadership structure reflects proportional representation and cross-party cooperation, with major clubs sharing leadership roles. Committee work often transcends party lines on technical issues, though political divisions may emerge on controversial topics. Essential for understanding committee structure, analyzing MP roles and responsibilities, researching policy expertise distribution, and tracking inter-party cooperation patterns.",
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_print_graph",
+		Description: "Map the document network around a parliamentary print: additional prints (e.g., '100-A' autopoprawki), associated prints, prints considered jointly in the same legislative process, committee reports and other prints referenced by process stages, together with the stage tree (referrals to committees, readings, decisions). Returns a list of linked prints with their roles, the links between them, and the process stages. Use this to see every document of a bill at once instead of assembling it from sejm_get_print_details and sejm_get_process_details.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
 				"term": map[string]interface{}{
 					"type":        "string",
-					"description": "Parliamentary term number (1-10). Must match the term where the committee was active.",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
 				},
-				"committee_code": map[string]interface{}{
+				"num": map[string]interface{}{
 					"type":        "string",
-					"description": "Committee code (e.g., 'ENM', 'ASW', 'SUE'). Get this from sejm_get_committees results.",
+					"description": "Print number. Get this from sejm_get_prints results (the 'number' field). Any print of the process can be used.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default, readable lists and stage tree) or 'json' (nodes, edges and stages for further processing).",
 				},
 			},
-			Required: []string{"term", "committee_code"},
+			Required: []string{"num"},
 		},
-	}, s.handleGetCommitteeDetails)
+	}, s.handleGetPrintGraph)
 
-	s.server.AddTool(mcp.Tool{
-		Name:        "sejm_search_votings",
-		Description: "Search and analyze parliamentary voting records with detailed vote counts and outcomes. Returns comprehensive voting data including vote title, topic, description, voting type (electronic/traditional/on list), date and time, sitting information, vote tallies (yes/no/abstain/not participating), majority type required, and whether the vote passed. Voting patterns reveal party discipline, coalition dynamics, and cross-party cooperation on specific issues. Government-opposition divisions typically emerge on major legislation, while technical bills may see broader consensus. MP individual voting behavior can indicate party loyalty, personal convictions, or constituency pressures. Essential for political analysis, tracking coalition stability, analyzing party discipline, studying legislative success rates, measuring parliamentary attendance, understanding government-opposition dynamics, and identifying pivotal votes that shaped policy outcomes.\n\nIMPORTANT: You must provide EITHER 'sitting' OR 'title' parameter (not both, not neither). Use 'sitting' to get all votes from a specific parliamentary session, or 'title' to search across multiple sessions for votes matching keywords.",
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_print_attachment",
+		Description: "Download attachment files associated with parliamentary prints. Returns file metadata and, on request, the file itself as base64 blob content or an MCP resource (PDFs, documents, images attached to legislative documents and bills), or its extracted text. Essential for accessing the full text of proposed legislation, supporting documentation, amendments, committee reports, legal analyses, and other materials that supplement the print metadata. Use this to get complete context and detailed content for print analysis.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
 				"term": map[string]interface{}{
 					"type":        "string",
-					"description": "Parliamentary term number (1-10). Each term has different voting records. Current term 10 covers 2019-2023 voting activity.",
-				},
-				"sitting": map[string]interface{}{
-					"type":        "string",
-					"description": "Specific parliamentary sitting number to get detailed votes from that sitting (e.g., '1', '2', '15', '25'). When provided, returns actual voting records with titles, vote counts, and results from that sitting. Recent sittings for term 10: 1-50+ are available. Use this when you want comprehensive voting data from a specific parliamentary session. MUTUALLY EXCLUSIVE with 'title' parameter.",
+					"description": "Parliamentary term number (1-10). Must match the term where the print was submitted.",
 				},
-				"title": map[string]interface{}{
+				"num": map[string]interface{}{
 					"type":        "string",
-					"description": "Search for votes containing specific keywords in their titles or topics (e.g., 'budget', 'ustawa', 'projekt', 'konstytucja'). Searches across recent proceedings (last 20 sessions) for matching votes. Use this to find votes on specific topics or legislation across multiple sittings. MUTUALLY EXCLUSIVE with 'sitting' parameter.",
+					"description": "Print number. Get this from sejm_get_prints results.",
 				},
-				"limit": map[string]interface{}{
+				"attach_name": map[string]interface{}{
 					"type":        "string",
-					"description": "Maximum number of voting records to return (default: 20). Use higher values (e.g., '50', '100') for comprehensive analysis, lower values for quick overviews.",
+					"description": "Attachment file name. Get this from print details (attachments array).",
 				},
-				"offset": map[string]interface{}{
+				"extract_text": map[string]interface{}{
 					"type":        "string",
-					"description": "Number of results to skip for pagination (default: 0). Use with limit for browsing through large result sets. Example: offset='50' with limit='20' gets results 51-70.",
+					"description": "Set to 'true' to include the attachment's readable text (PDF, DOCX, ODT, RTF or TXT) in the response, truncated to 10000 characters.",
 				},
-				"date_from": map[string]interface{}{
+				"return_content": map[string]interface{}{
 					"type":        "string",
-					"description": "Start date for voting search in YYYY-MM-DD format (e.g., '2023-01-01'). Only returns votes from this date onwards. Use with date_to for date range searches.",
+					"description": "How to return the file itself: 'none' (default, metadata only), 'blob' (embed the file as base64 content with its MIME type) or 'resource' (register the file as an MCP resource and return a link the client can read with resources/read).",
 				},
-				"date_to": map[string]interface{}{
+				"max_size_bytes": map[string]interface{}{
 					"type":        "string",
-					"description": "End date for voting search in YYYY-MM-DD format (e.g., '2023-12-31'). Only returns votes up to this date. Use with date_from for date range searches.",
+					"description": "Maximum file size returned with return_content (default: 5242880 bytes = 5 MB, max: 20971520 = 20 MB). Larger files are reported with metadata only.",
 				},
 			},
+			Required: []string{"term", "num", "attach_name"},
 		},
-	}, s.handleSearchVotings)
+	}, s.handleGetPrintAttachment)
 
-	s.server.AddTool(mcp.Tool{
-		Name:        "sejm_get_interpellations",
-		Description: "Retrieve parliamentary interpellations - formal written questions submitted by MPs to government ministers requiring official responses. These are a key tool of parliamentary oversight and government accountability. Returns detailed information including question title, submitting MP(s), target ministry/minister, submission and response dates, current status, response delays, and government replies. Critical for monitoring government accountability, tracking ministerial responsiveness, analyzing MP oversight activity, identifying policy concerns, researching government performance, and studying democratic accountability mechanisms. Use this to investigate government responsiveness, track specific policy issues, or analyze MP engagement with executive oversight.",
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_print_ria",
+		Description: "Extract the regulatory impact assessment (Ocena Skutków Regulacji, OSR) of a bill from its print attachments, without downloading the whole bill package. Looks for an attachment named as an OSR first, then for the OSR form at the end of the justification. Returns the form split into its numbered sections (problem, recommended solution, affected entities, consultations, impact on public finances, on competitiveness, on the labour market, evaluation...), with the key tables of amounts by year in sections 6 and 7. Government bills carry an OSR; bills of MPs, committees and citizens usually do not.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
 				"term": map[string]interface{}{
 					"type":        "string",
-					"description": "Parliamentary term number (1-10). Current term 10 covers 2019-2023 oversight activity. Each term reflects different political dynamics and government accountability patterns.",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
 				},
-				"limit": map[string]interface{}{
+				"num": map[string]interface{}{
 					"type":        "string",
-					"description": "Maximum number of interpellations to return (default: 20). Use higher values (e.g., '50', '100') for comprehensive oversight analysis, but be aware of context limits. Large datasets useful for trend analysis and accountability studies.",
+					"description": "Print number of the bill. Get this from sejm_get_prints results.",
 				},
-				"offset": map[string]interface{}{
+				"section": map[string]interface{}{
 					"type":        "string",
-					"description": "Starting position within the collection of results (default: 0). Use with limit for pagination through large datasets. For example, offset='50' with limit='50' returns results 51-100.",
+					"description": "Optional. Return only this numbered section of the OSR form (1-13), e.g. '6' for the impact on public finances or '7' for the impact on businesses and citizens.",
 				},
-				"sort_by": map[string]interface{}{
+				"format": map[string]interface{}{
 					"type":        "string",
-					"description": "Sort interpellations by specified field. Add minus sign for descending order (e.g., '-lastModified' for newest first, 'title' for alphabetical). Common fields: 'lastModified', 'title', 'receiptDate'.",
+					"description": "Output format: 'text' (default) or 'json'.",
 				},
 			},
+			Required: []string{"num"},
 		},
-	}, s.handleGetInterpellations)
+	}, s.handleGetPrintRIA)
 
-	s.server.AddTool(mcp.Tool{
-		Name:        "sejm_get_interpellation_body",
-		Description: "Retrieve the full HTML body content of a specific parliamentary interpellation. Returns the complete text of the interpellation question as submitted by MPs to government ministers. Essential for analyzing the detailed content, specific questions asked, legal references cited, and policy concerns raised. Use this after finding interpellations with sejm_get_interpellations to get the full question text for detailed analysis, research, or transparency reporting.",
+	s.addTool(mcp.Tool{
+		Name:        "sejm_search_print_attachments",
+		Description: "Find attachments of all prints of a term by type, file extension or file name, e.g. every print with a regulatory impact assessment (OSR), every .docx annex or all opinions, optionally within a document date range. Returns the print number, title and date with the attachment file name and download URL, the coordinates sejm_get_print_attachment needs. Useful for regulatory analysis pipelines that would otherwise open every print.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
 				"term": map[string]interface{}{
 					"type":        "string",
-					"description": "Parliamentary term number (1-10). Must match the term where the interpellation was submitted.",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
 				},
-				"num": map[string]interface{}{
+				"type": map[string]interface{}{
 					"type":        "string",
-					"description": "Interpellation number. Get this from sejm_get_interpellations results (the 'num' field).",
+					"description": "Optional. Comma-separated attachment types recognized by file name: 'ria' (regulatory impact assessment, OSR), 'annex' (załącznik), 'opinion', 'regulation_draft' (draft implementing regulations).",
 				},
-			},
-			Required: []string{"term", "num"},
-		},
-	}, s.handleGetInterpellationBody)
-
-	s.server.AddTool(mcp.Tool{
-		Name:        "sejm_get_interpellation_reply_body",
-		Description: "Retrieve the full HTML body content of a government reply to a parliamentary interpellation. Returns the complete ministerial response including policy explanations, statistical data, legal interpretations, and action plans. Critical for analyzing government accountability, policy responses, ministerial performance, and the quality of democratic oversight. Use this to examine how thoroughly government addresses MP concerns and parliamentary questions.",
-		InputSchema: mcp.ToolInputSchema{
-			Type: "object",
-			Properties: map[string]interface{}{
-				"term": map[string]interface{}{
+				"extension": map[string]interface{}{
 					"type":        "string",
-					"description": "Parliamentary term number (1-10). Must match the term where the interpellation was submitted.",
+					"description": "Optional. Comma-separated file extensions, e.g. 'docx' or 'pdf,odt'.",
 				},
-				"num": map[string]interface{}{
+				"name_contains": map[string]interface{}{
 					"type":        "string",
-					"description": "Interpellation number. Get this from sejm_get_interpellations results.",
+					"description": "Optional. Text the file name must contain, ignoring case and Polish diacritics.",
 				},
-				"key": map[string]interface{}{
+				"date_from": map[string]interface{}{
 					"type":        "string",
-					"description": "Reply key/identifier. Get this from the interpellation details (replies array in sejm_get_interpellations results).",
+					"description": "Optional. Only prints dated on or after this date (YYYY-MM-DD).",
 				},
-			},
-			Required: []string{"term", "num", "key"},
-		},
-	}, s.handleGetInterpellationReplyBody)
-
-	s.server.AddTool(mcp.Tool{
-		Name:        "sejm_get_interpellation_attachment",
-		Description: "Download attachment files associated with parliamentary interpellations. Returns binary file content (PDFs, documents, images) that MPs include with their interpellations or that ministries attach to their replies. Essential for accessing supporting documentation, legal references, statistical data, charts, reports, and evidence that supplement the interpellation text. Use this to get complete context and supporting materials for interpellation analysis.",
-		InputSchema: mcp.ToolInputSchema{
-			Type: "object",
-			Properties: map[string]interface{}{
-				"term": map[string]interface{}{
+				"date_to": map[string]interface{}{
 					"type":        "string",
-					"description": "Parliamentary term number (1-10). Must match the term where the interpellation was submitted.",
+					"description": "Optional. Only prints dated on or before this date (YYYY-MM-DD).",
 				},
-				"key": map[string]interface{}{
+				"limit": map[string]interface{}{
 					"type":        "string",
-					"description": "Attachment key/identifier. Get this from interpellation details (attachments array).",
+					"description": "Maximum number of attachments to return (default: 50, maximum: 100).",
 				},
-				"file_name": map[string]interface{}{
+				"offset": map[string]interface{}{
+					"type":        "string",
+					"description": "Number of matching attachments to skip, for the next page (default: 0).",
+				},
+				"format": map[string]interface{}{
 					"type":        "string",
-					"description": "Attachment file name. Get this from interpellation details (attachments array).",
+					"description": "Output format: 'text' (default) or 'json' (with download URLs).",
 				},
 			},
-			Required: []string{"term", "key", "file_name"},
 		},
-	}, s.handleGetInterpellationAttachment)
+	}, s.handleSearchPrintAttachments)
 
-	s.server.AddTool(mcp.Tool{
-		Name:        "sejm_get_transcripts",
-		Description: "Retrieve parliamentary proceeding transcripts - complete stenographic records of parliamentary debates, speeches, and discussions. Returns detailed transcript information including individual MP statements, speech timestamps, debate topics, speaker identification, and full text content. For large PDF transcripts, use pagination parameters (page, pages_per_chunk) to manage response size and avoid context overflow. For statement lists with hundreds of statements, use limit and offset for efficient pagination. Essential for analyzing parliamentary debates, tracking MP positions on issues, studying political discourse, researching specific policy discussions, and understanding the legislative decision-making process.",
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_print_text",
+		Description: "Extract readable text from a parliamentary print's main document (bill text, justification, committee report). Downloads the print's main attachment (PDF, DOCX, ODT, RTF or TXT) and returns its text with page-based pagination, so long bills can be read chunk by chunk without exceeding response limits. Use show_page_info='true' first to learn the page count of large documents. Essential for reading the actual content of proposed legislation rather than only its metadata.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
 				"term": map[string]interface{}{
 					"type":        "string",
-					"description": "Parliamentary term number (1-10). Current term 10 covers 2019-2023 parliamentary debates.",
-				},
-				"proceeding_id": map[string]interface{}{
-					"type":        "string",
-					"description": "Parliamentary proceeding/sitting number (e.g., '1', '15', '30'). Get this from sejm_get_proceedings results.",
+					"description": "Parliamentary term number (1-10). Must match the term where the print was submitted. Defaults to current term (10).",
 				},
-				"date": map[string]interface{}{
+				"num": map[string]interface{}{
 					"type":        "string",
-					"description": "Proceeding date in YYYY-MM-DD format (e.g., '2023-11-13'). Get this from sejm_get_proceedings results.",
+					"description": "Print number. Get this from sejm_get_prints results (the 'number' field).",
 				},
-				"format": map[string]interface{}{
+				"attach_name": map[string]interface{}{
 					"type":        "string",
-					"description": "Response format: 'list' for statement list (default), 'pdf' for complete transcript as PDF, 'text' for PDF converted to searchable text.",
+					"description": "Optional. Attachment file name from print details (attachments array). If omitted, the first PDF attachment is used, falling back to DOCX, ODT, RTF or TXT.",
 				},
-				"limit": map[string]interface{}{
+				"page": map[string]interface{}{
 					"type":        "string",
-					"description": "For 'list' format: Maximum number of statements to return (default: 25, max: 100). Use for pagination through potentially hundreds of statements per proceeding.",
+					"description": "Starting page number (default: 1).",
 				},
-				"offset": map[string]interface{}{
+				"pages_per_chunk": map[string]interface{}{
 					"type":        "string",
-					"description": "For 'list' format: Number of statements to skip (default: 0). Use with limit for pagination through statement lists.",
+					"description": "Number of pages to return at once (default: 5, max: 20).",
 				},
-				"page": map[string]interface{}{
+				"show_page_info": map[string]interface{}{
 					"type":        "string",
-					"description": "For 'text' format: Starting page number (1-based). Use with pages_per_chunk to control output size. Default: 1.",
+					"description": "Set to 'true' to return only page count and navigation information instead of text.",
 				},
-				"pages_per_chunk": map[string]interface{}{
+				"summarize": map[string]interface{}{
 					"type":        "string",
-					"description": "For 'text' format: Number of pages to include per response (1-10). Helps manage large transcript responses. Default: 5.",
+					"description": "Optional. Set to 'true' to return an extractive summary instead of the text: the most representative sentences quoted verbatim, each with a pointer to the page it comes from. Computed on the server from word frequencies, without an LLM. Useful for very long documents.",
 				},
-				"show_page_info": map[string]interface{}{
+				"summary_sentences": map[string]interface{}{
 					"type":        "string",
-					"description": "For 'text' format: Set to 'true' to show page count and navigation info instead of content. Useful for understanding document structure.",
+					"description": "Optional. Number of sentences in the summary when summarize='true' (default: 15, maximum: 50).",
 				},
 			},
-			Required: []string{"proceeding_id", "date"},
+			Required: []string{"num"},
 		},
-	}, s.handleGetTranscripts)
+	}, s.handleGetPrintText)
 
-	s.server.AddTool(mcp.Tool{
-		Name:        "sejm_get_statement",
-		Description: "Retrieve individual MP statement from parliamentary transcript - complete text of a specific speech or intervention during parliamentary proceedings. Returns detailed statement content including speaker information, timestamp, full text, context within the debate, and related discussion. Essential for analyzing specific MP positions, studying individual political statements, researching particular policy arguments, and understanding detailed parliamentary discourse. Use this to get the complete text of specific speeches or interventions.",
+	s.addTool(mcp.Tool{
+		Name:        "sejm_search_print_content",
+		Description: "Search for keywords inside a parliamentary print's main document without downloading the full text. Returns each match with surrounding context and the page number where it occurs, so you can jump straight to the relevant pages with sejm_get_print_text. Supports multiple comma-separated search terms. Ideal for checking whether a bill mentions specific institutions, amounts, dates or legal provisions.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
 				"term": map[string]interface{}{
 					"type":        "string",
-					"description": "Parliamentary term number (1-10). Current term 10 covers 2019-2023 statements.",
+					"description": "Parliamentary term number (1-10). Must match the term where the print was submitted. Defaults to current term (10).",
 				},
-				"proceeding_id": map[string]interface{}{
+				"num": map[string]interface{}{
 					"type":        "string",
-					"description": "Parliamentary proceeding/sitting number. Get this from transcript list results.",
+					"description": "Print number. Get this from sejm_get_prints results (the 'number' field).",
 				},
-				"date": map[string]interface{}{
+				"attach_name": map[string]interface{}{
 					"type":        "string",
-					"description": "Proceeding date in YYYY-MM-DD format. Get this from transcript list results.",
+					"description": "Optional. Attachment file name from print details (attachments array). If omitted, the first PDF attachment is used, falling back to DOCX, ODT, RTF or TXT.",
 				},
-				"statement_num": map[string]interface{}{
+				"search_terms": map[string]interface{}{
 					"type":        "string",
-					"description": "Statement number within the proceeding (e.g., '1', '5', '23'). Get this from sejm_get_transcripts results.",
+					"description": "Comma-separated keywords or phrases to search for (e.g., 'podatek,VAT,akcyza'). Search is case-insensitive.",
+				},
+				"context_chars": map[string]interface{}{
+					"type":        "string",
+					"description": "Number of characters to show before and after each match (default: 100, min: 20, max: 500).",
+				},
+				"max_matches_per_term": map[string]interface{}{
+					"type":        "string",
+					"description": "Maximum number of matches to return per search term (default: 10, max: 50).",
+				},
+				"match_mode": map[string]interface{}{
+					"type":        "string",
+					"description": matchModeParamDescription,
+				},
+				"ignore_diacritics": map[string]interface{}{
+					"type":        "string",
+					"description": ignoreDiacriticsParamDescription,
+				},
+			},
+			Required: []string{"num", "search_terms"},
+		},
+	}, s.handleSearchPrintContent)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_mps",
+		Description: "Retrieve comprehensive list of Members of Parliament (MPs) for a specific parliamentary term. Returns detailed information about all MPs including their personal details, political party affiliation (kluby poselskie and koła poselskie), electoral district, contact information, and current activity status. MPs organize into parliamentary clubs (kluby - minimum 15 MPs) and circles (koła - minimum 3 MPs) that determine committee representation, speaking time, and political influence. Current Term 10 includes major clubs: PiS (190 MPs), KO (156 MPs), Polska2050-TD (32 MPs), PSL-TD (32 MPs), Lewica (26 MPs), and Konfederacja (18 MPs). Essential for political analysis, research on parliamentary composition, coalition dynamics, party discipline analysis, and understanding the current makeup of the Polish Parliament.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Each term lasts 4 years. Term 10 is current (2019-2023). Term 9 was 2015-2019, Term 8 was 2011-2015, etc. If not specified, defaults to current term (10). Use '10' for most recent data.",
+				},
+				"limit": map[string]interface{}{
+					"type":        "string",
+					"description": "Maximum number of MPs to return (default: 50, max: 500). Term 10 has 498 MPs total. Use higher values for comprehensive analysis, lower for focused research.",
+				},
+				"offset": map[string]interface{}{
+					"type":        "string",
+					"description": "Number of MPs to skip for pagination (default: 0). Use with limit for browsing large MP lists. Example: offset='100' with limit='50' shows MPs 101-150.",
+				},
+				"club": map[string]interface{}{
+					"type":        "string",
+					"description": "Filter by parliamentary club/party (e.g., 'PiS', 'KO', 'Lewica', 'PSL-TD', 'Polska2050-TD', 'Konfederacja'). Shows only MPs from specified political grouping.",
+				},
+				"active": map[string]interface{}{
+					"type":        "string",
+					"description": "Filter by MP status: 'true' for active MPs only, 'false' for inactive, empty for all. Active MPs are currently serving in parliament.",
+				},
+				"last_name": map[string]interface{}{
+					"type":        "string",
+					"description": "Filter by MP last name (case-insensitive partial match). Examples: 'Kowal' finds Kowalski, 'Tusk' finds Donald Tusk. Useful for finding specific MPs or name patterns.",
+				},
+				"summary_only": map[string]interface{}{
+					"type":        "string",
+					"description": "Return condensed information: 'true' for summary mode (name, club, district only), 'false' for full details (default). Summary mode provides faster responses with essential information for large datasets.",
+				},
+			},
+		},
+	}, s.handleGetMPs)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_mp_details",
+		Description: "Get comprehensive biographical and political information about a specific Member of Parliament. Returns detailed profile including full name variations (for Polish grammar cases), birth information, education level, profession, electoral district details, political party membership (klub/koło affiliation), voting statistics, contact information, and current mandate status. Club membership determines committee assignments, leadership opportunities, speaking time allocation, and parliamentary influence. MP data includes relationships to committees, voting patterns that may reflect party discipline, bill authorship, and interpellation activity. Essential for creating MP profiles, analyzing individual political careers, understanding party dynamics, verifying MP credentials, or researching specific politicians and their political networks.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified. Different terms may have different MPs due to elections or mandate changes.",
+				},
+				"mp_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Unique MP identification number within the specified term. Get this ID from sejm_get_mps tool first. Each MP has a unique numeric ID that identifies them within their term (e.g., '1', '2', '123').",
+				},
+			},
+			Required: []string{"mp_id"},
+		},
+	}, s.handleGetMPDetails)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_mp_contact",
+		Description: "Get structured contact data for constituent outreach: e-mail, electoral district, official sejm.gov.pl profile and photo links, and the contact details (e-mail, phone, fax) of the MP's club office. Works for a single MP (mp_id) or as a bulk export for a whole club or the whole Sejm (club), with csv and json output for mail merges and spreadsheets.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to the current term.",
+				},
+				"mp_id": map[string]interface{}{
+					"type":        "string",
+					"description": "MP identification number for a single MP. Get it from sejm_get_mps.",
+				},
+				"club": map[string]interface{}{
+					"type":        "string",
+					"description": "Club ID for a bulk export (e.g., 'KO', 'PiS', 'Lewica'), or 'all' for every MP. Get club IDs from sejm_get_clubs. Ignored when mp_id is given.",
+				},
+				"active_only": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'false' to include MPs whose mandate has expired in bulk exports. Default: true.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default, readable list), 'csv' (one row per MP with a header), or 'json' (array of contact objects).",
+				},
+			},
+		},
+	}, s.handleGetMPContact)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_mp_declarations",
+		Description: "List an MP's financial disclosures: asset declarations (oświadczenia majątkowe) and benefits register entries (rejestr korzyści), with the year and the address of each document. The Sejm API does not publish them, so they are read from the MP's page on sejm.gov.pl (terms 7-10). Read a document with sejm_get_mp_declaration_text.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (7-10). Defaults to current term (10) if not specified.",
+				},
+				"mp_id": map[string]interface{}{
+					"type":        "string",
+					"description": "MP ID number (e.g., '1', '123'). Find IDs with sejm_get_mps.",
+				},
+				"kind": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. 'assets' for asset declarations, 'benefits' for the benefits register, or 'all' (default).",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json'.",
+				},
+			},
+			Required: []string{"mp_id"},
+		},
+	}, s.handleGetMPDeclarations)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_mp_declaration_text",
+		Description: "Download an MP's asset declaration or benefits register document from sejm.gov.pl and extract its text, paginated like other documents. Can also return the PDF itself. Many declarations are scanned handwritten forms without a text layer; for those, request the file with return_content.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"url": map[string]interface{}{
+					"type":        "string",
+					"description": "Address of the document on sejm.gov.pl, as listed by sejm_get_mp_declarations.",
+				},
+				"page": map[string]interface{}{
+					"type":        "string",
+					"description": "Starting page number (default: 1).",
+				},
+				"pages_per_chunk": map[string]interface{}{
+					"type":        "string",
+					"description": "Number of pages to return at once (default: 5, max: 20).",
+				},
+				"show_page_info": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to return only page count and navigation information instead of text.",
+				},
+				"return_content": map[string]interface{}{
+					"type":        "string",
+					"description": "How to return the file itself: 'none' (default, text only), 'blob' (embed the file as base64 content with its MIME type) or 'resource' (register the file as an MCP resource and return a link the client can read with resources/read).",
+				},
+				"max_size_bytes": map[string]interface{}{
+					"type":        "string",
+					"description": "Maximum file size returned with return_content (default: 5242880 bytes = 5 MB, max: 20971520 = 20 MB).",
+				},
+			},
+			Required: []string{"url"},
+		},
+	}, s.handleGetMPDeclarationText)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_export_mps",
+		Description: "Export the full MP dataset of a term in one call: every field the API provides for every MP (names and their grammatical forms, club, district, voivodeship, birth date and place, education, profession, e-mail, number of votes, mandate status and expiry reason) as JSON or CSV. Optionally select fields, filter by club or active mandate, and receive the file as text, an embedded blob or an MCP resource. Use this for building datasets instead of sejm_get_mps followed by hundreds of sejm_get_mp_details calls.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to the current term.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'json' (default, array of objects) or 'csv' (one row per MP with a header of field names).",
+				},
+				"fields": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Comma-separated fields to export, in this order (e.g., 'id,lastFirstName,club,districtNum,numberOfVotes'). Default: all fields. Available: id, firstName, secondName, lastName, firstLastName, lastFirstName, accusativeName, genitiveName, active, inactiveCause, waiverDesc, club, districtNum, districtName, voivodeship, birthDate, birthLocation, educationLevel, profession, email, numberOfVotes.",
+				},
+				"club": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Export only MPs of this club (e.g., 'KO', 'PiS'). Get club IDs from sejm_get_clubs.",
+				},
+				"active_only": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to leave out MPs whose mandate has expired. Default: false, the whole term is exported.",
+				},
+				"return_content": map[string]interface{}{
+					"type":        "string",
+					"description": "How to return the export: 'text' (default, inline), 'blob' (embedded base64 file with its MIME type) or 'resource' (registered MCP resource the client can read with resources/read).",
+				},
+			},
+		},
+	}, s.handleExportMPs)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_electoral_results",
+		Description: "Rank MPs by the personal votes they received in the Sejm election, from the vote counts in the MP profiles, in a single request. Answers questions like 'MPs elected with the fewest votes', 'the strongest candidate in each district' or 'total votes of a club's MPs per voivodeship'. Filter by club, electoral district (number or name) or voivodeship, sort by most or fewest votes, or group by district, voivodeship or club with totals, medians and the extremes of each group. Each MP also gets their rank among the MPs elected in the same district.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to the current term.",
+				},
+				"sort": map[string]interface{}{
+					"type":        "string",
+					"description": "Order of the MPs: 'most' (default) or 'fewest' votes first.",
+				},
+				"group_by": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. 'district', 'voivodeship' or 'club' to sum up the results per group instead of listing MPs. Default: 'mp'.",
+				},
+				"club": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only MPs of this club (e.g., 'KO', 'PiS'). Get club IDs from sejm_get_clubs.",
+				},
+				"district": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only MPs elected in this district, by number (1-41, e.g. '19') or name (e.g. 'Warszawa').",
+				},
+				"voivodeship": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only MPs elected in this voivodeship (e.g., 'mazowieckie').",
+				},
+				"active_only": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to leave out MPs whose mandate has expired. Default: false.",
+				},
+				"limit": map[string]interface{}{
+					"type":        "string",
+					"description": "Number of MPs listed (default: 20, max: 600). Groups are always listed in full.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json'.",
+				},
+			},
+		},
+	}, s.handleGetElectoralResults)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_mps_demographics",
+		Description: "Get the social profile of the Sejm: distributions of MPs' gender, age at the start of the term, birth decades, education level and professions, for the whole term and per club, from a single list request per term instead of hundreds of MP detail calls. Compare several terms with from_term, or profile one club in full. Useful for sociological analysis of parliamentary representation.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to the current term. With from_term, the last term compared.",
+				},
+				"from_term": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. First term to compare, e.g. '7' with term='10' for terms 7-10.",
+				},
+				"club": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only MPs of this club (e.g., 'KO', 'PiS'). Get club IDs from sejm_get_clubs.",
+				},
+				"by_club": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'false' to leave out the breakdown per club. Default: true.",
+				},
+				"infer_gender": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'false' to leave out gender, which is inferred from first names ending in -a (the API has no gender field). Default: true.",
+				},
+				"active_only": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to count only MPs whose mandate is active. Default: false, every MP of the term is counted.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json'.",
+				},
+			},
+		},
+	}, s.handleGetMPsDemographics)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_mp_complete_profile",
+		Description: "Get comprehensive MP profile combining biographical information, voting statistics, and committee memberships in a single request. This composite endpoint reduces the number of API calls from 4+ to 1 for complete MP analysis. Returns detailed MP profile including personal information, political party affiliation, electoral district, voting statistics (attendance rates, participation patterns), committee memberships with roles and appointment dates, and performance metrics. Essential for journalists researching MPs, citizens evaluating their representatives, academics studying parliamentary behavior, and transparency organizations creating accountability dashboards. Provides complete MP overview for democratic oversight and political analysis.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified. Different terms may have different MPs due to elections or mandate changes.",
+				},
+				"mp_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Unique MP identification number within the specified term. Get this ID from sejm_get_mps tool first. Each MP has a unique numeric ID that identifies them within their term (e.g., '1', '2', '123').",
+				},
+			},
+			Required: []string{"mp_id"},
+		},
+	}, s.handleGetMPCompleteProfile)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_committees",
+		Description: "Retrieve complete list of parliamentary committees with their structure, membership, and operational details. Returns information about standing committees (komisje stałe - permanent, 29 in Term 10), extraordinary committees (komisje nadzwyczajne - special purpose), and investigative committees (komisje śledcze - parliamentary inquiry bodies). Committee membership reflects proportional representation from parliamentary clubs, with leadership positions distributed based on political strength. Key committees include UST (Legislative - reviews all bills for legal consistency), FPB (Public Finance - budget oversight), SPC (Justice - legal system oversight), SUE (EU Affairs - European legislation). Each committee entry includes official name, code, appointed members with their roles, scope of work, and subcommittees. Critical for understanding parliamentary workflow, policy expertise distribution, and cross-party cooperation patterns.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Committee structure can change between terms. Current term is 10. Use this to see how committee organization has evolved over different parliamentary periods.",
+				},
+			},
+		},
+	}, s.handleGetCommittees)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_committee_details",
+		Description: "Retrieve detailed information about a specific parliamentary committee. Returns comprehensive committee data including full name, description, scope of work, complete membership list with roles (chairperson, deputy chairpersons from different parties for balance), appointment dates, contact information, subcommittees, and current status. Committee leadership structure reflects proportional representation and cross-party cooperation, with major clubs sharing leadership roles. Committee work often transcends party lines on technical issues, though political divisions may emerge on controversial topics. Essential for understanding committee structure, analyzing MP roles and responsibilities, researching policy expertise distribution, and tracking inter-party cooperation patterns.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Must match the term where the committee was active.",
+				},
+				"committee_code": map[string]interface{}{
+					"type":        "string",
+					"description": "Committee code (e.g., 'ENM', 'ASW', 'SUE'). Get this from sejm_get_committees results.",
+				},
+			},
+			Required: []string{"term", "committee_code"},
+		},
+	}, s.handleGetCommitteeDetails)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_committee_members",
+		Description: "List members of a specific parliamentary committee with their committee function and club, optionally filtered by role (chairman, deputy chairman, secretary, ordinary member) and by club. Returns a compact membership table with MP IDs ready for follow-up calls to sejm_get_mp_details, sejm_get_mp_voting_stats and other MP tools. Committee leadership is usually shared between the largest clubs, so role and club filters make it easy to see who controls a committee and how opposition parties are represented. Use this instead of sejm_get_committee_details when you only need the membership list.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
+				},
+				"committee_code": map[string]interface{}{
+					"type":        "string",
+					"description": "Committee code (e.g., 'ENM', 'ASW', 'SUE'). Get this from sejm_get_committees results.",
+				},
+				"role": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Filter by committee function: 'chairman' (przewodniczący), 'deputy' (zastępca przewodniczącego), 'secretary' (sekretarz), 'leadership' (chairman and deputies) or 'member' (members without a function). Polish names are accepted as well.",
+				},
+				"club": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Filter by parliamentary club code (e.g., 'PiS', 'KO', 'Lewica'). Case-insensitive. Get club codes from sejm_get_clubs.",
+				},
+				"include_expired": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Set to 'true' to include members whose mandate has expired (default: 'false').",
+				},
+			},
+			Required: []string{"committee_code"},
+		},
+	}, s.handleGetCommitteeMembers)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_subcommittees",
+		Description: "Explore the subcommittee structure of parliamentary committees. Without codes, lists every committee that has subcommittees together with their codes. With committee_code, shows the parent committee's subcommittees with names, chairs, member counts and appointment dates. With subcommittee_code, shows the subcommittee's scope, its parent committee, members sorted by role with clubs and MP IDs, and optionally its recent sittings. Much of the detailed legislative work on bills happens in subcommittees, so their membership shows who actually drafts amendments.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
+				},
+				"committee_code": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Parent committee code (e.g., 'ASW', 'ENM') to list its subcommittees. Get this from sejm_get_committees results.",
+				},
+				"subcommittee_code": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Subcommittee code (e.g., 'ASW01S') to show its members and sittings. Get this from sejm_get_subcommittees results.",
+				},
+				"include_sittings": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Set to 'true' to list the subcommittee's most recent sittings when subcommittee_code is given (default: 'false'). Sitting data is not published for every subcommittee.",
+				},
+			},
+		},
+	}, s.handleGetSubcommittees)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_search_votings",
+		Description: "Search and analyze parliamentary voting records with detailed vote counts and outcomes. Returns comprehensive voting data including vote title, topic, description, voting type (electronic/traditional/on list), date and time, sitting information, vote tallies (yes/no/abstain/not participating), majority type required, and whether the vote passed. Voting patterns reveal party discipline, coalition dynamics, and cross-party cooperation on specific issues. Government-opposition divisions typically emerge on major legislation, while technical bills may see broader consensus. MP individual voting behavior can indicate party loyalty, personal convictions, or constituency pressures. Essential for political analysis, tracking coalition stability, analyzing party discipline, studying legislative success rates, measuring parliamentary attendance, understanding government-opposition dynamics, and identifying pivotal votes that shaped policy outcomes.\n\nIMPORTANT: You must provide EITHER 'sitting' OR 'title' parameter (not both, not neither). Use 'sitting' to get all votes from a specific parliamentary session, or 'title' to search across multiple sessions for votes matching keywords.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Each term has different voting records. Current term 10 covers 2019-2023 voting activity.",
+				},
+				"sitting": map[string]interface{}{
+					"type":        "string",
+					"description": "Specific parliamentary sitting number to get detailed votes from that sitting (e.g., '1', '2', '15', '25'). When provided, returns actual voting records with titles, vote counts, and results from that sitting. Recent sittings for term 10: 1-50+ are available. Use this when you want comprehensive voting data from a specific parliamentary session. MUTUALLY EXCLUSIVE with 'title' parameter.",
+				},
+				"title": map[string]interface{}{
+					"type":        "string",
+					"description": "Search for votes containing specific keywords in their titles or topics (e.g., 'budget', 'ustawa', 'projekt', 'konstytucja'). Searches the 20 most recent sittings with votings by default (see scan_scope), or the whole term when the server runs with a voting index (-voting-index-dir). Use this to find votes on specific topics or legislation across multiple sittings. MUTUALLY EXCLUSIVE with 'sitting' parameter.",
+				},
+				"scan_scope": map[string]interface{}{
+					"type":        "string",
+					"description": "Which sittings a title search scans: 'recent' (default, the 20 most recent sittings with votings) or 'all' (every sitting of the term; slower, reports progress to clients that request it, and is a good candidate for async='true'). Ignored when the server uses a voting index, which always covers the whole term.",
+				},
+				"limit": map[string]interface{}{
+					"type":        "string",
+					"description": "Maximum number of voting records to return (default: 20). Use higher values (e.g., '50', '100') for comprehensive analysis, lower values for quick overviews.",
+				},
+				"offset": map[string]interface{}{
+					"type":        "string",
+					"description": "Number of results to skip for pagination (default: 0). Use with limit for browsing through large result sets. Example: offset='50' with limit='20' gets results 51-70.",
+				},
+				"date_from": map[string]interface{}{
+					"type":        "string",
+					"description": "Start date for voting search in YYYY-MM-DD format (e.g., '2023-01-01'). Only returns votes from this date onwards. Use with date_to for date range searches.",
+				},
+				"date_to": map[string]interface{}{
+					"type":        "string",
+					"description": "End date for voting search in YYYY-MM-DD format (e.g., '2023-12-31'). Only returns votes up to this date. Use with date_from for date range searches.",
+				},
+			},
+		},
+	}, s.handleSearchVotings)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_votings_sessions",
+		Description: "List the sitting days of a term on which votings were held, with the sitting number and the number of votings each day, newest first. Shows which sittings have votes and how many before drilling down with sejm_search_votings. Group by sitting to get each sitting's days and total votings.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
+				},
+				"sitting": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. List only the days of this sitting (e.g., '15').",
+				},
+				"date_from": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Earliest sitting day in YYYY-MM-DD format (e.g., '2024-01-01').",
+				},
+				"date_to": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Latest sitting day in YYYY-MM-DD format (e.g., '2024-12-31').",
+				},
+				"min_votings": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only days with at least this many votings (default: 1). Use '0' to include sitting days without votings.",
+				},
+				"group_by": map[string]interface{}{
+					"type":        "string",
+					"description": "'day' (default) lists each sitting day; 'sitting' lists each sitting with its days and total votings.",
+				},
+				"limit": map[string]interface{}{
+					"type":        "string",
+					"description": "Maximum number of entries to return (default: 30, max: 100).",
+				},
+				"offset": map[string]interface{}{
+					"type":        "string",
+					"description": "Number of entries to skip for pagination (default: 0).",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json'.",
+				},
+			},
+		},
+	}, s.handleGetVotingsSessions)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_find_defections",
+		Description: "Find party-line defections: votes where MPs voted against the majority of their own parliamentary club. For a sitting or a date range, downloads MP-level results of every voting, determines each club's majority position (YES, NO or ABSTAIN) and lists each MP who voted differently, with their club, their vote, the club line and the voting title. Also ranks the most frequent defectors. Essential for journalism and research on party discipline, coalition cohesion and rebel MPs.\n\nIMPORTANT: Provide 'sitting' or a date range ('date_from'/'date_to'); every voting requires a separate API call, so keep ranges to a few sittings.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
+				},
+				"sitting": map[string]interface{}{
+					"type":        "string",
+					"description": "Sitting number to analyze (e.g., '15'). Can be combined with a date range to analyze a single day of a sitting.",
+				},
+				"date_from": map[string]interface{}{
+					"type":        "string",
+					"description": "Start date in YYYY-MM-DD format (e.g., '2024-03-01'). Only votings from this date onwards are analyzed.",
+				},
+				"date_to": map[string]interface{}{
+					"type":        "string",
+					"description": "End date in YYYY-MM-DD format (e.g., '2024-03-31'). Only votings up to this date are analyzed.",
+				},
+				"club": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only report defections by members of this club (e.g., 'PiS', 'KO'). Case-insensitive.",
+				},
+				"max_votings": map[string]interface{}{
+					"type":        "string",
+					"description": "Maximum number of votings to analyze (default: 50, maximum: 200).",
+				},
+			},
+		},
+	}, s.handleFindDefections)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_club_positions",
+		Description: "Get each parliamentary club's position in a specific voting: YES, NO or ABSTAIN, inferred from the majority of its members' votes, with the number of dissenting members and of absent members. A compact alternative to the MP-by-MP records of sejm_get_voting_details for quickly reporting how each club voted and how united it was.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
+				},
+				"sitting": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary sitting number (e.g., '15'). Get this from sejm_search_votings results.",
+				},
+				"voting_number": map[string]interface{}{
+					"type":        "string",
+					"description": "Voting number within the sitting (e.g., '5'). Get this from sejm_search_votings results.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json'.",
+				},
+			},
+			Required: []string{"sitting", "voting_number"},
+		},
+	}, s.handleGetClubPositions)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_club_changes",
+		Description: "Track club membership changes within a term: MPs who moved between parliamentary clubs or circles, and clubs formed or dissolved. Compares the club of every MP in one voting per sitting with the current MP list, so each change is dated between two sittings. Also reports each club's size when first and last seen. Useful for following splits, mergers and defections to other clubs.\n\nIMPORTANT: Every sitting requires two API calls; narrow long terms with 'date_from'/'date_to'.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
+				},
+				"date_from": map[string]interface{}{
+					"type":        "string",
+					"description": "Start date in YYYY-MM-DD format (e.g., '2024-03-01'). Only sittings from this date onwards are compared.",
+				},
+				"date_to": map[string]interface{}{
+					"type":        "string",
+					"description": "End date in YYYY-MM-DD format (e.g., '2024-12-31'). Only sittings up to this date are compared, and the current MP list is skipped.",
+				},
+				"club": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only report changes into or out of this club (e.g., 'PiS', 'Polska2050'). Case-insensitive.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json'.",
+				},
+			},
+		},
+	}, s.handleGetClubChanges)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_compare_mps",
+		Description: "Compare how two or more MPs vote: for a sitting or a date range, downloads MP-level results of every voting, aligns the votes of the given MPs and reports pairwise agreement percentages together with the specific votings where each pair diverged (title, date and both votes). Useful for coalition analysis, tracking alliances across clubs and spotting MPs drifting away from their allies.\n\nIMPORTANT: Provide 'sitting' or a date range ('date_from'/'date_to'); every voting requires a separate API call, so keep ranges to a few sittings.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
+				},
+				"mp_ids": map[string]interface{}{
+					"type":        "string",
+					"description": "Comma-separated IDs of the MPs to compare (2-10 MPs, e.g., '1,25,130'). Get IDs from sejm_get_mps.",
+				},
+				"sitting": map[string]interface{}{
+					"type":        "string",
+					"description": "Sitting number to analyze (e.g., '15'). Can be combined with a date range to analyze a single day of a sitting.",
+				},
+				"date_from": map[string]interface{}{
+					"type":        "string",
+					"description": "Start date in YYYY-MM-DD format (e.g., '2024-03-01'). Only votings from this date onwards are analyzed.",
+				},
+				"date_to": map[string]interface{}{
+					"type":        "string",
+					"description": "End date in YYYY-MM-DD format (e.g., '2024-03-31'). Only votings up to this date are analyzed.",
+				},
+				"max_votings": map[string]interface{}{
+					"type":        "string",
+					"description": "Maximum number of votings to analyze (default: 50, maximum: 200).",
+				},
+				"max_divergences": map[string]interface{}{
+					"type":        "string",
+					"description": "Maximum number of divergent votings listed per pair (default: 10). Use '0' to show agreement percentages only.",
+				},
+			},
+			Required: []string{"mp_ids"},
+		},
+	}, s.handleCompareMPs)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_interpellations",
+		Description: "Retrieve parliamentary interpellations - formal written questions submitted by MPs to government ministers requiring official responses. These are a key tool of parliamentary oversight and government accountability. Returns detailed information including question title, submitting MP(s), target ministry/minister, submission and response dates, current status, response delays, and government replies. Critical for monitoring government accountability, tracking ministerial responsiveness, analyzing MP oversight activity, identifying policy concerns, researching government performance, and studying democratic accountability mechanisms. Use this to investigate government responsiveness, track specific policy issues, or analyze MP engagement with executive oversight.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Current term 10 covers 2019-2023 oversight activity. Each term reflects different political dynamics and government accountability patterns.",
+				},
+				"limit": map[string]interface{}{
+					"type":        "string",
+					"description": "Maximum number of interpellations to return (default: 20, maximum: 100). Use higher values (e.g., '50', '100') for comprehensive oversight analysis, but be aware of context limits. Large datasets useful for trend analysis and accountability studies.",
+				},
+				"offset": map[string]interface{}{
+					"type":        "string",
+					"description": "Starting position within the collection of results (default: 0). Use with limit for pagination through large datasets. For example, offset='50' with limit='50' returns results 51-100.",
+				},
+				"sort_by": map[string]interface{}{
+					"type":        "string",
+					"description": "Sort interpellations by specified field. Add minus sign for descending order (e.g., '-lastModified' for newest first, 'title' for alphabetical). Common fields: 'lastModified', 'title', 'receiptDate'.",
+				},
+			},
+		},
+	}, s.handleGetInterpellations)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_interpellation_details",
+		Description: "Get the full metadata of a single parliamentary interpellation by number: submitting MPs, recipients with sending dates and answer delays, receipt and modification dates, every government reply with its author, date and key, and all attachments with the key and file name needed to download them. Use this to find the identifiers required by sejm_get_interpellation_reply_body and sejm_get_interpellation_attachment.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Must match the term where the interpellation was submitted.",
+				},
+				"num": map[string]interface{}{
+					"type":        "string",
+					"description": "Interpellation number. Get this from sejm_get_interpellations results (the 'num' field).",
+				},
+			},
+			Required: []string{"term", "num"},
+		},
+	}, s.handleGetInterpellationDetails)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_interpellation_body",
+		Description: "Retrieve the full HTML body content of a specific parliamentary interpellation. Returns the complete text of the interpellation question as submitted by MPs to government ministers. Essential for analyzing the detailed content, specific questions asked, legal references cited, and policy concerns raised. Use this after finding interpellations with sejm_get_interpellations to get the full question text for detailed analysis, research, or transparency reporting.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Must match the term where the interpellation was submitted.",
+				},
+				"num": map[string]interface{}{
+					"type":        "string",
+					"description": "Interpellation number. Get this from sejm_get_interpellations results (the 'num' field).",
+				},
+				"render": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Output rendering: 'html' (default, the sanitized body, see sanitize) or 'markdown' (markup stripped; headings, lists, emphasis and links preserved). Markdown is much shorter and easier to read.",
+				},
+				"sanitize": map[string]interface{}{
+					"type":        "string",
+					"description": sanitizeParamDescription,
+				},
+			},
+			Required: []string{"term", "num"},
+		},
+	}, s.handleGetInterpellationBody)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_interpellation_reply_body",
+		Description: "Retrieve the full HTML body content of a government reply to a parliamentary interpellation. Returns the complete ministerial response including policy explanations, statistical data, legal interpretations, and action plans. Critical for analyzing government accountability, policy responses, ministerial performance, and the quality of democratic oversight. Use this to examine how thoroughly government addresses MP concerns and parliamentary questions.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Must match the term where the interpellation was submitted.",
+				},
+				"num": map[string]interface{}{
+					"type":        "string",
+					"description": "Interpellation number. Get this from sejm_get_interpellations results.",
+				},
+				"key": map[string]interface{}{
+					"type":        "string",
+					"description": "Reply key/identifier. Get this from sejm_get_interpellation_details (replies list).",
+				},
+				"render": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Output rendering: 'html' (default, the sanitized body, see sanitize) or 'markdown' (markup stripped; headings, lists, emphasis and links preserved). Markdown is much shorter and easier to read.",
+				},
+				"sanitize": map[string]interface{}{
+					"type":        "string",
+					"description": sanitizeParamDescription,
+				},
+			},
+			Required: []string{"term", "num", "key"},
+		},
+	}, s.handleGetInterpellationReplyBody)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_mp_interpellation_texts",
+		Description: "Bulk-read the interpellations of one MP: lists the MP's interpellations (newest first), downloads all their bodies and, optionally, the government replies in one batched operation with limited concurrency, and returns a compact digest per document: recipient, length, number of questions and the most representative sentences. Use this to study an MP's oversight themes without calling sejm_get_interpellation_body dozens of times.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
+				},
+				"mp_id": map[string]interface{}{
+					"type":        "string",
+					"description": "ID of the MP who submitted the interpellations. Get this from sejm_get_mps.",
+				},
+				"include_replies": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to also download and digest the government replies. Default: 'false'.",
+				},
+				"limit": map[string]interface{}{
+					"type":        "string",
+					"description": "Number of interpellations to process (default: 20, maximum: 100).",
+				},
+				"offset": map[string]interface{}{
+					"type":        "string",
+					"description": "Number of the newest interpellations to skip, for processing the next batch (default: 0).",
+				},
+				"digest_sentences": map[string]interface{}{
+					"type":        "string",
+					"description": "Representative sentences quoted per document (default: 3, maximum: 10). Use '0' for length and question counts only.",
+				},
+			},
+			Required: []string{"mp_id"},
+		},
+	}, s.handleGetMPInterpellationTexts)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_export_oversight_corpus",
+		Description: "Export all interpellations or written questions of a term as JSON Lines for building text corpora: one record per line with every field the API provides, optionally with the question bodies and government replies as plain text. The corpus is exported in chunks ordered by number with resumable offsets, returned inline, as an MCP resource or appended to a JSONL file in the server's output directory. Use this instead of paging sejm_get_interpellations and downloading bodies one by one.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
+				},
+				"kind": map[string]interface{}{
+					"type":        "string",
+					"description": "What to export: 'interpellations' (default) or 'written_questions'.",
+				},
+				"offset": map[string]interface{}{
+					"type":        "string",
+					"description": "Number of records to skip (default: 0). Each response gives the offset of the next chunk. With return_content='file', omit it to resume after the records already in the file.",
+				},
+				"limit": map[string]interface{}{
+					"type":        "string",
+					"description": "Records per chunk (default: 100, maximum: 500, or 100 with include_bodies).",
+				},
+				"include_bodies": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to add the text of each question in the 'body' field. Default: 'false'.",
+				},
+				"include_replies": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to also add the text of each government reply to its entry in 'replies'. Requires include_bodies='true'. Default: 'false'.",
+				},
+				"return_content": map[string]interface{}{
+					"type":        "string",
+					"description": "How to return the chunk: 'text' (default, embedded JSONL), 'resource' (registered MCP resource the client can read with resources/read) or 'file' (appended to a JSONL file in the server's output directory; requires -output-dir).",
+				},
+			},
+		},
+	}, s.handleExportOversightCorpus)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_cluster_interpellations",
+		Description: "Group the interpellations received in a time window into topics, computed locally with TF-IDF weighting and k-means clustering (no external language model). Returns each topic's characteristic words, its size and share, the main recipients and the most representative interpellations. Use this to see the dominant themes of parliamentary oversight without reading thousands of items.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
+				},
+				"date_from": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only interpellations received on or after this date (YYYY-MM-DD).",
+				},
+				"date_to": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only interpellations received on or before this date (YYYY-MM-DD).",
+				},
+				"max_items": map[string]interface{}{
+					"type":        "string",
+					"description": fmt.Sprintf("Maximum number of interpellations analyzed, newest first (default: %d, max: %d; with include_bodies max %d).", defaultClusterItems, maxClusterItems, maxClusterItemsWithBody),
+				},
+				"clusters": map[string]interface{}{
+					"type":        "string",
+					"description": fmt.Sprintf("Number of topics (2-%d). Default: chosen from the number of interpellations.", maxTopicClusters),
+				},
+				"include_bodies": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to also download each interpellation body and use its most representative sentences, not just the title. Slower; limited to 100 interpellations. Default: 'false'.",
+				},
+			},
+		},
+	}, s.handleClusterInterpellations)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_unanswered_interpellations",
+		Description: "Reply completeness check for interpellations: lists interpellations whose reply is past the statutory 21-day deadline and still missing, computed per ministry with aging buckets (1-29, 30-59, 60-89 and 90+ days overdue), and the most overdue cases. Use this to see which ministries leave parliamentary oversight unanswered and for how long.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
+				},
+				"recipient": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only replies owed by recipients whose name contains this text, e.g., 'zdrowia' or 'Minister Finansów'.",
+				},
+				"min_days_overdue": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only replies at least this many days past the deadline, e.g., '30', '60' or '90'.",
+				},
+				"limit": map[string]interface{}{
+					"type":        "string",
+					"description": fmt.Sprintf("Number of most overdue replies listed (default: %d; '0' lists only the per-ministry summary).", defaultUnansweredListed),
+				},
+				"max_items": map[string]interface{}{
+					"type":        "string",
+					"description": fmt.Sprintf("Maximum number of delayed interpellations analyzed, newest first (default: %d, max: %d).", defaultUnansweredItems, maxUnansweredItems),
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json' with per-ministry backlogs and overdue replies.",
+				},
+			},
+		},
+	}, s.handleGetUnansweredInterpellations)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_interpellation_attachment",
+		Description: "Download attachment files associated with parliamentary interpellations. Returns file metadata and, on request, the file itself as base64 blob content or an MCP resource (PDFs, documents, images that MPs include with their interpellations or that ministries attach to their replies), or its extracted text. Essential for accessing supporting documentation, legal references, statistical data, charts, reports, and evidence that supplement the interpellation text. Use this to get complete context and supporting materials for interpellation analysis.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Must match the term where the interpellation was submitted.",
+				},
+				"key": map[string]interface{}{
+					"type":        "string",
+					"description": "Attachment key/identifier. Get this from sejm_get_interpellation_details (attachments of the question or of a reply).",
+				},
+				"file_name": map[string]interface{}{
+					"type":        "string",
+					"description": "Attachment file name. Get this from sejm_get_interpellation_details (attachments of the question or of a reply).",
+				},
+				"extract_text": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to include the attachment's readable text (PDF, DOCX, ODT, RTF or TXT) in the response, truncated to 10000 characters.",
+				},
+				"return_content": map[string]interface{}{
+					"type":        "string",
+					"description": "How to return the file itself: 'none' (default, metadata only), 'blob' (embed the file as base64 content with its MIME type) or 'resource' (register the file as an MCP resource and return a link the client can read with resources/read).",
+				},
+				"max_size_bytes": map[string]interface{}{
+					"type":        "string",
+					"description": "Maximum file size returned with return_content (default: 5242880 bytes = 5 MB, max: 20971520 = 20 MB). Larger files are reported with metadata only.",
+				},
+			},
+			Required: []string{"term", "key", "file_name"},
+		},
+	}, s.handleGetInterpellationAttachment)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_transcripts",
+		Description: "Retrieve parliamentary proceeding transcripts - complete stenographic records of parliamentary debates, speeches, and discussions. Returns detailed transcript information including individual MP statements, speech timestamps, debate topics, speaker identification, and full text content. For large PDF transcripts, use pagination parameters (page, pages_per_chunk) to manage response size and avoid context overflow. For statement lists with hundreds of statements, use limit and offset for efficient pagination. Essential for analyzing parliamentary debates, tracking MP positions on issues, studying political discourse, researching specific policy discussions, and understanding the legislative decision-making process.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Current term 10 covers 2019-2023 parliamentary debates.",
+				},
+				"proceeding_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary proceeding/sitting number (e.g., '1', '15', '30'). Get this from sejm_get_proceedings results.",
+				},
+				"date": map[string]interface{}{
+					"type":        "string",
+					"description": "Proceeding date in YYYY-MM-DD format (e.g., '2023-11-13'). Get this from sejm_get_proceedings results.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Response format: 'list' for statement list (default), 'pdf' for complete transcript as PDF, 'text' for PDF converted to searchable text.",
+				},
+				"limit": map[string]interface{}{
+					"type":        "string",
+					"description": "For 'list' format: Maximum number of statements to return (default: 25, max: 100). Use for pagination through potentially hundreds of statements per proceeding.",
+				},
+				"offset": map[string]interface{}{
+					"type":        "string",
+					"description": "For 'list' format: Number of statements to skip (default: 0). Use with limit for pagination through statement lists.",
+				},
+				"group_by": map[string]interface{}{
+					"type":        "string",
+					"description": "For 'list' format: set to 'agenda_item' for a table of contents instead of statements: the agenda items taken up on this day, each with its range of statement numbers, times and title. Detected from the announcements of the chair, whose statements are read once.",
+				},
+				"agenda_item": map[string]interface{}{
+					"type":        "string",
+					"description": "For 'list' format: list only the statements of this agenda item number (from group_by='agenda_item'); '0' lists those before the first item.",
+				},
+				"page": map[string]interface{}{
+					"type":        "string",
+					"description": "For 'text' format: Starting page number (1-based). Use with pages_per_chunk to control output size. Default: 1.",
+				},
+				"pages_per_chunk": map[string]interface{}{
+					"type":        "string",
+					"description": "For 'text' format: Number of pages to include per response (1-10). Helps manage large transcript responses. Default: 5.",
+				},
+				"show_page_info": map[string]interface{}{
+					"type":        "string",
+					"description": "For 'text' format: Set to 'true' to show page count and navigation info instead of content. Useful for understanding document structure.",
+				},
+				"summarize": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Set to 'true' to return an extractive summary instead of the text: the most representative sentences quoted verbatim, each with a pointer to the page it comes from. Computed on the server from word frequencies, without an LLM. Useful for very long documents.",
+				},
+				"summary_sentences": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Number of sentences in the summary when summarize='true' (default: 15, maximum: 50).",
+				},
+			},
+			Required: []string{"proceeding_id", "date"},
+		},
+	}, s.handleGetTranscripts)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_sitting_timeline",
+		Description: "Chronological timeline of one day of a Sejm sitting, ready for plotting: opening, every voting with its time and result, breaks (gaps of 15+ minutes without statements or votings) and closing, plus per-hour counts of statements, speaking minutes and votings. Assembled from the day's votings and transcript statement times.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
+				},
+				"sitting": map[string]interface{}{
+					"type":        "string",
+					"description": "Sitting (proceeding) number, e.g. '15'. Get this from sejm_get_proceedings.",
+				},
+				"date": map[string]interface{}{
+					"type":        "string",
+					"description": "Day of the sitting in YYYY-MM-DD format. Optional for one-day sittings; required when the sitting lasted several days.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json' (events and hourly buckets with ISO timestamps).",
+				},
+			},
+			Required: []string{"sitting"},
+		},
+	}, s.handleGetSittingTimeline)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_sitting_turnout",
+		Description: "Per-voting turnout for a Sejm sitting in one compact table: votes cast against MPs holding a mandate, turnout percentage and distance from the quorum (230 of 460 MPs), with votings that came close to or below the quorum flagged. Computed from the sitting's voting totals, without per-MP requests.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to current term (10) if not specified.",
+				},
+				"sitting": map[string]interface{}{
+					"type":        "string",
+					"description": "Sitting (proceeding) number, e.g. '15'. Get this from sejm_get_proceedings.",
+				},
+				"date": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only votings on this day of the sitting (YYYY-MM-DD).",
+				},
+				"risk_margin": map[string]interface{}{
+					"type":        "string",
+					"description": fmt.Sprintf("Flag votings whose votes cast exceed the quorum by at most this many votes (default: %d).", defaultQuorumRiskMargin),
+				},
+				"flagged_only": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to list only votings at risk of or without quorum.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json'.",
+				},
+			},
+			Required: []string{"sitting"},
+		},
+	}, s.handleGetSittingTurnout)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_statement",
+		Description: "Retrieve individual MP statement from parliamentary transcript - complete text of a specific speech or intervention during parliamentary proceedings. Returns detailed statement content including speaker information, timestamp, full text, context within the debate, and related discussion. Essential for analyzing specific MP positions, studying individual political statements, researching particular policy arguments, and understanding detailed parliamentary discourse. Use this to get the complete text of specific speeches or interventions.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Current term 10 covers 2019-2023 statements.",
+				},
+				"proceeding_id": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary proceeding/sitting number. Get this from transcript list results.",
+				},
+				"date": map[string]interface{}{
+					"type":        "string",
+					"description": "Proceeding date in YYYY-MM-DD format. Get this from transcript list results.",
+				},
+				"statement_num": map[string]interface{}{
+					"type":        "string",
+					"description": "Statement number within the proceeding (e.g., '1', '5', '23'). Get this from sejm_get_transcripts results.",
 				},
 				"chunk_size": map[string]interface{}{
 					"type":        "string",
@@ -595,12 +1636,24 @@ func (s *SejmServer) registerSejmTools() {
 					"type":        "string",
 					"description": "Set to 'true' to show total chunks and navigation info instead of content. Useful for understanding statement structure.",
 				},
+				"render": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Output rendering: 'html' (default, the sanitized body, see sanitize) or 'markdown' (markup stripped; headings, lists, emphasis and links preserved). Markdown is much shorter and easier to read.",
+				},
+				"sanitize": map[string]interface{}{
+					"type":        "string",
+					"description": sanitizeParamDescription,
+				},
+				"metadata": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Structured speaker metadata as JSON: speaker name, club, function, start and end time, interruptions (applause, voices from the floor, interjections, bell) and clock times recorded in the text. 'false' (default) returns the content only, 'true' adds the metadata before the content, 'only' returns the metadata instead of the content.",
+				},
 			},
 			Required: []string{"proceeding_id", "date", "statement_num"},
 		},
 	}, s.handleGetStatement)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_search_transcript_content",
 		Description: "Search for specific text within parliamentary proceeding transcripts and get precise page locations. Downloads transcript PDFs, searches for specified terms, and returns detailed map showing exactly which pages contain each search term. Perfect for quickly locating specific MPs, debate topics, or policy discussions within large transcript documents without reading the entire text. IMPORTANT: Parliamentary proceedings can span multiple days - to find all mentions of a keyword across an entire proceeding, you need to search each day's transcript separately by iterating through all dates of the proceeding.",
 		InputSchema: mcp.ToolInputSchema{
@@ -630,12 +1683,20 @@ func (s *SejmServer) registerSejmTools() {
 					"type":        "string",
 					"description": "Optional. Maximum number of matches to show per search term (default: 10, max: 50).",
 				},
+				"match_mode": map[string]interface{}{
+					"type":        "string",
+					"description": matchModeParamDescription,
+				},
+				"ignore_diacritics": map[string]interface{}{
+					"type":        "string",
+					"description": ignoreDiacriticsParamDescription,
+				},
 			},
 			Required: []string{"proceeding_id", "date", "search_terms"},
 		},
 	}, s.handleSearchTranscriptContent)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_parliamentary_keywords",
 		Description: "Get comprehensive list of common parliamentary and political keywords for Polish Sejm searches. Returns suggested search terms for parliamentary transcripts, voting records, and political discourse. Essential for discovering effective search terms when you're unsure what keywords to use for parliamentary content search. Use this when searches return no results or when you need guidance on parliamentary terminology.",
 		InputSchema: mcp.ToolInputSchema{
@@ -653,7 +1714,7 @@ func (s *SejmServer) registerSejmTools() {
 		},
 	}, s.handleGetParliamentaryKeywords)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_committee_sittings_by_date",
 		Description: "Retrieve list of committee meetings scheduled for a specific date across all committees. Returns comprehensive information about parliamentary committee activities including meeting times, rooms, agendas, and committee codes. Essential for tracking daily parliamentary committee work, scheduling analysis, and understanding committee activity patterns. Use this to see all committee meetings happening on a particular day.",
 		InputSchema: mcp.ToolInputSchema{
@@ -676,9 +1737,9 @@ func (s *SejmServer) registerSejmTools() {
 		},
 	}, s.handleGetCommitteeSittingsByDate)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_committee_sittings",
-		Description: "Retrieve list of meetings for a specific parliamentary committee. Returns detailed information about committee meeting history including dates, agenda items, participants, and meeting outcomes. Essential for tracking specific committee work, analyzing committee productivity, and understanding legislative committee processes. Use this to explore the complete meeting history of a particular committee.",
+		Description: "Retrieve list of meetings for a specific parliamentary committee. Returns detailed information about committee meeting history including dates, agenda items, participants, and meeting outcomes. Essential for tracking specific committee work, analyzing committee productivity, and understanding legislative committee processes. Results are paged, most recent first by default, and can be limited to a date range, since busy committees have hundreds of sittings.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -694,12 +1755,151 @@ func (s *SejmServer) registerSejmTools() {
 					"type":        "string",
 					"description": "Set to 'true' to include canceled meetings in results. Default: false (only completed meetings).",
 				},
+				"date_from": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only sittings on or after this date (YYYY-MM-DD).",
+				},
+				"date_to": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only sittings on or before this date (YYYY-MM-DD).",
+				},
+				"sort_by": map[string]interface{}{
+					"type":        "string",
+					"description": "Order of sittings: '-date' (most recent first, default) or 'date' (oldest first).",
+				},
+				"limit": map[string]interface{}{
+					"type":        "string",
+					"description": "Maximum number of sittings to return (default: 20, maximum: 100).",
+				},
+				"offset": map[string]interface{}{
+					"type":        "string",
+					"description": "Number of sittings to skip, for the next page (default: 0). The output ends with the next_offset to use.",
+				},
 			},
 			Required: []string{"committee_code"},
 		},
 	}, s.handleGetCommitteeSittings)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
+		Name:        "sejm_list_joint_sittings",
+		Description: "List joint sittings of several committees (wspólne posiedzenia komisji) in a date range. The Sejm API lists a joint sitting once under each committee with its own sitting number, so counting sittings counts such meetings several times. This tool merges them into one meeting, using the API's jointWith links or, when missing, the same date, start time, room and agenda, and reports how many meetings took place after merging. Use it before analysing committee activity to avoid double counting.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to the current term.",
+				},
+				"date_from": map[string]interface{}{
+					"type":        "string",
+					"description": "First day to scan (YYYY-MM-DD).",
+				},
+				"date_to": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Last day to scan (YYYY-MM-DD), at most 31 days after date_from. Default: date_from.",
+				},
+				"committee_code": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only joint sittings this committee took part in (e.g., 'FPB'). Get codes from sejm_get_committees.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json'.",
+				},
+			},
+			Required: []string{"date_from"},
+		},
+	}, s.handleListJointSittings)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_committee_workload",
+		Description: "List the legislative backlog of a parliamentary committee: all open processes whose current stage is referred to the committee, with the date of referral, days spent in the committee, total process age and the latest step. Derived from the stages of every open process in the term, so it answers 'what is waiting in this committee and for how long' without scanning processes by hand. Oldest referrals come first.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to the current term.",
+				},
+				"committee_code": map[string]interface{}{
+					"type":        "string",
+					"description": "Committee code (e.g., 'FPB', 'ASW'). Get this from sejm_get_committees results.",
+				},
+				"include_reported": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to also list processes the committee has already reported on that still await the next reading. Default: false.",
+				},
+				"limit": map[string]interface{}{
+					"type":        "string",
+					"description": "Maximum number of processes to list (default 50, max 500).",
+				},
+			},
+			Required: []string{"committee_code"},
+		},
+	}, s.handleGetCommitteeWorkload)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_committee_attendance",
+		Description: "Compute per-member attendance for a parliamentary committee over its recent sittings. Downloads the sitting transcripts and counts a member as present when the transcript names them, returning attendance percentages and the sittings each member was not recorded at. Useful for assessing MP engagement in committee work and comparing members' participation.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to the current term.",
+				},
+				"committee_code": map[string]interface{}{
+					"type":        "string",
+					"description": "Committee code (e.g., 'ENM', 'ASW'). Get this from sejm_get_committees results.",
+				},
+				"date_from": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only analyze sittings on or after this date (YYYY-MM-DD).",
+				},
+				"date_to": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only analyze sittings on or before this date (YYYY-MM-DD).",
+				},
+				"max_sittings": map[string]interface{}{
+					"type":        "string",
+					"description": fmt.Sprintf("Maximum number of most recent sittings to analyze (default: %d, max: %d). Each sitting requires one transcript download.", defaultAttendanceSittings, maxAttendanceSittings),
+				},
+			},
+			Required: []string{"committee_code"},
+		},
+	}, s.handleGetCommitteeAttendance)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_schedule_feed",
+		Description: "Export upcoming plenary sittings (posiedzenia Sejmu) and committee sittings as an iCalendar (.ics) or RSS 2.0 document, ready to import into a calendar app or feed reader. Covers the next 'days' days for the whole Sejm or for one committee. In HTTP mode the same feeds can be subscribed to directly at /feeds/schedule.ics and /feeds/schedule.rss (query parameters: term, committee, days, include_committees).",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to the current term.",
+				},
+				"committee_code": map[string]interface{}{
+					"type":        "string",
+					"description": "Committee code (e.g., 'ENM', 'ASW'). When set, the feed lists only this committee's sittings. Get codes from sejm_get_committees.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Feed format: 'ical' (default) or 'rss'.",
+				},
+				"days": map[string]interface{}{
+					"type":        "string",
+					"description": fmt.Sprintf("Number of days ahead to include, starting today (default: %d, max: %d).", defaultFeedDays, maxFeedDays),
+				},
+				"include_committees": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'false' to list only plenary sittings when no committee_code is given. Default: true (one API request per day).",
+				},
+			},
+		},
+	}, s.handleGetScheduleFeed)
+
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_committee_sitting_details",
 		Description: "Get detailed information about a specific committee meeting including agenda, participants, decisions, and meeting metadata. Returns comprehensive sitting details with timestamps, attendees, topics discussed, and outcomes. Essential for analyzing specific committee decisions, understanding committee workflow, and researching detailed committee proceedings.",
 		InputSchema: mcp.ToolInputSchema{
@@ -715,67 +1915,184 @@ func (s *SejmServer) registerSejmTools() {
 				},
 				"sitting_number": map[string]interface{}{
 					"type":        "string",
-					"description": "Meeting number within the committee (e.g., '1', '5', '15'). Get this from committee sitting lists.",
+					"description": "Meeting number within the committee (e.g., '1', '5', '15'). Get this from committee sitting lists.",
+				},
+			},
+			Required: []string{"committee_code", "sitting_number"},
+		},
+	}, s.handleGetCommitteeSittingDetails)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_committee_transcript",
+		Description: "Retrieve committee meeting transcripts in HTML or PDF format with pagination support for large documents. Returns complete stenographic records of committee discussions, member statements, expert testimonies, and voting records. For large transcripts, use pagination parameters to manage response size and avoid context overflow. Essential for detailed analysis of committee work, policy development research, and understanding legislative decision-making processes.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Current term 10 covers 2019-2023 committee transcripts.",
+				},
+				"committee_code": map[string]interface{}{
+					"type":        "string",
+					"description": "Committee code (e.g., 'ENM', 'ASW'). Get this from committee listings.",
+				},
+				"sitting_number": map[string]interface{}{
+					"type":        "string",
+					"description": "Meeting number within the committee. Get this from committee sitting lists.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Response format: 'html' for HTML transcript (default), 'pdf' for PDF download info, 'text' for PDF converted to searchable text with pagination.",
+				},
+				"page": map[string]interface{}{
+					"type":        "string",
+					"description": "For 'text' format: Starting page number (1-based). Use with pages_per_chunk to control output size.",
+				},
+				"pages_per_chunk": map[string]interface{}{
+					"type":        "string",
+					"description": "For 'text' format: Number of pages to include per response (1-10). Default: 5.",
+				},
+				"show_page_info": map[string]interface{}{
+					"type":        "string",
+					"description": "For 'text' format: Set to 'true' to show page count and navigation info instead of content.",
+				},
+				"chunk_size": map[string]interface{}{
+					"type":        "string",
+					"description": "For 'html' format: Characters per chunk (1000-10000). Default: 5000.",
+				},
+				"chunk_number": map[string]interface{}{
+					"type":        "string",
+					"description": "For 'html' format: Which chunk to return (1-based). Default: 1.",
+				},
+				"show_chunk_info": map[string]interface{}{
+					"type":        "string",
+					"description": "For 'html' format: Set to 'true' to show document structure info instead of content.",
+				},
+				"sanitize": map[string]interface{}{
+					"type":        "string",
+					"description": sanitizeParamDescription,
+				},
+				"summarize": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Set to 'true' to return an extractive summary instead of the text: the most representative sentences quoted verbatim, each with a pointer to the page it comes from. Computed on the server from word frequencies, without an LLM. Useful for very long documents.",
+				},
+				"summary_sentences": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Number of sentences in the summary when summarize='true' (default: 15, maximum: 50).",
+				},
+				"agenda_item": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Split the transcript by agenda items (punkty porządku obrad) detected from the chair's announcements. Use 'list' to see the detected items and their sizes, or an item number (e.g., '2' for pkt 2 porządku obrad, '0' for the opening) to get only that discussion. The item text is paginated with chunk_size/chunk_number.",
+				},
+			},
+			Required: []string{"committee_code", "sitting_number"},
+		},
+	}, s.handleGetCommitteeTranscript)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_list_committee_transcripts",
+		Description: "List which recent sittings of a committee have an HTML and/or a PDF transcript published, with file sizes and dates when the API reports them, without downloading the transcripts. Use it before sejm_get_committee_transcript to avoid errors on sittings whose transcript is not published yet (or never will be, e.g. closed sittings).",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to the current term.",
+				},
+				"committee_code": map[string]interface{}{
+					"type":        "string",
+					"description": "Committee code (e.g., 'ENM', 'ASW'). Get this from sejm_get_committees results.",
+				},
+				"date_from": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only check sittings on or after this date (YYYY-MM-DD).",
+				},
+				"date_to": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only check sittings on or before this date (YYYY-MM-DD).",
+				},
+				"max_sittings": map[string]interface{}{
+					"type":        "string",
+					"description": fmt.Sprintf("Maximum number of most recent sittings to check (default: %d, max: %d). Each sitting takes two small requests.", defaultTranscriptListSittings, maxTranscriptListSittings),
+				},
+				"missing_only": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to list only sittings missing at least one format.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json'.",
 				},
 			},
-			Required: []string{"committee_code", "sitting_number"},
+			Required: []string{"committee_code"},
 		},
-	}, s.handleGetCommitteeSittingDetails)
+	}, s.handleListCommitteeTranscripts)
 
-	s.server.AddTool(mcp.Tool{
-		Name:        "sejm_get_committee_transcript",
-		Description: "Retrieve committee meeting transcripts in HTML or PDF format with pagination support for large documents. Returns complete stenographic records of committee discussions, member statements, expert testimonies, and voting records. For large transcripts, use pagination parameters to manage response size and avoid context overflow. Essential for detailed analysis of committee work, policy development research, and understanding legislative decision-making processes.",
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_committee_documents",
+		Description: "List a committee's desiderata (dezyderaty), its formal demands addressed to the government, and opinions (opinie), mostly addressed to other committees, with their numbers, dates and document addresses. The Sejm API does not publish them, so they are read from the committee's page on sejm.gov.pl (terms 7-10). Read a document with sejm_get_committee_document_text.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
 				"term": map[string]interface{}{
 					"type":        "string",
-					"description": "Parliamentary term number (1-10). Current term 10 covers 2019-2023 committee transcripts.",
+					"description": "Parliamentary term number (7-10). Defaults to current term (10) if not specified.",
 				},
 				"committee_code": map[string]interface{}{
 					"type":        "string",
-					"description": "Committee code (e.g., 'ENM', 'ASW'). Get this from committee listings.",
+					"description": "Committee code (e.g., 'ENM', 'ASW'). Get this from sejm_get_committees results.",
 				},
-				"sitting_number": map[string]interface{}{
+				"kind": map[string]interface{}{
 					"type":        "string",
-					"description": "Meeting number within the committee. Get this from committee sitting lists.",
+					"description": "Optional. 'desiderata', 'opinions', or 'all' (default).",
 				},
 				"format": map[string]interface{}{
 					"type":        "string",
-					"description": "Response format: 'html' for HTML transcript (default), 'pdf' for PDF download info, 'text' for PDF converted to searchable text with pagination.",
+					"description": "Output format: 'text' (default) or 'json'.",
+				},
+			},
+			Required: []string{"committee_code"},
+		},
+	}, s.handleGetCommitteeDocuments)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_committee_document_text",
+		Description: "Download a committee desideratum or opinion from sejm.gov.pl and extract its text, paginated like other documents. Can also return the file itself.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"url": map[string]interface{}{
+					"type":        "string",
+					"description": "Address of the document on sejm.gov.pl, as listed by sejm_get_committee_documents.",
 				},
 				"page": map[string]interface{}{
 					"type":        "string",
-					"description": "For 'text' format: Starting page number (1-based). Use with pages_per_chunk to control output size.",
+					"description": "Starting page number (default: 1).",
 				},
 				"pages_per_chunk": map[string]interface{}{
 					"type":        "string",
-					"description": "For 'text' format: Number of pages to include per response (1-10). Default: 5.",
+					"description": "Number of pages to return at once (default: 5, max: 20).",
 				},
 				"show_page_info": map[string]interface{}{
 					"type":        "string",
-					"description": "For 'text' format: Set to 'true' to show page count and navigation info instead of content.",
-				},
-				"chunk_size": map[string]interface{}{
-					"type":        "string",
-					"description": "For 'html' format: Characters per chunk (1000-10000). Default: 5000.",
+					"description": "Set to 'true' to return only page count and navigation information instead of text.",
 				},
-				"chunk_number": map[string]interface{}{
+				"return_content": map[string]interface{}{
 					"type":        "string",
-					"description": "For 'html' format: Which chunk to return (1-based). Default: 1.",
+					"description": "How to return the file itself: 'none' (default, text only), 'blob' (embed the file as base64 content with its MIME type) or 'resource' (register the file as an MCP resource and return a link the client can read with resources/read).",
 				},
-				"show_chunk_info": map[string]interface{}{
+				"max_size_bytes": map[string]interface{}{
 					"type":        "string",
-					"description": "For 'html' format: Set to 'true' to show document structure info instead of content.",
+					"description": "Maximum file size returned with return_content (default: 5242880 bytes = 5 MB, max: 20971520 = 20 MB).",
 				},
 			},
-			Required: []string{"committee_code", "sitting_number"},
+			Required: []string{"url"},
 		},
-	}, s.handleGetCommitteeTranscript)
+	}, s.handleGetCommitteeDocumentText)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_mp_photo",
-		Description: "Get MP (Member of Parliament) official photo in full size. Returns the MP's parliamentary portrait photo used in official documents and parliamentary materials. These photos are standardized parliamentary portraits that provide visual identification of MPs for democratic transparency and public accountability. Useful for creating MP profiles, media materials, parliamentary documentation, or citizen information resources.",
+		Description: "Get MP (Member of Parliament) official photo in full size. Returns the MP's parliamentary portrait photo used in official documents and parliamentary materials as image content (base64 JPEG) that vision-capable clients can display or analyze. These photos are standardized parliamentary portraits that provide visual identification of MPs for democratic transparency and public accountability. Useful for creating MP profiles, media materials, parliamentary documentation, or citizen information resources.",
 		InputSchema: mcp.ToolInputSchema{
 			Type: "object",
 			Properties: map[string]interface{}{
@@ -796,7 +2113,7 @@ func (s *SejmServer) registerSejmTools() {
 		},
 	}, s.handleGetMPPhoto)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_mp_voting_stats",
 		Description: "Get comprehensive voting statistics for a specific Member of Parliament including attendance rates, participation patterns, and voting behavior analysis. Returns detailed statistical data about the MP's parliamentary activity including sitting attendance, voting participation rates, excuse patterns, and overall engagement metrics. Essential for analyzing MP performance, democratic accountability research, parliamentary oversight, citizen engagement, and transparency reporting. Use this to assess individual MP accountability, compare MP activity levels, or analyze parliamentary attendance patterns.",
 		InputSchema: mcp.ToolInputSchema{
@@ -815,7 +2132,34 @@ func (s *SejmServer) registerSejmTools() {
 		},
 	}, s.handleGetMPVotingStats)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_mp_participation_trend",
+		Description: "Month-by-month voting participation of an MP across a term, built from the MP's daily voting statistics: votings, votes cast, missed votes, sitting days and excused days per month, with months below a threshold flagged and consecutive low months joined into disengagement periods. Use format='csv' or 'json' for a series ready for charting.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"term": map[string]interface{}{
+					"type":        "string",
+					"description": "Parliamentary term number (1-10). Defaults to the current term.",
+				},
+				"mp_id": map[string]interface{}{
+					"type":        "string",
+					"description": "MP identification number within the term, or the MP's name. Get IDs from sejm_get_mps.",
+				},
+				"low_threshold": map[string]interface{}{
+					"type":        "string",
+					"description": "Monthly participation in percent below which a month counts as low (1-100, default 80).",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default, one bar per month), 'csv' (one row per month with a header), or 'json' (the series with the low-participation periods).",
+				},
+			},
+			Required: []string{"mp_id"},
+		},
+	}, s.handleGetMPParticipationTrend)
+
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_mp_voting_details",
 		Description: "Get detailed voting records for a specific Member of Parliament during a particular parliamentary sitting. Returns comprehensive vote-by-vote information including specific voting choices (yes/no/abstain/absent), vote titles, topics, timestamps, and voting context. Essential for analyzing individual MP voting behavior, tracking specific legislative positions, researching MP consistency on issues, understanding party discipline, and conducting detailed political accountability analysis. Use this to examine how an MP voted on specific legislation or during important parliamentary sessions.",
 		InputSchema: mcp.ToolInputSchema{
@@ -842,7 +2186,7 @@ func (s *SejmServer) registerSejmTools() {
 		},
 	}, s.handleGetMPVotingDetails)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_videos",
 		Description: "Retrieve parliamentary video transmissions and live streams with comprehensive filtering and smart pagination. Returns detailed information about video broadcasts including live parliamentary sessions, committee meetings, special events, and archived proceedings. Each video entry includes streaming URLs, player links, transmission metadata, schedules, and technical details. **SMART PAGINATION**: Major terms have hundreds of video transmissions. Use pagination (limit/offset) and smart filters to manage large datasets. Examples: limit='25' for manageable chunks, live_only='true' for active streams, committee='ENM' for specific committee coverage, has_video='true' for streamable content. Results are typically sorted by date (newest first) so pagination naturally provides recent content. Essential for accessing live parliamentary coverage, following specific committee work, researching historical proceedings, media monitoring, and democratic transparency.",
 		InputSchema: mcp.ToolInputSchema{
@@ -896,7 +2240,7 @@ func (s *SejmServer) registerSejmTools() {
 		},
 	}, s.handleGetVideos)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_videos_today",
 		Description: "Get today's parliamentary video transmissions and live streams including current live sessions, scheduled broadcasts, and ongoing parliamentary activities. Returns real-time information about active video streams, upcoming transmissions, and current parliamentary events with streaming links and schedules. Essential for following current parliamentary activity, accessing live coverage, monitoring ongoing debates, and staying informed about real-time democratic processes. Perfect for media monitoring, civic engagement, and immediate parliamentary coverage.",
 		InputSchema: mcp.ToolInputSchema{
@@ -910,7 +2254,7 @@ func (s *SejmServer) registerSejmTools() {
 		},
 	}, s.handleGetVideosToday)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_videos_by_date",
 		Description: "Retrieve parliamentary video transmissions for a specific date including all sessions, committee meetings, and special events that occurred on that day. Returns comprehensive video coverage information with streaming URLs, archived recordings, meeting metadata, and transmission details. Essential for researching historical parliamentary activity, accessing archived proceedings, studying specific legislative sessions, and understanding parliamentary events on particular dates. Use this to find recordings of important votes, committee meetings, or special parliamentary events.",
 		InputSchema: mcp.ToolInputSchema{
@@ -929,7 +2273,7 @@ func (s *SejmServer) registerSejmTools() {
 		},
 	}, s.handleGetVideosByDate)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_video_details",
 		Description: "Get detailed metadata and streaming information for a specific video transmission including direct streaming URLs, player links, technical specifications, transmission schedule, and comprehensive event details. Returns complete video transmission data with multiple camera angles, sign language streams, player embed codes, and full technical metadata. Essential for accessing specific video content, embedding streams, technical integration, detailed media analysis, and comprehensive parliamentary video research.",
 		InputSchema: mcp.ToolInputSchema{
@@ -973,14 +2317,14 @@ func (s *SejmServer) handleGetMPs(ctx context.Context, request mcp.CallToolReque
 		offset = 0
 	}
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/MP", sejmBaseURL, term)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/MP", s.sejmBaseURL, term)
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve MPs from Polish Parliament API: %v. Please try again or check if the term number is valid.", err)), nil
 	}
 
 	var mps []sejm.MP
-	if err := json.Unmarshal(data, &mps); err != nil {
+	if err := s.decodeAPIResponse(data, &mps); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse MP data from API response: %v. The API may have returned unexpected data format.", err)), nil
 	}
 
@@ -1196,14 +2540,14 @@ func (s *SejmServer) handleGetMPDetails(ctx context.Context, request mcp.CallToo
 		return mcp.NewToolResultError("MP ID is required. Please provide the mp_id parameter with a valid MP identification number. You can get MP IDs from the sejm_get_mps tool."), nil
 	}
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/MP/%s", sejmBaseURL, term, mpID)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/MP/%s", s.sejmBaseURL, term, mpID)
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve MP details from Polish Parliament API: %v. Please verify the MP ID (%s) exists in term %d. You can get valid MP IDs using sejm_get_mps.", err, mpID, term)), nil
 	}
 
 	var mp sejm.MP
-	if err := json.Unmarshal(data, &mp); err != nil {
+	if err := s.decodeAPIResponse(data, &mp); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse MP data from API response: %v. The API may have returned unexpected data format.", err)), nil
 	}
 
@@ -1259,7 +2603,7 @@ func (s *SejmServer) handleGetMPCompleteProfile(ctx context.Context, request mcp
 	}
 
 	// 1. Get MP Details
-	mpEndpoint := fmt.Sprintf("%s/sejm/term%d/MP/%s", sejmBaseURL, term, mpID)
+	mpEndpoint := fmt.Sprintf("%s/sejm/term%d/MP/%s", s.sejmBaseURL, term, mpID)
 	mpData, err := s.makeAPIRequest(ctx, mpEndpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve MP details: %v. Please verify the MP ID (%s) exists in term %d.", err, mpID, term)), nil
@@ -1267,27 +2611,27 @@ func (s *SejmServer) handleGetMPCompleteProfile(ctx context.Context, request mcp
 	profile.CallCount++
 
 	var mp sejm.MP
-	if err := json.Unmarshal(mpData, &mp); err != nil {
+	if err := s.decodeAPIResponse(mpData, &mp); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse MP data: %v", err)), nil
 	}
 	profile.MPDetails = &mp
 
 	// 2. Get Voting Statistics
-	statsEndpoint := fmt.Sprintf("%s/sejm/term%d/MP/%s/votings/stats", sejmBaseURL, term, mpID)
+	statsEndpoint := fmt.Sprintf("%s/sejm/term%d/MP/%s/votings/stats", s.sejmBaseURL, term, mpID)
 	if statsData, err := s.makeAPIRequest(ctx, statsEndpoint, nil); err == nil {
 		profile.CallCount++
 		var stats map[string]interface{}
-		if json.Unmarshal(statsData, &stats) == nil {
+		if s.decodeAPIResponse(statsData, &stats) == nil {
 			profile.VotingStats = stats
 		}
 	}
 
 	// 3. Get Committee Memberships by checking all committees
-	committeesEndpoint := fmt.Sprintf("%s/sejm/term%d/committees", sejmBaseURL, term)
+	committeesEndpoint := fmt.Sprintf("%s/sejm/term%d/committees", s.sejmBaseURL, term)
 	if committeesData, err := s.makeAPIRequest(ctx, committeesEndpoint, nil); err == nil {
 		profile.CallCount++
 		var committees []sejm.Committee
-		if json.Unmarshal(committeesData, &committees) == nil {
+		if s.decodeAPIResponse(committeesData, &committees) == nil {
 			// Find committees where this MP is a member
 			for _, committee := range committees {
 				if committee.Members != nil {
@@ -1385,14 +2729,14 @@ func (s *SejmServer) handleGetCommittees(ctx context.Context, request mcp.CallTo
 		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
 	}
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/committees", sejmBaseURL, term)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/committees", s.sejmBaseURL, term)
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve committees from Polish Parliament API: %v. Please try again.", err)), nil
 	}
 
 	var committees []sejm.Committee
-	if err := json.Unmarshal(data, &committees); err != nil {
+	if err := s.decodeAPIResponse(data, &committees); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse committee data from API response: %v. The API may have returned unexpected data format.", err)), nil
 	}
 
@@ -1474,6 +2818,10 @@ func (s *SejmServer) handleSearchVotings(ctx context.Context, request mcp.CallTo
 	sitting := request.GetString("sitting", "")
 	title := request.GetString("title", "")
 	limit := request.GetString("limit", "20")
+	scanScope := request.GetString("scan_scope", votingScanRecent)
+	if scanScope != votingScanRecent && scanScope != votingScanAll {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid scan_scope '%s'. Use 'recent' (the %d most recent sittings with votings) or 'all' (every sitting of the term).", scanScope, recentVotingSittings)), nil
+	}
 
 	var endpoint string
 	var params map[string]string
@@ -1490,12 +2838,12 @@ func (s *SejmServer) handleSearchVotings(ctx context.Context, request mcp.CallTo
 	// Choose the correct endpoint based on parameters
 	if sitting != "" {
 		// Get detailed votes from a specific sitting
-		endpoint = fmt.Sprintf("%s/sejm/term%d/votings/%s", sejmBaseURL, term, sitting)
+		endpoint = fmt.Sprintf("%s/sejm/term%d/votings/%s", s.sejmBaseURL, term, sitting)
 		params = nil
 	} else {
 		// Search for votes by title - implement client-side search
 		// since the API search endpoint appears to be non-functional
-		return s.searchVotingsByTitle(ctx, term, title, limit)
+		return s.searchVotingsByTitle(ctx, term, title, limit, scanScope, s.newProgressReporter(ctx, request))
 	}
 
 	data, err := s.makeAPIRequest(ctx, endpoint, params)
@@ -1508,7 +2856,7 @@ func (s *SejmServer) handleSearchVotings(ctx context.Context, request mcp.CallTo
 	}
 
 	var votings []sejm.Voting
-	if err := json.Unmarshal(data, &votings); err != nil {
+	if err := s.decodeAPIResponse(data, &votings); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse voting data from API response: %v. The API may have returned unexpected data format.", err)), nil
 	}
 
@@ -1612,27 +2960,28 @@ func (s *SejmServer) handleGetInterpellations(ctx context.Context, request mcp.C
 		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
 	}
 
-	params := make(map[string]string)
-	limit := request.GetString("limit", "20") // Reduced default to avoid context overflow
-	params["limit"] = limit
-
-	if offset := request.GetString("offset", ""); offset != "" {
-		params["offset"] = offset
+	page, err := parseListPage(request, 20) // Reduced default to avoid context overflow
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid pagination: %v.", err)), nil
 	}
-	if sortBy := request.GetString("sort_by", ""); sortBy != "" {
+	params := page.apiParams()
+	sortBy := request.GetString("sort_by", "")
+	if sortBy != "" {
 		params["sort_by"] = sortBy
 	}
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/interpellations", sejmBaseURL, term)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/interpellations", s.sejmBaseURL, term)
 	data, err := s.makeAPIRequest(ctx, endpoint, params)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve interpellations from Polish Parliament API: %v. Please try again.", err)), nil
 	}
 
 	var interpellations []sejm.Interpellation
-	if err := json.Unmarshal(data, &interpellations); err != nil {
+	if err := s.decodeAPIResponse(data, &interpellations); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse interpellation data from API response: %v. The API may have returned unexpected data format.", err)), nil
 	}
+	shown, more := page.fetched(len(interpellations))
+	interpellations = interpellations[:shown]
 
 	// Analyze accountability patterns
 	answeredCount := 0
@@ -1662,8 +3011,10 @@ func (s *SejmServer) handleGetInterpellations(ctx context.Context, request mcp.C
 	}
 
 	accountabilitySummary := fmt.Sprintf("Parliamentary oversight analysis for term %d:", term)
-	accountabilitySummary += fmt.Sprintf("\n- %d interpellations found (limit: %s)", len(interpellations), limit)
-	accountabilitySummary += fmt.Sprintf("\n- %d have received government responses (%.1f%%)", answeredCount, float64(answeredCount)*100/float64(len(interpellations)))
+	accountabilitySummary += "\n- " + page.describe("interpellations", shown, -1)
+	if shown > 0 {
+		accountabilitySummary += fmt.Sprintf("\n- %d have received government responses (%.1f%%)", answeredCount, float64(answeredCount)*100/float64(shown))
+	}
 	accountabilitySummary += fmt.Sprintf("\n- %d responses were delayed", delayedCount)
 	if delayedCount > 0 {
 		accountabilitySummary += fmt.Sprintf("\n- Average delay: %d days, Maximum delay: %d days\n\n", avgDelay, maxDelay)
@@ -1673,10 +3024,7 @@ func (s *SejmServer) handleGetInterpellations(ctx context.Context, request mcp.C
 
 	// Show interpellation summaries instead of full data
 	accountabilitySummary += "Recent interpellations (title, submitter, status):\n"
-	for i, interp := range interpellations {
-		if i >= 10 { // Show only first 10 to save space
-			break
-		}
+	for _, interp := range interpellations {
 		title := "No title"
 		if interp.Title != nil {
 			title = *interp.Title
@@ -1695,67 +3043,131 @@ func (s *SejmServer) handleGetInterpellations(ctx context.Context, request mcp.C
 		accountabilitySummary += fmt.Sprintf("- %s (by %s) - %s\n", title, submitter, status)
 	}
 
-	if len(interpellations) > 10 {
-		accountabilitySummary += fmt.Sprintf("\n... and %d more interpellations. Use a smaller limit for more targeted results.", len(interpellations)-10)
+	call := fmt.Sprintf("term='%d'", term)
+	if sortBy != "" {
+		call += fmt.Sprintf(", sort_by='%s'", sortBy)
+	}
+	if actions := page.navigation("sejm_get_interpellations", call, more); len(actions) > 0 {
+		accountabilitySummary += "\n" + strings.Join(actions, "\n")
 	}
 
 	return mcp.NewToolResultText(accountabilitySummary), nil
 }
 
-func (s *SejmServer) searchVotingsByTitle(ctx context.Context, term int, titleSearch string, limitStr string) (*mcp.CallToolResult, error) {
+func (s *SejmServer) searchVotingsByTitle(ctx context.Context, term int, titleSearch string, limitStr string, scanScope string, progress *progressReporter) (*mcp.CallToolResult, error) {
+	if s.votingIndex != nil {
+		matches, stats, err := s.searchVotingIndex(ctx, term, titleSearch, progress)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to build the voting index for term %d: %v", term, err)), nil
+		}
+		scope := fmt.Sprintf("Searched the voting index: %d sittings, %d votings (whole term, updated %s)", stats.Sittings, stats.Votings, stats.UpdatedAt.Format("2006-01-02 15:04"))
+		if stats.Refreshed > 0 {
+			scope += fmt.Sprintf(", %d sittings indexed now", stats.Refreshed)
+		}
+		if stats.Failed > 0 {
+			scope += fmt.Sprintf(", %d sittings could not be indexed and will be retried", stats.Failed)
+		}
+		result := formatVotingTitleSearch(term, titleSearch, limitStr, scope, matches)
+		if stats.Failed > 0 {
+			markPartial(result)
+		}
+		return result, nil
+	}
+
 	// First, get all voting sessions
-	votingSessionsEndpoint := fmt.Sprintf("%s/sejm/term%d/votings", sejmBaseURL, term)
-	sessionsData, err := s.makeAPIRequest(ctx, votingSessionsEndpoint, nil)
+	sessions, err := s.fetchVotingSessions(ctx, term)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve voting sessions from Polish Parliament API: %v", err)), nil
 	}
 
-	var sessions []struct {
-		Date       string `json:"date"`
-		Proceeding int    `json:"proceeding"`
-		VotingsNum int    `json:"votingsNum"`
+	// The sessions list has an entry per sitting day; each sitting with votings is scanned once, newest first
+	var sittings []int
+	counts := make(map[int]int)
+	for i := len(sessions) - 1; i >= 0; i-- {
+		if sessions[i].VotingsNum == 0 {
+			continue
+		}
+		if _, seen := counts[sessions[i].Proceeding]; !seen {
+			sittings = append(sittings, sessions[i].Proceeding)
+		}
+		counts[sessions[i].Proceeding] += sessions[i].VotingsNum
 	}
-	if err := json.Unmarshal(sessionsData, &sessions); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse voting sessions data: %v", err)), nil
+	toScan := sittings
+	if scanScope == votingScanRecent && len(toScan) > recentVotingSittings {
+		toScan = toScan[:recentVotingSittings] // Limit to recent proceedings to avoid timeouts
 	}
 
-	// Search through recent proceedings (limit to avoid excessive API calls)
 	var allMatchingVotings []sejm.Voting
-	searchedProceedings := 0
-	maxProceedingsToSearch := 20 // Limit to recent proceedings to avoid timeouts
-
-	for i := len(sessions) - 1; i >= 0 && searchedProceedings < maxProceedingsToSearch; i-- {
-		session := sessions[i]
-		if session.VotingsNum == 0 {
-			continue
+	var scanned, reused []int
+	matched := make(map[[2]int]bool)
+	titleLower := strings.ToLower(titleSearch)
+	coverage := newSourceCoverage("sittings")
+	for i, sitting := range toScan {
+		if ctx.Err() != nil {
+			break
 		}
+		progress.report(i, len(toScan), fmt.Sprintf("Scanning sitting %d (%d of %d)", sitting, i+1, len(toScan)))
 
-		// Get detailed votings for this proceeding
-		proceedingEndpoint := fmt.Sprintf("%s/sejm/term%d/votings/%d", sejmBaseURL, term, session.Proceeding)
-		proceedingData, err := s.makeAPIRequest(ctx, proceedingEndpoint, nil)
+		votings, cached, err := s.sittingVotings(ctx, term, sitting, counts[sitting])
 		if err != nil {
-			continue // Skip failed requests to avoid breaking the search
+			coverage.fail(fmt.Sprintf("sitting %d", sitting), err)
+			continue // Skip failed sittings to avoid breaking the search
 		}
-
-		var votings []sejm.Voting
-		if err := json.Unmarshal(proceedingData, &votings); err != nil {
-			continue // Skip parsing errors
+		coverage.succeeded()
+		scanned = append(scanned, sitting)
+		if cached {
+			reused = append(reused, sitting)
 		}
 
-		// Search for title matches (case-insensitive)
-		titleLower := strings.ToLower(titleSearch)
+		// A voting whose title and topic both match is listed once
 		for _, voting := range votings {
-			if voting.Title != nil && strings.Contains(strings.ToLower(*voting.Title), titleLower) {
-				allMatchingVotings = append(allMatchingVotings, voting)
+			if !strings.Contains(strings.ToLower(stringValue(voting.Title)), titleLower) &&
+				!strings.Contains(strings.ToLower(stringValue(voting.Topic)), titleLower) {
+				continue
 			}
-			if voting.Topic != nil && strings.Contains(strings.ToLower(*voting.Topic), titleLower) {
-				allMatchingVotings = append(allMatchingVotings, voting)
+			if voting.VotingNumber != nil {
+				key := [2]int{sitting, int(*voting.VotingNumber)}
+				if matched[key] {
+					continue
+				}
+				matched[key] = true
 			}
+			allMatchingVotings = append(allMatchingVotings, voting)
 		}
+	}
 
-		searchedProceedings++
+	progress.report(len(toScan), len(toScan), "Scan finished")
+
+	scope := fmt.Sprintf("Searched %d of %d sittings with votings", len(scanned), len(sittings))
+	if len(scanned) == len(sittings) {
+		scope += " (whole term)"
+	} else if len(toScan) < len(sittings) {
+		scope += fmt.Sprintf(" (the %d most recent; older sittings were NOT searched, use scan_scope='all' for the whole term)", len(toScan))
+	}
+	if len(scanned) > 0 {
+		scope += fmt.Sprintf("\n- Scanned sittings: %s", formatSittingNumbers(scanned))
+		if len(reused) > 0 {
+			scope += fmt.Sprintf(" (%d reused from the cache)", len(reused))
+		}
+	}
+	if skipped := unscannedSittings(sittings, scanned); len(skipped) > 0 {
+		scope += fmt.Sprintf("\n- Skipped sittings: %s", formatSittingNumbers(skipped))
 	}
+	if coverage.partial() {
+		scope += fmt.Sprintf("\n- %s, results are partial (%s)", coverage.headline(), strings.Join(coverage.failed, "; "))
+	}
+	if ctx.Err() != nil {
+		scope += "\n- The scan was interrupted before it finished"
+	}
+	result := formatVotingTitleSearch(term, titleSearch, limitStr, scope, allMatchingVotings)
+	if coverage.partial() || ctx.Err() != nil {
+		markPartial(result)
+	}
+	return result, nil
+}
 
+// formatVotingTitleSearch renders title search matches; scope describes which votings were searched
+func formatVotingTitleSearch(term int, titleSearch, limitStr, scope string, allMatchingVotings []sejm.Voting) *mcp.CallToolResult {
 	// Apply limit
 	limitInt := 20
 	if limitStr != "" {
@@ -1763,7 +3175,8 @@ func (s *SejmServer) searchVotingsByTitle(ctx context.Context, term int, titleSe
 			limitInt = 20 // fallback to default
 		}
 	}
-	if len(allMatchingVotings) > limitInt {
+	totalMatches := len(allMatchingVotings)
+	if totalMatches > limitInt {
 		allMatchingVotings = allMatchingVotings[:limitInt]
 	}
 
@@ -1797,8 +3210,8 @@ func (s *SejmServer) searchVotingsByTitle(ctx context.Context, term int, titleSe
 	}
 
 	searchSummary := fmt.Sprintf("Voting search results for term %d (search: '%s'):", term, titleSearch)
-	searchSummary += fmt.Sprintf("\n- Searched %d recent proceedings", searchedProceedings)
-	searchSummary += fmt.Sprintf("\n- Found %d matching voting records (showing %d)", len(allMatchingVotings), len(allMatchingVotings))
+	searchSummary += "\n- " + scope
+	searchSummary += fmt.Sprintf("\n- Found %d matching voting records (showing %d)", totalMatches, len(allMatchingVotings))
 	if len(allMatchingVotings) > 0 {
 		searchSummary += fmt.Sprintf("\n- %d votes passed, %d failed", passedCount, len(allMatchingVotings)-passedCount)
 		searchSummary += fmt.Sprintf("\n- %d electronic votes, %d traditional votes", electronicCount, traditionalCount)
@@ -1857,18 +3270,18 @@ func (s *SejmServer) searchVotingsByTitle(ctx context.Context, term int, titleSe
 		}
 	}
 
-	return mcp.NewToolResultText(searchSummary), nil
+	return mcp.NewToolResultText(searchSummary)
 }
 
 func (s *SejmServer) handleGetTerms(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	endpoint := fmt.Sprintf("%s/sejm/term", sejmBaseURL)
+	endpoint := fmt.Sprintf("%s/sejm/term", s.sejmBaseURL)
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve terms from Polish Parliament API: %v. Please try again.", err)), nil
 	}
 
 	var terms []sejm.Term
-	if err := json.Unmarshal(data, &terms); err != nil {
+	if err := s.decodeAPIResponse(data, &terms); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse terms data from API response: %v. The API may have returned unexpected data format.", err)), nil
 	}
 
@@ -1897,14 +3310,14 @@ func (s *SejmServer) handleGetClubs(ctx context.Context, request mcp.CallToolReq
 		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
 	}
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/clubs", sejmBaseURL, term)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/clubs", s.sejmBaseURL, term)
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve clubs from Polish Parliament API: %v. Please try again.", err)), nil
 	}
 
 	var clubs []sejm.Club
-	if err := json.Unmarshal(data, &clubs); err != nil {
+	if err := s.decodeAPIResponse(data, &clubs); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse clubs data from API response: %v. The API may have returned unexpected data format.", err)), nil
 	}
 
@@ -1934,31 +3347,108 @@ func (s *SejmServer) handleGetVotingDetails(ctx context.Context, request mcp.Cal
 	sitting := request.GetString("sitting", "")
 	votingNumber := request.GetString("voting_number", "")
 	format := request.GetString("format", "json")
+	includeContext := request.GetString("include_context", "true") != "false"
+	includeRollCall := request.GetString("include_roll_call", "true") != "false"
+	verify := request.GetString("verify", "false") == "true"
 
 	if sitting == "" || votingNumber == "" {
 		return mcp.NewToolResultError("Both 'sitting' and 'voting_number' parameters are required. Get these from sejm_search_votings results."), nil
 	}
 
 	// First get the detailed voting information (JSON)
-	endpoint := fmt.Sprintf("%s/sejm/term%d/votings/%s/%s", sejmBaseURL, term, sitting, votingNumber)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/votings/%s/%s", s.sejmBaseURL, term, sitting, votingNumber)
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve voting details: %v. Please verify sitting=%s and voting_number=%s exist.", err, sitting, votingNumber)), nil
 	}
 
-	var voting sejm.Voting
-	if err := json.Unmarshal(data, &voting); err != nil {
+	var voting sejm.VotingDetails
+	if err := s.decodeAPIResponse(data, &voting); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse voting data: %v.", err)), nil
 	}
 
+	// Prints named in the voting title and their processes tell what exactly was voted on
+	var votingCtx votingContext
+	if includeContext && format != "pdf" {
+		votingCtx = s.resolveVotingContext(ctx, term, voting)
+	}
+	// Verification compares against the votes as served by the API, before any are filled in from the PDF
+	var apiVotes []sejm.Vote
+	if voting.Votes != nil {
+		apiVotes = *voting.Votes
+	}
+
 	if format == "json" {
+		// Older terms have no MP-level votes in JSON; fill them in from the official PDF when possible. The PDF
+		// header also names the chair, so it is read once for both.
+		noVotes := voting.Votes == nil || len(*voting.Votes) == 0
+		var pages []string
+		var pdfErr error
+		if noVotes || includeRollCall || verify {
+			pages, pdfErr = s.fetchVotingPDFPages(ctx, term, sitting, votingNumber)
+		}
+		source := ""
+		if noVotes {
+			var records []pdfVoteRecord
+			err := pdfErr
+			if err == nil {
+				records, err = votingPDFRecords(pages)
+			}
+			if err != nil {
+				source = fmt.Sprintf("\n\nNote: the API has no MP-level votes for this voting and they could not be read from the PDF (%v). Use format='text' to read the PDF.", err)
+			} else {
+				votes := pdfRecordsToVotes(records)
+				voting.Votes = &votes
+				source = fmt.Sprintf("\n\nNote: the API has no MP-level votes for this voting; the %d votes above were parsed from the official PDF and carry no MP IDs.", len(votes))
+			}
+		}
+
 		// Return structured JSON data
 		result, _ := json.MarshalIndent(voting, "", "  ")
-		return mcp.NewToolResultText(fmt.Sprintf("Detailed voting information for sitting %s, vote %s:\n\n%s", sitting, votingNumber, string(result))), nil
+		if includeRollCall {
+			rollCallJSON, _ := json.MarshalIndent(votingRollCallFromPDF(voting, pages, pdfErr), "", "  ")
+			source += fmt.Sprintf("\n\nRoll-call metadata:\n%s", string(rollCallJSON))
+		}
+		if verify {
+			verificationJSON, _ := json.MarshalIndent(verifyVotingAgainstPDF(voting, apiVotes, pages, pdfErr), "", "  ")
+			source += fmt.Sprintf("\n\nVerification of the tallies against the official PDF:\n%s", string(verificationJSON))
+		}
+		if includeContext {
+			contextJSON, _ := json.MarshalIndent(votingCtx, "", "  ")
+			source += fmt.Sprintf("\n\nRelated prints and legislative processes:\n%s", string(contextJSON))
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Detailed voting information for sitting %s, vote %s:\n\n%s%s", sitting, votingNumber, string(result), source)), nil
+	}
+
+	if format == "records" {
+		pages, err := s.fetchVotingPDFPages(ctx, term, sitting, votingNumber)
+		var records []pdfVoteRecord
+		if err == nil {
+			records, err = votingPDFRecords(pages)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to parse MP votes from the voting PDF: %v. Use format='text' to read the PDF as plain text.", err)), nil
+		}
+		response := votingPDFRecordsResponse(sitting, votingNumber, voting, records)
+		if verify {
+			response.Summary = append(response.Summary, verifyVotingAgainstPDF(voting, apiVotes, pages, nil).lines()...)
+		}
+		if includeRollCall {
+			// The chair's vote is looked up in the PDF records when the API has no MP-level votes
+			if voting.Votes == nil || len(*voting.Votes) == 0 {
+				votes := pdfRecordsToVotes(records)
+				voting.Votes = &votes
+			}
+			response.Summary = append(response.Summary, parseVotingRollCall(voting, pages).lines()...)
+		}
+		if includeContext {
+			response.Summary = append(response.Summary, votingCtx.lines()...)
+		}
+		return mcp.NewToolResultText(response.Format()), nil
 	}
 
 	// For text/pdf formats, try to get the PDF version
-	pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/votings/%s/%s/pdf", sejmBaseURL, term, sitting, votingNumber)
+	pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/votings/%s/%s/pdf", s.sejmBaseURL, term, sitting, votingNumber)
 
 	if format == "pdf" {
 		// Return PDF download info
@@ -1972,15 +3462,26 @@ func (s *SejmServer) handleGetVotingDetails(ctx context.Context, request mcp.Cal
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve PDF for text conversion: %v. This voting may not have a PDF version available.", err)), nil
 		}
 
-		extractedText, err := s.extractTextFromPDF(pdfData)
+		extractedText, err := s.extractTextFromPDF(ctx, pdfData)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to extract text from PDF: %v.", err)), nil
 		}
 
-		return mcp.NewToolResultText(fmt.Sprintf("Voting details for sitting %s, vote %s (converted from PDF):\n\n%s", sitting, votingNumber, extractedText)), nil
+		header := ""
+		if includeRollCall {
+			// The header of the PDF opens the extracted text
+			header = "Roll-call metadata:\n" + strings.Join(parseVotingRollCall(voting, []string{extractedText}).lines(), "\n") + "\n\n"
+		}
+		if includeContext {
+			header += "Related prints and processes:\n" + strings.Join(votingCtx.lines(), "\n") + "\n\n"
+		}
+		if verify {
+			header += "Verification:\n" + strings.Join(verifyVotingAgainstPDF(voting, apiVotes, []string{extractedText}, nil).lines(), "\n") + "\n\n"
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Voting details for sitting %s, vote %s (converted from PDF):\n\n%s%s", sitting, votingNumber, header, extractedText)), nil
 	}
 
-	return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'json', 'text', or 'pdf'.", format)), nil
+	return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'json', 'records', 'text', or 'pdf'.", format)), nil
 }
 
 func (s *SejmServer) handleSearchVotingContent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -1998,6 +3499,10 @@ func (s *SejmServer) handleSearchVotingContent(ctx context.Context, request mcp.
 	if sitting == "" || votingNumber == "" || searchTerms == "" {
 		return mcp.NewToolResultError("Parameters 'sitting', 'voting_number', and 'search_terms' are all required."), nil
 	}
+	options, err := parseTextSearchOptions(request)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid search options: %v.", err)), nil
+	}
 
 	// Parse parameters similar to eli_search_act_content
 	contextCharsInt := 100
@@ -2023,14 +3528,14 @@ func (s *SejmServer) handleSearchVotingContent(ctx context.Context, request mcp.
 	}
 
 	// Download the PDF
-	pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/votings/%s/%s/pdf", sejmBaseURL, term, sitting, votingNumber)
+	pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/votings/%s/%s/pdf", s.sejmBaseURL, term, sitting, votingNumber)
 	pdfData, err := s.makeTextRequest(ctx, pdfEndpoint, "pdf")
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve PDF for search: %v. This voting may not have a PDF version available.", err)), nil
 	}
 
 	// Use the same search logic as ELI content search
-	return s.searchPDFContent(ctx, pdfData, fmt.Sprintf("voting %s/%s", sitting, votingNumber), searchTerms, contextCharsInt, maxMatchesInt)
+	return s.searchPDFContent(ctx, pdfData, fmt.Sprintf("voting %s/%s", sitting, votingNumber), searchTerms, options, contextCharsInt, maxMatchesInt)
 }
 
 func (s *SejmServer) handleGetProceedings(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -2039,41 +3544,82 @@ func (s *SejmServer) handleGetProceedings(ctx context.Context, request mcp.CallT
 		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
 	}
 
-	params := make(map[string]string)
-	limit := request.GetString("limit", "20")
-	params["limit"] = limit
+	page, err := parseListPage(request, 20)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid pagination: %v.", err)), nil
+	}
+	sortBy := request.GetString("sort_by", "-number")
+	if sortBy != "number" && sortBy != "-number" {
+		return mcp.NewToolResultError("Parameter 'sort_by' must be 'number' (oldest first) or '-number' (most recent first)."), nil
+	}
+	since, err := parseDefectionDate("since", request.GetString("since", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	till, err := parseDefectionDate("till", request.GetString("till", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if !since.IsZero() && !till.IsZero() && till.Before(since) {
+		return mcp.NewToolResultError("Parameter 'till' must not be earlier than 'since'."), nil
+	}
+	includeAgenda := request.GetString("include_agenda", "false") == "true"
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/proceedings", sejmBaseURL, term)
-	data, err := s.makeAPIRequest(ctx, endpoint, params)
+	// The proceedings endpoint has no paging, so the whole (short) list is fetched and paged locally
+	endpoint := fmt.Sprintf("%s/sejm/term%d/proceedings", s.sejmBaseURL, term)
+	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve proceedings from Polish Parliament API: %v. Please try again.", err)), nil
 	}
 
 	var proceedings []sejm.Proceeding
-	if err := json.Unmarshal(data, &proceedings); err != nil {
+	if err := s.decodeAPIResponse(data, &proceedings); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse proceedings data from API response: %v. The API may have returned unexpected data format.", err)), nil
 	}
 
-	// Sort proceedings by number in descending order to show most recent first
-	for i := 0; i < len(proceedings)-1; i++ {
-		for j := i + 1; j < len(proceedings); j++ {
-			// Handle nil proceeding numbers safely
-			numI := int32(0)
-			if proceedings[i].Number != nil {
-				numI = *proceedings[i].Number
-			}
-			numJ := int32(0)
-			if proceedings[j].Number != nil {
-				numJ = *proceedings[j].Number
+	// A proceeding matches the date window when any of its sitting days falls in it
+	if !since.IsZero() || !till.IsZero() {
+		var matching []sejm.Proceeding
+		for _, proceeding := range proceedings {
+			if proceeding.Dates == nil {
+				continue
 			}
-			// Sort in descending order (most recent first)
-			if numI < numJ {
-				proceedings[i], proceedings[j] = proceedings[j], proceedings[i]
+			for _, date := range *proceeding.Dates {
+				if (since.IsZero() || !date.Time.Before(since)) && (till.IsZero() || !date.Time.After(till)) {
+					matching = append(matching, proceeding)
+					break
+				}
 			}
 		}
+		proceedings = matching
+	}
+
+	proceedingNumber := func(p sejm.Proceeding) int32 {
+		if p.Number == nil {
+			return 0
+		}
+		return *p.Number
 	}
+	sort.SliceStable(proceedings, func(i, j int) bool {
+		if sortBy == "number" {
+			return proceedingNumber(proceedings[i]) < proceedingNumber(proceedings[j])
+		}
+		return proceedingNumber(proceedings[i]) > proceedingNumber(proceedings[j])
+	})
+	total := len(proceedings)
+	start, end := page.bounds(total)
+	proceedings = proceedings[start:end]
 
-	summary := fmt.Sprintf("Parliamentary Proceedings for Term %d (most recent first):\n\n", term)
+	order := "most recent first"
+	if sortBy == "number" {
+		order = "oldest first"
+	}
+	summary := fmt.Sprintf("Parliamentary Proceedings for Term %d (%s):\n", term, order)
+	summary += page.describe("proceedings", len(proceedings), total) + "\n"
+	if !since.IsZero() || !till.IsZero() {
+		summary += fmt.Sprintf("Sitting days from %s to %s\n", formatOptionalDate(since, "start of term"), formatOptionalDate(till, "now"))
+	}
+	summary += "\n"
 	summary += "⚠️  IMPORTANT: Proceedings often span multiple days. When searching transcripts, you must search each day separately using sejm_search_transcript_content.\n\n"
 
 	multiDayCount := 0
@@ -2086,12 +3632,8 @@ func (s *SejmServer) handleGetProceedings(ctx context.Context, request mcp.CallT
 		summary += fmt.Sprintf("📅 Multi-day proceedings found: %d out of %d proceedings span multiple days.\n\n", multiDayCount, len(proceedings))
 	}
 
-	for i, proceeding := range proceedings {
-		if i >= 20 { // Limit displayed entries
-			summary += fmt.Sprintf("... and %d more proceedings\n", len(proceedings)-i)
-			break
-		}
-
+	coverage := newSourceCoverage("agendas")
+	for _, proceeding := range proceedings {
 		if proceeding.Number != nil {
 			summary += fmt.Sprintf("Proceeding %d:\n", *proceeding.Number)
 		}
@@ -2113,10 +3655,30 @@ func (s *SejmServer) handleGetProceedings(ctx context.Context, request mcp.CallT
 		if proceeding.Title != nil {
 			summary += fmt.Sprintf("  Title: %s\n", *proceeding.Title)
 		}
+		if includeAgenda {
+			summary += s.proceedingAgenda(ctx, term, proceeding, coverage)
+		}
 		summary += "\n"
 	}
 
-	return mcp.NewToolResultText(summary), nil
+	filters := fmt.Sprintf("term='%d', sort_by='%s'", term, sortBy)
+	if !since.IsZero() {
+		filters += fmt.Sprintf(", since='%s'", since.Format("2006-01-02"))
+	}
+	if !till.IsZero() {
+		filters += fmt.Sprintf(", till='%s'", till.Format("2006-01-02"))
+	}
+	if includeAgenda {
+		filters += ", include_agenda='true'"
+	}
+	for _, action := range page.navigation("sejm_get_proceedings", filters, end < total) {
+		summary += action + "\n"
+	}
+	if coverage.partial() {
+		summary += fmt.Sprintf("\nAgendas: %s\n", coverage.headline())
+	}
+
+	return coverage.result(summary), nil
 }
 
 func (s *SejmServer) handleGetPrints(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -2125,39 +3687,36 @@ func (s *SejmServer) handleGetPrints(ctx context.Context, request mcp.CallToolRe
 		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
 	}
 
-	params := make(map[string]string)
-	limit := request.GetString("limit", "30")
-	params["limit"] = limit
-
-	if offset := request.GetString("offset", ""); offset != "" {
-		params["offset"] = offset
+	page, err := parseListPage(request, 30)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid pagination: %v.", err)), nil
 	}
-	if sortBy := request.GetString("sort_by", ""); sortBy != "" {
+	params := page.apiParams()
+	sortBy := request.GetString("sort_by", "")
+	if sortBy != "" {
 		params["sort_by"] = sortBy
 	}
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/prints", sejmBaseURL, term)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/prints", s.sejmBaseURL, term)
 	data, err := s.makeAPIRequest(ctx, endpoint, params)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve prints from Polish Parliament API: %v. Please try again.", err)), nil
 	}
 
 	var prints []sejm.Print
-	if err := json.Unmarshal(data, &prints); err != nil {
+	if err := s.decodeAPIResponse(data, &prints); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse prints data from API response: %v. The API may have returned unexpected data format.", err)), nil
 	}
 
-	summary := fmt.Sprintf("Parliamentary Prints (Legislative Documents) for Term %d:\n\n", term)
+	shown, more := page.fetched(len(prints))
+	prints = prints[:shown]
 
-	// Note: Print type doesn't have DocumentType field, so we'll just show the prints directly
+	summary := fmt.Sprintf("Parliamentary Prints (Legislative Documents) for Term %d:\n", term)
+	summary += page.describe("prints", shown, -1) + "\n\n"
 
-	summary += "Recent Prints:\n"
-	for i, printItem := range prints {
-		if i >= 15 { // Limit displayed entries
-			summary += fmt.Sprintf("... and %d more prints\n", len(prints)-i)
-			break
-		}
+	// Note: Print type doesn't have DocumentType field, so we'll just show the prints directly
 
+	for _, printItem := range prints {
 		if printItem.Number != nil {
 			summary += fmt.Sprintf("Print %s:", *printItem.Number)
 		}
@@ -2167,6 +3726,14 @@ func (s *SejmServer) handleGetPrints(ctx context.Context, request mcp.CallToolRe
 		summary += "\n"
 	}
 
+	call := fmt.Sprintf("term='%d'", term)
+	if sortBy != "" {
+		call += fmt.Sprintf(", sort_by='%s'", sortBy)
+	}
+	if actions := page.navigation("sejm_get_prints", call, more); len(actions) > 0 {
+		summary += "\n" + strings.Join(actions, "\n") + "\n"
+	}
+
 	return mcp.NewToolResultText(summary), nil
 }
 
@@ -2189,17 +3756,33 @@ func (s *SejmServer) handleGetTranscripts(ctx context.Context, request mcp.CallT
 		return mcp.NewToolResultError("Both 'proceeding_id' and 'date' parameters are required. Get these from sejm_get_proceedings results."), nil
 	}
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/proceedings/%s/%s/transcripts", sejmBaseURL, term, proceedingID, date)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/proceedings/%s/%s/transcripts", s.sejmBaseURL, term, proceedingID, date)
+
+	if request.GetString("summarize", "false") == "true" {
+		pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/proceedings/%s/%s/transcripts/pdf", s.sejmBaseURL, term, proceedingID, date)
+		pdfData, err := s.makeTextRequest(ctx, pdfEndpoint, "pdf")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve PDF transcript for summarization: %v. This proceeding may not have a PDF transcript available.", err)), nil
+		}
+		pageTexts, err := s.extractPDFPageTexts(ctx, pdfData)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to extract transcript text: %v", err)), nil
+		}
+		return summaryResult(fmt.Sprintf("Sejm transcript, proceeding %s, %s (term %d)", proceedingID, date, term), pageTexts,
+			parseSummarySentences(request.GetString("summary_sentences", "")), "page", func(page int) string {
+				return fmt.Sprintf("sejm_get_transcripts with proceeding_id='%s', date='%s', format='text', page='%d', pages_per_chunk='1'", proceedingID, date, page)
+			}), nil
+	}
 
 	if format == "pdf" {
 		// Return PDF download info
-		pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/proceedings/%s/%s/transcripts/pdf", sejmBaseURL, term, proceedingID, date)
+		pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/proceedings/%s/%s/transcripts/pdf", s.sejmBaseURL, term, proceedingID, date)
 		return mcp.NewToolResultText(fmt.Sprintf("PDF transcript available at: %s\n\nUse format='text' to get searchable text extracted from this PDF.", pdfEndpoint)), nil
 	}
 
 	if format == "text" {
 		// Download PDF and convert to text with pagination
-		pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/proceedings/%s/%s/transcripts/pdf", sejmBaseURL, term, proceedingID, date)
+		pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/proceedings/%s/%s/transcripts/pdf", s.sejmBaseURL, term, proceedingID, date)
 		pdfData, err := s.makeTextRequest(ctx, pdfEndpoint, "pdf")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve PDF for text conversion: %v. This proceeding may not have a PDF transcript available.", err)), nil
@@ -2227,11 +3810,30 @@ func (s *SejmServer) handleGetTranscripts(ctx context.Context, request mcp.CallT
 	}
 
 	var statements sejm.StatementList
-	if err := json.Unmarshal(data, &statements); err != nil {
+	if err := s.decodeAPIResponse(data, &statements); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse transcript data from API response: %v. The API may have returned unexpected data format.", err)), nil
 	}
 
 	allStatements := *statements.Statements
+
+	// The table of contents and the statements of one agenda item need the items detected in the statements
+	groupBy := request.GetString("group_by", "")
+	agendaItem := request.GetString("agenda_item", "")
+	if groupBy != "" && groupBy != "agenda_item" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid group_by '%s'. Use 'agenda_item'.", groupBy)), nil
+	}
+	navigation := ""
+	if groupBy != "" || agendaItem != "" {
+		toc, coverage := s.transcriptTOC(ctx, request, term, proceedingID, date, allStatements)
+		if agendaItem == "" {
+			return transcriptTOCResult(term, proceedingID, date, toc, coverage), nil
+		}
+		allStatements, err = statementsOfAgendaItem(allStatements, toc, agendaItem)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Cannot list the statements: %v.", err)), nil
+		}
+		navigation = fmt.Sprintf(", agenda_item='%s'", agendaItem)
+	}
 	totalStatements := len(allStatements)
 
 	// Apply pagination
@@ -2253,6 +3855,9 @@ func (s *SejmServer) handleGetTranscripts(ctx context.Context, request mcp.CallT
 	var summary []string
 	summary = append(summary, fmt.Sprintf("Proceeding: %s (Term %d)", proceedingID, term))
 	summary = append(summary, fmt.Sprintf("Date: %s", date))
+	if agendaItem != "" {
+		summary = append(summary, fmt.Sprintf("Agenda item: %s", agendaItem))
+	}
 	summary = append(summary, fmt.Sprintf("Total statements: %d", totalStatements))
 	summary = append(summary, fmt.Sprintf("Showing: %d-%d of %d statements", start+1, end, totalStatements))
 
@@ -2293,15 +3898,18 @@ func (s *SejmServer) handleGetTranscripts(ctx context.Context, request mcp.CallT
 		if prevOffset < 0 {
 			prevOffset = 0
 		}
-		nextActions = append(nextActions, fmt.Sprintf("Previous page: sejm_get_transcripts with proceeding_id='%s', date='%s', offset='%d', limit='%d'", proceedingID, date, prevOffset, limit))
+		nextActions = append(nextActions, fmt.Sprintf("Previous page: sejm_get_transcripts with proceeding_id='%s', date='%s'%s, offset='%d', limit='%d'", proceedingID, date, navigation, prevOffset, limit))
 	}
 
 	if end < totalStatements {
 		nextOffset := offset + limit
-		nextActions = append(nextActions, fmt.Sprintf("Next page: sejm_get_transcripts with proceeding_id='%s', date='%s', offset='%d', limit='%d'", proceedingID, date, nextOffset, limit))
+		nextActions = append(nextActions, fmt.Sprintf("Next page: sejm_get_transcripts with proceeding_id='%s', date='%s'%s, offset='%d', limit='%d'", proceedingID, date, navigation, nextOffset, limit))
 	}
 
 	nextActions = append(nextActions, "Get full statement text: sejm_get_statement with specific statement_num")
+	if agendaItem == "" {
+		nextActions = append(nextActions, "Table of contents by agenda item: add group_by='agenda_item'")
+	}
 	nextActions = append(nextActions, "Search transcript content: sejm_search_transcript_content for specific topics or speakers")
 	nextActions = append(nextActions, "Download full transcript: sejm_get_transcripts with format='pdf' or format='text'")
 
@@ -2329,19 +3937,46 @@ func (s *SejmServer) handleGetStatement(ctx context.Context, request mcp.CallToo
 	chunkSize := request.GetString("chunk_size", "5000")
 	chunkNumber := request.GetString("chunk_number", "1")
 	showChunkInfo := request.GetString("show_chunk_info", "false")
+	render := request.GetString("render", renderHTML)
+	sanitize := request.GetString("sanitize", "true") != "false"
 
 	if proceedingID == "" || date == "" || statementNum == "" {
 		return mcp.NewToolResultError("Parameters 'proceeding_id', 'date', and 'statement_num' are all required. Get these from sejm_get_transcripts results."), nil
 	}
+	if _, err := renderHTMLBody("", render, false); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	metadataMode := strings.ToLower(request.GetString("metadata", statementMetadataNone))
+	if metadataMode != statementMetadataNone && metadataMode != statementMetadataInclude && metadataMode != statementMetadataOnly {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid metadata '%s'. Use 'false', 'true' or 'only'.", metadataMode)), nil
+	}
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/proceedings/%s/%s/transcripts/%s", sejmBaseURL, term, proceedingID, date, statementNum)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/proceedings/%s/%s/transcripts/%s", s.sejmBaseURL, term, proceedingID, date, statementNum)
 	data, err := s.makeTextRequest(ctx, endpoint, "html")
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve statement from Polish Parliament API: %v. Please verify proceeding_id=%s, date=%s, and statement_num=%s exist.", err, proceedingID, date, statementNum)), nil
 	}
 
+	var metadataJSON string
+	if metadataMode != statementMetadataNone {
+		output, _ := json.MarshalIndent(s.statementMetadataFor(ctx, term, proceedingID, date, statementNum, string(data)), "", "  ")
+		metadataJSON = string(output)
+		if metadataMode == statementMetadataOnly {
+			return mcp.NewToolResultText(metadataJSON), nil
+		}
+	}
+
+	content, _ := renderHTMLBody(string(data), render, sanitize)
+
 	// Handle HTML chunking for large responses
-	return s.chunkHTMLContent(string(data), fmt.Sprintf("Statement %s from proceeding %s on %s", statementNum, proceedingID, date), chunkSize, chunkNumber, showChunkInfo)
+	result, err := s.chunkHTMLContent(content, fmt.Sprintf("Statement %s from proceeding %s on %s", statementNum, proceedingID, date), chunkSize, chunkNumber, showChunkInfo)
+	if err != nil || result.IsError || metadataJSON == "" {
+		return result, err
+	}
+	if text, ok := result.Content[0].(mcp.TextContent); ok {
+		result.Content[0] = mcp.NewTextContent("Statement metadata:\n" + metadataJSON + "\n\n" + text.Text)
+	}
+	return result, nil
 }
 
 func (s *SejmServer) handleSearchTranscriptContent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -2359,6 +3994,10 @@ func (s *SejmServer) handleSearchTranscriptContent(ctx context.Context, request
 	if proceedingID == "" || date == "" || searchTerms == "" {
 		return mcp.NewToolResultError("Parameters 'proceeding_id', 'date', and 'search_terms' are all required."), nil
 	}
+	options, err := parseTextSearchOptions(request)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid search options: %v.", err)), nil
+	}
 
 	// Parse parameters similar to other search functions
 	contextCharsInt := 100
@@ -2384,14 +4023,14 @@ func (s *SejmServer) handleSearchTranscriptContent(ctx context.Context, request
 	}
 
 	// Download the PDF transcript
-	pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/proceedings/%s/%s/transcripts/pdf", sejmBaseURL, term, proceedingID, date)
+	pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/proceedings/%s/%s/transcripts/pdf", s.sejmBaseURL, term, proceedingID, date)
 	pdfData, err := s.makeTextRequest(ctx, pdfEndpoint, "pdf")
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve PDF transcript for search: %v. This proceeding may not have a PDF transcript available for date %s.", err, date)), nil
 	}
 
 	// Use the same search logic as other PDF content searches
-	return s.searchPDFContent(ctx, pdfData, fmt.Sprintf("transcript proceeding-%s date-%s", proceedingID, date), searchTerms, contextCharsInt, maxMatchesInt)
+	return s.searchPDFContent(ctx, pdfData, fmt.Sprintf("transcript proceeding-%s date-%s", proceedingID, date), searchTerms, options, contextCharsInt, maxMatchesInt)
 }
 
 func (s *SejmServer) handleGetParliamentaryKeywords(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -2603,45 +4242,48 @@ func (s *SejmServer) handleGetCommitteeSittingsByDate(ctx context.Context, reque
 		params["canceled"] = "true"
 	}
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/committees/sittings/%s", sejmBaseURL, term, date)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/committees/sittings/%s", s.sejmBaseURL, term, date)
 	data, err := s.makeAPIRequest(ctx, endpoint, params)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve committee sittings for date %s: %v. Please verify the date format is YYYY-MM-DD.", date, err)), nil
 	}
 
 	var sittings []sejm.CommitteeSitting
-	if err := json.Unmarshal(data, &sittings); err != nil {
+	if err := s.decodeAPIResponse(data, &sittings); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse committee sittings data: %v.", err)), nil
 	}
 
+	// A joint sitting is listed under each committee, so sittings are merged into meetings before counting
+	meetings := mergeJointSittings(sittings)
+	joint := 0
+	for _, meeting := range meetings {
+		if meeting.joint() {
+			joint++
+		}
+	}
+
 	summary := fmt.Sprintf("Committee meetings scheduled for %s (term %d):\n", date, term)
-	summary += fmt.Sprintf("- Total meetings: %d\n\n", len(sittings))
+	summary += fmt.Sprintf("- Total meetings: %d\n", len(meetings))
+	if joint > 0 {
+		summary += fmt.Sprintf("- Joint meetings: %d, each listed once rather than under every committee\n", joint)
+	}
+	summary += "\n"
 
-	if len(sittings) == 0 {
+	if len(meetings) == 0 {
 		summary += "No committee meetings scheduled for this date.\n"
 		return mcp.NewToolResultText(summary), nil
 	}
 
 	summary += "Meetings:\n"
-	for i, sitting := range sittings {
+	for i, meeting := range meetings {
 		if i >= 15 { // Limit display
-			summary += fmt.Sprintf("... and %d more meetings\n", len(sittings)-i)
+			summary += fmt.Sprintf("... and %d more meetings\n", len(meetings)-i)
 			break
 		}
-
-		if sitting.Code != nil {
-			summary += fmt.Sprintf("- Committee %s", *sitting.Code)
-		}
-		if sitting.Num != nil {
-			summary += fmt.Sprintf(" (Meeting #%d)", *sitting.Num)
-		}
-		if sitting.StartDateTime != nil {
-			summary += fmt.Sprintf(" - %s", sitting.StartDateTime.Format("15:04"))
-		}
-		if sitting.Room != nil {
-			summary += fmt.Sprintf(" in %s", *sitting.Room)
-		}
-		summary += "\n"
+		summary += "- " + meeting.line(0) + "\n"
+	}
+	if joint > 0 {
+		summary += fmt.Sprintf("\nJoint meetings with their agendas: sejm_list_joint_sittings with term='%d', date_from='%s'\n", term, date)
 	}
 
 	return mcp.NewToolResultText(summary), nil
@@ -2659,38 +4301,80 @@ func (s *SejmServer) handleGetCommitteeSittings(ctx context.Context, request mcp
 	if committeeCode == "" {
 		return mcp.NewToolResultError("Committee code is required (e.g., 'ENM', 'ASW'). Get committee codes from sejm_get_committees."), nil
 	}
+	page, err := parseListPage(request, 20)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid pagination: %v.", err)), nil
+	}
+	sortBy := request.GetString("sort_by", "-date")
+	if sortBy != "date" && sortBy != "-date" {
+		return mcp.NewToolResultError("Parameter 'sort_by' must be 'date' (oldest first) or '-date' (most recent first)."), nil
+	}
+	from, err := parseDefectionDate("date_from", request.GetString("date_from", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	to, err := parseDefectionDate("date_to", request.GetString("date_to", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return mcp.NewToolResultError("Parameter 'date_to' must not be earlier than 'date_from'."), nil
+	}
 
 	params := make(map[string]string)
 	if canceled == "true" {
 		params["canceled"] = "true"
 	}
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings", sejmBaseURL, term, committeeCode)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings", s.sejmBaseURL, term, committeeCode)
 	data, err := s.makeAPIRequest(ctx, endpoint, params)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve sittings for committee %s: %v. Please verify the committee code exists.", committeeCode, err)), nil
 	}
 
 	var sittings []sejm.CommitteeSitting
-	if err := json.Unmarshal(data, &sittings); err != nil {
+	if err := s.decodeAPIResponse(data, &sittings); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse committee sittings data: %v.", err)), nil
 	}
 
-	summary := fmt.Sprintf("Committee %s meetings (term %d):\n", committeeCode, term)
-	summary += fmt.Sprintf("- Total meetings: %d\n\n", len(sittings))
+	// The sittings endpoint has no paging, so the whole list is filtered, sorted and paged locally
+	total := len(sittings)
+	if !from.IsZero() || !to.IsZero() {
+		var matching []sejm.CommitteeSitting
+		for _, sitting := range sittings {
+			if sitting.Date != nil && (from.IsZero() || !sitting.Date.Time.Before(from)) && (to.IsZero() || !sitting.Date.Time.After(to)) {
+				matching = append(matching, sitting)
+			}
+		}
+		sittings = matching
+	}
+	sort.SliceStable(sittings, func(i, j int) bool {
+		if sortBy == "date" {
+			return committeeSittingBefore(sittings[i], sittings[j])
+		}
+		return committeeSittingBefore(sittings[j], sittings[i])
+	})
+	matched := len(sittings)
+	start, end := page.bounds(matched)
+	sittings = sittings[start:end]
+
+	order := "most recent first"
+	if sortBy == "date" {
+		order = "oldest first"
+	}
+	summary := fmt.Sprintf("Committee %s meetings (term %d, %s):\n", committeeCode, term, order)
+	summary += fmt.Sprintf("- Total meetings: %d\n", total)
+	if !from.IsZero() || !to.IsZero() {
+		summary += fmt.Sprintf("- From %s to %s: %d meetings\n", formatOptionalDate(from, "start of term"), formatOptionalDate(to, "now"), matched)
+	}
+	summary += "- " + page.describe("meetings", len(sittings), matched) + "\n\n"
 
-	if len(sittings) == 0 {
+	if matched == 0 {
 		summary += "No meetings found for this committee.\n"
 		return mcp.NewToolResultText(summary), nil
 	}
 
-	summary += "Recent meetings:\n"
-	for i, sitting := range sittings {
-		if i >= 20 { // Limit display
-			summary += fmt.Sprintf("... and %d more meetings\n", len(sittings)-i)
-			break
-		}
-
+	for _, sitting := range sittings {
 		if sitting.Num != nil {
 			summary += fmt.Sprintf("- Meeting #%d", *sitting.Num)
 		}
@@ -2705,9 +4389,46 @@ func (s *SejmServer) handleGetCommitteeSittings(ctx context.Context, request mcp
 		summary += "\n"
 	}
 
+	filters := fmt.Sprintf("term='%d', committee_code='%s', sort_by='%s'", term, committeeCode, sortBy)
+	if canceled == "true" {
+		filters += ", canceled='true'"
+	}
+	if !from.IsZero() {
+		filters += fmt.Sprintf(", date_from='%s'", from.Format("2006-01-02"))
+	}
+	if !to.IsZero() {
+		filters += fmt.Sprintf(", date_to='%s'", to.Format("2006-01-02"))
+	}
+	navigation := page.navigation("sejm_get_committee_sittings", filters, end < matched)
+	if len(navigation) > 0 {
+		summary += "\n" + strings.Join(navigation, "\n") + "\n"
+	}
+
 	return mcp.NewToolResultText(summary), nil
 }
 
+// committeeSittingBefore orders committee sittings by date and then by number; sittings without a date come first
+func committeeSittingBefore(a, b sejm.CommitteeSitting) bool {
+	var dateA, dateB time.Time
+	if a.Date != nil {
+		dateA = a.Date.Time
+	}
+	if b.Date != nil {
+		dateB = b.Date.Time
+	}
+	if !dateA.Equal(dateB) {
+		return dateA.Before(dateB)
+	}
+	var numA, numB int32
+	if a.Num != nil {
+		numA = *a.Num
+	}
+	if b.Num != nil {
+		numB = *b.Num
+	}
+	return numA < numB
+}
+
 func (s *SejmServer) handleGetCommitteeSittingDetails(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	term, err := s.validateTerm(request.GetString("term", ""))
 	if err != nil {
@@ -2721,14 +4442,14 @@ func (s *SejmServer) handleGetCommitteeSittingDetails(ctx context.Context, reque
 		return mcp.NewToolResultError("Both committee_code and sitting_number are required. Get these from committee sitting lists."), nil
 	}
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings/%s", sejmBaseURL, term, committeeCode, sittingNumber)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings/%s", s.sejmBaseURL, term, committeeCode, sittingNumber)
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve committee sitting details: %v. Please verify committee_code=%s and sitting_number=%s exist.", err, committeeCode, sittingNumber)), nil
 	}
 
 	var sitting sejm.CommitteeSitting
-	if err := json.Unmarshal(data, &sitting); err != nil {
+	if err := s.decodeAPIResponse(data, &sitting); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse committee sitting data: %v.", err)), nil
 	}
 
@@ -2789,15 +4510,35 @@ func (s *SejmServer) handleGetCommitteeTranscript(ctx context.Context, request m
 		return mcp.NewToolResultError("Both committee_code and sitting_number are required. Get these from committee sitting lists."), nil
 	}
 
+	if agendaItem := request.GetString("agenda_item", ""); agendaItem != "" {
+		return s.handleCommitteeTranscriptAgendaItem(ctx, term, committeeCode, sittingNumber, agendaItem, chunkSize, chunkNumber, showChunkInfo)
+	}
+
+	if request.GetString("summarize", "false") == "true" {
+		pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings/%s/pdf", s.sejmBaseURL, term, committeeCode, sittingNumber)
+		pdfData, err := s.makeTextRequest(ctx, pdfEndpoint, "pdf")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve PDF transcript for summarization: %v. This committee meeting may not have a PDF transcript available.", err)), nil
+		}
+		pageTexts, err := s.extractPDFPageTexts(ctx, pdfData)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to extract transcript text: %v", err)), nil
+		}
+		return summaryResult(fmt.Sprintf("Committee %s sitting #%s transcript (term %d)", committeeCode, sittingNumber, term), pageTexts,
+			parseSummarySentences(request.GetString("summary_sentences", "")), "page", func(page int) string {
+				return fmt.Sprintf("sejm_get_committee_transcript with committee_code='%s', sitting_number='%s', format='text', page='%d', pages_per_chunk='1'", committeeCode, sittingNumber, page)
+			}), nil
+	}
+
 	if format == "pdf" {
 		// Return PDF download info
-		pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings/%s/pdf", sejmBaseURL, term, committeeCode, sittingNumber)
+		pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings/%s/pdf", s.sejmBaseURL, term, committeeCode, sittingNumber)
 		return mcp.NewToolResultText(fmt.Sprintf("Committee transcript PDF available at: %s\n\nUse format='text' to get searchable text extracted from this PDF with pagination support.", pdfEndpoint)), nil
 	}
 
 	if format == "text" {
 		// Download PDF and convert to text with pagination
-		pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings/%s/pdf", sejmBaseURL, term, committeeCode, sittingNumber)
+		pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings/%s/pdf", s.sejmBaseURL, term, committeeCode, sittingNumber)
 		pdfData, err := s.makeTextRequest(ctx, pdfEndpoint, "pdf")
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve PDF for text conversion: %v. This committee meeting may not have a PDF transcript available.", err)), nil
@@ -2808,15 +4549,20 @@ func (s *SejmServer) handleGetCommitteeTranscript(ctx context.Context, request m
 	}
 
 	// Default: HTML format with chunking
-	htmlEndpoint := fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings/%s/html", sejmBaseURL, term, committeeCode, sittingNumber)
+	htmlEndpoint := fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings/%s/html", s.sejmBaseURL, term, committeeCode, sittingNumber)
 	htmlData, err := s.makeTextRequest(ctx, htmlEndpoint, "html")
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve HTML transcript: %v. This committee meeting may not have an HTML transcript available.", err)), nil
 	}
 
+	content := string(htmlData)
+	if request.GetString("sanitize", "true") != "false" {
+		content = sanitizeHTML(content)
+	}
+
 	// Handle HTML chunking for large responses
 	documentTitle := fmt.Sprintf("Committee %s Meeting #%s Transcript", committeeCode, sittingNumber)
-	return s.chunkHTMLContent(string(htmlData), documentTitle, chunkSize, chunkNumber, showChunkInfo)
+	return s.chunkHTMLContent(content, documentTitle, chunkSize, chunkNumber, showChunkInfo)
 }
 
 func (s *SejmServer) handleGetMPPhoto(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -2834,9 +4580,9 @@ func (s *SejmServer) handleGetMPPhoto(ctx context.Context, request mcp.CallToolR
 
 	var endpoint string
 	if size == "mini" {
-		endpoint = fmt.Sprintf("%s/sejm/term%d/MP/%s/photo-mini", sejmBaseURL, term, mpID)
+		endpoint = fmt.Sprintf("%s/sejm/term%d/MP/%s/photo-mini", s.sejmBaseURL, term, mpID)
 	} else {
-		endpoint = fmt.Sprintf("%s/sejm/term%d/MP/%s/photo", sejmBaseURL, term, mpID)
+		endpoint = fmt.Sprintf("%s/sejm/term%d/MP/%s/photo", s.sejmBaseURL, term, mpID)
 	}
 
 	// Make request for image data
@@ -2850,7 +4596,14 @@ func (s *SejmServer) handleGetMPPhoto(ctx context.Context, request mcp.CallToolR
 		photoSize = "mini (thumbnail)"
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("MP photo for ID %s (term %d) retrieved successfully in %s format.\n\nPhoto data: %d bytes\nEndpoint: %s\n\nNote: This is binary image data (JPEG format). The photo shows the official parliamentary portrait of the MP used in parliamentary documentation and public materials.", mpID, term, photoSize, len(imageData), endpoint)), nil
+	// The API serves JPEG portraits; sniff the bytes in case a different format is returned
+	mimeType := http.DetectContentType(imageData)
+	if !strings.HasPrefix(mimeType, "image/") {
+		mimeType = "image/jpeg"
+	}
+
+	description := fmt.Sprintf("MP photo for ID %s (term %d) retrieved successfully in %s format.\n\nPhoto data: %d bytes (%s)\nEndpoint: %s\n\nNote: The photo shows the official parliamentary portrait of the MP used in parliamentary documentation and public materials.", mpID, term, photoSize, len(imageData), mimeType, endpoint)
+	return mcp.NewToolResultImage(description, base64.StdEncoding.EncodeToString(imageData), mimeType), nil
 }
 
 func (s *SejmServer) handleGetMPVotingStats(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -2864,14 +4617,14 @@ func (s *SejmServer) handleGetMPVotingStats(ctx context.Context, request mcp.Cal
 		return mcp.NewToolResultError("MP ID is required. Please provide the mp_id parameter with a valid MP identification number. You can get MP IDs from the sejm_get_mps tool."), nil
 	}
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/MP/%s/votings/stats", sejmBaseURL, term, mpID)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/MP/%s/votings/stats", s.sejmBaseURL, term, mpID)
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve MP voting statistics: %v. Please verify the MP ID (%s) exists in term %d.", err, mpID, term)), nil
 	}
 
 	var stats []sejm.VotingStat
-	if err := json.Unmarshal(data, &stats); err != nil {
+	if err := s.decodeAPIResponse(data, &stats); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse voting statistics data: %v.", err)), nil
 	}
 
@@ -2977,14 +4730,14 @@ func (s *SejmServer) handleGetMPVotingDetails(ctx context.Context, request mcp.C
 		return mcp.NewToolResultError("All parameters are required: mp_id, sitting, and date. Get sitting numbers from sejm_search_votings or sejm_get_proceedings results."), nil
 	}
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/MP/%s/votings/%s/%s", sejmBaseURL, term, mpID, sitting, date)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/MP/%s/votings/%s/%s", s.sejmBaseURL, term, mpID, sitting, date)
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve MP voting details: %v. Please verify MP ID (%s), sitting (%s), and date (%s) are correct.", err, mpID, sitting, date)), nil
 	}
 
 	var votes []sejm.VoteMP
-	if err := json.Unmarshal(data, &votes); err != nil {
+	if err := s.decodeAPIResponse(data, &votes); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse voting details data: %v.", err)), nil
 	}
 
@@ -3123,14 +4876,14 @@ func (s *SejmServer) handleGetVideos(ctx context.Context, request mcp.CallToolRe
 	params["limit"] = fmt.Sprintf("%d", fetchLimit)
 	params["offset"] = fmt.Sprintf("%d", offset)
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/videos", sejmBaseURL, term)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/videos", s.sejmBaseURL, term)
 	apiData, err := s.makeAPIRequest(ctx, endpoint, params)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve videos: %v. Please try again.", err)), nil
 	}
 
 	var allVideos []sejm.Video
-	if err := json.Unmarshal(apiData, &allVideos); err != nil {
+	if err := s.decodeAPIResponse(apiData, &allVideos); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse videos data: %v.", err)), nil
 	}
 
@@ -3422,14 +5175,14 @@ func (s *SejmServer) handleGetVideosToday(ctx context.Context, request mcp.CallT
 		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
 	}
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/videos/today", sejmBaseURL, term)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/videos/today", s.sejmBaseURL, term)
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve today's videos: %v. Please try again.", err)), nil
 	}
 
 	var videos []sejm.Video
-	if err := json.Unmarshal(data, &videos); err != nil {
+	if err := s.decodeAPIResponse(data, &videos); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse today's videos data: %v.", err)), nil
 	}
 
@@ -3556,14 +5309,14 @@ func (s *SejmServer) handleGetVideosByDate(ctx context.Context, request mcp.Call
 		return mcp.NewToolResultError("Date parameter is required in YYYY-MM-DD format (e.g., '2023-12-13')."), nil
 	}
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/videos/%s", sejmBaseURL, term, date)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/videos/%s", s.sejmBaseURL, term, date)
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve videos for date %s: %v. Please verify the date format is YYYY-MM-DD.", date, err)), nil
 	}
 
 	var videos []sejm.Video
-	if err := json.Unmarshal(data, &videos); err != nil {
+	if err := s.decodeAPIResponse(data, &videos); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse videos data for date %s: %v.", date, err)), nil
 	}
 
@@ -3677,14 +5430,14 @@ func (s *SejmServer) handleGetVideoDetails(ctx context.Context, request mcp.Call
 		return mcp.NewToolResultError("Video ID (unid) is required. Get this from video listing results (32-character alphanumeric identifier)."), nil
 	}
 
-	endpoint := fmt.Sprintf("%s/sejm/term%d/videos/%s", sejmBaseURL, term, unid)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/videos/%s", s.sejmBaseURL, term, unid)
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve video details for ID %s: %v. Please verify the video ID exists in term %d.", unid, err, term)), nil
 	}
 
 	var video sejm.Video
-	if err := json.Unmarshal(data, &video); err != nil {
+	if err := s.decodeAPIResponse(data, &video); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse video details: %v.", err)), nil
 	}
 
@@ -3810,14 +5563,11 @@ func (s *SejmServer) handleGetWrittenQuestions(ctx context.Context, request mcp.
 	}
 
 	// Build API parameters
-	params := make(map[string]string)
-
-	if limit := request.GetString("limit", ""); limit != "" {
-		params["limit"] = limit
-	}
-	if offset := request.GetString("offset", ""); offset != "" {
-		params["offset"] = offset
+	page, err := parseListPage(request, 20)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid pagination: %v.", err)), nil
 	}
+	params := page.apiParams()
 	if sortBy := request.GetString("sort_by", ""); sortBy != "" {
 		params["sort_by"] = sortBy
 	}
@@ -3844,21 +5594,23 @@ func (s *SejmServer) handleGetWrittenQuestions(ctx context.Context, request mcp.
 		slog.String("term", termStr),
 		slog.Any("params", params))
 
-	endpoint := fmt.Sprintf("https://api.sejm.gov.pl/sejm/term%d/writtenQuestions", term)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/writtenQuestions", s.sejmBaseURL, term)
 	data, err := s.makeAPIRequest(ctx, endpoint, params)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch written questions: %v", err)), nil
 	}
 
 	var questions []sejm.WrittenQuestion
-	if err := json.Unmarshal(data, &questions); err != nil {
+	if err := s.decodeAPIResponse(data, &questions); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse written questions: %v", err)), nil
 	}
+	shown, more := page.fetched(len(questions))
+	questions = questions[:shown]
 
 	// Build response
 	var summary []string
 	summary = append(summary, fmt.Sprintf("Term: %d", term))
-	summary = append(summary, fmt.Sprintf("Found %d written questions", len(questions)))
+	summary = append(summary, page.describe("written questions", shown, -1))
 
 	// Add filter info
 	if from := request.GetString("from", ""); from != "" {
@@ -3883,17 +5635,7 @@ func (s *SejmServer) handleGetWrittenQuestions(ctx context.Context, request mcp.
 		results = append(results, "• Check different time periods with 'since' and 'till'")
 		results = append(results, "• Try different MP IDs with 'from' parameter")
 	} else {
-		// Show first 15 questions
-		displayCount := 15
-		if len(questions) < displayCount {
-			displayCount = len(questions)
-		}
-
-		results = append(results, fmt.Sprintf("Showing first %d written questions:", displayCount))
-		results = append(results, "")
-
-		for i := 0; i < displayCount; i++ {
-			q := questions[i]
+		for _, q := range questions {
 
 			num := "Unknown"
 			if q.Num != nil {
@@ -3956,10 +5698,6 @@ func (s *SejmServer) handleGetWrittenQuestions(ctx context.Context, request mcp.
 
 			results = append(results, "")
 		}
-
-		if len(questions) > displayCount {
-			results = append(results, fmt.Sprintf("... and %d more questions available", len(questions)-displayCount))
-		}
 	}
 
 	// Build next actions
@@ -3969,15 +5707,17 @@ func (s *SejmServer) handleGetWrittenQuestions(ctx context.Context, request mcp.
 	nextActions = append(nextActions, "Find delayed answers: use delayed='true'")
 	nextActions = append(nextActions, "Search by topic: use 'title' parameter with keywords")
 
-	// Add pagination hints if we have results
-	if len(questions) > 0 {
-		if offset := request.GetString("offset", ""); offset == "" {
-			nextActions = append(nextActions, "Next page: add offset='50' for more results")
-		}
-		if sortBy := request.GetString("sort_by", ""); sortBy == "" {
-			nextActions = append(nextActions, "Sort by date: add sort_by='-receiptDate' for newest first")
+	// Add pagination hints, repeating the filters so the next page stays consistent
+	var call []string
+	for _, name := range []string{"term", "sort_by", "from", "to", "title", "since", "till", "delayed"} {
+		if value := request.GetString(name, ""); value != "" {
+			call = append(call, fmt.Sprintf("%s='%s'", name, value))
 		}
 	}
+	nextActions = append(page.navigation("sejm_get_written_questions", strings.Join(call, ", "), more), nextActions...)
+	if len(questions) > 0 && request.GetString("sort_by", "") == "" {
+		nextActions = append(nextActions, "Sort by date: add sort_by='-receiptDate' for newest first")
+	}
 
 	response := StandardResponse{
 		Operation:   "Parliamentary Written Questions",
@@ -3992,7 +5732,7 @@ func (s *SejmServer) handleGetWrittenQuestions(ctx context.Context, request mcp.
 }
 
 func (s *SejmServer) registerProcessesTools() {
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_processes",
 		Description: "Retrieve parliamentary legislative processes for a specific term. Returns comprehensive information about legislative procedures including bills, resolutions, and other legislative documents progressing through the Polish parliamentary system. Each process tracks the complete legislative journey: print submission → committee assignment and review → first reading (general debate) → second reading (detailed examination with amendments) → third reading (final passage) → Senate review (30-day constitutional period) → Presidential decision (21-day period for signature or veto). Process data includes status at each stage, voting results, committee modifications, amendment history, and timeline analysis. Government-sponsored bills typically have higher success rates and faster processing compared to MP-initiated legislation. Essential for tracking legislation through parliament, analyzing legislative efficiency, understanding political success patterns, and researching the complete lifecycle of parliamentary proposals from initial submission to final enactment or rejection.",
 		InputSchema: mcp.ToolInputSchema{
@@ -4026,7 +5766,7 @@ func (s *SejmServer) registerProcessesTools() {
 		},
 	}, s.handleGetProcesses)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_processes_passed",
 		Description: "Retrieve parliamentary legislative processes that have been successfully passed for a specific term. Returns information about completed legislation that went through all required stages and was adopted. Essential for studying successful legislative outcomes, analyzing passed legislation patterns, and understanding what types of bills successfully navigate the parliamentary process.",
 		InputSchema: mcp.ToolInputSchema{
@@ -4060,7 +5800,7 @@ func (s *SejmServer) registerProcessesTools() {
 		},
 	}, s.handleGetProcessesPassed)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_process_details",
 		Description: "Get detailed information about a specific legislative process including complete procedural history, voting records, committee work, amendments, and current status. Returns comprehensive process data with all stages, decisions, dates, and outcomes. Essential for detailed legislative analysis, understanding specific bill progress, tracking amendments and changes, and studying the complete parliamentary procedure for individual pieces of legislation.",
 		InputSchema: mcp.ToolInputSchema{
@@ -4074,10 +5814,49 @@ func (s *SejmServer) registerProcessesTools() {
 					"type":        "string",
 					"description": "Process number (print number) to get details for (e.g., '1', '15', '100'). Get this from sejm_get_processes results.",
 				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default, readable overview with stage durations) or 'json' (structured timeline: every top-level stage with start date, end date, duration in days and responsible committees, plus the total time to passage).",
+				},
 			},
 			Required: []string{"process_number"},
 		},
 	}, s.handleGetProcessDetails)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_track_process_across_terms",
+		Description: "Follow a bill across parliamentary terms. Bills not passed by the end of a term lapse (zasada dyskontynuacji) and are often submitted again in the next term under a new print number; citizens' bills are continued. This tool finds the processes whose title contains a topic in each term and links every process to the most similar one of an earlier term by title, ignoring the sponsor and boilerplate such as 'projekt ustawy o zmianie ustawy'. Returns lineages of predecessor and successor prints with their dates, outcome and similarity. Use it to trace a policy idea through several parliaments instead of searching each term separately.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"topic": map[string]interface{}{
+					"type":        "string",
+					"description": "Fragment of the process title searched in every term, in Polish (e.g., 'ochronie zwierząt', 'związkach partnerskich', 'Kodeks wyborczy').",
+				},
+				"from_term": map[string]interface{}{
+					"type":        "string",
+					"description": "First term searched (default: 7, the first term with legislative processes in the API).",
+				},
+				"to_term": map[string]interface{}{
+					"type":        "string",
+					"description": "Last term searched (default: current term 10).",
+				},
+				"document_type": map[string]interface{}{
+					"type":        "string",
+					"description": "Only processes of this document type (e.g., 'projekt ustawy', 'projekt uchwały').",
+				},
+				"min_similarity": map[string]interface{}{
+					"type":        "string",
+					"description": "Minimum title similarity from 0 to 1 for linking two processes (default: 0.5). Lower it (e.g., '0.3') for bills reworded between terms.",
+				},
+				"format": map[string]interface{}{
+					"type":        "string",
+					"description": "Output format: 'text' (default) or 'json' (lineages with every process and its predecessor link).",
+				},
+			},
+			Required: []string{"topic"},
+		},
+	}, s.handleTrackProcessAcrossTerms)
 }
 
 func (s *SejmServer) handleGetProcesses(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -4107,14 +5886,14 @@ func (s *SejmServer) handleGetProcesses(ctx context.Context, request mcp.CallToo
 		slog.String("term", fmt.Sprintf("%d", term)),
 		slog.Any("params", params))
 
-	endpoint := fmt.Sprintf("https://api.sejm.gov.pl/sejm/term%d/processes", term)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/processes", s.sejmBaseURL, term)
 	data, err := s.makeAPIRequest(ctx, endpoint, params)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch legislative processes: %v", err)), nil
 	}
 
 	var processes []sejm.ProcessHeader
-	if err := json.Unmarshal(data, &processes); err != nil {
+	if err := s.decodeAPIResponse(data, &processes); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse processes: %v", err)), nil
 	}
 
@@ -4266,14 +6045,14 @@ func (s *SejmServer) handleGetProcessesPassed(ctx context.Context, request mcp.C
 		slog.String("term", fmt.Sprintf("%d", term)),
 		slog.Any("params", params))
 
-	endpoint := fmt.Sprintf("https://api.sejm.gov.pl/sejm/term%d/processes/passed", term)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/processes/passed", s.sejmBaseURL, term)
 	data, err := s.makeAPIRequest(ctx, endpoint, params)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch passed processes: %v", err)), nil
 	}
 
 	var processes []sejm.ProcessHeader
-	if err := json.Unmarshal(data, &processes); err != nil {
+	if err := s.decodeAPIResponse(data, &processes); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse passed processes: %v", err)), nil
 	}
 
@@ -4387,21 +6166,33 @@ func (s *SejmServer) handleGetProcessDetails(ctx context.Context, request mcp.Ca
 	if processNumber == "" {
 		return mcp.NewToolResultError("Process number is required. Please provide the process_number parameter. Get process numbers from sejm_get_processes results."), nil
 	}
+	format := strings.ToLower(request.GetString("format", "text"))
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
 
 	s.logger.Info("sejm_get_process_details called",
 		slog.String("term", fmt.Sprintf("%d", term)),
 		slog.String("processNumber", processNumber))
 
-	endpoint := fmt.Sprintf("https://api.sejm.gov.pl/sejm/term%d/processes/%s", term, processNumber)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/processes/%s", s.sejmBaseURL, term, processNumber)
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch process details: %v. Please verify process_number=%s exists in term %d.", err, processNumber, term)), nil
 	}
 
 	var process sejm.ProcessDetails
-	if err := json.Unmarshal(data, &process); err != nil {
+	if err := s.decodeAPIResponse(data, &process); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse process details: %v", err)), nil
 	}
+	timeline, err := buildProcessTimeline(term, processNumber, data, time.Now())
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse process details: %v", err)), nil
+	}
+	if format == "json" {
+		output, _ := json.MarshalIndent(timeline, "", "  ")
+		return mcp.NewToolResultText(string(output)), nil
+	}
 
 	// Build comprehensive summary
 	var summary []string
@@ -4423,6 +6214,9 @@ func (s *SejmServer) handleGetProcessDetails(ctx context.Context, request mcp.Ca
 	if process.DocumentType != nil {
 		summary = append(summary, fmt.Sprintf("Document type: %s", *process.DocumentType))
 	}
+	if total := timeline.timelineSummary(); total != "" {
+		summary = append(summary, total)
+	}
 
 	var results []string
 
@@ -4461,31 +6255,15 @@ func (s *SejmServer) handleGetProcessDetails(ctx context.Context, request mcp.Ca
 	}
 
 	// Stages information
-	if process.Stages != nil && len(*process.Stages) > 0 {
+	if len(timeline.Stages) > 0 {
 		results = append(results, "")
 		results = append(results, "📈 LEGISLATIVE STAGES:")
-		for i, stage := range *process.Stages {
+		for i, stage := range timeline.Stages {
 			if i >= 8 { // Limit stages to prevent overwhelming output
-				results = append(results, fmt.Sprintf("... and %d more stages", len(*process.Stages)-i))
+				results = append(results, fmt.Sprintf("... and %d more stages (format='json' returns the full timeline)", len(timeline.Stages)-i))
 				break
 			}
-
-			stageName := "Unknown stage"
-			if stage.StageName != nil {
-				stageName = *stage.StageName
-			}
-
-			date := ""
-			if stage.Date != nil {
-				date = fmt.Sprintf(" (%s)", stage.Date.Format("2006-01-02"))
-			}
-
-			stageType := ""
-			if stage.StageType != nil {
-				stageType = fmt.Sprintf(" [%s]", *stage.StageType)
-			}
-
-			results = append(results, fmt.Sprintf("%d. %s%s%s", i+1, stageName, date, stageType))
+			results = append(results, formatProcessTimelineStage(i+1, stage))
 		}
 	}
 
@@ -4502,8 +6280,8 @@ func (s *SejmServer) handleGetProcessDetails(ctx context.Context, request mcp.Ca
 	var nextActions []string
 	nextActions = append(nextActions, "View all processes: use sejm_get_processes")
 	nextActions = append(nextActions, "Find passed legislation: use sejm_get_processes_passed")
-	if process.Stages != nil && len(*process.Stages) > 0 {
-		nextActions = append(nextActions, "The stages field contains detailed procedural history")
+	if len(timeline.Stages) > 0 {
+		nextActions = append(nextActions, fmt.Sprintf("Structured stage timeline with durations: sejm_get_process_details with process_number='%s' and format='json'", processNumber))
 	}
 	if process.ELI != nil {
 		nextActions = append(nextActions, fmt.Sprintf("Get legal text: use eli_get_act_text for %s", *process.ELI))
@@ -4522,7 +6300,7 @@ func (s *SejmServer) handleGetProcessDetails(ctx context.Context, request mcp.Ca
 }
 
 func (s *SejmServer) registerBilateralGroupsTools() {
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_bilateral_groups",
 		Description: "Retrieve parliamentary bilateral groups for a specific term. Bilateral groups are international parliamentary cooperation groups that facilitate diplomatic and political relationships between the Polish Parliament and other national parliaments. Returns information about group names, appointment dates, English names, and basic group details. Essential for understanding international parliamentary cooperation, analyzing diplomatic relationships, and researching Poland's international parliamentary engagement.",
 		InputSchema: mcp.ToolInputSchema{
@@ -4544,7 +6322,7 @@ func (s *SejmServer) registerBilateralGroupsTools() {
 		},
 	}, s.handleGetBilateralGroups)
 
-	s.server.AddTool(mcp.Tool{
+	s.addTool(mcp.Tool{
 		Name:        "sejm_get_bilateral_group_details",
 		Description: "Get detailed information about a specific bilateral group including complete membership list, member roles, appointment dates, and group description. Returns comprehensive group data with all current and former members, their parliamentary clubs, membership periods, and any special roles or positions within the group. Essential for analyzing specific international parliamentary relationships, understanding group composition, researching MP involvement in international cooperation, and studying detailed diplomatic parliamentary engagement.",
 		InputSchema: mcp.ToolInputSchema{
@@ -4582,14 +6360,14 @@ func (s *SejmServer) handleGetBilateralGroups(ctx context.Context, request mcp.C
 		slog.String("term", fmt.Sprintf("%d", term)),
 		slog.Any("params", params))
 
-	endpoint := fmt.Sprintf("https://api.sejm.gov.pl/sejm/term%d/bilateralGroups", term)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/bilateralGroups", s.sejmBaseURL, term)
 	data, err := s.makeAPIRequest(ctx, endpoint, params)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch bilateral groups: %v", err)), nil
 	}
 
 	var groups []sejm.Group
-	if err := json.Unmarshal(data, &groups); err != nil {
+	if err := s.decodeAPIResponse(data, &groups); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse bilateral groups: %v", err)), nil
 	}
 
@@ -4691,14 +6469,14 @@ func (s *SejmServer) handleGetBilateralGroupDetails(ctx context.Context, request
 		slog.String("term", fmt.Sprintf("%d", term)),
 		slog.String("groupID", groupID))
 
-	endpoint := fmt.Sprintf("https://api.sejm.gov.pl/sejm/term%d/bilateralGroups/%s", term, groupID)
+	endpoint := fmt.Sprintf("%s/sejm/term%d/bilateralGroups/%s", s.sejmBaseURL, term, groupID)
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch bilateral group details: %v. Please verify group_id=%s exists in term %d.", err, groupID, term)), nil
 	}
 
 	var groupDetails sejm.GroupDetails
-	if err := json.Unmarshal(data, &groupDetails); err != nil {
+	if err := s.decodeAPIResponse(data, &groupDetails); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse bilateral group details: %v", err)), nil
 	}
 
@@ -4820,24 +6598,30 @@ func (s *SejmServer) handleGetInterpellationBody(ctx context.Context, request mc
 
 	term := request.GetString("term", "")
 	num := request.GetString("num", "")
+	render := request.GetString("render", renderHTML)
+	sanitize := request.GetString("sanitize", "true") != "false"
 
 	if term == "" || num == "" {
 		return mcp.NewToolResultError("Both 'term' and 'num' parameters are required. Get these from sejm_get_interpellations results."), nil
 	}
+	if _, err := renderHTMLBody("", render, false); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-	endpoint := fmt.Sprintf("https://api.sejm.gov.pl/sejm/term%s/interpellations/%s/body", term, num)
+	endpoint := fmt.Sprintf("%s/sejm/term%s/interpellations/%s/body", s.sejmBaseURL, term, num)
 
 	// Use text request for HTML content
 	data, err := s.makeTextRequest(ctx, endpoint, "html")
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve interpellation body: %v", err)), nil
 	}
+	content, _ := renderHTMLBody(string(data), render, sanitize)
 
 	response := StandardResponse{
 		Operation: fmt.Sprintf("Interpellation #%s Body (Term %s)", num, term),
 		Status:    "Retrieved Successfully",
-		Summary:   []string{fmt.Sprintf("Full HTML content of interpellation #%s from parliamentary term %s", num, term)},
-		Data:      []string{string(data)},
+		Summary:   []string{fmt.Sprintf("%s of interpellation #%s from parliamentary term %s", renderedContentLabel(render, sanitize), num, term)},
+		Data:      []string{content},
 		NextActions: []string{
 			fmt.Sprintf("Get replies: sejm_get_interpellation_reply_body with term='%s' and num='%s'", term, num),
 			fmt.Sprintf("View interpellation list: sejm_get_interpellations with term='%s'", term),
@@ -4854,24 +6638,30 @@ func (s *SejmServer) handleGetInterpellationReplyBody(ctx context.Context, reque
 	term := request.GetString("term", "")
 	num := request.GetString("num", "")
 	key := request.GetString("key", "")
+	render := request.GetString("render", renderHTML)
+	sanitize := request.GetString("sanitize", "true") != "false"
 
 	if term == "" || num == "" || key == "" {
 		return mcp.NewToolResultError("All parameters 'term', 'num', and 'key' are required. Get these from sejm_get_interpellations results."), nil
 	}
+	if _, err := renderHTMLBody("", render, false); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-	endpoint := fmt.Sprintf("https://api.sejm.gov.pl/sejm/term%s/interpellations/%s/reply/%s/body", term, num, key)
+	endpoint := fmt.Sprintf("%s/sejm/term%s/interpellations/%s/reply/%s/body", s.sejmBaseURL, term, num, key)
 
 	// Use text request for HTML content
 	data, err := s.makeTextRequest(ctx, endpoint, "html")
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve interpellation reply body: %v", err)), nil
 	}
+	content, _ := renderHTMLBody(string(data), render, sanitize)
 
 	response := StandardResponse{
 		Operation: fmt.Sprintf("Interpellation #%s Reply Body (Term %s, Key %s)", num, term, key),
 		Status:    "Retrieved Successfully",
-		Summary:   []string{fmt.Sprintf("Full HTML content of government reply to interpellation #%s from parliamentary term %s", num, term)},
-		Data:      []string{string(data)},
+		Summary:   []string{fmt.Sprintf("%s of government reply to interpellation #%s from parliamentary term %s", renderedContentLabel(render, sanitize), num, term)},
+		Data:      []string{content},
 		NextActions: []string{
 			fmt.Sprintf("Get original question: sejm_get_interpellation_body with term='%s' and num='%s'", term, num),
 			fmt.Sprintf("View interpellation list: sejm_get_interpellations with term='%s'", term),
@@ -4888,12 +6678,18 @@ func (s *SejmServer) handleGetInterpellationAttachment(ctx context.Context, requ
 	term := request.GetString("term", "")
 	key := request.GetString("key", "")
 	fileName := request.GetString("file_name", "")
+	extractText := request.GetString("extract_text", "false")
 
 	if term == "" || key == "" || fileName == "" {
 		return mcp.NewToolResultError("All parameters 'term', 'key', and 'file_name' are required. Get these from interpellation details."), nil
 	}
 
-	endpoint := fmt.Sprintf("https://api.sejm.gov.pl/sejm/term%s/interpellations/attachment/%s/%s", term, key, fileName)
+	deliveryMode, maxSize, err := parseAttachmentDelivery(request.GetString("return_content", ""), request.GetString("max_size_bytes", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	endpoint := fmt.Sprintf("%s/sejm/term%s/interpellations/attachment/%s/%s", s.sejmBaseURL, term, key, fileName)
 
 	// Use binary request for attachment files
 	data, err := s.makeAPIRequestWithHeaders(ctx, endpoint, nil, map[string]string{"Accept": "*/*"})
@@ -4901,6 +6697,15 @@ func (s *SejmServer) handleGetInterpellationAttachment(ctx context.Context, requ
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve interpellation attachment: %v", err)), nil
 	}
 
+	resourceURI := fmt.Sprintf("%sterm%s/interpellations/attachment/%s/%s", attachmentResourceScheme, term, key, fileName)
+	fileContent, deliverySummary := s.deliverAttachment(deliveryMode, maxSize, resourceURI, fileName, endpoint, data)
+
+	dataSection := []string{fmt.Sprintf("Binary file content available (%d bytes). File type can be determined from extension: %s", len(data), fileName)}
+	if extractText == "true" {
+		dataSection = append(dataSection, "")
+		dataSection = append(dataSection, s.attachmentTextPreview(ctx, fileName, data)...)
+	}
+
 	// For binary files, we should provide metadata instead of raw content
 	response := StandardResponse{
 		Operation: fmt.Sprintf("Interpellation Attachment: %s (Term %s)", fileName, term),
@@ -4908,16 +6713,19 @@ func (s *SejmServer) handleGetInterpellationAttachment(ctx context.Context, requ
 		Summary: []string{
 			fmt.Sprintf("Downloaded attachment file '%s' from interpellation (key: %s)", fileName, key),
 			fmt.Sprintf("File size: %d bytes", len(data)),
+			deliverySummary,
 		},
-		Data: []string{fmt.Sprintf("Binary file content available (%d bytes). File type can be determined from extension: %s", len(data), fileName)},
+		Data: dataSection,
 		NextActions: []string{
 			fmt.Sprintf("Get interpellation details: sejm_get_interpellations with term='%s'", term),
-			"Process the binary content based on file type (PDF, DOC, image, etc.)",
+			"Read the document text: repeat this call with extract_text='true'",
 		},
 		Note: fmt.Sprintf("Attachment file downloaded from term %s on %s. Binary content available for further processing.", term, time.Now().Format("2006-01-02 15:04:05 MST")),
 	}
 
-	return mcp.NewToolResultText(response.Format()), nil
+	result := mcp.NewToolResultText(response.Format())
+	result.Content = append(result.Content, fileContent...)
+	return result, nil
 }
 
 func (s *SejmServer) handleGetPrintDetails(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -4930,7 +6738,7 @@ func (s *SejmServer) handleGetPrintDetails(ctx context.Context, request mcp.Call
 		return mcp.NewToolResultError("Both 'term' and 'num' parameters are required. Get these from sejm_get_prints results."), nil
 	}
 
-	endpoint := fmt.Sprintf("https://api.sejm.gov.pl/sejm/term%s/prints/%s", term, num)
+	endpoint := fmt.Sprintf("%s/sejm/term%s/prints/%s", s.sejmBaseURL, term, num)
 
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
@@ -4938,7 +6746,7 @@ func (s *SejmServer) handleGetPrintDetails(ctx context.Context, request mcp.Call
 	}
 
 	var printData sejm.Print
-	if err := json.Unmarshal(data, &printData); err != nil {
+	if err := s.decodeAPIResponse(data, &printData); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse print data: %v", err)), nil
 	}
 
@@ -4968,6 +6776,8 @@ func (s *SejmServer) handleGetPrintDetails(ctx context.Context, request mcp.Call
 
 	if printData.Attachments != nil && len(*printData.Attachments) > 0 {
 		nextActions = append(nextActions, fmt.Sprintf("Download attachments: sejm_get_print_attachment with term='%s' and num='%s'", term, num))
+		nextActions = append(nextActions, fmt.Sprintf("Read document text: sejm_get_print_text with term='%s' and num='%s'", term, num))
+		nextActions = append(nextActions, fmt.Sprintf("Search document content: sejm_search_print_content with term='%s', num='%s' and search_terms", term, num))
 		summary = append(summary, fmt.Sprintf("Attachments available: %d files", len(*printData.Attachments)))
 	}
 
@@ -4989,12 +6799,18 @@ func (s *SejmServer) handleGetPrintAttachment(ctx context.Context, request mcp.C
 	term := request.GetString("term", "")
 	num := request.GetString("num", "")
 	attachName := request.GetString("attach_name", "")
+	extractText := request.GetString("extract_text", "false")
 
 	if term == "" || num == "" || attachName == "" {
 		return mcp.NewToolResultError("All parameters 'term', 'num', and 'attach_name' are required. Get these from print details."), nil
 	}
 
-	endpoint := fmt.Sprintf("https://api.sejm.gov.pl/sejm/term%s/prints/%s/%s", term, num, attachName)
+	deliveryMode, maxSize, err := parseAttachmentDelivery(request.GetString("return_content", ""), request.GetString("max_size_bytes", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	endpoint := fmt.Sprintf("%s/sejm/term%s/prints/%s/%s", s.sejmBaseURL, term, num, attachName)
 
 	// Use binary request for attachment files
 	data, err := s.makeAPIRequestWithHeaders(ctx, endpoint, nil, map[string]string{"Accept": "*/*"})
@@ -5002,6 +6818,15 @@ func (s *SejmServer) handleGetPrintAttachment(ctx context.Context, request mcp.C
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve print attachment: %v", err)), nil
 	}
 
+	resourceURI := fmt.Sprintf("%sterm%s/prints/%s/%s", attachmentResourceScheme, term, num, attachName)
+	fileContent, deliverySummary := s.deliverAttachment(deliveryMode, maxSize, resourceURI, attachName, endpoint, data)
+
+	dataSection := []string{fmt.Sprintf("Binary file content available (%d bytes). File type can be determined from extension: %s", len(data), attachName)}
+	if extractText == "true" {
+		dataSection = append(dataSection, "")
+		dataSection = append(dataSection, s.attachmentTextPreview(ctx, attachName, data)...)
+	}
+
 	// For binary files, we should provide metadata instead of raw content
 	response := StandardResponse{
 		Operation: fmt.Sprintf("Print Attachment: %s (Term %s, Print #%s)", attachName, term, num),
@@ -5009,17 +6834,187 @@ func (s *SejmServer) handleGetPrintAttachment(ctx context.Context, request mcp.C
 		Summary: []string{
 			fmt.Sprintf("Downloaded attachment file '%s' from print #%s", attachName, num),
 			fmt.Sprintf("File size: %d bytes", len(data)),
+			deliverySummary,
 		},
-		Data: []string{fmt.Sprintf("Binary file content available (%d bytes). File type can be determined from extension: %s", len(data), attachName)},
+		Data: dataSection,
 		NextActions: []string{
 			fmt.Sprintf("Get print details: sejm_get_print_details with term='%s' and num='%s'", term, num),
 			fmt.Sprintf("View all prints: sejm_get_prints with term='%s'", term),
-			"Process the binary content based on file type (PDF, DOC, image, etc.)",
+			fmt.Sprintf("Read the full text with pagination: sejm_get_print_text with term='%s', num='%s' and attach_name='%s'", term, num, attachName),
 		},
 		Note: fmt.Sprintf("Attachment file downloaded from term %s on %s. Binary content available for further processing.", term, time.Now().Format("2006-01-02 15:04:05 MST")),
 	}
 
-	return mcp.NewToolResultText(response.Format()), nil
+	result := mcp.NewToolResultText(response.Format())
+	result.Content = append(result.Content, fileContent...)
+	return result, nil
+}
+
+func (s *SejmServer) handleGetPrintText(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_print_text called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+
+	num := request.GetString("num", "")
+	attachName := request.GetString("attach_name", "")
+	page := request.GetString("page", "1")
+	pagesPerChunk := request.GetString("pages_per_chunk", "5")
+	showPageInfo := request.GetString("show_page_info", "false")
+
+	if num == "" {
+		return mcp.NewToolResultError("Parameter 'num' is required. Get print numbers from sejm_get_prints results."), nil
+	}
+
+	docData, attachName, err := s.downloadPrintDocument(ctx, term, num, attachName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve print document: %v", err)), nil
+	}
+
+	format := detectDocumentFormat(attachName, docData)
+	if request.GetString("summarize", "false") == "true" {
+		var pageTexts []string
+		if format == documentFormatPDF {
+			pageTexts, err = s.extractPDFPageTexts(ctx, docData)
+		} else {
+			var text string
+			text, err = extractDocumentText(format, docData)
+			pageTexts = splitTextIntoPages(text, documentPageChars)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to extract text from attachment '%s': %v", attachName, err)), nil
+		}
+		return summaryResult(fmt.Sprintf("print %s (%s)", num, attachName), pageTexts,
+			parseSummarySentences(request.GetString("summary_sentences", "")), "page", func(page int) string {
+				return fmt.Sprintf("sejm_get_print_text with term='%d', num='%s', attach_name='%s', page='%d', pages_per_chunk='1'", term, num, attachName, page)
+			}), nil
+	}
+
+	if format == documentFormatPDF {
+		// Use pagination to manage large print documents
+		return s.extractTextWithPagination(ctx, docData, "", "", fmt.Sprintf("print-%s-%s", num, attachName), page, pagesPerChunk, showPageInfo)
+	}
+
+	text, err := extractDocumentText(format, docData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to extract text from attachment '%s': %v", attachName, err)), nil
+	}
+	return s.documentTextWithPagination(ctx, text, fmt.Sprintf("print %s (%s)", num, attachName), "sejm_get_print_text", page, pagesPerChunk, showPageInfo)
+}
+
+func (s *SejmServer) handleSearchPrintContent(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_search_print_content called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+
+	num := request.GetString("num", "")
+	attachName := request.GetString("attach_name", "")
+	searchTerms := request.GetString("search_terms", "")
+	contextChars := request.GetString("context_chars", "100")
+	maxMatchesPerTerm := request.GetString("max_matches_per_term", "10")
+
+	if num == "" || searchTerms == "" {
+		return mcp.NewToolResultError("Parameters 'num' and 'search_terms' are both required."), nil
+	}
+	options, err := parseTextSearchOptions(request)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid search options: %v.", err)), nil
+	}
+
+	// Parse parameters similar to eli_search_act_content
+	contextCharsInt := 100
+	if contextChars != "" {
+		if parsed, err := fmt.Sscanf(contextChars, "%d", &contextCharsInt); parsed == 1 && err == nil {
+			if contextCharsInt > 500 {
+				contextCharsInt = 500
+			} else if contextCharsInt < 20 {
+				contextCharsInt = 20
+			}
+		}
+	}
+
+	maxMatchesInt := 10
+	if maxMatchesPerTerm != "" {
+		if parsed, err := fmt.Sscanf(maxMatchesPerTerm, "%d", &maxMatchesInt); parsed == 1 && err == nil {
+			if maxMatchesInt > 50 {
+				maxMatchesInt = 50
+			} else if maxMatchesInt < 1 {
+				maxMatchesInt = 1
+			}
+		}
+	}
+
+	docData, attachName, err := s.downloadPrintDocument(ctx, term, num, attachName)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve print document for search: %v", err)), nil
+	}
+
+	documentName := fmt.Sprintf("print %s (%s)", num, attachName)
+	format := detectDocumentFormat(attachName, docData)
+	if format == documentFormatPDF {
+		// Use the same search logic as ELI content search
+		return s.searchPDFContent(ctx, docData, documentName, searchTerms, options, contextCharsInt, maxMatchesInt)
+	}
+
+	text, err := extractDocumentText(format, docData)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to extract text from attachment '%s': %v", attachName, err)), nil
+	}
+	return s.searchPageTexts("Document Content Search", splitTextIntoPages(text, documentPageChars), documentName, searchTerms, options, contextCharsInt, maxMatchesInt)
+}
+
+// downloadPrintDocument fetches a print attachment, picking the main document from print details when no name is given
+func (s *SejmServer) downloadPrintDocument(ctx context.Context, term int, num, attachName string) ([]byte, string, error) {
+	if attachName == "" {
+		endpoint := fmt.Sprintf("%s/sejm/term%d/prints/%s", s.sejmBaseURL, term, num)
+		data, err := s.makeAPIRequest(ctx, endpoint, nil)
+		if err != nil {
+			return nil, "", fmt.Errorf("could not load details of print #%s: %w", num, err)
+		}
+
+		var printData sejm.Print
+		if err := s.decodeAPIResponse(data, &printData); err != nil {
+			return nil, "", fmt.Errorf("could not parse details of print #%s: %w", num, err)
+		}
+
+		var attachments []string
+		if printData.Attachments != nil {
+			attachments = *printData.Attachments
+		}
+		attachName, err = selectPrintAttachment(attachments)
+		if err != nil {
+			return nil, "", fmt.Errorf("print #%s: %w", num, err)
+		}
+	}
+
+	endpoint := fmt.Sprintf("%s/sejm/term%d/prints/%s/%s", s.sejmBaseURL, term, num, attachName)
+	data, err := s.makeAPIRequestWithHeaders(ctx, endpoint, nil, map[string]string{"Accept": "*/*"})
+	if err != nil {
+		return nil, "", fmt.Errorf("could not download attachment '%s': %w", attachName, err)
+	}
+
+	return data, attachName, nil
+}
+
+// selectPrintAttachment picks the main document of a print: the first PDF attachment,
+// otherwise the first attachment in another format that can be converted to text
+func selectPrintAttachment(attachments []string) (string, error) {
+	if len(attachments) == 0 {
+		return "", fmt.Errorf("print has no attachments")
+	}
+	for _, format := range supportedDocumentFormats {
+		for _, name := range attachments {
+			if documentFormatFromName(name) == format {
+				return name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("print has no attachment in a text-extractable format (%s); available: %s", strings.Join(supportedDocumentFormats, ", "), strings.Join(attachments, ", "))
 }
 
 func (s *SejmServer) handleGetClubDetails(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -5032,7 +7027,7 @@ func (s *SejmServer) handleGetClubDetails(ctx context.Context, request mcp.CallT
 		return mcp.NewToolResultError("Both 'term' and 'club_id' parameters are required. Get these from sejm_get_clubs results."), nil
 	}
 
-	endpoint := fmt.Sprintf("https://api.sejm.gov.pl/sejm/term%s/clubs/%s", term, clubID)
+	endpoint := fmt.Sprintf("%s/sejm/term%s/clubs/%s", s.sejmBaseURL, term, clubID)
 
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
@@ -5040,7 +7035,7 @@ func (s *SejmServer) handleGetClubDetails(ctx context.Context, request mcp.CallT
 	}
 
 	var club sejm.Club
-	if err := json.Unmarshal(data, &club); err != nil {
+	if err := s.decodeAPIResponse(data, &club); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse club data: %v", err)), nil
 	}
 
@@ -5091,7 +7086,7 @@ func (s *SejmServer) handleGetCommitteeDetails(ctx context.Context, request mcp.
 		return mcp.NewToolResultError("Both 'term' and 'committee_code' parameters are required. Get these from sejm_get_committees results."), nil
 	}
 
-	endpoint := fmt.Sprintf("https://api.sejm.gov.pl/sejm/term%s/committees/%s", term, committeeCode)
+	endpoint := fmt.Sprintf("%s/sejm/term%s/committees/%s", s.sejmBaseURL, term, committeeCode)
 
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
@@ -5099,7 +7094,7 @@ func (s *SejmServer) handleGetCommitteeDetails(ctx context.Context, request mcp.
 	}
 
 	var committee sejm.Committee
-	if err := json.Unmarshal(data, &committee); err != nil {
+	if err := s.decodeAPIResponse(data, &committee); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse committee data: %v", err)), nil
 	}
 
@@ -5147,6 +7142,242 @@ func (s *SejmServer) handleGetCommitteeDetails(ctx context.Context, request mcp.
 	return mcp.NewToolResultText(response.Format()), nil
 }
 
+// committeeRoles maps accepted role filter values to the committee function they match
+var committeeRoles = map[string]string{
+	"chairman":                  "chairman",
+	"chair":                     "chairman",
+	"chairperson":               "chairman",
+	"przewodniczący":            "chairman",
+	"przewodnicząca":            "chairman",
+	"deputy":                    "deputy",
+	"deputy chairman":           "deputy",
+	"vice-chairman":             "deputy",
+	"zastępca":                  "deputy",
+	"zastępca przewodniczącego": "deputy",
+	"secretary":                 "secretary",
+	"sekretarz":                 "secretary",
+	"leadership":                "leadership",
+	"prezydium":                 "leadership",
+	"member":                    "member",
+	"członek":                   "member",
+}
+
+// committeeMemberRole classifies a committee function string returned by the API
+func committeeMemberRole(function string) string {
+	function = strings.ToLower(strings.TrimSpace(function))
+	switch {
+	case function == "":
+		return "member"
+	case strings.Contains(function, "zastęp"):
+		return "deputy"
+	case strings.HasPrefix(function, "przewodnicząc"):
+		return "chairman"
+	case strings.Contains(function, "sekretarz"):
+		return "secretary"
+	default:
+		return "member"
+	}
+}
+
+// matchesCommitteeRole reports whether a member function matches a normalized role filter
+func matchesCommitteeRole(function, role string) bool {
+	memberRole := committeeMemberRole(function)
+	if role == "leadership" {
+		return memberRole == "chairman" || memberRole == "deputy"
+	}
+	return memberRole == role
+}
+
+func (s *SejmServer) handleGetCommitteeMembers(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_committee_members called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+
+	committeeCode := strings.TrimSpace(request.GetString("committee_code", ""))
+	if committeeCode == "" {
+		return mcp.NewToolResultError("'committee_code' parameter is required. Get committee codes from sejm_get_committees results."), nil
+	}
+
+	roleFilter := strings.ToLower(strings.TrimSpace(request.GetString("role", "")))
+	role := ""
+	if roleFilter != "" {
+		normalized, ok := committeeRoles[roleFilter]
+		if !ok {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid role '%s'. Use 'chairman', 'deputy', 'secretary', 'leadership' or 'member'.", roleFilter)), nil
+		}
+		role = normalized
+	}
+
+	clubFilter := strings.TrimSpace(request.GetString("club", ""))
+	includeExpired := request.GetString("include_expired", "false") == "true"
+
+	endpoint := fmt.Sprintf("%s/sejm/term%d/committees/%s", s.sejmBaseURL, term, committeeCode)
+	data, err := s.makeAPIRequest(ctx, endpoint, nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve committee members: %v. Please verify the committee code '%s' exists in term %d using sejm_get_committees.", err, committeeCode, term)), nil
+	}
+
+	var committee sejm.Committee
+	if err := s.decodeAPIResponse(data, &committee); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse committee data: %v", err)), nil
+	}
+
+	var members []sejm.Member
+	if committee.Members != nil {
+		members = *committee.Members
+	}
+
+	var matched []sejm.Member
+	expiredSkipped := 0
+	for _, member := range members {
+		if member.MandateExpired != nil && !includeExpired {
+			expiredSkipped++
+			continue
+		}
+
+		function := ""
+		if member.Function != nil {
+			function = *member.Function
+		}
+		if role != "" && !matchesCommitteeRole(function, role) {
+			continue
+		}
+
+		if clubFilter != "" {
+			if member.Club == nil || !strings.EqualFold(*member.Club, clubFilter) {
+				continue
+			}
+		}
+
+		matched = append(matched, member)
+	}
+
+	// Leadership first, then alphabetically
+	roleOrder := map[string]int{"chairman": 0, "deputy": 1, "secretary": 2, "member": 3}
+	sort.SliceStable(matched, func(i, j int) bool {
+		fi, fj := "", ""
+		if matched[i].Function != nil {
+			fi = *matched[i].Function
+		}
+		if matched[j].Function != nil {
+			fj = *matched[j].Function
+		}
+		ri, rj := roleOrder[committeeMemberRole(fi)], roleOrder[committeeMemberRole(fj)]
+		if ri != rj {
+			return ri < rj
+		}
+		ni, nj := "", ""
+		if matched[i].LastFirstName != nil {
+			ni = *matched[i].LastFirstName
+		}
+		if matched[j].LastFirstName != nil {
+			nj = *matched[j].LastFirstName
+		}
+		return ni < nj
+	})
+
+	committeeName := committeeCode
+	if committee.Name != nil {
+		committeeName = *committee.Name
+	}
+
+	var summary []string
+	summary = append(summary, fmt.Sprintf("Committee: %s (%s)", committeeName, committeeCode))
+	summary = append(summary, fmt.Sprintf("Term: %d", term))
+	summary = append(summary, fmt.Sprintf("Total members: %d", len(members)))
+	if roleFilter != "" {
+		summary = append(summary, fmt.Sprintf("Role filter: %s", roleFilter))
+	}
+	if clubFilter != "" {
+		summary = append(summary, fmt.Sprintf("Club filter: %s", clubFilter))
+	}
+	if expiredSkipped > 0 {
+		summary = append(summary, fmt.Sprintf("Members with expired mandate hidden: %d", expiredSkipped))
+	}
+	summary = append(summary, fmt.Sprintf("Matching members: %d", len(matched)))
+
+	var results []string
+	var mpIDs []string
+	clubCounts := make(map[string]int)
+	for _, member := range matched {
+		name := "Unknown"
+		if member.LastFirstName != nil {
+			name = *member.LastFirstName
+		}
+		club := "no club"
+		if member.Club != nil && *member.Club != "" {
+			club = *member.Club
+		}
+		clubCounts[club]++
+
+		id := "?"
+		if member.Id != nil {
+			id = fmt.Sprintf("%d", *member.Id)
+			mpIDs = append(mpIDs, id)
+		}
+
+		line := fmt.Sprintf("• [mp_id %s] %s (%s)", id, name, club)
+		if member.Function != nil && *member.Function != "" {
+			line += fmt.Sprintf(" - %s", *member.Function)
+		}
+		if member.MandateExpired != nil {
+			line += fmt.Sprintf(" [mandate expired %s]", member.MandateExpired.String())
+		}
+		results = append(results, line)
+	}
+
+	if len(clubCounts) > 1 {
+		clubs := make([]string, 0, len(clubCounts))
+		for club := range clubCounts {
+			clubs = append(clubs, club)
+		}
+		sort.Slice(clubs, func(i, j int) bool {
+			if clubCounts[clubs[i]] != clubCounts[clubs[j]] {
+				return clubCounts[clubs[i]] > clubCounts[clubs[j]]
+			}
+			return clubs[i] < clubs[j]
+		})
+		var breakdown []string
+		for _, club := range clubs {
+			breakdown = append(breakdown, fmt.Sprintf("%s: %d", club, clubCounts[club]))
+		}
+		summary = append(summary, fmt.Sprintf("By club: %s", strings.Join(breakdown, ", ")))
+	}
+
+	if len(mpIDs) > 0 {
+		results = append(results, "", fmt.Sprintf("MP IDs: %s", strings.Join(mpIDs, ", ")))
+	}
+
+	var nextActions []string
+	if len(mpIDs) > 0 {
+		nextActions = append(nextActions, fmt.Sprintf("Get MP profile: sejm_get_mp_details with term='%d' and mp_id='%s'", term, mpIDs[0]))
+		nextActions = append(nextActions, fmt.Sprintf("Check voting record: sejm_get_mp_voting_stats with term='%d' and mp_id='%s'", term, mpIDs[0]))
+	} else {
+		nextActions = append(nextActions, "Remove or broaden the role/club filters")
+	}
+	nextActions = append(nextActions, fmt.Sprintf("Full committee profile: sejm_get_committee_details with term='%d' and committee_code='%s'", term, committeeCode))
+	nextActions = append(nextActions, fmt.Sprintf("Committee sittings: sejm_get_committee_sittings with term='%d' and committee_code='%s'", term, committeeCode))
+
+	status := "Retrieved Successfully"
+	if len(matched) == 0 {
+		status = "No Results Found"
+	}
+
+	response := StandardResponse{
+		Operation:   fmt.Sprintf("Committee Members: %s (Term %d)", committeeCode, term),
+		Status:      status,
+		Summary:     summary,
+		Data:        results,
+		NextActions: nextActions,
+		Note:        "Membership reflects the current composition (or the composition at the date the committee was closed).",
+	}
+
+	return mcp.NewToolResultText(response.Format()), nil
+}
+
 func (s *SejmServer) handleGetCurrentProceeding(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	s.logger.Info("sejm_get_current_proceeding called", slog.Any("arguments", request.Params.Arguments))
 
@@ -5156,7 +7387,7 @@ func (s *SejmServer) handleGetCurrentProceeding(ctx context.Context, request mcp
 		return mcp.NewToolResultError("'term' parameter is required."), nil
 	}
 
-	endpoint := fmt.Sprintf("https://api.sejm.gov.pl/sejm/term%s/proceedings/current", term)
+	endpoint := fmt.Sprintf("%s/sejm/term%s/proceedings/current", s.sejmBaseURL, term)
 
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
@@ -5164,7 +7395,7 @@ func (s *SejmServer) handleGetCurrentProceeding(ctx context.Context, request mcp
 	}
 
 	var proceeding sejm.Proceeding
-	if err := json.Unmarshal(data, &proceeding); err != nil {
+	if err := s.decodeAPIResponse(data, &proceeding); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse proceeding data: %v", err)), nil
 	}
 
@@ -0,0 +1,287 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// rewriteTransport redirects all outgoing API requests to a local test server
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rewritten := req.Clone(req.Context())
+	rewritten.URL.Scheme = t.target.Scheme
+	rewritten.URL.Host = t.target.Host
+	rewritten.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(rewritten)
+}
+
+// newServerWithFixtures creates a server whose API requests are answered from the given path->JSON map
+func newServerWithFixtures(t *testing.T, fixtures map[string]string) *SejmServer {
+	t.Helper()
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := fixtures[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(mock.Close)
+
+	target, err := url.Parse(mock.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse mock server URL: %v", err)
+	}
+
+	server := NewSejmServer()
+	server.client = &http.Client{Transport: &rewriteTransport{target: target}}
+	return server
+}
+
+func TestCommitteeMemberRole(t *testing.T) {
+	testCases := []struct {
+		function string
+		expected string
+	}{
+		{"", "member"},
+		{"przewodniczący", "chairman"},
+		{"przewodnicząca", "chairman"},
+		{"zastępca przewodniczącego", "deputy"},
+		{"zastępczyni przewodniczącego", "deputy"},
+		{"sekretarz", "secretary"},
+		{"członek", "member"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.function, func(t *testing.T) {
+			if role := committeeMemberRole(tc.function); role != tc.expected {
+				t.Errorf("Expected role '%s' for function '%s', got '%s'", tc.expected, tc.function, role)
+			}
+		})
+	}
+
+	if !matchesCommitteeRole("zastępca przewodniczącego", "leadership") {
+		t.Error("Deputy chairman should match leadership filter")
+	}
+	if matchesCommitteeRole("", "leadership") {
+		t.Error("Ordinary member should not match leadership filter")
+	}
+}
+
+func TestHandleGetCommitteeMembers(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/committees/ASW": `{
+			"code": "ASW",
+			"name": "Komisja Administracji i Spraw Wewnętrznych",
+			"members": [
+				{"id": 1, "lastFirstName": "Kowalski Jan", "club": "KO", "function": "przewodniczący"},
+				{"id": 2, "lastFirstName": "Nowak Anna", "club": "PiS", "function": "zastępca przewodniczącego"},
+				{"id": 3, "lastFirstName": "Wiśniewski Piotr", "club": "PiS"},
+				{"id": 4, "lastFirstName": "Zieliński Adam", "club": "KO", "mandateExpired": "2024-06-01"}
+			]
+		}`,
+	})
+
+	t.Run("leadership filter", func(t *testing.T) {
+		request := createMockRequest(map[string]interface{}{
+			"committee_code": "ASW",
+			"role":           "leadership",
+		})
+		result, err := server.handleGetCommitteeMembers(context.Background(), request)
+		if err != nil || result.IsError {
+			t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+		}
+		content := extractTextContent(result)
+		if !strings.Contains(content, "[mp_id 1] Kowalski Jan") || !strings.Contains(content, "[mp_id 2] Nowak Anna") {
+			t.Errorf("Expected chairman and deputy in output, got: %s", content)
+		}
+		if strings.Contains(content, "Wiśniewski") {
+			t.Errorf("Ordinary member should be filtered out, got: %s", content)
+		}
+		if !strings.Contains(content, "MP IDs: 1, 2") {
+			t.Errorf("Expected MP ID list, got: %s", content)
+		}
+	})
+
+	t.Run("club filter hides expired mandates", func(t *testing.T) {
+		request := createMockRequest(map[string]interface{}{
+			"committee_code": "ASW",
+			"club":           "ko",
+		})
+		result, _ := server.handleGetCommitteeMembers(context.Background(), request)
+		content := extractTextContent(result)
+		if !strings.Contains(content, "Kowalski Jan") || strings.Contains(content, "Zieliński") {
+			t.Errorf("Expected only active KO members, got: %s", content)
+		}
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		request := createMockRequest(map[string]interface{}{
+			"committee_code": "ASW",
+			"role":           "treasurer",
+		})
+		result, _ := server.handleGetCommitteeMembers(context.Background(), request)
+		if result == nil || !result.IsError {
+			t.Error("Expected error for invalid role")
+		}
+	})
+}
+
+func TestSelectPrintAttachment(t *testing.T) {
+	name, err := selectPrintAttachment([]string{"uzasadnienie.docx", "1234.PDF", "1234-A.pdf"})
+	if err != nil || name != "1234.PDF" {
+		t.Errorf("Expected first PDF attachment '1234.PDF', got '%s' (err: %v)", name, err)
+	}
+
+	name, err = selectPrintAttachment([]string{"schemat.jpg", "1234.odt", "1234.docx"})
+	if err != nil || name != "1234.docx" {
+		t.Errorf("Expected DOCX attachment when no PDF exists, got '%s' (err: %v)", name, err)
+	}
+
+	if _, err := selectPrintAttachment(nil); err == nil {
+		t.Error("Expected error for print without attachments")
+	}
+
+	_, err = selectPrintAttachment([]string{"schemat.jpg"})
+	if err == nil || !strings.Contains(err.Error(), "schemat.jpg") {
+		t.Errorf("Expected error listing available attachments, got: %v", err)
+	}
+}
+
+func TestHandlePrintTextErrors(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/prints/100": `{"number": "100", "title": "Projekt ustawy", "attachments": ["100.jpg"]}`,
+	})
+
+	testCases := []struct {
+		name     string
+		handler  func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		args     map[string]interface{}
+		expected string
+	}{
+		{"text missing num", server.handleGetPrintText, map[string]interface{}{}, "'num' is required"},
+		{"text invalid term", server.handleGetPrintText, map[string]interface{}{"term": "12", "num": "100"}, "Invalid parliamentary term"},
+		{"text without document", server.handleGetPrintText, map[string]interface{}{"num": "100"}, "text-extractable format"},
+		{"text missing attachment", server.handleGetPrintText, map[string]interface{}{"num": "100", "attach_name": "100.docx"}, "could not download attachment"},
+		{"search missing terms", server.handleSearchPrintContent, map[string]interface{}{"num": "100"}, "'search_terms' are both required"},
+		{"search unknown print", server.handleSearchPrintContent, map[string]interface{}{"num": "999", "search_terms": "VAT"}, "print #999"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.handler(context.Background(), createMockRequest(tc.args))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result == nil || !result.IsError {
+				t.Fatalf("Expected error result, got: %s", extractTextContent(result))
+			}
+			if content := extractTextContent(result); !strings.Contains(content, tc.expected) {
+				t.Errorf("Expected error containing '%s', got: %s", tc.expected, content)
+			}
+		})
+	}
+}
+
+func TestHandleGetPrintTextDOCX(t *testing.T) {
+	docx := buildTestDocument(t, map[string]string{
+		"word/document.xml": `<w:document xmlns:w="w"><w:body><w:p><w:r><w:t>Projekt ustawy o podatku VAT</w:t></w:r></w:p></w:body></w:document>`,
+	})
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/prints/200":          `{"number": "200", "attachments": ["200.docx"]}`,
+		"/sejm/term10/prints/200/200.docx": string(docx),
+	})
+
+	result, err := server.handleGetPrintText(context.Background(), createMockRequest(map[string]interface{}{"num": "200"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	if content := extractTextContent(result); !strings.Contains(content, "Projekt ustawy o podatku VAT") {
+		t.Errorf("Expected extracted DOCX text, got: %s", content)
+	}
+
+	result, err = server.handleSearchPrintContent(context.Background(), createMockRequest(map[string]interface{}{"num": "200", "search_terms": "vat"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	if content := extractTextContent(result); !strings.Contains(content, "Page 1 (1 matches)") {
+		t.Errorf("Expected match on page 1, got: %s", content)
+	}
+}
+
+func TestHandleGetMPPhotoReturnsImage(t *testing.T) {
+	jpeg := "\xFF\xD8\xFF\xE0\x00\x10JFIF\x00"
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/MP/5/photo-mini": jpeg,
+	})
+
+	request := createMockRequest(map[string]interface{}{"mp_id": "5", "size": "mini"})
+	result, err := server.handleGetMPPhoto(context.Background(), request)
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	if len(result.Content) != 2 {
+		t.Fatalf("Expected text and image content, got %d items", len(result.Content))
+	}
+	image, ok := mcp.AsImageContent(result.Content[1])
+	if !ok {
+		t.Fatalf("Expected image content, got %T", result.Content[1])
+	}
+	if image.MIMEType != "image/jpeg" || image.Data != base64.StdEncoding.EncodeToString([]byte(jpeg)) {
+		t.Errorf("Unexpected image content: mimeType=%s data=%s", image.MIMEType, image.Data)
+	}
+	if !strings.Contains(extractTextContent(result), "mini (thumbnail)") {
+		t.Errorf("Expected size description, got: %s", extractTextContent(result))
+	}
+}
+
+func TestHandleGetCommitteeSittingsPaging(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/committees/ASW/sittings": `[
+			{"num": 1, "date": "2024-01-10"},
+			{"num": 2, "date": "2024-02-14"},
+			{"num": 3, "date": "2024-03-06"},
+			{"num": 4, "date": "2024-03-06"},
+			{"num": 5, "date": "2024-04-17"}
+		]`,
+	})
+
+	result, err := server.handleGetCommitteeSittings(context.Background(), createMockRequest(map[string]interface{}{
+		"committee_code": "ASW", "limit": "2", "date_from": "2024-02-01",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"most recent first", "Total meetings: 5", "From 2024-02-01 to now: 4 meetings", "Showing meetings 1-2 of 4",
+		"Meeting #5 on 2024-04-17\n- Meeting #4 on 2024-03-06\n",
+		"offset='2', limit='2'",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, content)
+		}
+	}
+	if strings.Contains(content, "Meeting #1 ") {
+		t.Errorf("Expected sittings before date_from to be left out:\n%s", content)
+	}
+
+	result, _ = server.handleGetCommitteeSittings(context.Background(), createMockRequest(map[string]interface{}{
+		"committee_code": "ASW", "sort_by": "date", "offset": "4",
+	}))
+	if content := extractTextContent(result); !strings.Contains(content, "Showing meetings 5-5 of 5") || !strings.Contains(content, "Meeting #5 on 2024-04-17") {
+		t.Errorf("Expected the last sitting on the oldest-first page at offset 4:\n%s", content)
+	}
+}
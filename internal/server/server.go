@@ -19,12 +19,60 @@ import (
 	"github.com/alexshin/httpcache"
 	"github.com/hashicorp/golang-lru/v2/expirable"
 	"github.com/janisz/sejm-mcp/pkg/eli"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Config holds server configuration options
 type Config struct {
 	DebugMode bool
+	// Language is the default output language ("en" or "pl") used when a tool call does not specify one
+	Language string
+	// JobsDir is the directory where background job state and results are persisted; empty keeps jobs in memory only
+	JobsDir string
+	// WatchDir is the directory where acts watched with eli_watch_act and their detected changes are persisted; empty keeps them in memory only
+	WatchDir string
+	// WatchInterval is how often watched acts are checked in SSE and HTTP mode; 0 uses the default of 6 hours
+	WatchInterval time.Duration
+	// VotingIndexDir enables a persistent index of voting titles in this directory, so title searches cover whole terms; empty disables it
+	VotingIndexDir string
+	// OutputDir enables save_to_file on tools with large outputs, which then write their output to files in this directory; empty disables it
+	OutputDir string
+	// SejmBaseURL overrides the Sejm API base URL (default https://api.sejm.gov.pl), e.g. for a mirror or proxy
+	SejmBaseURL string
+	// ELIBaseURL overrides the ELI API base URL (default https://api.sejm.gov.pl/eli)
+	ELIBaseURL string
+	// MockDir serves API responses from recorded fixture files in this directory instead of the network
+	MockDir string
+	// RecordDir saves successful API responses as fixture files in this directory for later use with MockDir
+	RecordDir string
+	// MaxOutputChars is the default response length limit applied when a tool call does not set max_output_chars; 0 means unlimited
+	MaxOutputChars int
+	// UpstreamTimeout limits a whole upstream API request including its body; 0 uses the default of 45 seconds
+	UpstreamTimeout time.Duration
+	// MaxIdleConns and MaxIdleConnsPerHost size the upstream connection pool; 0 uses the defaults of 100 and 20
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	// HTTPCacheTTLs overrides the Cache-Control lifetimes of tool responses in HTTP mode by tool class
+	// ("reference", "default", "live"); a lifetime of 0 disables caching for the class
+	HTTPCacheTTLs map[string]time.Duration
+	// OTLPEndpoint is the OTLP/HTTP collector URL traces are exported to, e.g. http://localhost:4318; empty disables tracing
+	OTLPEndpoint string
+	// RateLimit is the number of MCP requests per minute accepted in HTTP mode; 0 means unlimited
+	RateLimit int
+	// ToolTimeouts limits how long a tool call may run, by tool name or "default" for all other tools; a
+	// timed out call returns the results collected so far. 0 or no entry means unlimited
+	ToolTimeouts map[string]time.Duration
+	// MaxConnections bounds the open SSE streams in SSE mode and the MCP requests in flight in HTTP mode;
+	// clients over the limit are rejected with 503. 0 means unlimited
+	MaxConnections int
+	// MaxMessagesPerMinute limits the messages of each SSE session, or of each client address in HTTP mode; 0 means unlimited
+	MaxMessagesPerMinute int
+	// IdleTimeout closes SSE streams whose client sent no message for this long; 0 keeps them open
+	IdleTimeout time.Duration
 }
 
 // PopularAct represents a frequently searched legal act
@@ -46,6 +94,7 @@ type CacheEntry struct {
 type HTTPCacheStats struct {
 	Hits        int64
 	Misses      int64
+	Revalidated int64
 	Requests    int64
 	LastCleanup time.Time
 }
@@ -59,7 +108,11 @@ type Cache struct {
 	Keywords      *CacheEntry
 	Institutions  *CacheEntry
 	HTTPStats     *HTTPCacheStats
-	mu            sync.RWMutex
+	// SittingVotings holds the parsed votings of sittings scanned by title searches, keyed by "term/sitting"
+	SittingVotings map[string]*CacheEntry
+	// ActTexts is the act text index of eli_fulltext_search: extracted page texts keyed by "publisher/year/position"
+	ActTexts map[string]*CacheEntry
+	mu       sync.RWMutex
 }
 
 // SejmServer provides access to Polish Parliament and Legal Information System APIs through MCP protocol.
@@ -69,6 +122,26 @@ type SejmServer struct {
 	cache  *Cache
 	logger *slog.Logger
 	config Config
+	jobs   *jobManager
+
+	votingIndex *votingIndex
+	snapshots   *snapshotStore
+	watches     *watchManager
+	health      *upstreamHealth
+	drift       *schemaDriftLog
+	usage       *toolUsageStats
+
+	// connections limits and counts the clients in SSE and HTTP mode; nil in stdio mode
+	connections *connectionLimiter
+
+	tracer         trace.Tracer
+	tracerProvider *sdktrace.TracerProvider
+
+	sejmBaseURL string
+	eliBaseURL  string
+
+	// profiles are servers with their own configuration served by this one in HTTP mode
+	profiles map[string]*SejmServer
 }
 
 
@@ -107,13 +180,9 @@ func NewSejmServer() *SejmServer {
 
 // NewSejmServerWithConfig creates a new instance of SejmServer with custom configuration.
 func NewSejmServerWithConfig(config Config) *SejmServer {
-	// Create base HTTP transport with improved connection handling
-	baseTransport := &http.Transport{
-		MaxIdleConns:        10,
-		MaxIdleConnsPerHost: 5,
-		IdleConnTimeout:     90 * time.Second,
-		DisableKeepAlives:   false, // Enable keep-alives for better connection reuse
-	}
+	// Create pooled base HTTP transport that requests compressed responses
+	pooledTransport := newUpstreamTransport(config)
+	baseTransport := &compressionTransport{transport: pooledTransport}
 
 	// Wrap with HTTP cache for automatic caching of all API responses
 	// Use LRU cache with TTL that forces caching even when server sends no-cache headers
@@ -128,11 +197,10 @@ func NewSejmServerWithConfig(config Config) *SejmServer {
 			return true
 		},
 	})
-	cachedTransport.Transport = baseTransport
 
 	// Create HTTP client with caching enabled
 	client := &http.Client{
-		Timeout:   45 * time.Second, // Increased timeout for stability
+		Timeout:   upstreamTimeout(config),
 		Transport: cachedTransport,
 	}
 
@@ -149,10 +217,36 @@ func NewSejmServerWithConfig(config Config) *SejmServer {
 	}))
 	logger.Info("SEJM-MCP server starting up with enhanced structured logging enabled",
 		slog.Bool("debugMode", config.DebugMode),
+		slog.String("language", config.Language),
 		slog.String("logLevel", logLevel.String()),
 		slog.String("cacheType", "LRU with TTL"),
 		slog.Int("cacheSize", 1000),
-		slog.Duration("cacheTTL", 60*time.Minute))
+		slog.Duration("cacheTTL", 60*time.Minute),
+		slog.Duration("upstreamTimeout", upstreamTimeout(config)),
+		slog.Int("maxIdleConns", pooledTransport.MaxIdleConns),
+		slog.Int("maxIdleConnsPerHost", pooledTransport.MaxIdleConnsPerHost))
+
+	// Mock mode replaces the network entirely; record mode saves what the network returns
+	switch {
+	case config.MockDir != "":
+		cachedTransport.Transport = &fixtureTransport{dir: config.MockDir, logger: logger}
+		logger.Info("Mock mode enabled: serving API responses from recorded fixtures", slog.String("mockDir", config.MockDir))
+	case config.RecordDir != "":
+		cachedTransport.Transport = &recordingTransport{dir: config.RecordDir, transport: newConditionalTransport(baseTransport, logger), logger: logger}
+		logger.Info("Recording API responses as fixtures", slog.String("recordDir", config.RecordDir))
+	default:
+		cachedTransport.Transport = newConditionalTransport(baseTransport, logger)
+	}
+
+	sejmBaseURL := defaultSejmBaseURL
+	if config.SejmBaseURL != "" {
+		sejmBaseURL = strings.TrimRight(config.SejmBaseURL, "/")
+	}
+	eliBaseURL := defaultELIBaseURL
+	if config.ELIBaseURL != "" {
+		eliBaseURL = strings.TrimRight(config.ELIBaseURL, "/")
+	}
+	logger.Debug("Upstream API base URLs", slog.String("sejm", sejmBaseURL), slog.String("eli", eliBaseURL))
 
 	s := &SejmServer{
 		client: client,
@@ -163,16 +257,34 @@ func NewSejmServerWithConfig(config Config) *SejmServer {
 		},
 		logger: logger,
 		config: config,
+		jobs:   newJobManager(config.JobsDir, logger),
+
+		votingIndex: newVotingIndex(config.VotingIndexDir, logger),
+		snapshots:   newSnapshotStore(),
+		watches:     newWatchManager(config.WatchDir, logger),
+		health:      newUpstreamHealth(),
+		drift:       newSchemaDriftLog(logger),
+		usage:       newToolUsageStats(),
+
+		sejmBaseURL: sejmBaseURL,
+		eliBaseURL:  eliBaseURL,
 	}
 
 	mcpServer := server.NewMCPServer(
 		"sejm-mcp",
 		"1.0.0",
 		server.WithLogging(),
+		server.WithToolCapabilities(false),
+		// Directory-backed argument values (committee codes, publishers, ...) can be completed
+		server.WithCompletions(),
+		server.WithPromptCompletionProvider(&completionProvider{server: s}),
+		server.WithResourceCompletionProvider(&completionProvider{server: s}),
 	)
 
 	s.server = mcpServer
+	s.setupTracing()
 	s.registerTools()
+	s.registerResourceTemplates()
 
 	return s
 }
@@ -192,7 +304,9 @@ func (s *SejmServer) RunSSE(addr string) error {
 		server.WithSSEEndpoint("/mcp"),
 		server.WithMessageEndpoint("/mcp/message"),
 		server.WithKeepAlive(true),
-		server.WithKeepAliveInterval(10*time.Second))
+		server.WithKeepAliveInterval(10*time.Second),
+		server.WithSessionIDGenerator(sseSessionID))
+	s.connections = newConnectionLimiter("sse", s.config, s.logger)
 
 	// Create a custom HTTP server that includes health check and uses the SSE server
 	mux := http.NewServeMux()
@@ -202,7 +316,8 @@ func (s *SejmServer) RunSSE(addr string) error {
 		s.logger.Debug("Health check request received", slog.String("method", r.Method), slog.String("path", r.URL.Path))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		if _, err := w.Write([]byte(`{"status":"healthy","service":"sejm-mcp","version":"1.0.0"}`)); err != nil {
+		// The server itself stays up when an upstream fails; the status and upstreams fields report the failure
+		if err := json.NewEncoder(w).Encode(s.healthResponse()); err != nil {
 			s.logger.Warn("Failed to write health check response", slog.Any("error", err))
 		}
 	})
@@ -250,7 +365,7 @@ func (s *SejmServer) RunSSE(addr string) error {
 	})
 
 	// Mount the SSE server on the MCP endpoint
-	mux.Handle("/mcp", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	mux.Handle("/mcp", s.connections.withSSEStreamLimit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		s.logger.Info("MCP request received",
 			slog.String("method", r.Method),
 			slog.String("path", r.URL.Path),
@@ -258,10 +373,14 @@ func (s *SejmServer) RunSSE(addr string) error {
 			slog.String("accept", r.Header.Get("Accept")))
 
 		sseServer.ServeHTTP(w, r)
-	}))
+	})))
 
 	// Mount the message handler for SSE
-	mux.Handle("/mcp/message", sseServer.MessageHandler())
+	mux.Handle("/mcp/message", s.connections.withSSEMessageLimit(sseServer.MessageHandler()))
+
+	// Watched acts are checked in the background while clients can receive change notifications
+	go s.runWatchChecker(context.Background())
+	go s.connections.runReaper(context.Background())
 
 	// Create listener to get the actual assigned port
 	listener, err := net.Listen("tcp", addr)
@@ -288,6 +407,7 @@ func (s *SejmServer) RunSSE(addr string) error {
 	httpServer := &http.Server{
 		Handler:           mux,
 		ReadHeaderTimeout: 30 * time.Second,
+		IdleTimeout:       s.config.IdleTimeout,
 	}
 
 	return httpServer.Serve(listener)
@@ -297,12 +417,6 @@ func (s *SejmServer) RunSSE(addr string) error {
 func (s *SejmServer) RunHTTP(addr string) error {
 	s.logger.Info("Starting server in HTTP mode", slog.String("address", addr))
 
-	// Create StreamableHTTPServer for stateless operation
-	httpServer := server.NewStreamableHTTPServer(s.server,
-		server.WithEndpointPath("/mcp"),
-		server.WithStateLess(true),
-		server.WithHeartbeatInterval(30*time.Second))
-
 	// Create a custom HTTP server that includes health check and uses the HTTP server
 	mux := http.NewServeMux()
 
@@ -311,7 +425,8 @@ func (s *SejmServer) RunHTTP(addr string) error {
 		s.logger.Debug("Health check request received", slog.String("method", r.Method), slog.String("path", r.URL.Path))
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		if _, err := w.Write([]byte(`{"status":"healthy","service":"sejm-mcp","version":"1.0.0"}`)); err != nil {
+		// The server itself stays up when an upstream fails; the status and upstreams fields report the failure
+		if err := json.NewEncoder(w).Encode(s.healthResponse()); err != nil {
 			s.logger.Warn("Failed to write health check response", slog.Any("error", err))
 		}
 	})
@@ -327,6 +442,9 @@ func (s *SejmServer) RunHTTP(addr string) error {
 			"status":  "healthy",
 			"mcp":     "/mcp",
 		}
+		if len(s.profiles) > 0 {
+			rootResponse["profiles"] = s.profileNames()
+		}
 		if err := json.NewEncoder(w).Encode(rootResponse); err != nil {
 			s.logger.Warn("Failed to encode root response", slog.Any("error", err))
 		}
@@ -358,16 +476,26 @@ func (s *SejmServer) RunHTTP(addr string) error {
 		}
 	})
 
+	// Calendar and feed subscriptions for upcoming sittings
+	mux.HandleFunc("/feeds/schedule.ics", s.handleScheduleFeedHTTP(feedFormatICal, "text/calendar; charset=utf-8"))
+	mux.HandleFunc("/feeds/schedule.rss", s.handleScheduleFeedHTTP(feedFormatRSS, "application/rss+xml; charset=utf-8"))
+
+	s.connections = newConnectionLimiter("http", s.config, s.logger)
+	cachingHandler := s.mcpHTTPHandler()
+	s.logger.Info("HTTP response caching enabled", slog.String("ttls", s.describeHTTPCacheTTLs()))
+
+	// Each profile has its own MCP endpoint, and the shared one serves a profile selected by header
+	profileHandlers := s.startProfiles(context.Background())
+	if len(profileHandlers) > 0 {
+		mux.Handle(profilePathPrefix, s.connections.withHTTPConnectionLimit(s.handleProfileHTTP(profileHandlers)))
+	}
+
 	// Mount the HTTP server on the MCP endpoint
-	mux.Handle("/mcp", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		s.logger.Info("MCP HTTP request received",
-			slog.String("method", r.Method),
-			slog.String("path", r.URL.Path),
-			slog.String("userAgent", r.Header.Get("User-Agent")),
-			slog.String("contentType", r.Header.Get("Content-Type")))
+	mux.Handle("/mcp", s.connections.withHTTPConnectionLimit(s.handleProfileHeader(cachingHandler, profileHandlers)))
 
-		httpServer.ServeHTTP(w, r)
-	}))
+	// Watched acts are checked in the background while clients can receive change notifications
+	go s.runWatchChecker(context.Background())
+	go s.connections.runReaper(context.Background())
 
 	// Create listener to get the actual assigned port
 	listener, err := net.Listen("tcp", addr)
@@ -387,24 +515,170 @@ func (s *SejmServer) RunHTTP(addr string) error {
 	s.logger.Info("HTTP server will be available with endpoints",
 		slog.String("actualAddress", actualAddr),
 		slog.String("health", "http://localhost:"+port+"/health"),
-		slog.String("mcp", "http://localhost:"+port+"/mcp"))
+		slog.String("mcp", "http://localhost:"+port+"/mcp"),
+		slog.String("feeds", "http://localhost:"+port+"/feeds/schedule.ics"))
 
 	// Start the HTTP server with our custom mux and listener
 	srv := &http.Server{
 		Handler:           mux,
 		ReadHeaderTimeout: 30 * time.Second,
+		IdleTimeout:       s.config.IdleTimeout,
 	}
 
 	return srv.Serve(listener)
 }
 
+// mcpHTTPHandler returns the stateless MCP endpoint of the server. Tool call responses carry Cache-Control
+// and ETag headers for reverse proxies and CDNs, and requests over the configured rate are rejected.
+func (s *SejmServer) mcpHTTPHandler() http.Handler {
+	httpServer := server.NewStreamableHTTPServer(s.server,
+		server.WithEndpointPath("/mcp"),
+		server.WithStateLess(true),
+		server.WithHeartbeatInterval(30*time.Second))
+	return s.withRateLimit(s.withHTTPCaching(httpServer))
+}
+
 func (s *SejmServer) registerTools() {
 	s.registerSejmTools()
 	s.registerELITools()
+	s.registerJobTools()
+	s.registerSnapshotTools()
+	s.registerWatchTools()
+	s.registerPingTool()
+	s.registerCapabilitiesTool()
+	s.registerRawJSONTool()
 }
 
+// addTool registers a tool with the MCP server, adding the parameters shared by all tools
+// and wrapping the handler with the common argument normalization and result post-processing
+// (integer coercion, output localization); tools also get read-only behavior annotations and a title
+func (s *SejmServer) addTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
+	if tool.InputSchema.Properties == nil {
+		tool.InputSchema.Properties = map[string]interface{}{}
+	}
+	applyIntegerSchema(tool.InputSchema.Properties)
+	applyEntitySchema(tool.Name, tool.InputSchema.Properties)
+	applyActAliasSchema(&tool)
+	applyToolAnnotations(&tool)
+	tool.InputSchema.Properties["language"] = map[string]interface{}{
+		"type":        "string",
+		"description": languageParamDescription,
+	}
+	tool.InputSchema.Properties["max_output_chars"] = map[string]interface{}{
+		"type":        []string{"integer", "string"},
+		"description": maxOutputCharsParamDescription,
+	}
+	saveable := artifactTools[tool.Name] && s.config.OutputDir != ""
+	if saveable {
+		tool.InputSchema.Properties["save_to_file"] = map[string]interface{}{
+			"type":        "string",
+			"description": saveToFileParamDescription,
+		}
+	}
+	if s.config.DebugMode {
+		tool.InputSchema.Properties["debug"] = map[string]interface{}{
+			"type":        "string",
+			"description": debugParamDescription,
+		}
+	}
+	if asyncTools[tool.Name] {
+		tool.InputSchema.Properties["async"] = map[string]interface{}{
+			"type":        "string",
+			"description": asyncParamDescription,
+		}
+	}
+	if _, ok := listSnapshotSources[tool.Name]; ok {
+		tool.InputSchema.Properties["materialize"] = map[string]interface{}{
+			"type":        "string",
+			"description": materializeParamDescription,
+		}
+		tool.InputSchema.Properties["page_size"] = map[string]interface{}{
+			"type":        []string{"integer", "string"},
+			"description": pageSizeParamDescription,
+		}
+	}
+
+	handler = s.tracedToolHandler(tool.Name, s.withListSnapshot(tool.Name, handler))
+	s.server.AddTool(tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		request, err := normalizeArguments(request)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid parameter: %v. Numeric parameters accept either integers or numeric strings.", err)), nil
+		}
+		request, resolutions, err := s.resolveEntityArguments(ctx, tool.Name, request)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Could not resolve a name: %v.", err)), nil
+		}
+		request, actResolutions, err := resolveActAlias(request)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Could not resolve the act: %v.", err)), nil
+		}
+		resolutions = append(resolutions, actResolutions...)
+
+		language, err := s.resolveLanguage(request.GetString("language", ""))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid language: %v. Please use 'en' for English or 'pl' for Polish output.", err)), nil
+		}
+		maxOutputChars, err := s.resolveMaxOutputChars(request.GetString("max_output_chars", ""))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid max_output_chars: %v. Use a number of characters (minimum %d) or '0' for no limit.", err, minOutputChars)), nil
+		}
+		saveToFile := saveable && request.GetString("save_to_file", "false") == "true"
+		// Traces list upstream URLs and timings, so they are only handed out by servers started with -debug
+		debug := s.config.DebugMode && request.GetString("debug", "false") == "true"
+
+		if asyncTools[tool.Name] && request.GetString("async", "false") == "true" {
+			syncRequest := withoutAsync(request)
+			j := s.jobs.submit(tool.Name, jobArguments(request), func(jobCtx context.Context) (*mcp.CallToolResult, error) {
+				var debugTrace *requestTrace
+				if debug {
+					jobCtx, debugTrace = withRequestTrace(jobCtx)
+				}
+				result, err := handler(jobCtx, syncRequest)
+				if err == nil {
+					localizeResult(result, language)
+					if saveToFile {
+						result = s.saveArtifact(tool.Name, syncRequest, result)
+					} else {
+						limitResult(result, maxOutputChars)
+					}
+					appendDebugTrace(result, debugTrace)
+				}
+				return result, err
+			})
+			result := jobSubmittedResult(j)
+			appendResolutionNote(result, resolutions)
+			localizeResult(result, language)
+			return result, nil
+		}
+
+		var debugTrace *requestTrace
+		if debug {
+			ctx, debugTrace = withRequestTrace(ctx)
+		}
+		// Background jobs outlive the client's request timeout on purpose, so only direct calls are limited
+		result, err := s.withToolTimeout(tool.Name, handler)(ctx, request)
+		if err != nil {
+			return result, err
+		}
+		appendResolutionNote(result, resolutions)
+		localizeResult(result, language)
+		if saveToFile {
+			result = s.saveArtifact(tool.Name, request, result)
+		} else {
+			limitResult(result, maxOutputChars)
+		}
+		appendDebugTrace(result, debugTrace)
+		return result, nil
+	})
+}
+
+// makeAPIRequest fetches a JSON endpoint; responses of terms 1-7 are normalized to the form of newer terms
 func (s *SejmServer) makeAPIRequest(ctx context.Context, endpoint string, params map[string]string) ([]byte, error) {
-	return s.makeAPIRequestWithHeaders(ctx, endpoint, params, map[string]string{"Accept": "application/json"})
+	data, err := s.makeAPIRequestWithHeaders(ctx, endpoint, params, map[string]string{"Accept": "application/json"})
+	if err != nil {
+		return data, err
+	}
+	return normalizeLegacyResponse(endpoint, data), nil
 }
 
 func (s *SejmServer) makeTextRequest(ctx context.Context, endpoint string, format string) ([]byte, error) {
@@ -414,10 +688,36 @@ func (s *SejmServer) makeTextRequest(ctx context.Context, endpoint string, forma
 	} else {
 		acceptHeader = "text/html"
 	}
-	return s.makeAPIRequestWithHeaders(ctx, endpoint, nil, map[string]string{"Accept": acceptHeader})
+	data, err := s.makeAPIRequestWithHeaders(ctx, endpoint, nil, map[string]string{"Accept": acceptHeader})
+	if err != nil || format != "html" {
+		return data, err
+	}
+	// Legacy HTML bodies may be Windows-1250 encoded or contain mojibake; hand out clean UTF-8 only
+	return normalizeHTMLBody(data), nil
 }
 
+// makeAPIRequestWithHeaders performs an upstream request inside a span; the response cache status and every
+// attempt are recorded on it
 func (s *SejmServer) makeAPIRequestWithHeaders(ctx context.Context, endpoint string, params map[string]string, headers map[string]string) ([]byte, error) {
+	ctx, span := s.startSpan(ctx, "upstream "+upstreamName(endpoint),
+		attribute.String("url.full", endpoint),
+		attribute.String("http.request.method", http.MethodGet))
+	ctx, call := traceUpstreamCall(ctx, endpoint, params)
+	start := time.Now()
+	body, err := s.doAPIRequest(ctx, endpoint, params, headers)
+	call.finish(len(body), time.Since(start), err)
+	span.SetAttributes(attribute.Int("http.response.body.size", len(body)))
+	endSpan(span, err)
+	return body, err
+}
+
+// doAPIRequest performs an upstream GET request with retries
+func (s *SejmServer) doAPIRequest(ctx context.Context, endpoint string, params map[string]string, headers map[string]string) ([]byte, error) {
+	// A cancelled or timed out tool call starts no more requests
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	reqURL, err := url.Parse(endpoint)
 	if err != nil {
 		s.logger.Error("Invalid URL parsing failed",
@@ -483,6 +783,10 @@ func (s *SejmServer) makeAPIRequestWithHeaders(ctx context.Context, endpoint str
 		resp, err := s.client.Do(req)
 		duration := time.Since(start)
 
+		if err != nil && ctx.Err() != nil {
+			// The caller gave up; this says nothing about the health of the API
+			return nil, ctx.Err()
+		}
 		if err != nil {
 			s.logger.Error("HTTP request failed",
 				slog.Int("attempt", attempt+1),
@@ -490,6 +794,11 @@ func (s *SejmServer) makeAPIRequestWithHeaders(ctx context.Context, endpoint str
 				slog.Duration("duration", duration),
 				slog.Any("error", err))
 			lastErr = err
+			s.health.record(finalURL, 0, err)
+			upstreamCallFrom(ctx).attempt(0)
+			trace.SpanFromContext(ctx).AddEvent("attempt failed", trace.WithAttributes(
+				attribute.Int("attempt", attempt+1),
+				attribute.String("error", err.Error())))
 			// Check if this is a network error that might benefit from retry
 			if attempt < maxRetries-1 {
 				continue
@@ -502,6 +811,12 @@ func (s *SejmServer) makeAPIRequestWithHeaders(ctx context.Context, endpoint str
 			slog.Int("maxRetries", maxRetries),
 			slog.Duration("duration", duration),
 			slog.Int("status", resp.StatusCode))
+		s.health.record(finalURL, resp.StatusCode, nil)
+		upstreamCallFrom(ctx).attempt(resp.StatusCode)
+		trace.SpanFromContext(ctx).AddEvent("attempt", trace.WithAttributes(
+			attribute.Int("attempt", attempt+1),
+			attribute.Int("http.response.status_code", resp.StatusCode),
+			attribute.Int64("duration_ms", duration.Milliseconds())))
 
 		// Handle HTTP status errors
 		if resp.StatusCode != http.StatusOK {
@@ -569,6 +884,8 @@ func (s *SejmServer) makeAPIRequestWithHeaders(ctx context.Context, endpoint str
 		if resp.Header.Get("X-From-Cache") == "1" {
 			cacheStatus = "HIT"
 		}
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("cache.status", cacheStatus))
+		upstreamCallFrom(ctx).cacheStatus(cacheStatus)
 
 		s.logger.Info("Processing successful response",
 			slog.Int64("contentLength", resp.ContentLength),
@@ -646,40 +963,38 @@ func (s *SejmServer) validateTerm(termStr string) (int, error) {
 
 // getCachedPublishers returns publishers from cache or fetches them
 func (s *SejmServer) getCachedPublishers(ctx context.Context) ([]eli.PublishingHouse, error) {
+	ctx, span := s.startSpan(ctx, "cache publishers")
+	defer span.End()
 	s.cache.mu.RLock()
 	if s.cache.Publishers != nil && time.Now().Before(s.cache.Publishers.ExpiresAt) {
 		publishers := s.cache.Publishers.Data.([]eli.PublishingHouse)
 		s.cache.mu.RUnlock()
+		span.SetAttributes(attribute.Bool("cache.hit", true))
 		return publishers, nil
 	}
 	s.cache.mu.RUnlock()
+	span.SetAttributes(attribute.Bool("cache.hit", false))
 
-	// Cache miss or expired, fetch fresh data
-	s.cache.mu.Lock()
-	defer s.cache.mu.Unlock()
-
-	// Double-check in case another goroutine updated while we waited for lock
-	if s.cache.Publishers != nil && time.Now().Before(s.cache.Publishers.ExpiresAt) {
-		return s.cache.Publishers.Data.([]eli.PublishingHouse), nil
-	}
-
-	// Fetch publishers from API
-	endpoint := "https://api.sejm.gov.pl/eli/acts"
+	// Cache miss or expired, fetch fresh data without holding the lock: the request updates the HTTP cache
+	// statistics, which take the same lock
+	endpoint := s.eliBaseURL + "/acts"
 	data, err := s.makeAPIRequest(ctx, endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch publishers: %w", err)
 	}
 
 	var publishers []eli.PublishingHouse
-	if err := json.Unmarshal(data, &publishers); err != nil {
+	if err := s.decodeAPIResponse(data, &publishers); err != nil {
 		return nil, fmt.Errorf("failed to parse publishers: %w", err)
 	}
 
 	// Cache for 24 hours
+	s.cache.mu.Lock()
 	s.cache.Publishers = &CacheEntry{
 		Data:      publishers,
 		ExpiresAt: time.Now().Add(24 * time.Hour),
 	}
+	s.cache.mu.Unlock()
 
 	return publishers, nil
 }
@@ -1399,4 +1714,7 @@ func (s *SejmServer) updateHTTPCacheStats(resp *http.Response) {
 	} else {
 		s.cache.HTTPStats.Misses++
 	}
+	if resp.Header.Get(revalidatedHeader) == "1" {
+		s.cache.HTTPStats.Revalidated++
+	}
 }
@@ -0,0 +1,291 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// sittingBreakThreshold is the shortest gap between statements and votings reported as a break
+const sittingBreakThreshold = 15 * time.Minute
+
+// Kinds of sitting timeline events
+const (
+	timelineOpening = "opening"
+	timelineVoting  = "voting"
+	timelineBreak   = "break"
+	timelineClosing = "closing"
+)
+
+// timelineEvent is a point or a span of a sitting day
+type timelineEvent struct {
+	Start time.Time  `json:"start"`
+	End   *time.Time `json:"end,omitempty"`
+	Kind  string     `json:"kind"`
+	Label string     `json:"label"`
+}
+
+// timelineHour is the activity within one clock hour of a sitting day
+type timelineHour struct {
+	Hour            string `json:"hour"`
+	Statements      int    `json:"statements"`
+	SpeakingMinutes int    `json:"speakingMinutes"`
+	Votings         int    `json:"votings"`
+}
+
+// activitySpan is a statement or, with equal start and end, a voting
+type activitySpan struct {
+	start, end time.Time
+}
+
+// spokenStatements returns the statements with a start time, sorted by it. Statement 0 is the course of the
+// sitting and unspoken statements were only submitted in writing, so both are left out.
+func spokenStatements(statements []sejm.Statement) []sejm.Statement {
+	var spoken []sejm.Statement
+	for _, statement := range statements {
+		if statement.StartDateTime == nil || (statement.Num != nil && *statement.Num == 0) ||
+			(statement.Unspoken != nil && *statement.Unspoken) {
+			continue
+		}
+		spoken = append(spoken, statement)
+	}
+	sort.SliceStable(spoken, func(i, j int) bool { return spoken[i].StartDateTime.Before(spoken[j].StartDateTime.Time) })
+	return spoken
+}
+
+// votingTimelineLabel describes a voting for the timeline, e.g. '#12 Pkt. 5 ... → PASSED (230 yes, 210 no)'
+func votingTimelineLabel(voting sejm.Voting) string {
+	label := ""
+	if voting.VotingNumber != nil {
+		label = fmt.Sprintf("#%d ", *voting.VotingNumber)
+	}
+	label += truncateRunes(valueOrDefault(stringValue(voting.Title), "No title"), 120)
+	if voting.Topic != nil && *voting.Topic != "" {
+		label += " – " + truncateRunes(*voting.Topic, 100)
+	}
+	label += " → " + votingOutcome(voting)
+	if voting.Yes != nil && voting.No != nil {
+		label += fmt.Sprintf(" (%d yes, %d no)", *voting.Yes, *voting.No)
+	}
+	return label
+}
+
+// buildSittingTimeline merges the statements and votings of a sitting day into chronological events (opening,
+// votings, breaks, closing) and per-hour activity. Every hour between the first and the last activity is listed,
+// so the hours can be plotted directly. Speaking time of a statement spanning hours is split between them.
+func buildSittingTimeline(statements []sejm.Statement, votings []sejm.Voting) ([]timelineEvent, []timelineHour) {
+	var spans []activitySpan
+	var events []timelineEvent
+	hours := make(map[time.Time]*timelineHour)
+	hour := func(t time.Time) *timelineHour {
+		key := t.Truncate(time.Hour)
+		if hours[key] == nil {
+			hours[key] = &timelineHour{Hour: key.Format("15:04")}
+		}
+		return hours[key]
+	}
+
+	for _, statement := range spokenStatements(statements) {
+		start := statement.StartDateTime.Time
+		end := start
+		if statement.EndDateTime != nil && statement.EndDateTime.After(start) {
+			end = statement.EndDateTime.Time
+		}
+		spans = append(spans, activitySpan{start, end})
+		hour(start).Statements++
+		for from := start; from.Before(end); {
+			to := from.Truncate(time.Hour).Add(time.Hour)
+			if to.After(end) {
+				to = end
+			}
+			hour(from).SpeakingMinutes += int(to.Sub(from).Round(time.Minute) / time.Minute)
+			from = to
+		}
+	}
+	for _, voting := range votings {
+		if voting.Date == nil {
+			continue
+		}
+		spans = append(spans, activitySpan{voting.Date.Time, voting.Date.Time})
+		hour(voting.Date.Time).Votings++
+		events = append(events, timelineEvent{Start: voting.Date.Time, Kind: timelineVoting, Label: votingTimelineLabel(voting)})
+	}
+	if len(spans) == 0 {
+		return nil, nil
+	}
+
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+	events = append(events, timelineEvent{Start: spans[0].start, Kind: timelineOpening, Label: "First statement or voting"})
+	last := spans[0].end
+	for _, span := range spans[1:] {
+		if gap := span.start.Sub(last); gap >= sittingBreakThreshold {
+			end := span.start
+			events = append(events, timelineEvent{Start: last, End: &end, Kind: timelineBreak,
+				Label: fmt.Sprintf("No statements or votings for %d min", int(gap/time.Minute))})
+		}
+		if span.end.After(last) {
+			last = span.end
+		}
+	}
+	events = append(events, timelineEvent{Start: last, Kind: timelineClosing, Label: "Last statement or voting"})
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Start.Before(events[j].Start) })
+
+	var perHour []timelineHour
+	for at := spans[0].start.Truncate(time.Hour); !at.After(last); at = at.Add(time.Hour) {
+		perHour = append(perHour, *hour(at))
+	}
+	return events, perHour
+}
+
+// sittingDay returns the requested day of a sitting, or its only day when none is given
+func (s *SejmServer) sittingDay(ctx context.Context, term, sitting int, date string) (string, error) {
+	if date != "" {
+		if _, err := parseDefectionDate("date", date); err != nil {
+			return "", err
+		}
+		return date, nil
+	}
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/proceedings/%d", s.sejmBaseURL, term, sitting), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve sitting %d: %w", sitting, err)
+	}
+	var proceeding sejm.Proceeding
+	if err := s.decodeAPIResponse(data, &proceeding); err != nil {
+		return "", fmt.Errorf("failed to parse sitting %d: %w", sitting, err)
+	}
+	if proceeding.Dates == nil || len(*proceeding.Dates) == 0 {
+		return "", fmt.Errorf("sitting %d has no dates", sitting)
+	}
+	var dates []string
+	for _, day := range *proceeding.Dates {
+		dates = append(dates, day.String())
+	}
+	if len(dates) > 1 {
+		return "", fmt.Errorf("sitting %d lasted %d days (%s); choose one with the date parameter", sitting, len(dates), strings.Join(dates, ", "))
+	}
+	return dates[0], nil
+}
+
+func (s *SejmServer) handleGetSittingTimeline(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_sitting_timeline called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	sitting, err := strconv.Atoi(request.GetString("sitting", ""))
+	if err != nil || sitting < 1 {
+		return mcp.NewToolResultError("Parameter 'sitting' is required and must be a positive number. Find sittings with sejm_get_proceedings."), nil
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+	date, err := s.sittingDay(ctx, term, sitting, request.GetString("date", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Cannot determine the sitting day: %v.", err)), nil
+	}
+	day, _ := parseDefectionDate("date", date)
+
+	coverage := newSourceCoverage("sources")
+	var statements []sejm.Statement
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/proceedings/%d/%s/transcripts", s.sejmBaseURL, term, sitting, date), nil)
+	if err == nil {
+		var list sejm.StatementList
+		if err = s.decodeAPIResponse(data, &list); err == nil && list.Statements != nil {
+			statements = *list.Statements
+		}
+	}
+	if err != nil {
+		coverage.fail("transcript statements", err)
+	} else {
+		coverage.succeeded()
+	}
+
+	var votings []sejm.Voting
+	data, err = s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/votings/%d", s.sejmBaseURL, term, sitting), nil)
+	if err == nil {
+		var all []sejm.Voting
+		if err = s.decodeAPIResponse(data, &all); err == nil {
+			for _, voting := range all {
+				if votingDateInRange(voting, day, day) {
+					votings = append(votings, voting)
+				}
+			}
+		}
+	}
+	if err != nil {
+		coverage.fail("votings", err)
+	} else {
+		coverage.succeeded()
+	}
+	if len(coverage.failed) == 2 {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve sitting %d on %s: %s", sitting, date, strings.Join(coverage.failed, "; "))), nil
+	}
+
+	events, hours := buildSittingTimeline(statements, votings)
+	spoken := len(spokenStatements(statements))
+
+	if format == "json" {
+		result, _ := json.MarshalIndent(map[string]interface{}{
+			"term":        term,
+			"sitting":     sitting,
+			"date":        date,
+			"statements":  spoken,
+			"votings":     len(votings),
+			"events":      events,
+			"hours":       hours,
+			"unavailable": coverage.failed,
+		}, "", "  ")
+		return coverage.result(string(result)), nil
+	}
+
+	summary := []string{
+		fmt.Sprintf("Sitting %d, term %d, %s", sitting, term, date),
+		fmt.Sprintf("Statements: %d", spoken),
+		fmt.Sprintf("Votings: %d", len(votings)),
+	}
+	var results []string
+	status := coverage.status("Retrieved Successfully")
+	if len(events) == 0 {
+		status = "No Results Found"
+		results = append(results, "No timed statements or votings were published for this day.")
+	} else {
+		summary = append(summary, fmt.Sprintf("Activity: %s – %s", events[0].Start.Format("15:04"), events[len(events)-1].Start.Format("15:04")))
+		results = append(results, "Hourly activity (hour: statements, speaking minutes, votings):")
+		for _, h := range hours {
+			results = append(results, fmt.Sprintf("  %s: %d statements, %d min, %d votings", h.Hour, h.Statements, h.SpeakingMinutes, h.Votings))
+		}
+		results = append(results, "", "Events:")
+		for _, event := range events {
+			line := "  " + event.Start.Format("15:04")
+			if event.End != nil {
+				line += "–" + event.End.Format("15:04")
+			}
+			results = append(results, fmt.Sprintf("%s [%s] %s", line, event.Kind, event.Label))
+		}
+	}
+
+	response := StandardResponse{
+		Operation:   "Sitting Timeline",
+		Status:      status,
+		Summary:     summary,
+		Unavailable: coverage.unavailable(),
+		Data:        results,
+		NextActions: []string{
+			fmt.Sprintf("Statements of the day: sejm_get_transcripts with proceeding_id='%d' and date='%s'", sitting, date),
+			fmt.Sprintf("Details of a voting: sejm_get_voting_details with sitting='%d' and voting_number", sitting),
+			"Data for plotting: add format='json'",
+		},
+		Note: fmt.Sprintf("Times are as published by the Sejm (Polish time). Gaps of at least %d minutes without statements or votings are reported as breaks; written (unspoken) statements are not counted.", int(sittingBreakThreshold/time.Minute)),
+	}
+	return coverage.result(response.Format()), nil
+}
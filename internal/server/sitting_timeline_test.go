@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+const sittingTimelineStatements = `{"proceedingNum": 15, "date": "2024-07-10", "statements": [
+	{"num": 0, "name": "Przebieg posiedzenia", "startDateTime": "2024-07-10T09:00:00", "endDateTime": "2024-07-10T18:00:00"},
+	{"num": 1, "name": "Marszałek", "startDateTime": "2024-07-10T09:05:00", "endDateTime": "2024-07-10T09:10:00"},
+	{"num": 2, "name": "Jan Kowalski", "startDateTime": "2024-07-10T09:50:00", "endDateTime": "2024-07-10T10:20:00"},
+	{"num": 3, "name": "Anna Nowak", "unspoken": true, "startDateTime": "2024-07-10T10:30:00"},
+	{"num": 4, "name": "Piotr Wiśniewski", "startDateTime": "2024-07-10T11:30:00", "endDateTime": "2024-07-10T11:40:00"}
+]}`
+
+const sittingTimelineVotings = `[
+	{"votingNumber": 1, "sitting": 15, "date": "2024-07-10T10:25:00", "title": "Pkt. 3 Projekt ustawy", "yes": 300, "no": 100, "majorityVotes": 201},
+	{"votingNumber": 2, "sitting": 15, "date": "2024-07-11T09:00:00", "title": "Pkt. 4 Uchwała", "yes": 200, "no": 220, "majorityVotes": 211}
+]`
+
+func TestBuildSittingTimeline(t *testing.T) {
+	var list sejm.StatementList
+	if err := json.Unmarshal([]byte(sittingTimelineStatements), &list); err != nil {
+		t.Fatalf("Failed to parse statements: %v", err)
+	}
+	var votings []sejm.Voting
+	if err := json.Unmarshal([]byte(sittingTimelineVotings), &votings); err != nil {
+		t.Fatalf("Failed to parse votings: %v", err)
+	}
+	events, hours := buildSittingTimeline(*list.Statements, votings[:1])
+
+	var kinds []string
+	for _, event := range events {
+		kinds = append(kinds, event.Start.Format("15:04")+" "+event.Kind)
+	}
+	expected := "09:05 opening, 09:10 break, 10:25 voting, 10:25 break, 11:40 closing"
+	if got := strings.Join(kinds, ", "); got != expected {
+		t.Errorf("Expected events %q, got %q", expected, got)
+	}
+	if len(hours) != 3 {
+		t.Fatalf("Expected hours 09:00-11:00, got %+v", hours)
+	}
+	if hours[0].Statements != 2 || hours[0].SpeakingMinutes != 15 || hours[1].SpeakingMinutes != 20 || hours[1].Votings != 1 {
+		t.Errorf("Unexpected hourly activity: %+v", hours)
+	}
+
+	if events, hours := buildSittingTimeline(nil, nil); events != nil || hours != nil {
+		t.Errorf("Expected an empty timeline without activity, got %+v %+v", events, hours)
+	}
+}
+
+func TestHandleGetSittingTimeline(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/proceedings/15":                        `{"number": 15, "dates": ["2024-07-10", "2024-07-11"]}`,
+		"/sejm/term10/proceedings/15/2024-07-10/transcripts": sittingTimelineStatements,
+		"/sejm/term10/votings/15":                            sittingTimelineVotings,
+	})
+
+	result, err := server.handleGetSittingTimeline(context.Background(), createMockRequest(map[string]interface{}{
+		"sitting": "15", "date": "2024-07-10",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{"Statements: 3", "Votings: 1", "10:25 [voting] #1 Pkt. 3 Projekt ustawy → PASSED", "09:10–09:50 [break]"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output: %s", expected, text)
+		}
+	}
+
+	result, _ = server.handleGetSittingTimeline(context.Background(), createMockRequest(map[string]interface{}{"sitting": "15"}))
+	if !result.IsError || !strings.Contains(extractTextContent(result), "2024-07-10, 2024-07-11") {
+		t.Errorf("Expected a multi-day sitting to require a date, got: %s", extractTextContent(result))
+	}
+
+	result, _ = server.handleGetSittingTimeline(context.Background(), createMockRequest(map[string]interface{}{
+		"sitting": "15", "date": "2024-07-11", "format": "json",
+	}))
+	if result.IsError {
+		t.Fatalf("Expected a partial timeline without the transcript, got: %s", extractTextContent(result))
+	}
+	var response struct {
+		Votings     int      `json:"votings"`
+		Unavailable []string `json:"unavailable"`
+	}
+	if err := json.Unmarshal([]byte(extractTextContent(result)), &response); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if response.Votings != 1 || len(response.Unavailable) != 1 {
+		t.Errorf("Expected one voting and the missing transcript reported, got %+v", response)
+	}
+}
@@ -0,0 +1,202 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// sejmStatutorySize is the statutory number of MPs; the Constitution requires at least half of it to be
+	// present for the Sejm to pass a resolution
+	sejmStatutorySize = 460
+	sejmQuorum        = sejmStatutorySize / 2
+	// defaultQuorumRiskMargin is how close to the quorum the votes cast may come before a voting is flagged
+	defaultQuorumRiskMargin = 20
+)
+
+// Quorum flags of a voting
+const (
+	quorumOK      = "ok"
+	quorumRisk    = "risk"
+	quorumMissing = "no quorum"
+)
+
+// votingTurnout is the participation in one voting
+type votingTurnout struct {
+	VotingNumber int32   `json:"votingNumber"`
+	Time         string  `json:"time,omitempty"`
+	Title        string  `json:"title"`
+	Voted        int     `json:"voted"`
+	Mandates     int     `json:"mandates"`
+	Turnout      float64 `json:"turnoutPercent"`
+	QuorumMargin int     `json:"quorumMargin"`
+	Quorum       string  `json:"quorum"`
+}
+
+// computeVotingTurnout relates the votes cast to the MPs holding a mandate, which the API gives as those who
+// voted plus those who did not; vacant seats are therefore not counted against turnout. The quorum is checked
+// against the statutory number of MPs.
+func computeVotingTurnout(voting sejm.Voting, riskMargin int) (votingTurnout, bool) {
+	if voting.TotalVoted == nil {
+		return votingTurnout{}, false
+	}
+	turnout := votingTurnout{
+		Title:    truncateRunes(valueOrDefault(stringValue(voting.Title), "No title"), 100),
+		Voted:    int(*voting.TotalVoted),
+		Mandates: int(*voting.TotalVoted),
+	}
+	if voting.VotingNumber != nil {
+		turnout.VotingNumber = *voting.VotingNumber
+	}
+	if voting.Date != nil {
+		turnout.Time = voting.Date.Format("2006-01-02 15:04")
+	}
+	if voting.NotParticipating != nil {
+		turnout.Mandates += int(*voting.NotParticipating)
+	}
+	if turnout.Mandates > 0 {
+		turnout.Turnout = float64(turnout.Voted) * 100 / float64(turnout.Mandates)
+	}
+	turnout.QuorumMargin = turnout.Voted - sejmQuorum
+	switch {
+	case turnout.QuorumMargin < 0:
+		turnout.Quorum = quorumMissing
+	case turnout.QuorumMargin <= riskMargin:
+		turnout.Quorum = quorumRisk
+	default:
+		turnout.Quorum = quorumOK
+	}
+	return turnout, true
+}
+
+func (s *SejmServer) handleGetSittingTurnout(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_sitting_turnout called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	sitting, err := strconv.Atoi(request.GetString("sitting", ""))
+	if err != nil || sitting < 1 {
+		return mcp.NewToolResultError("Parameter 'sitting' is required and must be a positive number. Find sittings with sejm_get_proceedings."), nil
+	}
+	day, err := parseDefectionDate("date", request.GetString("date", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	riskMargin := defaultQuorumRiskMargin
+	if value := request.GetString("risk_margin", ""); value != "" {
+		riskMargin, err = strconv.Atoi(value)
+		if err != nil || riskMargin < 0 || riskMargin > sejmQuorum {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid risk_margin '%s'. Use a number of votes between 0 and %d.", value, sejmQuorum)), nil
+		}
+	}
+	flaggedOnly := request.GetString("flagged_only", "false") == "true"
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/votings/%d", s.sejmBaseURL, term, sitting), nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve votings of sitting %d: %v. Please verify the sitting number.", sitting, err)), nil
+	}
+	var votings []sejm.Voting
+	if err := s.decodeAPIResponse(data, &votings); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse votings of sitting %d: %v.", sitting, err)), nil
+	}
+
+	var turnouts []votingTurnout
+	var listed []votingTurnout
+	counts := make(map[string]int)
+	totalTurnout := 0.0
+	lowest := -1
+	for _, voting := range votings {
+		if !votingDateInRange(voting, day, day) {
+			continue
+		}
+		turnout, ok := computeVotingTurnout(voting, riskMargin)
+		if !ok {
+			continue
+		}
+		turnouts = append(turnouts, turnout)
+		counts[turnout.Quorum]++
+		totalTurnout += turnout.Turnout
+		if lowest < 0 || turnout.Voted < turnouts[lowest].Voted {
+			lowest = len(turnouts) - 1
+		}
+		if !flaggedOnly || turnout.Quorum != quorumOK {
+			listed = append(listed, turnout)
+		}
+	}
+	dayLabel := ""
+	if !day.IsZero() {
+		dayLabel = " on " + day.Format("2006-01-02")
+	}
+	if len(turnouts) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Sitting %d of term %d has no votings with vote counts%s.", sitting, term, dayLabel)), nil
+	}
+	average := totalTurnout / float64(len(turnouts))
+
+	if format == "json" {
+		result, _ := json.MarshalIndent(map[string]interface{}{
+			"term":           term,
+			"sitting":        sitting,
+			"quorum":         sejmQuorum,
+			"riskMargin":     riskMargin,
+			"votings":        len(turnouts),
+			"averageTurnout": average,
+			"atRisk":         counts[quorumRisk],
+			"withoutQuorum":  counts[quorumMissing],
+			"turnout":        listed,
+		}, "", "  ")
+		return mcp.NewToolResultText(string(result)), nil
+	}
+
+	summary := []string{
+		fmt.Sprintf("Sitting %d, term %d%s", sitting, term, dayLabel),
+		fmt.Sprintf("Votings: %d, average turnout %.1f%%", len(turnouts), average),
+		fmt.Sprintf("Lowest turnout: voting #%d with %d votes cast", turnouts[lowest].VotingNumber, turnouts[lowest].Voted),
+		fmt.Sprintf("Quorum (%d of %d MPs): %d at risk (within %d votes), %d without quorum", sejmQuorum, sejmStatutorySize, counts[quorumRisk], riskMargin, counts[quorumMissing]),
+	}
+	results := []string{"#    | time             | voted/mandates | turnout | vs quorum | flag | title"}
+	for _, turnout := range listed {
+		flag := ""
+		switch turnout.Quorum {
+		case quorumRisk:
+			flag = "⚠️"
+		case quorumMissing:
+			flag = "❌"
+		}
+		results = append(results, fmt.Sprintf("%-4d | %-16s | %3d/%-3d        | %5.1f%%  | %+4d      | %-4s | %s",
+			turnout.VotingNumber, turnout.Time, turnout.Voted, turnout.Mandates, turnout.Turnout, turnout.QuorumMargin, flag, turnout.Title))
+	}
+	if len(listed) == 0 {
+		results = []string{fmt.Sprintf("No voting came within %d votes of the quorum.", riskMargin)}
+	}
+
+	notes := []string{
+		"Turnout is the votes cast divided by the MPs holding a mandate (voted plus not participating), so vacant seats are not counted.",
+		fmt.Sprintf("The quorum is half of the statutory %d MPs; votes cast are a lower bound of the MPs present, since present MPs may not vote.", sejmStatutorySize),
+	}
+	response := StandardResponse{
+		Operation: "Sitting Turnout",
+		Status:    "Retrieved Successfully",
+		Summary:   summary,
+		Data:      results,
+		NextActions: []string{
+			fmt.Sprintf("Who did not vote: sejm_get_voting_details with sitting='%d' and voting_number", sitting),
+			"Only flagged votings: flagged_only='true'",
+			"Structured output: add format='json'",
+		},
+		Note: strings.Join(notes, " "),
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+const sittingTurnoutVotings = `[
+	{"votingNumber": 1, "sitting": 15, "date": "2024-07-10T10:00:00", "title": "Pkt. 3 Projekt ustawy", "totalVoted": 450, "notParticipating": 9},
+	{"votingNumber": 2, "sitting": 15, "date": "2024-07-10T21:30:00", "title": "Pkt. 4 Uchwała", "totalVoted": 240, "notParticipating": 219},
+	{"votingNumber": 3, "sitting": 15, "date": "2024-07-11T09:00:00", "title": "Pkt. 5 Wniosek", "totalVoted": 225, "notParticipating": 234},
+	{"votingNumber": 4, "sitting": 15, "date": "2024-07-11T09:05:00", "title": "Lista"}
+]`
+
+func TestComputeVotingTurnout(t *testing.T) {
+	var votings []sejm.Voting
+	if err := json.Unmarshal([]byte(sittingTurnoutVotings), &votings); err != nil {
+		t.Fatalf("Failed to parse votings: %v", err)
+	}
+	expected := []struct {
+		quorum   string
+		margin   int
+		mandates int
+	}{{quorumOK, 220, 459}, {quorumRisk, 10, 459}, {quorumMissing, -5, 459}}
+	for i, want := range expected {
+		turnout, ok := computeVotingTurnout(votings[i], defaultQuorumRiskMargin)
+		if !ok || turnout.Quorum != want.quorum || turnout.QuorumMargin != want.margin || turnout.Mandates != want.mandates {
+			t.Errorf("Voting %d: expected %+v, got %+v", i+1, want, turnout)
+		}
+	}
+	if _, ok := computeVotingTurnout(votings[3], defaultQuorumRiskMargin); ok {
+		t.Error("Expected a voting without totals to be skipped")
+	}
+}
+
+func TestHandleGetSittingTurnout(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/votings/15": sittingTurnoutVotings,
+	})
+
+	result, err := server.handleGetSittingTurnout(context.Background(), createMockRequest(map[string]interface{}{
+		"sitting": "15", "flagged_only": "true",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{"Votings: 3", "1 at risk (within 20 votes), 1 without quorum", "Lowest turnout: voting #3 with 225 votes cast", "Pkt. 4 Uchwała", "Pkt. 5 Wniosek"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output: %s", expected, text)
+		}
+	}
+	if strings.Contains(text, "Pkt. 3 Projekt ustawy") {
+		t.Errorf("Expected the voting with a safe quorum to be left out: %s", text)
+	}
+
+	result, _ = server.handleGetSittingTurnout(context.Background(), createMockRequest(map[string]interface{}{
+		"sitting": "15", "date": "2024-07-10", "format": "json",
+	}))
+	var response struct {
+		Votings int             `json:"votings"`
+		Turnout []votingTurnout `json:"turnout"`
+	}
+	if err := json.Unmarshal([]byte(extractTextContent(result)), &response); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if response.Votings != 2 || len(response.Turnout) != 2 {
+		t.Errorf("Expected the two votings of the first day, got %+v", response)
+	}
+
+	result, _ = server.handleGetSittingTurnout(context.Background(), createMockRequest(map[string]interface{}{"sitting": "15", "risk_margin": "-1"}))
+	if !result.IsError {
+		t.Error("Expected an error for a negative risk_margin")
+	}
+}
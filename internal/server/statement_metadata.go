@@ -0,0 +1,222 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+// Values of the 'metadata' parameter of sejm_get_statement
+const (
+	statementMetadataNone    = "false"
+	statementMetadataInclude = "true"
+	statementMetadataOnly    = "only"
+)
+
+// maxStatementHeadingRunes bounds the speaker heading; longer lines ending with a colon are speech, not a heading
+const maxStatementHeadingRunes = 200
+
+var (
+	// statementBlockPattern matches tags that end a line of the transcript markup
+	statementBlockPattern = regexp.MustCompile(`(?i)<\s*/?\s*(?:p|div|br|h[1-6]|li|tr|blockquote)\b[^>]*>`)
+	// statementParenthesisPattern captures parenthesized remarks of the stenographers, e.g. "(Oklaski)"
+	statementParenthesisPattern = regexp.MustCompile(`\(([^()]{2,500})\)`)
+	// statementTimePattern captures clock times as written in transcripts, e.g. "godz. 12 min 03"
+	statementTimePattern = regexp.MustCompile(`(?i)godz\.\s*(\d{1,2})(?:\s*min\.?\s*(\d{1,2}))?`)
+	// statementSpacePattern collapses the whitespace left by removed markup
+	statementSpacePattern = regexp.MustCompile(`\s+`)
+)
+
+// statementInterruptionKinds maps the opening words of stenographers' remarks to an interruption kind
+var statementInterruptionKinds = []struct {
+	prefix string
+	kind   string
+}{
+	{"oklaski", "applause"},
+	{"głos z sali", "voice from the floor"},
+	{"głosy z sali", "voice from the floor"},
+	{"okrzyki", "shouts"},
+	{"dzwonek", "bell"},
+	{"wesołość", "laughter"},
+	{"poruszenie", "commotion"},
+	{"gwar", "commotion"},
+	{"wrzawa", "commotion"},
+	{"przerwa w posiedzeniu", "procedural"},
+	{"wznowienie posiedzenia", "procedural"},
+	{"początek posiedzenia", "procedural"},
+	{"koniec posiedzenia", "procedural"},
+	{"posiedzeniu przewodniczy", "procedural"},
+	{"na tym stenogram", "procedural"},
+}
+
+// statementSpeakerTitles open the remark of another speaker interjecting from the floor, e.g. "(Poseł Jan Nowak: ...)"
+var statementSpeakerTitles = []string{"poseł", "posłanka", "marszałek", "wicemarszałek", "minister", "sekretarz", "podsekretarz", "prezes"}
+
+// statementInterruption is a stenographers' remark recorded inside a statement
+type statementInterruption struct {
+	Kind    string `json:"kind"`
+	Speaker string `json:"speaker,omitempty"`
+	Text    string `json:"text"`
+}
+
+// statementTimeMark is a clock time recorded in the statement, usually with a break or resumption of the sitting
+type statementTimeMark struct {
+	Time string `json:"time"`
+	Text string `json:"text"`
+}
+
+// statementMetadata describes a statement without its text: who spoke, in which role, when, and how the room reacted
+type statementMetadata struct {
+	Num           int32                   `json:"num"`
+	Speaker       string                  `json:"speaker,omitempty"`
+	Heading       string                  `json:"heading,omitempty"`
+	Function      string                  `json:"function,omitempty"`
+	MemberID      int32                   `json:"memberId,omitempty"`
+	Club          string                  `json:"club,omitempty"`
+	Start         string                  `json:"start,omitempty"`
+	End           string                  `json:"end,omitempty"`
+	Rapporteur    bool                    `json:"rapporteur,omitempty"`
+	Secretary     bool                    `json:"secretary,omitempty"`
+	Unspoken      bool                    `json:"unspoken,omitempty"`
+	Interruptions []statementInterruption `json:"interruptions"`
+	TimeMarks     []statementTimeMark     `json:"timeMarks,omitempty"`
+	Warnings      []string                `json:"warnings,omitempty"`
+}
+
+// statementLines turns statement HTML into plain text lines, one per paragraph of the transcript
+func statementLines(content string) []string {
+	text := htmlToPlainText(statementBlockPattern.ReplaceAllString(content, "\n"))
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(statementSpacePattern.ReplaceAllString(line, " "))
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// classifyStatementRemark returns the interruption a parenthesized remark records; ok is false for remarks that
+// are ordinary parentheses of the speech, e.g. references to prints or acts
+func classifyStatementRemark(remark string, wholeLine bool) (statementInterruption, bool) {
+	remark = strings.TrimSpace(remark)
+	lower := strings.ToLower(remark)
+	for _, known := range statementInterruptionKinds {
+		if strings.HasPrefix(lower, known.prefix) {
+			interruption := statementInterruption{Kind: known.kind, Text: remark}
+			if known.kind == "voice from the floor" {
+				if _, words, found := strings.Cut(remark, ":"); found {
+					interruption.Text = strings.TrimSpace(words)
+				}
+			}
+			return interruption, true
+		}
+	}
+	if speaker, words, found := strings.Cut(remark, ":"); found && len([]rune(speaker)) <= 100 {
+		lowerSpeaker := strings.ToLower(speaker)
+		for _, title := range statementSpeakerTitles {
+			if strings.HasPrefix(lowerSpeaker, title+" ") {
+				return statementInterruption{Kind: "interjection", Speaker: strings.TrimSpace(speaker), Text: strings.TrimSpace(words)}, true
+			}
+		}
+	}
+	// A paragraph holding only a parenthesized remark is always the stenographers' note
+	if wholeLine {
+		return statementInterruption{Kind: "other", Text: remark}, true
+	}
+	return statementInterruption{}, false
+}
+
+// parseStatementMarkup extracts the speaker heading, interruptions and time marks from statement HTML
+func parseStatementMarkup(content string) statementMetadata {
+	metadata := statementMetadata{Interruptions: []statementInterruption{}}
+	for _, line := range statementLines(content) {
+		if metadata.Heading == "" && strings.HasSuffix(line, ":") && !strings.HasPrefix(line, "(") &&
+			len([]rune(line)) <= maxStatementHeadingRunes {
+			metadata.Heading = strings.TrimSuffix(line, ":")
+			continue
+		}
+		wholeLine := strings.HasPrefix(line, "(") && strings.HasSuffix(line, ")")
+		for _, match := range statementParenthesisPattern.FindAllStringSubmatch(line, -1) {
+			interruption, ok := classifyStatementRemark(match[1], wholeLine && match[0] == line)
+			if !ok {
+				continue
+			}
+			metadata.Interruptions = append(metadata.Interruptions, interruption)
+			if clock := statementTimePattern.FindStringSubmatch(match[1]); clock != nil {
+				metadata.TimeMarks = append(metadata.TimeMarks, statementTimeMark{Time: formatStatementClock(clock[1], clock[2]), Text: interruption.Text})
+			}
+		}
+	}
+	return metadata
+}
+
+// formatStatementClock formats the hour and optional minutes of a transcript time as HH:MM
+func formatStatementClock(hour, minutes string) string {
+	h, _ := strconv.Atoi(hour)
+	m, _ := strconv.Atoi(minutes)
+	return fmt.Sprintf("%02d:%02d", h, m)
+}
+
+// statementMetadataFor combines the markup of a statement with its entry in the sitting's statement list and
+// the speaker's club. Failures of the extra lookups are reported as warnings, the markup is always parsed.
+func (s *SejmServer) statementMetadataFor(ctx context.Context, term int, proceedingID, date, statementNum, content string) statementMetadata {
+	metadata := parseStatementMarkup(content)
+	if num, err := strconv.Atoi(statementNum); err == nil {
+		metadata.Num = int32(num)
+	}
+
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/proceedings/%s/%s/transcripts", s.sejmBaseURL, term, proceedingID, date), nil)
+	var list sejm.StatementList
+	if err == nil {
+		err = s.decodeAPIResponse(data, &list)
+	}
+	if err != nil {
+		metadata.Warnings = append(metadata.Warnings, fmt.Sprintf("statement list unavailable, speaker details come from the markup only: %v", err))
+		return metadata
+	}
+
+	var entry *sejm.Statement
+	if list.Statements != nil {
+		for i := range *list.Statements {
+			if statement := &(*list.Statements)[i]; statement.Num != nil && *statement.Num == metadata.Num {
+				entry = statement
+				break
+			}
+		}
+	}
+	if entry == nil {
+		metadata.Warnings = append(metadata.Warnings, fmt.Sprintf("statement %s is not in the statement list of %s", statementNum, date))
+		return metadata
+	}
+	metadata.Speaker = stringValue(entry.Name)
+	metadata.Function = stringValue(entry.Function)
+	if entry.StartDateTime != nil {
+		metadata.Start = entry.StartDateTime.Format("2006-01-02T15:04:05")
+	}
+	if entry.EndDateTime != nil {
+		metadata.End = entry.EndDateTime.Format("2006-01-02T15:04:05")
+	}
+	metadata.Rapporteur = entry.Rapporteur != nil && *entry.Rapporteur
+	metadata.Secretary = entry.Secretary != nil && *entry.Secretary
+	metadata.Unspoken = entry.Unspoken != nil && *entry.Unspoken
+
+	if entry.MemberID != nil && *entry.MemberID > 0 {
+		metadata.MemberID = *entry.MemberID
+		data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/MP/%d", s.sejmBaseURL, term, metadata.MemberID), nil)
+		var mp sejm.MP
+		if err == nil {
+			err = s.decodeAPIResponse(data, &mp)
+		}
+		if err != nil {
+			metadata.Warnings = append(metadata.Warnings, fmt.Sprintf("club of MP %d unavailable: %v", metadata.MemberID, err))
+		} else {
+			metadata.Club = stringValue(mp.Club)
+		}
+	}
+	return metadata
+}
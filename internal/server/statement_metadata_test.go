@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const statementFixtureHTML = `<html><body>
+<h2 class="mowca">Poseł Jan Kowalski:</h2>
+<p>Panie Marszałku! Wysoka Izbo! Projekt (druk nr 123) zasługuje na poparcie. (Oklaski)</p>
+<p>(Głos z sali: Nieprawda!)</p>
+<p>(Poseł Anna Nowak: Proszę o spokój)</p>
+<p>(Dzwonek)</p>
+<p>Dziękuję bardzo.</p>
+<p>(Przerwa w posiedzeniu o godz. 12 min 3)</p>
+</body></html>`
+
+func TestParseStatementMarkup(t *testing.T) {
+	metadata := parseStatementMarkup(statementFixtureHTML)
+	if metadata.Heading != "Poseł Jan Kowalski" {
+		t.Errorf("Expected speaker heading, got %q", metadata.Heading)
+	}
+	expected := []statementInterruption{
+		{Kind: "applause", Text: "Oklaski"},
+		{Kind: "voice from the floor", Text: "Nieprawda!"},
+		{Kind: "interjection", Speaker: "Poseł Anna Nowak", Text: "Proszę o spokój"},
+		{Kind: "bell", Text: "Dzwonek"},
+		{Kind: "procedural", Text: "Przerwa w posiedzeniu o godz. 12 min 3"},
+	}
+	if len(metadata.Interruptions) != len(expected) {
+		t.Fatalf("Expected %d interruptions, got %+v", len(expected), metadata.Interruptions)
+	}
+	for i, interruption := range expected {
+		if metadata.Interruptions[i] != interruption {
+			t.Errorf("Interruption %d: expected %+v, got %+v", i, interruption, metadata.Interruptions[i])
+		}
+	}
+	if len(metadata.TimeMarks) != 1 || metadata.TimeMarks[0].Time != "12:03" {
+		t.Errorf("Expected one time mark at 12:03, got %+v", metadata.TimeMarks)
+	}
+}
+
+func TestHandleGetStatementMetadata(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/proceedings/5/2024-02-01/transcripts/7": statementFixtureHTML,
+		"/sejm/term10/proceedings/5/2024-02-01/transcripts": `{"proceedingNum": 5, "statements": [
+			{"num": 7, "name": "Jan Kowalski", "function": "Poseł", "memberID": 42,
+			 "startDateTime": "2024-02-01T11:50:00", "endDateTime": "2024-02-01T12:03:00", "rapporteur": true}
+		]}`,
+		"/sejm/term10/MP/42": `{"id": 42, "firstLastName": "Jan Kowalski", "club": "KO"}`,
+	})
+
+	result, err := server.handleGetStatement(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "proceeding_id": "5", "date": "2024-02-01", "statement_num": "7", "metadata": "only",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	var metadata statementMetadata
+	if err := json.Unmarshal([]byte(extractTextContent(result)), &metadata); err != nil {
+		t.Fatalf("Expected JSON metadata, got %v: %s", err, extractTextContent(result))
+	}
+	if metadata.Num != 7 || metadata.Speaker != "Jan Kowalski" || metadata.Function != "Poseł" || metadata.Club != "KO" ||
+		metadata.MemberID != 42 || !metadata.Rapporteur || metadata.Start != "2024-02-01T11:50:00" || len(metadata.Warnings) != 0 {
+		t.Errorf("Unexpected metadata: %+v", metadata)
+	}
+
+	result, _ = server.handleGetStatement(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "proceeding_id": "5", "date": "2024-02-01", "statement_num": "7", "metadata": "true", "render": "markdown",
+	}))
+	content := extractTextContent(result)
+	if !strings.HasPrefix(content, "Statement metadata:\n{") || !strings.Contains(content, "Dziękuję bardzo.") {
+		t.Errorf("Expected metadata followed by the statement, got: %s", content)
+	}
+
+	result, _ = server.handleGetStatement(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "proceeding_id": "5", "date": "2024-02-01", "statement_num": "7", "metadata": "yes",
+	}))
+	if !result.IsError {
+		t.Errorf("Expected an error for an invalid metadata value")
+	}
+}
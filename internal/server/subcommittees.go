@@ -0,0 +1,322 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxListedSubcommitteeSittings limits the sittings shown for a single subcommittee
+const maxListedSubcommitteeSittings = 10
+
+// fetchCommittee retrieves a committee or subcommittee by code
+func (s *SejmServer) fetchCommittee(ctx context.Context, term int, code string) (sejm.Committee, error) {
+	var committee sejm.Committee
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/committees/%s", s.sejmBaseURL, term, code), nil)
+	if err != nil {
+		return committee, err
+	}
+	if err := s.decodeAPIResponse(data, &committee); err != nil {
+		return committee, fmt.Errorf("failed to parse committee data: %w", err)
+	}
+	return committee, nil
+}
+
+// fetchCommittees retrieves committees by code with limited concurrency, keeping the input order
+func (s *SejmServer) fetchCommittees(ctx context.Context, term int, codes []string) ([]sejm.Committee, []error) {
+	committees := make([]sejm.Committee, len(codes))
+	errs := make([]error, len(codes))
+	slots := make(chan struct{}, maxConcurrentBodyFetches)
+	var wg sync.WaitGroup
+	for i, code := range codes {
+		wg.Add(1)
+		go func(i int, code string) {
+			defer wg.Done()
+			if err := acquireSlot(ctx, slots); err != nil {
+				errs[i] = err
+				return
+			}
+			defer func() { <-slots }()
+			committees[i], errs[i] = s.fetchCommittee(ctx, term, code)
+		}(i, code)
+	}
+	wg.Wait()
+	return committees, errs
+}
+
+// sortedCommitteeMembers orders members by role (leadership first) and then by name
+func sortedCommitteeMembers(members []sejm.Member) []sejm.Member {
+	roleOrder := map[string]int{"chairman": 0, "deputy": 1, "secretary": 2, "member": 3}
+	sorted := append([]sejm.Member(nil), members...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri := roleOrder[committeeMemberRole(stringValue(sorted[i].Function))]
+		rj := roleOrder[committeeMemberRole(stringValue(sorted[j].Function))]
+		if ri != rj {
+			return ri < rj
+		}
+		return stringValue(sorted[i].LastFirstName) < stringValue(sorted[j].LastFirstName)
+	})
+	return sorted
+}
+
+// subcommitteeChair returns the name of a subcommittee's chair, if listed
+func subcommitteeChair(committee sejm.Committee) string {
+	if committee.Members == nil {
+		return ""
+	}
+	for _, member := range *committee.Members {
+		if committeeMemberRole(stringValue(member.Function)) == "chairman" {
+			return stringValue(member.LastFirstName)
+		}
+	}
+	return ""
+}
+
+func (s *SejmServer) handleGetSubcommittees(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_subcommittees called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	committeeCode := strings.ToUpper(strings.TrimSpace(request.GetString("committee_code", "")))
+	subcommitteeCode := strings.ToUpper(strings.TrimSpace(request.GetString("subcommittee_code", "")))
+	includeSittings := request.GetString("include_sittings", "false") == "true"
+
+	if subcommitteeCode != "" {
+		return s.subcommitteeDetails(ctx, term, committeeCode, subcommitteeCode, includeSittings)
+	}
+	if committeeCode != "" {
+		return s.committeeSubcommittees(ctx, term, committeeCode)
+	}
+
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/committees", s.sejmBaseURL, term), nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve committees from Polish Parliament API: %v. Please try again.", err)), nil
+	}
+	var committees []sejm.Committee
+	if err := s.decodeAPIResponse(data, &committees); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse committees data: %v.", err)), nil
+	}
+
+	var results []string
+	total, parents := 0, 0
+	for _, committee := range committees {
+		if committee.SubCommittees == nil || len(*committee.SubCommittees) == 0 {
+			continue
+		}
+		parents++
+		total += len(*committee.SubCommittees)
+		results = append(results, fmt.Sprintf("• %s (%s): %s", stringValue(committee.Name), stringValue(committee.Code), strings.Join(*committee.SubCommittees, ", ")))
+	}
+	if total == 0 {
+		response := StandardResponse{
+			Operation:   "Subcommittees",
+			Status:      "No Results Found",
+			Summary:     []string{fmt.Sprintf("No committee lists subcommittees in term %d", term)},
+			NextActions: []string{"List committees: sejm_get_committees"},
+		}
+		return mcp.NewToolResultText(response.Format()), nil
+	}
+
+	response := StandardResponse{
+		Operation: "Subcommittees",
+		Status:    "Retrieved Successfully",
+		Summary: []string{
+			fmt.Sprintf("Term %d: %d subcommittees in %d of %d committees", term, total, parents, len(committees)),
+		},
+		Data: results,
+		NextActions: []string{
+			"Names, chairs and sizes of one committee's subcommittees: sejm_get_subcommittees with committee_code",
+			"Members and sittings of a subcommittee: sejm_get_subcommittees with subcommittee_code and include_sittings='true'",
+		},
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
+
+// committeeSubcommittees lists the subcommittees of one committee with their names, chairs and sizes
+func (s *SejmServer) committeeSubcommittees(ctx context.Context, term int, committeeCode string) (*mcp.CallToolResult, error) {
+	parent, err := s.fetchCommittee(ctx, term, committeeCode)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve committee %s: %v. Please verify the committee code exists.", committeeCode, err)), nil
+	}
+	parentName := fmt.Sprintf("%s (%s)", valueOrDefault(stringValue(parent.Name), committeeCode), committeeCode)
+	if parent.SubCommittees == nil || len(*parent.SubCommittees) == 0 {
+		response := StandardResponse{
+			Operation:   "Subcommittees",
+			Status:      "No Results Found",
+			Summary:     []string{fmt.Sprintf("%s has no subcommittees in term %d", parentName, term)},
+			NextActions: []string{"Committees with subcommittees: sejm_get_subcommittees without committee_code"},
+		}
+		return mcp.NewToolResultText(response.Format()), nil
+	}
+
+	codes := *parent.SubCommittees
+	subcommittees, errs := s.fetchCommittees(ctx, term, codes)
+	var results []string
+	for i, code := range codes {
+		if errs[i] != nil {
+			results = append(results, fmt.Sprintf("• %s: details not available (%v)", code, errs[i]))
+			continue
+		}
+		subcommittee := subcommittees[i]
+		line := fmt.Sprintf("• %s: %s", code, valueOrDefault(stringValue(subcommittee.Name), "No name"))
+		var details []string
+		if subcommittee.Members != nil {
+			details = append(details, fmt.Sprintf("%d members", len(*subcommittee.Members)))
+		}
+		if chair := subcommitteeChair(subcommittee); chair != "" {
+			details = append(details, "chair: "+chair)
+		}
+		if subcommittee.AppointmentDate != nil {
+			details = append(details, "appointed "+subcommittee.AppointmentDate.Format("2006-01-02"))
+		}
+		if len(details) > 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(details, ", "))
+		}
+		results = append(results, line)
+	}
+
+	response := StandardResponse{
+		Operation: "Subcommittees",
+		Status:    "Retrieved Successfully",
+		Summary: []string{
+			fmt.Sprintf("Parent committee: %s, term %d", parentName, term),
+			fmt.Sprintf("Subcommittees: %d", len(codes)),
+		},
+		Data: results,
+		NextActions: []string{
+			fmt.Sprintf("Members and sittings of a subcommittee: sejm_get_subcommittees with committee_code='%s', subcommittee_code and include_sittings='true'", committeeCode),
+			fmt.Sprintf("Parent committee members: sejm_get_committee_members with committee_code='%s'", committeeCode),
+		},
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
+
+// subcommitteeDetails shows one subcommittee's members and, optionally, its sittings
+func (s *SejmServer) subcommitteeDetails(ctx context.Context, term int, parentCode, code string, includeSittings bool) (*mcp.CallToolResult, error) {
+	subcommittee, err := s.fetchCommittee(ctx, term, code)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve subcommittee %s: %v. Get subcommittee codes from sejm_get_subcommittees.", code, err)), nil
+	}
+
+	// The subcommittee record does not name its parent; find the committee that lists it
+	if parentCode == "" {
+		if data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/committees", s.sejmBaseURL, term), nil); err == nil {
+			var committees []sejm.Committee
+			if s.decodeAPIResponse(data, &committees) == nil {
+				for _, committee := range committees {
+					if committee.SubCommittees != nil && containsString(*committee.SubCommittees, code) {
+						parentCode = stringValue(committee.Code)
+						break
+					}
+				}
+			}
+		}
+	}
+
+	summary := []string{fmt.Sprintf("Subcommittee: %s (%s), term %d", valueOrDefault(stringValue(subcommittee.Name), "No name"), code, term)}
+	if parentCode != "" {
+		summary = append(summary, "Parent committee: "+parentCode)
+	}
+	if subcommittee.AppointmentDate != nil {
+		summary = append(summary, "Appointed: "+subcommittee.AppointmentDate.Format("2006-01-02"))
+	}
+	if subcommittee.Scope != nil && *subcommittee.Scope != "" {
+		summary = append(summary, "Scope: "+truncateRunes(*subcommittee.Scope, 500))
+	}
+
+	var results []string
+	members := []sejm.Member{}
+	if subcommittee.Members != nil {
+		members = sortedCommitteeMembers(*subcommittee.Members)
+	}
+	summary = append(summary, fmt.Sprintf("Members: %d", len(members)))
+	results = append(results, "Members:")
+	for _, member := range members {
+		line := "  • " + valueOrDefault(stringValue(member.LastFirstName), "Unknown")
+		if member.Club != nil {
+			line += fmt.Sprintf(" (%s)", *member.Club)
+		}
+		if member.Function != nil && *member.Function != "" {
+			line += fmt.Sprintf(" – %s", *member.Function)
+		}
+		if member.Id != nil {
+			line += fmt.Sprintf(" [MP ID: %d]", *member.Id)
+		}
+		results = append(results, line)
+	}
+
+	var note string
+	if includeSittings {
+		results = append(results, "", "Sittings:")
+		data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/committees/%s/sittings", s.sejmBaseURL, term, code), nil)
+		var sittings []sejm.CommitteeSitting
+		if err == nil {
+			err = s.decodeAPIResponse(data, &sittings)
+		}
+		switch {
+		case err != nil:
+			results = append(results, "  Not available")
+			note = fmt.Sprintf("Sitting data could not be retrieved for this subcommittee: %v", err)
+		case len(sittings) == 0:
+			results = append(results, "  No sittings recorded")
+		default:
+			sort.SliceStable(sittings, func(i, j int) bool {
+				return sittings[i].Num != nil && sittings[j].Num != nil && *sittings[i].Num > *sittings[j].Num
+			})
+			summary = append(summary, fmt.Sprintf("Sittings: %d", len(sittings)))
+			for i, sitting := range sittings {
+				if i == maxListedSubcommitteeSittings {
+					results = append(results, fmt.Sprintf("  ... and %d earlier sittings", len(sittings)-i))
+					break
+				}
+				line := "  •"
+				if sitting.Num != nil {
+					line += fmt.Sprintf(" #%d", *sitting.Num)
+				}
+				if sitting.Date != nil {
+					line += " " + sitting.Date.Format("2006-01-02")
+				}
+				if sitting.Agenda != nil {
+					line += ": " + truncateRunes(strings.Join(strings.Fields(htmlToPlainText(*sitting.Agenda)), " "), 200)
+				}
+				results = append(results, line)
+			}
+		}
+	}
+
+	nextActions := []string{
+		fmt.Sprintf("Sitting details: sejm_get_committee_sitting_details with committee_code='%s' and sitting_number", code),
+		fmt.Sprintf("Sitting transcript: sejm_get_committee_transcript with committee_code='%s' and sitting_number", code),
+	}
+	if !includeSittings {
+		nextActions = append([]string{"List the subcommittee's sittings: include_sittings='true'"}, nextActions...)
+	}
+
+	response := StandardResponse{
+		Operation:   "Subcommittee Details",
+		Status:      "Retrieved Successfully",
+		Summary:     summary,
+		Data:        results,
+		NextActions: nextActions,
+		Note:        note,
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestHandleGetSubcommittees(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/committees": `[
+			{"code": "ASW", "name": "Komisja Administracji i Spraw Wewnętrznych", "subCommittees": ["ASW01S", "ASW02S"]},
+			{"code": "ENM", "name": "Komisja Edukacji i Nauki"}
+		]`,
+		"/sejm/term10/committees/ASW": `{"code": "ASW", "name": "Komisja Administracji i Spraw Wewnętrznych", "subCommittees": ["ASW01S", "ASW02S"]}`,
+		"/sejm/term10/committees/ASW01S": `{"code": "ASW01S", "name": "Podkomisja stała do spraw samorządu", "appointmentDate": "2024-01-10",
+			"members": [
+				{"id": 3, "lastFirstName": "Zielińska Anna", "club": "KO"},
+				{"id": 1, "lastFirstName": "Kowalski Jan", "club": "PiS", "function": "przewodniczący"},
+				{"id": 2, "lastFirstName": "Adamski Piotr", "club": "Lewica"}
+			]}`,
+		"/sejm/term10/committees/ASW02S": `{"code": "ASW02S", "name": "Podkomisja stała do spraw policji", "members": []}`,
+		"/sejm/term10/committees/ASW01S/sittings": `[
+			{"num": 1, "date": "2024-02-01", "agenda": "<p>Rozpatrzenie projektu ustawy</p>"},
+			{"num": 2, "date": "2024-03-05", "agenda": "Sprawy bieżące"}
+		]`,
+	})
+
+	result, err := server.handleGetSubcommittees(context.Background(), createMockRequest(map[string]interface{}{"term": "10"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Term 10: 2 subcommittees in 1 of 2 committees",
+		"• Komisja Administracji i Spraw Wewnętrznych (ASW): ASW01S, ASW02S",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+
+	result, _ = server.handleGetSubcommittees(context.Background(), createMockRequest(map[string]interface{}{"term": "10", "committee_code": "asw"}))
+	content = extractTextContent(result)
+	for _, expected := range []string{
+		"• ASW01S: Podkomisja stała do spraw samorządu (3 members, chair: Kowalski Jan, appointed 2024-01-10)",
+		"• ASW02S: Podkomisja stała do spraw policji (0 members)",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+
+	result, _ = server.handleGetSubcommittees(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "subcommittee_code": "ASW01S", "include_sittings": "true",
+	}))
+	content = extractTextContent(result)
+	for _, expected := range []string{
+		"Parent committee: ASW",
+		"Members: 3",
+		"Sittings: 2",
+		"• #2 2024-03-05: Sprawy bieżące",
+		"• #1 2024-02-01: Rozpatrzenie projektu ustawy",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+	chair := strings.Index(content, "Kowalski Jan")
+	if chair < 0 || chair > strings.Index(content, "Adamski Piotr") || strings.Index(content, "Adamski Piotr") > strings.Index(content, "Zielińska Anna") {
+		t.Errorf("Expected the chair first and other members by name, got: %s", content)
+	}
+}
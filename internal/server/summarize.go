@@ -0,0 +1,270 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultSummarySentences is the number of sentences returned when summary_sentences is not given
+const defaultSummarySentences = 15
+
+// maxSummarySentences caps the summary_sentences parameter
+const maxSummarySentences = 50
+
+// Sentences outside these word counts are headers, page numbers or run-on fragments and are not selected
+const (
+	minSummarySentenceWords = 6
+	maxSummarySentenceWords = 80
+)
+
+// maxSummarySentenceChars limits the length of a quoted sentence in the summary
+const maxSummarySentenceChars = 500
+
+// paragraphBreakPattern matches blank lines separating paragraphs
+var paragraphBreakPattern = regexp.MustCompile(`\n\s*\n`)
+
+// sentenceBoundaryPattern marks the end of a sentence followed by the start of another (capitalized) one
+var sentenceBoundaryPattern = regexp.MustCompile(`([.!?…])\s+(\p{Lu})`)
+
+// summaryStopwords are frequent Polish words that carry no topical information
+var summaryStopwords = map[string]bool{
+	"albo": true, "ale": true, "ani": true, "aby": true, "bez": true, "bardzo": true, "będzie": true, "będą": true,
+	"był": true, "była": true, "było": true, "były": true, "być": true, "czy": true, "dla": true, "do": true,
+	"gdy": true, "gdyż": true, "jak": true, "jako": true, "jest": true, "jego": true, "jej": true, "jeśli": true,
+	"jeżeli": true, "już": true, "ich": true, "lub": true, "ma": true, "mają": true, "może": true, "można": true,
+	"nad": true, "nie": true, "nich": true, "niż": true, "oraz": true, "one": true, "ono": true, "pod": true,
+	"przez": true, "przy": true, "się": true, "są": true, "tak": true, "także": true, "tego": true, "tej": true,
+	"ten": true, "też": true, "to": true, "tym": true, "tylko": true, "który": true, "która": true,
+	"które": true, "którego": true, "której": true, "których": true, "którym": true, "którzy": true, "więc": true,
+	"właśnie": true, "wszystko": true, "wtedy": true, "zaś": true, "że": true, "żeby": true, "również": true,
+	"proszę": true, "panie": true, "pani": true, "państwo": true, "marszałku": true, "dziękuję": true,
+	"art": true, "ust": true, "pkt": true, "lit": true, "poz": true,
+}
+
+// summarySentence is a sentence selected for an extractive summary
+type summarySentence struct {
+	Page  int
+	Index int
+	Text  string
+	Score float64
+}
+
+// splitSentences splits text into sentences, treating blank lines as hard boundaries and joining wrapped lines
+func splitSentences(text string) []string {
+	var sentences []string
+	for _, paragraph := range paragraphBreakPattern.Split(text, -1) {
+		paragraph = strings.Join(strings.Fields(paragraph), " ")
+		if paragraph == "" {
+			continue
+		}
+		marked := sentenceBoundaryPattern.ReplaceAllString(paragraph, "$1\n$2")
+		for _, sentence := range strings.Split(marked, "\n") {
+			if sentence = strings.TrimSpace(sentence); sentence != "" {
+				sentences = append(sentences, sentence)
+			}
+		}
+	}
+	return sentences
+}
+
+// summaryTokens returns the lowercase content words of a sentence
+func summaryTokens(sentence string) []string {
+	words := strings.FieldsFunc(strings.ToLower(sentence), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	tokens := words[:0]
+	for _, word := range words {
+		if len([]rune(word)) >= 3 && !summaryStopwords[word] {
+			tokens = append(tokens, word)
+		}
+	}
+	return tokens
+}
+
+// extractiveSummary selects the most representative sentences of a document using word frequencies.
+// Each sentence is scored by the summed normalized frequency of its content words divided by the square
+// root of their count; sentences repeated in the document (running headers, footers) are skipped. The result is returned in document order
+// together with the number of candidate sentences.
+func extractiveSummary(pageTexts []string, maxSentences int) ([]summarySentence, int) {
+	type candidate struct {
+		summarySentence
+		tokens []string
+	}
+
+	var candidates []candidate
+	occurrences := make(map[string]int)
+	frequencies := make(map[string]int)
+	for pageNum, pageText := range pageTexts {
+		for _, sentence := range splitSentences(pageText) {
+			occurrences[sentence]++
+			wordCount := len(strings.Fields(sentence))
+			if wordCount < minSummarySentenceWords || wordCount > maxSummarySentenceWords {
+				continue
+			}
+			tokens := summaryTokens(sentence)
+			if len(tokens) == 0 {
+				continue
+			}
+			for _, token := range tokens {
+				frequencies[token]++
+			}
+			candidates = append(candidates, candidate{
+				summarySentence: summarySentence{Page: pageNum + 1, Index: len(candidates), Text: sentence},
+				tokens:          tokens,
+			})
+		}
+	}
+
+	maxFrequency := 0
+	for _, frequency := range frequencies {
+		if frequency > maxFrequency {
+			maxFrequency = frequency
+		}
+	}
+
+	var scored []summarySentence
+	for _, c := range candidates {
+		if occurrences[c.Text] > 1 {
+			continue
+		}
+		total := 0.0
+		for _, token := range c.tokens {
+			total += float64(frequencies[token]) / float64(maxFrequency)
+		}
+		// Dampen the length penalty so informative longer sentences are not outscored by short ones
+		c.Score = total / math.Sqrt(float64(len(c.tokens)))
+		scored = append(scored, c.summarySentence)
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+	if len(scored) > maxSentences {
+		scored = scored[:maxSentences]
+	}
+	sort.Slice(scored, func(i, j int) bool {
+		return scored[i].Index < scored[j].Index
+	})
+	return scored, len(candidates)
+}
+
+// parseSummarySentences parses the summary_sentences parameter with the usual clamping
+func parseSummarySentences(value string) int {
+	count := defaultSummarySentences
+	if value != "" {
+		if parsed, err := fmt.Sscanf(value, "%d", &count); parsed != 1 || err != nil || count < 1 {
+			count = defaultSummarySentences
+		}
+	}
+	if count > maxSummarySentences {
+		count = maxSummarySentences
+	}
+	return count
+}
+
+// summaryResult builds the response for summarize='true'. Every quoted sentence points to its page;
+// unit names the kind of page ('page' for PDF pages, 'section' for virtual pages) and pageHint
+// describes how to read the full text of a page.
+func summaryResult(documentName string, pageTexts []string, maxSentences int, unit string, pageHint func(page int) string) *mcp.CallToolResult {
+	sentences, candidates := extractiveSummary(pageTexts, maxSentences)
+	if len(sentences) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Could not summarize %s: no extractable sentences were found. The document may be scanned or empty; read it page by page instead.", documentName))
+	}
+
+	var data []string
+	pageMentions := make(map[int]int)
+	for _, sentence := range sentences {
+		text := sentence.Text
+		if len(text) > maxSummarySentenceChars {
+			text = truncateRunes(text, maxSummarySentenceChars) + "…"
+		}
+		data = append(data, fmt.Sprintf("[%s %d] %s", unit, sentence.Page, text))
+		pageMentions[sentence.Page]++
+	}
+
+	pages := make([]int, 0, len(pageMentions))
+	for page := range pageMentions {
+		pages = append(pages, page)
+	}
+	sort.Slice(pages, func(i, j int) bool {
+		if pageMentions[pages[i]] != pageMentions[pages[j]] {
+			return pageMentions[pages[i]] > pageMentions[pages[j]]
+		}
+		return pages[i] < pages[j]
+	})
+
+	var nextActions []string
+	for i, page := range pages {
+		if i >= 3 {
+			break
+		}
+		nextActions = append(nextActions, fmt.Sprintf("Read %s %d in full (%d summary sentences): %s", unit, page, pageMentions[page], pageHint(page)))
+	}
+
+	response := StandardResponse{
+		Operation: "Document Summary",
+		Status:    "Retrieved Successfully",
+		Summary: []string{
+			fmt.Sprintf("Document: %s", documentName),
+			fmt.Sprintf("Total %ss: %d", unit, len(pageTexts)),
+			fmt.Sprintf("Sentences analyzed: %d", candidates),
+			fmt.Sprintf("Summary sentences: %d", len(sentences)),
+		},
+		Data:        data,
+		NextActions: nextActions,
+		Note:        fmt.Sprintf("Extractive summary: sentences are quoted verbatim and chosen by word frequency, without any generated text. Each sentence is prefixed with the %s it comes from.", unit),
+	}
+	return mcp.NewToolResultText(response.Format())
+}
+
+// truncateRunes shortens text to at most limit bytes without splitting a UTF-8 character
+func truncateRunes(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	for limit > 0 && !utf8.RuneStart(text[limit]) {
+		limit--
+	}
+	return text[:limit]
+}
+
+// summarizeActText summarizes a legal act, preferring the PDF so that summary sentences point to
+// pages readable with eli_get_act_text; HTML-only acts are split into virtual sections instead
+func (s *SejmServer) summarizeActText(ctx context.Context, publisher, year, position string, htmlAvailable, pdfAvailable bool, maxSentences int) (*mcp.CallToolResult, error) {
+	documentName := fmt.Sprintf("%s/%s/%s", publisher, year, position)
+
+	if pdfAvailable {
+		pdfData, err := s.makeTextRequest(ctx, fmt.Sprintf("%s/acts/%s/%s/%s/text.pdf", s.eliBaseURL, publisher, year, position), "pdf")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve PDF for summarization: %v", err)), nil
+		}
+		pageTexts, err := s.extractPDFPageTexts(ctx, pdfData)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to extract legal act text: %v", err)), nil
+		}
+		return summaryResult(documentName, pageTexts, maxSentences, "page", func(page int) string {
+			return fmt.Sprintf("eli_get_act_text with publisher='%s', year='%s', position='%s', format='text', page='%d', pages_per_chunk='1'", publisher, year, position, page)
+		}), nil
+	}
+
+	if htmlAvailable {
+		htmlData, err := s.makeTextRequest(ctx, fmt.Sprintf("%s/acts/%s/%s/%s/text.html", s.eliBaseURL, publisher, year, position), "html")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve HTML for summarization: %v", err)), nil
+		}
+		pageTexts := splitTextIntoPages(normalizeExtractedText(htmlToPlainText(string(htmlData))), documentPageChars)
+		return summaryResult(documentName, pageTexts, maxSentences, "section", func(page int) string {
+			return fmt.Sprintf("eli_get_act_text with publisher='%s', year='%s', position='%s' (section %d of %d, about %d%% into the HTML text) or eli_search_act_content with a phrase from the sentence", publisher, year, position, page, len(pageTexts), (page-1)*100/len(pageTexts))
+		}), nil
+	}
+
+	return mcp.NewToolResultError(fmt.Sprintf("No text formats available for legal act %s. This document does not have HTML or PDF text available for summarization.", documentName)), nil
+}
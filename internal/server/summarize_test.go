@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSplitSentences(t *testing.T) {
+	text := "Pierwsze zdanie kończy się tutaj. Drugie zdanie\nprzechodzi do nowej linii! art. 5 ust. 2 nie dzieli zdania.\n\nNowy akapit bez kropki"
+	sentences := splitSentences(text)
+	expected := []string{
+		"Pierwsze zdanie kończy się tutaj.",
+		"Drugie zdanie przechodzi do nowej linii! art. 5 ust. 2 nie dzieli zdania.",
+		"Nowy akapit bez kropki",
+	}
+	if strings.Join(sentences, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected %q, got %q", expected, sentences)
+	}
+}
+
+func TestExtractiveSummary(t *testing.T) {
+	header := "Dziennik Ustaw Rzeczypospolitej Polskiej strona numer porządkowy dokumentu."
+	pages := []string{
+		header + "\n\nUstawa reguluje podatek dochodowy od osób fizycznych oraz podatek rolny. Krótkie zdanie.",
+		header + "\n\nPogoda w dniu posiedzenia była wyjątkowo słoneczna i ciepła dla wszystkich.",
+		header + "\n\nPodatek dochodowy od osób fizycznych pobiera się według skali podatkowej ustawy.",
+	}
+
+	sentences, candidates := extractiveSummary(pages, 2)
+	if candidates != 6 {
+		t.Errorf("Expected 6 candidate sentences, got %d", candidates)
+	}
+	if len(sentences) != 2 {
+		t.Fatalf("Expected 2 summary sentences, got %+v", sentences)
+	}
+	if sentences[0].Page != 1 || sentences[1].Page != 3 {
+		t.Errorf("Expected sentences about taxes from pages 1 and 3 in document order, got %+v", sentences)
+	}
+	for _, sentence := range sentences {
+		if sentence.Text == header {
+			t.Errorf("Repeated header should not be selected: %+v", sentences)
+		}
+	}
+}
+
+func TestParseSummarySentences(t *testing.T) {
+	testCases := map[string]int{"": 15, "5": 5, "0": 15, "abc": 15, "500": 50}
+	for input, expected := range testCases {
+		if got := parseSummarySentences(input); got != expected {
+			t.Errorf("parseSummarySentences(%q) = %d, expected %d", input, got, expected)
+		}
+	}
+}
+
+func TestHandleGetPrintTextSummarize(t *testing.T) {
+	body := "Projekt ustawy zmienia zasady finansowania szpitali powiatowych w całym kraju. " +
+		"Uzasadnienie wskazuje, że finansowanie szpitali powiatowych wymaga pilnej zmiany przepisów ustawy. " +
+		"Na marginesie warto wspomnieć o zupełnie innej sprawie dotyczącej pogody i klimatu."
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/prints/400":         `{"number": "400", "attachments": ["400.txt"]}`,
+		"/sejm/term10/prints/400/400.txt": body,
+	})
+
+	result, err := server.handleGetPrintText(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "num": "400", "summarize": "true", "summary_sentences": "2",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Document Summary",
+		"Summary sentences: 2",
+		"[page 1] Projekt ustawy zmienia zasady finansowania szpitali",
+		"sejm_get_print_text with term='10', num='400', attach_name='400.txt', page='1'",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+	if strings.Contains(content, "pogody") {
+		t.Errorf("Off-topic sentence should not be in the summary, got: %s", content)
+	}
+}
@@ -0,0 +1,292 @@
+package server
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Match modes of the content search tools
+const (
+	matchSubstring = "substring"
+	matchWord      = "word"
+	matchStem      = "stem"
+	matchRegex     = "regex"
+)
+
+const (
+	// minPolishStemRunes is the shortest stem left after removing an inflectional suffix
+	minPolishStemRunes = 3
+	// maxPolishEndingRunes bounds the ending a stem may take, so 'ustawa' finds 'ustawami' but not 'ustawodawca'
+	maxPolishEndingRunes = 5
+)
+
+// Parameter descriptions shared by the content search tools
+const (
+	matchModeParamDescription        = "Optional. How terms are matched: 'substring' (default, the term anywhere in the text), 'word' (whole words only, so 'VAT' does not match 'prywatny'), 'stem' (inflected Polish forms: 'ustawa' also finds 'ustawy', 'ustawie', 'ustawą') or 'regex' (search_terms is one regular expression, e.g. 'art\\. 1[0-9]+'; commas are part of the pattern). Matching is case-insensitive and spaces in a term match any whitespace, including line breaks."
+	ignoreDiacriticsParamDescription = "Optional. 'true' (default) to ignore Polish diacritics, so 'zrodlo' matches 'źródło' and 'ustawa' matches 'ustawą'; 'false' to require them exactly."
+)
+
+// diacriticFolds maps lowercase letters with diacritics to their base letter; Polish letters come first
+var diacriticFolds = map[rune]rune{
+	'ą': 'a', 'ć': 'c', 'ę': 'e', 'ł': 'l', 'ń': 'n', 'ó': 'o', 'ś': 's', 'ź': 'z', 'ż': 'z',
+	'á': 'a', 'à': 'a', 'â': 'a', 'ä': 'a', 'ã': 'a', 'å': 'a', 'č': 'c', 'ç': 'c', 'ď': 'd',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e', 'ě': 'e', 'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ň': 'n', 'ñ': 'n', 'ò': 'o', 'ô': 'o', 'ö': 'o', 'õ': 'o', 'ő': 'o', 'ř': 'r', 'š': 's',
+	'ť': 't', 'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u', 'ů': 'u', 'ű': 'u', 'ý': 'y', 'ÿ': 'y', 'ž': 'z',
+}
+
+// polishSuffixes are common inflectional endings of Polish nouns, adjectives and verbal nouns, longest first
+var polishSuffixes = []string{
+	"owaniami", "owaniach", "ościami", "ościach", "owania", "owaniu", "owanie", "ością",
+	"ości", "ość", "ami", "ach", "owi", "ego", "emu", "ymi", "imi", "ych", "ich", "iej", "ową", "owa", "owe", "owy",
+	"ej", "om", "ów", "ie", "ia", "ii", "ią", "ię", "ym", "im",
+	"ą", "ę", "y", "a", "e", "i", "o", "u",
+}
+
+// textSearchOptions controls how search terms match page text
+type textSearchOptions struct {
+	Mode             string
+	IgnoreDiacritics bool
+}
+
+// describe names the matching rules for response summaries
+func (o textSearchOptions) describe() string {
+	if o.IgnoreDiacritics {
+		return o.Mode + ", case- and diacritics-insensitive"
+	}
+	return o.Mode + ", case-insensitive"
+}
+
+// parseTextSearchOptions reads the match_mode and ignore_diacritics parameters
+func parseTextSearchOptions(request mcp.CallToolRequest) (textSearchOptions, error) {
+	options := textSearchOptions{
+		Mode:             strings.ToLower(strings.TrimSpace(request.GetString("match_mode", matchSubstring))),
+		IgnoreDiacritics: request.GetString("ignore_diacritics", "true") != "false",
+	}
+	switch options.Mode {
+	case matchSubstring, matchWord, matchStem, matchRegex:
+		return options, nil
+	}
+	return options, fmt.Errorf("invalid match_mode '%s'. Use 'substring', 'word', 'stem' or 'regex'", options.Mode)
+}
+
+// splitSearchTerms splits comma-separated search terms; in regex mode the whole value is a single pattern
+func splitSearchTerms(searchTerms string, options textSearchOptions) []string {
+	if options.Mode == matchRegex {
+		if pattern := strings.TrimSpace(searchTerms); pattern != "" {
+			return []string{pattern}
+		}
+		return nil
+	}
+	var terms []string
+	for _, term := range strings.Split(searchTerms, ",") {
+		if cleaned := strings.TrimSpace(term); cleaned != "" {
+			terms = append(terms, cleaned)
+		}
+	}
+	return terms
+}
+
+// foldRune lowercases a rune and, when requested, removes its diacritics
+func foldRune(r rune, diacritics bool) rune {
+	r = unicode.ToLower(r)
+	if diacritics {
+		if base, ok := diacriticFolds[r]; ok {
+			return base
+		}
+	}
+	return r
+}
+
+// foldText lowercases text and optionally removes diacritics. Every rune is folded to exactly one rune, and
+// offsets maps each byte of the folded text, plus its end, to the byte of the original text it came from.
+func foldText(text string, diacritics bool) (string, []int) {
+	var folded strings.Builder
+	folded.Grow(len(text))
+	offsets := make([]int, 0, len(text)+1)
+	for i, r := range text {
+		n, _ := folded.WriteRune(foldRune(r, diacritics))
+		for k := 0; k < n; k++ {
+			offsets = append(offsets, i)
+		}
+	}
+	offsets = append(offsets, len(text))
+	return folded.String(), offsets
+}
+
+// stripDiacritics removes diacritics without changing letter case, so regular expression escapes like \D and
+// \S keep their meaning
+func stripDiacritics(pattern string) string {
+	return strings.Map(func(r rune) rune {
+		base, ok := diacriticFolds[unicode.ToLower(r)]
+		if !ok {
+			return r
+		}
+		if unicode.IsUpper(r) {
+			return unicode.ToUpper(base)
+		}
+		return base
+	}, pattern)
+}
+
+// stemPolishWord removes the longest known inflectional suffix that leaves a stem of at least three letters
+func stemPolishWord(word string) string {
+	for _, suffix := range polishSuffixes {
+		if strings.HasSuffix(word, suffix) && utf8.RuneCountInString(word)-utf8.RuneCountInString(suffix) >= minPolishStemRunes {
+			return strings.TrimSuffix(word, suffix)
+		}
+	}
+	return word
+}
+
+// isWordRune tells whether a rune is part of a word for whole-word matching
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// textMatcher finds one search term in page texts
+type textMatcher struct {
+	pattern    *regexp.Regexp
+	diacritics bool
+	// wordStart and wordEnd require the match to begin and end at a word boundary
+	wordStart bool
+	wordEnd   bool
+}
+
+// newTextMatcher compiles a search term for the given options
+func newTextMatcher(term string, options textSearchOptions) (*textMatcher, error) {
+	matcher := &textMatcher{diacritics: options.IgnoreDiacritics}
+	var expression string
+	switch options.Mode {
+	case matchRegex:
+		expression = term
+		if options.IgnoreDiacritics {
+			expression = stripDiacritics(expression)
+		}
+		expression = "(?i)" + expression
+	default:
+		folded, _ := foldText(term, options.IgnoreDiacritics)
+		words := strings.Fields(folded)
+		for i, word := range words {
+			if options.Mode == matchStem && utf8.RuneCountInString(word) > minPolishStemRunes && !strings.ContainsFunc(word, func(r rune) bool { return !unicode.IsLetter(r) }) {
+				words[i] = regexp.QuoteMeta(stemPolishWord(word)) + fmt.Sprintf(`\pL{0,%d}`, maxPolishEndingRunes)
+			} else {
+				words[i] = regexp.QuoteMeta(word)
+			}
+		}
+		expression = strings.Join(words, `\s+`)
+		matcher.wordStart = options.Mode == matchWord || options.Mode == matchStem
+		matcher.wordEnd = options.Mode == matchWord || options.Mode == matchStem
+	}
+
+	pattern, err := regexp.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression '%s': %v", term, err)
+	}
+	if pattern.MatchString("") {
+		return nil, fmt.Errorf("search term '%s' matches empty text", term)
+	}
+	matcher.pattern = pattern
+	return matcher, nil
+}
+
+// find returns the byte ranges of up to limit matches in text
+func (m *textMatcher) find(text string, limit int) [][2]int {
+	folded, offsets := foldText(text, m.diacritics)
+	var matches [][2]int
+	for position := 0; position < len(folded) && len(matches) < limit; {
+		location := m.pattern.FindStringIndex(folded[position:])
+		if location == nil {
+			break
+		}
+		start, end := position+location[0], position+location[1]
+		if m.atBoundaries(folded, start, end) {
+			matches = append(matches, [2]int{offsets[start], offsets[end]})
+			position = end
+			continue
+		}
+		// Retry from the next rune, a match inside a longer word may be followed by a proper one
+		_, size := utf8.DecodeRuneInString(folded[start:])
+		position = start + size
+	}
+	return matches
+}
+
+// atBoundaries checks the word boundaries the match mode requires
+func (m *textMatcher) atBoundaries(text string, start, end int) bool {
+	if m.wordStart && start > 0 {
+		if r, _ := utf8.DecodeLastRuneInString(text[:start]); isWordRune(r) {
+			return false
+		}
+	}
+	if m.wordEnd && end < len(text) {
+		if r, _ := utf8.DecodeRuneInString(text[end:]); isWordRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// textSearchMatch is a match of a search term with its 1-based page and highlighted context
+type textSearchMatch struct {
+	Page    int
+	Context string
+}
+
+// matchContext returns about contextChars bytes of text around a match, with the match in bold and whitespace
+// collapsed; the cut points are moved to rune boundaries
+func matchContext(text string, start, end, contextChars int) string {
+	contextStart := start - contextChars/2
+	if contextStart < 0 {
+		contextStart = 0
+	}
+	for contextStart > 0 && !utf8.RuneStart(text[contextStart]) {
+		contextStart--
+	}
+	contextEnd := end + contextChars/2
+	if contextEnd > len(text) {
+		contextEnd = len(text)
+	}
+	for contextEnd < len(text) && !utf8.RuneStart(text[contextEnd]) {
+		contextEnd++
+	}
+	context := text[contextStart:start] + "**" + text[start:end] + "**" + text[end:contextEnd]
+	return strings.Join(strings.Fields(context), " ")
+}
+
+// searchTermsInPages finds up to maxMatches matches of every term in page texts. It returns the matches by term
+// and their total; an invalid term is an error.
+func searchTermsInPages(pageTexts, terms []string, options textSearchOptions, contextChars, maxMatches int) (map[string][]textSearchMatch, int, error) {
+	matchers := make([]*textMatcher, len(terms))
+	for i, term := range terms {
+		matcher, err := newTextMatcher(term, options)
+		if err != nil {
+			return nil, 0, err
+		}
+		matchers[i] = matcher
+	}
+
+	termMatches := make(map[string][]textSearchMatch)
+	total := 0
+	for pageNum, pageText := range pageTexts {
+		for i, term := range terms {
+			remaining := maxMatches - len(termMatches[term])
+			if remaining <= 0 {
+				continue
+			}
+			for _, location := range matchers[i].find(pageText, remaining) {
+				termMatches[term] = append(termMatches[term], textSearchMatch{
+					Page:    pageNum + 1,
+					Context: matchContext(pageText, location[0], location[1], contextChars),
+				})
+				total++
+			}
+		}
+	}
+	return termMatches, total, nil
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestStemPolishWord(t *testing.T) {
+	testCases := map[string]string{
+		"ustawy":     "ustaw",
+		"ustawa":     "ustaw",
+		"ustawach":   "ustaw",
+		"podatkowej": "podatkow",
+		"wolności":   "woln",
+		"sąd":        "sąd",
+		"kary":       "kar",
+	}
+	for word, expected := range testCases {
+		if stem := stemPolishWord(word); stem != expected {
+			t.Errorf("stemPolishWord(%q) = %q, expected %q", word, stem, expected)
+		}
+	}
+}
+
+func TestFoldTextOffsets(t *testing.T) {
+	text := "Źródło ŁĄKI"
+	folded, offsets := foldText(text, true)
+	if folded != "zrodlo laki" {
+		t.Fatalf("Unexpected folded text %q", folded)
+	}
+	if len(offsets) != len(folded)+1 || offsets[len(folded)] != len(text) {
+		t.Fatalf("Offsets must cover the folded text and its end, got %v", offsets)
+	}
+	start := strings.Index(folded, "laki")
+	if original := text[offsets[start]:offsets[start+len("laki")]]; original != "ŁĄKI" {
+		t.Errorf("Expected the match to map back to 'ŁĄKI', got %q", original)
+	}
+}
+
+func TestTextMatcherModes(t *testing.T) {
+	text := "Projekt ustawy o zmianie ustawą, prywatny podatek VAT.\nArt. 15 i art. 152 ustawy z dnia"
+	testCases := []struct {
+		name    string
+		term    string
+		options textSearchOptions
+		matches []string
+	}{
+		{"substring keeps diacritics exact", "ustawa", textSearchOptions{Mode: matchSubstring}, nil},
+		{"substring ignores diacritics", "ustawa", textSearchOptions{Mode: matchSubstring, IgnoreDiacritics: true}, []string{"ustawą"}},
+		{"substring finds inner text", "vat", textSearchOptions{Mode: matchSubstring, IgnoreDiacritics: true}, []string{"VAT"}},
+		{"word skips inner text", "wat", textSearchOptions{Mode: matchWord, IgnoreDiacritics: true}, nil},
+		{"word matches whole words", "art. 15", textSearchOptions{Mode: matchWord, IgnoreDiacritics: true}, []string{"Art. 15"}},
+		{"stem finds inflected forms", "ustawa", textSearchOptions{Mode: matchStem, IgnoreDiacritics: true}, []string{"ustawy", "ustawą", "ustawy"}},
+		{"phrase spans line breaks", "VAT. art", textSearchOptions{Mode: matchSubstring, IgnoreDiacritics: true}, []string{"VAT.\nArt"}},
+		{"regex", `art\. 15\d`, textSearchOptions{Mode: matchRegex, IgnoreDiacritics: true}, []string{"art. 152"}},
+		{"regex ignores diacritics", `ustaw[ąa]`, textSearchOptions{Mode: matchRegex, IgnoreDiacritics: true}, []string{"ustawą"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matcher, err := newTextMatcher(tc.term, tc.options)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			var found []string
+			for _, location := range matcher.find(text, 10) {
+				found = append(found, text[location[0]:location[1]])
+			}
+			if strings.Join(found, "|") != strings.Join(tc.matches, "|") {
+				t.Errorf("Expected matches %q, got %q", tc.matches, found)
+			}
+		})
+	}
+
+	for _, term := range []string{"(unclosed", "a*"} {
+		if _, err := newTextMatcher(term, textSearchOptions{Mode: matchRegex}); err == nil {
+			t.Errorf("Expected an error for pattern %q", term)
+		}
+	}
+}
+
+func TestMatchContext(t *testing.T) {
+	text := "Zażółć gęślą jaźń,\n  ustawa   o podatku"
+	start := strings.Index(text, "ustawa")
+	context := matchContext(text, start, start+len("ustawa"), 20)
+	if context != "jaźń, **ustawa** o podat" {
+		t.Errorf("Unexpected context %q", context)
+	}
+
+	// A cut inside a two-byte letter moves to the start of the letter
+	context = matchContext(text, start, start+len("ustawa"), 14)
+	if !utf8.ValidString(context) || !strings.HasPrefix(context, "źń, **ustawa**") {
+		t.Errorf("Unexpected context %q", context)
+	}
+}
+
+func TestSearchPageTextsMatchModes(t *testing.T) {
+	server := NewSejmServer()
+	pages := []string{"Projekt ustawy o podatku", "Zmiana ustawą z dnia 1 marca", "ustawodawca ustawami"}
+
+	result, err := server.searchPageTexts("Document Content Search", pages, "print 1", "ustawa", textSearchOptions{Mode: matchStem, IgnoreDiacritics: true}, 100, 10)
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{"Total matches found: 3", "Matching: stem, case- and diacritics-insensitive", "**ustawy**", "**ustawą**", "ustawodawca **ustawami**"} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+
+	result, _ = server.searchPageTexts("Document Content Search", pages, "print 1", "(", textSearchOptions{Mode: matchRegex}, 100, 10)
+	if !result.IsError || !strings.Contains(extractTextContent(result), "invalid regular expression") {
+		t.Errorf("Expected an invalid pattern error, got: %s", extractTextContent(result))
+	}
+
+	if _, err := parseTextSearchOptions(createMockRequest(map[string]interface{}{"match_mode": "fuzzy"})); err == nil {
+		t.Error("Expected an error for an unknown match mode")
+	}
+}
@@ -0,0 +1,394 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// tkRulingCategory is the ELI reference category linking an act to Constitutional Tribunal rulings
+const tkRulingCategory = "Orzeczenie TK"
+
+// tkOutcomeFetchLimit caps how many ruling texts are downloaded to determine outcomes
+const tkOutcomeFetchLimit = 10
+
+// tkCaseTypes lists Constitutional Tribunal case signature prefixes in their canonical spelling
+var tkCaseTypes = []string{"Kpt", "Kp", "SK", "Ts", "Tw", "K", "P", "U"}
+
+var (
+	// tkCaseNumberPattern matches case signatures such as 'K 1/20', 'SK 35/15' or 'Kp 1/17'
+	tkCaseNumberPattern = regexp.MustCompile(`(?i)\b(Kpt|Kp|SK|Ts|Tw|K|P|U)\s*(\d{1,4})\s*/\s*(\d{2,4})\b`)
+	// tkRulingDatePattern matches Polish dates such as 'z dnia 22 października 2020 r.'
+	tkRulingDatePattern = regexp.MustCompile(`(?i)z dnia (\d{1,2}) (\p{L}+) (\d{4})`)
+)
+
+// polishGenitiveMonths maps month names as used in legal dates to month numbers
+var polishGenitiveMonths = map[string]time.Month{
+	"stycznia":     time.January,
+	"lutego":       time.February,
+	"marca":        time.March,
+	"kwietnia":     time.April,
+	"maja":         time.May,
+	"czerwca":      time.June,
+	"lipca":        time.July,
+	"sierpnia":     time.August,
+	"września":     time.September,
+	"października": time.October,
+	"listopada":    time.November,
+	"grudnia":      time.December,
+}
+
+// tkRuling describes a Constitutional Tribunal ruling published in the ELI database
+type tkRuling struct {
+	CaseNumber string
+	Kind       string
+	RulingDate string
+	Title      string
+	ELI        string
+	Provision  string
+	Date       string
+	Outcome    string
+}
+
+// normalizeTKCaseNumber returns the canonical form of a case signature ('k1/20' -> 'K 1/20')
+func normalizeTKCaseNumber(text string) (string, bool) {
+	match := tkCaseNumberPattern.FindStringSubmatch(text)
+	if match == nil {
+		return "", false
+	}
+	caseType := match[1]
+	for _, canonical := range tkCaseTypes {
+		if strings.EqualFold(canonical, caseType) {
+			caseType = canonical
+			break
+		}
+	}
+	return fmt.Sprintf("%s %s/%s", caseType, match[2], match[3]), true
+}
+
+// parseTKRulingTitle extracts the ruling kind, date and case number from an ELI act title
+func parseTKRulingTitle(title string) tkRuling {
+	ruling := tkRuling{Title: title, Kind: "ruling"}
+
+	lower := strings.ToLower(title)
+	switch {
+	case strings.HasPrefix(lower, "wyrok"):
+		ruling.Kind = "judgment (wyrok)"
+	case strings.HasPrefix(lower, "postanowienie"):
+		ruling.Kind = "decision (postanowienie)"
+	case strings.HasPrefix(lower, "obwieszczenie"):
+		ruling.Kind = "announcement (obwieszczenie)"
+	}
+
+	if caseNumber, ok := normalizeTKCaseNumber(title); ok {
+		ruling.CaseNumber = caseNumber
+	}
+
+	if match := tkRulingDatePattern.FindStringSubmatch(title); match != nil {
+		if month, ok := polishGenitiveMonths[strings.ToLower(match[2])]; ok {
+			day, _ := strconv.Atoi(match[1])
+			year, _ := strconv.Atoi(match[3])
+			ruling.RulingDate = time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+		}
+	}
+	return ruling
+}
+
+// tkOutcomeFromText classifies the operative part of a ruling
+func tkOutcomeFromText(text string) string {
+	lower := strings.ToLower(text)
+	unconstitutional := strings.Contains(lower, "jest niezgodny") || strings.Contains(lower, "są niezgodne") ||
+		strings.Contains(lower, "jest niezgodna") || strings.Contains(lower, "jest niezgodne")
+	constitutional := strings.Contains(lower, "jest zgodny") || strings.Contains(lower, "są zgodne") ||
+		strings.Contains(lower, "jest zgodna") || strings.Contains(lower, "jest zgodne")
+	discontinued := strings.Contains(lower, "umorzyć") || strings.Contains(lower, "umarza")
+
+	switch {
+	case unconstitutional && constitutional:
+		return "partially unconstitutional (niezgodny w części)"
+	case unconstitutional:
+		return "unconstitutional (niezgodny)"
+	case constitutional:
+		return "constitutional (zgodny)"
+	case discontinued:
+		return "proceedings discontinued (umorzenie)"
+	default:
+		return "not determined from text"
+	}
+}
+
+// tkRulingFromReference builds a ruling description from an ELI reference entry
+func tkRulingFromReference(ref eli.CustomReferenceDetailsInfo) tkRuling {
+	var ruling tkRuling
+	if ref.Act != nil {
+		if ref.Act.Title != nil {
+			ruling = parseTKRulingTitle(*ref.Act.Title)
+		}
+		if ref.Act.ELI != nil {
+			ruling.ELI = *ref.Act.ELI
+		}
+	}
+	if ruling.Kind == "" {
+		ruling.Kind = "ruling"
+	}
+	if ref.Art != nil {
+		ruling.Provision = *ref.Art
+	}
+	if ref.Date != nil && !ref.Date.IsZero() {
+		ruling.Date = ref.Date.Format("2006-01-02")
+	}
+	return ruling
+}
+
+// formatTKRuling renders a ruling for tool output
+func formatTKRuling(index int, ruling tkRuling) []string {
+	caseNumber := ruling.CaseNumber
+	if caseNumber == "" {
+		caseNumber = "case number not found in title"
+	}
+	lines := []string{fmt.Sprintf("%d. %s - %s", index, caseNumber, ruling.Kind)}
+	if ruling.RulingDate != "" {
+		lines = append(lines, fmt.Sprintf("   Ruling date: %s", ruling.RulingDate))
+	}
+	if ruling.Date != "" {
+		lines = append(lines, fmt.Sprintf("   Relation date: %s", ruling.Date))
+	}
+	if ruling.Provision != "" {
+		lines = append(lines, fmt.Sprintf("   Affected provision: %s", ruling.Provision))
+	}
+	if ruling.Outcome != "" {
+		lines = append(lines, fmt.Sprintf("   Outcome: %s", ruling.Outcome))
+	}
+	if ruling.ELI != "" {
+		lines = append(lines, fmt.Sprintf("   Published as: %s", ruling.ELI))
+	}
+	if ruling.Title != "" {
+		lines = append(lines, fmt.Sprintf("   Title: %s", ruling.Title))
+	}
+	return lines
+}
+
+// fetchTKRulingOutcome downloads a ruling's text and classifies its outcome
+func (s *SejmServer) fetchTKRulingOutcome(ctx context.Context, eliAddress string) string {
+	parts := strings.Split(eliAddress, "/")
+	if len(parts) != 3 {
+		return "not determined (unknown ELI address)"
+	}
+	act := eli.Act{}
+	detailsEndpoint := fmt.Sprintf("%s/acts/%s", s.eliBaseURL, eliAddress)
+	if data, err := s.makeAPIRequest(ctx, detailsEndpoint, nil); err == nil {
+		_ = s.decodeAPIResponse(data, &act)
+	}
+	text, _, err := s.fetchActPlainText(ctx, act, parts[0], parts[1], parts[2])
+	if err != nil || text == "" {
+		s.logger.Warn("Could not fetch ruling text", slog.String("eli", eliAddress), slog.Any("error", err))
+		return "not determined (ruling text unavailable)"
+	}
+	return tkOutcomeFromText(text)
+}
+
+func (s *SejmServer) handleGetTKRulings(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("eli_get_tk_rulings called", slog.Any("arguments", request.Params.Arguments))
+
+	publisher := request.GetString("publisher", "")
+	year := request.GetString("year", "")
+	position := request.GetString("position", "")
+	includeOutcome := request.GetString("include_outcome", "false")
+
+	if publisher == "" || year == "" || position == "" {
+		return mcp.NewToolResultError("All three parameters are required: publisher (e.g., 'DU'), year (e.g., '1997'), and position (e.g., '553'). Get these from eli_search_acts results."), nil
+	}
+
+	endpoint := fmt.Sprintf("%s/acts/%s/%s/%s/references", s.eliBaseURL, publisher, year, position)
+	apiData, err := s.makeAPIRequest(ctx, endpoint, nil)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve legal act references from ELI database: %v. Please verify the legal act exists with coordinates: publisher=%s, year=%s, position=%s.", err, publisher, year, position)), nil
+	}
+
+	var references eli.CustomReferencesDetailsInfo
+	if err := s.decodeAPIResponse(apiData, &references); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse legal references data from ELI API response: %v.", err)), nil
+	}
+
+	var rulings []tkRuling
+	for _, ref := range references[tkRulingCategory] {
+		rulings = append(rulings, tkRulingFromReference(ref))
+	}
+	sort.SliceStable(rulings, func(i, j int) bool {
+		return rulings[i].RulingDate > rulings[j].RulingDate
+	})
+
+	if includeOutcome == "true" {
+		for i := range rulings {
+			if i >= tkOutcomeFetchLimit {
+				break
+			}
+			if rulings[i].ELI != "" {
+				rulings[i].Outcome = s.fetchTKRulingOutcome(ctx, rulings[i].ELI)
+			}
+		}
+	}
+
+	actID := fmt.Sprintf("%s/%s/%s", publisher, year, position)
+	summary := []string{
+		fmt.Sprintf("Act: %s", actID),
+		fmt.Sprintf("Constitutional Tribunal rulings affecting this act: %d", len(rulings)),
+	}
+
+	var data []string
+	for i, ruling := range rulings {
+		data = append(data, formatTKRuling(i+1, ruling)...)
+		data = append(data, "")
+	}
+
+	status := "Retrieved Successfully"
+	if len(rulings) == 0 {
+		status = "No Results Found"
+		data = append(data, fmt.Sprintf("No '%s' references are recorded for this act in the ELI database.", tkRulingCategory))
+	}
+
+	nextActions := []string{
+		"Find other acts affected by a ruling: eli_get_tk_ruling_acts with case_number (e.g. 'K 1/20')",
+		fmt.Sprintf("See all legal relationships: eli_get_act_references with publisher='%s', year='%s', position='%s'", publisher, year, position),
+	}
+	if includeOutcome != "true" && len(rulings) > 0 {
+		nextActions = append(nextActions, "Classify ruling outcomes from their texts: repeat with include_outcome='true'")
+	}
+
+	note := "Rulings come from the ELI 'Orzeczenie TK' relation, sorted by ruling date (newest first)."
+	if includeOutcome == "true" && len(rulings) > tkOutcomeFetchLimit {
+		note += fmt.Sprintf(" Outcomes were determined for the %d most recent rulings only.", tkOutcomeFetchLimit)
+	}
+
+	response := StandardResponse{
+		Operation:   fmt.Sprintf("Constitutional Tribunal Rulings: %s", actID),
+		Status:      status,
+		Summary:     summary,
+		Data:        data,
+		NextActions: nextActions,
+		Note:        note,
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
+
+func (s *SejmServer) handleGetTKRulingActs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("eli_get_tk_ruling_acts called", slog.Any("arguments", request.Params.Arguments))
+
+	caseNumber, ok := normalizeTKCaseNumber(request.GetString("case_number", ""))
+	if !ok {
+		return mcp.NewToolResultError("Parameter 'case_number' must be a Constitutional Tribunal case signature such as 'K 1/20', 'SK 35/15' or 'P 7/18'."), nil
+	}
+	includeOutcome := request.GetString("include_outcome", "false")
+
+	params := map[string]string{
+		"title": caseNumber,
+		"limit": "50",
+	}
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/acts/search", s.eliBaseURL), params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to search ELI database for ruling %s: %v", caseNumber, err)), nil
+	}
+
+	var searchResult struct {
+		Items []eli.Act `json:"items"`
+	}
+	if err := s.decodeAPIResponse(data, &searchResult); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse ELI search results: %v", err)), nil
+	}
+
+	var results []string
+	rulingCount := 0
+	affectedCount := 0
+	coverage := newSourceCoverage("reference lists")
+	for _, act := range searchResult.Items {
+		if act.Title == nil || act.ELI == nil {
+			continue
+		}
+		ruling := parseTKRulingTitle(*act.Title)
+		if ruling.CaseNumber != caseNumber {
+			continue
+		}
+		ruling.ELI = *act.ELI
+		if includeOutcome == "true" {
+			ruling.Outcome = s.fetchTKRulingOutcome(ctx, ruling.ELI)
+		}
+		rulingCount++
+		results = append(results, formatTKRuling(rulingCount, ruling)...)
+
+		refData, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/acts/%s/references", s.eliBaseURL, ruling.ELI), nil)
+		if err != nil {
+			coverage.fail("references of "+ruling.ELI, err)
+			results = append(results, fmt.Sprintf("   Affected acts: could not be retrieved (%v)", err), "")
+			continue
+		}
+		var references eli.CustomReferencesDetailsInfo
+		if err := s.decodeAPIResponse(refData, &references); err != nil {
+			coverage.fail("references of "+ruling.ELI, fmt.Errorf("failed to parse references: %w", err))
+			results = append(results, fmt.Sprintf("   Affected acts: could not be parsed (%v)", err), "")
+			continue
+		}
+		coverage.succeeded()
+
+		categories := make([]string, 0, len(references))
+		for category := range references {
+			categories = append(categories, category)
+		}
+		sort.Strings(categories)
+
+		results = append(results, "   Affected acts:")
+		listed := 0
+		for _, category := range categories {
+			for _, ref := range references[category] {
+				if ref.Act == nil || ref.Act.ELI == nil {
+					continue
+				}
+				line := fmt.Sprintf("   • %s [%s]", *ref.Act.ELI, category)
+				if ref.Act.Title != nil {
+					line += " " + *ref.Act.Title
+				}
+				if ref.Art != nil {
+					line += fmt.Sprintf(" (provision: %s)", *ref.Art)
+				}
+				results = append(results, line)
+				listed++
+			}
+		}
+		if listed == 0 {
+			results = append(results, "   • none recorded in ELI references")
+		}
+		affectedCount += listed
+		results = append(results, "")
+	}
+
+	status := "Retrieved Successfully"
+	if rulingCount == 0 {
+		status = "No Results Found"
+		results = append(results, fmt.Sprintf("No ruling with signature %s was found among ELI acts. Rulings are published in Dziennik Ustaw only when they concern normative acts.", caseNumber))
+	}
+
+	response := StandardResponse{
+		Operation: fmt.Sprintf("Constitutional Tribunal Case %s", caseNumber),
+		Status:    coverage.status(status),
+		Summary: []string{
+			fmt.Sprintf("Case number: %s", caseNumber),
+			fmt.Sprintf("Published rulings found: %d", rulingCount),
+			fmt.Sprintf("Affected act references: %d", affectedCount),
+		},
+		Unavailable: coverage.unavailable(),
+		Data:        results,
+		NextActions: []string{
+			"List all rulings for an affected act: eli_get_tk_rulings with its publisher, year and position",
+			"Read the ruling: eli_get_act_text with the ruling's publisher, year and position",
+		},
+		Note: "Affected acts come from the ELI references of the published ruling.",
+	}
+	return coverage.result(response.Format()), nil
+}
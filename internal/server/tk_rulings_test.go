@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTKCaseNumber(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"K 1/20", "K 1/20", true},
+		{"sk35/15", "SK 35/15", true},
+		{"KP 1 / 17", "Kp 1/17", true},
+		{"sygn. akt P 7/18", "P 7/18", true},
+		{"druk 123", "", false},
+	}
+
+	for _, tc := range testCases {
+		got, ok := normalizeTKCaseNumber(tc.input)
+		if got != tc.expected || ok != tc.ok {
+			t.Errorf("normalizeTKCaseNumber(%q) = %q, %v; expected %q, %v", tc.input, got, ok, tc.expected, tc.ok)
+		}
+	}
+}
+
+func TestParseTKRulingTitle(t *testing.T) {
+	ruling := parseTKRulingTitle("Wyrok Trybunału Konstytucyjnego z dnia 22 października 2020 r. sygn. akt K 1/20")
+	if ruling.Kind != "judgment (wyrok)" || ruling.RulingDate != "2020-10-22" || ruling.CaseNumber != "K 1/20" {
+		t.Errorf("Unexpected parsed ruling: %+v", ruling)
+	}
+}
+
+func TestTKOutcomeFromText(t *testing.T) {
+	testCases := map[string]string{
+		"Art. 4a ust. 1 pkt 2 ustawy jest niezgodny z art. 38 Konstytucji.":            "unconstitutional (niezgodny)",
+		"Art. 5 jest zgodny z art. 2 Konstytucji.":                                     "constitutional (zgodny)",
+		"1. Art. 1 jest niezgodny z Konstytucją. 2. Art. 2 jest zgodny z Konstytucją.": "partially unconstitutional (niezgodny w części)",
+		"postanawia: umorzyć postępowanie":                                             "proceedings discontinued (umorzenie)",
+		"":                                                                             "not determined from text",
+	}
+	for text, expected := range testCases {
+		if got := tkOutcomeFromText(text); got != expected {
+			t.Errorf("tkOutcomeFromText(%q) = %q, expected %q", text, got, expected)
+		}
+	}
+}
+
+func TestHandleGetTKRulings(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/DU/1993/78/references": `{
+			"Orzeczenie TK": [
+				{"id": "DU/2021/175", "act": {"ELI": "DU/2021/175", "title": "Wyrok Trybunału Konstytucyjnego z dnia 22 października 2020 r. sygn. akt K 1/20"}, "art": "art. 4a ust. 1 pkt 2", "date": "2021-01-27"}
+			],
+			"Akty zmieniające": [
+				{"id": "DU/2020/1000", "act": {"ELI": "DU/2020/1000", "title": "Ustawa o zmianie ustawy"}}
+			]
+		}`,
+		"/eli/acts/DU/2021/175":           `{"title": "Wyrok Trybunału Konstytucyjnego", "textHTML": true}`,
+		"/eli/acts/DU/2021/175/text.html": `<html><body><p>Art. 4a ust. 1 pkt 2 ustawy jest niezgodny z art. 38 Konstytucji.</p></body></html>`,
+	})
+
+	result, err := server.handleGetTKRulings(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "DU", "year": "1993", "position": "78", "include_outcome": "true",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{"K 1/20 - judgment (wyrok)", "Ruling date: 2020-10-22", "Affected provision: art. 4a ust. 1 pkt 2", "Outcome: unconstitutional (niezgodny)", "rulings affecting this act: 1"} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+	if strings.Contains(content, "Ustawa o zmianie ustawy") {
+		t.Errorf("Non-TK references should not be listed, got: %s", content)
+	}
+}
+
+func TestHandleGetTKRulingActs(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/search": `{"count": 2, "items": [
+			{"ELI": "DU/2021/175", "title": "Wyrok Trybunału Konstytucyjnego z dnia 22 października 2020 r. sygn. akt K 1/20"},
+			{"ELI": "DU/2021/900", "title": "Wyrok Trybunału Konstytucyjnego z dnia 3 marca 2021 r. sygn. akt K 11/20"}
+		]}`,
+		"/eli/acts/DU/2021/175/references": `{
+			"Akty uchylone w części": [
+				{"id": "DU/1993/78", "act": {"ELI": "DU/1993/78", "title": "Ustawa o planowaniu rodziny"}, "art": "art. 4a ust. 1 pkt 2"}
+			]
+		}`,
+	})
+
+	result, err := server.handleGetTKRulingActs(context.Background(), createMockRequest(map[string]interface{}{
+		"case_number": "k1/20",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{"Published rulings found: 1", "DU/1993/78 [Akty uchylone w części] Ustawa o planowaniu rodziny (provision: art. 4a ust. 1 pkt 2)"} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+	if strings.Contains(content, "K 11/20") {
+		t.Errorf("Rulings with other case numbers should be filtered out, got: %s", content)
+	}
+
+	result, _ = server.handleGetTKRulingActs(context.Background(), createMockRequest(map[string]interface{}{
+		"case_number": "unknown",
+	}))
+	if !result.IsError {
+		t.Error("Expected error for invalid case number")
+	}
+}
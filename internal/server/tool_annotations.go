@@ -0,0 +1,69 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// localTools lists tools answered from the server's own state instead of the upstream APIs
+var localTools = map[string]bool{
+	"sejm_get_job_status":    true,
+	"sejm_get_job_result":    true,
+	"sejm_get_list_snapshot": true,
+}
+
+// titleWords spells out words of tool names that are not simply capitalized in titles
+var titleWords = map[string]string{
+	"mp":   "MP",
+	"mps":  "MPs",
+	"eli":  "ELI",
+	"eu":   "EU",
+	"tk":   "TK",
+	"pdf":  "PDF",
+	"id":   "ID",
+	"json": "JSON",
+}
+
+// toolTitle derives a human-readable title from a tool name, e.g. "sejm_get_mp_details" becomes
+// "Sejm: Get MP Details" and "eli_search_acts" becomes "ELI: Search Acts"
+func toolTitle(name string) string {
+	source, rest := "", name
+	switch {
+	case strings.HasPrefix(name, "sejm_"):
+		source, rest = "Sejm: ", strings.TrimPrefix(name, "sejm_")
+	case strings.HasPrefix(name, "eli_"):
+		source, rest = "ELI: ", strings.TrimPrefix(name, "eli_")
+	}
+	words := strings.Split(rest, "_")
+	for i, word := range words {
+		if spelled, ok := titleWords[word]; ok {
+			words[i] = spelled
+		} else if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return source + strings.Join(words, " ")
+}
+
+// applyToolAnnotations fills in the behavior hints clients use to decide which calls are safe to run in
+// parallel, retry or cache. Tools read public parliamentary and legal data, so they default to read-only and
+// idempotent; hints already set on the tool, e.g. for eli_watch_act which changes server state, are kept.
+func applyToolAnnotations(tool *mcp.Tool) {
+	annotations := &tool.Annotations
+	if annotations.Title == "" {
+		annotations.Title = toolTitle(tool.Name)
+	}
+	if annotations.ReadOnlyHint == nil {
+		annotations.ReadOnlyHint = mcp.ToBoolPtr(true)
+	}
+	if annotations.DestructiveHint == nil {
+		annotations.DestructiveHint = mcp.ToBoolPtr(false)
+	}
+	if annotations.IdempotentHint == nil {
+		annotations.IdempotentHint = mcp.ToBoolPtr(true)
+	}
+	if annotations.OpenWorldHint == nil {
+		annotations.OpenWorldHint = mcp.ToBoolPtr(!localTools[tool.Name])
+	}
+}
@@ -0,0 +1,52 @@
+package server
+
+import "testing"
+
+func TestToolTitle(t *testing.T) {
+	tests := map[string]string{
+		"sejm_get_mp_details":      "Sejm: Get MP Details",
+		"sejm_compare_mps":         "Sejm: Compare MPs",
+		"eli_search_acts":          "ELI: Search Acts",
+		"eli_get_eu_references":    "ELI: Get EU References",
+		"eli_get_tk_ruling_acts":   "ELI: Get TK Ruling Acts",
+		"sejm_get_job_status":      "Sejm: Get Job Status",
+		"unprefixed_tool":          "Unprefixed Tool",
+		"sejm_get_print_text":      "Sejm: Get Print Text",
+		"sejm_get_daily_digest":    "Sejm: Get Daily Digest",
+		"eli_get_act_references":   "ELI: Get Act References",
+		"sejm_get_mp_voting_stats": "Sejm: Get MP Voting Stats",
+	}
+	for name, want := range tests {
+		if got := toolTitle(name); got != want {
+			t.Errorf("toolTitle(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestRegisteredToolsAreAnnotated(t *testing.T) {
+	s := NewSejmServer()
+	// Tools that change server state, but are still idempotent and never destructive
+	stateChanging := map[string]bool{"eli_watch_act": true}
+	tools := s.server.ListTools()
+	if len(tools) == 0 {
+		t.Fatal("no tools registered")
+	}
+	for name, tool := range tools {
+		annotations := tool.Tool.Annotations
+		if annotations.Title == "" {
+			t.Errorf("%s: missing title", name)
+		}
+		if annotations.ReadOnlyHint == nil || *annotations.ReadOnlyHint == stateChanging[name] {
+			t.Errorf("%s: readOnlyHint should be %v", name, !stateChanging[name])
+		}
+		if annotations.IdempotentHint == nil || !*annotations.IdempotentHint {
+			t.Errorf("%s: idempotentHint should be true", name)
+		}
+		if annotations.DestructiveHint == nil || *annotations.DestructiveHint {
+			t.Errorf("%s: destructiveHint should be false", name)
+		}
+		if annotations.OpenWorldHint == nil || *annotations.OpenWorldHint == localTools[name] {
+			t.Errorf("%s: openWorldHint should be %v", name, !localTools[name])
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// defaultToolTimeoutKey sets the timeout of every tool without its own entry in Config.ToolTimeouts
+const defaultToolTimeoutKey = "default"
+
+// toolNamePattern matches the names of the tools of this server, e.g. sejm_search_votings
+var toolNamePattern = regexp.MustCompile(`^(sejm|eli)_[a-z0-9_]+$`)
+
+// ParseToolTimeouts parses the -tool-timeout flag, e.g. "default=2m,sejm_find_defections=10m". A timeout of 0
+// lets a tool run without a limit; tools that are not listed use the default, which is unlimited when not set.
+func ParseToolTimeouts(raw string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		tool, value, ok := strings.Cut(entry, "=")
+		tool = strings.TrimSpace(tool)
+		if !ok {
+			return nil, fmt.Errorf("invalid entry '%s': use tool=duration, e.g. default=2m", entry)
+		}
+		if tool != defaultToolTimeoutKey && !toolNamePattern.MatchString(tool) {
+			return nil, fmt.Errorf("invalid tool name '%s': use 'default' or a tool name such as sejm_search_votings", tool)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || timeout < 0 {
+			return nil, fmt.Errorf("invalid duration '%s' for '%s'", value, tool)
+		}
+		timeouts[tool] = timeout
+	}
+	return timeouts, nil
+}
+
+// toolTimeout returns the execution timeout of a tool; 0 means unlimited
+func (s *SejmServer) toolTimeout(tool string) time.Duration {
+	if timeout, ok := s.config.ToolTimeouts[tool]; ok {
+		return timeout
+	}
+	return s.config.ToolTimeouts[defaultToolTimeoutKey]
+}
+
+// withToolTimeout stops a tool call that runs longer than its timeout by cancelling its context, which aborts
+// the upstream requests in flight. Fan-out tools then return what they collected so far with a note that the
+// results are partial; a tool that fails instead gets an error naming the timeout.
+func (s *SejmServer) withToolTimeout(tool string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	timeout := s.toolTimeout(tool)
+	if timeout <= 0 {
+		return handler
+	}
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		callCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		result, err := handler(callCtx, request)
+		// A call cancelled by the client is not a timeout
+		if ctx.Err() != nil || !errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+			return result, err
+		}
+		s.logger.Warn("Tool call timed out", slog.String("tool", tool), slog.Duration("timeout", timeout))
+		return timedOutResult(tool, timeout, result, err), nil
+	}
+}
+
+// timedOutResult reports a tool call stopped by its timeout: results collected until then are kept with a
+// note and marked partial, anything else becomes an error with hints on how to narrow the call
+func timedOutResult(tool string, timeout time.Duration, result *mcp.CallToolResult, err error) *mcp.CallToolResult {
+	hint := "Narrow the request, e.g. a shorter date range, fewer sittings or a lower limit"
+	if asyncTools[tool] {
+		hint += ", or run it in the background with async='true'"
+	}
+	if err != nil || result == nil || result.IsError {
+		return mcp.NewToolResultError(fmt.Sprintf("%s did not finish within the %s timeout of this server. %s.", tool, timeout, hint))
+	}
+	result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf("Timeout: the call was stopped after %s, so the results above are partial; sources that were not reached are listed as unavailable. %s.", timeout, hint)))
+	return markPartial(result)
+}
+
+// acquireSlot waits for a free slot of a bounded fan-out. It gives up with the context error once the call is
+// cancelled or timed out, so queued work is skipped instead of starting upstream requests that cannot finish.
+func acquireSlot(ctx context.Context, slots chan struct{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
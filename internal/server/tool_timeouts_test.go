@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestParseToolTimeouts(t *testing.T) {
+	timeouts, err := ParseToolTimeouts("default=2m, sejm_find_defections=10m,eli_search_acts=0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if timeouts["default"] != 2*time.Minute || timeouts["sejm_find_defections"] != 10*time.Minute || timeouts["eli_search_acts"] != 0 {
+		t.Errorf("Unexpected timeouts: %v", timeouts)
+	}
+	for _, raw := range []string{"default", "default=soon", "default=-1m", "search=1m"} {
+		if _, err := ParseToolTimeouts(raw); err == nil {
+			t.Errorf("Expected %q to be rejected", raw)
+		}
+	}
+
+	server := &SejmServer{config: Config{ToolTimeouts: timeouts}}
+	if server.toolTimeout("sejm_get_mps") != 2*time.Minute || server.toolTimeout("eli_search_acts") != 0 {
+		t.Error("Expected tools without an entry to use the default and 0 to disable the timeout")
+	}
+}
+
+func TestWithToolTimeout(t *testing.T) {
+	server := NewSejmServer()
+	server.config.ToolTimeouts = map[string]time.Duration{"default": 20 * time.Millisecond}
+
+	partial := server.withToolTimeout("sejm_find_defections", func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		<-ctx.Done()
+		return mcp.NewToolResultText("Status: Partially Retrieved"), nil
+	})
+	result, err := partial(context.Background(), createMockRequest(map[string]interface{}{}))
+	if err != nil || result.IsError || len(result.Content) != 2 {
+		t.Fatalf("Expected the partial result with a timeout note, got %v %+v", err, result)
+	}
+	if note := result.Content[1].(mcp.TextContent).Text; !strings.Contains(note, "stopped after 20ms") || !strings.Contains(note, "async='true'") {
+		t.Errorf("Unexpected timeout note: %s", note)
+	}
+
+	failing := server.withToolTimeout("sejm_get_mps", func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	result, err = failing(context.Background(), createMockRequest(map[string]interface{}{}))
+	if err != nil || !result.IsError || !strings.Contains(extractTextContent(result), "did not finish within the 20ms timeout") {
+		t.Errorf("Expected a timeout error, got %v %s", err, extractTextContent(result))
+	}
+
+	// A call cancelled by the client is passed through unchanged
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := failing(ctx, createMockRequest(map[string]interface{}{})); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the cancellation error, got %v", err)
+	}
+}
+
+func TestFanOutStopsWhenCancelled(t *testing.T) {
+	slots := make(chan struct{}, 1)
+	slots <- struct{}{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := acquireSlot(ctx, slots); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected waiting for a busy slot to end with the timeout, got %v", err)
+	}
+
+	server := newServerWithFixtures(t, map[string]string{"/sejm/term10/committees/ASW": `{"code": "ASW"}`})
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, errs := server.fetchCommittees(cancelled, 10, []string{"ASW", "ZDR"})
+	for i, err := range errs {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected committee %d to be skipped as cancelled, got %v", i, err)
+		}
+	}
+	for name, status := range server.health.snapshot() {
+		if status.Failures > 0 {
+			t.Errorf("Expected cancelled calls not to count as failures of %s, got %+v", name, status)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation scope of the server's spans
+const tracerName = "github.com/janisz/sejm-mcp/internal/server"
+
+// newTracerProvider creates a provider that exports spans over OTLP/HTTP to the configured endpoint. Without an
+// endpoint it returns nil and spans are not recorded.
+func newTracerProvider(config Config) (*sdktrace.TracerProvider, error) {
+	if config.OTLPEndpoint == "" {
+		return nil, nil
+	}
+	exporter, err := otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(config.OTLPEndpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter for %s: %w", config.OTLPEndpoint, err)
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceName("sejm-mcp"),
+			semconv.ServiceVersion("1.0.0"),
+		)),
+	), nil
+}
+
+// setupTracing installs the tracer used by tool handlers, upstream requests and PDF extraction
+func (s *SejmServer) setupTracing() {
+	s.tracer = noop.NewTracerProvider().Tracer(tracerName)
+	provider, err := newTracerProvider(s.config)
+	if err != nil {
+		s.logger.Warn("Tracing disabled", slog.Any("error", err))
+		return
+	}
+	if provider != nil {
+		s.tracerProvider = provider
+		s.tracer = provider.Tracer(tracerName)
+		s.logger.Info("Exporting traces over OTLP", slog.String("endpoint", s.config.OTLPEndpoint))
+	}
+}
+
+// Shutdown flushes spans that have not been exported yet, also of the profile servers; it does nothing when
+// tracing is off
+func (s *SejmServer) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, profile := range s.profiles {
+		errs = append(errs, profile.Shutdown(ctx))
+	}
+	if s.tracerProvider != nil {
+		errs = append(errs, s.tracerProvider.Shutdown(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// startSpan starts a span with the server's tracer; servers built without one record nothing
+func (s *SejmServer) startSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	if s.tracer == nil {
+		return ctx, noop.Span{}
+	}
+	return s.tracer.Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// endSpan records the outcome of an operation on its span and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// tracedToolHandler wraps a tool handler in a span named after the tool. Results reported as tool errors
+// mark the span as failed too.
+func (s *SejmServer) tracedToolHandler(name string, handler server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		ctx, span := s.startSpan(ctx, "tool "+name,
+			attribute.String("mcp.tool.name", name),
+			attribute.Int("mcp.tool.argument_count", len(request.GetArguments())),
+		)
+		result, err := handler(ctx, request)
+		if err == nil && result != nil {
+			chars := resultTextLength(result)
+			span.SetAttributes(attribute.Int("mcp.tool.result_chars", chars))
+			if result.IsError {
+				span.SetStatus(codes.Error, "tool returned an error result")
+			} else {
+				s.usage.record(name, chars)
+			}
+		}
+		endSpan(span, err)
+		return result, err
+	}
+}
+
+// resultTextLength counts the characters of the text content of a tool result
+func resultTextLength(result *mcp.CallToolResult) int {
+	length := 0
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			length += len(text.Text)
+		}
+	}
+	return length
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracedToolHandler(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/committees": `[{"code": "ASW", "name": "Komisja Administracji i Spraw Wewnętrznych"}]`,
+	})
+	recorder := tracetest.NewSpanRecorder()
+	server.tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracerName)
+
+	handler := server.tracedToolHandler("sejm_get_committees", server.handleGetCommittees)
+	if _, err := handler(context.Background(), createMockRequest(map[string]interface{}{"term": "10"})); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := handler(context.Background(), createMockRequest(map[string]interface{}{"term": "9"})); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 4 {
+		t.Fatalf("Expected a tool and an upstream span per call, got %d spans", len(spans))
+	}
+	upstream, tool := spans[0], spans[1]
+	if tool.Name() != "tool sejm_get_committees" || upstream.Name() != "upstream sejm" {
+		t.Fatalf("Unexpected span names %q and %q", tool.Name(), upstream.Name())
+	}
+	if upstream.Parent().SpanID() != tool.SpanContext().SpanID() {
+		t.Errorf("Expected the upstream request to be a child of the tool span")
+	}
+	if tool.Status().Code == codes.Error || upstream.Status().Code == codes.Error {
+		t.Errorf("Expected a successful call, got %v and %v", tool.Status(), upstream.Status())
+	}
+	if failedUpstream, failedTool := spans[2], spans[3]; failedUpstream.Status().Code != codes.Error || failedTool.Status().Code != codes.Error {
+		t.Errorf("Expected a missing resource to fail both spans, got %v and %v", failedUpstream.Status(), failedTool.Status())
+	}
+}
+
+func TestStartSpanWithoutTracer(t *testing.T) {
+	server := &SejmServer{}
+	ctx, span := server.startSpan(context.Background(), "operation")
+	endSpan(span, nil)
+	if ctx == nil || span.SpanContext().IsValid() {
+		t.Errorf("Expected a non-recording span for a server without a tracer")
+	}
+}
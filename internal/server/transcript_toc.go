@@ -0,0 +1,245 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// transcriptTOCEntry is a run of consecutive statements of a sitting day devoted to one agenda item. An item
+// that is interrupted and taken up again later has several entries. Item 0 covers the statements before the
+// first item is taken up.
+type transcriptTOCEntry struct {
+	Item           int    `json:"item"`
+	Title          string `json:"title"`
+	FirstStatement int    `json:"firstStatement"`
+	LastStatement  int    `json:"lastStatement"`
+	Statements     int    `json:"statements"`
+	Start          string `json:"start,omitempty"`
+	End            string `json:"end,omitempty"`
+}
+
+// isPresidingStatement tells whether a statement was made by the chair of the sitting (Marszałek,
+// Wicemarszałek or Marszałek Senior), who announces the agenda items
+func isPresidingStatement(statement sejm.Statement) bool {
+	function, _ := foldText(stringValue(statement.Function), true)
+	return strings.Contains(function, "marszalek")
+}
+
+// statementNumber returns the number of a statement, -1 when it has none
+func statementNumber(statement sejm.Statement) int {
+	if statement.Num == nil {
+		return -1
+	}
+	return int(*statement.Num)
+}
+
+// agendaTransitions fetches the statements of the chair with limited concurrency and returns, by statement
+// number, the agenda item each of them takes up with the heading found after the announcement. Statements
+// that could not be fetched are recorded in coverage.
+func (s *SejmServer) agendaTransitions(ctx context.Context, request mcp.CallToolRequest, term int, proceedingID, date string, statements []sejm.Statement, coverage *sourceCoverage) (map[int]int, map[int]string) {
+	var presiding []int
+	for _, statement := range statements {
+		if number := statementNumber(statement); number > 0 && isPresidingStatement(statement) {
+			presiding = append(presiding, number)
+		}
+	}
+
+	progress := s.newProgressReporter(ctx, request)
+	texts := make([]string, len(presiding))
+	failures := make([]error, len(presiding))
+	slots := make(chan struct{}, maxConcurrentBodyFetches)
+	var wg sync.WaitGroup
+	var progressMu sync.Mutex
+	done := 0
+	for i, number := range presiding {
+		wg.Add(1)
+		go func(i, number int) {
+			defer wg.Done()
+			if err := acquireSlot(ctx, slots); err != nil {
+				failures[i] = err
+				return
+			}
+			defer func() { <-slots }()
+			defer func() {
+				progressMu.Lock()
+				done++
+				progress.report(done, len(presiding), fmt.Sprintf("Read statement %d of the chair (%d of %d)", number, done, len(presiding)))
+				progressMu.Unlock()
+			}()
+			endpoint := fmt.Sprintf("%s/sejm/term%d/proceedings/%s/%s/transcripts/%d", s.sejmBaseURL, term, proceedingID, date, number)
+			data, err := s.makeTextRequest(ctx, endpoint, "html")
+			if err != nil {
+				failures[i] = err
+				return
+			}
+			texts[i] = htmlToTextLines(string(data))
+		}(i, number)
+	}
+	wg.Wait()
+
+	transitions := make(map[int]int)
+	headings := make(map[int]string)
+	for i, number := range presiding {
+		if failures[i] != nil {
+			coverage.fail(fmt.Sprintf("statement %d", number), failures[i])
+			continue
+		}
+		coverage.succeeded()
+		markers := findAgendaMarkers(texts[i])
+		if len(markers) == 0 {
+			continue
+		}
+		// The first announcement counts; later mentions in the same statement refer to other items
+		transitions[number] = markers[0].number
+		if _, ok := headings[markers[0].number]; !ok {
+			line, _, _ := strings.Cut(texts[i][markers[0].offset:], "\n")
+			headings[markers[0].number] = strings.Join(strings.Fields(line), " ")
+		}
+	}
+	return transitions, headings
+}
+
+// buildTranscriptTOC groups statements, in the order of their numbers, into runs of one agenda item. A run
+// starts at each statement of the chair that takes up an item; titles come from the agenda of the sitting,
+// or else from the announcement itself.
+func buildTranscriptTOC(statements []sejm.Statement, transitions map[int]int, agenda, headings map[int]string) []transcriptTOCEntry {
+	ordered := make([]sejm.Statement, 0, len(statements))
+	for _, statement := range statements {
+		// Statement 0 is the course of the sitting, not a speech
+		if statementNumber(statement) > 0 {
+			ordered = append(ordered, statement)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return statementNumber(ordered[i]) < statementNumber(ordered[j]) })
+
+	var toc []transcriptTOCEntry
+	for _, statement := range ordered {
+		number := statementNumber(statement)
+		item, starts := transitions[number]
+		if len(toc) == 0 || (starts && item != toc[len(toc)-1].Item) {
+			if !starts {
+				item = 0
+			}
+			title := valueOrDefault(agenda[item], headings[item])
+			if item == 0 {
+				title = "Opening of the sitting day and matters outside the agenda items"
+			}
+			if len(title) > agendaHeadingChars {
+				title = truncateRunes(title, agendaHeadingChars) + "…"
+			}
+			toc = append(toc, transcriptTOCEntry{Item: item, Title: title, FirstStatement: number})
+		}
+		entry := &toc[len(toc)-1]
+		entry.LastStatement = number
+		entry.Statements++
+		if statement.StartDateTime != nil && entry.Start == "" {
+			entry.Start = statement.StartDateTime.Format("15:04")
+		}
+		if statement.EndDateTime != nil {
+			entry.End = statement.EndDateTime.Format("15:04")
+		}
+	}
+	return toc
+}
+
+// proceedingAgendaItems fetches the numbered agenda of a Sejm sitting; it is optional and an empty map is
+// returned when the sitting details are unavailable
+func (s *SejmServer) proceedingAgendaItems(ctx context.Context, term int, proceedingID string) map[int]string {
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/proceedings/%s", s.sejmBaseURL, term, proceedingID), nil)
+	if err != nil {
+		return map[int]string{}
+	}
+	var proceeding sejm.Proceeding
+	if err := s.decodeAPIResponse(data, &proceeding); err != nil || proceeding.Agenda == nil {
+		return map[int]string{}
+	}
+	return parseCommitteeAgenda(*proceeding.Agenda)
+}
+
+// transcriptTOC detects the agenda items of a sitting day in its statements
+func (s *SejmServer) transcriptTOC(ctx context.Context, request mcp.CallToolRequest, term int, proceedingID, date string, statements []sejm.Statement) ([]transcriptTOCEntry, *sourceCoverage) {
+	coverage := newSourceCoverage("statements of the chair")
+	transitions, headings := s.agendaTransitions(ctx, request, term, proceedingID, date, statements, coverage)
+	return buildTranscriptTOC(statements, transitions, s.proceedingAgendaItems(ctx, term, proceedingID), headings), coverage
+}
+
+// statementsOfAgendaItem keeps the statements that belong to the runs of an agenda item
+func statementsOfAgendaItem(statements []sejm.Statement, toc []transcriptTOCEntry, agendaItem string) ([]sejm.Statement, error) {
+	item, err := strconv.Atoi(strings.TrimSpace(agendaItem))
+	if err != nil || item < 0 {
+		return nil, fmt.Errorf("invalid agenda_item '%s'. Use an item number from group_by='agenda_item', e.g. '3', or '0' for the opening", agendaItem)
+	}
+	var selected []sejm.Statement
+	for _, entry := range toc {
+		if entry.Item != item {
+			continue
+		}
+		for _, statement := range statements {
+			if number := statementNumber(statement); number >= entry.FirstStatement && number <= entry.LastStatement {
+				selected = append(selected, statement)
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("agenda item %d was not detected on this day. Use group_by='agenda_item' to see the detected items", item)
+	}
+	return selected, nil
+}
+
+// transcriptTOCResult renders the table of contents of a sitting day for sejm_get_transcripts
+func transcriptTOCResult(term int, proceedingID, date string, toc []transcriptTOCEntry, coverage *sourceCoverage) *mcp.CallToolResult {
+	items := make(map[int]bool)
+	for _, entry := range toc {
+		if entry.Item > 0 {
+			items[entry.Item] = true
+		}
+	}
+	summary := []string{
+		fmt.Sprintf("Proceeding: %s (Term %d)", proceedingID, term),
+		fmt.Sprintf("Date: %s", date),
+		fmt.Sprintf("Agenda items detected: %d in %d sections", len(items), len(toc)),
+	}
+
+	var data []string
+	for _, entry := range toc {
+		label := fmt.Sprintf("Item %d", entry.Item)
+		if entry.Item == 0 {
+			label = "Opening"
+		}
+		line := fmt.Sprintf("• %s: statements %d-%d (%d)", label, entry.FirstStatement, entry.LastStatement, entry.Statements)
+		if entry.Start != "" {
+			line += fmt.Sprintf(", %s-%s", entry.Start, valueOrDefault(entry.End, "?"))
+		}
+		data = append(data, line)
+		if entry.Title != "" {
+			data = append(data, "  "+entry.Title)
+		}
+	}
+
+	nextActions := []string{
+		fmt.Sprintf("Statements of one item: sejm_get_transcripts with proceeding_id='%s', date='%s', agenda_item='<number>'", proceedingID, date),
+		"Full text of a statement: sejm_get_statement with statement_num from the ranges above",
+	}
+	status := coverage.status("Retrieved Successfully")
+	if len(items) == 0 {
+		status = "No Results Found"
+		nextActions = append(nextActions, "Browse all statements: sejm_get_transcripts without group_by")
+	}
+	response := StandardResponse{
+		Operation:   "Transcript Table of Contents",
+		Status:      status,
+		Summary:     summary,
+		Unavailable: coverage.unavailable(),
+		Data:        data,
+		NextActions: nextActions,
+		Note:        "Items are detected where the chair announces them ('Przystępujemy do rozpatrzenia punktu 3. porządku dziennego'). An item that is interrupted and resumed appears in several sections; statements of an item announced without those words stay in the preceding section.",
+	}
+	return coverage.result(response.Format())
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const transcriptTOCStatements = `{"proceedingNum": 5, "date": "2024-05-10", "statements": [
+	{"num": 0, "name": "Przebieg posiedzenia"},
+	{"num": 1, "name": "Szymon Hołownia", "function": "Marszałek", "startDateTime": "2024-05-10T09:00:00", "endDateTime": "2024-05-10T09:05:00"},
+	{"num": 2, "name": "Jan Kowalski", "function": "Poseł Sprawozdawca", "startDateTime": "2024-05-10T09:05:00", "endDateTime": "2024-05-10T09:20:00"},
+	{"num": 3, "name": "Monika Wielichowska", "function": "Wicemarszałek", "startDateTime": "2024-05-10T09:20:00", "endDateTime": "2024-05-10T09:22:00"},
+	{"num": 4, "name": "Anna Nowak", "function": "Poseł", "startDateTime": "2024-05-10T09:22:00", "endDateTime": "2024-05-10T09:30:00"},
+	{"num": 5, "name": "Monika Wielichowska", "function": "Wicemarszałek", "startDateTime": "2024-05-10T09:30:00", "endDateTime": "2024-05-10T09:31:00"},
+	{"num": 6, "name": "Piotr Zieliński", "function": "Poseł", "startDateTime": "2024-05-10T09:31:00", "endDateTime": "2024-05-10T09:40:00"}
+]}`
+
+func newTranscriptTOCServer(t *testing.T) *SejmServer {
+	t.Helper()
+	return newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/proceedings/5/2024-05-10/transcripts":   transcriptTOCStatements,
+		"/sejm/term10/proceedings/5/2024-05-10/transcripts/1": "<p>Otwieram posiedzenie.</p><p>Przystępujemy do rozpatrzenia punktu 2. porządku dziennego: Sprawozdanie Komisji o projekcie ustawy o drogach.</p>",
+		"/sejm/term10/proceedings/5/2024-05-10/transcripts/3": "<p>Dziękuję. Przechodzimy do rozpatrzenia punktu 4. porządku dziennego.</p>",
+		"/sejm/term10/proceedings/5/2024-05-10/transcripts/5": "<p>Powracamy do rozpatrzenia punktu 2. porządku dziennego.</p>",
+		"/sejm/term10/proceedings/5":                          `{"number": 5, "agenda": "<ol><li>Ślubowanie posła</li><li>Projekt ustawy o drogach</li><li>Informacja rządu</li><li>Projekt ustawy o szkołach</li></ol>"}`,
+	})
+}
+
+func TestTranscriptTableOfContents(t *testing.T) {
+	server := newTranscriptTOCServer(t)
+
+	result, err := server.handleGetTranscripts(context.Background(), createMockRequest(map[string]interface{}{
+		"proceeding_id": "5", "date": "2024-05-10", "group_by": "agenda_item",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{
+		"Agenda items detected: 2 in 3 sections",
+		"• Item 2: statements 1-2 (2), 09:00-09:20",
+		"  Projekt ustawy o drogach",
+		"• Item 4: statements 3-4 (2), 09:20-09:30",
+		"  Projekt ustawy o szkołach",
+		"• Item 2: statements 5-6 (2), 09:30-09:40",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+
+	result, _ = server.handleGetTranscripts(context.Background(), createMockRequest(map[string]interface{}{
+		"proceeding_id": "5", "date": "2024-05-10", "agenda_item": "2", "limit": "3",
+	}))
+	text = extractTextContent(result)
+	for _, expected := range []string{"Agenda item: 2", "Total statements: 4", "• Statement 5: Monika Wielichowska", "agenda_item='2', offset='3', limit='3'"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+	if strings.Contains(text, "Statement 4:") {
+		t.Errorf("Expected the statements of item 4 to be left out:\n%s", text)
+	}
+
+	result, _ = server.handleGetTranscripts(context.Background(), createMockRequest(map[string]interface{}{
+		"proceeding_id": "5", "date": "2024-05-10", "agenda_item": "3",
+	}))
+	if !result.IsError || !strings.Contains(extractTextContent(result), "agenda item 3 was not detected") {
+		t.Errorf("Expected an undetected item to be rejected, got: %s", extractTextContent(result))
+	}
+}
@@ -0,0 +1,309 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// interpellationReplyDays is the statutory reply deadline counted from sending (art. 192 of the Standing Orders of the Sejm)
+	interpellationReplyDays = 21
+	// defaultUnansweredItems and maxUnansweredItems bound the delayed interpellations analyzed
+	defaultUnansweredItems = 500
+	maxUnansweredItems     = 2000
+	// defaultUnansweredListed is the number of most overdue interpellations listed
+	defaultUnansweredListed = 20
+)
+
+// overdueBuckets are the aging buckets of overdue replies, by days past the deadline
+var overdueBuckets = []struct {
+	label string
+	from  int
+}{
+	{"1-29 days", 1},
+	{"30-59 days", 30},
+	{"60-89 days", 60},
+	{"90+ days", 90},
+}
+
+// overdueBucket returns the index of the aging bucket for a number of days past the deadline
+func overdueBucket(days int) int {
+	bucket := 0
+	for i, b := range overdueBuckets {
+		if days >= b.from {
+			bucket = i
+		}
+	}
+	return bucket
+}
+
+// overdueReply is a recipient that has not replied to an interpellation by the deadline
+type overdueReply struct {
+	Num          int    `json:"num"`
+	Title        string `json:"title"`
+	Recipient    string `json:"recipient"`
+	Sent         string `json:"sent,omitempty"`
+	Deadline     string `json:"deadline,omitempty"`
+	DaysOverdue  int    `json:"daysOverdue"`
+	Prolongation bool   `json:"prolongationRequested,omitempty"`
+}
+
+// ministryBacklog summarizes the overdue replies of one recipient
+type ministryBacklog struct {
+	Recipient   string         `json:"recipient"`
+	Overdue     int            `json:"overdue"`
+	Buckets     map[string]int `json:"buckets"`
+	MaxOverdue  int            `json:"maxDaysOverdue"`
+	MeanOverdue float64        `json:"meanDaysOverdue"`
+}
+
+// overdueReplies returns the recipients of an interpellation whose reply is past the deadline. The API reports
+// the delay per recipient and resets it to zero once that recipient answers; interpellations without recipient
+// details fall back to the overall delay for every addressee.
+func overdueReplies(interpellation sejm.Interpellation) []overdueReply {
+	base := overdueReply{Title: valueOrDefault(stringValue(interpellation.Title), "No title")}
+	if interpellation.Num != nil {
+		base.Num = int(*interpellation.Num)
+	}
+	if interpellation.Replies != nil {
+		for _, reply := range *interpellation.Replies {
+			if reply.Prolongation != nil && *reply.Prolongation {
+				base.Prolongation = true
+			}
+		}
+	}
+
+	var result []overdueReply
+	add := func(recipient string, sent string, delay *int32) {
+		if delay == nil || *delay <= 0 {
+			return
+		}
+		entry := base
+		entry.Recipient = valueOrDefault(recipient, "Unknown recipient")
+		entry.DaysOverdue = int(*delay)
+		if sent != "" {
+			entry.Sent = sent
+			if date, ok := parseProcessDate(sent); ok {
+				entry.Deadline = date.AddDate(0, 0, interpellationReplyDays).Format("2006-01-02")
+			}
+		}
+		result = append(result, entry)
+	}
+
+	sent := ""
+	if interpellation.SentDate != nil {
+		sent = interpellation.SentDate.Format("2006-01-02")
+	}
+	if interpellation.RecipientDetails != nil && len(*interpellation.RecipientDetails) > 0 {
+		for _, recipient := range *interpellation.RecipientDetails {
+			recipientSent := sent
+			if recipient.Sent != nil {
+				recipientSent = recipient.Sent.Format("2006-01-02")
+			}
+			add(stringValue(recipient.Name), recipientSent, recipient.AnswerDelayedDays)
+		}
+		return result
+	}
+	if interpellation.To != nil && len(*interpellation.To) > 0 {
+		for _, recipient := range *interpellation.To {
+			add(recipient, sent, interpellation.AnswerDelayedDays)
+		}
+		return result
+	}
+	add("", sent, interpellation.AnswerDelayedDays)
+	return result
+}
+
+// summarizeBacklogs groups overdue replies by recipient, ordered by the number of overdue replies
+func summarizeBacklogs(replies []overdueReply) []ministryBacklog {
+	byRecipient := make(map[string]*ministryBacklog)
+	totals := make(map[string]int)
+	for _, reply := range replies {
+		backlog := byRecipient[reply.Recipient]
+		if backlog == nil {
+			backlog = &ministryBacklog{Recipient: reply.Recipient, Buckets: make(map[string]int)}
+			for _, b := range overdueBuckets {
+				backlog.Buckets[b.label] = 0
+			}
+			byRecipient[reply.Recipient] = backlog
+		}
+		backlog.Overdue++
+		backlog.Buckets[overdueBuckets[overdueBucket(reply.DaysOverdue)].label]++
+		if reply.DaysOverdue > backlog.MaxOverdue {
+			backlog.MaxOverdue = reply.DaysOverdue
+		}
+		totals[reply.Recipient] += reply.DaysOverdue
+	}
+
+	result := make([]ministryBacklog, 0, len(byRecipient))
+	for recipient, backlog := range byRecipient {
+		backlog.MeanOverdue = float64(totals[recipient]) / float64(backlog.Overdue)
+		result = append(result, *backlog)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Overdue != result[j].Overdue {
+			return result[i].Overdue > result[j].Overdue
+		}
+		return result[i].Recipient < result[j].Recipient
+	})
+	return result
+}
+
+func (s *SejmServer) handleGetUnansweredInterpellations(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_unanswered_interpellations called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	maxItems := defaultUnansweredItems
+	if value := request.GetString("max_items", ""); value != "" {
+		maxItems, err = strconv.Atoi(value)
+		if err != nil || maxItems < 1 || maxItems > maxUnansweredItems {
+			return mcp.NewToolResultError(fmt.Sprintf("Parameter 'max_items' must be a number between 1 and %d.", maxUnansweredItems)), nil
+		}
+	}
+	listed := defaultUnansweredListed
+	if value := request.GetString("limit", ""); value != "" {
+		listed, err = strconv.Atoi(value)
+		if err != nil || listed < 0 {
+			return mcp.NewToolResultError("Parameter 'limit' must be a non-negative number."), nil
+		}
+	}
+	minDays := 0
+	if value := request.GetString("min_days_overdue", ""); value != "" {
+		minDays, err = strconv.Atoi(value)
+		if err != nil || minDays < 0 {
+			return mcp.NewToolResultError("Parameter 'min_days_overdue' must be a non-negative number, e.g., 30, 60 or 90."), nil
+		}
+	}
+	recipientFilter := strings.TrimSpace(request.GetString("recipient", ""))
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+
+	interpellations, err := s.fetchInterpellationPages(ctx, term, map[string]string{"delayed": "true"}, maxItems)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve delayed interpellations for term %d: %v", term, err)), nil
+	}
+
+	var replies []overdueReply
+	for _, interpellation := range interpellations {
+		for _, reply := range overdueReplies(interpellation) {
+			if reply.DaysOverdue < minDays {
+				continue
+			}
+			if recipientFilter != "" && !strings.Contains(strings.ToLower(reply.Recipient), strings.ToLower(recipientFilter)) {
+				continue
+			}
+			replies = append(replies, reply)
+		}
+	}
+	sort.SliceStable(replies, func(i, j int) bool {
+		return replies[i].DaysOverdue > replies[j].DaysOverdue
+	})
+	backlogs := summarizeBacklogs(replies)
+	totals := make([]int, len(overdueBuckets))
+	for _, reply := range replies {
+		totals[overdueBucket(reply.DaysOverdue)]++
+	}
+	truncated := len(interpellations) == maxItems
+	if listed > len(replies) {
+		listed = len(replies)
+	}
+
+	if format == "json" {
+		buckets := make(map[string]int)
+		for i, b := range overdueBuckets {
+			buckets[b.label] = totals[i]
+		}
+		result, _ := json.MarshalIndent(map[string]interface{}{
+			"term":            term,
+			"deadlineDays":    interpellationReplyDays,
+			"interpellations": len(interpellations),
+			"overdueReplies":  len(replies),
+			"truncated":       truncated,
+			"buckets":         buckets,
+			"ministries":      backlogs,
+			"mostOverdue":     replies[:listed],
+		}, "", "  ")
+		return mcp.NewToolResultText(string(result)), nil
+	}
+
+	summary := []string{
+		fmt.Sprintf("Term: %d", term),
+		fmt.Sprintf("Delayed interpellations analyzed: %d", len(interpellations)),
+		fmt.Sprintf("Replies past the %d-day deadline: %d, from %d recipients", interpellationReplyDays, len(replies), len(backlogs)),
+	}
+	var bucketParts []string
+	for i, b := range overdueBuckets {
+		bucketParts = append(bucketParts, fmt.Sprintf("%s: %d", b.label, totals[i]))
+	}
+	summary = append(summary, "Overdue by "+strings.Join(bucketParts, ", "))
+	if recipientFilter != "" {
+		summary = append(summary, fmt.Sprintf("Recipient filter: '%s'", recipientFilter))
+	}
+	if minDays > 0 {
+		summary = append(summary, fmt.Sprintf("Only replies at least %d days overdue", minDays))
+	}
+
+	var results []string
+	if len(replies) == 0 {
+		results = append(results, "No reply is past its deadline for the given filters.")
+	} else {
+		results = append(results, "Overdue replies by recipient:")
+		for _, backlog := range backlogs {
+			var parts []string
+			for _, b := range overdueBuckets {
+				if count := backlog.Buckets[b.label]; count > 0 {
+					parts = append(parts, fmt.Sprintf("%s: %d", b.label, count))
+				}
+			}
+			results = append(results, fmt.Sprintf("• %s: %d overdue (%s; longest %d days, mean %.0f days)",
+				backlog.Recipient, backlog.Overdue, strings.Join(parts, ", "), backlog.MaxOverdue, backlog.MeanOverdue))
+		}
+		if listed > 0 {
+			results = append(results, "", fmt.Sprintf("Most overdue (%d of %d):", listed, len(replies)))
+			for _, reply := range replies[:listed] {
+				line := fmt.Sprintf("• Interpellation %d to %s: %d days overdue", reply.Num, reply.Recipient, reply.DaysOverdue)
+				if reply.Deadline != "" {
+					line += fmt.Sprintf(" (sent %s, deadline %s)", reply.Sent, reply.Deadline)
+				}
+				if reply.Prolongation {
+					line += ", extension requested"
+				}
+				results = append(results, line, "  "+reply.Title)
+			}
+		}
+	}
+
+	var notes []string
+	if truncated {
+		notes = append(notes, fmt.Sprintf("Only the %d most recently received delayed interpellations were analyzed; raise max_items to cover older ones.", maxItems))
+	}
+	notes = append(notes, "Delays are computed by the Sejm API per recipient and reset once that recipient replies; an extension requested by a ministry does not stop the count.")
+
+	response := StandardResponse{
+		Operation: "Unanswered Interpellations",
+		Status:    "Retrieved Successfully",
+		Summary:   summary,
+		Data:      results,
+		NextActions: []string{
+			"Interpellation details and replies: sejm_get_interpellation_details with term and num",
+			"One ministry only: add recipient (e.g., 'zdrowia')",
+			"Oldest cases only: add min_days_overdue='90'",
+			"Structured output: add format='json'",
+		},
+		Note: strings.Join(notes, " "),
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestOverdueBucket(t *testing.T) {
+	for days, expected := range map[int]string{1: "1-29 days", 29: "1-29 days", 30: "30-59 days", 89: "60-89 days", 90: "90+ days", 400: "90+ days"} {
+		if got := overdueBuckets[overdueBucket(days)].label; got != expected {
+			t.Errorf("overdueBucket(%d) = %s, expected %s", days, got, expected)
+		}
+	}
+}
+
+func TestHandleGetUnansweredInterpellations(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/interpellations": `[
+			{"num": 101, "title": "w sprawie kolejek do specjalistów", "sentDate": "2025-01-10", "answerDelayedDays": 120,
+				"recipientDetails": [
+					{"name": "minister zdrowia", "sent": "2025-01-10", "answerDelayedDays": 120},
+					{"name": "minister finansów", "sent": "2025-01-10", "answerDelayedDays": 0}
+				],
+				"replies": [{"from": "minister zdrowia", "prolongation": true}]},
+			{"num": 102, "title": "w sprawie szpitali powiatowych", "sentDate": "2025-03-01", "answerDelayedDays": 45,
+				"recipientDetails": [{"name": "minister zdrowia", "sent": "2025-03-01", "answerDelayedDays": 45}]},
+			{"num": 103, "title": "w sprawie podatku od nieruchomości", "sentDate": "2025-04-01", "answerDelayedDays": 10,
+				"to": ["minister finansów"]}
+		]`,
+	})
+
+	result, err := server.handleGetUnansweredInterpellations(context.Background(), createMockRequest(map[string]interface{}{}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Delayed interpellations analyzed: 3",
+		"Replies past the 21-day deadline: 3, from 2 recipients",
+		"Overdue by 1-29 days: 1, 30-59 days: 1, 60-89 days: 0, 90+ days: 1",
+		"• minister zdrowia: 2 overdue (30-59 days: 1, 90+ days: 1; longest 120 days, mean 82 days)",
+		"• minister finansów: 1 overdue (1-29 days: 1; longest 10 days, mean 10 days)",
+		"• Interpellation 101 to minister zdrowia: 120 days overdue (sent 2025-01-10, deadline 2025-01-31), extension requested",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+
+	result, _ = server.handleGetUnansweredInterpellations(context.Background(), createMockRequest(map[string]interface{}{
+		"recipient": "FINANSÓW", "min_days_overdue": "5",
+	}))
+	content = extractTextContent(result)
+	if !strings.Contains(content, "Replies past the 21-day deadline: 1, from 1 recipients") || strings.Contains(content, "minister zdrowia") {
+		t.Errorf("Expected only the ministry of finance, got: %s", content)
+	}
+}
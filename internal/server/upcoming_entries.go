@@ -0,0 +1,261 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// defaultUpcomingDays is the window of eli_get_upcoming_entries when days is not given
+	defaultUpcomingDays = 30
+	// maxUpcomingDays bounds the window to one year ahead
+	maxUpcomingDays = 365
+	// defaultUpcomingActs and maxUpcomingActs bound the acts fetched for the window
+	defaultUpcomingActs = 200
+	maxUpcomingActs     = 500
+)
+
+// upcomingEntry is an act entering into force within the window
+type upcomingEntry struct {
+	Date      string `json:"entryIntoForce"`
+	Address   string `json:"address"`
+	Display   string `json:"displayAddress,omitempty"`
+	Publisher string `json:"publisher"`
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    string `json:"status,omitempty"`
+}
+
+// upcomingGroup lists the acts of one publisher and type
+type upcomingGroup struct {
+	Publisher string          `json:"publisher"`
+	Type      string          `json:"type"`
+	Acts      []upcomingEntry `json:"acts"`
+}
+
+// groupUpcomingEntries keeps the acts entering into force between from and to (inclusive) and groups them by
+// publisher and type; groups and the acts in them are ordered by date. Acts whose date is unknown are counted
+// separately.
+func groupUpcomingEntries(acts []eli.Act, from, to time.Time) ([]upcomingGroup, int) {
+	groups := make(map[string]*upcomingGroup)
+	undated := 0
+	for _, act := range acts {
+		validity := newActValidity(act)
+		if validity.from.IsZero() {
+			undated++
+			continue
+		}
+		date := validity.from.Format("2006-01-02")
+		if date < from.Format("2006-01-02") || date > to.Format("2006-01-02") {
+			continue
+		}
+		entry := upcomingEntry{
+			Date:      date,
+			Address:   actAddress(act),
+			Display:   stringValue(act.DisplayAddress),
+			Publisher: valueOrDefault(stringValue(act.Publisher), "Unknown"),
+			Type:      valueOrDefault(stringValue(act.Type), "Unknown type"),
+			Title:     valueOrDefault(stringValue(act.Title), "No title"),
+			Status:    stringValue(act.Status),
+		}
+		key := entry.Publisher + "\x00" + entry.Type
+		if groups[key] == nil {
+			groups[key] = &upcomingGroup{Publisher: entry.Publisher, Type: entry.Type}
+		}
+		groups[key].Acts = append(groups[key].Acts, entry)
+	}
+
+	result := make([]upcomingGroup, 0, len(groups))
+	for _, group := range groups {
+		sort.SliceStable(group.Acts, func(i, j int) bool {
+			if group.Acts[i].Date != group.Acts[j].Date {
+				return group.Acts[i].Date < group.Acts[j].Date
+			}
+			return group.Acts[i].Address < group.Acts[j].Address
+		})
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Publisher != result[j].Publisher {
+			return result[i].Publisher < result[j].Publisher
+		}
+		return result[i].Type < result[j].Type
+	})
+	return result, undated
+}
+
+// completeEntryDates fetches full metadata for search hits that carry no entry-into-force date
+func (s *SejmServer) completeEntryDates(ctx context.Context, acts []eli.Act) ([]eli.Act, int) {
+	var missing []eli.Act
+	var positions []int
+	for i, act := range acts {
+		if newActValidity(act).from.IsZero() {
+			missing = append(missing, act)
+			positions = append(positions, i)
+		}
+	}
+	if len(missing) == 0 {
+		return acts, 0
+	}
+	detailed, failures := s.fetchActsDetails(ctx, missing)
+	completed := append([]eli.Act(nil), acts...)
+	for i, position := range positions {
+		completed[position] = detailed[i]
+	}
+	return completed, failures
+}
+
+func (s *SejmServer) handleGetUpcomingEntries(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("eli_get_upcoming_entries called", slog.Any("arguments", request.Params.Arguments))
+
+	from, err := parseDefectionDate("from", request.GetString("from", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if from.IsZero() {
+		now := time.Now()
+		from = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	days := defaultUpcomingDays
+	if value := request.GetString("days", ""); value != "" {
+		days, err = strconv.Atoi(value)
+		if err != nil || days < 1 || days > maxUpcomingDays {
+			return mcp.NewToolResultError(fmt.Sprintf("Parameter 'days' must be a number between 1 and %d (e.g., 30, 60 or 90).", maxUpcomingDays)), nil
+		}
+	}
+	limit := defaultUpcomingActs
+	if value := request.GetString("limit", ""); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxUpcomingActs {
+			return mcp.NewToolResultError(fmt.Sprintf("Parameter 'limit' must be a number between 1 and %d.", maxUpcomingActs)), nil
+		}
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+	to := from.AddDate(0, 0, days-1)
+
+	params := map[string]string{
+		"dateEffectFrom": from.Format("2006-01-02"),
+		"dateEffectTo":   to.Format("2006-01-02"),
+		"limit":          strconv.Itoa(limit),
+	}
+	publisher := strings.ToUpper(strings.TrimSpace(request.GetString("publisher", "")))
+	if publisher != "" {
+		params["publisher"] = publisher
+	}
+	docType := strings.TrimSpace(request.GetString("type", ""))
+	if docType != "" {
+		params["type"] = docType
+	}
+
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/acts/search", s.eliBaseURL), params)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to search Polish legal acts database: %v. Please verify your search parameters are valid.", err)), nil
+	}
+	var searchResult struct {
+		Items []eli.Act `json:"items"`
+		Count int       `json:"count"`
+	}
+	if err := s.decodeAPIResponse(data, &searchResult); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse legal acts search results: %v.", err)), nil
+	}
+
+	// Search hits may lack the entry-into-force date; the act metadata has it
+	acts, failures := s.completeEntryDates(ctx, searchResult.Items)
+	groups, undated := groupUpcomingEntries(acts, from, to)
+	total := 0
+	for _, group := range groups {
+		total += len(group.Acts)
+	}
+
+	window := fmt.Sprintf("%s to %s (%d days)", from.Format("2006-01-02"), to.Format("2006-01-02"), days)
+	if format == "json" {
+		result, _ := json.MarshalIndent(map[string]interface{}{
+			"from":      from.Format("2006-01-02"),
+			"to":        to.Format("2006-01-02"),
+			"total":     total,
+			"matched":   searchResult.Count,
+			"fetched":   len(searchResult.Items),
+			"undated":   undated,
+			"publisher": publisher,
+			"type":      docType,
+			"groups":    groups,
+		}, "", "  ")
+		return mcp.NewToolResultText(string(result)), nil
+	}
+
+	summary := []string{
+		fmt.Sprintf("Entry into force window: %s", window),
+		fmt.Sprintf("Acts entering into force: %d", total),
+	}
+	if publisher != "" || docType != "" {
+		summary = append(summary, fmt.Sprintf("Filters: publisher %s, type %s", valueOrDefault(publisher, "any"), valueOrDefault(docType, "any")))
+	}
+	publisherCounts := make(map[string]int)
+	var publishers []string
+	for _, group := range groups {
+		if publisherCounts[group.Publisher] == 0 {
+			publishers = append(publishers, group.Publisher)
+		}
+		publisherCounts[group.Publisher] += len(group.Acts)
+	}
+	for _, p := range publishers {
+		summary = append(summary, fmt.Sprintf("%s: %d acts", p, publisherCounts[p]))
+	}
+
+	var results []string
+	for _, group := range groups {
+		if len(results) > 0 {
+			results = append(results, "")
+		}
+		results = append(results, fmt.Sprintf("%s — %s (%d):", group.Publisher, group.Type, len(group.Acts)))
+		for _, entry := range group.Acts {
+			line := fmt.Sprintf("• %s: %s", entry.Date, valueOrDefault(entry.Display, entry.Address))
+			line += fmt.Sprintf(" [%s] %s", entry.Address, entry.Title)
+			results = append(results, line)
+		}
+	}
+
+	status := "Retrieved Successfully"
+	if total == 0 {
+		status = "No Results Found"
+		results = append(results, fmt.Sprintf("No act enters into force between %s. Try a longer window with days=60 or days=90.", window))
+	}
+	var notes []string
+	if searchResult.Count > len(searchResult.Items) {
+		notes = append(notes, fmt.Sprintf("Only %d of %d matching acts were fetched; raise limit or narrow the window with publisher or type.", len(searchResult.Items), searchResult.Count))
+	}
+	if undated > 0 {
+		notes = append(notes, fmt.Sprintf("%d matching acts have no entry-into-force date in their metadata and are not listed.", undated))
+	}
+	if failures > 0 {
+		notes = append(notes, fmt.Sprintf("Details of %d acts could not be retrieved, so their dates may be missing.", failures))
+	}
+	notes = append(notes, "Dates are the entry into force of the act as a whole; individual provisions may apply later.")
+
+	response := StandardResponse{
+		Operation: "Upcoming Entries Into Force",
+		Status:    status,
+		Summary:   summary,
+		Data:      results,
+		NextActions: []string{
+			"Act metadata: eli_get_act_details with publisher, year and position",
+			"Text of an act: eli_get_act_text with publisher, year and position",
+			"Acts it amends: eli_get_act_references with publisher, year and position",
+			"Structured output: add format='json'",
+		},
+		Note: strings.Join(notes, " "),
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
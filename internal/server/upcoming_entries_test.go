@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+)
+
+func TestGroupUpcomingEntries(t *testing.T) {
+	from, _ := time.Parse("2006-01-02", "2026-01-01")
+	to := from.AddDate(0, 0, 29)
+	var acts []eli.Act
+	if err := json.Unmarshal([]byte(`[
+		{"publisher": "MP", "type": "Obwieszczenie", "title": "Obwieszczenie", "entryIntoForce": "2026-01-05"},
+		{"publisher": "DU", "type": "Ustawa", "title": "Ustawa B", "entryIntoForce": "2026-01-20"},
+		{"publisher": "DU", "type": "Ustawa", "title": "Ustawa A", "entryIntoForce": "2026-01-02"},
+		{"publisher": "DU", "type": "Ustawa", "title": "Ustawa C", "entryIntoForce": "2026-02-01"},
+		{"publisher": "DU", "type": "Rozporządzenie", "title": "Bez daty"}
+	]`), &acts); err != nil {
+		t.Fatal(err)
+	}
+
+	groups, undated := groupUpcomingEntries(acts, from, to)
+	if undated != 1 {
+		t.Errorf("Expected 1 undated act, got %d", undated)
+	}
+	if len(groups) != 2 || groups[0].Publisher != "DU" || groups[1].Publisher != "MP" {
+		t.Fatalf("Expected DU and MP groups, got %+v", groups)
+	}
+	if len(groups[0].Acts) != 2 || groups[0].Acts[0].Title != "Ustawa A" || groups[0].Acts[1].Title != "Ustawa B" {
+		t.Errorf("Expected DU acts ordered by date without the act outside the window, got %+v", groups[0].Acts)
+	}
+}
+
+func TestHandleGetUpcomingEntries(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/eli/acts/search": `{"count": 3, "items": [
+			{"publisher": "DU", "year": 2026, "pos": 10, "type": "Ustawa", "title": "Ustawa o zmianie ustawy o podatku", "entryIntoForce": "2026-03-01"},
+			{"publisher": "DU", "year": 2026, "pos": 12, "type": "Rozporządzenie", "title": "Rozporządzenie w sprawie stawek"},
+			{"publisher": "MP", "year": 2026, "pos": 5, "type": "Uchwała", "title": "Uchwała Sejmu", "entryIntoForce": "2026-02-10"}
+		]}`,
+		"/eli/acts/DU/2026/12": `{"publisher": "DU", "year": 2026, "pos": 12, "type": "Rozporządzenie", "title": "Rozporządzenie w sprawie stawek",
+			"entryIntoForce": "2026-02-15"}`,
+	})
+
+	result, err := server.handleGetUpcomingEntries(context.Background(), createMockRequest(map[string]interface{}{
+		"from": "2026-02-01", "days": "60",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Entry into force window: 2026-02-01 to 2026-04-01 (60 days)",
+		"Acts entering into force: 3",
+		"DU: 2 acts",
+		"DU — Rozporządzenie (1):",
+		"• 2026-02-15: DU/2026/12 [DU/2026/12] Rozporządzenie w sprawie stawek",
+		"MP — Uchwała (1):",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+
+	result, _ = server.handleGetUpcomingEntries(context.Background(), createMockRequest(map[string]interface{}{"days": "400"}))
+	if !result.IsError {
+		t.Error("Expected an error for a window longer than a year")
+	}
+}
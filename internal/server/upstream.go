@@ -0,0 +1,128 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// fixtureSuffix is appended to the request path to form a fixture file name, so that a list endpoint
+// (sejm/term10/MP.response) and its detail endpoints (sejm/term10/MP/1.response) can coexist
+const fixtureSuffix = ".response"
+
+// NormalizeBaseURL validates an upstream API base URL and strips its trailing slash. An empty value is
+// returned unchanged so that the caller falls back to the default API.
+func NormalizeBaseURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URL '%s': %w", raw, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("invalid base URL '%s': must be an absolute http or https URL", raw)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", fmt.Errorf("invalid base URL '%s': must not contain a query or fragment", raw)
+	}
+	return strings.TrimRight(raw, "/"), nil
+}
+
+// fixturePath maps a request URL to its fixture file. The host is ignored, so fixtures recorded against
+// one mirror replay for any other; the query string, when present, becomes part of the file name.
+func fixturePath(dir string, u *url.URL, withQuery bool) string {
+	name := path.Clean("/" + u.Path)
+	if withQuery && u.RawQuery != "" {
+		name += "@" + u.Query().Encode()
+	}
+	return filepath.Join(dir, filepath.FromSlash(name)) + fixtureSuffix
+}
+
+// fixtureTransport answers API requests from recorded fixture files instead of the network. A fixture
+// recorded with the exact query is preferred; otherwise the fixture for the bare path is used.
+type fixtureTransport struct {
+	dir    string
+	logger *slog.Logger
+}
+
+func (t *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for _, withQuery := range []bool{true, false} {
+		file := fixturePath(t.dir, req.URL, withQuery)
+		body, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		t.logger.Debug("Serving mock fixture", slog.String("url", req.URL.String()), slog.String("file", file))
+		return fixtureResponse(req, http.StatusOK, contentTypeForPath(req.URL.Path), body), nil
+	}
+
+	t.logger.Warn("No mock fixture for request", slog.String("url", req.URL.String()), slog.String("file", fixturePath(t.dir, req.URL, false)))
+	message := fmt.Sprintf(`{"error":"no recorded fixture for %s"}`, req.URL.Path)
+	return fixtureResponse(req, http.StatusNotFound, "application/json", []byte(message)), nil
+}
+
+// recordingTransport forwards requests upstream and saves successful responses as fixtures for fixtureTransport
+type recordingTransport struct {
+	dir       string
+	transport http.RoundTripper
+	logger    *slog.Logger
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	file := fixturePath(t.dir, req.URL, true)
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		t.logger.Warn("Failed to create fixture directory", slog.String("file", file), slog.Any("error", err))
+		return resp, nil
+	}
+	if err := os.WriteFile(file, body, 0o644); err != nil {
+		t.logger.Warn("Failed to record fixture", slog.String("file", file), slog.Any("error", err))
+		return resp, nil
+	}
+	t.logger.Debug("Recorded fixture", slog.String("url", req.URL.String()), slog.String("file", file))
+	return resp, nil
+}
+
+// contentTypeForPath guesses the content type of a fixture from the extension of the API path;
+// endpoints without an extension return JSON
+func contentTypeForPath(urlPath string) string {
+	if contentType := mime.TypeByExtension(path.Ext(urlPath)); contentType != "" {
+		return contentType
+	}
+	return "application/json"
+}
+
+func fixtureResponse(req *http.Request, status int, contentType string, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{contentType}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
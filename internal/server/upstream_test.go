@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeBaseURL(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"", "", false},
+		{"https://mirror.example/sejm-api/", "https://mirror.example/sejm-api", false},
+		{" http://localhost:8081 ", "http://localhost:8081", false},
+		{"mirror.example", "", true},
+		{"ftp://mirror.example", "", true},
+		{"https://mirror.example/api?token=1", "", true},
+	}
+
+	for _, tc := range testCases {
+		got, err := NormalizeBaseURL(tc.input)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("NormalizeBaseURL(%q) error = %v, wantErr %v", tc.input, err, tc.wantErr)
+			continue
+		}
+		if got != tc.expected {
+			t.Errorf("NormalizeBaseURL(%q) = %q, expected %q", tc.input, got, tc.expected)
+		}
+	}
+}
+
+func TestRecordAndMockFixtures(t *testing.T) {
+	requests := 0
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/mirror/sejm/term10/MP" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":1,"firstLastName":"Jan Kowalski","club":"KO","active":true,"districtName":"Warszawa"}]`))
+	}))
+
+	fixtures := t.TempDir()
+	recorder := NewSejmServerWithConfig(Config{SejmBaseURL: mirror.URL + "/mirror/", RecordDir: fixtures})
+	recorded, err := recorder.handleGetMPs(context.Background(), createMockRequest(map[string]interface{}{"term": "10"}))
+	if err != nil || recorded.IsError {
+		t.Fatalf("Recording call failed: %v %v", err, extractTextContent(recorded))
+	}
+	mirror.Close()
+	if requests != 1 {
+		t.Fatalf("Expected 1 request to the mirror, got %d", requests)
+	}
+	if _, err := os.Stat(filepath.Join(fixtures, "mirror", "sejm", "term10", "MP"+fixtureSuffix)); err != nil {
+		t.Fatalf("Expected recorded fixture: %v", err)
+	}
+
+	// The mirror is gone; mock mode must answer from the recorded fixture alone
+	mock := NewSejmServerWithConfig(Config{SejmBaseURL: mirror.URL + "/mirror", MockDir: fixtures})
+	replayed, err := mock.handleGetMPs(context.Background(), createMockRequest(map[string]interface{}{"term": "10"}))
+	if err != nil || replayed.IsError {
+		t.Fatalf("Mock call failed: %v %v", err, extractTextContent(replayed))
+	}
+	if extractTextContent(replayed) != extractTextContent(recorded) {
+		t.Errorf("Mock output differs from recorded output:\n%s\n---\n%s", extractTextContent(replayed), extractTextContent(recorded))
+	}
+	if !strings.Contains(extractTextContent(replayed), "Jan Kowalski") {
+		t.Errorf("Expected MP from fixture in output, got: %s", extractTextContent(replayed))
+	}
+
+	missing, err := mock.handleGetMPs(context.Background(), createMockRequest(map[string]interface{}{"term": "9"}))
+	if err != nil || !missing.IsError {
+		t.Errorf("Expected an error result for a request without fixture, got: %v", extractTextContent(missing))
+	}
+}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+// maxVotingContextPrints caps the prints resolved for one voting
+const maxVotingContextPrints = 5
+
+var (
+	// votingPrintListPattern finds print references in voting titles, e.g. "druk nr 123" or "druki nr 123, 145 i 145-A"
+	votingPrintListPattern = regexp.MustCompile(`(?i)druk(?:i|u|ów)?\s+nr\s+(\d+(?:-[a-z0-9]+)?(?:(?:\s*,\s*|\s+i\s+|\s+oraz\s+)\d+(?:-[a-z0-9]+)?)*)`)
+	// votingPrintNumberPattern splits a print list into numbers
+	votingPrintNumberPattern = regexp.MustCompile(`(?i)\d+(?:-[a-z0-9]+)?`)
+	// votingPrintLinkPattern reads print numbers from API links to prints
+	votingPrintLinkPattern = regexp.MustCompile(`/prints/([0-9]+(?:-[A-Za-z0-9]+)?)`)
+)
+
+// votingContextPrint is a print a voting refers to, with the legislative process it belongs to
+type votingContextPrint struct {
+	Number  string `json:"number"`
+	Title   string `json:"title,omitempty"`
+	Process string `json:"process,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// votingContextProcess is a legislative process a voting belongs to
+type votingContextProcess struct {
+	Number string `json:"number"`
+	Title  string `json:"title,omitempty"`
+	Passed bool   `json:"passed"`
+}
+
+// votingContext tells what a voting was about: the prints named in it and their legislative processes
+type votingContext struct {
+	Prints    []votingContextPrint   `json:"prints"`
+	Processes []votingContextProcess `json:"processes,omitempty"`
+}
+
+// votingPrintNumbers returns the print numbers referenced by a voting's links, title, topic and description,
+// each once and in order of appearance
+func votingPrintNumbers(voting sejm.VotingDetails) []string {
+	var numbers []string
+	add := func(number string) {
+		number = strings.ToUpper(number)
+		if !containsString(numbers, number) {
+			numbers = append(numbers, number)
+		}
+	}
+	if voting.Links != nil {
+		for _, link := range *voting.Links {
+			if fields, ok := link.(map[string]interface{}); ok {
+				if match := votingPrintLinkPattern.FindStringSubmatch(fmt.Sprint(fields["href"])); match != nil {
+					add(match[1])
+				}
+			}
+		}
+	}
+	for _, text := range []*string{voting.Title, voting.Topic, voting.Description} {
+		if text == nil {
+			continue
+		}
+		for _, list := range votingPrintListPattern.FindAllStringSubmatch(*text, -1) {
+			for _, number := range votingPrintNumberPattern.FindAllString(list[1], -1) {
+				add(number)
+			}
+		}
+	}
+	return numbers
+}
+
+// resolveVotingContext looks up the prints a voting refers to and their legislative processes. Lookups that
+// fail are reported on the print instead of failing the voting.
+func (s *SejmServer) resolveVotingContext(ctx context.Context, term int, voting sejm.VotingDetails) votingContext {
+	result := votingContext{Prints: []votingContextPrint{}}
+	numbers := votingPrintNumbers(voting)
+	if len(numbers) > maxVotingContextPrints {
+		numbers = numbers[:maxVotingContextPrints]
+	}
+
+	var processes []string
+	for _, number := range numbers {
+		entry := votingContextPrint{Number: number}
+		p, err := s.fetchPrint(ctx, term, number)
+		if err != nil {
+			s.logger.Warn("Could not resolve print referenced by voting", slog.String("print", number), slog.Any("error", err))
+			entry.Error = err.Error()
+			result.Prints = append(result.Prints, entry)
+			continue
+		}
+		entry.Title = stringValue(p.Title)
+		// Processes are numbered after the print that started them
+		if p.ProcessPrint != nil && len(*p.ProcessPrint) > 0 {
+			entry.Process = (*p.ProcessPrint)[0]
+			if !containsString(processes, entry.Process) {
+				processes = append(processes, entry.Process)
+			}
+		}
+		result.Prints = append(result.Prints, entry)
+	}
+
+	for _, number := range processes {
+		data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/processes/%s", s.sejmBaseURL, term, number), nil)
+		if err != nil {
+			s.logger.Warn("Could not resolve process referenced by voting", slog.String("process", number), slog.Any("error", err))
+			result.Processes = append(result.Processes, votingContextProcess{Number: number})
+			continue
+		}
+		var process struct {
+			Title  string `json:"title"`
+			Passed bool   `json:"passed"`
+		}
+		if err := s.decodeAPIResponse(data, &process); err != nil {
+			result.Processes = append(result.Processes, votingContextProcess{Number: number})
+			continue
+		}
+		result.Processes = append(result.Processes, votingContextProcess{Number: number, Title: process.Title, Passed: process.Passed})
+	}
+	return result
+}
+
+// lines renders the context for text responses
+func (c votingContext) lines() []string {
+	if len(c.Prints) == 0 {
+		return []string{"No print is referenced in the voting title or topic (e.g. procedural votings)."}
+	}
+	var lines []string
+	for _, p := range c.Prints {
+		switch {
+		case p.Error != "":
+			lines = append(lines, fmt.Sprintf("Print %s: could not be retrieved (%s)", p.Number, p.Error))
+		case p.Process != "":
+			lines = append(lines, fmt.Sprintf("Print %s: %s (process %s)", p.Number, valueOrDefault(p.Title, "No title"), p.Process))
+		default:
+			lines = append(lines, fmt.Sprintf("Print %s: %s", p.Number, valueOrDefault(p.Title, "No title")))
+		}
+	}
+	for _, process := range c.Processes {
+		state := "in progress"
+		if process.Passed {
+			state = "passed"
+		}
+		lines = append(lines, fmt.Sprintf("Process %s: %s (%s)", process.Number, valueOrDefault(process.Title, "details unavailable"), state))
+	}
+	return lines
+}
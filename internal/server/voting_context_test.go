@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+func TestVotingPrintNumbers(t *testing.T) {
+	title := "Pkt 5. Sprawozdanie Komisji o rządowym projekcie ustawy (druki nr 123, 145 i 145-a)"
+	topic := "głosowanie nad całością projektu, druk nr 123"
+	links := []interface{}{map[string]interface{}{"href": "https://api.sejm.gov.pl/sejm/term10/prints/200", "rel": "print"}}
+	numbers := votingPrintNumbers(sejm.VotingDetails{Title: &title, Topic: &topic, Links: &links})
+	if strings.Join(numbers, ",") != "200,123,145,145-A" {
+		t.Errorf("Unexpected print numbers: %v", numbers)
+	}
+
+	procedural := "Wniosek o przerwę"
+	if numbers := votingPrintNumbers(sejm.VotingDetails{Title: &procedural}); len(numbers) != 0 {
+		t.Errorf("Expected no prints for a procedural voting, got %v", numbers)
+	}
+}
+
+func TestHandleGetVotingDetailsContext(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/votings/7/12":  `{"sitting": 7, "votingNumber": 12, "title": "Pkt 3. Projekt ustawy o podatku (druki nr 100 i 100-A)", "topic": "całość projektu", "votes": [{"MP": 1, "vote": "YES"}]}`,
+		"/sejm/term10/prints/100":    `{"number": "100", "title": "Rządowy projekt ustawy o podatku", "processPrint": ["100"]}`,
+		"/sejm/term10/prints/100-A":  `{"number": "100-A", "title": "Sprawozdanie komisji", "processPrint": ["100"]}`,
+		"/sejm/term10/processes/100": `{"number": "100", "title": "Rządowy projekt ustawy o podatku", "passed": true}`,
+	})
+
+	result, err := server.handleGetVotingDetails(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "sitting": "7", "voting_number": "12",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{
+		"Related prints and legislative processes:",
+		`"number": "100-A"`,
+		`"title": "Sprawozdanie komisji"`,
+		`"process": "100"`,
+		`"passed": true`,
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+	if strings.Count(text, `"title": "Rządowy projekt ustawy o podatku"`) != 2 {
+		t.Errorf("Expected the process to be resolved once for both prints:\n%s", text)
+	}
+
+	result, _ = server.handleGetVotingDetails(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "sitting": "7", "voting_number": "12", "include_context": "false",
+	}))
+	if strings.Contains(extractTextContent(result), "Related prints") {
+		t.Errorf("Expected no context with include_context='false'")
+	}
+}
+
+func TestVotingContextLines(t *testing.T) {
+	lines := votingContext{
+		Prints:    []votingContextPrint{{Number: "100", Title: "Projekt", Process: "100"}, {Number: "999", Error: "resource not found"}},
+		Processes: []votingContextProcess{{Number: "100", Title: "Projekt"}},
+	}.lines()
+	expected := []string{
+		"Print 100: Projekt (process 100)",
+		"Print 999: could not be retrieved (resource not found)",
+		"Process 100: Projekt (in progress)",
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected lines: %v", lines)
+	}
+}
@@ -0,0 +1,273 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+// Scan scopes of title searches in sejm_search_votings without a voting index
+const (
+	votingScanRecent = "recent"
+	votingScanAll    = "all"
+)
+
+// recentVotingSittings is the number of most recent sittings scanned with scan_scope='recent'
+const recentVotingSittings = 20
+
+// votingIndexRefreshInterval is how long an index is trusted before the voting sessions list is checked again
+const votingIndexRefreshInterval = 10 * time.Minute
+
+// indexedSitting holds the votings of one sitting and the count the sessions list reported when they were fetched
+type indexedSitting struct {
+	VotingsNum int           `json:"votingsNum"`
+	Votings    []sejm.Voting `json:"votings"`
+}
+
+// termVotingIndex is the index of one term, stored as a single JSON file
+type termVotingIndex struct {
+	Term      int                     `json:"term"`
+	UpdatedAt time.Time               `json:"updatedAt"`
+	Sittings  map[int]*indexedSitting `json:"sittings"`
+
+	checkedAt time.Time
+	// lock is held while the term is refreshed or searched; a refresh of one term does not block searches of others
+	lock chan struct{}
+}
+
+// votingIndex keeps voting titles and topics of whole terms on disk, so title searches need no sitting
+// downloads once a term is indexed. It is filled lazily on the first search in a term and refreshed
+// incrementally: only sittings whose voting count changed since they were indexed are downloaded again.
+type votingIndex struct {
+	mu     sync.Mutex // guards terms; each term is locked on its own
+	dir    string
+	terms  map[int]*termVotingIndex
+	logger *slog.Logger
+}
+
+// votingIndexStats describes the index state after a refresh
+type votingIndexStats struct {
+	Sittings  int
+	Votings   int
+	Refreshed int
+	Failed    int
+	UpdatedAt time.Time
+}
+
+// newVotingIndex creates an index stored in dir, or returns nil when dir is empty or cannot be created
+func newVotingIndex(dir string, logger *slog.Logger) *votingIndex {
+	if dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		logger.Warn("Cannot create voting index directory, title searches will scan recent sittings", slog.String("dir", dir), slog.Any("error", err))
+		return nil
+	}
+	return &votingIndex{dir: dir, terms: make(map[int]*termVotingIndex), logger: logger}
+}
+
+func (idx *votingIndex) path(term int) string {
+	return filepath.Join(idx.dir, fmt.Sprintf("votings-term%d.json", term))
+}
+
+// load returns the in-memory index of a term, reading it from disk on first use
+func (idx *votingIndex) load(term int) *termVotingIndex {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if index, ok := idx.terms[term]; ok {
+		return index
+	}
+	index := &termVotingIndex{Term: term, Sittings: make(map[int]*indexedSitting)}
+	if data, err := os.ReadFile(idx.path(term)); err == nil {
+		var stored termVotingIndex
+		if err := json.Unmarshal(data, &stored); err != nil || stored.Sittings == nil {
+			idx.logger.Warn("Ignoring unreadable voting index", slog.String("file", idx.path(term)), slog.Any("error", err))
+		} else {
+			index = &stored
+		}
+	}
+	index.lock = make(chan struct{}, 1)
+	idx.terms[term] = index
+	return index
+}
+
+// persist writes the index of a term atomically, so a crash never leaves a truncated file behind
+func (idx *votingIndex) persist(index *termVotingIndex) {
+	data, err := json.Marshal(index)
+	if err != nil {
+		idx.logger.Warn("Failed to encode voting index", slog.Int("term", index.Term), slog.Any("error", err))
+		return
+	}
+	tmp := idx.path(index.Term) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		idx.logger.Warn("Failed to write voting index", slog.String("file", tmp), slog.Any("error", err))
+		return
+	}
+	if err := os.Rename(tmp, idx.path(index.Term)); err != nil {
+		idx.logger.Warn("Failed to replace voting index", slog.String("file", idx.path(index.Term)), slog.Any("error", err))
+	}
+}
+
+// stats summarizes the index of a term
+func (index *termVotingIndex) stats() votingIndexStats {
+	stats := votingIndexStats{Sittings: len(index.Sittings), UpdatedAt: index.UpdatedAt}
+	for _, sitting := range index.Sittings {
+		stats.Votings += len(sitting.Votings)
+	}
+	return stats
+}
+
+// search returns the indexed votings whose title or topic contains the query, newest first
+func (index *termVotingIndex) search(query string) []sejm.Voting {
+	query = strings.ToLower(query)
+	var matches []sejm.Voting
+	for _, sitting := range index.Sittings {
+		for _, voting := range sitting.Votings {
+			if strings.Contains(strings.ToLower(stringValue(voting.Title)), query) ||
+				strings.Contains(strings.ToLower(stringValue(voting.Topic)), query) {
+				matches = append(matches, voting)
+			}
+		}
+	}
+	key := func(v sejm.Voting) (int32, int32) {
+		var sitting, number int32
+		if v.Sitting != nil {
+			sitting = *v.Sitting
+		}
+		if v.VotingNumber != nil {
+			number = *v.VotingNumber
+		}
+		return sitting, number
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		si, ni := key(matches[i])
+		sj, nj := key(matches[j])
+		if si != sj {
+			return si > sj
+		}
+		return ni > nj
+	})
+	return matches
+}
+
+// searchVotingIndex refreshes the index of a term when it is due and searches it. A search waiting for another
+// refresh of the same term gives up when its context is done.
+func (s *SejmServer) searchVotingIndex(ctx context.Context, term int, query string, progress *progressReporter) ([]sejm.Voting, votingIndexStats, error) {
+	idx := s.votingIndex
+	index := idx.load(term)
+	if err := acquireSlot(ctx, index.lock); err != nil {
+		return nil, votingIndexStats{}, fmt.Errorf("gave up waiting for the voting index of term %d: %w", term, err)
+	}
+	defer func() { <-index.lock }()
+
+	refreshed, failed := 0, 0
+	if time.Since(index.checkedAt) >= votingIndexRefreshInterval {
+		var err error
+		refreshed, failed, err = s.refreshVotingIndex(ctx, index, progress)
+		if err != nil && len(index.Sittings) == 0 {
+			return nil, votingIndexStats{}, err
+		}
+		if err != nil {
+			// A stale index is more useful than no answer; the next search retries the refresh
+			s.logger.Warn("Voting index refresh failed, searching the stored index", slog.Int("term", term), slog.Any("error", err))
+		}
+		if refreshed > 0 {
+			idx.persist(index)
+		}
+	}
+
+	stats := index.stats()
+	stats.Refreshed, stats.Failed = refreshed, failed
+	return index.search(query), stats, nil
+}
+
+// refreshVotingIndex downloads the sittings that are missing from the index or whose voting count changed
+func (s *SejmServer) refreshVotingIndex(ctx context.Context, index *termVotingIndex, progress *progressReporter) (refreshed, failed int, err error) {
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/votings", s.sejmBaseURL, index.Term), nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to retrieve voting sessions: %w", err)
+	}
+	var sessions []struct {
+		Proceeding int `json:"proceeding"`
+		VotingsNum int `json:"votingsNum"`
+	}
+	if err := s.decodeAPIResponse(data, &sessions); err != nil {
+		return 0, 0, fmt.Errorf("failed to parse voting sessions: %w", err)
+	}
+
+	// The sessions list has one entry per sitting day; a sitting's votings are fetched together
+	counts := make(map[int]int)
+	for _, session := range sessions {
+		counts[session.Proceeding] += session.VotingsNum
+	}
+	var stale []int
+	for sitting, count := range counts {
+		if count == 0 {
+			continue
+		}
+		if indexed, ok := index.Sittings[sitting]; !ok || indexed.VotingsNum != count {
+			stale = append(stale, sitting)
+		}
+	}
+	sort.Ints(stale)
+
+	fetched := make([]*indexedSitting, len(stale))
+	slots := make(chan struct{}, maxConcurrentBodyFetches)
+	var wg sync.WaitGroup
+	var progressMu sync.Mutex
+	done := 0
+	for i, sitting := range stale {
+		wg.Add(1)
+		go func(i, sitting int) {
+			defer wg.Done()
+			if acquireSlot(ctx, slots) != nil {
+				return
+			}
+			defer func() { <-slots }()
+			defer func() {
+				progressMu.Lock()
+				done++
+				progress.report(done, len(stale), fmt.Sprintf("Indexed sitting %d (%d of %d)", sitting, done, len(stale)))
+				progressMu.Unlock()
+			}()
+			data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/votings/%d", s.sejmBaseURL, index.Term, sitting), nil)
+			if err != nil {
+				s.logger.Warn("Failed to index sitting votings", slog.Int("term", index.Term), slog.Int("sitting", sitting), slog.Any("error", err))
+				return
+			}
+			var votings []sejm.Voting
+			if err := s.decodeAPIResponse(data, &votings); err != nil {
+				s.logger.Warn("Failed to parse sitting votings for the index", slog.Int("term", index.Term), slog.Int("sitting", sitting), slog.Any("error", err))
+				return
+			}
+			fetched[i] = &indexedSitting{VotingsNum: counts[sitting], Votings: votings}
+		}(i, sitting)
+	}
+	wg.Wait()
+
+	for i, sitting := range stale {
+		if fetched[i] == nil {
+			failed++
+			continue
+		}
+		index.Sittings[sitting] = fetched[i]
+		refreshed++
+	}
+	if refreshed > 0 {
+		index.UpdatedAt = time.Now()
+	}
+	// Sittings that failed are retried on the next search instead of waiting for the refresh interval
+	if failed == 0 {
+		index.checkedAt = time.Now()
+	}
+	return refreshed, failed, nil
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearchVotingIndex(t *testing.T) {
+	fixtures := map[string]string{
+		"/sejm/term10/votings": `[
+			{"date": "2024-01-10", "proceeding": 3, "votingsNum": 1},
+			{"date": "2024-01-11", "proceeding": 3, "votingsNum": 1},
+			{"date": "2024-02-07", "proceeding": 4, "votingsNum": 1}
+		]`,
+		"/sejm/term10/votings/3": `[
+			{"sitting": 3, "votingNumber": 1, "title": "Ustawa budżetowa na rok 2024", "topic": "budżet", "yes": 240, "no": 200},
+			{"sitting": 3, "votingNumber": 2, "title": "Ustawa o kolei", "topic": "transport", "yes": 300, "no": 100}
+		]`,
+		"/sejm/term10/votings/4": `[{"sitting": 4, "votingNumber": 1, "title": "Wniosek o odrzucenie projektu", "topic": "Ustawa budżetowa", "yes": 100, "no": 300}]`,
+	}
+	server := newServerWithFixtures(t, fixtures)
+	dir := t.TempDir()
+	server.votingIndex = newVotingIndex(dir, server.logger)
+
+	matches, stats, err := server.searchVotingIndex(context.Background(), 10, "BUDŻETOWA", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stats.Sittings != 2 || stats.Votings != 3 || stats.Refreshed != 2 {
+		t.Errorf("Unexpected index stats: %+v", stats)
+	}
+	if len(matches) != 2 || *matches[0].Sitting != 4 || *matches[1].Sitting != 3 {
+		t.Errorf("Expected the matches of both sittings, newest first, got %+v", matches)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "votings-term10.json")); err != nil {
+		t.Errorf("Expected the index to be written to disk: %v", err)
+	}
+
+	// A new server reads the stored index; only the sitting whose voting count changed is downloaded again
+	fixtures["/sejm/term10/votings"] = `[
+		{"date": "2024-01-10", "proceeding": 3, "votingsNum": 2},
+		{"date": "2024-02-07", "proceeding": 4, "votingsNum": 2}
+	]`
+	fixtures["/sejm/term10/votings/4"] = `[
+		{"sitting": 4, "votingNumber": 1, "title": "Wniosek o odrzucenie projektu", "topic": "Ustawa budżetowa"},
+		{"sitting": 4, "votingNumber": 2, "title": "Ustawa budżetowa – całość", "topic": "budżet"}
+	]`
+	reloaded := newServerWithFixtures(t, fixtures)
+	reloaded.votingIndex = newVotingIndex(dir, reloaded.logger)
+	matches, stats, err = reloaded.searchVotingIndex(context.Background(), 10, "budżetowa", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if stats.Refreshed != 1 || stats.Votings != 4 || len(matches) != 3 {
+		t.Errorf("Expected one sitting refreshed and three matches, got %+v and %d matches", stats, len(matches))
+	}
+
+	result, err := reloaded.searchVotingsByTitle(context.Background(), 10, "kolei", "", votingScanRecent, nil)
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	if content := extractTextContent(result); !strings.Contains(content, "Searched the voting index: 2 sittings, 4 votings (whole term") || !strings.Contains(content, "Ustawa o kolei") {
+		t.Errorf("Expected the search to use the index, got: %s", content)
+	}
+}
+
+func TestSearchVotingsByTitleScanScope(t *testing.T) {
+	var sessions []string
+	fixtures := map[string]string{}
+	for sitting := 1; sitting <= 22; sitting++ {
+		// Two sitting days per sitting; the sitting must still be scanned only once
+		sessions = append(sessions, fmt.Sprintf(`{"proceeding": %d, "votingsNum": 1}`, sitting), fmt.Sprintf(`{"proceeding": %d, "votingsNum": 1}`, sitting))
+		fixtures[fmt.Sprintf("/sejm/term10/votings/%d", sitting)] = fmt.Sprintf(`[{"sitting": %d, "votingNumber": 1, "title": "Ustawa nr %d o kolei"}]`, sitting, sitting)
+	}
+	fixtures["/sejm/term10/votings"] = "[" + strings.Join(sessions, ",") + "]"
+	server := newServerWithFixtures(t, fixtures)
+
+	result, _ := server.handleSearchVotings(context.Background(), createMockRequest(map[string]interface{}{"term": "10", "title": "kolei"}))
+	content := extractTextContent(result)
+	if !strings.Contains(content, "Searched 20 of 22 sittings with votings (the 20 most recent; older sittings were NOT searched, use scan_scope='all'") {
+		t.Errorf("Expected the partial scan to be reported, got: %s", content)
+	}
+	if strings.Contains(content, "Ustawa nr 2 o kolei") {
+		t.Errorf("Expected old sittings to be skipped, got: %s", content)
+	}
+
+	result, _ = server.handleSearchVotings(context.Background(), createMockRequest(map[string]interface{}{"term": "10", "title": "kolei", "scan_scope": "all", "limit": "50"}))
+	content = extractTextContent(result)
+	if !strings.Contains(content, "Searched 22 of 22 sittings with votings (whole term)") || !strings.Contains(content, "Found 22 matching voting records") {
+		t.Errorf("Expected the whole term to be scanned once per sitting, got: %s", content)
+	}
+
+	result, _ = server.handleSearchVotings(context.Background(), createMockRequest(map[string]interface{}{"term": "10", "title": "kolei", "scan_scope": "all", "limit": "5"}))
+	if content = extractTextContent(result); !strings.Contains(content, "Found 22 matching voting records (showing 5)") {
+		t.Errorf("Expected the total match count before the limit, got: %s", content)
+	}
+
+	result, _ = server.handleSearchVotings(context.Background(), createMockRequest(map[string]interface{}{"term": "10", "title": "kolei", "scan_scope": "everything"}))
+	if !result.IsError {
+		t.Error("Expected an error for an unknown scan_scope")
+	}
+}
+
+func TestSearchVotingIndexLocksPerTerm(t *testing.T) {
+	requested := make(chan struct{})
+	release := make(chan struct{})
+	mock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sejm/term9/votings":
+			close(requested)
+			<-release
+			fmt.Fprint(w, `[]`)
+		case "/sejm/term10/votings":
+			fmt.Fprint(w, `[{"proceeding": 3, "votingsNum": 1}]`)
+		case "/sejm/term10/votings/3":
+			fmt.Fprint(w, `[{"sitting": 3, "votingNumber": 1, "title": "Ustawa o kolei"}]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mock.Close()
+	target, _ := url.Parse(mock.URL)
+	server := NewSejmServer()
+	server.client = &http.Client{Transport: &rewriteTransport{target: target}}
+	server.votingIndex = newVotingIndex(t.TempDir(), server.logger)
+
+	if _, _, err := server.searchVotingIndex(context.Background(), 10, "kolei", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	refreshed := make(chan error, 1)
+	go func() {
+		_, _, err := server.searchVotingIndex(context.Background(), 9, "kolei", nil)
+		refreshed <- err
+	}()
+	<-requested
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	matches, _, err := server.searchVotingIndex(ctx, 10, "kolei", nil)
+	if err != nil || len(matches) != 1 {
+		t.Errorf("Expected term 10 to be searched while term 9 is refreshed, got %v and %+v", err, matches)
+	}
+
+	waiting, cancelWaiting := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelWaiting()
+	if _, _, err := server.searchVotingIndex(waiting, 9, "kolei", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a search waiting for the refresh of term 9 to give up with its context, got %v", err)
+	}
+
+	close(release)
+	if err := <-refreshed; err != nil {
+		t.Errorf("Unexpected error refreshing term 9: %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+// pdfVoteRecord is one MP's vote read from the official voting PDF
+type pdfVoteRecord struct {
+	Name string         `json:"name"`
+	Club string         `json:"club,omitempty"`
+	Vote sejm.VoteValue `json:"vote"`
+}
+
+// pdfVoteValues maps the vote markers printed in voting PDFs to API vote values
+var pdfVoteValues = map[string]sejm.VoteValue{
+	"za":             sejm.VoteValueYES,
+	"pr.":            sejm.VoteValueNO,
+	"przeciw":        sejm.VoteValueNO,
+	"ws.":            sejm.VoteValueABSTAIN,
+	"wstrz.":         sejm.VoteValueABSTAIN,
+	"wstrzymał się":  sejm.VoteValueABSTAIN,
+	"wstrzymała się": sejm.VoteValueABSTAIN,
+	"ng.":            sejm.VoteValueABSENT,
+	"nie głosował":   sejm.VoteValueABSENT,
+	"nie głosowała":  sejm.VoteValueABSENT,
+	"nb.":            sejm.VoteValueABSENT,
+	"ob.":            sejm.VoteValuePRESENT,
+}
+
+var (
+	// pdfClubHeadingPattern matches a club heading line such as "PiS(235)" or "Koalicja Obywatelska (134)"
+	pdfClubHeadingPattern = regexp.MustCompile(`^\s*([^\s()\d][^()]{0,60}?)\s*\((\d+)\)`)
+	// pdfVoteEntryPattern matches "Surname Name marker", optionally numbered; several entries may share a line
+	// when the PDF is laid out in columns. Vote markers are lowercase, so summary lines ("Za - 233") are skipped.
+	pdfVoteEntryPattern = regexp.MustCompile(`(?:\d+\.?[ \t]+)?((?:\p{Lu}[\p{L}'’.-]*[ \t]+){1,3}\p{Lu}[\p{L}'’-]*)[ \t]+(` + pdfVoteMarkerPattern() + `)(?:[ \t]+|$)`)
+)
+
+// pdfVoteMarkerPattern builds the alternation of vote markers, longest first so that
+// "wstrzymał się" is not cut short by a shorter marker
+func pdfVoteMarkerPattern() string {
+	markers := make([]string, 0, len(pdfVoteValues))
+	for marker := range pdfVoteValues {
+		markers = append(markers, regexp.QuoteMeta(marker))
+	}
+	sort.Slice(markers, func(i, j int) bool {
+		if len(markers[i]) != len(markers[j]) {
+			return len(markers[i]) > len(markers[j])
+		}
+		return markers[i] < markers[j]
+	})
+	return strings.Join(markers, "|")
+}
+
+// parseVotingPDFRecords extracts MP-level votes from the text of a voting PDF. Club headings
+// assign the club to the entries that follow them; entries before any heading have no club.
+func parseVotingPDFRecords(pageTexts []string) []pdfVoteRecord {
+	var records []pdfVoteRecord
+	club := ""
+	for _, page := range pageTexts {
+		for _, line := range strings.Split(page, "\n") {
+			if heading := pdfClubHeadingPattern.FindStringSubmatch(line); heading != nil {
+				club = strings.TrimSpace(heading[1])
+				continue
+			}
+			for _, entry := range pdfVoteEntryPattern.FindAllStringSubmatch(line, -1) {
+				records = append(records, pdfVoteRecord{
+					Name: strings.Join(strings.Fields(entry[1]), " "),
+					Club: club,
+					Vote: pdfVoteValues[entry[2]],
+				})
+			}
+		}
+	}
+	return records
+}
+
+// fetchVotingPDFPages downloads the voting PDF and extracts the text of its pages
+func (s *SejmServer) fetchVotingPDFPages(ctx context.Context, term int, sitting, votingNumber string) ([]string, error) {
+	pdfEndpoint := fmt.Sprintf("%s/sejm/term%d/votings/%s/%s/pdf", s.sejmBaseURL, term, sitting, votingNumber)
+	pdfData, err := s.makeTextRequest(ctx, pdfEndpoint, "pdf")
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve voting PDF: %w", err)
+	}
+	return s.extractPDFPageTexts(ctx, pdfData)
+}
+
+// fetchVotingPDFRecords downloads the voting PDF and parses its MP-level votes
+func (s *SejmServer) fetchVotingPDFRecords(ctx context.Context, term int, sitting, votingNumber string) ([]pdfVoteRecord, error) {
+	pageTexts, err := s.fetchVotingPDFPages(ctx, term, sitting, votingNumber)
+	if err != nil {
+		return nil, err
+	}
+	return votingPDFRecords(pageTexts)
+}
+
+// votingPDFRecords parses the MP-level votes of the pages of a voting PDF
+func votingPDFRecords(pageTexts []string) ([]pdfVoteRecord, error) {
+	records := parseVotingPDFRecords(pageTexts)
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no MP votes recognized in the voting PDF")
+	}
+	return records, nil
+}
+
+// pdfRecordsToVotes converts parsed records to API votes. PDFs list MPs as "Surname Name", and
+// carry no MP IDs, so the MP field stays empty.
+func pdfRecordsToVotes(records []pdfVoteRecord) []sejm.Vote {
+	votes := make([]sejm.Vote, 0, len(records))
+	for _, record := range records {
+		vote := sejm.Vote{Vote: &record.Vote}
+		parts := strings.SplitN(record.Name, " ", 2)
+		vote.LastName = &parts[0]
+		if len(parts) > 1 {
+			vote.FirstName = &parts[1]
+		}
+		if record.Club != "" {
+			vote.Club = &record.Club
+		}
+		votes = append(votes, vote)
+	}
+	return votes
+}
+
+// summarizePDFRecords tallies parsed votes per club, in order of first appearance
+func summarizePDFRecords(records []pdfVoteRecord) []string {
+	var clubs []string
+	tallies := make(map[string]map[sejm.VoteValue]int)
+	for _, record := range records {
+		club := valueOrDefault(record.Club, "No club heading")
+		if tallies[club] == nil {
+			tallies[club] = make(map[sejm.VoteValue]int)
+			clubs = append(clubs, club)
+		}
+		tallies[club][record.Vote]++
+	}
+
+	lines := make([]string, 0, len(clubs))
+	for _, club := range clubs {
+		var counts []string
+		for _, value := range []sejm.VoteValue{sejm.VoteValueYES, sejm.VoteValueNO, sejm.VoteValueABSTAIN, sejm.VoteValueABSENT, sejm.VoteValuePRESENT} {
+			if count := tallies[club][value]; count > 0 {
+				counts = append(counts, fmt.Sprintf("%s %d", value, count))
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", club, strings.Join(counts, ", ")))
+	}
+	return lines
+}
+
+// votingPDFRecordsResponse renders parsed PDF votes as structured records
+func votingPDFRecordsResponse(sitting, votingNumber string, voting sejm.VotingDetails, records []pdfVoteRecord) StandardResponse {
+	summary := []string{fmt.Sprintf("Sitting %s, voting %s: %d MP votes parsed from the official PDF", sitting, votingNumber, len(records))}
+	if voting.Title != nil {
+		summary = append(summary, fmt.Sprintf("Title: %s", *voting.Title))
+	}
+	if voting.TotalVoted != nil {
+		summary = append(summary, fmt.Sprintf("Votes cast according to the API: %d", *voting.TotalVoted))
+	}
+	summary = append(summary, summarizePDFRecords(records)...)
+
+	data, _ := json.MarshalIndent(records, "", "  ")
+	return StandardResponse{
+		Operation: "Voting PDF Records",
+		Status:    "Retrieved Successfully",
+		Summary:   summary,
+		Data:      []string{string(data)},
+		NextActions: []string{
+			"Find an MP's line in the original document: sejm_search_voting_content with search_terms",
+			"Compare with club positions: sejm_find_defections",
+		},
+		Note: "Records are read from the PDF text layout and carry no MP IDs; names are printed as 'Surname Name'. Compare the per-club tallies with the printed club totals when precision matters.",
+	}
+}
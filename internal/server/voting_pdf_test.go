@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+const votingPDFText = `Sejm Rzeczypospolitej Polskiej
+Głosowanie nr 12
+na 5. posiedzeniu Sejmu w dniu 14 stycznia 2004 r.
+Głosowało - 5 Za - 2 Przeciw - 1 Wstrzymało się - 1 Nie głosowało - 1
+SLD(3) Głosowało - 3 Za - 2 Przeciw - 0 Wstrzymało się - 1
+1 Adamczyk Andrzej za 2 Bańkowska Anna Maria wstrzymała się
+3 Kowalski-Nowak Jan za
+PO(2) Głosowało - 1 Za - 0 Przeciw - 1 Nie głosowało - 1
+Zielińska Ewa pr. Żak Piotr ng.
+Strona 1 z 1`
+
+func TestParseVotingPDFRecords(t *testing.T) {
+	records := parseVotingPDFRecords([]string{votingPDFText})
+	expected := []pdfVoteRecord{
+		{Name: "Adamczyk Andrzej", Club: "SLD", Vote: sejm.VoteValueYES},
+		{Name: "Bańkowska Anna Maria", Club: "SLD", Vote: sejm.VoteValueABSTAIN},
+		{Name: "Kowalski-Nowak Jan", Club: "SLD", Vote: sejm.VoteValueYES},
+		{Name: "Zielińska Ewa", Club: "PO", Vote: sejm.VoteValueNO},
+		{Name: "Żak Piotr", Club: "PO", Vote: sejm.VoteValueABSENT},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %d records, got %d: %+v", len(expected), len(records), records)
+	}
+	for i, record := range records {
+		if record != expected[i] {
+			t.Errorf("Record %d: expected %+v, got %+v", i, expected[i], record)
+		}
+	}
+
+	tallies := strings.Join(summarizePDFRecords(records), "\n")
+	if !strings.Contains(tallies, "SLD: YES 2, ABSTAIN 1") || !strings.Contains(tallies, "PO: NO 1, ABSENT 1") {
+		t.Errorf("Unexpected tallies: %s", tallies)
+	}
+
+	votes := pdfRecordsToVotes(records[1:2])
+	if *votes[0].LastName != "Bańkowska" || *votes[0].FirstName != "Anna Maria" || *votes[0].Club != "SLD" {
+		t.Errorf("Unexpected vote conversion: %+v", votes[0])
+	}
+}
+
+func TestHandleGetVotingDetailsWithoutMPVotes(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term4/votings/5/12":     `{"sitting": 5, "votingNumber": 12, "title": "Pkt 3. Projekt ustawy", "totalVoted": 5}`,
+		"/sejm/term4/votings/5/12/pdf": "not a PDF",
+	})
+
+	result, err := server.handleGetVotingDetails(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "4", "sitting": "5", "voting_number": "12",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	if content := extractTextContent(result); !strings.Contains(content, "could not be read from the PDF") {
+		t.Errorf("Expected a note about the missing MP-level votes, got: %s", content)
+	}
+
+	result, _ = server.handleGetVotingDetails(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "4", "sitting": "5", "voting_number": "12", "format": "records",
+	}))
+	if !result.IsError || !strings.Contains(extractTextContent(result), "Failed to parse MP votes") {
+		t.Errorf("Expected an error for an unreadable PDF, got: %s", extractTextContent(result))
+	}
+}
@@ -0,0 +1,230 @@
+package server
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+// votingRollCall is the roll-call metadata of a voting: who chaired it, which legal item was voted on and how.
+// Sources tells where each field was read from: the API, the voting title, topic or description, or the
+// header of the official PDF.
+type votingRollCall struct {
+	Chair           string            `json:"chair,omitempty"`
+	ChairVote       string            `json:"chairVote,omitempty"`
+	Item            string            `json:"item,omitempty"`
+	Reading         int               `json:"reading,omitempty"`
+	Amendments      []string          `json:"amendments,omitempty"`
+	MinorityMotions []string          `json:"minorityMotions,omitempty"`
+	Method          string            `json:"method,omitempty"`
+	Majority        string            `json:"majority,omitempty"`
+	MajorityVotes   int32             `json:"majorityVotes,omitempty"`
+	Sources         map[string]string `json:"sources,omitempty"`
+	PDFError        string            `json:"pdfError,omitempty"`
+}
+
+var (
+	// votingReadingPattern finds the reading of a bill, e.g. "trzecie czytanie" or "w drugim czytaniu"
+	votingReadingPattern = regexp.MustCompile(`(?i)(pierwsz|drugi|trzeci)\p{L}*\s+czytani`)
+	// votingAmendmentPattern finds amendment numbers, e.g. "poprawka nr 5" or "poprawki 3, 7 i 12"
+	votingAmendmentPattern = regexp.MustCompile(`(?i)poprawk\p{L}*\s+(?:nr\s+)?(\d+(?:(?:\s*,\s*|\s+i\s+|\s+oraz\s+)\d+)*)`)
+	// votingMinorityMotionPattern finds minority motion numbers, e.g. "wniosek mniejszości nr 2"
+	votingMinorityMotionPattern = regexp.MustCompile(`(?i)wnios\p{L}*\s+mniejszości\s+(?:nr\s+)?(\d+(?:(?:\s*,\s*|\s+i\s+|\s+oraz\s+)\d+)*)`)
+	// votingChairPattern finds the presiding officer in a PDF header, e.g. "Przewodniczący: Wicemarszałek Sejmu Jan Nowak"
+	votingChairPattern = regexp.MustCompile(`(?i)przewodnicz(?:ący|ąca|y)(?:\s+obradom)?\s*[:\-–]\s*(.+)`)
+	// votingMethodPattern finds the voting method in a PDF header, e.g. "Głosowanie imienne"
+	votingMethodPattern = regexp.MustCompile(`(?i)głosowanie\s+(imienne|tajne|jawne|elektroniczne|kartkami)`)
+	// votingHeaderEndPattern matches the first line after the PDF header: the totals or a club heading
+	votingHeaderEndPattern = regexp.MustCompile(`(?i)^\s*głosowało|^\s*[^\s()\d][^()]{0,60}?\s*\(\d+\)`)
+	// votingListNumberPattern splits a list of amendments or motions into numbers
+	votingListNumberPattern = regexp.MustCompile(`\d+`)
+)
+
+// votingReadings maps the stem of an ordinal to the reading number
+var votingReadings = map[string]int{"pierwsz": 1, "drugi": 2, "trzeci": 3}
+
+// votingItemKinds recognizes the kind of item voted on by phrases of the title and topic, most specific first
+var votingItemKinds = []struct {
+	phrase string
+	kind   string
+}{
+	{"wniosek mniejszości", "minority motion"},
+	{"poprawk", "amendment"},
+	{"uchwał senatu", "Senate amendments"},
+	{"stanowisk senatu", "Senate amendments"},
+	{"wniosek o odrzucenie", "motion to reject"},
+	{"wniosek o przerw", "motion to adjourn"},
+	{"wniosek o odroczenie", "motion to adjourn"},
+	{"uzupełnienie porządku", "agenda"},
+	{"zmianę porządku", "agenda"},
+	{"zmiany porządku", "agenda"},
+	{"kworum", "quorum"},
+	{"wotum nieufności", "vote of no confidence"},
+	{"wotum zaufania", "vote of confidence"},
+	{"wybór", "election"},
+	{"powołanie", "appointment"},
+	{"odwołanie", "dismissal"},
+	{"całości", "whole bill"},
+	{"całość", "whole bill"},
+	{"projekt uchwały", "resolution"},
+}
+
+// votingMethods describes the API kinds of voting
+var votingMethods = map[sejm.VotingKind]string{
+	sejm.VotingKindELECTRONIC:  "electronic",
+	sejm.VotingKindTRADITIONAL: "traditional (by show of hands or ballot cards)",
+	sejm.VotingKindONLIST:      "on a list of candidates or options",
+}
+
+// parseVotingNumbers returns the numbers of a matched list, e.g. "3, 7 i 12"
+func parseVotingNumbers(pattern *regexp.Regexp, text string) []string {
+	var numbers []string
+	for _, match := range pattern.FindAllStringSubmatch(text, -1) {
+		for _, number := range votingListNumberPattern.FindAllString(match[1], -1) {
+			if !containsString(numbers, number) {
+				numbers = append(numbers, number)
+			}
+		}
+	}
+	return numbers
+}
+
+// parseVotingRollCall reads the roll-call metadata of a voting from the API fields and, when given, the pages
+// of its PDF. Fields are taken from the first text that has them, in the order topic, title, description.
+func parseVotingRollCall(voting sejm.VotingDetails, pdfPages []string) votingRollCall {
+	rollCall := votingRollCall{Sources: map[string]string{}}
+	if voting.Kind != nil {
+		rollCall.Method = valueOrDefault(votingMethods[*voting.Kind], string(*voting.Kind))
+		rollCall.Sources["method"] = "API"
+	}
+	if voting.MajorityType != nil {
+		rollCall.Majority = strings.ToLower(strings.ReplaceAll(string(*voting.MajorityType), "_", " "))
+		rollCall.Sources["majority"] = "API"
+	}
+	if voting.MajorityVotes != nil {
+		rollCall.MajorityVotes = *voting.MajorityVotes
+	}
+
+	texts := []struct {
+		source string
+		text   *string
+	}{{"topic", voting.Topic}, {"title", voting.Title}, {"description", voting.Description}}
+	for _, text := range texts {
+		if text.text == nil || strings.TrimSpace(*text.text) == "" {
+			continue
+		}
+		lower := strings.ToLower(*text.text)
+		if rollCall.Item == "" {
+			for _, kind := range votingItemKinds {
+				if strings.Contains(lower, kind.phrase) {
+					rollCall.Item = kind.kind
+					rollCall.Sources["item"] = text.source
+					break
+				}
+			}
+		}
+		if rollCall.Reading == 0 {
+			if match := votingReadingPattern.FindStringSubmatch(*text.text); match != nil {
+				rollCall.Reading = votingReadings[strings.ToLower(match[1])]
+				rollCall.Sources["reading"] = text.source
+			}
+		}
+		if rollCall.Amendments == nil {
+			if rollCall.Amendments = parseVotingNumbers(votingAmendmentPattern, *text.text); rollCall.Amendments != nil {
+				rollCall.Sources["amendments"] = text.source
+			}
+		}
+		if rollCall.MinorityMotions == nil {
+			if rollCall.MinorityMotions = parseVotingNumbers(votingMinorityMotionPattern, *text.text); rollCall.MinorityMotions != nil {
+				rollCall.Sources["minorityMotions"] = text.source
+			}
+		}
+	}
+
+	if len(pdfPages) > 0 {
+		for _, line := range strings.Split(pdfPages[0], "\n") {
+			if votingHeaderEndPattern.MatchString(line) {
+				break
+			}
+			if match := votingChairPattern.FindStringSubmatch(line); match != nil && rollCall.Chair == "" {
+				rollCall.Chair = strings.Join(strings.Fields(match[1]), " ")
+				rollCall.Sources["chair"] = "PDF header"
+			}
+			if match := votingMethodPattern.FindStringSubmatch(line); match != nil {
+				// The PDF names the method more precisely than the API kind
+				rollCall.Method = strings.ToLower(match[1])
+				rollCall.Sources["method"] = "PDF header"
+			}
+		}
+	}
+	if rollCall.Chair != "" && voting.Votes != nil {
+		rollCall.ChairVote = chairVote(rollCall.Chair, *voting.Votes)
+	}
+	return rollCall
+}
+
+// chairVote returns how the presiding officer voted, found by the last two words of the chair, which name
+// them after the office, e.g. "Wicemarszałek Sejmu Jan Nowak"
+func chairVote(chair string, votes []sejm.Vote) string {
+	words := strings.Fields(chair)
+	if len(words) < 2 {
+		return ""
+	}
+	first, last := normalizePolish(words[len(words)-2]), normalizePolish(words[len(words)-1])
+	for _, vote := range votes {
+		if vote.LastName == nil || vote.FirstName == nil || vote.Vote == nil {
+			continue
+		}
+		if normalizePolish(*vote.LastName) == last && strings.HasPrefix(normalizePolish(*vote.FirstName), first) {
+			return string(*vote.Vote)
+		}
+	}
+	return ""
+}
+
+// votingRollCallFromPDF reads the roll-call metadata of a voting with the header of its PDF. A PDF that could
+// not be read leaves the fields from the API, with the error on the result.
+func votingRollCallFromPDF(voting sejm.VotingDetails, pdfPages []string, pdfErr error) votingRollCall {
+	rollCall := parseVotingRollCall(voting, pdfPages)
+	if pdfErr != nil {
+		rollCall.PDFError = pdfErr.Error()
+	}
+	return rollCall
+}
+
+// lines renders roll-call metadata for text output
+func (r votingRollCall) lines() []string {
+	var lines []string
+	add := func(label, value, field string) {
+		if value == "" {
+			return
+		}
+		if source := r.Sources[field]; source != "" {
+			value += fmt.Sprintf(" (from the %s)", source)
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", label, value))
+	}
+	chair := r.Chair
+	if chair != "" && r.ChairVote != "" {
+		chair += ", voted " + r.ChairVote
+	}
+	add("Chair", chair, "chair")
+	add("Item voted on", r.Item, "item")
+	if r.Reading > 0 {
+		add("Reading", fmt.Sprintf("%d", r.Reading), "reading")
+	}
+	add("Amendments", strings.Join(r.Amendments, ", "), "amendments")
+	add("Minority motions", strings.Join(r.MinorityMotions, ", "), "minorityMotions")
+	add("Voting method", r.Method, "method")
+	majority := r.Majority
+	if majority != "" && r.MajorityVotes > 0 {
+		majority += fmt.Sprintf(", %d votes needed", r.MajorityVotes)
+	}
+	add("Majority", majority, "majority")
+	if r.PDFError != "" {
+		lines = append(lines, fmt.Sprintf("PDF header could not be read: %s", r.PDFError))
+	}
+	return lines
+}
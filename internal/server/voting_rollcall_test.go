@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+func TestParseVotingRollCall(t *testing.T) {
+	title := "Pkt 4. Rządowy projekt ustawy o podatku - trzecie czytanie (druki nr 100 i 100-A)"
+	topic := "głosowanie nad poprawkami 3, 7 i 12"
+	kind := sejm.VotingKindELECTRONIC
+	majority := sejm.VotingMajoritySIMPLEMAJORITY
+	majorityVotes := int32(215)
+	last, first, vote := "Nowak", "Jan", sejm.VoteValueNO
+	votes := []sejm.Vote{{LastName: &last, FirstName: &first, Vote: &vote}}
+	header := "Sejm Rzeczypospolitej Polskiej\nGłosowanie imienne nr 12\nPrzewodniczący: Wicemarszałek Sejmu Jan Nowak\n" + votingPDFText
+
+	rollCall := parseVotingRollCall(sejm.VotingDetails{
+		Title: &title, Topic: &topic, Kind: &kind, MajorityType: &majority, MajorityVotes: &majorityVotes, Votes: &votes,
+	}, []string{header})
+	if rollCall.Chair != "Wicemarszałek Sejmu Jan Nowak" || rollCall.ChairVote != "NO" {
+		t.Errorf("Unexpected chair: %+v", rollCall)
+	}
+	if rollCall.Item != "amendment" || rollCall.Sources["item"] != "topic" || rollCall.Reading != 3 || rollCall.Sources["reading"] != "title" {
+		t.Errorf("Unexpected item: %+v", rollCall)
+	}
+	if strings.Join(rollCall.Amendments, ",") != "3,7,12" || rollCall.MinorityMotions != nil {
+		t.Errorf("Unexpected amendments: %+v", rollCall)
+	}
+	if rollCall.Method != "imienne" || rollCall.Sources["method"] != "PDF header" || rollCall.Majority != "simple majority" {
+		t.Errorf("Unexpected method: %+v", rollCall)
+	}
+
+	lines := strings.Join(rollCall.lines(), "\n")
+	for _, expected := range []string{
+		"Chair: Wicemarszałek Sejmu Jan Nowak, voted NO (from the PDF header)",
+		"Amendments: 3, 7, 12 (from the topic)",
+		"Majority: simple majority, 215 votes needed (from the API)",
+	} {
+		if !strings.Contains(lines, expected) {
+			t.Errorf("Expected %q in lines:\n%s", expected, lines)
+		}
+	}
+
+	// Club headings end the header, so names below them are not taken for the chair
+	motion := "Wniosek mniejszości nr 2"
+	rollCall = parseVotingRollCall(sejm.VotingDetails{Title: &motion}, []string{votingPDFText + "\nPrzewodniczący: Jan Nowak"})
+	if rollCall.Chair != "" || rollCall.Item != "minority motion" || strings.Join(rollCall.MinorityMotions, ",") != "2" {
+		t.Errorf("Unexpected motion roll call: %+v", rollCall)
+	}
+}
+
+func TestHandleGetVotingDetailsRollCall(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/votings/7/12":     `{"sitting": 7, "votingNumber": 12, "title": "Pkt 3. Projekt ustawy w drugim czytaniu", "topic": "wniosek o odrzucenie projektu", "kind": "ELECTRONIC", "votes": [{"MP": 1, "vote": "YES"}]}`,
+		"/sejm/term10/votings/7/12/pdf": "not a PDF",
+	})
+
+	result, err := server.handleGetVotingDetails(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "sitting": "7", "voting_number": "12", "include_context": "false",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{
+		"Roll-call metadata:",
+		`"item": "motion to reject"`,
+		`"reading": 2`,
+		`"method": "electronic"`,
+		`"pdfError":`,
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+
+	result, _ = server.handleGetVotingDetails(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "sitting": "7", "voting_number": "12", "include_roll_call": "false",
+	}))
+	if strings.Contains(extractTextContent(result), "Roll-call metadata") {
+		t.Errorf("Expected no roll-call metadata with include_roll_call='false'")
+	}
+}
@@ -0,0 +1,288 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultVotingSessionsLimit is the number of entries listed when limit is not given
+const defaultVotingSessionsLimit = 30
+
+// sittingVotingsTTL bounds how long the parsed votings of a sitting are reused by title searches
+const sittingVotingsTTL = 60 * time.Minute
+
+// votingSession is one sitting day of the /votings index with the number of votings held that day
+type votingSession struct {
+	Date       string `json:"date"`
+	Proceeding int    `json:"proceeding"`
+	VotingsNum int    `json:"votingsNum"`
+}
+
+// votingSittingSummary aggregates the sitting days of one sitting
+type votingSittingSummary struct {
+	Sitting    int      `json:"sitting"`
+	Days       []string `json:"days"`
+	VotingsNum int      `json:"votingsNum"`
+}
+
+// fetchVotingSessions downloads the /votings index of a term: one entry per sitting day, in chronological order
+func (s *SejmServer) fetchVotingSessions(ctx context.Context, term int) ([]votingSession, error) {
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/votings", s.sejmBaseURL, term), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve voting sessions: %w", err)
+	}
+	var sessions []votingSession
+	if err := s.decodeAPIResponse(data, &sessions); err != nil {
+		return nil, fmt.Errorf("failed to parse voting sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// cachedSittingVotings are the parsed votings of a sitting and the voting count of the index they were fetched for
+type cachedSittingVotings struct {
+	votingsNum int
+	votings    []sejm.Voting
+}
+
+// sittingVotings returns the votings of a sitting, reusing those parsed by an earlier search while the /votings
+// index still reports the same number of votings for it; cached tells whether they came from the cache
+func (s *SejmServer) sittingVotings(ctx context.Context, term, sitting, votingsNum int) (votings []sejm.Voting, cached bool, err error) {
+	key := fmt.Sprintf("%d/%d", term, sitting)
+	s.cache.mu.RLock()
+	entry := s.cache.SittingVotings[key]
+	s.cache.mu.RUnlock()
+	if entry != nil && time.Now().Before(entry.ExpiresAt) {
+		if data := entry.Data.(cachedSittingVotings); data.votingsNum == votingsNum {
+			return data.votings, true, nil
+		}
+	}
+
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/sejm/term%d/votings/%d", s.sejmBaseURL, term, sitting), nil)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := s.decodeAPIResponse(data, &votings); err != nil {
+		return nil, false, fmt.Errorf("failed to parse votings: %w", err)
+	}
+
+	s.cache.mu.Lock()
+	if s.cache.SittingVotings == nil {
+		s.cache.SittingVotings = make(map[string]*CacheEntry)
+	}
+	s.cache.SittingVotings[key] = &CacheEntry{
+		Data:      cachedSittingVotings{votingsNum: votingsNum, votings: votings},
+		ExpiresAt: time.Now().Add(sittingVotingsTTL),
+	}
+	s.cache.mu.Unlock()
+	return votings, false, nil
+}
+
+// unscannedSittings returns the sittings that are not in scanned, keeping their order
+func unscannedSittings(sittings, scanned []int) []int {
+	done := make(map[int]bool, len(scanned))
+	for _, sitting := range scanned {
+		done[sitting] = true
+	}
+	var skipped []int
+	for _, sitting := range sittings {
+		if !done[sitting] {
+			skipped = append(skipped, sitting)
+		}
+	}
+	return skipped
+}
+
+// formatSittingNumbers lists sitting numbers given newest first compactly, joining runs of consecutive
+// sittings into ranges, e.g. "31-29, 25"
+func formatSittingNumbers(sittings []int) string {
+	var parts []string
+	for i := 0; i < len(sittings); {
+		j := i
+		for j+1 < len(sittings) && sittings[j+1] == sittings[j]-1 {
+			j++
+		}
+		if j > i {
+			parts = append(parts, fmt.Sprintf("%d-%d", sittings[i], sittings[j]))
+		} else {
+			parts = append(parts, strconv.Itoa(sittings[i]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ", ")
+}
+
+// votingSessionFilter selects sitting days of the /votings index
+type votingSessionFilter struct {
+	from, to   time.Time
+	sitting    int
+	minVotings int
+}
+
+// matches reports whether a sitting day passes the filter
+func (f votingSessionFilter) matches(session votingSession) bool {
+	if f.sitting > 0 && session.Proceeding != f.sitting {
+		return false
+	}
+	if session.VotingsNum < f.minVotings {
+		return false
+	}
+	if !f.from.IsZero() && session.Date < f.from.Format("2006-01-02") {
+		return false
+	}
+	if !f.to.IsZero() && session.Date > f.to.Format("2006-01-02") {
+		return false
+	}
+	return true
+}
+
+// groupVotingSessions merges sitting days into sittings, keeping the order of their first day
+func groupVotingSessions(sessions []votingSession) []votingSittingSummary {
+	var sittings []votingSittingSummary
+	index := make(map[int]int)
+	for _, session := range sessions {
+		i, ok := index[session.Proceeding]
+		if !ok {
+			i = len(sittings)
+			index[session.Proceeding] = i
+			sittings = append(sittings, votingSittingSummary{Sitting: session.Proceeding})
+		}
+		sittings[i].Days = append(sittings[i].Days, session.Date)
+		sittings[i].VotingsNum += session.VotingsNum
+	}
+	return sittings
+}
+
+func (s *SejmServer) handleGetVotingsSessions(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_votings_sessions called", slog.Any("arguments", request.Params.Arguments))
+
+	term, err := s.validateTerm(request.GetString("term", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid parliamentary term: %v. Please use term numbers 1-10.", err)), nil
+	}
+	var filter votingSessionFilter
+	if filter.from, err = parseDefectionDate("date_from", request.GetString("date_from", "")); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if filter.to, err = parseDefectionDate("date_to", request.GetString("date_to", "")); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if value := request.GetString("sitting", ""); value != "" {
+		if filter.sitting, err = strconv.Atoi(value); err != nil || filter.sitting < 1 {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid sitting '%s': must be a positive number.", value)), nil
+		}
+	}
+	filter.minVotings = 1
+	if value := request.GetString("min_votings", ""); value != "" {
+		if filter.minVotings, err = strconv.Atoi(value); err != nil || filter.minVotings < 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid min_votings '%s': must be a non-negative number. Use '0' to include sitting days without votings.", value)), nil
+		}
+	}
+	groupBy := request.GetString("group_by", "day")
+	if groupBy != "day" && groupBy != "sitting" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid group_by '%s'. Use 'day' or 'sitting'.", groupBy)), nil
+	}
+	format := request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format '%s'. Use 'text' or 'json'.", format)), nil
+	}
+	page, err := parseListPage(request, defaultVotingSessionsLimit)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	sessions, err := s.fetchVotingSessions(ctx, term)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to retrieve the voting sessions of term %d: %v", term, err)), nil
+	}
+
+	// Newest first, as clients usually look for recent sittings
+	var matched []votingSession
+	totalVotings := 0
+	for i := len(sessions) - 1; i >= 0; i-- {
+		if filter.matches(sessions[i]) {
+			matched = append(matched, sessions[i])
+			totalVotings += sessions[i].VotingsNum
+		}
+	}
+	sittings := groupVotingSessions(matched)
+
+	noun, total := "sitting days", len(matched)
+	if groupBy == "sitting" {
+		noun, total = "sittings", len(sittings)
+	}
+	start, end := page.bounds(total)
+
+	if format == "json" {
+		result := map[string]interface{}{
+			"term":         term,
+			"total":        total,
+			"offset":       page.offset,
+			"totalVotings": totalVotings,
+		}
+		if groupBy == "sitting" {
+			result["sittings"] = sittings[start:end]
+		} else {
+			result["days"] = matched[start:end]
+		}
+		if end < total {
+			result["next_offset"] = end
+		}
+		out, _ := json.MarshalIndent(result, "", "  ")
+		return mcp.NewToolResultText(string(out)), nil
+	}
+
+	summary := []string{
+		fmt.Sprintf("Term %d: %d sitting days in %d sittings, %d votings in total", term, len(matched), len(sittings), totalVotings),
+		page.describe(noun, end-start, total),
+	}
+	var results []string
+	if groupBy == "sitting" {
+		for _, sitting := range sittings[start:end] {
+			days := sitting.Days[len(sitting.Days)-1]
+			if len(sitting.Days) > 1 {
+				days += " to " + sitting.Days[0]
+			}
+			results = append(results, fmt.Sprintf("• Sitting %d (%s): %d votings on %d days", sitting.Sitting, days, sitting.VotingsNum, len(sitting.Days)))
+		}
+	} else {
+		for _, session := range matched[start:end] {
+			results = append(results, fmt.Sprintf("• %s, sitting %d: %d votings", session.Date, session.Proceeding, session.VotingsNum))
+		}
+	}
+
+	call := fmt.Sprintf("term='%d'", term)
+	if groupBy != "day" {
+		call += fmt.Sprintf(", group_by='%s'", groupBy)
+	}
+	for _, name := range []string{"sitting", "date_from", "date_to", "min_votings"} {
+		if value := request.GetString(name, ""); value != "" {
+			call += fmt.Sprintf(", %s='%s'", name, value)
+		}
+	}
+	nextActions := page.navigation("sejm_get_votings_sessions", call, end < total)
+	if len(matched) > 0 {
+		nextActions = append(nextActions, fmt.Sprintf("Votings of a sitting: sejm_search_votings with term='%d', sitting='%d'", term, matched[0].Proceeding))
+	}
+
+	note := "Sitting days without votings are skipped unless min_votings='0'."
+	if len(matched) == 0 {
+		note = "No sitting days match the filters. " + note
+	}
+	response := StandardResponse{
+		Operation:   "Voting Sessions",
+		Status:      "Retrieved Successfully",
+		Summary:     summary,
+		Data:        results,
+		NextActions: nextActions,
+		Note:        note,
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const votingSessionsFixture = `[
+	{"date": "2024-01-10", "proceeding": 3, "votingsNum": 12},
+	{"date": "2024-01-11", "proceeding": 3, "votingsNum": 0},
+	{"date": "2024-01-12", "proceeding": 3, "votingsNum": 30},
+	{"date": "2024-01-24", "proceeding": 4, "votingsNum": 5},
+	{"date": "2024-02-07", "proceeding": 5, "votingsNum": 41}
+]`
+
+func TestHandleGetVotingsSessions(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{"/sejm/term10/votings": votingSessionsFixture})
+
+	result, err := server.handleGetVotingsSessions(context.Background(), createMockRequest(map[string]interface{}{"limit": "3"}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{
+		"Term 10: 4 sitting days in 3 sittings, 88 votings in total",
+		"Showing sitting days 1-3 of 4",
+		"• 2024-02-07, sitting 5: 41 votings",
+		"• 2024-01-12, sitting 3: 30 votings",
+		"offset='3', limit='3'",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+	if strings.Contains(text, "2024-01-11") {
+		t.Errorf("Expected a day without votings to be skipped:\n%s", text)
+	}
+
+	result, _ = server.handleGetVotingsSessions(context.Background(), createMockRequest(map[string]interface{}{
+		"group_by": "sitting", "date_to": "2024-01-31", "min_votings": "0",
+	}))
+	text = extractTextContent(result)
+	for _, expected := range []string{
+		"• Sitting 4 (2024-01-24): 5 votings on 1 days",
+		"• Sitting 3 (2024-01-10 to 2024-01-12): 42 votings on 3 days",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+	if strings.Contains(text, "Sitting 5") {
+		t.Errorf("Expected date_to to exclude sitting 5:\n%s", text)
+	}
+
+	result, _ = server.handleGetVotingsSessions(context.Background(), createMockRequest(map[string]interface{}{"group_by": "week"}))
+	if !result.IsError {
+		t.Errorf("Expected an invalid group_by to be rejected, got: %s", extractTextContent(result))
+	}
+}
+
+func TestSearchVotingsByTitleDeduplicatesAndReportsSittings(t *testing.T) {
+	var sessions []string
+	fixtures := map[string]string{}
+	for sitting := 1; sitting <= recentVotingSittings+2; sitting++ {
+		sessions = append(sessions, fmt.Sprintf(`{"date": "2024-01-%02d", "proceeding": %d, "votingsNum": 1}`, sitting, sitting))
+		fixtures[fmt.Sprintf("/sejm/term10/votings/%d", sitting)] = fmt.Sprintf(`[{"sitting": %d, "votingNumber": 1, "title": "Inne", "topic": "Inne"}]`, sitting)
+	}
+	fixtures["/sejm/term10/votings"] = "[" + strings.Join(sessions, ",") + "]"
+	fixtures["/sejm/term10/votings/22"] = `[
+		{"sitting": 22, "votingNumber": 1, "title": "Ustawa o kolei", "topic": "Projekt ustawy o kolei", "yes": 300, "no": 100},
+		{"sitting": 22, "votingNumber": 2, "title": "Inne", "topic": "Poprawka do ustawy o kolei", "yes": 100, "no": 300}
+	]`
+	delete(fixtures, "/sejm/term10/votings/20")
+	server := newServerWithFixtures(t, fixtures)
+
+	result, err := server.searchVotingsByTitle(context.Background(), 10, "kolei", "", votingScanRecent, nil)
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	for _, expected := range []string{
+		"Found 2 matching voting records",
+		"Searched 19 of 22 sittings with votings",
+		"Scanned sittings: 22-21, 19-3",
+		"Skipped sittings: 20, 2-1",
+		"1 of 20 sittings unavailable",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, text)
+		}
+	}
+
+	result, _ = server.searchVotingsByTitle(context.Background(), 10, "kolei", "", votingScanRecent, nil)
+	if text := extractTextContent(result); !strings.Contains(text, "(19 reused from the cache)") {
+		t.Errorf("Expected the second search to reuse parsed sittings:\n%s", text)
+	}
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+// Tally fields compared by voting verification, in output order
+var votingTallyFields = []string{"voted", "yes", "no", "abstain", "absent"}
+
+// pdfTallyLabels maps the tally labels printed in voting PDFs to tally fields
+var pdfTallyLabels = map[string]string{
+	"glosowalo":      "voted",
+	"za":             "yes",
+	"przeciw":        "no",
+	"wstrzymalo sie": "abstain",
+	"nie glosowalo":  "absent",
+}
+
+// pdfTallyPattern matches a printed tally such as "Za - 233" or "NIE GŁOSOWAŁO – 12". Labels are matched on
+// normalized text, and "nie glosowalo" comes first so it is not read as "glosowalo". MP entries ("Nowak Jan za")
+// are not followed by a dash and a number, so they do not match.
+var pdfTallyPattern = regexp.MustCompile(`(?:^|[^a-z])(nie glosowalo|glosowalo|wstrzymalo sie|przeciw|za)\s*[-–—:]\s*(\d+)`)
+
+// votingTally holds the counts of a voting or of one club in it; a field is absent when it is not reported
+type votingTally map[string]int
+
+// pdfClubTally is the tally printed under a club heading of a voting PDF
+type pdfClubTally struct {
+	Club  string      `json:"club"`
+	Tally votingTally `json:"tally"`
+}
+
+// votingDiscrepancy is a count that differs between the API and the official PDF
+type votingDiscrepancy struct {
+	Scope string `json:"scope"`
+	Field string `json:"field"`
+	API   int    `json:"api"`
+	PDF   int    `json:"pdf"`
+}
+
+// votingVerification is the result of cross-checking the API tallies of a voting against its official PDF
+type votingVerification struct {
+	Verified      bool                `json:"verified"`
+	Checked       int                 `json:"checked"`
+	Discrepancies []votingDiscrepancy `json:"discrepancies,omitempty"`
+	Unchecked     []string            `json:"unchecked,omitempty"`
+	PDFError      string              `json:"pdfError,omitempty"`
+}
+
+// parsePDFTallies reads the tallies printed in a voting PDF: the overall one in the header, before any club
+// heading, and one under each club heading ("PiS(190) Za - 0 Przeciw - 187 ...")
+func parsePDFTallies(pageTexts []string) (votingTally, []pdfClubTally) {
+	var overall votingTally
+	var clubs []pdfClubTally
+	for _, page := range pageTexts {
+		for _, line := range strings.Split(page, "\n") {
+			tally := votingTally{}
+			for _, match := range pdfTallyPattern.FindAllStringSubmatch(normalizePolish(line), -1) {
+				tally[pdfTallyLabels[match[1]]], _ = strconv.Atoi(match[2])
+			}
+			if len(tally) < 2 {
+				continue
+			}
+			if heading := pdfClubHeadingPattern.FindStringSubmatch(line); heading != nil {
+				clubs = append(clubs, pdfClubTally{Club: strings.TrimSpace(heading[1]), Tally: tally})
+			} else if overall == nil && len(clubs) == 0 {
+				overall = tally
+			}
+		}
+	}
+	return overall, clubs
+}
+
+// apiVotingTally returns the overall tally reported by the API
+func apiVotingTally(voting sejm.VotingDetails) votingTally {
+	tally := votingTally{}
+	for field, value := range map[string]*int32{
+		"voted":   voting.TotalVoted,
+		"yes":     voting.Yes,
+		"no":      voting.No,
+		"abstain": voting.Abstain,
+		"absent":  voting.NotParticipating,
+	} {
+		if value != nil {
+			tally[field] = int(*value)
+		}
+	}
+	return tally
+}
+
+// apiClubTallies counts the MP-level votes of the API per club
+func apiClubTallies(votes []sejm.Vote) map[string]votingTally {
+	fields := map[sejm.VoteValue]string{
+		sejm.VoteValueYES:     "yes",
+		sejm.VoteValueNO:      "no",
+		sejm.VoteValueABSTAIN: "abstain",
+		sejm.VoteValueABSENT:  "absent",
+	}
+	tallies := make(map[string]votingTally)
+	for _, vote := range votes {
+		if vote.Vote == nil || fields[*vote.Vote] == "" {
+			continue
+		}
+		club := normalizePolish(valueOrDefault(stringValue(vote.Club), "niez."))
+		if tallies[club] == nil {
+			tallies[club] = votingTally{"voted": 0, "yes": 0, "no": 0, "abstain": 0, "absent": 0}
+		}
+		tallies[club][fields[*vote.Vote]]++
+		if *vote.Vote != sejm.VoteValueABSENT {
+			tallies[club]["voted"]++
+		}
+	}
+	return tallies
+}
+
+// compareTallies adds a discrepancy for every field reported by both sides with different counts, and returns
+// how many fields were compared
+func (v *votingVerification) compareTallies(scope string, api, pdf votingTally) int {
+	compared := 0
+	for _, field := range votingTallyFields {
+		apiCount, inAPI := api[field]
+		pdfCount, inPDF := pdf[field]
+		if !inAPI || !inPDF {
+			continue
+		}
+		compared++
+		if apiCount != pdfCount {
+			v.Discrepancies = append(v.Discrepancies, votingDiscrepancy{Scope: scope, Field: field, API: apiCount, PDF: pdfCount})
+		}
+	}
+	v.Checked += compared
+	return compared
+}
+
+// verifyVotingAgainstPDF cross-checks the overall tally of a voting and, when the API has MP-level votes, the
+// per-club tallies against the numbers printed in the official PDF. apiVotes must be the votes as served by the
+// API, before any are filled in from the PDF.
+func verifyVotingAgainstPDF(voting sejm.VotingDetails, apiVotes []sejm.Vote, pageTexts []string, pdfErr error) votingVerification {
+	var verification votingVerification
+	if pdfErr != nil {
+		verification.PDFError = pdfErr.Error()
+		return verification
+	}
+	overall, clubs := parsePDFTallies(pageTexts)
+	if overall == nil {
+		verification.Unchecked = append(verification.Unchecked, "overall tally: not found in the PDF header")
+	} else if verification.compareTallies("overall", apiVotingTally(voting), overall) == 0 {
+		verification.Unchecked = append(verification.Unchecked, "overall tally: the API reports no counts for this voting")
+	}
+
+	switch {
+	case len(clubs) == 0:
+		verification.Unchecked = append(verification.Unchecked, "club tallies: none printed in the PDF")
+	case len(apiVotes) == 0:
+		verification.Unchecked = append(verification.Unchecked, "club tallies: the API has no MP-level votes for this voting")
+	default:
+		apiClubs := apiClubTallies(apiVotes)
+		for _, club := range clubs {
+			tally, ok := apiClubs[normalizePolish(club.Club)]
+			if !ok {
+				verification.Unchecked = append(verification.Unchecked, fmt.Sprintf("club %s: not found among the clubs of the API votes", club.Club))
+				continue
+			}
+			verification.compareTallies(club.Club, tally, club.Tally)
+		}
+	}
+	verification.Verified = verification.Checked > 0 && len(verification.Discrepancies) == 0
+	return verification
+}
+
+// lines renders the verification for text output
+func (v votingVerification) lines() []string {
+	if v.PDFError != "" {
+		return []string{fmt.Sprintf("Verification against the PDF failed: %s", v.PDFError)}
+	}
+	var lines []string
+	switch {
+	case v.Checked == 0:
+		lines = append(lines, "Verification against the PDF: no counts could be compared")
+	case len(v.Discrepancies) == 0:
+		lines = append(lines, fmt.Sprintf("Verification against the PDF: all %d compared counts match", v.Checked))
+	default:
+		lines = append(lines, fmt.Sprintf("Verification against the PDF: %d of %d compared counts DIFFER", len(v.Discrepancies), v.Checked))
+	}
+	for _, discrepancy := range v.Discrepancies {
+		lines = append(lines, fmt.Sprintf("Discrepancy, %s %s: API %d, PDF %d", discrepancy.Scope, discrepancy.Field, discrepancy.API, discrepancy.PDF))
+	}
+	for _, unchecked := range v.Unchecked {
+		lines = append(lines, "Not verified, "+unchecked)
+	}
+	return lines
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/janisz/sejm-mcp/pkg/sejm"
+)
+
+func TestParsePDFTallies(t *testing.T) {
+	overall, clubs := parsePDFTallies([]string{votingPDFText})
+	if overall["voted"] != 5 || overall["yes"] != 2 || overall["abstain"] != 1 || overall["absent"] != 1 {
+		t.Errorf("Unexpected overall tally: %v", overall)
+	}
+	if len(clubs) != 2 || clubs[0].Club != "SLD" || clubs[1].Tally["no"] != 1 {
+		t.Fatalf("Unexpected club tallies: %+v", clubs)
+	}
+	if _, ok := clubs[1].Tally["abstain"]; ok {
+		t.Errorf("Expected a tally not printed for the club to be absent, got %v", clubs[1].Tally)
+	}
+
+	overall, _ = parsePDFTallies([]string{"GŁOSOWAŁO – 446   ZA – 237   PRZECIW – 207   WSTRZYMAŁO SIĘ – 2   NIE GŁOSOWAŁO – 14"})
+	if overall["voted"] != 446 || overall["no"] != 207 || overall["absent"] != 14 {
+		t.Errorf("Unexpected tally of an uppercase header: %v", overall)
+	}
+}
+
+func TestVerifyVotingAgainstPDF(t *testing.T) {
+	number := func(n int32) *int32 { return &n }
+	vote := func(club string, value sejm.VoteValue) sejm.Vote { return sejm.Vote{Club: &club, Vote: &value} }
+	voting := sejm.VotingDetails{TotalVoted: number(4), Yes: number(2), No: number(1), Abstain: number(1), NotParticipating: number(1)}
+	votes := []sejm.Vote{
+		vote("SLD", sejm.VoteValueYES), vote("SLD", sejm.VoteValueYES), vote("SLD", sejm.VoteValueABSTAIN),
+		vote("PO", sejm.VoteValueNO),
+	}
+
+	verification := verifyVotingAgainstPDF(voting, votes, []string{votingPDFText}, nil)
+	expected := []votingDiscrepancy{
+		{Scope: "overall", Field: "voted", API: 4, PDF: 5},
+		{Scope: "PO", Field: "absent", API: 0, PDF: 1},
+	}
+	if verification.Verified || verification.Checked != 13 || len(verification.Discrepancies) != len(expected) {
+		t.Fatalf("Unexpected verification: %+v", verification)
+	}
+	for i, discrepancy := range verification.Discrepancies {
+		if discrepancy != expected[i] {
+			t.Errorf("Discrepancy %d: expected %+v, got %+v", i, expected[i], discrepancy)
+		}
+	}
+	if lines := strings.Join(verification.lines(), "\n"); !strings.Contains(lines, "2 of 13 compared counts DIFFER") || !strings.Contains(lines, "Discrepancy, PO absent: API 0, PDF 1") {
+		t.Errorf("Unexpected verification lines:\n%s", lines)
+	}
+
+	voting.TotalVoted = number(5)
+	verification = verifyVotingAgainstPDF(voting, nil, []string{votingPDFText}, nil)
+	if !verification.Verified || verification.Checked != 5 || len(verification.Unchecked) != 1 {
+		t.Errorf("Expected the overall tally to match and club tallies to be unchecked, got %+v", verification)
+	}
+}
+
+func TestHandleGetVotingDetailsVerify(t *testing.T) {
+	server := newServerWithFixtures(t, map[string]string{
+		"/sejm/term10/votings/7/12":     `{"sitting": 7, "votingNumber": 12, "title": "Głosowanie nad całością projektu", "totalVoted": 440, "yes": 230, "no": 210, "abstain": 0, "notParticipating": 20, "votes": []}`,
+		"/sejm/term10/votings/7/12/pdf": "not a PDF",
+	})
+
+	result, err := server.handleGetVotingDetails(context.Background(), createMockRequest(map[string]interface{}{
+		"term": "10", "sitting": "7", "voting_number": "12", "include_context": "false", "include_roll_call": "false", "verify": "true",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	text := extractTextContent(result)
+	if !strings.Contains(text, "Verification of the tallies against the official PDF:") || !strings.Contains(text, `"verified": false`) || !strings.Contains(text, `"pdfError":`) {
+		t.Errorf("Expected a failed verification for an unreadable PDF, got:\n%s", text)
+	}
+}
@@ -0,0 +1,596 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janisz/sejm-mcp/pkg/eli"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultWatchInterval is how often watched acts are checked when no interval is configured
+const defaultWatchInterval = 6 * time.Hour
+
+// maxWatches limits the number of watched acts, each of which costs a details request per check
+const maxWatches = 100
+
+// maxWatchUpdates is the number of detected changes kept before the oldest are discarded
+const maxWatchUpdates = 200
+
+// watchNotificationLogger names the source of change alerts sent as MCP log notifications
+const watchNotificationLogger = "eli-watch"
+
+// watchFileName is the file in the watch directory holding watched acts and detected changes
+const watchFileName = "watches.json"
+
+// actSnapshot is the part of act metadata compared between checks
+type actSnapshot struct {
+	Title          string              `json:"title,omitempty"`
+	Status         string              `json:"status,omitempty"`
+	InForce        string              `json:"inForce,omitempty"`
+	ChangeDate     string              `json:"changeDate,omitempty"`
+	RepealDate     string              `json:"repealDate,omitempty"`
+	ExpirationDate string              `json:"expirationDate,omitempty"`
+	References     map[string][]string `json:"references,omitempty"`
+}
+
+// actWatch is an act registered with eli_watch_act
+type actWatch struct {
+	Address   string      `json:"address"`
+	Publisher string      `json:"publisher"`
+	Year      int         `json:"year"`
+	Position  int         `json:"position"`
+	CreatedAt time.Time   `json:"createdAt"`
+	CheckedAt time.Time   `json:"checkedAt"`
+	LastError string      `json:"lastError,omitempty"`
+	Snapshot  actSnapshot `json:"snapshot"`
+}
+
+// watchUpdate is a change detected in a watched act
+type watchUpdate struct {
+	Address    string    `json:"address"`
+	Title      string    `json:"title,omitempty"`
+	DetectedAt time.Time `json:"detectedAt"`
+	Changes    []string  `json:"changes"`
+}
+
+// watchManager keeps watched acts and their detected changes in memory and, optionally, in a directory
+type watchManager struct {
+	mu      sync.Mutex
+	dir     string
+	watches map[string]*actWatch
+	updates []watchUpdate
+	logger  *slog.Logger
+}
+
+// watchState is the persisted form of the watch manager
+type watchState struct {
+	Watches []*actWatch   `json:"watches"`
+	Updates []watchUpdate `json:"updates"`
+}
+
+// newWatchManager creates a watch manager, loading the watches stored in dir by a previous run
+func newWatchManager(dir string, logger *slog.Logger) *watchManager {
+	m := &watchManager{dir: dir, watches: make(map[string]*actWatch), logger: logger}
+	if dir == "" {
+		return m
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		logger.Warn("Cannot create watch directory, watched acts will not be persisted", slog.String("dir", dir), slog.Any("error", err))
+		m.dir = ""
+		return m
+	}
+	data, err := os.ReadFile(filepath.Join(dir, watchFileName))
+	if err != nil {
+		return m
+	}
+	var state watchState
+	if err := json.Unmarshal(data, &state); err != nil {
+		logger.Warn("Ignoring unreadable watch file", slog.String("dir", dir), slog.Any("error", err))
+		return m
+	}
+	for _, w := range state.Watches {
+		if w != nil && w.Address != "" {
+			m.watches[w.Address] = w
+		}
+	}
+	m.updates = state.Updates
+	logger.Info("Loaded watched acts", slog.String("dir", dir), slog.Int("count", len(m.watches)))
+	return m
+}
+
+// persistLocked writes all watches and updates atomically. The caller must hold the lock.
+func (m *watchManager) persistLocked() {
+	if m.dir == "" {
+		return
+	}
+	state := watchState{Updates: m.updates}
+	for _, w := range m.watches {
+		state.Watches = append(state.Watches, w)
+	}
+	sort.Slice(state.Watches, func(i, j int) bool { return state.Watches[i].Address < state.Watches[j].Address })
+	data, err := json.Marshal(state)
+	if err != nil {
+		m.logger.Warn("Failed to encode watched acts", slog.Any("error", err))
+		return
+	}
+	file := filepath.Join(m.dir, watchFileName)
+	if err := os.WriteFile(file+".tmp", data, 0o644); err != nil {
+		m.logger.Warn("Failed to persist watched acts", slog.String("file", file), slog.Any("error", err))
+		return
+	}
+	if err := os.Rename(file+".tmp", file); err != nil {
+		m.logger.Warn("Failed to persist watched acts", slog.String("file", file), slog.Any("error", err))
+	}
+}
+
+// add registers a watch, replacing an existing watch of the same act; it fails when the watch limit is reached
+func (m *watchManager) add(w actWatch) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.watches[w.Address]; !exists && len(m.watches) >= maxWatches {
+		return fmt.Errorf("at most %d acts can be watched; remove a watch first", maxWatches)
+	}
+	m.watches[w.Address] = &w
+	m.persistLocked()
+	return nil
+}
+
+// remove drops the watch of an act and reports whether it existed
+func (m *watchManager) remove(address string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.watches[address]; !ok {
+		return false
+	}
+	delete(m.watches, address)
+	m.persistLocked()
+	return true
+}
+
+// list returns copies of all watches ordered by address
+func (m *watchManager) list() []actWatch {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	watches := make([]actWatch, 0, len(m.watches))
+	for _, w := range m.watches {
+		watches = append(watches, *w)
+	}
+	sort.Slice(watches, func(i, j int) bool { return watches[i].Address < watches[j].Address })
+	return watches
+}
+
+// due returns the watches last checked at least interval ago
+func (m *watchManager) due(interval time.Duration) []actWatch {
+	var due []actWatch
+	for _, w := range m.list() {
+		if time.Since(w.CheckedAt) >= interval {
+			due = append(due, w)
+		}
+	}
+	return due
+}
+
+// record stores the result of checking a watch and returns the update when the act changed
+func (m *watchManager) record(address string, snapshot actSnapshot, checkErr error) *watchUpdate {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w, ok := m.watches[address]
+	if !ok {
+		// The watch was removed while it was being checked
+		return nil
+	}
+	w.CheckedAt = time.Now()
+	if checkErr != nil {
+		w.LastError = checkErr.Error()
+		m.persistLocked()
+		return nil
+	}
+	w.LastError = ""
+	changes := diffActSnapshots(w.Snapshot, snapshot)
+	w.Snapshot = snapshot
+	var update *watchUpdate
+	if len(changes) > 0 {
+		update = &watchUpdate{Address: address, Title: snapshot.Title, DetectedAt: w.CheckedAt, Changes: changes}
+		m.updates = append(m.updates, *update)
+		if len(m.updates) > maxWatchUpdates {
+			m.updates = m.updates[len(m.updates)-maxWatchUpdates:]
+		}
+	}
+	m.persistLocked()
+	return update
+}
+
+// updatesSince returns the changes detected after since, newest first
+func (m *watchManager) updatesSince(since time.Time) []watchUpdate {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var updates []watchUpdate
+	for i := len(m.updates) - 1; i >= 0; i-- {
+		if m.updates[i].DetectedAt.After(since) {
+			updates = append(updates, m.updates[i])
+		}
+	}
+	return updates
+}
+
+// snapshotAct extracts the compared metadata of an act; references are kept as sorted act addresses per category
+func snapshotAct(act eli.Act) actSnapshot {
+	snapshot := actSnapshot{
+		Title:  stringValue(act.Title),
+		Status: stringValue(act.Status),
+	}
+	if act.InForce != nil {
+		snapshot.InForce = string(*act.InForce)
+	}
+	if act.ChangeDate != nil {
+		snapshot.ChangeDate = act.ChangeDate.Format("2006-01-02 15:04:05")
+	}
+	if act.RepealDate != nil {
+		snapshot.RepealDate = act.RepealDate.String()
+	}
+	if act.ExpirationDate != nil {
+		snapshot.ExpirationDate = act.ExpirationDate.String()
+	}
+	if act.References != nil {
+		snapshot.References = make(map[string][]string)
+		for category, references := range *act.References {
+			for _, reference := range references {
+				if id := stringValue(reference.Id); id != "" {
+					snapshot.References[category] = append(snapshot.References[category], id)
+				}
+			}
+			sort.Strings(snapshot.References[category])
+		}
+	}
+	return snapshot
+}
+
+// describeReferenceCategory explains what a new reference of the category means for the watched act
+func describeReferenceCategory(category string) string {
+	switch {
+	case category == "Akty zmieniające":
+		return "Amended by"
+	case category == "Akty uchylające":
+		return "Repealed by"
+	case strings.Contains(strings.ToLower(category), "jednolit"):
+		return "New consolidated text"
+	case category == "Akty wykonawcze":
+		return "New implementing act"
+	}
+	return "New reference"
+}
+
+// diffActSnapshots lists the changes between two snapshots of an act, in a stable order
+func diffActSnapshots(old, current actSnapshot) []string {
+	var changes []string
+	field := func(name, before, after string) {
+		if before != after {
+			changes = append(changes, fmt.Sprintf("%s: %s → %s", name, valueOrDefault(before, "none"), valueOrDefault(after, "none")))
+		}
+	}
+	field("Status", old.Status, current.Status)
+	field("In force", old.InForce, current.InForce)
+	field("Repeal date", old.RepealDate, current.RepealDate)
+	field("Expiration date", old.ExpirationDate, current.ExpirationDate)
+
+	categories := make([]string, 0, len(current.References))
+	for category := range current.References {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	for _, category := range categories {
+		known := make(map[string]bool, len(old.References[category]))
+		for _, id := range old.References[category] {
+			known[id] = true
+		}
+		for _, id := range current.References[category] {
+			if !known[id] {
+				changes = append(changes, fmt.Sprintf("%s %s [%s]", describeReferenceCategory(category), id, category))
+			}
+		}
+	}
+	if len(changes) == 0 && old.ChangeDate != current.ChangeDate {
+		changes = append(changes, fmt.Sprintf("Metadata updated on %s", valueOrDefault(current.ChangeDate, "unknown date")))
+	}
+	return changes
+}
+
+// parseActAddress validates the act coordinates of a watch
+func parseActAddress(publisher, year, position string) (actWatch, error) {
+	publisher = strings.ToUpper(strings.TrimSpace(publisher))
+	y, yearErr := strconv.Atoi(year)
+	p, positionErr := strconv.Atoi(position)
+	if publisher == "" || yearErr != nil || positionErr != nil || y < 1 || p < 1 {
+		return actWatch{}, fmt.Errorf("publisher, year and position are required, e.g. publisher='DU', year='1997', position='78'")
+	}
+	return actWatch{Address: fmt.Sprintf("%s/%d/%d", publisher, y, p), Publisher: publisher, Year: y, Position: p}, nil
+}
+
+// fetchActSnapshot downloads the metadata of a watched act
+func (s *SejmServer) fetchActSnapshot(ctx context.Context, w actWatch) (actSnapshot, error) {
+	data, err := s.makeAPIRequest(ctx, fmt.Sprintf("%s/acts/%s/%d/%d", s.eliBaseURL, w.Publisher, w.Year, w.Position), nil)
+	if err != nil {
+		return actSnapshot{}, fmt.Errorf("failed to retrieve act %s: %w", w.Address, err)
+	}
+	var act eli.Act
+	if err := s.decodeAPIResponse(data, &act); err != nil {
+		return actSnapshot{}, fmt.Errorf("failed to parse act %s: %w", w.Address, err)
+	}
+	return snapshotAct(act), nil
+}
+
+// watchInterval returns the configured interval between checks of a watched act
+func (s *SejmServer) watchInterval() time.Duration {
+	if s.config.WatchInterval > 0 {
+		return s.config.WatchInterval
+	}
+	return defaultWatchInterval
+}
+
+// checkWatches checks the given watches one by one and notifies connected clients of the changes found
+func (s *SejmServer) checkWatches(ctx context.Context, watches []actWatch) (updates []watchUpdate, failed int) {
+	for _, w := range watches {
+		snapshot, err := s.fetchActSnapshot(ctx, w)
+		if err != nil {
+			s.logger.Warn("Failed to check watched act", slog.String("act", w.Address), slog.Any("error", err))
+			failed++
+		}
+		if update := s.watches.record(w.Address, snapshot, err); update != nil {
+			updates = append(updates, *update)
+			s.notifyWatchUpdate(*update)
+		}
+	}
+	return updates, failed
+}
+
+// notifyWatchUpdate sends a detected change to all connected clients as an MCP log notification
+func (s *SejmServer) notifyWatchUpdate(update watchUpdate) {
+	s.logger.Info("Watched act changed", slog.String("act", update.Address), slog.Any("changes", update.Changes))
+	s.server.SendNotificationToAllClients("notifications/message", map[string]any{
+		"level":  mcp.LoggingLevelNotice,
+		"logger": watchNotificationLogger,
+		"data":   update,
+	})
+}
+
+// runWatchChecker periodically checks watched acts that are due. It runs for the lifetime of the SSE and HTTP
+// servers; in stdio mode acts are checked when sejm_get_watch_updates is called.
+func (s *SejmServer) runWatchChecker(ctx context.Context) {
+	interval := s.watchInterval()
+	// Checking a few times per interval keeps the delay after a new watch or a restart short
+	ticker := time.NewTicker(max(interval/4, time.Minute))
+	defer ticker.Stop()
+	s.logger.Info("Watched act checker started", slog.Duration("interval", interval))
+	for {
+		if due := s.watches.due(interval); len(due) > 0 {
+			updates, failed := s.checkWatches(ctx, due)
+			s.logger.Info("Checked watched acts", slog.Int("checked", len(due)), slog.Int("changed", len(updates)), slog.Int("failed", failed))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// formatWatch renders a watched act for tool output
+func formatWatch(w actWatch) string {
+	line := fmt.Sprintf("• %s: %s", w.Address, valueOrDefault(w.Snapshot.Title, "No title"))
+	var state []string
+	if w.Snapshot.Status != "" {
+		state = append(state, "status: "+w.Snapshot.Status)
+	}
+	if !w.CheckedAt.IsZero() {
+		state = append(state, "checked "+w.CheckedAt.Format("2006-01-02 15:04"))
+	}
+	if w.LastError != "" {
+		state = append(state, "last check failed: "+w.LastError)
+	}
+	if len(state) > 0 {
+		line += fmt.Sprintf(" [%s]", strings.Join(state, "; "))
+	}
+	return line
+}
+
+func (s *SejmServer) registerWatchTools() {
+	s.addTool(mcp.Tool{
+		Name:        "eli_watch_act",
+		Description: "Watch a legal act for changes instead of polling it: registers the act, and the server checks its metadata periodically for a changed status, repeal or expiration, new amending acts, new consolidated texts and other new references. In SSE and HTTP mode changes are pushed to connected clients as MCP log notifications (logger 'eli-watch'); in every mode they can be read with sejm_get_watch_updates. Also lists and removes watches.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"action": map[string]interface{}{
+					"type":        "string",
+					"description": "'add' (default) to watch the act, 'remove' to stop watching it, 'list' to show all watched acts.",
+				},
+				"publisher": map[string]interface{}{
+					"type":        "string",
+					"description": "Publisher code of the act (e.g., 'DU' for Dziennik Ustaw, 'MP' for Monitor Polski). Required for add and remove.",
+				},
+				"year": map[string]interface{}{
+					"type":        "string",
+					"description": "Publication year of the act (e.g., '1997'). Required for add and remove.",
+				},
+				"position": map[string]interface{}{
+					"type":        "string",
+					"description": "Position of the act in the publication (e.g., '78'). Required for add and remove.",
+				},
+			},
+		},
+		// Watches are server state: adding the same act twice keeps a single watch
+		Annotations: mcp.ToolAnnotation{ReadOnlyHint: mcp.ToBoolPtr(false)},
+	}, s.handleWatchAct)
+
+	s.addTool(mcp.Tool{
+		Name:        "sejm_get_watch_updates",
+		Description: "Get the changes detected in legal acts watched with eli_watch_act: status changes, repeals and expirations, new amending acts, new consolidated texts and other new references, newest first. Acts not checked within the check interval are checked first, so this also works in stdio mode without a background checker.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"since": map[string]interface{}{
+					"type":        "string",
+					"description": "Optional. Only changes detected after this date (YYYY-MM-DD). Default: all kept changes.",
+				},
+				"check_now": map[string]interface{}{
+					"type":        "string",
+					"description": "Set to 'true' to check every watched act now instead of only those due. Responses may come from the server's HTTP cache for up to an hour.",
+				},
+			},
+		},
+	}, s.handleGetWatchUpdates)
+}
+
+func (s *SejmServer) handleWatchAct(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("eli_watch_act called", slog.Any("arguments", request.Params.Arguments))
+
+	action := strings.ToLower(request.GetString("action", "add"))
+	if action == "list" {
+		watches := s.watches.list()
+		var data []string
+		for _, w := range watches {
+			data = append(data, formatWatch(w))
+		}
+		status := "Retrieved Successfully"
+		if len(watches) == 0 {
+			status = "No Results Found"
+		}
+		response := StandardResponse{
+			Operation: "Act Watch",
+			Status:    status,
+			Summary:   []string{fmt.Sprintf("Watched acts: %d of %d", len(watches), maxWatches), fmt.Sprintf("Check interval: %s", s.watchInterval())},
+			Data:      data,
+			NextActions: []string{
+				"Detected changes: sejm_get_watch_updates",
+				"Watch another act: eli_watch_act with publisher, year and position",
+			},
+		}
+		return mcp.NewToolResultText(response.Format()), nil
+	}
+	if action != "add" && action != "remove" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid action '%s'. Use 'add', 'remove' or 'list'.", action)), nil
+	}
+
+	w, err := parseActAddress(request.GetString("publisher", ""), request.GetString("year", ""), request.GetString("position", ""))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid act: %v. Get act coordinates from eli_search_acts.", err)), nil
+	}
+
+	if action == "remove" {
+		if !s.watches.remove(w.Address) {
+			return mcp.NewToolResultError(fmt.Sprintf("Act %s is not watched. List watched acts with eli_watch_act action='list'.", w.Address)), nil
+		}
+		response := StandardResponse{
+			Operation:   "Act Watch",
+			Status:      "Watch Removed",
+			Summary:     []string{fmt.Sprintf("Act %s is no longer watched", w.Address)},
+			NextActions: []string{"Remaining watches: eli_watch_act with action='list'"},
+		}
+		return mcp.NewToolResultText(response.Format()), nil
+	}
+
+	// The first snapshot is the baseline later checks are compared with
+	snapshot, err := s.fetchActSnapshot(ctx, w)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to watch act: %v. Please verify the act exists with eli_get_act_details.", err)), nil
+	}
+	w.Snapshot, w.CreatedAt, w.CheckedAt = snapshot, time.Now(), time.Now()
+	if err := s.watches.add(w); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to watch act: %v.", err)), nil
+	}
+
+	references := 0
+	for _, ids := range snapshot.References {
+		references += len(ids)
+	}
+	note := "Changes are reported by sejm_get_watch_updates."
+	if s.watches.dir == "" {
+		note += " Watches are kept in memory only; start the server with -watch-dir to keep them across restarts."
+	}
+	response := StandardResponse{
+		Operation: "Act Watch",
+		Status:    "Watch Added",
+		Summary: []string{
+			fmt.Sprintf("Watching %s: %s", w.Address, valueOrDefault(snapshot.Title, "No title")),
+			fmt.Sprintf("Current status: %s", valueOrDefault(snapshot.Status, "unknown")),
+			fmt.Sprintf("Known references: %d", references),
+			fmt.Sprintf("Check interval: %s", s.watchInterval()),
+		},
+		NextActions: []string{
+			"Detected changes: sejm_get_watch_updates",
+			"All watched acts: eli_watch_act with action='list'",
+			fmt.Sprintf("Stop watching: eli_watch_act with action='remove', publisher='%s', year='%d', position='%d'", w.Publisher, w.Year, w.Position),
+		},
+		Note: note,
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
+
+func (s *SejmServer) handleGetWatchUpdates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	s.logger.Info("sejm_get_watch_updates called", slog.Any("arguments", request.Params.Arguments))
+
+	since, err := parseDefectionDate("since", request.GetString("since", ""))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	watches := s.watches.list()
+	if len(watches) == 0 {
+		return mcp.NewToolResultError("No acts are watched. Start watching an act with eli_watch_act."), nil
+	}
+
+	toCheck := s.watches.due(s.watchInterval())
+	if request.GetString("check_now", "false") == "true" {
+		toCheck = watches
+	}
+	_, failed := s.checkWatches(ctx, toCheck)
+
+	updates := s.watches.updatesSince(since)
+	var data []string
+	for _, update := range updates {
+		data = append(data, fmt.Sprintf("• %s %s: %s", update.DetectedAt.Format("2006-01-02 15:04"), update.Address, valueOrDefault(update.Title, "No title")))
+		for _, change := range update.Changes {
+			data = append(data, "  - "+change)
+		}
+	}
+
+	summary := []string{
+		fmt.Sprintf("Watched acts: %d", len(watches)),
+		fmt.Sprintf("Checked now: %d", len(toCheck)),
+		fmt.Sprintf("Changes found: %d", len(updates)),
+	}
+	if !since.IsZero() {
+		summary = append(summary, "Since: "+since.Format("2006-01-02"))
+	}
+	status := "Retrieved Successfully"
+	if len(updates) == 0 {
+		status = "No Changes"
+	}
+	note := ""
+	if failed > 0 {
+		status = "Partially Retrieved"
+		note = fmt.Sprintf("%d acts could not be checked; they are retried on the next call.", failed)
+	}
+	response := StandardResponse{
+		Operation: "Watch Updates",
+		Status:    status,
+		Summary:   summary,
+		Data:      data,
+		NextActions: []string{
+			"Amendments in detail: eli_get_act_references with publisher, year and position",
+			"Current metadata: eli_get_act_details with publisher, year and position",
+			"Watched acts: eli_watch_act with action='list'",
+		},
+		Note: note,
+	}
+	return mcp.NewToolResultText(response.Format()), nil
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestWatchActAndUpdates(t *testing.T) {
+	fixtures := map[string]string{
+		"/eli/acts/DU/2020/100": `{"publisher": "DU", "year": 2020, "pos": 100, "title": "Ustawa o ochronie danych",
+			"status": "obowiązujący", "inForce": "IN_FORCE",
+			"references": {"Akty wykonawcze": [{"id": "DU/2020/200"}]}}`,
+	}
+	server := newServerWithFixtures(t, fixtures)
+	server.watches = newWatchManager(t.TempDir(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	result, err := server.handleWatchAct(context.Background(), createMockRequest(map[string]interface{}{
+		"publisher": "du", "year": "2020", "position": "100",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v %s", err, extractTextContent(result))
+	}
+	for _, expected := range []string{"Watching DU/2020/100: Ustawa o ochronie danych", "Current status: obowiązujący", "Known references: 1"} {
+		if !strings.Contains(extractTextContent(result), expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, extractTextContent(result))
+		}
+	}
+
+	result, _ = server.handleGetWatchUpdates(context.Background(), createMockRequest(map[string]interface{}{"check_now": "true"}))
+	if content := extractTextContent(result); !strings.Contains(content, "Changes found: 0") {
+		t.Errorf("Expected no changes for an unchanged act, got: %s", content)
+	}
+
+	fixtures["/eli/acts/DU/2020/100"] = `{"publisher": "DU", "year": 2020, "pos": 100, "title": "Ustawa o ochronie danych",
+		"status": "uchylony", "inForce": "NOT_IN_FORCE", "repealDate": "2025-01-01",
+		"references": {
+			"Akty wykonawcze": [{"id": "DU/2020/200"}],
+			"Akty zmieniające": [{"id": "DU/2024/15"}],
+			"Akty uchylające": [{"id": "DU/2024/999"}]
+		}}`
+	result, _ = server.handleGetWatchUpdates(context.Background(), createMockRequest(map[string]interface{}{"check_now": "true"}))
+	content := extractTextContent(result)
+	for _, expected := range []string{
+		"Changes found: 1",
+		"DU/2020/100: Ustawa o ochronie danych",
+		"  - Status: obowiązujący → uchylony",
+		"  - In force: IN_FORCE → NOT_IN_FORCE",
+		"  - Repeal date: none → 2025-01-01",
+		"  - Repealed by DU/2024/999 [Akty uchylające]",
+		"  - Amended by DU/2024/15 [Akty zmieniające]",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected '%s' in output, got: %s", expected, content)
+		}
+	}
+	if strings.Contains(content, "DU/2020/200") {
+		t.Errorf("Known references should not be reported again, got: %s", content)
+	}
+
+	// Watches and detected changes survive a restart
+	reloaded := newWatchManager(server.watches.dir, server.logger)
+	if watches := reloaded.list(); len(watches) != 1 || watches[0].Snapshot.Status != "uchylony" {
+		t.Errorf("Expected the watch to be reloaded with its latest snapshot, got %+v", watches)
+	}
+	if updates := reloaded.updatesSince(server.watches.list()[0].CreatedAt.AddDate(-1, 0, 0)); len(updates) != 1 {
+		t.Errorf("Expected the detected change to be reloaded, got %+v", updates)
+	}
+
+	result, _ = server.handleWatchAct(context.Background(), createMockRequest(map[string]interface{}{
+		"action": "remove", "publisher": "DU", "year": "2020", "position": "100",
+	}))
+	if result.IsError || len(server.watches.list()) != 0 {
+		t.Errorf("Expected the watch to be removed, got: %s", extractTextContent(result))
+	}
+	result, _ = server.handleGetWatchUpdates(context.Background(), createMockRequest(map[string]interface{}{}))
+	if !result.IsError {
+		t.Errorf("Expected an error without watched acts, got: %s", extractTextContent(result))
+	}
+}
+
+func TestDiffActSnapshotsMetadataOnly(t *testing.T) {
+	old := actSnapshot{Status: "obowiązujący", ChangeDate: "2024-01-01 10:00:00"}
+	current := actSnapshot{Status: "obowiązujący", ChangeDate: "2024-03-01 09:30:00"}
+	changes := diffActSnapshots(old, current)
+	if len(changes) != 1 || changes[0] != "Metadata updated on 2024-03-01 09:30:00" {
+		t.Errorf("Expected a metadata update, got %v", changes)
+	}
+	if changes := diffActSnapshots(current, current); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %v", changes)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,11 +14,19 @@ import (
 	"github.com/janisz/sejm-mcp/pkg/sejm"
 )
 
-const (
-	sejmBaseURL = "https://api.sejm.gov.pl"
-	eliBaseURL  = "https://api.sejm.gov.pl/eli"
+// The APIs under test can be pointed at a mirror with the same variables the server reads
+var (
+	sejmBaseURL = envOrDefault("SEJM_API_URL", "https://api.sejm.gov.pl")
+	eliBaseURL  = envOrDefault("ELI_API_URL", "https://api.sejm.gov.pl/eli")
 )
 
+func envOrDefault(name, fallback string) string {
+	if value := strings.TrimRight(os.Getenv(name), "/"); value != "" {
+		return value
+	}
+	return fallback
+}
+
 func TestSejmAPIsIntegration(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")